@@ -0,0 +1,509 @@
+package access
+
+import (
+	"context"
+
+	"github.com/golang/protobuf/proto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/onflow/flow-go/engine/common/rpc/convert"
+	exeapi "github.com/onflow/flow-go/engine/execution"
+	"github.com/onflow/flow-go/model/flow"
+)
+
+// AccessAccountAPI shares its response messages with the account endpoints of the Execution API. The lookups are
+// served at the latest sealed block if the block height of the request is 0.
+
+// GetAccountKeyAtIndexRequest is the request message of AccessAccountAPI.GetAccountKeyAtIndex.
+type GetAccountKeyAtIndexRequest struct {
+	Address     []byte `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	Index       uint64 `protobuf:"varint,2,opt,name=index,proto3" json:"index,omitempty"`
+	BlockHeight uint64 `protobuf:"varint,3,opt,name=block_height,json=blockHeight,proto3" json:"block_height,omitempty"`
+}
+
+func (m *GetAccountKeyAtIndexRequest) Reset()         { *m = GetAccountKeyAtIndexRequest{} }
+func (m *GetAccountKeyAtIndexRequest) String() string { return proto.CompactTextString(m) }
+func (*GetAccountKeyAtIndexRequest) ProtoMessage()    {}
+
+func (m *GetAccountKeyAtIndexRequest) GetAddress() []byte {
+	if m != nil {
+		return m.Address
+	}
+	return nil
+}
+
+func (m *GetAccountKeyAtIndexRequest) GetIndex() uint64 {
+	if m != nil {
+		return m.Index
+	}
+	return 0
+}
+
+func (m *GetAccountKeyAtIndexRequest) GetBlockHeight() uint64 {
+	if m != nil {
+		return m.BlockHeight
+	}
+	return 0
+}
+
+// ListAccountKeysRequest is the request message of AccessAccountAPI.ListAccountKeys.
+type ListAccountKeysRequest struct {
+	Address     []byte `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	Offset      uint64 `protobuf:"varint,2,opt,name=offset,proto3" json:"offset,omitempty"`
+	Limit       uint64 `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+	BlockHeight uint64 `protobuf:"varint,4,opt,name=block_height,json=blockHeight,proto3" json:"block_height,omitempty"`
+}
+
+func (m *ListAccountKeysRequest) Reset()         { *m = ListAccountKeysRequest{} }
+func (m *ListAccountKeysRequest) String() string { return proto.CompactTextString(m) }
+func (*ListAccountKeysRequest) ProtoMessage()    {}
+
+func (m *ListAccountKeysRequest) GetAddress() []byte {
+	if m != nil {
+		return m.Address
+	}
+	return nil
+}
+
+func (m *ListAccountKeysRequest) GetOffset() uint64 {
+	if m != nil {
+		return m.Offset
+	}
+	return 0
+}
+
+func (m *ListAccountKeysRequest) GetLimit() uint64 {
+	if m != nil {
+		return m.Limit
+	}
+	return 0
+}
+
+func (m *ListAccountKeysRequest) GetBlockHeight() uint64 {
+	if m != nil {
+		return m.BlockHeight
+	}
+	return 0
+}
+
+// GetAccountContractNamesRequest is the request message of AccessAccountAPI.GetAccountContractNames.
+type GetAccountContractNamesRequest struct {
+	Address     []byte `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	BlockHeight uint64 `protobuf:"varint,2,opt,name=block_height,json=blockHeight,proto3" json:"block_height,omitempty"`
+}
+
+func (m *GetAccountContractNamesRequest) Reset()         { *m = GetAccountContractNamesRequest{} }
+func (m *GetAccountContractNamesRequest) String() string { return proto.CompactTextString(m) }
+func (*GetAccountContractNamesRequest) ProtoMessage()    {}
+
+func (m *GetAccountContractNamesRequest) GetAddress() []byte {
+	if m != nil {
+		return m.Address
+	}
+	return nil
+}
+
+func (m *GetAccountContractNamesRequest) GetBlockHeight() uint64 {
+	if m != nil {
+		return m.BlockHeight
+	}
+	return 0
+}
+
+// GetAccountContractRequest is the request message of AccessAccountAPI.GetAccountContract.
+type GetAccountContractRequest struct {
+	Address     []byte `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	Name        string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	BlockHeight uint64 `protobuf:"varint,3,opt,name=block_height,json=blockHeight,proto3" json:"block_height,omitempty"`
+}
+
+func (m *GetAccountContractRequest) Reset()         { *m = GetAccountContractRequest{} }
+func (m *GetAccountContractRequest) String() string { return proto.CompactTextString(m) }
+func (*GetAccountContractRequest) ProtoMessage()    {}
+
+func (m *GetAccountContractRequest) GetAddress() []byte {
+	if m != nil {
+		return m.Address
+	}
+	return nil
+}
+
+func (m *GetAccountContractRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *GetAccountContractRequest) GetBlockHeight() uint64 {
+	if m != nil {
+		return m.BlockHeight
+	}
+	return 0
+}
+
+// GetAccountStorageUsedRequest is the request message of AccessAccountAPI.GetAccountStorageUsed.
+type GetAccountStorageUsedRequest struct {
+	Address     []byte `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	BlockHeight uint64 `protobuf:"varint,2,opt,name=block_height,json=blockHeight,proto3" json:"block_height,omitempty"`
+}
+
+func (m *GetAccountStorageUsedRequest) Reset()         { *m = GetAccountStorageUsedRequest{} }
+func (m *GetAccountStorageUsedRequest) String() string { return proto.CompactTextString(m) }
+func (*GetAccountStorageUsedRequest) ProtoMessage()    {}
+
+func (m *GetAccountStorageUsedRequest) GetAddress() []byte {
+	if m != nil {
+		return m.Address
+	}
+	return nil
+}
+
+func (m *GetAccountStorageUsedRequest) GetBlockHeight() uint64 {
+	if m != nil {
+		return m.BlockHeight
+	}
+	return 0
+}
+
+// AccessAccountAPIServer is the server API for the AccessAccountAPI service.
+type AccessAccountAPIServer interface {
+	GetAccountKeyAtIndex(context.Context, *GetAccountKeyAtIndexRequest) (*exeapi.AccountKeyResponse, error)
+	ListAccountKeys(context.Context, *ListAccountKeysRequest) (*exeapi.AccountKeysResponse, error)
+	GetAccountContractNames(context.Context, *GetAccountContractNamesRequest) (*exeapi.AccountContractNamesResponse, error)
+	GetAccountContract(context.Context, *GetAccountContractRequest) (*exeapi.AccountContractResponse, error)
+	GetAccountStorageUsed(context.Context, *GetAccountStorageUsedRequest) (*exeapi.AccountStorageUsedResponse, error)
+}
+
+func getAccountKeyAtIndexHandler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAccountKeyAtIndexRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AccessAccountAPIServer).GetAccountKeyAtIndex(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/flow.access.AccessAccountAPI/GetAccountKeyAtIndex",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccessAccountAPIServer).GetAccountKeyAtIndex(ctx, req.(*GetAccountKeyAtIndexRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func listAccountKeysHandler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListAccountKeysRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AccessAccountAPIServer).ListAccountKeys(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/flow.access.AccessAccountAPI/ListAccountKeys",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccessAccountAPIServer).ListAccountKeys(ctx, req.(*ListAccountKeysRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func getAccountContractNamesHandler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAccountContractNamesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AccessAccountAPIServer).GetAccountContractNames(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/flow.access.AccessAccountAPI/GetAccountContractNames",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccessAccountAPIServer).GetAccountContractNames(ctx, req.(*GetAccountContractNamesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func getAccountContractHandler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAccountContractRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AccessAccountAPIServer).GetAccountContract(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/flow.access.AccessAccountAPI/GetAccountContract",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccessAccountAPIServer).GetAccountContract(ctx, req.(*GetAccountContractRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func getAccountStorageUsedHandler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAccountStorageUsedRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AccessAccountAPIServer).GetAccountStorageUsed(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/flow.access.AccessAccountAPI/GetAccountStorageUsed",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccessAccountAPIServer).GetAccountStorageUsed(ctx, req.(*GetAccountStorageUsedRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var accessAccountAPIServiceDesc = grpc.ServiceDesc{
+	ServiceName: "flow.access.AccessAccountAPI",
+	HandlerType: (*AccessAccountAPIServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetAccountKeyAtIndex",
+			Handler:    getAccountKeyAtIndexHandler,
+		},
+		{
+			MethodName: "ListAccountKeys",
+			Handler:    listAccountKeysHandler,
+		},
+		{
+			MethodName: "GetAccountContractNames",
+			Handler:    getAccountContractNamesHandler,
+		},
+		{
+			MethodName: "GetAccountContract",
+			Handler:    getAccountContractHandler,
+		},
+		{
+			MethodName: "GetAccountStorageUsed",
+			Handler:    getAccountStorageUsedHandler,
+		},
+	},
+	Streams: []grpc.StreamDesc{},
+}
+
+// RegisterAccessAccountAPIServer registers the account endpoints of the Access API with the gRPC server.
+func RegisterAccessAccountAPIServer(s *grpc.Server, srv AccessAccountAPIServer) {
+	s.RegisterService(&accessAccountAPIServiceDesc, srv)
+}
+
+// AccessAccountAPIClient is the client API for the AccessAccountAPI service.
+type AccessAccountAPIClient interface {
+	GetAccountKeyAtIndex(ctx context.Context, in *GetAccountKeyAtIndexRequest, opts ...grpc.CallOption) (*exeapi.AccountKeyResponse, error)
+	ListAccountKeys(ctx context.Context, in *ListAccountKeysRequest, opts ...grpc.CallOption) (*exeapi.AccountKeysResponse, error)
+	GetAccountContractNames(ctx context.Context, in *GetAccountContractNamesRequest, opts ...grpc.CallOption) (*exeapi.AccountContractNamesResponse, error)
+	GetAccountContract(ctx context.Context, in *GetAccountContractRequest, opts ...grpc.CallOption) (*exeapi.AccountContractResponse, error)
+	GetAccountStorageUsed(ctx context.Context, in *GetAccountStorageUsedRequest, opts ...grpc.CallOption) (*exeapi.AccountStorageUsedResponse, error)
+}
+
+type accessAccountAPIClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewAccessAccountAPIClient(cc grpc.ClientConnInterface) AccessAccountAPIClient {
+	return &accessAccountAPIClient{cc}
+}
+
+func (c *accessAccountAPIClient) GetAccountKeyAtIndex(ctx context.Context, in *GetAccountKeyAtIndexRequest, opts ...grpc.CallOption) (*exeapi.AccountKeyResponse, error) {
+	out := new(exeapi.AccountKeyResponse)
+	err := c.cc.Invoke(ctx, "/flow.access.AccessAccountAPI/GetAccountKeyAtIndex", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *accessAccountAPIClient) ListAccountKeys(ctx context.Context, in *ListAccountKeysRequest, opts ...grpc.CallOption) (*exeapi.AccountKeysResponse, error) {
+	out := new(exeapi.AccountKeysResponse)
+	err := c.cc.Invoke(ctx, "/flow.access.AccessAccountAPI/ListAccountKeys", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *accessAccountAPIClient) GetAccountContractNames(ctx context.Context, in *GetAccountContractNamesRequest, opts ...grpc.CallOption) (*exeapi.AccountContractNamesResponse, error) {
+	out := new(exeapi.AccountContractNamesResponse)
+	err := c.cc.Invoke(ctx, "/flow.access.AccessAccountAPI/GetAccountContractNames", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *accessAccountAPIClient) GetAccountContract(ctx context.Context, in *GetAccountContractRequest, opts ...grpc.CallOption) (*exeapi.AccountContractResponse, error) {
+	out := new(exeapi.AccountContractResponse)
+	err := c.cc.Invoke(ctx, "/flow.access.AccessAccountAPI/GetAccountContract", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *accessAccountAPIClient) GetAccountStorageUsed(ctx context.Context, in *GetAccountStorageUsedRequest, opts ...grpc.CallOption) (*exeapi.AccountStorageUsedResponse, error) {
+	out := new(exeapi.AccountStorageUsedResponse)
+	err := c.cc.Invoke(ctx, "/flow.access.AccessAccountAPI/GetAccountStorageUsed", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// AccountHandler implements the AccessAccountAPI gRPC service on top of an API.
+type AccountHandler struct {
+	api   API
+	chain flow.Chain
+}
+
+func NewAccountHandler(api API, chain flow.Chain) *AccountHandler {
+	return &AccountHandler{
+		api:   api,
+		chain: chain,
+	}
+}
+
+// GetAccountKeyAtIndex returns the key with the given index of an account.
+func (h *AccountHandler) GetAccountKeyAtIndex(
+	ctx context.Context,
+	req *GetAccountKeyAtIndexRequest,
+) (*exeapi.AccountKeyResponse, error) {
+	address, height, err := h.accountRequest(ctx, req.GetAddress(), req.GetBlockHeight())
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := h.api.GetAccountKeyAtIndex(ctx, address, req.GetIndex(), height)
+	if err != nil {
+		return nil, err
+	}
+
+	message, err := convert.AccountKeyToMessage(*key)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &exeapi.AccountKeyResponse{
+		AccountKey: message,
+	}, nil
+}
+
+// ListAccountKeys returns a page of the keys of an account, and the total number of keys of the account.
+func (h *AccountHandler) ListAccountKeys(
+	ctx context.Context,
+	req *ListAccountKeysRequest,
+) (*exeapi.AccountKeysResponse, error) {
+	address, height, err := h.accountRequest(ctx, req.GetAddress(), req.GetBlockHeight())
+	if err != nil {
+		return nil, err
+	}
+
+	keys, total, err := h.api.ListAccountKeys(ctx, address, req.GetOffset(), req.GetLimit(), height)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &exeapi.AccountKeysResponse{
+		TotalCount: total,
+	}
+	for _, key := range keys {
+		message, err := convert.AccountKeyToMessage(key)
+		if err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+		resp.AccountKeys = append(resp.AccountKeys, message)
+	}
+
+	return resp, nil
+}
+
+// GetAccountContractNames returns the names of the contracts deployed to an account.
+func (h *AccountHandler) GetAccountContractNames(
+	ctx context.Context,
+	req *GetAccountContractNamesRequest,
+) (*exeapi.AccountContractNamesResponse, error) {
+	address, height, err := h.accountRequest(ctx, req.GetAddress(), req.GetBlockHeight())
+	if err != nil {
+		return nil, err
+	}
+
+	names, err := h.api.GetAccountContractNames(ctx, address, height)
+	if err != nil {
+		return nil, err
+	}
+
+	return &exeapi.AccountContractNamesResponse{
+		Names: names,
+	}, nil
+}
+
+// GetAccountContract returns the code of a contract deployed to an account.
+func (h *AccountHandler) GetAccountContract(
+	ctx context.Context,
+	req *GetAccountContractRequest,
+) (*exeapi.AccountContractResponse, error) {
+	address, height, err := h.accountRequest(ctx, req.GetAddress(), req.GetBlockHeight())
+	if err != nil {
+		return nil, err
+	}
+
+	code, err := h.api.GetAccountContract(ctx, address, req.GetName(), height)
+	if err != nil {
+		return nil, err
+	}
+
+	return &exeapi.AccountContractResponse{
+		Code: code,
+	}, nil
+}
+
+// GetAccountStorageUsed returns the storage used by an account, in bytes.
+func (h *AccountHandler) GetAccountStorageUsed(
+	ctx context.Context,
+	req *GetAccountStorageUsedRequest,
+) (*exeapi.AccountStorageUsedResponse, error) {
+	address, height, err := h.accountRequest(ctx, req.GetAddress(), req.GetBlockHeight())
+	if err != nil {
+		return nil, err
+	}
+
+	storageUsed, err := h.api.GetAccountStorageUsed(ctx, address, height)
+	if err != nil {
+		return nil, err
+	}
+
+	return &exeapi.AccountStorageUsedResponse{
+		StorageUsed: storageUsed,
+	}, nil
+}
+
+// accountRequest converts the address of an account request, and resolves the block height of the request to
+// the height of the latest sealed block if it is 0.
+func (h *AccountHandler) accountRequest(ctx context.Context, rawAddress []byte, height uint64) (flow.Address, uint64, error) {
+	address, err := convert.Address(rawAddress, h.chain)
+	if err != nil {
+		return flow.EmptyAddress, 0, err
+	}
+
+	if height == 0 {
+		header, err := h.api.GetLatestBlockHeader(ctx, true)
+		if err != nil {
+			return flow.EmptyAddress, 0, err
+		}
+		height = header.Height
+	}
+
+	return address, height, nil
+}
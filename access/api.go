@@ -28,14 +28,22 @@ type API interface {
 	SendTransaction(ctx context.Context, tx *flow.TransactionBody) error
 	GetTransaction(ctx context.Context, id flow.Identifier) (*flow.TransactionBody, error)
 	GetTransactionResult(ctx context.Context, id flow.Identifier) (*TransactionResult, error)
+	DryRunTransaction(ctx context.Context, tx *flow.TransactionBody, skipSignatureCheck bool) (*DryRunResult, error)
 
 	GetAccount(ctx context.Context, address flow.Address) (*flow.Account, error)
 	GetAccountAtLatestBlock(ctx context.Context, address flow.Address) (*flow.Account, error)
 	GetAccountAtBlockHeight(ctx context.Context, address flow.Address, height uint64) (*flow.Account, error)
+	GetAccountsAtBlockHeight(ctx context.Context, addresses []flow.Address, height uint64) ([]AccountResult, error)
+	GetAccountKeyAtIndex(ctx context.Context, address flow.Address, keyIndex uint64, height uint64) (*flow.AccountPublicKey, error)
+	ListAccountKeys(ctx context.Context, address flow.Address, offset uint64, limit uint64, height uint64) ([]flow.AccountPublicKey, uint64, error)
+	GetAccountContractNames(ctx context.Context, address flow.Address, height uint64) ([]string, error)
+	GetAccountContract(ctx context.Context, address flow.Address, name string, height uint64) ([]byte, error)
+	GetAccountStorageUsed(ctx context.Context, address flow.Address, height uint64) (uint64, error)
 
 	ExecuteScriptAtLatestBlock(ctx context.Context, script []byte, arguments [][]byte) ([]byte, error)
 	ExecuteScriptAtBlockHeight(ctx context.Context, blockHeight uint64, script []byte, arguments [][]byte) ([]byte, error)
 	ExecuteScriptAtBlockID(ctx context.Context, blockID flow.Identifier, script []byte, arguments [][]byte) ([]byte, error)
+	ExecuteScriptsAtBlockID(ctx context.Context, blockID flow.Identifier, scripts []Script) ([]ScriptResult, error)
 
 	GetEventsForHeightRange(ctx context.Context, eventType string, startHeight, endHeight uint64) ([]flow.BlockEvents, error)
 	GetEventsForBlockIDs(ctx context.Context, eventType string, blockIDs []flow.Identifier) ([]flow.BlockEvents, error)
@@ -43,6 +51,19 @@ type API interface {
 	GetLatestProtocolStateSnapshot(ctx context.Context) ([]byte, error)
 }
 
+// HistoricalBlocks is implemented by the APIs serving the blocks of previous sporks from the access nodes of those
+// sporks. These access nodes return only some of the fields of their blocks, which the block IDs can not be
+// recomputed from, so the blocks of previous sporks are returned along with the IDs they were created with.
+type HistoricalBlocks interface {
+	// GetHistoricalBlockHeaderByHeight returns the block header at the given height of a previous spork and its ID,
+	// or false if the height is not of a previous spork.
+	GetHistoricalBlockHeaderByHeight(ctx context.Context, height uint64) (*flow.Header, flow.Identifier, bool, error)
+
+	// GetHistoricalBlockByHeight returns the block at the given height of a previous spork and its ID, or false if
+	// the height is not of a previous spork.
+	GetHistoricalBlockByHeight(ctx context.Context, height uint64) (*flow.Block, flow.Identifier, bool, error)
+}
+
 // TODO: Combine this with flow.TransactionResult?
 type TransactionResult struct {
 	Status       flow.TransactionStatus
@@ -73,6 +94,44 @@ func MessageToTransactionResult(message *access.TransactionResultResponse) *Tran
 	}
 }
 
+// DryRunResult is the outcome of executing a transaction against the latest sealed state without committing its
+// changes. The error code and message are set if the transaction would fail.
+type DryRunResult struct {
+	BlockID         flow.Identifier // the sealed block the transaction was executed at
+	ComputationUsed uint64
+	Events          []flow.Event
+	StorageDeltas   []StorageDelta // sorted by address
+	FeeEstimate     uint64         // the fee deducted from the payer, in the smallest unit of FLOW
+	ErrorCode       uint32
+	ErrorMessage    string
+}
+
+// StorageDelta is the change of the storage used by an account, in bytes.
+type StorageDelta struct {
+	Address flow.Address
+	Delta   int64
+}
+
+// Script is a script and its arguments, executed as part of a batch of scripts.
+type Script struct {
+	Code      []byte
+	Arguments [][]byte
+}
+
+// AccountResult is the result of a single account lookup of a batch. The error message is set if the
+// lookup failed, the account is set otherwise.
+type AccountResult struct {
+	Account      *flow.Account
+	ErrorMessage string
+}
+
+// ScriptResult is the result of a single script of a batch. The error message is set if the script
+// failed, the value is set otherwise.
+type ScriptResult struct {
+	Value        []byte
+	ErrorMessage string
+}
+
 // NetworkParameters contains the network-wide parameters for the Flow blockchain.
 type NetworkParameters struct {
 	ChainID flow.ChainID
@@ -0,0 +1,222 @@
+package access
+
+import (
+	"context"
+
+	"github.com/golang/protobuf/proto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/onflow/flow-go/engine/common/rpc/convert"
+	exeapi "github.com/onflow/flow-go/engine/execution"
+	"github.com/onflow/flow-go/model/flow"
+)
+
+// AccessBatchAPI shares its messages with the batch endpoints of the Execution API, except for the request message
+// that has no counterpart there.
+
+// GetAccountsAtBlockHeightRequest is the request message of AccessBatchAPI.GetAccountsAtBlockHeight.
+type GetAccountsAtBlockHeightRequest struct {
+	BlockHeight uint64   `protobuf:"varint,1,opt,name=block_height,json=blockHeight,proto3" json:"block_height,omitempty"`
+	Addresses   [][]byte `protobuf:"bytes,2,rep,name=addresses,proto3" json:"addresses,omitempty"`
+}
+
+func (m *GetAccountsAtBlockHeightRequest) Reset()         { *m = GetAccountsAtBlockHeightRequest{} }
+func (m *GetAccountsAtBlockHeightRequest) String() string { return proto.CompactTextString(m) }
+func (*GetAccountsAtBlockHeightRequest) ProtoMessage()    {}
+
+func (m *GetAccountsAtBlockHeightRequest) GetBlockHeight() uint64 {
+	if m != nil {
+		return m.BlockHeight
+	}
+	return 0
+}
+
+func (m *GetAccountsAtBlockHeightRequest) GetAddresses() [][]byte {
+	if m != nil {
+		return m.Addresses
+	}
+	return nil
+}
+
+// AccessBatchAPIServer is the server API for the AccessBatchAPI service.
+type AccessBatchAPIServer interface {
+	GetAccountsAtBlockHeight(context.Context, *GetAccountsAtBlockHeightRequest) (*exeapi.AccountsResponse, error)
+	ExecuteScriptsAtBlockID(context.Context, *exeapi.ExecuteScriptsAtBlockIDRequest) (*exeapi.ScriptsResponse, error)
+}
+
+func getAccountsAtBlockHeightHandler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAccountsAtBlockHeightRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AccessBatchAPIServer).GetAccountsAtBlockHeight(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/flow.access.AccessBatchAPI/GetAccountsAtBlockHeight",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccessBatchAPIServer).GetAccountsAtBlockHeight(ctx, req.(*GetAccountsAtBlockHeightRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func executeScriptsAtBlockIDHandler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(exeapi.ExecuteScriptsAtBlockIDRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AccessBatchAPIServer).ExecuteScriptsAtBlockID(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/flow.access.AccessBatchAPI/ExecuteScriptsAtBlockID",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccessBatchAPIServer).ExecuteScriptsAtBlockID(ctx, req.(*exeapi.ExecuteScriptsAtBlockIDRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var accessBatchAPIServiceDesc = grpc.ServiceDesc{
+	ServiceName: "flow.access.AccessBatchAPI",
+	HandlerType: (*AccessBatchAPIServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetAccountsAtBlockHeight",
+			Handler:    getAccountsAtBlockHeightHandler,
+		},
+		{
+			MethodName: "ExecuteScriptsAtBlockID",
+			Handler:    executeScriptsAtBlockIDHandler,
+		},
+	},
+	Streams: []grpc.StreamDesc{},
+}
+
+// RegisterAccessBatchAPIServer registers the batch endpoints of the Access API with the gRPC server.
+func RegisterAccessBatchAPIServer(s *grpc.Server, srv AccessBatchAPIServer) {
+	s.RegisterService(&accessBatchAPIServiceDesc, srv)
+}
+
+// AccessBatchAPIClient is the client API for the AccessBatchAPI service.
+type AccessBatchAPIClient interface {
+	GetAccountsAtBlockHeight(ctx context.Context, in *GetAccountsAtBlockHeightRequest, opts ...grpc.CallOption) (*exeapi.AccountsResponse, error)
+	ExecuteScriptsAtBlockID(ctx context.Context, in *exeapi.ExecuteScriptsAtBlockIDRequest, opts ...grpc.CallOption) (*exeapi.ScriptsResponse, error)
+}
+
+type accessBatchAPIClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewAccessBatchAPIClient(cc grpc.ClientConnInterface) AccessBatchAPIClient {
+	return &accessBatchAPIClient{cc}
+}
+
+func (c *accessBatchAPIClient) GetAccountsAtBlockHeight(ctx context.Context, in *GetAccountsAtBlockHeightRequest, opts ...grpc.CallOption) (*exeapi.AccountsResponse, error) {
+	out := new(exeapi.AccountsResponse)
+	err := c.cc.Invoke(ctx, "/flow.access.AccessBatchAPI/GetAccountsAtBlockHeight", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *accessBatchAPIClient) ExecuteScriptsAtBlockID(ctx context.Context, in *exeapi.ExecuteScriptsAtBlockIDRequest, opts ...grpc.CallOption) (*exeapi.ScriptsResponse, error) {
+	out := new(exeapi.ScriptsResponse)
+	err := c.cc.Invoke(ctx, "/flow.access.AccessBatchAPI/ExecuteScriptsAtBlockID", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// BatchHandler implements the AccessBatchAPI gRPC service on top of an API.
+type BatchHandler struct {
+	api   API
+	chain flow.Chain
+}
+
+func NewBatchHandler(api API, chain flow.Chain) *BatchHandler {
+	return &BatchHandler{
+		api:   api,
+		chain: chain,
+	}
+}
+
+// GetAccountsAtBlockHeight returns the accounts with the given addresses at the given block height, with
+// an error message instead of the account for each lookup that failed.
+func (h *BatchHandler) GetAccountsAtBlockHeight(
+	ctx context.Context,
+	req *GetAccountsAtBlockHeightRequest,
+) (*exeapi.AccountsResponse, error) {
+	addresses := make([]flow.Address, len(req.GetAddresses()))
+	for i, rawAddress := range req.GetAddresses() {
+		address, err := convert.Address(rawAddress, h.chain)
+		if err != nil {
+			return nil, err
+		}
+		addresses[i] = address
+	}
+
+	results, err := h.api.GetAccountsAtBlockHeight(ctx, addresses, req.GetBlockHeight())
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &exeapi.AccountsResponse{
+		Results: make([]*exeapi.AccountResult, len(results)),
+	}
+	for i, result := range results {
+		if result.ErrorMessage != "" {
+			resp.Results[i] = &exeapi.AccountResult{ErrorMessage: result.ErrorMessage}
+			continue
+		}
+
+		account, err := convert.AccountToMessage(result.Account)
+		if err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+		resp.Results[i] = &exeapi.AccountResult{Account: account}
+	}
+
+	return resp, nil
+}
+
+// ExecuteScriptsAtBlockID executes the given scripts at the given block, with an error message instead of
+// the value for each script that failed.
+func (h *BatchHandler) ExecuteScriptsAtBlockID(
+	ctx context.Context,
+	req *exeapi.ExecuteScriptsAtBlockIDRequest,
+) (*exeapi.ScriptsResponse, error) {
+	blockID := convert.MessageToIdentifier(req.GetBlockId())
+
+	scripts := make([]Script, len(req.GetScripts()))
+	for i, script := range req.GetScripts() {
+		scripts[i] = Script{
+			Code:      script.GetScript(),
+			Arguments: script.GetArguments(),
+		}
+	}
+
+	results, err := h.api.ExecuteScriptsAtBlockID(ctx, blockID, scripts)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &exeapi.ScriptsResponse{
+		Results: make([]*exeapi.ScriptResult, len(results)),
+	}
+	for i, result := range results {
+		resp.Results[i] = &exeapi.ScriptResult{
+			Value:        result.Value,
+			ErrorMessage: result.ErrorMessage,
+		}
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,220 @@
+package access
+
+import (
+	"context"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/onflow/flow/protobuf/go/flow/entities"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/onflow/flow-go/engine/common/rpc/convert"
+	exeapi "github.com/onflow/flow-go/engine/execution"
+	"github.com/onflow/flow-go/model/flow"
+)
+
+// AccessDryRunAPI shares its storage deltas with the dry run endpoint of the Execution API.
+
+// DryRunTransactionRequest is the request message of AccessDryRunAPI.DryRunTransaction. The signatures of
+// the transaction are not verified if skip_signature_check is set.
+type DryRunTransactionRequest struct {
+	Transaction        *entities.Transaction `protobuf:"bytes,1,opt,name=transaction,proto3" json:"transaction,omitempty"`
+	SkipSignatureCheck bool                  `protobuf:"varint,2,opt,name=skip_signature_check,json=skipSignatureCheck,proto3" json:"skip_signature_check,omitempty"`
+}
+
+func (m *DryRunTransactionRequest) Reset()         { *m = DryRunTransactionRequest{} }
+func (m *DryRunTransactionRequest) String() string { return proto.CompactTextString(m) }
+func (*DryRunTransactionRequest) ProtoMessage()    {}
+
+func (m *DryRunTransactionRequest) GetTransaction() *entities.Transaction {
+	if m != nil {
+		return m.Transaction
+	}
+	return nil
+}
+
+func (m *DryRunTransactionRequest) GetSkipSignatureCheck() bool {
+	if m != nil {
+		return m.SkipSignatureCheck
+	}
+	return false
+}
+
+// DryRunTransactionResponse holds the outcome of a dry run at the latest sealed block. The error code and
+// message are set if the transaction would fail, the fee estimate is in the smallest unit of FLOW.
+type DryRunTransactionResponse struct {
+	BlockId         []byte                        `protobuf:"bytes,1,opt,name=block_id,json=blockId,proto3" json:"block_id,omitempty"`
+	ComputationUsed uint64                        `protobuf:"varint,2,opt,name=computation_used,json=computationUsed,proto3" json:"computation_used,omitempty"`
+	Events          []*entities.Event             `protobuf:"bytes,3,rep,name=events,proto3" json:"events,omitempty"`
+	StorageDeltas   []*exeapi.AccountStorageDelta `protobuf:"bytes,4,rep,name=storage_deltas,json=storageDeltas,proto3" json:"storage_deltas,omitempty"`
+	FeeEstimate     uint64                        `protobuf:"varint,5,opt,name=fee_estimate,json=feeEstimate,proto3" json:"fee_estimate,omitempty"`
+	ErrorCode       uint32                        `protobuf:"varint,6,opt,name=error_code,json=errorCode,proto3" json:"error_code,omitempty"`
+	ErrorMessage    string                        `protobuf:"bytes,7,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
+}
+
+func (m *DryRunTransactionResponse) Reset()         { *m = DryRunTransactionResponse{} }
+func (m *DryRunTransactionResponse) String() string { return proto.CompactTextString(m) }
+func (*DryRunTransactionResponse) ProtoMessage()    {}
+
+func (m *DryRunTransactionResponse) GetBlockId() []byte {
+	if m != nil {
+		return m.BlockId
+	}
+	return nil
+}
+
+func (m *DryRunTransactionResponse) GetComputationUsed() uint64 {
+	if m != nil {
+		return m.ComputationUsed
+	}
+	return 0
+}
+
+func (m *DryRunTransactionResponse) GetEvents() []*entities.Event {
+	if m != nil {
+		return m.Events
+	}
+	return nil
+}
+
+func (m *DryRunTransactionResponse) GetStorageDeltas() []*exeapi.AccountStorageDelta {
+	if m != nil {
+		return m.StorageDeltas
+	}
+	return nil
+}
+
+func (m *DryRunTransactionResponse) GetFeeEstimate() uint64 {
+	if m != nil {
+		return m.FeeEstimate
+	}
+	return 0
+}
+
+func (m *DryRunTransactionResponse) GetErrorCode() uint32 {
+	if m != nil {
+		return m.ErrorCode
+	}
+	return 0
+}
+
+func (m *DryRunTransactionResponse) GetErrorMessage() string {
+	if m != nil {
+		return m.ErrorMessage
+	}
+	return ""
+}
+
+// AccessDryRunAPIServer is the server API for the AccessDryRunAPI service.
+type AccessDryRunAPIServer interface {
+	DryRunTransaction(context.Context, *DryRunTransactionRequest) (*DryRunTransactionResponse, error)
+}
+
+func dryRunTransactionHandler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DryRunTransactionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AccessDryRunAPIServer).DryRunTransaction(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/flow.access.AccessDryRunAPI/DryRunTransaction",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccessDryRunAPIServer).DryRunTransaction(ctx, req.(*DryRunTransactionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var accessDryRunAPIServiceDesc = grpc.ServiceDesc{
+	ServiceName: "flow.access.AccessDryRunAPI",
+	HandlerType: (*AccessDryRunAPIServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "DryRunTransaction",
+			Handler:    dryRunTransactionHandler,
+		},
+	},
+	Streams: []grpc.StreamDesc{},
+}
+
+// RegisterAccessDryRunAPIServer registers the dry run endpoint of the Access API with the gRPC server.
+func RegisterAccessDryRunAPIServer(s *grpc.Server, srv AccessDryRunAPIServer) {
+	s.RegisterService(&accessDryRunAPIServiceDesc, srv)
+}
+
+// AccessDryRunAPIClient is the client API for the AccessDryRunAPI service.
+type AccessDryRunAPIClient interface {
+	DryRunTransaction(ctx context.Context, in *DryRunTransactionRequest, opts ...grpc.CallOption) (*DryRunTransactionResponse, error)
+}
+
+type accessDryRunAPIClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewAccessDryRunAPIClient(cc grpc.ClientConnInterface) AccessDryRunAPIClient {
+	return &accessDryRunAPIClient{cc}
+}
+
+func (c *accessDryRunAPIClient) DryRunTransaction(ctx context.Context, in *DryRunTransactionRequest, opts ...grpc.CallOption) (*DryRunTransactionResponse, error) {
+	out := new(DryRunTransactionResponse)
+	err := c.cc.Invoke(ctx, "/flow.access.AccessDryRunAPI/DryRunTransaction", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// DryRunHandler implements the AccessDryRunAPI gRPC service on top of an API.
+type DryRunHandler struct {
+	api   API
+	chain flow.Chain
+}
+
+func NewDryRunHandler(api API, chain flow.Chain) *DryRunHandler {
+	return &DryRunHandler{
+		api:   api,
+		chain: chain,
+	}
+}
+
+// DryRunTransaction executes the given transaction against the latest sealed state without committing it.
+func (h *DryRunHandler) DryRunTransaction(
+	ctx context.Context,
+	req *DryRunTransactionRequest,
+) (*DryRunTransactionResponse, error) {
+	if req.GetTransaction() == nil {
+		return nil, status.Error(codes.InvalidArgument, "transaction is required")
+	}
+
+	tx, err := convert.MessageToTransaction(req.GetTransaction(), h.chain)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	result, err := h.api.DryRunTransaction(ctx, &tx, req.GetSkipSignatureCheck())
+	if err != nil {
+		return nil, err
+	}
+
+	deltas := make([]*exeapi.AccountStorageDelta, len(result.StorageDeltas))
+	for i, delta := range result.StorageDeltas {
+		deltas[i] = &exeapi.AccountStorageDelta{
+			Address: delta.Address.Bytes(),
+			Delta:   delta.Delta,
+		}
+	}
+
+	return &DryRunTransactionResponse{
+		BlockId:         result.BlockID[:],
+		ComputationUsed: result.ComputationUsed,
+		Events:          convert.EventsToMessages(result.Events),
+		StorageDeltas:   deltas,
+		FeeEstimate:     result.FeeEstimate,
+		ErrorCode:       result.ErrorCode,
+		ErrorMessage:    result.ErrorMessage,
+	}, nil
+}
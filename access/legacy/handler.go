@@ -79,6 +79,22 @@ func (h *Handler) GetBlockHeaderByHeight(
 	ctx context.Context,
 	req *accessproto.GetBlockHeaderByHeightRequest,
 ) (*accessproto.BlockHeaderResponse, error) {
+	// the blocks of previous sporks are served with the IDs returned by the access nodes of those sporks
+	if historical, ok := h.api.(access.HistoricalBlocks); ok {
+		header, id, found, err := historical.GetHistoricalBlockHeaderByHeight(ctx, req.GetHeight())
+		if err != nil {
+			return nil, err
+		}
+		if found {
+			resp, err := blockHeaderResponse(header)
+			if err != nil {
+				return nil, err
+			}
+			resp.Block.Id = id[:]
+			return resp, nil
+		}
+	}
+
 	header, err := h.api.GetBlockHeaderByHeight(ctx, req.GetHeight())
 	if err != nil {
 		return nil, err
@@ -120,6 +136,22 @@ func (h *Handler) GetBlockByHeight(
 	ctx context.Context,
 	req *accessproto.GetBlockByHeightRequest,
 ) (*accessproto.BlockResponse, error) {
+	// the blocks of previous sporks are served with the IDs returned by the access nodes of those sporks
+	if historical, ok := h.api.(access.HistoricalBlocks); ok {
+		block, id, found, err := historical.GetHistoricalBlockByHeight(ctx, req.GetHeight())
+		if err != nil {
+			return nil, err
+		}
+		if found {
+			resp, err := blockResponse(block)
+			if err != nil {
+				return nil, err
+			}
+			resp.Block.Id = id[:]
+			return resp, nil
+		}
+	}
+
 	block, err := h.api.GetBlockByHeight(ctx, req.GetHeight())
 	if err != nil {
 		return nil, err
@@ -0,0 +1,75 @@
+package access
+
+import (
+	"context"
+	"strings"
+
+	"github.com/onflow/flow-go/model/flow"
+)
+
+// StreamAPI provides the server-streaming functionality of the Flow Access API.
+//
+// Each subscription delivers data to the given handler one block height at a time, in height order,
+// and only moves on to the next height once the handler has returned. A slow consumer therefore
+// slows down its own subscription instead of buffering data on the node. A client that lost its
+// subscription can resume it by subscribing again from the height after the last one it received.
+type StreamAPI interface {
+	// SubscribeBlockHeaders streams the headers of finalized blocks starting at startHeight. If
+	// startHeight is 0, the stream starts at the latest finalized block. It blocks until the
+	// context is cancelled or the handler returns an error.
+	SubscribeBlockHeaders(ctx context.Context, startHeight uint64, handler func(*flow.Header) error) error
+
+	// SubscribeEvents streams the events matching the filter for every sealed block starting at
+	// startHeight. Blocks without matching events are delivered with an empty event list, so the
+	// client always knows the last height that was processed. If startHeight is 0, the stream
+	// starts at the latest sealed block. It blocks until the context is cancelled or the handler
+	// returns an error.
+	SubscribeEvents(ctx context.Context, startHeight uint64, filter EventFilter, handler func(flow.BlockEvents) error) error
+
+	// SubscribeTransactionStatus streams the result of the given transaction every time its status
+	// changes, starting with its current status. The stream ends once the transaction is sealed or
+	// expired, or when the context is cancelled or the handler returns an error.
+	SubscribeTransactionStatus(ctx context.Context, txID flow.Identifier, handler func(*TransactionResult) error) error
+}
+
+// EventFilter selects the events delivered by an event subscription.
+type EventFilter struct {
+	// EventTypes are the event types to subscribe to, at least one is required.
+	EventTypes []flow.EventType
+	// Addresses optionally restricts the events to the ones emitted by contracts deployed at
+	// any of the given addresses.
+	Addresses []flow.Address
+}
+
+// Match returns true if the given event passes the address filter. The event type filter
+// is applied when the events are retrieved.
+func (f EventFilter) Match(event flow.Event) bool {
+	if len(f.Addresses) == 0 {
+		return true
+	}
+
+	address, ok := EventContractAddress(event.Type)
+	if !ok {
+		return false
+	}
+
+	for _, a := range f.Addresses {
+		if a == address {
+			return true
+		}
+	}
+
+	return false
+}
+
+// EventContractAddress returns the address of the contract that defines the given event type.
+// Contract event types have the format A.<address>.<contract>.<event>, all other event types
+// (e.g. flow.AccountCreated) are not defined by a contract and false is returned.
+func EventContractAddress(eventType flow.EventType) (flow.Address, bool) {
+	parts := strings.Split(string(eventType), ".")
+	if len(parts) != 4 || parts[0] != "A" {
+		return flow.EmptyAddress, false
+	}
+
+	return flow.HexToAddress(parts[1]), true
+}
@@ -0,0 +1,336 @@
+package access
+
+import (
+	"context"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/onflow/flow/protobuf/go/flow/access"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/onflow/flow-go/engine/common/rpc/convert"
+	"github.com/onflow/flow-go/model/flow"
+)
+
+// AccessStreamAPI responses reuse the Access API messages, so clients can decode them with the published types.
+
+// SubscribeEventsRequest is the request message of AccessStreamAPI.SubscribeEvents.
+type SubscribeEventsRequest struct {
+	StartHeight uint64   `protobuf:"varint,1,opt,name=start_height,json=startHeight,proto3" json:"start_height,omitempty"`
+	EventTypes  []string `protobuf:"bytes,2,rep,name=event_types,json=eventTypes,proto3" json:"event_types,omitempty"`
+	Addresses   [][]byte `protobuf:"bytes,3,rep,name=addresses,proto3" json:"addresses,omitempty"`
+}
+
+func (m *SubscribeEventsRequest) Reset()         { *m = SubscribeEventsRequest{} }
+func (m *SubscribeEventsRequest) String() string { return proto.CompactTextString(m) }
+func (*SubscribeEventsRequest) ProtoMessage()    {}
+
+func (m *SubscribeEventsRequest) GetStartHeight() uint64 {
+	if m != nil {
+		return m.StartHeight
+	}
+	return 0
+}
+
+func (m *SubscribeEventsRequest) GetEventTypes() []string {
+	if m != nil {
+		return m.EventTypes
+	}
+	return nil
+}
+
+func (m *SubscribeEventsRequest) GetAddresses() [][]byte {
+	if m != nil {
+		return m.Addresses
+	}
+	return nil
+}
+
+// AccessStreamAPIServer is the server API for the AccessStreamAPI service.
+type AccessStreamAPIServer interface {
+	SubscribeBlockHeaders(*access.GetBlockHeaderByHeightRequest, SubscribeBlockHeadersServer) error
+	SubscribeEvents(*SubscribeEventsRequest, SubscribeEventsServer) error
+	SubscribeTransactionStatus(*access.GetTransactionRequest, SubscribeTransactionStatusServer) error
+}
+
+type SubscribeBlockHeadersServer interface {
+	Send(*access.BlockHeaderResponse) error
+	grpc.ServerStream
+}
+
+type accessStreamAPISubscribeBlockHeadersServer struct {
+	grpc.ServerStream
+}
+
+func (x *accessStreamAPISubscribeBlockHeadersServer) Send(m *access.BlockHeaderResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+type SubscribeEventsServer interface {
+	Send(*access.EventsResponse_Result) error
+	grpc.ServerStream
+}
+
+type accessStreamAPISubscribeEventsServer struct {
+	grpc.ServerStream
+}
+
+func (x *accessStreamAPISubscribeEventsServer) Send(m *access.EventsResponse_Result) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+type SubscribeTransactionStatusServer interface {
+	Send(*access.TransactionResultResponse) error
+	grpc.ServerStream
+}
+
+type accessStreamAPISubscribeTransactionStatusServer struct {
+	grpc.ServerStream
+}
+
+func (x *accessStreamAPISubscribeTransactionStatusServer) Send(m *access.TransactionResultResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func subscribeBlockHeadersHandler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(access.GetBlockHeaderByHeightRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(AccessStreamAPIServer).SubscribeBlockHeaders(m, &accessStreamAPISubscribeBlockHeadersServer{stream})
+}
+
+func subscribeEventsHandler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SubscribeEventsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(AccessStreamAPIServer).SubscribeEvents(m, &accessStreamAPISubscribeEventsServer{stream})
+}
+
+func subscribeTransactionStatusHandler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(access.GetTransactionRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(AccessStreamAPIServer).SubscribeTransactionStatus(m, &accessStreamAPISubscribeTransactionStatusServer{stream})
+}
+
+var accessStreamAPIServiceDesc = grpc.ServiceDesc{
+	ServiceName: "flow.access.AccessStreamAPI",
+	HandlerType: (*AccessStreamAPIServer)(nil),
+	Methods:     []grpc.MethodDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "SubscribeBlockHeaders",
+			Handler:       subscribeBlockHeadersHandler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "SubscribeEvents",
+			Handler:       subscribeEventsHandler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "SubscribeTransactionStatus",
+			Handler:       subscribeTransactionStatusHandler,
+			ServerStreams: true,
+		},
+	},
+}
+
+// RegisterAccessStreamAPIServer registers the streaming endpoints of the Access API with the gRPC server.
+func RegisterAccessStreamAPIServer(s *grpc.Server, srv AccessStreamAPIServer) {
+	s.RegisterService(&accessStreamAPIServiceDesc, srv)
+}
+
+// AccessStreamAPIClient is the client API for the AccessStreamAPI service.
+type AccessStreamAPIClient interface {
+	SubscribeBlockHeaders(ctx context.Context, in *access.GetBlockHeaderByHeightRequest, opts ...grpc.CallOption) (SubscribeBlockHeadersClient, error)
+	SubscribeEvents(ctx context.Context, in *SubscribeEventsRequest, opts ...grpc.CallOption) (SubscribeEventsClient, error)
+	SubscribeTransactionStatus(ctx context.Context, in *access.GetTransactionRequest, opts ...grpc.CallOption) (SubscribeTransactionStatusClient, error)
+}
+
+type accessStreamAPIClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewAccessStreamAPIClient(cc grpc.ClientConnInterface) AccessStreamAPIClient {
+	return &accessStreamAPIClient{cc}
+}
+
+type SubscribeBlockHeadersClient interface {
+	Recv() (*access.BlockHeaderResponse, error)
+	grpc.ClientStream
+}
+
+type accessStreamAPISubscribeBlockHeadersClient struct {
+	grpc.ClientStream
+}
+
+func (x *accessStreamAPISubscribeBlockHeadersClient) Recv() (*access.BlockHeaderResponse, error) {
+	m := new(access.BlockHeaderResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *accessStreamAPIClient) SubscribeBlockHeaders(ctx context.Context, in *access.GetBlockHeaderByHeightRequest, opts ...grpc.CallOption) (SubscribeBlockHeadersClient, error) {
+	stream, err := c.cc.NewStream(ctx, &accessStreamAPIServiceDesc.Streams[0], "/flow.access.AccessStreamAPI/SubscribeBlockHeaders", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &accessStreamAPISubscribeBlockHeadersClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type SubscribeEventsClient interface {
+	Recv() (*access.EventsResponse_Result, error)
+	grpc.ClientStream
+}
+
+type accessStreamAPISubscribeEventsClient struct {
+	grpc.ClientStream
+}
+
+func (x *accessStreamAPISubscribeEventsClient) Recv() (*access.EventsResponse_Result, error) {
+	m := new(access.EventsResponse_Result)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *accessStreamAPIClient) SubscribeEvents(ctx context.Context, in *SubscribeEventsRequest, opts ...grpc.CallOption) (SubscribeEventsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &accessStreamAPIServiceDesc.Streams[1], "/flow.access.AccessStreamAPI/SubscribeEvents", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &accessStreamAPISubscribeEventsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type SubscribeTransactionStatusClient interface {
+	Recv() (*access.TransactionResultResponse, error)
+	grpc.ClientStream
+}
+
+type accessStreamAPISubscribeTransactionStatusClient struct {
+	grpc.ClientStream
+}
+
+func (x *accessStreamAPISubscribeTransactionStatusClient) Recv() (*access.TransactionResultResponse, error) {
+	m := new(access.TransactionResultResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *accessStreamAPIClient) SubscribeTransactionStatus(ctx context.Context, in *access.GetTransactionRequest, opts ...grpc.CallOption) (SubscribeTransactionStatusClient, error) {
+	stream, err := c.cc.NewStream(ctx, &accessStreamAPIServiceDesc.Streams[2], "/flow.access.AccessStreamAPI/SubscribeTransactionStatus", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &accessStreamAPISubscribeTransactionStatusClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// StreamHandler implements the AccessStreamAPI gRPC service on top of a StreamAPI.
+type StreamHandler struct {
+	api   StreamAPI
+	chain flow.Chain
+}
+
+func NewStreamHandler(api StreamAPI, chain flow.Chain) *StreamHandler {
+	return &StreamHandler{
+		api:   api,
+		chain: chain,
+	}
+}
+
+// SubscribeBlockHeaders streams finalized block headers starting at the requested height.
+func (h *StreamHandler) SubscribeBlockHeaders(
+	req *access.GetBlockHeaderByHeightRequest,
+	stream SubscribeBlockHeadersServer,
+) error {
+	return h.api.SubscribeBlockHeaders(stream.Context(), req.GetHeight(), func(header *flow.Header) error {
+		resp, err := blockHeaderResponse(header)
+		if err != nil {
+			return status.Error(codes.Internal, err.Error())
+		}
+		return stream.Send(resp)
+	})
+}
+
+// SubscribeEvents streams the events of sealed blocks matching the filter starting at the requested height.
+func (h *StreamHandler) SubscribeEvents(
+	req *SubscribeEventsRequest,
+	stream SubscribeEventsServer,
+) error {
+	if len(req.GetEventTypes()) == 0 {
+		return status.Error(codes.InvalidArgument, "at least one event type is required")
+	}
+
+	filter := EventFilter{
+		EventTypes: make([]flow.EventType, len(req.GetEventTypes())),
+		Addresses:  make([]flow.Address, len(req.GetAddresses())),
+	}
+	for i, eventType := range req.GetEventTypes() {
+		t, err := convert.EventType(eventType)
+		if err != nil {
+			return err
+		}
+		filter.EventTypes[i] = flow.EventType(t)
+	}
+	for i, rawAddress := range req.GetAddresses() {
+		address, err := convert.Address(rawAddress, h.chain)
+		if err != nil {
+			return err
+		}
+		filter.Addresses[i] = address
+	}
+
+	return h.api.SubscribeEvents(stream.Context(), req.GetStartHeight(), filter, func(events flow.BlockEvents) error {
+		resp, err := blockEventsToMessage(events)
+		if err != nil {
+			return status.Error(codes.Internal, err.Error())
+		}
+		return stream.Send(resp)
+	})
+}
+
+// SubscribeTransactionStatus streams the result of a transaction every time its status changes.
+func (h *StreamHandler) SubscribeTransactionStatus(
+	req *access.GetTransactionRequest,
+	stream SubscribeTransactionStatusServer,
+) error {
+	id, err := convert.TransactionID(req.GetId())
+	if err != nil {
+		return err
+	}
+
+	return h.api.SubscribeTransactionStatus(stream.Context(), id, func(result *TransactionResult) error {
+		return stream.Send(TransactionResultToMessage(result))
+	})
+}
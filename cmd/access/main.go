@@ -76,6 +76,7 @@ func main() {
 		logTxTimeToFinalizedExecuted bool
 		retryEnabled                 bool
 		rpcMetricsEnabled            bool
+		transactionResultsCacheSize  uint
 	)
 
 	cmd.FlowNode(flow.RoleAccess.String()).
@@ -87,14 +88,20 @@ func main() {
 			flags.UintVar(&executionGRPCPort, "execution-ingress-port", 9000, "the grpc ingress port for all execution nodes")
 			flags.StringVarP(&rpcConf.GRPCListenAddr, "rpc-addr", "r", "localhost:9000", "the address the gRPC server listens on")
 			flags.StringVarP(&rpcConf.HTTPListenAddr, "http-addr", "h", "localhost:8000", "the address the http proxy server listens on")
+			flags.StringVar(&rpcConf.RESTListenAddr, "rest-addr", "", "the address the REST API server listens on, the REST API is disabled if empty")
 			flags.StringVarP(&rpcConf.CollectionAddr, "static-collection-ingress-addr", "", "", "the address (of the collection node) to send transactions to")
 			flags.StringVarP(&rpcConf.ExecutionAddr, "script-addr", "s", "localhost:9000", "the address (of the execution node) forward the script to")
 			flags.StringVarP(&rpcConf.HistoricalAccessAddrs, "historical-access-addr", "", "", "comma separated rpc addresses for historical access nodes")
+			flags.StringVarP(&rpcConf.HistoricalAccessHeights, "historical-access-heights", "", "", "comma separated height ranges (start-end) of the sporks of the historical access nodes, in the order of historical-access-addr, block, event, account and script queries at these heights are routed to the historical access nodes")
 			flags.DurationVar(&rpcConf.CollectionClientTimeout, "collection-client-timeout", 3*time.Second, "grpc client timeout for a collection node")
 			flags.DurationVar(&rpcConf.ExecutionClientTimeout, "execution-client-timeout", 3*time.Second, "grpc client timeout for an execution node")
 			flags.UintVar(&rpcConf.MaxHeightRange, "rpc-max-height-range", backend.DefaultMaxHeightRange, "maximum size for height range requests")
 			flags.StringSliceVar(&rpcConf.PreferredExecutionNodeIDs, "preferred-execution-node-ids", nil, "comma separated list of execution nodes ids to choose from when making an upstream call e.g. b4a4dbdcd443d...,fb386a6a... etc.")
 			flags.StringSliceVar(&rpcConf.FixedExecutionNodeIDs, "fixed-execution-node-ids", nil, "comma separated list of execution nodes ids to choose from when making an upstream call if no matching preferred execution id is found e.g. b4a4dbdcd443d...,fb386a6a... etc.")
+			flags.BoolVar(&rpcConf.LocalIndexEnabled, "local-index-enabled", false, "whether to index the events and transaction results of sealed blocks locally instead of requesting them from execution nodes")
+			flags.DurationVar(&rpcConf.LocalIndexInterval, "local-index-interval", time.Second, "how often to index newly sealed blocks when the local index is enabled")
+			flags.BoolVar(&rpcConf.LocalScriptExecutionEnabled, "local-script-execution-enabled", false, "whether to execute scripts at sealed blocks locally over register values verified against the sealed state commitment instead of forwarding them to execution nodes")
+			flags.UintVar(&transactionResultsCacheSize, "transaction-results-cache-size", 10000, "number of locally indexed transaction results to be cached")
 			flags.BoolVar(&logTxTimeToFinalized, "log-tx-time-to-finalized", false, "log transaction time to finalized")
 			flags.BoolVar(&logTxTimeToExecuted, "log-tx-time-to-executed", false, "log transaction time to executed")
 			flags.BoolVar(&logTxTimeToFinalizedExecuted, "log-tx-time-to-finalized-executed", false, "log transaction time to finalized and executed")
@@ -104,6 +111,10 @@ func main() {
 			flags.StringVarP(&nodeInfoFile, "node-info-file", "", "", "full path to a json file which provides more details about nodes when reporting its reachability metrics")
 			flags.StringToIntVar(&apiRatelimits, "api-rate-limits", nil, "per second rate limits for Access API methods e.g. Ping=300,GetTransaction=500 etc.")
 			flags.StringToIntVar(&apiBurstlimits, "api-burst-limits", nil, "burst limits for Access API methods e.g. Ping=100,GetTransaction=100 etc.")
+			flags.StringVar(&rpcConf.APIKeyHeader, "api-key-header", "", "request header holding the API key that identifies a client for rate limiting, clients are identified by IP address if empty")
+			flags.StringSliceVar(&rpcConf.APIKeys, "api-keys", nil, "comma separated list of the API keys accepted in the api-key-header, clients sending any other key are identified by IP address")
+			flags.IntVar(&rpcConf.APIQuota.Limit, "api-quota", 0, "per client quota for expensive Access API calls in blocks per second, e.g. a GetEventsForHeightRange call for 100 blocks costs 100; disabled if 0")
+			flags.IntVar(&rpcConf.APIQuota.Burst, "api-quota-burst", 0, "max quota a client can spend at once, which is also the max number of blocks of a single call; defaults to api-quota")
 		}).
 		Module("mutable follower state", func(node *cmd.FlowNodeBuilder) error {
 			// For now, we only support state implementations from package badger.
@@ -232,8 +243,12 @@ func main() {
 				node.Storage.Collections,
 				node.Storage.Transactions,
 				node.Storage.Receipts,
+				node.DB,
+				storage.NewEvents(node.Metrics.Cache, node.DB),
+				storage.NewTransactionResults(node.Metrics.Cache, node.DB, transactionResultsCacheSize),
 				node.RootChainID,
 				transactionMetrics,
+				metrics.NewRateLimitCollector(),
 				collectionGRPCPort,
 				executionGRPCPort,
 				retryEnabled,
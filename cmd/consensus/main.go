@@ -26,6 +26,7 @@ import (
 	"github.com/onflow/flow-go/engine/consensus/ingestion"
 	"github.com/onflow/flow-go/engine/consensus/provider"
 	"github.com/onflow/flow-go/engine/consensus/sealing"
+	"github.com/onflow/flow-go/fvm"
 	"github.com/onflow/flow-go/model/bootstrap"
 	"github.com/onflow/flow-go/model/encodable"
 	"github.com/onflow/flow-go/model/encoding"
@@ -71,26 +72,29 @@ func main() {
 		chunkAlpha                             uint
 		requiredApprovalsForSealVerification   uint
 		requiredApprovalsForSealConstruction   uint
+		requiredChallengesForWithholding       uint
+		challengeTimeout                       uint64
 		emergencySealing                       bool
 
-		err               error
-		mutableState      protocol.MutableState
-		privateDKGData    *bootstrap.DKGParticipantPriv
-		guarantees        mempool.Guarantees
-		results           mempool.IncorporatedResults
-		receipts          mempool.ExecutionTree
-		approvals         mempool.Approvals
-		seals             mempool.IncorporatedResultSeals
-		pendingReceipts   mempool.PendingReceipts
-		prov              *provider.Engine
-		receiptRequester  *requester.Engine
-		syncCore          *synchronization.Core
-		comp              *compliance.Engine
-		conMetrics        module.ConsensusMetrics
-		mainMetrics       module.HotstuffMetrics
-		receiptValidator  module.ReceiptValidator
-		approvalValidator module.ApprovalValidator
-		chunkAssigner     *chmodule.ChunkAssigner
+		err                error
+		mutableState       protocol.MutableState
+		privateDKGData     *bootstrap.DKGParticipantPriv
+		guarantees         mempool.Guarantees
+		results            mempool.IncorporatedResults
+		receipts           mempool.ExecutionTree
+		approvals          mempool.Approvals
+		seals              mempool.IncorporatedResultSeals
+		pendingReceipts    mempool.PendingReceipts
+		prov               *provider.Engine
+		receiptRequester   *requester.Engine
+		syncCore           *synchronization.Core
+		comp               *compliance.Engine
+		conMetrics         module.ConsensusMetrics
+		mainMetrics        module.HotstuffMetrics
+		receiptValidator   module.ReceiptValidator
+		approvalValidator  module.ApprovalValidator
+		challengeValidator module.ChallengeValidator
+		chunkAssigner      *chmodule.ChunkAssigner
 	)
 
 	cmd.FlowNode(flow.RoleConsensus.String()).
@@ -113,6 +117,8 @@ func main() {
 			flags.UintVar(&chunkAlpha, "chunk-alpha", chmodule.DefaultChunkAssignmentAlpha, "number of verifiers that should be assigned to each chunk")
 			flags.UintVar(&requiredApprovalsForSealVerification, "required-verification-seal-approvals", validation.DefaultRequiredApprovalsForSealValidation, "minimum number of approvals that are required to verify a seal")
 			flags.UintVar(&requiredApprovalsForSealConstruction, "required-construction-seal-approvals", sealing.DefaultRequiredApprovalsForSealConstruction, "minimum number of approvals that are required to construct a seal")
+			flags.UintVar(&requiredChallengesForWithholding, "required-withholding-challenges", sealing.DefaultRequiredChallengesForWithholding, "minimum number of assigned verifiers that must challenge a chunk to withhold its result from sealing")
+			flags.Uint64Var(&challengeTimeout, "challenge-timeout", sealing.DefaultChallengeTimeout, "number of finalized blocks after which the challenges of a result expire if its block is not sealed")
 			flags.BoolVar(&emergencySealing, "emergency-sealing-active", sealing.DefaultEmergencySealingActive, "(de)activation of emergency sealing")
 		}).
 		Module("consensus node metrics", func(node *cmd.FlowNodeBuilder) error {
@@ -134,6 +140,10 @@ func main() {
 			if requiredApprovalsForSealConstruction > chunkAlpha {
 				return fmt.Errorf("invalid consensus parameters: requiredApprovalsForSealConstruction > chunkAlpha")
 			}
+			// We need to ensure `0 < requiredChallengesForWithholding <= chunkAlpha`
+			if requiredChallengesForWithholding == 0 || requiredChallengesForWithholding > chunkAlpha {
+				return fmt.Errorf("invalid consensus parameters: requiredChallengesForWithholding must be in [1, chunkAlpha]")
+			}
 
 			chunkAssigner, err = chmodule.NewChunkAssigner(chunkAlpha, node.State)
 			if err != nil {
@@ -154,6 +164,16 @@ func main() {
 				node.State,
 				resultApprovalSigVerifier)
 
+			// challenged chunks are verified again to check their fault proofs
+			vm := fvm.NewVirtualMachine(fvm.NewInterpreterRuntime())
+			vmCtx := fvm.NewContext(node.Logger, node.FvmOptions...)
+			challengeValidator = validation.NewChallengeValidator(
+				node.State,
+				node.Storage.Index,
+				chmodule.NewChunkVerifier(vm, vmCtx),
+				signature.NewAggregationVerifier(encoding.ChallengeTag),
+				signature.NewAggregationVerifier(encoding.ExecutionReceiptTag))
+
 			sealValidator := validation.NewSealValidator(
 				node.State,
 				node.Storage.Headers,
@@ -254,6 +274,7 @@ func main() {
 				node.Storage.Receipts,
 				node.Storage.Headers,
 				node.Storage.Index,
+				bstorage.NewChallenges(node.DB),
 				results,
 				receipts,
 				approvals,
@@ -262,7 +283,10 @@ func main() {
 				chunkAssigner,
 				receiptValidator,
 				approvalValidator,
+				challengeValidator,
 				requiredApprovalsForSealConstruction,
+				requiredChallengesForWithholding,
+				challengeTimeout,
 				emergencySealing,
 			)
 
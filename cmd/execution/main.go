@@ -2,6 +2,7 @@ package main
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"io"
 	"os"
@@ -26,13 +27,18 @@ import (
 	"github.com/onflow/flow-go/engine/execution/computation/committer"
 	"github.com/onflow/flow-go/engine/execution/ingestion"
 	exeprovider "github.com/onflow/flow-go/engine/execution/provider"
+	"github.com/onflow/flow-go/engine/execution/pruner"
 	"github.com/onflow/flow-go/engine/execution/rpc"
 	"github.com/onflow/flow-go/engine/execution/state"
 	"github.com/onflow/flow-go/engine/execution/state/bootstrap"
+	"github.com/onflow/flow-go/engine/execution/statesync"
 	"github.com/onflow/flow-go/fvm"
 	"github.com/onflow/flow-go/fvm/extralog"
+	led "github.com/onflow/flow-go/ledger"
 	"github.com/onflow/flow-go/ledger/common/pathfinder"
 	ledger "github.com/onflow/flow-go/ledger/complete"
+	"github.com/onflow/flow-go/ledger/complete/mtrie/flattener"
+	"github.com/onflow/flow-go/ledger/complete/mtrie/trie"
 	wal "github.com/onflow/flow-go/ledger/complete/wal"
 	bootstrapFilenames "github.com/onflow/flow-go/model/bootstrap"
 	"github.com/onflow/flow-go/model/encodable"
@@ -58,11 +64,15 @@ func main() {
 		events                      *storage.Events
 		serviceEvents               *storage.ServiceEvents
 		txResults                   *storage.TransactionResults
+		txTraces                    *storage.TransactionTraces
+		executionDatas              *storage.ExecutionDatas
 		results                     *storage.ExecutionResults
 		receipts                    *storage.ExecutionReceipts
 		myReceipts                  *storage.MyExecutionReceipts
 		providerEngine              *exeprovider.Engine
 		checkerEng                  *checker.Engine
+		prunerEng                   *pruner.Engine
+		stateSyncEng                *statesync.Engine
 		syncCore                    *chainsync.Core
 		pendingBlocks               *buffer.PendingBlocks // used in follower engine
 		deltas                      *ingestion.Deltas
@@ -78,10 +88,18 @@ func main() {
 		collector                   module.ExecutionMetrics
 		mTrieCacheSize              uint32
 		transactionResultsCacheSize uint
+		registerCacheSize           uint
 		checkpointDistance          uint
 		checkpointsToKeep           uint
+		pruningRetention            uint64
+		pruningInterval             time.Duration
+		pruneWALSegments            bool
 		stateDeltasLimit            uint
 		cadenceExecutionCache       uint
+		parallelExecutionWorkers    uint
+		transactionTraces           bool
+		pipelinedExecution          bool
+		commitExecutionData         bool
 		chdpCacheSize               uint
 		requestInterval             time.Duration
 		preferredExeNodeIDStr       string
@@ -91,6 +109,14 @@ func main() {
 		extensiveLog                bool
 		checkStakedAtBlock          func(blockID flow.Identifier) (bool, error)
 		diskWAL                     *wal.DiskWAL
+		forkDumpDir                 string
+		peerBootstrap               bool
+		peerBootstrapDepth          uint16
+		peerBootstrapWorkers        uint
+		peerBootstrapTimeout        time.Duration
+		syncedCommit                flow.StateCommitment  // the execution state synced from other execution nodes
+		syncedHeader                *flow.Header          // the sealed block the execution state was synced at
+		syncedResult                *flow.ExecutionResult // the execution result of the sealed block
 	)
 
 	cmd.FlowNode(flow.RoleExecution.String()).
@@ -99,20 +125,34 @@ func main() {
 			datadir := filepath.Join(homedir, ".flow", "execution")
 
 			flags.StringVarP(&rpcConf.ListenAddr, "rpc-addr", "i", "localhost:9000", "the address the gRPC server listens on")
+			flags.StringVar(&rpcConf.AdminListenAddr, "admin-addr", "localhost:9003", "the address the gRPC server of the admin endpoints listens on, only reachable by the operator (empty to disable)")
 			flags.StringVar(&triedir, "triedir", datadir, "directory to store the execution State")
 			flags.Uint32Var(&mTrieCacheSize, "mtrie-cache-size", 500, "cache size for MTrie")
 			flags.UintVar(&checkpointDistance, "checkpoint-distance", 40, "number of WAL segments between checkpoints")
 			flags.UintVar(&checkpointsToKeep, "checkpoints-to-keep", 5, "number of recent checkpoints to keep (0 to keep all)")
+			flags.Uint64Var(&pruningRetention, "pruning-retention", 0, "number of recent sealed heights whose execution data is kept, older execution data is pruned (0 to keep all)")
+			flags.DurationVar(&pruningInterval, "pruning-interval", 10*time.Minute, "the interval between pruning runs")
+			flags.BoolVar(&pruneWALSegments, "prune-wal-segments", false, "remove the WAL segments covered by the oldest kept checkpoint")
 			flags.UintVar(&stateDeltasLimit, "state-deltas-limit", 100, "maximum number of state deltas in the memory pool")
 			flags.UintVar(&cadenceExecutionCache, "cadence-execution-cache", computation.DefaultProgramsCacheSize, "cache size for Cadence execution")
+			flags.UintVar(&parallelExecutionWorkers, "parallel-execution-workers", 0, "number of transactions of a collection executed speculatively in parallel, transactions are executed sequentially if 0 or 1")
+			flags.BoolVar(&transactionTraces, "transaction-traces", false, "record the registers read and written, the programs loaded, the computation used and the events of every executed transaction")
+			flags.BoolVar(&pipelinedExecution, "pipelined-execution", false, "start executing a block once the transactions of its parent are executed, while the state of the parent is committed and persisted")
+			flags.BoolVar(&commitExecutionData, "commit-execution-data", false, "commit to the ID of the execution data of blocks in their execution results, to be enabled on all nodes at once at a spork")
 			flags.UintVar(&chdpCacheSize, "chdp-cache", 100, "cache size for Chunk Data Packs")
 			flags.DurationVar(&requestInterval, "request-interval", 60*time.Second, "the interval between requests for the requester engine")
 			flags.StringVar(&preferredExeNodeIDStr, "preferred-exe-node-id", "", "node ID for preferred execution node used for state sync")
 			flags.UintVar(&transactionResultsCacheSize, "transaction-results-cache-size", 10000, "number of transaction results to be cached")
+			flags.UintVar(&registerCacheSize, "register-cache-size", 10000, "number of register values read from or written to the ledger to be cached (0 to disable)")
 			flags.BoolVar(&syncByBlocks, "sync-by-blocks", true, "deprecated, sync by blocks instead of execution state deltas")
 			flags.BoolVar(&syncFast, "sync-fast", false, "fast sync allows execution node to skip fetching collection during state syncing, and rely on state syncing to catch up")
 			flags.IntVar(&syncThreshold, "sync-threshold", 100, "the maximum number of sealed and unexecuted blocks before triggering state syncing")
 			flags.BoolVar(&extensiveLog, "extensive-logging", false, "extensive logging logs tx contents and block headers")
+			flags.StringVar(&forkDumpDir, "fork-dump-dir", filepath.Join(datadir, "fork-dumps"), "directory to write the diagnostic dump to when the execution result of a sealed block differs from its sealed result")
+			flags.BoolVar(&peerBootstrap, "peer-bootstrap", false, "bootstrap the execution state from the latest sealed state of other execution nodes instead of the root checkpoint")
+			flags.Uint16Var(&peerBootstrapDepth, "peer-bootstrap-depth", 12, "depth at which the execution state trie is split into subtries when bootstrapping from other execution nodes")
+			flags.UintVar(&peerBootstrapWorkers, "peer-bootstrap-workers", 8, "number of subtries requested at once when bootstrapping from other execution nodes")
+			flags.DurationVar(&peerBootstrapTimeout, "peer-bootstrap-timeout", 30*time.Second, "time to wait for a response from another execution node before requesting from another one")
 		}).
 		Module("mutable follower state", func(node *cmd.FlowNodeBuilder) error {
 			// For now, we only support state implementations from package badger.
@@ -162,6 +202,41 @@ func main() {
 			diskWAL, err = wal.NewDiskWAL(node.Logger.With().Str("subcomponent", "wal").Logger(), node.MetricsRegisterer, collector, triedir, int(mTrieCacheSize), pathfinder.PathByteSize, wal.SegmentSize)
 			return diskWAL, err
 		}).
+		Component("state sync engine", func(node *cmd.FlowNodeBuilder) (module.ReadyDoneAware, error) {
+			stateSyncEng, err = statesync.New(node.Logger, node.Network, node.Me, node.State, peerBootstrapTimeout)
+			if err != nil {
+				return nil, fmt.Errorf("could not create state sync engine: %w", err)
+			}
+
+			if !peerBootstrap {
+				return stateSyncEng, nil
+			}
+
+			_, bootstrapped, err := bootstrap.NewBootstrapper(node.Logger).IsBootstrapped(node.DB)
+			if err != nil {
+				return nil, fmt.Errorf("could not query database to know whether database has been bootstrapped: %w", err)
+			}
+			if bootstrapped {
+				node.Logger.Info().Msg("execution database already bootstrapped, not bootstrapping from other execution nodes")
+				return stateSyncEng, nil
+			}
+
+			// the sync only returns once the whole state is fetched, which is awaited before starting other components
+			var mtrie *trie.MTrie
+			mtrie, syncedHeader, syncedResult, err = stateSyncEng.SyncSealedState(context.Background(), peerBootstrapDepth, peerBootstrapWorkers)
+			if err != nil {
+				return nil, fmt.Errorf("could not sync execution state from other execution nodes: %w", err)
+			}
+
+			// the ledger restores the synced trie as its root checkpoint
+			err = storeRootCheckpoint(mtrie, triedir)
+			if err != nil {
+				return nil, fmt.Errorf("could not store synced execution state: %w", err)
+			}
+			syncedCommit = mtrie.RootHash()
+
+			return stateSyncEng, nil
+		}).
 		Component("execution state ledger", func(node *cmd.FlowNodeBuilder) (module.ReadyDoneAware, error) {
 
 			// check if the execution database already exists
@@ -172,8 +247,24 @@ func main() {
 				return nil, fmt.Errorf("could not query database to know whether database has been bootstrapped: %w", err)
 			}
 
-			// if the execution database does not exist, then we need to bootstrap the execution database.
-			if !bootstrapped {
+			// if the execution state was synced from other execution nodes, the node starts executing from the
+			// synced sealed block, whose result is stored, so the results of its children can refer to it.
+			if !bootstrapped && syncedHeader != nil {
+				err = bootstrapper.BootstrapExecutionDatabase(node.DB, syncedCommit, syncedHeader)
+				if err != nil {
+					return nil, fmt.Errorf("could not bootstrap execution database: %w", err)
+				}
+
+				err = results.Store(syncedResult)
+				if err != nil {
+					return nil, fmt.Errorf("could not store synced execution result: %w", err)
+				}
+				err = results.Index(syncedHeader.ID(), syncedResult.ID())
+				if err != nil {
+					return nil, fmt.Errorf("could not index synced execution result: %w", err)
+				}
+			} else if !bootstrapped {
+				// if the execution database does not exist, then we need to bootstrap the execution database.
 				// when bootstrapping, the bootstrap folder must have a checkpoint file
 				// we need to cover this file to the trie folder to restore the trie to restore the execution state.
 				err = copyBootstrapState(node.BaseConfig.BootstrapDir, triedir)
@@ -187,9 +278,9 @@ func main() {
 				if err != nil {
 					return nil, fmt.Errorf("could not bootstrap execution database: %w", err)
 				}
-			} else {
+			} else if !peerBootstrap {
 				// if execution database has been bootstrapped, then the root statecommit must equal to the one
-				// in the bootstrap folder
+				// in the bootstrap folder, unless it was bootstrapped from other execution nodes
 				if !bytes.Equal(commit, node.RootSeal.FinalState) {
 					return nil, fmt.Errorf("mismatching root statecommitment. database has state commitment: %x, "+
 						"bootstap has statecommitment: %x",
@@ -198,7 +289,14 @@ func main() {
 			}
 
 			ledgerStorage, err = ledger.NewLedger(diskWAL, int(mTrieCacheSize), collector, node.Logger.With().Str("subcomponent", "ledger").Logger(), ledger.DefaultPathFinderVersion)
-			return ledgerStorage, err
+			if err != nil {
+				return nil, err
+			}
+
+			// serve the execution state to other execution nodes bootstrapping from it
+			stateSyncEng.ServeFrom(ledgerStorage)
+
+			return ledgerStorage, nil
 		}).
 		Component("execution state ledger WAL compactor", func(node *cmd.FlowNodeBuilder) (module.ReadyDoneAware, error) {
 
@@ -224,7 +322,17 @@ func main() {
 			vm := fvm.NewVirtualMachine(rt)
 			vmCtx := fvm.NewContext(node.Logger, node.FvmOptions...)
 
-			committer := committer.NewLedgerViewCommitter(ledgerStorage, node.Tracer)
+			// block execution reads and commits registers through the register cache
+			var executionLedger led.Ledger = ledgerStorage
+			if registerCacheSize > 0 {
+				registerCache, err := state.NewRegisterCache(registerCacheSize, node.Metrics.Cache)
+				if err != nil {
+					return nil, fmt.Errorf("could not create register cache: %w", err)
+				}
+				executionLedger = state.NewCachedLedger(ledgerStorage, registerCache)
+			}
+
+			committer := committer.NewLedgerViewCommitter(executionLedger, node.Tracer)
 			manager, err := computation.New(
 				node.Logger,
 				collector,
@@ -235,6 +343,8 @@ func main() {
 				vmCtx,
 				cadenceExecutionCache,
 				committer,
+				parallelExecutionWorkers,
+				transactionTraces,
 			)
 			if err != nil {
 				return nil, err
@@ -248,9 +358,11 @@ func main() {
 			events = storage.NewEvents(node.Metrics.Cache, node.DB)
 			serviceEvents = storage.NewServiceEvents(node.Metrics.Cache, node.DB)
 			txResults = storage.NewTransactionResults(node.Metrics.Cache, node.DB, transactionResultsCacheSize)
+			txTraces = storage.NewTransactionTraces(node.DB)
+			executionDatas = storage.NewExecutionDatas(node.DB)
 
 			executionState = state.NewExecutionState(
-				ledgerStorage,
+				executionLedger,
 				stateCommitments,
 				node.Storage.Blocks,
 				node.Storage.Headers,
@@ -273,6 +385,7 @@ func main() {
 				node.State,
 				node.Me,
 				executionState,
+				executionDatas,
 				collector,
 				checkStakedAtBlock,
 			)
@@ -285,9 +398,31 @@ func main() {
 				node.State,
 				executionState,
 				node.Storage.Seals,
+				results,
+				node.DB,
+				forkDumpDir,
 			)
 			return checkerEng, nil
 		}).
+		Component("pruner engine", func(node *cmd.FlowNodeBuilder) (module.ReadyDoneAware, error) {
+			var checkpointer *wal.Checkpointer
+			if pruneWALSegments {
+				checkpointer, err = ledgerStorage.Checkpointer()
+				if err != nil {
+					return nil, fmt.Errorf("cannot create checkpointer: %w", err)
+				}
+			}
+
+			prunerEng = pruner.New(
+				node.Logger,
+				node.DB,
+				metrics.NewExecutionPruningCollector(),
+				pruningRetention,
+				pruningInterval,
+				checkpointer,
+			)
+			return prunerEng, nil
+		}).
 		Component("ingestion engine", func(node *cmd.FlowNodeBuilder) (module.ReadyDoneAware, error) {
 			collectionRequester, err = requester.New(node.Logger, node.Metrics.Engine, node.Network, node.Me, node.State,
 				engine.RequestCollections,
@@ -317,6 +452,9 @@ func main() {
 				events,
 				serviceEvents,
 				txResults,
+				txTraces,
+				executionDatas,
+				node.DB,
 				computationManager,
 				providerEngine,
 				executionState,
@@ -328,6 +466,9 @@ func main() {
 				syncThreshold,
 				syncFast,
 				checkStakedAtBlock,
+				checkerEng.Halted,
+				pipelinedExecution,
+				commitExecutionData,
 			)
 
 			// TODO: we should solve these mutual dependencies better
@@ -415,6 +556,22 @@ func main() {
 			)
 			return eng, err
 		}).
+		Component("execution data provider engine", func(node *cmd.FlowNodeBuilder) (module.ReadyDoneAware, error) {
+			retrieve := func(chunkExecutionDataID flow.Identifier) (flow.Entity, error) {
+				return executionDatas.ChunkByID(chunkExecutionDataID)
+			}
+			eng, err := provider.New(
+				node.Logger,
+				node.Metrics.Engine,
+				node.Network,
+				node.Me,
+				node.State,
+				engine.ProvideExecutionData,
+				filter.Any,
+				retrieve,
+			)
+			return eng, err
+		}).
 		Component("sychronization engine", func(node *cmd.FlowNodeBuilder) (module.ReadyDoneAware, error) {
 			// initialize the synchronization engine
 			syncEngine, err = synchronization.New(
@@ -434,11 +591,43 @@ func main() {
 			return syncEngine, nil
 		}).
 		Component("grpc server", func(node *cmd.FlowNodeBuilder) (module.ReadyDoneAware, error) {
-			rpcEng := rpc.New(node.Logger, rpcConf, ingestionEng, node.Storage.Blocks, events, results, txResults, node.RootChainID)
+			rpcEng := rpc.New(node.Logger, rpcConf, ingestionEng, node.Storage.Blocks, events, results, txResults, txTraces, executionDatas, node.RootChainID)
 			return rpcEng, nil
 		}).Run()
 }
 
+// storeRootCheckpoint stores the given trie as the root checkpoint of the execution state, replacing the root
+// checkpoint of an interrupted bootstrap.
+func storeRootCheckpoint(mtrie *trie.MTrie, dir string) error {
+	err := os.MkdirAll(dir, 0700)
+	if err != nil {
+		return err
+	}
+
+	err = os.Remove(filepath.Join(dir, bootstrapFilenames.FilenameWALRootCheckpoint))
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("could not remove previous root checkpoint: %w", err)
+	}
+
+	flat, err := flattener.FlattenTrie(mtrie)
+	if err != nil {
+		return fmt.Errorf("could not flatten trie: %w", err)
+	}
+
+	writer, err := wal.CreateCheckpointWriterForFile(dir, bootstrapFilenames.FilenameWALRootCheckpoint)
+	if err != nil {
+		return fmt.Errorf("could not create checkpoint writer: %w", err)
+	}
+
+	err = wal.StoreCheckpoint(flat.ToFlattenedForestWithASingleTrie(), writer)
+	if err != nil {
+		_ = writer.Close()
+		return fmt.Errorf("could not store checkpoint: %w", err)
+	}
+
+	return writer.Close()
+}
+
 // copy the checkpoint files from the bootstrap folder to the execution state folder
 // Checkpoint file is required to restore the trie, and has to be placed in the execution
 // state folder.
@@ -0,0 +1,113 @@
+package prune
+
+import (
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flow-go/cmd/util/cmd/common"
+	"github.com/onflow/flow-go/engine/execution/pruner"
+	"github.com/onflow/flow-go/ledger/common/pathfinder"
+	"github.com/onflow/flow-go/ledger/complete/wal"
+	"github.com/onflow/flow-go/module/metrics"
+)
+
+var (
+	flagDatadir           string
+	flagTriedir           string
+	flagRetention         uint64
+	flagCheckpointsToKeep uint
+)
+
+var Cmd = &cobra.Command{
+	Use:   "prune-execution-data",
+	Short: "Prunes the execution data of old sealed blocks and the WAL segments covered by checkpoints of a stopped execution node (Possible data loss!)",
+	Run:   run,
+}
+
+func init() {
+
+	Cmd.Flags().StringVar(&flagDatadir, "datadir", "",
+		"directory that stores the protocol state and the execution data")
+	_ = Cmd.MarkFlagRequired("datadir")
+
+	Cmd.Flags().StringVar(&flagTriedir, "triedir", "",
+		"directory that stores the WAL and the checkpoints of the execution state, the WAL is not pruned if empty")
+
+	Cmd.Flags().Uint64Var(&flagRetention, "retention", 0,
+		"number of recent sealed heights whose execution data is kept (0 to keep all)")
+
+	Cmd.Flags().UintVar(&flagCheckpointsToKeep, "checkpoints-to-keep", 0,
+		"number of recent checkpoints to keep before removing the WAL segments they cover (0 to keep all)")
+}
+
+func run(*cobra.Command, []string) {
+
+	db := common.InitStorage(flagDatadir)
+	defer db.Close()
+
+	var checkpointer *wal.Checkpointer
+	if flagTriedir != "" {
+		diskWAL, err := wal.NewDiskWAL(log.Logger, nil, metrics.NewNoopCollector(), flagTriedir, 0, pathfinder.PathByteSize, wal.SegmentSize)
+		if err != nil {
+			log.Fatal().Err(err).Msg("could not open WAL")
+		}
+		defer func() {
+			<-diskWAL.Done()
+		}()
+
+		checkpointer, err = diskWAL.NewCheckpointer()
+		if err != nil {
+			log.Fatal().Err(err).Msg("could not create checkpointer")
+		}
+
+		removeCheckpoints(checkpointer)
+	}
+
+	p := pruner.New(log.Logger, db, metrics.NewNoopCollector(), flagRetention, 0, checkpointer)
+
+	if flagRetention > 0 {
+		threshold, err := p.Threshold()
+		if err != nil {
+			log.Fatal().Err(err).Msg("could not get pruning threshold")
+		}
+
+		log.Info().Uint64("threshold", threshold).Msg("pruning execution data")
+
+		err = p.PruneToHeight(threshold)
+		if err != nil {
+			log.Fatal().Err(err).Msg("could not prune execution data")
+		}
+	}
+
+	removed, err := p.PruneWAL()
+	if err != nil {
+		log.Fatal().Err(err).Msg("could not prune WAL segments")
+	}
+
+	log.Info().Uint64("removed_wal_bytes", removed).Msg("pruning finished")
+}
+
+// removeCheckpoints removes all but the most recent checkpoints, so the WAL segments covered by the remaining
+// checkpoints can be removed.
+func removeCheckpoints(checkpointer *wal.Checkpointer) {
+	if flagCheckpointsToKeep == 0 {
+		return
+	}
+
+	checkpoints, err := checkpointer.Checkpoints()
+	if err != nil {
+		log.Fatal().Err(err).Msg("could not list checkpoints")
+	}
+
+	if len(checkpoints) <= int(flagCheckpointsToKeep) {
+		return
+	}
+
+	for _, checkpoint := range checkpoints[:len(checkpoints)-int(flagCheckpointsToKeep)] {
+		err := checkpointer.RemoveCheckpoint(checkpoint)
+		if err != nil {
+			log.Fatal().Err(err).Int("checkpoint", checkpoint).Msg("could not remove checkpoint")
+		}
+		log.Info().Int("checkpoint", checkpoint).Msg("checkpoint removed")
+	}
+}
@@ -24,14 +24,26 @@ func run(*cobra.Command, []string) {
 	// err := db.Update(operation.InsertExecutionForkEvidence(expectedSeals))
 
 	if err == storage.ErrNotFound {
-		log.Info().Msg("no execution fork was found, exit")
+		log.Info().Msg("no execution fork was found")
+	} else if err != nil {
+		log.Fatal().Err(err).Msg("could not remove execution fork")
+		return
+	} else {
+		log.Info().Msg("execution fork removed")
+	}
+
+	// execution nodes halt their execution when their result of a sealed block differs from the sealed result
+	err = db.Update(operation.RemoveExecutionForkHalt())
+
+	if err == storage.ErrNotFound {
+		log.Info().Msg("no execution halt was found, exit")
 		return
 	}
 
 	if err != nil {
-		log.Fatal().Err(err).Msg("could not remove execution fork")
+		log.Fatal().Err(err).Msg("could not remove execution halt")
 		return
 	}
 
-	log.Info().Msg("execution fork removed")
+	log.Info().Msg("execution halt removed, execution resumes after restart")
 }
@@ -0,0 +1,179 @@
+package replay
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flow-go/cmd/util/cmd/common"
+	"github.com/onflow/flow-go/engine/execution/computation/committer"
+	"github.com/onflow/flow-go/engine/execution/computation/computer"
+	"github.com/onflow/flow-go/fvm"
+	"github.com/onflow/flow-go/ledger/common/pathfinder"
+	"github.com/onflow/flow-go/ledger/complete"
+	"github.com/onflow/flow-go/ledger/complete/wal"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module/metrics"
+	"github.com/onflow/flow-go/module/trace"
+	"github.com/onflow/flow-go/storage"
+	"github.com/onflow/flow-go/storage/badger/operation"
+)
+
+var (
+	flagDatadir    string
+	flagTriedir    string
+	flagChain      string
+	flagFromHeight uint64
+	flagToHeight   uint64
+)
+
+var Cmd = &cobra.Command{
+	Use:   "replay-blocks",
+	Short: "Re-executes the finalized blocks in a height range on the stored state of their parents, and reports the differences with their stored execution",
+	Run:   run,
+}
+
+func init() {
+
+	Cmd.Flags().StringVar(&flagDatadir, "datadir", "",
+		"directory that stores the protocol state and the execution data")
+	_ = Cmd.MarkFlagRequired("datadir")
+
+	Cmd.Flags().StringVar(&flagTriedir, "triedir", "",
+		"directory that stores the WAL and the checkpoints of the execution state")
+	_ = Cmd.MarkFlagRequired("triedir")
+
+	Cmd.Flags().StringVar(&flagChain, "chain", "",
+		"chain ID of the network the blocks are from")
+	_ = Cmd.MarkFlagRequired("chain")
+
+	Cmd.Flags().Uint64Var(&flagFromHeight, "from-height", 0,
+		"height of the first block to replay")
+	_ = Cmd.MarkFlagRequired("from-height")
+
+	Cmd.Flags().Uint64Var(&flagToHeight, "to-height", 0,
+		"height of the last block to replay, only the first block is replayed if 0")
+}
+
+func getChain(chainName string) (chain flow.Chain, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("invalid chain: %s", r)
+		}
+	}()
+	chain = flow.ChainID(chainName).Chain()
+	return
+}
+
+func run(*cobra.Command, []string) {
+
+	toHeight := flagToHeight
+	if toHeight == 0 {
+		toHeight = flagFromHeight
+	}
+	if toHeight < flagFromHeight {
+		log.Fatal().Msg("--to-height must not be lower than --from-height")
+	}
+
+	chain, err := getChain(flagChain)
+	if err != nil {
+		log.Fatal().Err(err).Msg("invalid chain")
+	}
+
+	db := common.InitStorage(flagDatadir)
+	defer db.Close()
+	storages := common.InitStorages(db)
+
+	// nothing is pruned if the pruned height is not stored
+	var prunedHeight uint64
+	err = db.View(operation.RetrieveExecutionPrunedHeight(&prunedHeight))
+	if err != nil && !errors.Is(err, storage.ErrNotFound) {
+		log.Fatal().Err(err).Msg("could not retrieve pruned height")
+	}
+
+	diskWAL, err := wal.NewDiskWAL(log.Logger, nil, metrics.NewNoopCollector(), flagTriedir, complete.DefaultCacheSize, pathfinder.PathByteSize, wal.SegmentSize)
+	if err != nil {
+		log.Fatal().Err(err).Msg("could not open WAL")
+	}
+	defer func() {
+		<-diskWAL.Done()
+	}()
+
+	led, err := complete.NewLedger(diskWAL, complete.DefaultCacheSize, metrics.NewNoopCollector(), log.Logger, complete.DefaultPathFinderVersion)
+	if err != nil {
+		log.Fatal().Err(err).Msg("could not load execution state from the WAL and checkpoints")
+	}
+
+	// the replayed updates are kept in memory only, the execution state on disk is not modified
+	diskWAL.PauseRecord()
+
+	// the virtual machine is configured as on the execution node
+	vmOpts := []fvm.Option{
+		fvm.WithChain(chain),
+		fvm.WithBlocks(fvm.NewBlockFinder(storages.Headers)),
+	}
+	if flow.ChainID(flagChain) == flow.Testnet {
+		vmOpts = append(vmOpts,
+			fvm.WithRestrictedAccountCreation(false),
+			fvm.WithRestrictedDeployment(false),
+			fvm.WithAccountStorageLimit(true),
+		)
+	}
+	vm := fvm.NewVirtualMachine(fvm.NewInterpreterRuntime())
+	vmCtx := fvm.NewContext(log.Logger, vmOpts...)
+
+	tracer := trace.NewNoopTracer()
+	blockComputer, err := computer.NewBlockComputer(vm, vmCtx, metrics.NewNoopCollector(), tracer, log.Logger, committer.NewLedgerViewCommitter(led, tracer))
+	if err != nil {
+		log.Fatal().Err(err).Msg("could not create block computer")
+	}
+
+	r := &replayer{
+		log:         log.Logger,
+		headers:     storages.Headers,
+		blocks:      storages.Blocks,
+		collections: storages.Collections,
+		commits:     storages.Commits,
+		results:     storages.Results,
+		events:      storages.Events,
+		ledger:      led,
+		computer:    blockComputer,
+
+		prunedHeight: prunedHeight,
+	}
+
+	mismatched := 0
+	for height := flagFromHeight; height <= toHeight; height++ {
+		mismatches, err := r.replayHeight(height)
+		if err != nil {
+			log.Fatal().Err(err).Uint64("height", height).Msg("could not replay block")
+		}
+
+		for _, mismatch := range mismatches {
+			log.Error().
+				Uint64("height", mismatch.Height).
+				Hex("block_id", mismatch.BlockID[:]).
+				Str("kind", mismatch.Kind).
+				Str("stored", mismatch.Stored).
+				Str("replayed", mismatch.Replayed).
+				Msg("replayed execution differs from stored execution")
+		}
+		if len(mismatches) > 0 {
+			mismatched++
+			continue
+		}
+
+		log.Info().Uint64("height", height).Msg("replayed execution matches stored execution")
+	}
+
+	if mismatched > 0 {
+		log.Fatal().Int("mismatched_blocks", mismatched).Msg("replayed execution differs from stored execution")
+	}
+
+	log.Info().
+		Uint64("from_height", flagFromHeight).
+		Uint64("to_height", toHeight).
+		Msg("replayed execution of all blocks matches stored execution")
+}
@@ -0,0 +1,267 @@
+package replay
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/rs/zerolog"
+
+	"github.com/onflow/flow-go/engine/execution"
+	"github.com/onflow/flow-go/engine/execution/computation/computer"
+	"github.com/onflow/flow-go/engine/execution/state"
+	"github.com/onflow/flow-go/engine/execution/state/delta"
+	"github.com/onflow/flow-go/fvm/programs"
+	"github.com/onflow/flow-go/ledger"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module/mempool/entity"
+	"github.com/onflow/flow-go/storage"
+)
+
+// Mismatch is a difference between the stored execution of a block and its replayed execution.
+type Mismatch struct {
+	Height   uint64
+	BlockID  flow.Identifier
+	Kind     string // what differs, for example the end state of a chunk
+	Stored   string
+	Replayed string
+}
+
+// storedExecution is the execution of a block stored by the execution node.
+type storedExecution struct {
+	Commit       flow.StateCommitment
+	Result       *flow.ExecutionResult // nil if not stored
+	Events       []flow.Event
+	EventsPruned bool // whether the events of the block are pruned
+}
+
+// replayer re-executes blocks on the state of their parent, and compares the replayed execution with the stored
+// execution.
+type replayer struct {
+	log         zerolog.Logger
+	headers     storage.Headers
+	blocks      storage.Blocks
+	collections storage.Collections
+	commits     storage.Commits
+	results     storage.ExecutionResults
+	events      storage.Events
+	ledger      ledger.Ledger
+	computer    computer.BlockComputer
+
+	// the height up to which the execution data was pruned, the events of the blocks up to this height are not
+	// compared
+	prunedHeight uint64
+}
+
+// replayHeight replays the finalized block at the given height, and returns the mismatches with its stored execution.
+func (r *replayer) replayHeight(height uint64) ([]Mismatch, error) {
+	header, err := r.headers.ByHeight(height)
+	if err != nil {
+		return nil, fmt.Errorf("could not get header at height %d: %w", height, err)
+	}
+	blockID := header.ID()
+
+	stored, err := r.storedExecution(header)
+	if err != nil {
+		return nil, fmt.Errorf("could not get stored execution of block %v: %w", blockID, err)
+	}
+
+	computed, err := r.execute(header)
+	if err != nil {
+		return nil, fmt.Errorf("could not replay block %v: %w", blockID, err)
+	}
+
+	if stored.Result == nil {
+		r.log.Warn().
+			Uint64("height", height).
+			Hex("block_id", blockID[:]).
+			Msg("execution result is not stored, only comparing the state commitment")
+	} else if stored.EventsPruned {
+		r.log.Warn().
+			Uint64("height", height).
+			Hex("block_id", blockID[:]).
+			Msg("events are pruned, only comparing the state commitments")
+	}
+
+	return compareExecution(header, stored, computed), nil
+}
+
+// storedExecution returns the stored execution of the given block.
+func (r *replayer) storedExecution(header *flow.Header) (*storedExecution, error) {
+	blockID := header.ID()
+	commit, err := r.commits.ByBlockID(blockID)
+	if err != nil {
+		return nil, fmt.Errorf("could not get state commitment: %w", err)
+	}
+
+	result, err := r.results.ByBlockID(blockID)
+	if errors.Is(err, storage.ErrNotFound) {
+		return &storedExecution{Commit: commit}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("could not get execution result: %w", err)
+	}
+
+	if header.Height <= r.prunedHeight {
+		return &storedExecution{
+			Commit:       commit,
+			Result:       result,
+			EventsPruned: true,
+		}, nil
+	}
+
+	events, err := r.events.ByBlockID(blockID)
+	if err != nil {
+		return nil, fmt.Errorf("could not get events: %w", err)
+	}
+
+	return &storedExecution{
+		Commit: commit,
+		Result: result,
+		Events: events,
+	}, nil
+}
+
+// execute executes the given block on the stored state of its parent, without persisting anything.
+func (r *replayer) execute(header *flow.Header) (*execution.ComputationResult, error) {
+	block, err := r.blocks.ByID(header.ID())
+	if err != nil {
+		return nil, fmt.Errorf("could not get block: %w", err)
+	}
+
+	parentCommit, err := r.commits.ByBlockID(header.ParentID)
+	if err != nil {
+		return nil, fmt.Errorf("could not get state commitment of parent: %w", err)
+	}
+
+	collections := make(map[flow.Identifier]*entity.CompleteCollection, len(block.Payload.Guarantees))
+	for _, guarantee := range block.Payload.Guarantees {
+		collection, err := r.collections.ByID(guarantee.CollectionID)
+		if err != nil {
+			return nil, fmt.Errorf("could not get collection %v: %w", guarantee.CollectionID, err)
+		}
+		collections[guarantee.ID()] = &entity.CompleteCollection{
+			Guarantee:    guarantee,
+			Transactions: collection.Transactions,
+		}
+	}
+
+	executableBlock := &entity.ExecutableBlock{
+		Block:               block,
+		CompleteCollections: collections,
+		StartState:          parentCommit,
+	}
+
+	view := delta.NewView(state.LedgerGetRegister(r.ledger, parentCommit))
+
+	// the programs are not cached across blocks, so every block is replayed the same way
+	return r.computer.ExecuteBlock(context.Background(), executableBlock, view, programs.NewEmptyPrograms())
+}
+
+// compareExecution compares the stored execution of a block with its replayed execution. The chunks and events are
+// only compared if the execution result is stored.
+func compareExecution(header *flow.Header, stored *storedExecution, computed *execution.ComputationResult) []Mismatch {
+	var mismatches []Mismatch
+	mismatch := func(kind string, stored, replayed interface{}) {
+		mismatches = append(mismatches, Mismatch{
+			Height:   header.Height,
+			BlockID:  header.ID(),
+			Kind:     kind,
+			Stored:   fmt.Sprintf("%x", stored),
+			Replayed: fmt.Sprintf("%x", replayed),
+		})
+	}
+
+	var finalCommit flow.StateCommitment
+	if len(computed.StateCommitments) > 0 {
+		finalCommit = computed.StateCommitments[len(computed.StateCommitments)-1]
+	}
+	if !bytes.Equal(stored.Commit, finalCommit) {
+		mismatch("state commitment", stored.Commit, finalCommit)
+	}
+
+	if stored.Result == nil {
+		return mismatches
+	}
+
+	if len(stored.Result.Chunks) != len(computed.StateCommitments) {
+		mismatches = append(mismatches, Mismatch{
+			Height:   header.Height,
+			BlockID:  header.ID(),
+			Kind:     "number of chunks",
+			Stored:   fmt.Sprint(len(stored.Result.Chunks)),
+			Replayed: fmt.Sprint(len(computed.StateCommitments)),
+		})
+	}
+	for i, chunk := range stored.Result.Chunks {
+		if i >= len(computed.StateCommitments) {
+			break
+		}
+		if !bytes.Equal(chunk.EndState, computed.StateCommitments[i]) {
+			mismatch(fmt.Sprintf("end state of chunk %d", i), chunk.EndState, computed.StateCommitments[i])
+		}
+	}
+
+	if stored.EventsPruned {
+		return mismatches
+	}
+
+	// the stored events are not in execution order
+	storedEvents := sortedEvents(stored.Events)
+	replayedEvents := sortedEvents(computed.Events)
+	if len(storedEvents) != len(replayedEvents) {
+		mismatches = append(mismatches, Mismatch{
+			Height:   header.Height,
+			BlockID:  header.ID(),
+			Kind:     "number of events",
+			Stored:   fmt.Sprint(len(storedEvents)),
+			Replayed: fmt.Sprint(len(replayedEvents)),
+		})
+	}
+	for i := range storedEvents {
+		if i >= len(replayedEvents) {
+			break
+		}
+		if !eventsEqual(storedEvents[i], replayedEvents[i]) {
+			// the events following a differing event usually differ as well, so only the first one is reported
+			mismatches = append(mismatches, Mismatch{
+				Height:   header.Height,
+				BlockID:  header.ID(),
+				Kind:     fmt.Sprintf("event %d", i),
+				Stored:   eventString(storedEvents[i]),
+				Replayed: eventString(replayedEvents[i]),
+			})
+			break
+		}
+	}
+
+	return mismatches
+}
+
+// eventsEqual returns whether the given events are equal, including their payloads, which are not part of their IDs.
+func eventsEqual(a, b flow.Event) bool {
+	return a.Type == b.Type &&
+		a.TransactionID == b.TransactionID &&
+		a.TransactionIndex == b.TransactionIndex &&
+		a.EventIndex == b.EventIndex &&
+		bytes.Equal(a.Payload, b.Payload)
+}
+
+func eventString(e flow.Event) string {
+	return fmt.Sprintf("%s of transaction %v at index %d with payload %x", e.Type, e.TransactionID, e.EventIndex, e.Payload)
+}
+
+// sortedEvents returns a copy of the given events, sorted by transaction index and event index.
+func sortedEvents(events []flow.Event) []flow.Event {
+	sorted := make([]flow.Event, len(events))
+	copy(sorted, events)
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].TransactionIndex != sorted[j].TransactionIndex {
+			return sorted[i].TransactionIndex < sorted[j].TransactionIndex
+		}
+		return sorted[i].EventIndex < sorted[j].EventIndex
+	})
+	return sorted
+}
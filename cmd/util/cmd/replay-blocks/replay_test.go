@@ -0,0 +1,183 @@
+package replay
+
+import (
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/engine/execution"
+	computermock "github.com/onflow/flow-go/engine/execution/computation/computer/mock"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module/mempool/entity"
+	"github.com/onflow/flow-go/storage"
+	storagemock "github.com/onflow/flow-go/storage/mock"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+type testSetup struct {
+	replayer    *replayer
+	headers     *storagemock.Headers
+	blocks      *storagemock.Blocks
+	collections *storagemock.Collections
+	commits     *storagemock.Commits
+	results     *storagemock.ExecutionResults
+	events      *storagemock.Events
+	computer    *computermock.BlockComputer
+}
+
+func newTestSetup() *testSetup {
+	s := &testSetup{
+		headers:     new(storagemock.Headers),
+		blocks:      new(storagemock.Blocks),
+		collections: new(storagemock.Collections),
+		commits:     new(storagemock.Commits),
+		results:     new(storagemock.ExecutionResults),
+		events:      new(storagemock.Events),
+		computer:    new(computermock.BlockComputer),
+	}
+	s.replayer = &replayer{
+		log:         zerolog.Nop(),
+		headers:     s.headers,
+		blocks:      s.blocks,
+		collections: s.collections,
+		commits:     s.commits,
+		results:     s.results,
+		events:      s.events,
+		computer:    s.computer,
+	}
+	return s
+}
+
+// storeBlock stores the given block with a single collection, and the state commitment of its parent.
+func (s *testSetup) storeBlock(block *flow.Block) {
+	collection := unittest.CollectionFixture(2)
+	guarantee := unittest.CollectionGuaranteeFixture()
+	guarantee.CollectionID = collection.ID()
+	block.SetPayload(flow.Payload{Guarantees: []*flow.CollectionGuarantee{guarantee}})
+
+	s.headers.On("ByHeight", block.Header.Height).Return(block.Header, nil)
+	s.blocks.On("ByID", block.ID()).Return(block, nil)
+	s.collections.On("ByID", collection.ID()).Return(&collection, nil)
+	s.commits.On("ByBlockID", block.Header.ParentID).Return(unittest.StateCommitmentFixture(), nil)
+}
+
+// storeExecution stores the given execution of the given block.
+func (s *testSetup) storeExecution(block *flow.Block, result *flow.ExecutionResult, events []flow.Event) {
+	commit, _ := result.FinalStateCommitment()
+	s.commits.On("ByBlockID", block.ID()).Return(commit, nil)
+	s.results.On("ByBlockID", block.ID()).Return(result, nil)
+	s.events.On("ByBlockID", block.ID()).Return(events, nil)
+}
+
+// replayResult makes the replay of the given block end in the end states of the chunks of the given result, and
+// emit the given events.
+func (s *testSetup) replayResult(block *flow.Block, result *flow.ExecutionResult, events []flow.Event) {
+	computed := &execution.ComputationResult{Events: events}
+	for _, chunk := range result.Chunks {
+		computed.StateCommitments = append(computed.StateCommitments, chunk.EndState)
+	}
+	s.computer.On("ExecuteBlock", mock.Anything, mock.MatchedBy(func(b *entity.ExecutableBlock) bool {
+		return b.ID() == block.ID() && len(b.CompleteCollections) == 1 && b.HasStartState()
+	}), mock.Anything, mock.Anything).Return(computed, nil)
+}
+
+// TestReplayMatchingExecution tests that the replay of a block executed the same way reports no mismatches, also if
+// the events are stored in a different order.
+func TestReplayMatchingExecution(t *testing.T) {
+	s := newTestSetup()
+
+	block := unittest.BlockFixture()
+	s.storeBlock(&block)
+
+	result := unittest.ExecutionResultFixture(unittest.WithBlock(&block))
+	txID := unittest.IdentifierFixture()
+	events := []flow.Event{
+		unittest.EventFixture(flow.EventAccountCreated, 0, 0, txID),
+		unittest.EventFixture(flow.EventAccountUpdated, 0, 1, txID),
+	}
+	s.storeExecution(&block, result, []flow.Event{events[1], events[0]})
+	s.replayResult(&block, result, events)
+
+	mismatches, err := s.replayer.replayHeight(block.Header.Height)
+	require.NoError(t, err)
+	assert.Empty(t, mismatches)
+}
+
+// TestReplayDivergentExecution tests that the differences of the replayed execution are reported.
+func TestReplayDivergentExecution(t *testing.T) {
+	s := newTestSetup()
+
+	block := unittest.BlockFixture()
+	s.storeBlock(&block)
+
+	result := unittest.ExecutionResultFixture(unittest.WithBlock(&block))
+	txID := unittest.IdentifierFixture()
+	stored := unittest.EventFixture(flow.EventAccountCreated, 0, 0, txID)
+	s.storeExecution(&block, result, []flow.Event{stored})
+
+	// the replay differs in the end state of the first chunk and in the payload of the event
+	replayedResult := *result
+	replayedResult.Chunks = flow.ChunkList{}
+	for _, chunk := range result.Chunks {
+		c := *chunk
+		replayedResult.Chunks = append(replayedResult.Chunks, &c)
+	}
+	replayedResult.Chunks[0].EndState = unittest.StateCommitmentFixture()
+	replayed := stored
+	replayed.Payload = []byte("other payload")
+	s.replayResult(&block, &replayedResult, []flow.Event{replayed})
+
+	mismatches, err := s.replayer.replayHeight(block.Header.Height)
+	require.NoError(t, err)
+
+	kinds := make([]string, 0, len(mismatches))
+	for _, mismatch := range mismatches {
+		assert.Equal(t, block.ID(), mismatch.BlockID)
+		assert.Equal(t, block.Header.Height, mismatch.Height)
+		kinds = append(kinds, mismatch.Kind)
+	}
+	assert.Equal(t, []string{"end state of chunk 0", "event 0"}, kinds)
+}
+
+// TestReplayPrunedExecution tests that only the state commitment is compared for a block whose execution result is
+// not stored.
+func TestReplayPrunedExecution(t *testing.T) {
+	s := newTestSetup()
+
+	block := unittest.BlockFixture()
+	s.storeBlock(&block)
+
+	result := unittest.ExecutionResultFixture(unittest.WithBlock(&block))
+	s.commits.On("ByBlockID", block.ID()).Return(unittest.StateCommitmentFixture(), nil)
+	s.results.On("ByBlockID", block.ID()).Return(nil, storage.ErrNotFound)
+	s.replayResult(&block, result, nil)
+
+	mismatches, err := s.replayer.replayHeight(block.Header.Height)
+	require.NoError(t, err)
+	require.Len(t, mismatches, 1)
+	assert.Equal(t, "state commitment", mismatches[0].Kind)
+	s.events.AssertNotCalled(t, "ByBlockID", mock.Anything)
+}
+
+// TestReplayPrunedEvents tests that the events are not compared for a block whose execution data is pruned.
+func TestReplayPrunedEvents(t *testing.T) {
+	s := newTestSetup()
+
+	block := unittest.BlockFixture()
+	s.storeBlock(&block)
+	s.replayer.prunedHeight = block.Header.Height
+
+	result := unittest.ExecutionResultFixture(unittest.WithBlock(&block))
+	commit, _ := result.FinalStateCommitment()
+	s.commits.On("ByBlockID", block.ID()).Return(commit, nil)
+	s.results.On("ByBlockID", block.ID()).Return(result, nil)
+	s.replayResult(&block, result, []flow.Event{unittest.EventFixture(flow.EventAccountCreated, 0, 0, unittest.IdentifierFixture())})
+
+	mismatches, err := s.replayer.replayHeight(block.Header.Height)
+	require.NoError(t, err)
+	assert.Empty(t, mismatches)
+	s.events.AssertNotCalled(t, "ByBlockID", mock.Anything)
+}
@@ -13,8 +13,10 @@ import (
 	export "github.com/onflow/flow-go/cmd/util/cmd/exec-data-json-export"
 	extract "github.com/onflow/flow-go/cmd/util/cmd/execution-state-extract"
 	ledger_json_exporter "github.com/onflow/flow-go/cmd/util/cmd/export-json-execution-state"
+	prune_execution_data "github.com/onflow/flow-go/cmd/util/cmd/prune-execution-data"
 	read_badger "github.com/onflow/flow-go/cmd/util/cmd/read-badger/cmd"
 	read_protocol_state "github.com/onflow/flow-go/cmd/util/cmd/read-protocol-state/cmd"
+	replay_blocks "github.com/onflow/flow-go/cmd/util/cmd/replay-blocks"
 	truncate_database "github.com/onflow/flow-go/cmd/util/cmd/truncate-database"
 )
 
@@ -56,6 +58,8 @@ func addCommands() {
 	rootCmd.AddCommand(read_badger.RootCmd)
 	rootCmd.AddCommand(read_protocol_state.RootCmd)
 	rootCmd.AddCommand(ledger_json_exporter.Cmd)
+	rootCmd.AddCommand(prune_execution_data.Cmd)
+	rootCmd.AddCommand(replay_blocks.Cmd)
 }
 
 func initConfig() {
@@ -13,9 +13,9 @@ import (
 	recovery "github.com/onflow/flow-go/consensus/recovery/protocol"
 	followereng "github.com/onflow/flow-go/engine/common/follower"
 	synceng "github.com/onflow/flow-go/engine/common/synchronization"
-	"github.com/onflow/flow-go/engine/verification/finder"
-	"github.com/onflow/flow-go/engine/verification/match"
-	"github.com/onflow/flow-go/engine/verification/verifier"
+	"github.com/onflow/flow-go/engine/verification/dashboard"
+	"github.com/onflow/flow-go/engine/verification/fetcher"
+	"github.com/onflow/flow-go/engine/verification/pipeline"
 	"github.com/onflow/flow-go/fvm"
 	"github.com/onflow/flow-go/model/encodable"
 	"github.com/onflow/flow-go/model/encoding"
@@ -24,8 +24,8 @@ import (
 	"github.com/onflow/flow-go/module/buffer"
 	"github.com/onflow/flow-go/module/chunks"
 	finalizer "github.com/onflow/flow-go/module/finalizer/consensus"
-	"github.com/onflow/flow-go/module/mempool/stdmap"
 	"github.com/onflow/flow-go/module/metrics"
+	"github.com/onflow/flow-go/module/misbehavior"
 	"github.com/onflow/flow-go/module/signature"
 	"github.com/onflow/flow-go/module/synchronization"
 	"github.com/onflow/flow-go/state/protocol"
@@ -35,55 +35,52 @@ import (
 
 const (
 	// requestInterval represents the time interval in milliseconds that the
-	// match engine retries sending resource requests to the network
+	// fetcher engine retries sending chunk data pack requests to the network
 	// this value is set following this issue (3443)
 	requestInterval = 5000 * time.Millisecond
 
-	// processInterval represents the time interval in milliseconds that the
-	// finder engine iterates over the execution receipts ready to process
-	// this value is set following this issue (3443)
-	processInterval = 1000 * time.Millisecond
-
-	// failureThreshold represents the number of retries match engine sends
-	// at `requestInterval` milliseconds for each of the missing resources.
-	// When it reaches the threshold ingest engine makes a missing challenge for the resources.
+	// failureThreshold represents the number of retries fetcher engine sends
+	// at `requestInterval` milliseconds for each of the missing chunk data packs.
 	// This value is currently set to account for a single 24-hour failure of an Execution node.
 	failureThreshold = 17500
 )
 
 func main() {
 	var (
-		followerState       protocol.MutableState
-		err                 error
-		receiptLimit        uint                       // size of execution-receipt/result related mempools
-		chunkAlpha          uint                       // number of verifiers assigned per chunk
-		chunkLimit          uint                       // size of chunk-related mempools
-		cachedReceipts      *stdmap.ReceiptDataPacks   // used in finder engine
-		pendingReceipts     *stdmap.ReceiptDataPacks   // used in finder engine
-		readyReceipts       *stdmap.ReceiptDataPacks   // used in finder engine
-		blockIDsCache       *stdmap.Identifiers        // used in finder engine
-		processedResultsIDs *stdmap.Identifiers        // used in finder engine
-		discardedResultIDs  *stdmap.Identifiers        // used in finder engine
-		receiptIDsByBlock   *stdmap.IdentifierMap      // used in finder engine
-		receiptIDsByResult  *stdmap.IdentifierMap      // used in finder engine
-		chunkIDsByResult    *stdmap.IdentifierMap      // used in match engine
-		pendingResults      *stdmap.ResultDataPacks    // used in match engine
-		pendingChunks       *match.Chunks              // used in match engine
-		headerStorage       *storage.Headers           // used in match and finder engines
-		syncCore            *synchronization.Core      // used in follower engine
-		pendingBlocks       *buffer.PendingBlocks      // used in follower engine
-		finderEng           *finder.Engine             // the finder engine
-		verifierEng         *verifier.Engine           // the verifier engine
-		matchEng            *match.Engine              // the match engine
-		followerEng         *followereng.Engine        // the follower engine
-		collector           module.VerificationMetrics // used to collect metrics of all engines
+		followerState   protocol.MutableState
+		err             error
+		receiptLimit    uint                       // deprecated, execution receipts are no longer held in memory
+		chunkAlpha      uint                       // number of verifiers assigned per chunk
+		chunkLimit      uint                       // size of chunk-related mempools
+		chunkWorkers    uint                       // number of assigned chunks fetched in parallel
+		verifierWorkers uint                       // number of chunks verified in parallel
+		chunkTimeout    time.Duration              // time after which the verifier stops waiting for a chunk verification
+		penaltyReports  uint                       // number of misbehavior reports after which an execution node is penalized
+		adminAddr       string                     // address the dashboard of assigned chunks is served on
+		pendingChunks   *fetcher.Chunks            // used in fetcher engine
+		headerStorage   *storage.Headers           // used in fetcher engine and dashboard
+		chunksQueue     *storage.ChunksQueue       // used in verification pipeline and dashboard
+		chunkProgress   *storage.ConsumerProgress  // used in verification pipeline and dashboard
+		chunkStatuses   *storage.ChunkStatuses     // used in verification pipeline and dashboard
+		chunkVerdicts   *storage.ChunkVerdicts     // used in verification pipeline and dashboard
+		syncCore        *synchronization.Core      // used in follower engine
+		pendingBlocks   *buffer.PendingBlocks      // used in follower engine
+		pipe            *pipeline.Pipeline         // the verification pipeline
+		followerEng     *followereng.Engine        // the follower engine
+		collector       module.VerificationMetrics // used to collect metrics of all engines
 	)
 
 	cmd.FlowNode(flow.RoleVerification.String()).
 		ExtraFlags(func(flags *pflag.FlagSet) {
 			flags.UintVar(&receiptLimit, "receipt-limit", 1000, "maximum number of execution receipts in the memory pool")
+			_ = flags.MarkDeprecated("receipt-limit", "execution receipts are read from storage")
 			flags.UintVar(&chunkLimit, "chunk-limit", 10000, "maximum number of chunk states in the memory pool")
 			flags.UintVar(&chunkAlpha, "chunk-alpha", chunks.DefaultChunkAssignmentAlpha, "number of verifiers that should be assigned to each chunk")
+			flags.UintVar(&chunkWorkers, "chunk-workers", 20, "number of assigned chunks whose chunk data packs are fetched in parallel")
+			flags.UintVar(&verifierWorkers, "verifier-workers", 4, "number of chunks verified in parallel")
+			flags.UintVar(&penaltyReports, "misbehavior-penalty-threshold", 3, "number of invalid chunk data packs after which an execution node is no longer requested chunk data packs, until restart")
+			flags.StringVar(&adminAddr, "admin-addr", "localhost:9003", "the address the HTTP server of the dashboard of assigned chunks listens on, only reachable by the operator (empty to disable)")
+			flags.DurationVar(&chunkTimeout, "chunk-verification-timeout", 5*time.Minute, "time after which the verifier stops waiting for a chunk verification and verifies the next chunk, 0 for no timeout")
 		}).
 		Module("mutable follower state", func(node *cmd.FlowNodeBuilder) error {
 			// For now, we only support state implementations from package badger.
@@ -105,113 +102,8 @@ func main() {
 			collector = metrics.NewVerificationCollector(node.Tracer, node.MetricsRegisterer)
 			return nil
 		}).
-		Module("cached execution receipts mempool", func(node *cmd.FlowNodeBuilder) error {
-			cachedReceipts, err = stdmap.NewReceiptDataPacks(receiptLimit)
-			if err != nil {
-				return err
-			}
-
-			// registers size method of backend for metrics
-			err = node.Metrics.Mempool.Register(metrics.ResourceCachedReceipt, cachedReceipts.Size)
-			if err != nil {
-				return fmt.Errorf("could not register backend metric: %w", err)
-			}
-			return nil
-		}).
-		Module("pending execution receipts mempool", func(node *cmd.FlowNodeBuilder) error {
-			pendingReceipts, err = stdmap.NewReceiptDataPacks(receiptLimit)
-			if err != nil {
-				return err
-			}
-
-			// registers size method of backend for metrics
-			err = node.Metrics.Mempool.Register(metrics.ResourcePendingReceipt, pendingReceipts.Size)
-			if err != nil {
-				return fmt.Errorf("could not register backend metric: %w", err)
-			}
-			return nil
-		}).
-		Module("ready execution receipts mempool", func(node *cmd.FlowNodeBuilder) error {
-			readyReceipts, err = stdmap.NewReceiptDataPacks(receiptLimit)
-			if err != nil {
-				return err
-			}
-
-			// registers size method of backend for metrics
-			err = node.Metrics.Mempool.Register(metrics.ResourceReceipt, readyReceipts.Size)
-			if err != nil {
-				return fmt.Errorf("could not register backend metric: %w", err)
-			}
-			return nil
-		}).
-		Module("pending execution receipts ids by block mempool", func(node *cmd.FlowNodeBuilder) error {
-			receiptIDsByBlock, err = stdmap.NewIdentifierMap(receiptLimit)
-			if err != nil {
-				return err
-			}
-
-			// registers size method of backend for metrics
-			err = node.Metrics.Mempool.Register(metrics.ResourcePendingReceiptIDsByBlock, receiptIDsByBlock.Size)
-			if err != nil {
-				return fmt.Errorf("could not register backend metric: %w", err)
-			}
-
-			return nil
-		}).
-		Module("execution receipt ids by result mempool", func(node *cmd.FlowNodeBuilder) error {
-			receiptIDsByResult, err = stdmap.NewIdentifierMap(receiptLimit)
-			if err != nil {
-				return err
-			}
-
-			// registers size method of backend for metrics
-			err = node.Metrics.Mempool.Register(metrics.ResourceReceiptIDsByResult, receiptIDsByResult.Size)
-			if err != nil {
-				return fmt.Errorf("could not register backend metric: %w", err)
-			}
-
-			return nil
-		}).
-		Module("chunk ids by result mempool", func(node *cmd.FlowNodeBuilder) error {
-			chunkIDsByResult, err = stdmap.NewIdentifierMap(chunkLimit)
-			if err != nil {
-				return err
-			}
-
-			// registers size method of backend for metrics
-			err = node.Metrics.Mempool.Register(metrics.ResourceChunkIDsByResult, chunkIDsByResult.Size)
-			if err != nil {
-				return fmt.Errorf("could not register backend metric: %w", err)
-			}
-
-			return nil
-		}).
-		Module("cached block ids mempool", func(node *cmd.FlowNodeBuilder) error {
-			blockIDsCache, err = stdmap.NewIdentifiers(receiptLimit)
-			if err != nil {
-				return err
-			}
-
-			// registers size method of backend for metrics
-			err = node.Metrics.Mempool.Register(metrics.ResourceCachedBlockID, blockIDsCache.Size)
-			if err != nil {
-				return fmt.Errorf("could not register backend metric: %w", err)
-			}
-
-			return nil
-		}).
-		Module("pending results mempool", func(node *cmd.FlowNodeBuilder) error {
-			pendingResults = stdmap.NewResultDataPacks(receiptLimit)
-
-			// registers size method of backend for metrics
-			err = node.Metrics.Mempool.Register(metrics.ResourcePendingResult, pendingResults.Size)
-			if err != nil {
-				return fmt.Errorf("could not register backend metric: %w", err)
-			}
-			return nil
-		}).
 		Module("pending chunks mempool", func(node *cmd.FlowNodeBuilder) error {
-			pendingChunks = match.NewChunks(chunkLimit)
+			pendingChunks = fetcher.NewChunks(chunkLimit)
 
 			err = node.Metrics.Mempool.Register(metrics.ResourcePendingChunk, pendingChunks.Size)
 			if err != nil {
@@ -219,30 +111,6 @@ func main() {
 			}
 			return nil
 		}).
-		Module("processed results ids mempool", func(node *cmd.FlowNodeBuilder) error {
-			processedResultsIDs, err = stdmap.NewIdentifiers(receiptLimit)
-			if err != nil {
-				return err
-			}
-			// registers size method of backend for metrics
-			err = node.Metrics.Mempool.Register(metrics.ResourceProcessedResultID, processedResultsIDs.Size)
-			if err != nil {
-				return fmt.Errorf("could not register backend metric: %w", err)
-			}
-			return nil
-		}).
-		Module("discarded results ids mempool", func(node *cmd.FlowNodeBuilder) error {
-			discardedResultIDs, err = stdmap.NewIdentifiers(receiptLimit)
-			if err != nil {
-				return err
-			}
-			// registers size method of backend for metrics
-			err = node.Metrics.Mempool.Register(metrics.ResourceDiscardedResultID, discardedResultIDs.Size)
-			if err != nil {
-				return fmt.Errorf("could not register backend metric: %w", err)
-			}
-			return nil
-		}).
 		Module("pending block cache", func(node *cmd.FlowNodeBuilder) error {
 			// consensus cache for follower engine
 			pendingBlocks = buffer.NewPendingBlocks()
@@ -259,67 +127,70 @@ func main() {
 			headerStorage = storage.NewHeaders(node.Metrics.Cache, node.DB)
 			return nil
 		}).
+		Module("verification storage", func(node *cmd.FlowNodeBuilder) error {
+			chunksQueue = storage.NewChunkQueue(node.DB)
+			chunkProgress = storage.NewConsumerProgress(node.DB, module.ConsumeProgressVerificationChunkIndex)
+			chunkStatuses = storage.NewChunkStatuses(node.DB)
+			chunkVerdicts = storage.NewChunkVerdicts(node.DB)
+			return nil
+		}).
 		Module("sync core", func(node *cmd.FlowNodeBuilder) error {
 			syncCore, err = synchronization.New(node.Logger, synchronization.DefaultConfig())
 			return err
 		}).
-		Component("verifier engine", func(node *cmd.FlowNodeBuilder) (module.ReadyDoneAware, error) {
+		Component("verification pipeline", func(node *cmd.FlowNodeBuilder) (module.ReadyDoneAware, error) {
 			rt := fvm.NewInterpreterRuntime()
 			vm := fvm.NewVirtualMachine(rt)
 			vmCtx := fvm.NewContext(node.Logger, node.FvmOptions...)
 			chunkVerifier := chunks.NewChunkVerifier(vm, vmCtx)
-			approvalStorage := storage.NewResultApprovals(node.Metrics.Cache, node.DB)
-			verifierEng, err = verifier.New(
-				node.Logger,
-				collector,
-				node.Tracer,
-				node.Network,
-				node.State,
-				node.Me,
-				chunkVerifier,
-				approvalStorage)
-			return verifierEng, err
-		}).
-		Component("match engine", func(node *cmd.FlowNodeBuilder) (module.ReadyDoneAware, error) {
 			assigner, err := chunks.NewChunkAssigner(chunkAlpha, node.State)
 			if err != nil {
 				return nil, err
 			}
-			matchEng, err = match.New(node.Logger,
+			if penaltyReports == 0 {
+				return nil, fmt.Errorf("misbehavior penalty threshold must be positive")
+			}
+
+			config := pipeline.DefaultConfig()
+			config.ChunkWorkers = int64(chunkWorkers)
+			config.VerifierWorkers = verifierWorkers
+			config.ChunkTimeout = chunkTimeout
+			config.RetryInterval = requestInterval
+			config.MaxAttempts = failureThreshold
+
+			pipe, err = pipeline.New(node.Logger,
 				collector,
 				node.Tracer,
 				node.Network,
 				node.Me,
-				pendingResults,
-				chunkIDsByResult,
-				verifierEng,
-				assigner,
 				node.State,
+				chunkVerifier,
+				assigner,
+				misbehavior.NewReporter(node.Logger, penaltyReports),
 				pendingChunks,
 				headerStorage,
-				requestInterval,
-				failureThreshold)
-			return matchEng, err
+				node.Storage.Blocks,
+				node.Storage.Results,
+				node.Storage.Receipts,
+				storage.NewResultApprovals(node.Metrics.Cache, node.DB),
+				chunkStatuses,
+				chunkVerdicts,
+				chunksQueue,
+				chunkProgress,
+				storage.NewConsumerProgress(node.DB, module.ConsumeProgressVerificationBlockHeight),
+				config)
+			return pipe, err
 		}).
-		Component("finder engine", func(node *cmd.FlowNodeBuilder) (module.ReadyDoneAware, error) {
-			finderEng, err = finder.New(node.Logger,
-				collector,
-				node.Tracer,
-				node.Network,
-				node.Me,
+		Component("dashboard engine", func(node *cmd.FlowNodeBuilder) (module.ReadyDoneAware, error) {
+			dash := dashboard.New(
 				node.State,
-				matchEng,
-				cachedReceipts,
-				pendingReceipts,
-				readyReceipts,
 				headerStorage,
-				processedResultsIDs,
-				discardedResultIDs,
-				receiptIDsByBlock,
-				receiptIDsByResult,
-				blockIDsCache,
-				processInterval)
-			return finderEng, err
+				node.Storage.Results,
+				chunksQueue,
+				chunkProgress,
+				chunkStatuses,
+				chunkVerdicts)
+			return dashboard.NewEngine(node.Logger, dash, adminAddr), nil
 		}).
 		Component("follower engine", func(node *cmd.FlowNodeBuilder) (module.ReadyDoneAware, error) {
 
@@ -351,9 +222,9 @@ func main() {
 				return nil, fmt.Errorf("could not find latest finalized block and pending blocks to recover consensus follower: %w", err)
 			}
 
-			// creates a consensus follower with ingestEngine as the notifier
+			// creates a consensus follower with the block consumer of the verification pipeline as the notifier
 			// so that it gets notified upon each new finalized block
-			followerCore, err := consensus.NewFollower(node.Logger, committee, node.Storage.Headers, final, verifier, finderEng, node.RootBlock.Header, node.RootQC, finalized, pending)
+			followerCore, err := consensus.NewFollower(node.Logger, committee, node.Storage.Headers, final, verifier, pipe.BlockConsumer, node.RootBlock.Header, node.RootQC, finalized, pending)
 			if err != nil {
 				return nil, fmt.Errorf("could not create follower core logic: %w", err)
 			}
@@ -114,6 +114,8 @@ func (suite *Suite) RunTest(
 			backend.DefaultMaxHeightRange,
 			nil,
 			nil,
+			nil,
+			nil,
 			suite.log,
 		)
 
@@ -290,6 +292,8 @@ func (suite *Suite) TestSendTransactionToRandomCollectionNode() {
 			backend.DefaultMaxHeightRange,
 			nil,
 			nil,
+			nil,
+			nil,
 			suite.log,
 		)
 
@@ -449,7 +453,7 @@ func (suite *Suite) TestGetSealedTransaction() {
 		require.NoError(suite.T(), err)
 
 		rpcEng := rpc.New(suite.log, suite.state, rpc.Config{}, nil, nil, nil, blocks, headers, collections, transactions,
-			nil, suite.chainID, metrics, 0, 0, false, false, nil, nil)
+			nil, nil, nil, nil, suite.chainID, metrics, metrics, 0, 0, false, false, nil, nil)
 
 		// create the ingest engine
 		ingestEng, err := ingestion.New(suite.log, suite.net, suite.state, suite.me, suite.request, blocks, headers, collections,
@@ -523,6 +527,8 @@ func (suite *Suite) TestExecuteScript() {
 			backend.DefaultMaxHeightRange,
 			nil,
 			nil,
+			nil,
+			nil,
 			suite.log,
 		)
 
@@ -0,0 +1,167 @@
+// Code generated by mockery v1.0.0. DO NOT EDIT.
+
+package mock
+
+import (
+	context "context"
+
+	execution "github.com/onflow/flow-go/engine/execution"
+	grpc "google.golang.org/grpc"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// ExecutionAccountAPIClient is an autogenerated mock type for the ExecutionAccountAPIClient type
+type ExecutionAccountAPIClient struct {
+	mock.Mock
+}
+
+// GetAccountContract provides a mock function with given fields: ctx, in, opts
+func (_m *ExecutionAccountAPIClient) GetAccountContract(ctx context.Context, in *execution.GetAccountContractRequest, opts ...grpc.CallOption) (*execution.AccountContractResponse, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, in)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 *execution.AccountContractResponse
+	if rf, ok := ret.Get(0).(func(context.Context, *execution.GetAccountContractRequest, ...grpc.CallOption) *execution.AccountContractResponse); ok {
+		r0 = rf(ctx, in, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*execution.AccountContractResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *execution.GetAccountContractRequest, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, in, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetAccountContractNames provides a mock function with given fields: ctx, in, opts
+func (_m *ExecutionAccountAPIClient) GetAccountContractNames(ctx context.Context, in *execution.GetAccountContractNamesRequest, opts ...grpc.CallOption) (*execution.AccountContractNamesResponse, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, in)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 *execution.AccountContractNamesResponse
+	if rf, ok := ret.Get(0).(func(context.Context, *execution.GetAccountContractNamesRequest, ...grpc.CallOption) *execution.AccountContractNamesResponse); ok {
+		r0 = rf(ctx, in, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*execution.AccountContractNamesResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *execution.GetAccountContractNamesRequest, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, in, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetAccountKeyAtIndex provides a mock function with given fields: ctx, in, opts
+func (_m *ExecutionAccountAPIClient) GetAccountKeyAtIndex(ctx context.Context, in *execution.GetAccountKeyAtIndexRequest, opts ...grpc.CallOption) (*execution.AccountKeyResponse, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, in)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 *execution.AccountKeyResponse
+	if rf, ok := ret.Get(0).(func(context.Context, *execution.GetAccountKeyAtIndexRequest, ...grpc.CallOption) *execution.AccountKeyResponse); ok {
+		r0 = rf(ctx, in, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*execution.AccountKeyResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *execution.GetAccountKeyAtIndexRequest, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, in, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetAccountStorageUsed provides a mock function with given fields: ctx, in, opts
+func (_m *ExecutionAccountAPIClient) GetAccountStorageUsed(ctx context.Context, in *execution.GetAccountStorageUsedRequest, opts ...grpc.CallOption) (*execution.AccountStorageUsedResponse, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, in)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 *execution.AccountStorageUsedResponse
+	if rf, ok := ret.Get(0).(func(context.Context, *execution.GetAccountStorageUsedRequest, ...grpc.CallOption) *execution.AccountStorageUsedResponse); ok {
+		r0 = rf(ctx, in, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*execution.AccountStorageUsedResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *execution.GetAccountStorageUsedRequest, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, in, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListAccountKeys provides a mock function with given fields: ctx, in, opts
+func (_m *ExecutionAccountAPIClient) ListAccountKeys(ctx context.Context, in *execution.ListAccountKeysRequest, opts ...grpc.CallOption) (*execution.AccountKeysResponse, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, in)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 *execution.AccountKeysResponse
+	if rf, ok := ret.Get(0).(func(context.Context, *execution.ListAccountKeysRequest, ...grpc.CallOption) *execution.AccountKeysResponse); ok {
+		r0 = rf(ctx, in, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*execution.AccountKeysResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *execution.ListAccountKeysRequest, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, in, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
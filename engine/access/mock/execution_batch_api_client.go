@@ -0,0 +1,137 @@
+// Code generated by mockery v1.0.0. DO NOT EDIT.
+
+package mock
+
+import (
+	context "context"
+
+	execution "github.com/onflow/flow-go/engine/execution"
+	grpc "google.golang.org/grpc"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// ExecutionBatchAPIClient is an autogenerated mock type for the ExecutionBatchAPIClient type
+type ExecutionBatchAPIClient struct {
+	mock.Mock
+}
+
+// ExecuteScriptsAtBlockID provides a mock function with given fields: ctx, in, opts
+func (_m *ExecutionBatchAPIClient) ExecuteScriptsAtBlockID(ctx context.Context, in *execution.ExecuteScriptsAtBlockIDRequest, opts ...grpc.CallOption) (*execution.ScriptsResponse, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, in)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 *execution.ScriptsResponse
+	if rf, ok := ret.Get(0).(func(context.Context, *execution.ExecuteScriptsAtBlockIDRequest, ...grpc.CallOption) *execution.ScriptsResponse); ok {
+		r0 = rf(ctx, in, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*execution.ScriptsResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *execution.ExecuteScriptsAtBlockIDRequest, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, in, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetAccountsAtBlockID provides a mock function with given fields: ctx, in, opts
+func (_m *ExecutionBatchAPIClient) GetAccountsAtBlockID(ctx context.Context, in *execution.GetAccountsAtBlockIDRequest, opts ...grpc.CallOption) (*execution.AccountsResponse, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, in)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 *execution.AccountsResponse
+	if rf, ok := ret.Get(0).(func(context.Context, *execution.GetAccountsAtBlockIDRequest, ...grpc.CallOption) *execution.AccountsResponse); ok {
+		r0 = rf(ctx, in, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*execution.AccountsResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *execution.GetAccountsAtBlockIDRequest, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, in, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetRegisterProofAtBlockID provides a mock function with given fields: ctx, in, opts
+func (_m *ExecutionBatchAPIClient) GetRegisterProofAtBlockID(ctx context.Context, in *execution.GetRegisterProofAtBlockIDRequest, opts ...grpc.CallOption) (*execution.RegisterProofResponse, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, in)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 *execution.RegisterProofResponse
+	if rf, ok := ret.Get(0).(func(context.Context, *execution.GetRegisterProofAtBlockIDRequest, ...grpc.CallOption) *execution.RegisterProofResponse); ok {
+		r0 = rf(ctx, in, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*execution.RegisterProofResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *execution.GetRegisterProofAtBlockIDRequest, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, in, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetTransactionResultsByBlockID provides a mock function with given fields: ctx, in, opts
+func (_m *ExecutionBatchAPIClient) GetTransactionResultsByBlockID(ctx context.Context, in *execution.GetTransactionResultsByBlockIDRequest, opts ...grpc.CallOption) (*execution.TransactionResultsResponse, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, in)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 *execution.TransactionResultsResponse
+	if rf, ok := ret.Get(0).(func(context.Context, *execution.GetTransactionResultsByBlockIDRequest, ...grpc.CallOption) *execution.TransactionResultsResponse); ok {
+		r0 = rf(ctx, in, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*execution.TransactionResultsResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *execution.GetTransactionResultsByBlockIDRequest, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, in, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
@@ -0,0 +1,85 @@
+// Code generated by mockery v1.0.0. DO NOT EDIT.
+
+package mock
+
+import (
+	context "context"
+
+	execution "github.com/onflow/flow-go/engine/execution"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// ExecutionBatchAPIServer is an autogenerated mock type for the ExecutionBatchAPIServer type
+type ExecutionBatchAPIServer struct {
+	mock.Mock
+}
+
+// ExecuteScriptsAtBlockID provides a mock function with given fields: _a0, _a1
+func (_m *ExecutionBatchAPIServer) ExecuteScriptsAtBlockID(_a0 context.Context, _a1 *execution.ExecuteScriptsAtBlockIDRequest) (*execution.ScriptsResponse, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 *execution.ScriptsResponse
+	if rf, ok := ret.Get(0).(func(context.Context, *execution.ExecuteScriptsAtBlockIDRequest) *execution.ScriptsResponse); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*execution.ScriptsResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *execution.ExecuteScriptsAtBlockIDRequest) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetAccountsAtBlockID provides a mock function with given fields: _a0, _a1
+func (_m *ExecutionBatchAPIServer) GetAccountsAtBlockID(_a0 context.Context, _a1 *execution.GetAccountsAtBlockIDRequest) (*execution.AccountsResponse, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 *execution.AccountsResponse
+	if rf, ok := ret.Get(0).(func(context.Context, *execution.GetAccountsAtBlockIDRequest) *execution.AccountsResponse); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*execution.AccountsResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *execution.GetAccountsAtBlockIDRequest) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetRegisterProofAtBlockID provides a mock function with given fields: _a0, _a1
+func (_m *ExecutionBatchAPIServer) GetRegisterProofAtBlockID(_a0 context.Context, _a1 *execution.GetRegisterProofAtBlockIDRequest) (*execution.RegisterProofResponse, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 *execution.RegisterProofResponse
+	if rf, ok := ret.Get(0).(func(context.Context, *execution.GetRegisterProofAtBlockIDRequest) *execution.RegisterProofResponse); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*execution.RegisterProofResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *execution.GetRegisterProofAtBlockIDRequest) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
@@ -0,0 +1,47 @@
+// Code generated by mockery v1.0.0. DO NOT EDIT.
+
+package mock
+
+import (
+	context "context"
+
+	execution "github.com/onflow/flow-go/engine/execution"
+	grpc "google.golang.org/grpc"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// ExecutionDryRunAPIClient is an autogenerated mock type for the ExecutionDryRunAPIClient type
+type ExecutionDryRunAPIClient struct {
+	mock.Mock
+}
+
+// DryRunTransaction provides a mock function with given fields: ctx, in, opts
+func (_m *ExecutionDryRunAPIClient) DryRunTransaction(ctx context.Context, in *execution.DryRunTransactionRequest, opts ...grpc.CallOption) (*execution.DryRunTransactionResponse, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, in)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 *execution.DryRunTransactionResponse
+	if rf, ok := ret.Get(0).(func(context.Context, *execution.DryRunTransactionRequest, ...grpc.CallOption) *execution.DryRunTransactionResponse); ok {
+		r0 = rf(ctx, in, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*execution.DryRunTransactionResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *execution.DryRunTransactionRequest, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, in, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
@@ -9,20 +9,24 @@ import (
 	"time"
 
 	accessproto "github.com/onflow/flow/protobuf/go/flow/access"
+	legacyaccessproto "github.com/onflow/flow/protobuf/go/flow/legacy/access"
 	"github.com/rs/zerolog"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 
+	accessapi "github.com/onflow/flow-go/access"
 	accessmock "github.com/onflow/flow-go/engine/access/mock"
 	"github.com/onflow/flow-go/engine/access/rpc"
 	"github.com/onflow/flow-go/model/flow"
 	"github.com/onflow/flow-go/module/metrics"
 	module "github.com/onflow/flow-go/module/mock"
 	protocol "github.com/onflow/flow-go/state/protocol/mock"
+	"github.com/onflow/flow-go/storage"
 	storagemock "github.com/onflow/flow-go/storage/mock"
 	grpcutils "github.com/onflow/flow-go/utils/grpc"
 	"github.com/onflow/flow-go/utils/unittest"
@@ -41,6 +45,7 @@ type RateLimitTestSuite struct {
 	me         *module.Local
 	chainID    flow.ChainID
 	metrics    *metrics.NoopCollector
+	rejected   *module.RateLimitMetrics
 	rpcEng     *rpc.Engine
 	client     accessproto.AccessAPIClient
 	closer     io.Closer
@@ -55,6 +60,7 @@ type RateLimitTestSuite struct {
 	// test rate limit
 	rateLimit  int
 	burstLimit int
+	quota      int
 }
 
 func (suite *RateLimitTestSuite) SetupTest() {
@@ -88,10 +94,20 @@ func (suite *RateLimitTestSuite) SetupTest() {
 
 	suite.chainID = flow.Testnet
 	suite.metrics = metrics.NewNoopCollector()
+	suite.rejected = new(module.RateLimitMetrics)
+	suite.rejected.On("RequestRejected", mock.Anything, mock.Anything)
+
+	// set the quota to test with
+	suite.quota = 10
 
 	config := rpc.Config{
 		GRPCListenAddr: ":0", // :0 to let the OS pick a free port
 		HTTPListenAddr: ":0",
+		APIKeyHeader:   apiKeyHeader,
+		APIKeys:        []string{"client-a", "client-b"},
+		APIQuota: rpc.QuotaConfig{
+			Limit: suite.quota,
+		},
 	}
 
 	// set the rate limit to test with
@@ -100,15 +116,17 @@ func (suite *RateLimitTestSuite) SetupTest() {
 	suite.burstLimit = 2
 
 	apiRateLimt := map[string]int{
-		"Ping": suite.rateLimit,
+		"Ping":            suite.rateLimit,
+		"SubscribeEvents": suite.rateLimit,
 	}
 
 	apiBurstLimt := map[string]int{
-		"Ping": suite.rateLimit,
+		"Ping":            suite.rateLimit,
+		"SubscribeEvents": suite.rateLimit,
 	}
 
 	suite.rpcEng = rpc.New(suite.log, suite.state, config, suite.execClient, suite.collClient, nil, suite.blocks, suite.headers, suite.collections, suite.transactions,
-		nil, suite.chainID, suite.metrics, 0, 0, false, false, apiRateLimt, apiBurstLimt)
+		nil, nil, nil, nil, suite.chainID, suite.metrics, suite.rejected, 0, 0, false, false, apiRateLimt, apiBurstLimt)
 	unittest.AssertClosesBefore(suite.T(), suite.rpcEng.Ready(), 2*time.Second)
 
 	// wait for the server to startup
@@ -133,6 +151,8 @@ func (suite *RateLimitTestSuite) TearDownTest() {
 	}
 }
 
+const apiKeyHeader = "x-api-key"
+
 func TestRateLimit(t *testing.T) {
 	suite.Run(t, new(RateLimitTestSuite))
 }
@@ -187,6 +207,208 @@ func (suite *RateLimitTestSuite) TestRatelimitingWithBurst() {
 	suite.assertRateLimitError(err)
 }
 
+// TestRatelimitingPerClient tests that the rate limit of each client is independent of the other clients
+func (suite *RateLimitTestSuite) TestRatelimitingPerClient() {
+
+	req := &accessproto.PingRequest{}
+	clientA := metadata.AppendToOutgoingContext(context.Background(), apiKeyHeader, "client-a")
+	clientB := metadata.AppendToOutgoingContext(context.Background(), apiKeyHeader, "client-b")
+
+	// expect the calls of both clients to be forwarded upstream
+	suite.execClient.On("Ping", mock.Anything, mock.Anything).Return(nil, nil).Times(2 * suite.burstLimit)
+	suite.collClient.On("Ping", mock.Anything, mock.Anything).Return(nil, nil).Times(2 * suite.burstLimit)
+
+	// exhaust the burst of the first client
+	for i := 0; i < suite.burstLimit; i++ {
+		_, err := suite.client.Ping(clientA, req)
+		assert.NoError(suite.T(), err)
+	}
+	_, err := suite.client.Ping(clientA, req)
+	suite.assertRateLimitError(err)
+
+	// the second client is not affected by the first one
+	for i := 0; i < suite.burstLimit; i++ {
+		_, err := suite.client.Ping(clientB, req)
+		assert.NoError(suite.T(), err)
+	}
+	_, err = suite.client.Ping(clientB, req)
+	suite.assertRateLimitError(err)
+
+	suite.rejected.AssertNumberOfCalls(suite.T(), "RequestRejected", 2)
+	suite.rejected.AssertCalled(suite.T(), "RequestRejected", "Ping", metrics.RateLimitReasonRate)
+}
+
+// TestRatelimitingUnauthenticatedKey tests that clients sending unknown API keys are limited by their address
+func (suite *RateLimitTestSuite) TestRatelimitingUnauthenticatedKey() {
+
+	req := &accessproto.PingRequest{}
+	keys := []string{"unknown-a", "unknown-b"}
+
+	// expect the calls within the limit of the address, and the call with an authenticated key
+	suite.execClient.On("Ping", mock.Anything, mock.Anything).Return(nil, nil).Times(suite.burstLimit + 1)
+	suite.collClient.On("Ping", mock.Anything, mock.Anything).Return(nil, nil).Times(suite.burstLimit + 1)
+
+	// a new key for every call does not escape the limit of the address
+	for i := 0; i < suite.burstLimit; i++ {
+		ctx := metadata.AppendToOutgoingContext(context.Background(), apiKeyHeader, keys[i%len(keys)])
+		_, err := suite.client.Ping(ctx, req)
+		assert.NoError(suite.T(), err)
+	}
+	ctx := metadata.AppendToOutgoingContext(context.Background(), apiKeyHeader, "unknown-c")
+	_, err := suite.client.Ping(ctx, req)
+	suite.assertRateLimitError(err)
+
+	// an authenticated key has its own limits
+	ctx = metadata.AppendToOutgoingContext(context.Background(), apiKeyHeader, "client-a")
+	_, err = suite.client.Ping(ctx, req)
+	assert.NoError(suite.T(), err)
+}
+
+// TestStreamRatelimiting tests that opening streams is rate limited like calls
+func (suite *RateLimitTestSuite) TestStreamRatelimiting() {
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	conn, err := grpc.Dial(suite.rpcEng.GRPCAddress().String(), grpc.WithInsecure())
+	suite.Require().NoError(err)
+	defer conn.Close()
+	client := accessapi.NewAccessStreamAPIClient(conn)
+
+	// the requests have no event type, so that the streams fail right after passing the rate limit check
+	subscribe := func() error {
+		stream, err := client.SubscribeEvents(ctx, &accessapi.SubscribeEventsRequest{})
+		if err != nil {
+			return err
+		}
+		_, err = stream.Recv()
+		return err
+	}
+
+	for i := 0; i < suite.burstLimit; i++ {
+		err := subscribe()
+		assert.Equal(suite.T(), codes.InvalidArgument, status.Code(err))
+	}
+
+	err = subscribe()
+	suite.assertRateLimitError(err)
+
+	suite.rejected.AssertCalled(suite.T(), "RequestRejected", "SubscribeEvents", metrics.RateLimitReasonRate)
+}
+
+// TestQuota tests that expensive calls are limited by the number of blocks they cover
+func (suite *RateLimitTestSuite) TestQuota() {
+
+	ctx := context.Background()
+
+	// the requests have no event type, so that they fail right after passing the quota check
+	request := func(startHeight uint64, endHeight uint64) error {
+		_, err := suite.client.GetEventsForHeightRange(ctx, &accessproto.GetEventsForHeightRangeRequest{
+			StartHeight: startHeight,
+			EndHeight:   endHeight,
+		})
+		return err
+	}
+
+	// a request covering more blocks than the quota is rejected right away
+	err := request(1, uint64(suite.quota)+1)
+	suite.assertRateLimitError(err)
+
+	// requests are accepted until the quota is spent
+	err = request(1, uint64(suite.quota)/2)
+	assert.Equal(suite.T(), codes.InvalidArgument, status.Code(err))
+	err = request(1, uint64(suite.quota)/2)
+	assert.Equal(suite.T(), codes.InvalidArgument, status.Code(err))
+
+	err = request(1, uint64(suite.quota)/2)
+	suite.assertRateLimitError(err)
+
+	suite.rejected.AssertNumberOfCalls(suite.T(), "RequestRejected", 2)
+	suite.rejected.AssertCalled(suite.T(), "RequestRejected", "GetEventsForHeightRange", metrics.RateLimitReasonQuota)
+}
+
+// TestLegacyQuota tests that the expensive calls of the legacy Access API are limited by the same quota
+func (suite *RateLimitTestSuite) TestLegacyQuota() {
+
+	ctx := context.Background()
+
+	conn, err := grpc.Dial(suite.rpcEng.GRPCAddress().String(), grpc.WithInsecure())
+	suite.Require().NoError(err)
+	defer conn.Close()
+	client := legacyaccessproto.NewAccessAPIClient(conn)
+
+	// the max height range of the backend is 0, so that the requests fail right after passing the quota check
+	request := func(startHeight uint64, endHeight uint64) error {
+		_, err := client.GetEventsForHeightRange(ctx, &legacyaccessproto.GetEventsForHeightRangeRequest{
+			Type:        "A.Event",
+			StartHeight: startHeight,
+			EndHeight:   endHeight,
+		})
+		return err
+	}
+
+	// a request covering more blocks than the quota is rejected right away
+	err = request(1, uint64(suite.quota)+1)
+	suite.assertRateLimitError(err)
+
+	// requests are accepted until the quota is spent
+	err = request(1, uint64(suite.quota)/2)
+	assert.Error(suite.T(), err)
+	assert.NotEqual(suite.T(), codes.ResourceExhausted, status.Code(err))
+	err = request(1, uint64(suite.quota)/2)
+	assert.Error(suite.T(), err)
+	assert.NotEqual(suite.T(), codes.ResourceExhausted, status.Code(err))
+
+	err = request(1, uint64(suite.quota)/2)
+	suite.assertRateLimitError(err)
+
+	suite.rejected.AssertNumberOfCalls(suite.T(), "RequestRejected", 2)
+	suite.rejected.AssertCalled(suite.T(), "RequestRejected", "GetEventsForHeightRange", metrics.RateLimitReasonQuota)
+}
+
+// TestBatchQuota tests that batch calls are limited by the number of accounts they look up
+func (suite *RateLimitTestSuite) TestBatchQuota() {
+
+	ctx := context.Background()
+	height := uint64(5)
+
+	// the requested height is unknown, so that the requests fail right after passing the quota check
+	suite.headers.On("ByHeight", height).Return(nil, storage.ErrNotFound)
+
+	conn, err := grpc.Dial(suite.rpcEng.GRPCAddress().String(), grpc.WithInsecure())
+	suite.Require().NoError(err)
+	defer conn.Close()
+	client := accessapi.NewAccessBatchAPIClient(conn)
+
+	request := func(accounts int) error {
+		addresses := make([][]byte, accounts)
+		for i := range addresses {
+			addresses[i] = unittest.AddressFixture().Bytes()
+		}
+		_, err := client.GetAccountsAtBlockHeight(ctx, &accessapi.GetAccountsAtBlockHeightRequest{
+			BlockHeight: height,
+			Addresses:   addresses,
+		})
+		return err
+	}
+
+	// a batch larger than the quota is rejected right away
+	err = request(suite.quota + 1)
+	suite.assertRateLimitError(err)
+
+	// batches are accepted until the quota is spent
+	err = request(suite.quota / 2)
+	assert.Equal(suite.T(), codes.NotFound, status.Code(err))
+	err = request(suite.quota / 2)
+	assert.Equal(suite.T(), codes.NotFound, status.Code(err))
+
+	err = request(suite.quota / 2)
+	suite.assertRateLimitError(err)
+
+	suite.rejected.AssertNumberOfCalls(suite.T(), "RequestRejected", 2)
+	suite.rejected.AssertCalled(suite.T(), "RequestRejected", "GetAccountsAtBlockHeight", metrics.RateLimitReasonQuota)
+}
+
 func (suite *RateLimitTestSuite) assertRateLimitError(err error) {
 	assert.Error(suite.T(), err)
 	status, ok := status.FromError(err)
@@ -0,0 +1,499 @@
+package rest
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/onflow/flow-go/access"
+	"github.com/onflow/flow-go/model/flow"
+)
+
+// request is an HTTP request with the variables of the matched route path.
+type request struct {
+	*http.Request
+	vars map[string]string
+}
+
+// Handler implements the REST API endpoints on top of the Access API.
+type Handler struct {
+	api   access.API
+	chain flow.Chain
+}
+
+func NewHandler(api access.API, chain flow.Chain) *Handler {
+	return &Handler{
+		api:   api,
+		chain: chain,
+	}
+}
+
+// GetLatestBlock returns the latest finalized block, or the latest sealed block if the sealed query
+// parameter is true.
+func (h *Handler) GetLatestBlock(r *request) (interface{}, error) {
+	sealed, err := optionalBool(r, "sealed")
+	if err != nil {
+		return nil, err
+	}
+
+	block, err := h.api.GetLatestBlock(r.Context(), sealed)
+	if err != nil {
+		return nil, err
+	}
+
+	return blockToModel(block), nil
+}
+
+// GetBlockByID returns the block with the given ID.
+func (h *Handler) GetBlockByID(r *request) (interface{}, error) {
+	id, err := parseIdentifier(r.vars["id"])
+	if err != nil {
+		return nil, err
+	}
+
+	block, err := h.api.GetBlockByID(r.Context(), id)
+	if err != nil {
+		return nil, err
+	}
+
+	return blockToModel(block), nil
+}
+
+// GetBlockByHeight returns the block at the height given by the height query parameter.
+func (h *Handler) GetBlockByHeight(r *request) (interface{}, error) {
+	height, err := requiredUint(r, "height")
+	if err != nil {
+		return nil, err
+	}
+
+	// the blocks of previous sporks are served with the IDs returned by the access nodes of those sporks
+	if historical, ok := h.api.(access.HistoricalBlocks); ok {
+		block, id, found, err := historical.GetHistoricalBlockByHeight(r.Context(), height)
+		if err != nil {
+			return nil, err
+		}
+		if found {
+			model := blockToModel(block)
+			model.ID = id
+			return model, nil
+		}
+	}
+
+	block, err := h.api.GetBlockByHeight(r.Context(), height)
+	if err != nil {
+		return nil, err
+	}
+
+	return blockToModel(block), nil
+}
+
+// GetCollectionByID returns the collection with the given ID.
+func (h *Handler) GetCollectionByID(r *request) (interface{}, error) {
+	id, err := parseIdentifier(r.vars["id"])
+	if err != nil {
+		return nil, err
+	}
+
+	collection, err := h.api.GetCollectionByID(r.Context(), id)
+	if err != nil {
+		return nil, err
+	}
+
+	return collectionToModel(collection), nil
+}
+
+// SendTransaction submits the transaction in the request body and returns its ID.
+func (h *Handler) SendTransaction(r *request) (interface{}, error) {
+	var m Transaction
+	err := decodeBody(r, &m)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := modelToTransaction(m, h.chain)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	err = h.api.SendTransaction(r.Context(), tx)
+	if err != nil {
+		return nil, err
+	}
+
+	return SendTransactionResponse{ID: tx.ID()}, nil
+}
+
+// DryRunTransaction executes the transaction in the request body against the latest sealed state without
+// submitting it. Signatures are not verified if the skip_signature_check query parameter is true.
+func (h *Handler) DryRunTransaction(r *request) (interface{}, error) {
+	var m Transaction
+	err := decodeBody(r, &m)
+	if err != nil {
+		return nil, err
+	}
+
+	skipSignatureCheck, err := optionalBool(r, "skip_signature_check")
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := modelToTransaction(m, h.chain)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	result, err := h.api.DryRunTransaction(r.Context(), tx, skipSignatureCheck)
+	if err != nil {
+		return nil, err
+	}
+
+	return dryRunResultToModel(result), nil
+}
+
+// GetTransaction returns the transaction with the given ID.
+func (h *Handler) GetTransaction(r *request) (interface{}, error) {
+	id, err := parseIdentifier(r.vars["id"])
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := h.api.GetTransaction(r.Context(), id)
+	if err != nil {
+		return nil, err
+	}
+
+	return transactionToModel(tx), nil
+}
+
+// GetTransactionResult returns the result of the transaction with the given ID.
+func (h *Handler) GetTransactionResult(r *request) (interface{}, error) {
+	id, err := parseIdentifier(r.vars["id"])
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := h.api.GetTransactionResult(r.Context(), id)
+	if err != nil {
+		return nil, err
+	}
+
+	return transactionResultToModel(result), nil
+}
+
+// GetAccount returns the account with the given address at the latest sealed block, or at the
+// height given by the block_height query parameter.
+func (h *Handler) GetAccount(r *request) (interface{}, error) {
+	address, err := parseAddress(r.vars["address"], h.chain)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	var account *flow.Account
+	if r.URL.Query().Get("block_height") != "" {
+		height, err := requiredUint(r, "block_height")
+		if err != nil {
+			return nil, err
+		}
+		account, err = h.api.GetAccountAtBlockHeight(r.Context(), address, height)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		account, err = h.api.GetAccountAtLatestBlock(r.Context(), address)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return accountToModel(account), nil
+}
+
+// GetAccountKeyAtIndex returns the key with the given index of an account at the latest sealed block, or at
+// the height given by the block_height query parameter.
+func (h *Handler) GetAccountKeyAtIndex(r *request) (interface{}, error) {
+	address, height, err := h.accountRequest(r)
+	if err != nil {
+		return nil, err
+	}
+
+	index, err := strconv.ParseUint(r.vars["index"], 10, 64)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid key index %q", r.vars["index"])
+	}
+
+	key, err := h.api.GetAccountKeyAtIndex(r.Context(), address, index, height)
+	if err != nil {
+		return nil, err
+	}
+
+	return accountKeyToModel(*key), nil
+}
+
+// ListAccountKeys returns the page of the keys of an account given by the offset and limit query parameters, at
+// the latest sealed block or at the height given by the block_height query parameter.
+func (h *Handler) ListAccountKeys(r *request) (interface{}, error) {
+	address, height, err := h.accountRequest(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var offset uint64
+	if r.URL.Query().Get("offset") != "" {
+		offset, err = requiredUint(r, "offset")
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	limit, err := requiredUint(r, "limit")
+	if err != nil {
+		return nil, err
+	}
+
+	keys, total, err := h.api.ListAccountKeys(r.Context(), address, offset, limit, height)
+	if err != nil {
+		return nil, err
+	}
+
+	return AccountKeys{
+		Keys:       accountKeysToModel(keys),
+		TotalCount: total,
+	}, nil
+}
+
+// GetAccountContractNames returns the names of the contracts deployed to an account at the latest sealed block, or
+// at the height given by the block_height query parameter.
+func (h *Handler) GetAccountContractNames(r *request) (interface{}, error) {
+	address, height, err := h.accountRequest(r)
+	if err != nil {
+		return nil, err
+	}
+
+	names, err := h.api.GetAccountContractNames(r.Context(), address, height)
+	if err != nil {
+		return nil, err
+	}
+
+	return AccountContractNames{Names: names}, nil
+}
+
+// GetAccountContract returns the contract with the given name deployed to an account at the latest sealed block,
+// or at the height given by the block_height query parameter.
+func (h *Handler) GetAccountContract(r *request) (interface{}, error) {
+	address, height, err := h.accountRequest(r)
+	if err != nil {
+		return nil, err
+	}
+
+	name := r.vars["name"]
+	code, err := h.api.GetAccountContract(r.Context(), address, name, height)
+	if err != nil {
+		return nil, err
+	}
+
+	return AccountContract{
+		Name: name,
+		Code: string(code),
+	}, nil
+}
+
+// GetAccountStorageUsed returns the storage used by an account at the latest sealed block, or at the height given
+// by the block_height query parameter.
+func (h *Handler) GetAccountStorageUsed(r *request) (interface{}, error) {
+	address, height, err := h.accountRequest(r)
+	if err != nil {
+		return nil, err
+	}
+
+	storageUsed, err := h.api.GetAccountStorageUsed(r.Context(), address, height)
+	if err != nil {
+		return nil, err
+	}
+
+	return AccountStorageUsed{StorageUsed: storageUsed}, nil
+}
+
+// accountRequest parses the address path variable of an account request, and returns the height given by the
+// block_height query parameter, or the height of the latest sealed block if it is absent.
+func (h *Handler) accountRequest(r *request) (flow.Address, uint64, error) {
+	address, err := parseAddress(r.vars["address"], h.chain)
+	if err != nil {
+		return flow.EmptyAddress, 0, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	if r.URL.Query().Get("block_height") != "" {
+		height, err := requiredUint(r, "block_height")
+		if err != nil {
+			return flow.EmptyAddress, 0, err
+		}
+		return address, height, nil
+	}
+
+	header, err := h.api.GetLatestBlockHeader(r.Context(), true)
+	if err != nil {
+		return flow.EmptyAddress, 0, err
+	}
+
+	return address, header.Height, nil
+}
+
+// ExecuteScript executes the script in the request body at the latest sealed block, or at the block
+// given by either the block_id or the block_height query parameter.
+func (h *Handler) ExecuteScript(r *request) (interface{}, error) {
+	var m ScriptRequest
+	err := decodeBody(r, &m)
+	if err != nil {
+		return nil, err
+	}
+
+	script := []byte(m.Script)
+	arguments := make([][]byte, len(m.Arguments))
+	for i, argument := range m.Arguments {
+		arguments[i] = argument
+	}
+
+	query := r.URL.Query()
+	var value []byte
+	switch {
+	case query.Get("block_id") != "" && query.Get("block_height") != "":
+		return nil, status.Error(codes.InvalidArgument, "only one of block_id and block_height can be given")
+	case query.Get("block_id") != "":
+		blockID, err := parseIdentifier(query.Get("block_id"))
+		if err != nil {
+			return nil, err
+		}
+		value, err = h.api.ExecuteScriptAtBlockID(r.Context(), blockID, script, arguments)
+		if err != nil {
+			return nil, err
+		}
+	case query.Get("block_height") != "":
+		height, err := requiredUint(r, "block_height")
+		if err != nil {
+			return nil, err
+		}
+		value, err = h.api.ExecuteScriptAtBlockHeight(r.Context(), height, script, arguments)
+		if err != nil {
+			return nil, err
+		}
+	default:
+		value, err = h.api.ExecuteScriptAtLatestBlock(r.Context(), script, arguments)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return ScriptResult{Value: cadenceValueToModel(value)}, nil
+}
+
+// GetEvents returns the events of the type given by the type query parameter, either for the blocks
+// in the height range given by the start_height and end_height query parameters, or for the comma
+// separated list of blocks given by the block_ids query parameter.
+func (h *Handler) GetEvents(r *request) (interface{}, error) {
+	query := r.URL.Query()
+
+	eventType := query.Get("type")
+	if strings.TrimSpace(eventType) == "" {
+		return nil, status.Error(codes.InvalidArgument, "missing event type")
+	}
+
+	var events []flow.BlockEvents
+	if rawIDs := query.Get("block_ids"); rawIDs != "" {
+		var blockIDs []flow.Identifier
+		for _, rawID := range strings.Split(rawIDs, ",") {
+			blockID, err := parseIdentifier(rawID)
+			if err != nil {
+				return nil, err
+			}
+			blockIDs = append(blockIDs, blockID)
+		}
+
+		var err error
+		events, err = h.api.GetEventsForBlockIDs(r.Context(), eventType, blockIDs)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		startHeight, err := requiredUint(r, "start_height")
+		if err != nil {
+			return nil, err
+		}
+		endHeight, err := requiredUint(r, "end_height")
+		if err != nil {
+			return nil, err
+		}
+
+		events, err = h.api.GetEventsForHeightRange(r.Context(), eventType, startHeight, endHeight)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return blockEventsToModel(events), nil
+}
+
+func decodeBody(r *request, v interface{}) error {
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+
+	err := decoder.Decode(v)
+	if err != nil {
+		return status.Errorf(codes.InvalidArgument, "invalid request body: %v", err)
+	}
+	return nil
+}
+
+func parseIdentifier(raw string) (flow.Identifier, error) {
+	id, err := flow.HexStringToIdentifier(raw)
+	if err != nil {
+		return flow.ZeroID, status.Errorf(codes.InvalidArgument, "invalid ID %q", raw)
+	}
+	return id, nil
+}
+
+// parseAddress parses a hex encoded address, with or without 0x prefix, and checks it is valid for
+// the given chain.
+func parseAddress(raw string, chain flow.Chain) (flow.Address, error) {
+	b, err := hex.DecodeString(strings.TrimPrefix(raw, "0x"))
+	if err != nil || len(b) == 0 || len(b) > flow.AddressLength {
+		return flow.EmptyAddress, fmt.Errorf("invalid address %q", raw)
+	}
+
+	address := flow.BytesToAddress(b)
+	if !chain.IsValid(address) {
+		return flow.EmptyAddress, fmt.Errorf("address %s is invalid for chain %s", address, chain)
+	}
+
+	return address, nil
+}
+
+func requiredUint(r *request, name string) (uint64, error) {
+	raw := r.URL.Query().Get(name)
+	if raw == "" {
+		return 0, status.Errorf(codes.InvalidArgument, "missing %s", name)
+	}
+
+	value, err := strconv.ParseUint(raw, 10, 64)
+	if err != nil {
+		return 0, status.Errorf(codes.InvalidArgument, "invalid %s %q", name, raw)
+	}
+	return value, nil
+}
+
+func optionalBool(r *request, name string) (bool, error) {
+	raw := r.URL.Query().Get(name)
+	if raw == "" {
+		return false, nil
+	}
+
+	value, err := strconv.ParseBool(raw)
+	if err != nil {
+		return false, status.Errorf(codes.InvalidArgument, "invalid %s %q", name, raw)
+	}
+	return value, nil
+}
@@ -0,0 +1,342 @@
+package rest
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/onflow/flow-go/access"
+	accessmock "github.com/onflow/flow-go/access/mock"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+// serve sends the request to a REST server backed by the given API and decodes the JSON response.
+func serve(t *testing.T, api access.API, method string, url string, body string, resp interface{}) int {
+	server := NewServer(api, "", flow.Testnet.Chain(), nil, zerolog.Nop())
+
+	req := httptest.NewRequest(method, url, strings.NewReader(body))
+	rec := httptest.NewRecorder()
+	server.Handler.ServeHTTP(rec, req)
+
+	assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), resp))
+
+	return rec.Code
+}
+
+func TestGetBlock(t *testing.T) {
+	block := unittest.BlockFixture()
+
+	t.Run("by id", func(t *testing.T) {
+		api := new(accessmock.API)
+		api.On("GetBlockByID", mock.Anything, block.ID()).Return(&block, nil)
+
+		var resp Block
+		code := serve(t, api, http.MethodGet, "/v1/blocks/"+block.ID().String(), "", &resp)
+		assert.Equal(t, http.StatusOK, code)
+		assert.Equal(t, block.ID(), resp.ID)
+		assert.Equal(t, block.Header.Height, resp.Height)
+		assert.Len(t, resp.CollectionGuarantees, len(block.Payload.Guarantees))
+	})
+
+	t.Run("by height", func(t *testing.T) {
+		api := new(accessmock.API)
+		api.On("GetBlockByHeight", mock.Anything, block.Header.Height).Return(&block, nil)
+
+		var resp Block
+		code := serve(t, api, http.MethodGet, "/v1/blocks?height="+strconv.FormatUint(block.Header.Height, 10), "", &resp)
+		assert.Equal(t, http.StatusOK, code)
+		assert.Equal(t, block.ID(), resp.ID)
+	})
+
+	t.Run("latest sealed", func(t *testing.T) {
+		api := new(accessmock.API)
+		api.On("GetLatestBlock", mock.Anything, true).Return(&block, nil)
+
+		var resp Block
+		code := serve(t, api, http.MethodGet, "/v1/blocks/latest?sealed=true", "", &resp)
+		assert.Equal(t, http.StatusOK, code)
+		assert.Equal(t, block.ID(), resp.ID)
+	})
+
+	t.Run("invalid id", func(t *testing.T) {
+		var resp Error
+		code := serve(t, new(accessmock.API), http.MethodGet, "/v1/blocks/invalid", "", &resp)
+		assert.Equal(t, http.StatusBadRequest, code)
+		assert.Equal(t, http.StatusBadRequest, resp.Code)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		api := new(accessmock.API)
+		api.On("GetBlockByID", mock.Anything, block.ID()).Return(nil, status.Error(codes.NotFound, "not found"))
+
+		var resp Error
+		code := serve(t, api, http.MethodGet, "/v1/blocks/"+block.ID().String(), "", &resp)
+		assert.Equal(t, http.StatusNotFound, code)
+		assert.Equal(t, "not found", resp.Message)
+	})
+}
+
+func TestSendTransaction(t *testing.T) {
+	chain := flow.Testnet.Chain()
+	tx := unittest.TransactionBodyFixture()
+	tx.Payer = chain.ServiceAddress()
+	tx.ProposalKey.Address = chain.ServiceAddress()
+	tx.Authorizers = []flow.Address{chain.ServiceAddress()}
+	tx.Arguments = [][]byte{[]byte(`{"type":"UInt64","value":"10"}`)}
+	tx.PayloadSignatures = nil
+	tx.EnvelopeSignatures = []flow.TransactionSignature{{
+		Address:   chain.ServiceAddress(),
+		KeyIndex:  0,
+		Signature: unittest.SignatureFixture(),
+	}}
+
+	body, err := json.Marshal(transactionToModel(&tx))
+	require.NoError(t, err)
+
+	api := new(accessmock.API)
+	api.On("SendTransaction", mock.Anything, mock.Anything).Return(nil)
+
+	var resp SendTransactionResponse
+	code := serve(t, api, http.MethodPost, "/v1/transactions", string(body), &resp)
+	require.Equal(t, http.StatusOK, code)
+
+	// the transaction decoded from JSON is identical to the one that was encoded
+	sent := api.Calls[0].Arguments.Get(1).(*flow.TransactionBody)
+	assert.Equal(t, tx.ID(), sent.ID())
+	assert.Equal(t, tx.ID(), resp.ID)
+}
+
+func TestDryRunTransaction(t *testing.T) {
+	chain := flow.Testnet.Chain()
+	tx := unittest.TransactionBodyFixture()
+	tx.Payer = chain.ServiceAddress()
+	tx.ProposalKey.Address = chain.ServiceAddress()
+	tx.Authorizers = []flow.Address{chain.ServiceAddress()}
+	tx.PayloadSignatures = nil
+	tx.EnvelopeSignatures = nil
+
+	body, err := json.Marshal(transactionToModel(&tx))
+	require.NoError(t, err)
+
+	blockID := unittest.IdentifierFixture()
+	api := new(accessmock.API)
+	api.On("DryRunTransaction", mock.Anything, mock.Anything, true).Return(&access.DryRunResult{
+		BlockID:         blockID,
+		ComputationUsed: 12,
+		StorageDeltas:   []access.StorageDelta{{Address: chain.ServiceAddress(), Delta: -8}},
+		FeeEstimate:     1000,
+	}, nil)
+
+	var resp DryRunResult
+	code := serve(t, api, http.MethodPost, "/v1/transactions/dry_run?skip_signature_check=true", string(body), &resp)
+	require.Equal(t, http.StatusOK, code)
+	assert.Equal(t, blockID, resp.BlockID)
+	assert.Equal(t, uint64(12), resp.ComputationUsed)
+	assert.Equal(t, uint64(1000), resp.FeeEstimate)
+	assert.Equal(t, []StorageDelta{{Address: chain.ServiceAddress().Hex(), Delta: -8}}, resp.StorageDeltas)
+
+	// the unsigned transaction decoded from JSON is identical to the one that was encoded
+	dryRun := api.Calls[0].Arguments.Get(1).(*flow.TransactionBody)
+	assert.Equal(t, tx.ID(), dryRun.ID())
+}
+
+func TestGetTransactionResult(t *testing.T) {
+	txID := unittest.IdentifierFixture()
+	payload := []byte(`{"type":"String","value":"hello"}`)
+	event := unittest.EventFixture(flow.EventAccountCreated, 0, 0, txID)
+	event.Payload = payload
+
+	api := new(accessmock.API)
+	api.On("GetTransactionResult", mock.Anything, txID).Return(&access.TransactionResult{
+		Status:       flow.TransactionStatusSealed,
+		StatusCode:   1,
+		ErrorMessage: "failed",
+		Events:       []flow.Event{event},
+	}, nil)
+
+	var resp TransactionResult
+	code := serve(t, api, http.MethodGet, "/v1/transaction_results/"+txID.String(), "", &resp)
+	require.Equal(t, http.StatusOK, code)
+
+	assert.Equal(t, "SEALED", resp.Status)
+	assert.Equal(t, uint(1), resp.StatusCode)
+	assert.Equal(t, "failed", resp.ErrorMessage)
+	require.Len(t, resp.Events, 1)
+	// the event payload is embedded as JSON-Cadence
+	assert.JSONEq(t, string(payload), string(resp.Events[0].Payload))
+}
+
+func TestExecuteScript(t *testing.T) {
+	script := "pub fun main(a: UInt64): UInt64 { return a }"
+	argument := `{"type":"UInt64","value":"10"}`
+	body := `{"script":"` + script + `","arguments":[` + argument + `]}`
+
+	api := new(accessmock.API)
+	api.On("ExecuteScriptAtBlockHeight", mock.Anything, uint64(5), []byte(script), [][]byte{[]byte(argument)}).
+		Return([]byte(argument), nil)
+
+	var resp ScriptResult
+	code := serve(t, api, http.MethodPost, "/v1/scripts?block_height=5", body, &resp)
+	require.Equal(t, http.StatusOK, code)
+	assert.JSONEq(t, argument, string(resp.Value))
+
+	var errResp Error
+	code = serve(t, api, http.MethodPost, "/v1/scripts?block_height=5&block_id="+unittest.IdentifierFixture().String(), body, &errResp)
+	assert.Equal(t, http.StatusBadRequest, code)
+}
+
+func TestAccountLookups(t *testing.T) {
+	address := flow.Testnet.Chain().ServiceAddress()
+	latest := unittest.BlockHeaderFixture()
+
+	t.Run("contract at latest sealed block", func(t *testing.T) {
+		api := new(accessmock.API)
+		api.On("GetLatestBlockHeader", mock.Anything, true).Return(&latest, nil)
+		api.On("GetAccountContract", mock.Anything, address, "Token", latest.Height).Return([]byte("pub contract Token {}"), nil)
+
+		var resp AccountContract
+		code := serve(t, api, http.MethodGet, "/v1/accounts/"+address.Hex()+"/contracts/Token", "", &resp)
+		require.Equal(t, http.StatusOK, code)
+		assert.Equal(t, "Token", resp.Name)
+		assert.Equal(t, "pub contract Token {}", resp.Code)
+	})
+
+	t.Run("keys at height", func(t *testing.T) {
+		key, err := unittest.AccountKeyDefaultFixture()
+		require.NoError(t, err)
+		publicKey := key.PublicKey(1000)
+		publicKey.Index = 2
+
+		api := new(accessmock.API)
+		api.On("ListAccountKeys", mock.Anything, address, uint64(2), uint64(10), uint64(5)).
+			Return([]flow.AccountPublicKey{publicKey}, uint64(3), nil)
+
+		var resp AccountKeys
+		code := serve(t, api, http.MethodGet, "/v1/accounts/"+address.Hex()+"/keys?offset=2&limit=10&block_height=5", "", &resp)
+		require.Equal(t, http.StatusOK, code)
+		require.Len(t, resp.Keys, 1)
+		assert.Equal(t, 2, resp.Keys[0].Index)
+		assert.Equal(t, uint64(3), resp.TotalCount)
+	})
+
+	t.Run("missing key", func(t *testing.T) {
+		api := new(accessmock.API)
+		api.On("GetAccountKeyAtIndex", mock.Anything, address, uint64(7), uint64(5)).
+			Return(nil, status.Error(codes.NotFound, "key does not exist"))
+
+		var resp Error
+		code := serve(t, api, http.MethodGet, "/v1/accounts/"+address.Hex()+"/keys/7?block_height=5", "", &resp)
+		assert.Equal(t, http.StatusNotFound, code)
+	})
+
+	t.Run("invalid key index", func(t *testing.T) {
+		var resp Error
+		code := serve(t, new(accessmock.API), http.MethodGet, "/v1/accounts/"+address.Hex()+"/keys/first?block_height=5", "", &resp)
+		assert.Equal(t, http.StatusBadRequest, code)
+	})
+}
+
+func TestGetEvents(t *testing.T) {
+	blockID := unittest.IdentifierFixture()
+	events := []flow.BlockEvents{{
+		BlockID:     blockID,
+		BlockHeight: 5,
+		Events:      []flow.Event{unittest.EventFixture(flow.EventAccountCreated, 0, 0, unittest.IdentifierFixture())},
+	}}
+
+	t.Run("height range", func(t *testing.T) {
+		api := new(accessmock.API)
+		api.On("GetEventsForHeightRange", mock.Anything, string(flow.EventAccountCreated), uint64(5), uint64(6)).
+			Return(events, nil)
+
+		var resp []BlockEvents
+		code := serve(t, api, http.MethodGet, "/v1/events?type=flow.AccountCreated&start_height=5&end_height=6", "", &resp)
+		require.Equal(t, http.StatusOK, code)
+		require.Len(t, resp, 1)
+		assert.Equal(t, blockID, resp[0].BlockID)
+		assert.Len(t, resp[0].Events, 1)
+	})
+
+	t.Run("block ids", func(t *testing.T) {
+		api := new(accessmock.API)
+		api.On("GetEventsForBlockIDs", mock.Anything, string(flow.EventAccountCreated), []flow.Identifier{blockID}).
+			Return(events, nil)
+
+		var resp []BlockEvents
+		code := serve(t, api, http.MethodGet, "/v1/events?type=flow.AccountCreated&block_ids="+blockID.String(), "", &resp)
+		require.Equal(t, http.StatusOK, code)
+		require.Len(t, resp, 1)
+	})
+
+	t.Run("missing type", func(t *testing.T) {
+		var resp Error
+		code := serve(t, new(accessmock.API), http.MethodGet, "/v1/events?start_height=5&end_height=6", "", &resp)
+		assert.Equal(t, http.StatusBadRequest, code)
+	})
+}
+
+func TestUnknownEndpoint(t *testing.T) {
+	var resp Error
+	code := serve(t, new(accessmock.API), http.MethodGet, "/v1/unknown", "", &resp)
+	assert.Equal(t, http.StatusNotFound, code)
+}
+
+// limiter is a rate limiter that records the rate limited requests and rejects them once the quota is spent.
+type limiter struct {
+	methods []string
+	costs   []int
+	quota   int
+}
+
+func (l *limiter) Allow(_ *http.Request, method string, cost int) error {
+	l.methods = append(l.methods, method)
+	l.costs = append(l.costs, cost)
+	if cost > l.quota {
+		return status.Error(codes.ResourceExhausted, "quota reached")
+	}
+	l.quota -= cost
+	return nil
+}
+
+func TestRateLimit(t *testing.T) {
+	rateLimited := &limiter{quota: 10}
+	api := new(accessmock.API)
+	api.On("GetEventsForHeightRange", mock.Anything, "A.0x1.Foo.Bar", uint64(1), uint64(5)).Return(nil, nil)
+	server := NewServer(api, "", flow.Testnet.Chain(), rateLimited, zerolog.Nop())
+
+	request := func(url string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		server.Handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, url, nil))
+		return rec
+	}
+
+	// events requests cost one unit per block they cover
+	rec := request("/v1/events?type=A.0x1.Foo.Bar&start_height=1&end_height=5")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	rec = request("/v1/events?type=A.0x1.Foo.Bar&start_height=1&end_height=5")
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	// requests are rejected once the quota is spent, without calling the API
+	rec = request("/v1/events?type=A.0x1.Foo.Bar&start_height=1&end_height=5")
+	assert.Equal(t, http.StatusTooManyRequests, rec.Code)
+	api.AssertNumberOfCalls(t, "GetEventsForHeightRange", 2)
+
+	// other requests are rate limited under the name of their route without being charged to the quota
+	rec = request("/v1/blocks/invalid")
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	assert.Equal(t, []string{"GetEvents", "GetEvents", "GetEvents", "GetBlockByID"}, rateLimited.methods)
+	assert.Equal(t, []int{5, 5, 5, 0}, rateLimited.costs)
+}
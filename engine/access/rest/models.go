@@ -0,0 +1,416 @@
+package rest
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/onflow/flow-go/access"
+	"github.com/onflow/flow-go/model/flow"
+)
+
+// The JSON models of the REST API. Identifiers and addresses are hex encoded, binary data such as
+// signatures and public keys is base64 encoded and Cadence values (script results, transaction
+// arguments and event payloads) are embedded in the JSON-Cadence data interchange format.
+
+type Error struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+type BlockHeader struct {
+	ID        flow.Identifier `json:"id"`
+	ParentID  flow.Identifier `json:"parent_id"`
+	Height    uint64          `json:"height"`
+	Timestamp time.Time       `json:"timestamp"`
+}
+
+type CollectionGuarantee struct {
+	CollectionID flow.Identifier   `json:"collection_id"`
+	SignerIDs    []flow.Identifier `json:"signer_ids"`
+	Signature    []byte            `json:"signature"`
+}
+
+type BlockSeal struct {
+	BlockID    flow.Identifier `json:"block_id"`
+	ResultID   flow.Identifier `json:"result_id"`
+	FinalState string          `json:"final_state"`
+}
+
+type Block struct {
+	BlockHeader
+	CollectionGuarantees []CollectionGuarantee `json:"collection_guarantees"`
+	BlockSeals           []BlockSeal           `json:"block_seals"`
+	Signatures           [][]byte              `json:"signatures"`
+}
+
+type Collection struct {
+	ID           flow.Identifier   `json:"id"`
+	Transactions []flow.Identifier `json:"transactions"`
+}
+
+type ProposalKey struct {
+	Address        string `json:"address"`
+	KeyIndex       uint64 `json:"key_index"`
+	SequenceNumber uint64 `json:"sequence_number"`
+}
+
+type TransactionSignature struct {
+	Address   string `json:"address"`
+	KeyIndex  uint64 `json:"key_index"`
+	Signature []byte `json:"signature"`
+}
+
+type Transaction struct {
+	ID                 flow.Identifier        `json:"id"`
+	Script             string                 `json:"script"`
+	Arguments          []json.RawMessage      `json:"arguments"`
+	ReferenceBlockID   flow.Identifier        `json:"reference_block_id"`
+	GasLimit           uint64                 `json:"gas_limit"`
+	Payer              string                 `json:"payer"`
+	ProposalKey        ProposalKey            `json:"proposal_key"`
+	Authorizers        []string               `json:"authorizers"`
+	PayloadSignatures  []TransactionSignature `json:"payload_signatures"`
+	EnvelopeSignatures []TransactionSignature `json:"envelope_signatures"`
+}
+
+type Event struct {
+	Type             flow.EventType  `json:"type"`
+	TransactionID    flow.Identifier `json:"transaction_id"`
+	TransactionIndex uint32          `json:"transaction_index"`
+	EventIndex       uint32          `json:"event_index"`
+	Payload          json.RawMessage `json:"payload"`
+}
+
+type BlockEvents struct {
+	BlockID        flow.Identifier `json:"block_id"`
+	BlockHeight    uint64          `json:"block_height"`
+	BlockTimestamp time.Time       `json:"block_timestamp"`
+	Events         []Event         `json:"events"`
+}
+
+type TransactionResult struct {
+	BlockID      flow.Identifier `json:"block_id"`
+	Status       string          `json:"status"`
+	StatusCode   uint            `json:"status_code"`
+	ErrorMessage string          `json:"error_message"`
+	Events       []Event         `json:"events"`
+}
+
+type DryRunResult struct {
+	BlockID         flow.Identifier `json:"block_id"`
+	ComputationUsed uint64          `json:"computation_used"`
+	Events          []Event         `json:"events"`
+	StorageDeltas   []StorageDelta  `json:"storage_deltas"`
+	FeeEstimate     uint64          `json:"fee_estimate"`
+	ErrorCode       uint32          `json:"error_code"`
+	ErrorMessage    string          `json:"error_message"`
+}
+
+type StorageDelta struct {
+	Address string `json:"address"`
+	Delta   int64  `json:"delta"`
+}
+
+type AccountKey struct {
+	Index          int    `json:"index"`
+	PublicKey      []byte `json:"public_key"`
+	SigningAlgo    string `json:"signing_algorithm"`
+	HashingAlgo    string `json:"hashing_algorithm"`
+	SequenceNumber uint64 `json:"sequence_number"`
+	Weight         int    `json:"weight"`
+	Revoked        bool   `json:"revoked"`
+}
+
+type Account struct {
+	Address   string            `json:"address"`
+	Balance   uint64            `json:"balance"`
+	Keys      []AccountKey      `json:"keys"`
+	Contracts map[string]string `json:"contracts"`
+}
+
+type AccountKeys struct {
+	Keys       []AccountKey `json:"keys"`
+	TotalCount uint64       `json:"total_count"`
+}
+
+type AccountContractNames struct {
+	Names []string `json:"names"`
+}
+
+type AccountContract struct {
+	Name string `json:"name"`
+	Code string `json:"code"`
+}
+
+type AccountStorageUsed struct {
+	StorageUsed uint64 `json:"storage_used"`
+}
+
+type ScriptRequest struct {
+	Script    string            `json:"script"`
+	Arguments []json.RawMessage `json:"arguments"`
+}
+
+type ScriptResult struct {
+	Value json.RawMessage `json:"value"`
+}
+
+type SendTransactionResponse struct {
+	ID flow.Identifier `json:"id"`
+}
+
+// transactionStatuses are the names of the transaction statuses.
+var transactionStatuses = map[flow.TransactionStatus]string{
+	flow.TransactionStatusUnknown:   "UNKNOWN",
+	flow.TransactionStatusPending:   "PENDING",
+	flow.TransactionStatusFinalized: "FINALIZED",
+	flow.TransactionStatusExecuted:  "EXECUTED",
+	flow.TransactionStatusSealed:    "SEALED",
+	flow.TransactionStatusExpired:   "EXPIRED",
+}
+
+func blockHeaderToModel(h *flow.Header) BlockHeader {
+	return BlockHeader{
+		ID:        h.ID(),
+		ParentID:  h.ParentID,
+		Height:    h.Height,
+		Timestamp: h.Timestamp,
+	}
+}
+
+func blockToModel(b *flow.Block) Block {
+	guarantees := make([]CollectionGuarantee, len(b.Payload.Guarantees))
+	for i, g := range b.Payload.Guarantees {
+		guarantees[i] = CollectionGuarantee{
+			CollectionID: g.CollectionID,
+			SignerIDs:    g.SignerIDs,
+			Signature:    g.Signature,
+		}
+	}
+
+	seals := make([]BlockSeal, len(b.Payload.Seals))
+	for i, s := range b.Payload.Seals {
+		seals[i] = BlockSeal{
+			BlockID:    s.BlockID,
+			ResultID:   s.ResultID,
+			FinalState: hex.EncodeToString(s.FinalState),
+		}
+	}
+
+	return Block{
+		BlockHeader:          blockHeaderToModel(b.Header),
+		CollectionGuarantees: guarantees,
+		BlockSeals:           seals,
+		Signatures:           [][]byte{b.Header.ParentVoterSig},
+	}
+}
+
+func collectionToModel(c *flow.LightCollection) Collection {
+	transactions := c.Transactions
+	if transactions == nil {
+		transactions = []flow.Identifier{}
+	}
+
+	return Collection{
+		ID:           c.ID(),
+		Transactions: transactions,
+	}
+}
+
+func transactionToModel(tx *flow.TransactionBody) Transaction {
+	arguments := make([]json.RawMessage, len(tx.Arguments))
+	for i, argument := range tx.Arguments {
+		arguments[i] = cadenceValueToModel(argument)
+	}
+
+	authorizers := make([]string, len(tx.Authorizers))
+	for i, authorizer := range tx.Authorizers {
+		authorizers[i] = authorizer.Hex()
+	}
+
+	return Transaction{
+		ID:               tx.ID(),
+		Script:           string(tx.Script),
+		Arguments:        arguments,
+		ReferenceBlockID: tx.ReferenceBlockID,
+		GasLimit:         tx.GasLimit,
+		Payer:            tx.Payer.Hex(),
+		ProposalKey: ProposalKey{
+			Address:        tx.ProposalKey.Address.Hex(),
+			KeyIndex:       tx.ProposalKey.KeyIndex,
+			SequenceNumber: tx.ProposalKey.SequenceNumber,
+		},
+		Authorizers:        authorizers,
+		PayloadSignatures:  signaturesToModel(tx.PayloadSignatures),
+		EnvelopeSignatures: signaturesToModel(tx.EnvelopeSignatures),
+	}
+}
+
+func signaturesToModel(signatures []flow.TransactionSignature) []TransactionSignature {
+	models := make([]TransactionSignature, len(signatures))
+	for i, s := range signatures {
+		models[i] = TransactionSignature{
+			Address:   s.Address.Hex(),
+			KeyIndex:  s.KeyIndex,
+			Signature: s.Signature,
+		}
+	}
+	return models
+}
+
+// modelToTransaction converts a transaction submitted by a client. The addresses are validated for
+// the given chain, all other validation is left to the API.
+func modelToTransaction(m Transaction, chain flow.Chain) (*flow.TransactionBody, error) {
+	payer, err := parseAddress(m.Payer, chain)
+	if err != nil {
+		return nil, fmt.Errorf("invalid payer: %w", err)
+	}
+
+	proposer, err := parseAddress(m.ProposalKey.Address, chain)
+	if err != nil {
+		return nil, fmt.Errorf("invalid proposal key: %w", err)
+	}
+
+	tx := flow.NewTransactionBody().
+		SetScript([]byte(m.Script)).
+		SetReferenceBlockID(m.ReferenceBlockID).
+		SetGasLimit(m.GasLimit).
+		SetPayer(payer).
+		SetProposalKey(proposer, m.ProposalKey.KeyIndex, m.ProposalKey.SequenceNumber)
+
+	for _, argument := range m.Arguments {
+		tx.AddArgument(argument)
+	}
+
+	for _, a := range m.Authorizers {
+		authorizer, err := parseAddress(a, chain)
+		if err != nil {
+			return nil, fmt.Errorf("invalid authorizer: %w", err)
+		}
+		tx.AddAuthorizer(authorizer)
+	}
+
+	for _, s := range m.PayloadSignatures {
+		address, err := parseAddress(s.Address, chain)
+		if err != nil {
+			return nil, fmt.Errorf("invalid payload signature: %w", err)
+		}
+		tx.AddPayloadSignature(address, s.KeyIndex, s.Signature)
+	}
+
+	for _, s := range m.EnvelopeSignatures {
+		address, err := parseAddress(s.Address, chain)
+		if err != nil {
+			return nil, fmt.Errorf("invalid envelope signature: %w", err)
+		}
+		tx.AddEnvelopeSignature(address, s.KeyIndex, s.Signature)
+	}
+
+	return tx, nil
+}
+
+func eventsToModel(events []flow.Event) []Event {
+	models := make([]Event, len(events))
+	for i, e := range events {
+		models[i] = Event{
+			Type:             e.Type,
+			TransactionID:    e.TransactionID,
+			TransactionIndex: e.TransactionIndex,
+			EventIndex:       e.EventIndex,
+			Payload:          cadenceValueToModel(e.Payload),
+		}
+	}
+	return models
+}
+
+func blockEventsToModel(blockEvents []flow.BlockEvents) []BlockEvents {
+	models := make([]BlockEvents, len(blockEvents))
+	for i, b := range blockEvents {
+		models[i] = BlockEvents{
+			BlockID:        b.BlockID,
+			BlockHeight:    b.BlockHeight,
+			BlockTimestamp: b.BlockTimestamp,
+			Events:         eventsToModel(b.Events),
+		}
+	}
+	return models
+}
+
+func transactionResultToModel(result *access.TransactionResult) TransactionResult {
+	return TransactionResult{
+		BlockID:      result.BlockID,
+		Status:       transactionStatuses[result.Status],
+		StatusCode:   result.StatusCode,
+		ErrorMessage: result.ErrorMessage,
+		Events:       eventsToModel(result.Events),
+	}
+}
+
+func dryRunResultToModel(result *access.DryRunResult) DryRunResult {
+	deltas := make([]StorageDelta, len(result.StorageDeltas))
+	for i, d := range result.StorageDeltas {
+		deltas[i] = StorageDelta{
+			Address: d.Address.Hex(),
+			Delta:   d.Delta,
+		}
+	}
+
+	return DryRunResult{
+		BlockID:         result.BlockID,
+		ComputationUsed: result.ComputationUsed,
+		Events:          eventsToModel(result.Events),
+		StorageDeltas:   deltas,
+		FeeEstimate:     result.FeeEstimate,
+		ErrorCode:       result.ErrorCode,
+		ErrorMessage:    result.ErrorMessage,
+	}
+}
+
+func accountKeyToModel(k flow.AccountPublicKey) AccountKey {
+	return AccountKey{
+		Index:          k.Index,
+		PublicKey:      k.PublicKey.Encode(),
+		SigningAlgo:    k.SignAlgo.String(),
+		HashingAlgo:    k.HashAlgo.String(),
+		SequenceNumber: k.SeqNumber,
+		Weight:         k.Weight,
+		Revoked:        k.Revoked,
+	}
+}
+
+func accountKeysToModel(keys []flow.AccountPublicKey) []AccountKey {
+	models := make([]AccountKey, len(keys))
+	for i, k := range keys {
+		models[i] = accountKeyToModel(k)
+	}
+	return models
+}
+
+func accountToModel(a *flow.Account) Account {
+	keys := accountKeysToModel(a.Keys)
+
+	contracts := make(map[string]string, len(a.Contracts))
+	for name, code := range a.Contracts {
+		contracts[name] = string(code)
+	}
+
+	return Account{
+		Address:   a.Address.Hex(),
+		Balance:   a.Balance,
+		Keys:      keys,
+		Contracts: contracts,
+	}
+}
+
+// cadenceValueToModel embeds a JSON-Cadence encoded value. Values that are not valid JSON, which
+// may be the case for arguments of transactions sent by other means, are embedded as base64 strings.
+func cadenceValueToModel(value []byte) json.RawMessage {
+	if len(value) > 0 && json.Valid(value) {
+		return value
+	}
+
+	encoded, _ := json.Marshal(value)
+	return encoded
+}
@@ -0,0 +1,251 @@
+package rest
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/rs/zerolog"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/onflow/flow-go/access"
+	"github.com/onflow/flow-go/model/flow"
+)
+
+// maxRequestSize is the maximum size of a request body, large enough for any transaction or script.
+const maxRequestSize = 4 << 20
+
+// maxRequestCost caps the quota cost of a single request, any request this expensive exceeds every sensible quota.
+const maxRequestCost = 1 << 30
+
+// apiHandlerFunc handles a request and returns the model that is encoded as the JSON response.
+type apiHandlerFunc func(r *request) (interface{}, error)
+
+// costFunc returns the quota cost of a request.
+type costFunc func(r *request) int
+
+// RateLimiter limits the requests of each client.
+type RateLimiter interface {
+	// Allow returns an error with the ResourceExhausted code if the client sending the request exceeded its limits
+	// for the given method, or its quota for a request of the given cost.
+	Allow(r *http.Request, method string, cost int) error
+}
+
+// route maps requests with the given method and path to a handler. Path segments in braces,
+// e.g. {id}, match any value and are passed to the handler as path variables. Requests are rate
+// limited under the name of the route, and charged the cost of the route to the quota of the client.
+type route struct {
+	name    string
+	method  string
+	path    []string
+	handler apiHandlerFunc
+	cost    costFunc // nil if the requests of the route are not charged to the quota
+}
+
+// NewServer returns an HTTP server that serves the Access API as a REST API with JSON encoding,
+// backed by the given API implementation. The requests are rate limited by the given limiter,
+// unless it is nil.
+func NewServer(api access.API, address string, chain flow.Chain, limiter RateLimiter, log zerolog.Logger) *http.Server {
+	h := NewHandler(api, chain)
+
+	routes := []route{
+		newRoute("GetLatestBlock", http.MethodGet, "/v1/blocks/latest", h.GetLatestBlock, nil),
+		newRoute("GetBlockByID", http.MethodGet, "/v1/blocks/{id}", h.GetBlockByID, nil),
+		newRoute("GetBlockByHeight", http.MethodGet, "/v1/blocks", h.GetBlockByHeight, nil),
+		newRoute("GetCollectionByID", http.MethodGet, "/v1/collections/{id}", h.GetCollectionByID, nil),
+		newRoute("SendTransaction", http.MethodPost, "/v1/transactions", h.SendTransaction, nil),
+		newRoute("DryRunTransaction", http.MethodPost, "/v1/transactions/dry_run", h.DryRunTransaction, unitCost),
+		newRoute("GetTransaction", http.MethodGet, "/v1/transactions/{id}", h.GetTransaction, nil),
+		newRoute("GetTransactionResult", http.MethodGet, "/v1/transaction_results/{id}", h.GetTransactionResult, nil),
+		newRoute("GetAccount", http.MethodGet, "/v1/accounts/{address}", h.GetAccount, nil),
+		newRoute("GetAccountKeyAtIndex", http.MethodGet, "/v1/accounts/{address}/keys/{index}", h.GetAccountKeyAtIndex, nil),
+		newRoute("ListAccountKeys", http.MethodGet, "/v1/accounts/{address}/keys", h.ListAccountKeys, nil),
+		newRoute("GetAccountContractNames", http.MethodGet, "/v1/accounts/{address}/contracts", h.GetAccountContractNames, nil),
+		newRoute("GetAccountContract", http.MethodGet, "/v1/accounts/{address}/contracts/{name}", h.GetAccountContract, nil),
+		newRoute("GetAccountStorageUsed", http.MethodGet, "/v1/accounts/{address}/storage_used", h.GetAccountStorageUsed, nil),
+		newRoute("ExecuteScript", http.MethodPost, "/v1/scripts", h.ExecuteScript, unitCost),
+		newRoute("GetEvents", http.MethodGet, "/v1/events", h.GetEvents, eventsCost),
+	}
+
+	return &http.Server{
+		Addr:    address,
+		Handler: newRouter(routes, limiter, log.With().Str("component", "rest").Logger()),
+	}
+}
+
+func newRoute(name string, method string, path string, handler apiHandlerFunc, cost costFunc) route {
+	return route{
+		name:    name,
+		method:  method,
+		path:    strings.Split(strings.Trim(path, "/"), "/"),
+		handler: handler,
+		cost:    cost,
+	}
+}
+
+// unitCost is the cost of requests executing a single script or transaction.
+func unitCost(*request) int {
+	return 1
+}
+
+// eventsCost is the cost of an events request, which is the number of blocks it covers. Malformed
+// requests cost one unit, they are rejected by the handler.
+func eventsCost(r *request) int {
+	query := r.URL.Query()
+	if rawIDs := query.Get("block_ids"); rawIDs != "" {
+		return len(strings.Split(rawIDs, ","))
+	}
+
+	startHeight, err := strconv.ParseUint(query.Get("start_height"), 10, 64)
+	if err != nil {
+		return 1
+	}
+	endHeight, err := strconv.ParseUint(query.Get("end_height"), 10, 64)
+	if err != nil || endHeight < startHeight {
+		return 1
+	}
+
+	span := endHeight - startHeight + 1
+	if span > maxRequestCost {
+		return maxRequestCost
+	}
+	return int(span)
+}
+
+// match returns the path variables if the route matches the given path segments.
+func (r route) match(method string, segments []string) (map[string]string, bool) {
+	if r.method != method || len(r.path) != len(segments) {
+		return nil, false
+	}
+
+	vars := make(map[string]string)
+	for i, p := range r.path {
+		if strings.HasPrefix(p, "{") && strings.HasSuffix(p, "}") {
+			vars[strings.Trim(p, "{}")] = segments[i]
+			continue
+		}
+		if p != segments[i] {
+			return nil, false
+		}
+	}
+
+	return vars, true
+}
+
+type router struct {
+	routes  []route
+	limiter RateLimiter // nil if requests are not rate limited
+	log     zerolog.Logger
+}
+
+func newRouter(routes []route, limiter RateLimiter, log zerolog.Logger) *router {
+	return &router{
+		routes:  routes,
+		limiter: limiter,
+		log:     log,
+	}
+}
+
+func (rt *router) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+	w.Header().Set("Access-Control-Allow-Methods", "GET, POST, OPTIONS")
+	w.Header().Set("Access-Control-Allow-Headers", "*")
+
+	if r.Method == http.MethodOptions {
+		return
+	}
+
+	segments := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	for _, route := range rt.routes {
+		vars, ok := route.match(r.Method, segments)
+		if !ok {
+			continue
+		}
+
+		r.Body = http.MaxBytesReader(w, r.Body, maxRequestSize)
+		req := &request{Request: r, vars: vars}
+
+		if rt.limiter != nil {
+			cost := 0
+			if route.cost != nil {
+				cost = route.cost(req)
+			}
+			err := rt.limiter.Allow(r, route.name, cost)
+			if err != nil {
+				rt.writeError(w, r, err)
+				return
+			}
+		}
+
+		resp, err := route.handler(req)
+		if err != nil {
+			rt.writeError(w, r, err)
+			return
+		}
+		rt.writeJSON(w, http.StatusOK, resp)
+		return
+	}
+
+	rt.writeJSON(w, http.StatusNotFound, Error{
+		Code:    http.StatusNotFound,
+		Message: "no such endpoint",
+	})
+}
+
+// writeError writes the error response for the given error. Errors returned by the API carry a gRPC
+// status code, which is mapped to the corresponding HTTP status code.
+func (rt *router) writeError(w http.ResponseWriter, r *http.Request, err error) {
+	code := httpStatusCode(status.Code(err))
+	message := err.Error()
+	if s, ok := status.FromError(err); ok {
+		message = s.Message()
+	}
+
+	if code == http.StatusInternalServerError {
+		rt.log.Error().Err(err).Str("method", r.Method).Str("path", r.URL.Path).Msg("request failed")
+	}
+
+	rt.writeJSON(w, code, Error{
+		Code:    code,
+		Message: message,
+	})
+}
+
+func (rt *router) writeJSON(w http.ResponseWriter, code int, resp interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+
+	err := json.NewEncoder(w).Encode(resp)
+	if err != nil {
+		rt.log.Error().Err(err).Msg("failed to write response")
+	}
+}
+
+func httpStatusCode(code codes.Code) int {
+	switch code {
+	case codes.InvalidArgument, codes.OutOfRange, codes.FailedPrecondition:
+		return http.StatusBadRequest
+	case codes.NotFound:
+		return http.StatusNotFound
+	case codes.AlreadyExists:
+		return http.StatusConflict
+	case codes.PermissionDenied:
+		return http.StatusForbidden
+	case codes.Unauthenticated:
+		return http.StatusUnauthorized
+	case codes.ResourceExhausted:
+		return http.StatusTooManyRequests
+	case codes.Unimplemented:
+		return http.StatusNotImplemented
+	case codes.Unavailable:
+		return http.StatusServiceUnavailable
+	case codes.DeadlineExceeded:
+		return http.StatusGatewayTimeout
+	case codes.Canceled:
+		return http.StatusRequestTimeout
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -26,6 +26,9 @@ const maxExecutionNodesCnt = 3
 // DefaultMaxHeightRange is the default maximum size of range requests.
 const DefaultMaxHeightRange = 250
 
+// MaxBatchSize is the maximum number of accounts or scripts of a batch request.
+const MaxBatchSize = 500
+
 var preferredENIdentifiers flow.IdentifierList
 var fixedENIdentifiers flow.IdentifierList
 
@@ -39,6 +42,7 @@ var fixedENIdentifiers flow.IdentifierList
 // Block details related calls are handled by backendBlockDetails.
 // Event related calls are handled by backendEvents.
 // Account related calls are handled by backendAccounts.
+// Streaming calls are handled by backendStream.
 //
 // All remaining calls are handled by the base Backend in this file.
 type Backend struct {
@@ -48,6 +52,7 @@ type Backend struct {
 	backendBlockHeaders
 	backendBlockDetails
 	backendAccounts
+	backendStream
 
 	executionRPC      execproto.ExecutionAPIClient
 	state             protocol.State
@@ -74,6 +79,8 @@ func New(
 	maxHeightRange uint,
 	preferredExecutionNodeIDs []string,
 	fixedExecutionNodeIDs []string,
+	localIndex *LocalIndex,
+	scriptExecutor *ScriptExecutor,
 	log zerolog.Logger,
 ) *Backend {
 	retry := newRetry()
@@ -91,6 +98,7 @@ func New(
 			staticExecutionRPC: executionRPC,
 			connFactory:        connFactory,
 			state:              state,
+			scriptExecutor:     scriptExecutor,
 			log:                log,
 		},
 		backendTransactions: backendTransactions{
@@ -107,6 +115,7 @@ func New(
 			retry:                retry,
 			connFactory:          connFactory,
 			previousAccessNodes:  historicalAccessNodes,
+			index:                localIndex,
 			log:                  log,
 		},
 		backendEvents: backendEvents{
@@ -117,6 +126,7 @@ func New(
 			connFactory:        connFactory,
 			log:                log,
 			maxHeightRange:     maxHeightRange,
+			index:              localIndex,
 		},
 		backendBlockHeaders: backendBlockHeaders{
 			headers: headers,
@@ -134,6 +144,12 @@ func New(
 			connFactory:        connFactory,
 			log:                log,
 		},
+		backendStream: backendStream{
+			headers:  headers,
+			state:    state,
+			notifier: newHeightNotifier(),
+			log:      log,
+		},
 		collections:       collections,
 		executionReceipts: executionReceipts,
 		connFactory:       connFactory,
@@ -142,6 +158,11 @@ func New(
 
 	retry.SetBackend(b)
 
+	// the stream backend retrieves events and transaction results the same way the
+	// events and transactions backends do
+	b.backendStream.events = &b.backendEvents
+	b.backendStream.transactions = &b.backendTransactions
+
 	var err error
 	preferredENIdentifiers, err = identifierList(preferredExecutionNodeIDs)
 	if err != nil {
@@ -156,6 +177,12 @@ func New(
 	return b
 }
 
+// NotifyFinalizedBlockHeight is called by the ingestion engine for each newly finalized block.
+func (b *Backend) NotifyFinalizedBlockHeight(height uint64) {
+	b.backendTransactions.NotifyFinalizedBlockHeight(height)
+	b.backendStream.NotifyFinalizedBlockHeight(height)
+}
+
 func identifierList(ids []string) (flow.IdentifierList, error) {
 	idList := make(flow.IdentifierList, len(ids))
 	for i, idStr := range ids {
@@ -2,6 +2,7 @@ package backend
 
 import (
 	"context"
+	"fmt"
 	"time"
 
 	"github.com/hashicorp/go-multierror"
@@ -10,7 +11,9 @@ import (
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 
+	"github.com/onflow/flow-go/access"
 	"github.com/onflow/flow-go/engine/common/rpc/convert"
+	exeapi "github.com/onflow/flow-go/engine/execution"
 	"github.com/onflow/flow-go/model/flow"
 	"github.com/onflow/flow-go/state/protocol"
 	"github.com/onflow/flow-go/storage"
@@ -71,6 +74,125 @@ func (b *backendAccounts) GetAccountAtBlockHeight(
 	return account, nil
 }
 
+// GetAccountsAtBlockHeight looks up a batch of accounts at the given height. The batch is forwarded as a single
+// request to one execution node, which reads all accounts from the same view of the execution state. Accounts
+// that could not be looked up are reported in their result and do not fail the whole batch.
+func (b *backendAccounts) GetAccountsAtBlockHeight(
+	ctx context.Context,
+	addresses []flow.Address,
+	height uint64,
+) ([]access.AccountResult, error) {
+	if len(addresses) == 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "no addresses given")
+	}
+	if len(addresses) > MaxBatchSize {
+		return nil, status.Errorf(codes.InvalidArgument, "requested batch size (%d) exceeded maximum (%d)", len(addresses), MaxBatchSize)
+	}
+
+	// get header at given height
+	header, err := b.headers.ByHeight(height)
+	if err != nil {
+		err = convertStorageError(err)
+		return nil, err
+	}
+
+	return b.getAccountsAtBlockID(ctx, addresses, header.ID())
+}
+
+func (b *backendAccounts) getAccountsAtBlockID(
+	ctx context.Context,
+	addresses []flow.Address,
+	blockID flow.Identifier,
+) ([]access.AccountResult, error) {
+
+	execNodes, err := executionNodesForBlockID(blockID, b.executionReceipts, b.state, b.log)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get accounts from the execution node: %v", err)
+	}
+
+	// the static execution node is not known to serve batch requests, so it is asked for each account separately
+	if len(execNodes) == 0 {
+		results := make([]access.AccountResult, len(addresses))
+		for i, address := range addresses {
+			account, err := b.getAccountAtBlockID(ctx, address, blockID)
+			if err != nil {
+				if status.Code(err) != codes.NotFound {
+					return nil, err
+				}
+				results[i] = access.AccountResult{ErrorMessage: err.Error()}
+				continue
+			}
+			results[i] = access.AccountResult{Account: account}
+		}
+		return results, nil
+	}
+
+	exeReq := exeapi.GetAccountsAtBlockIDRequest{
+		BlockId:   blockID[:],
+		Addresses: make([][]byte, len(addresses)),
+	}
+	for i, address := range addresses {
+		exeReq.Addresses[i] = address.Bytes()
+	}
+
+	var errors *multierror.Error
+	for _, execNode := range execNodes {
+		start := time.Now()
+
+		resp, err := b.tryGetAccounts(ctx, execNode, exeReq)
+		duration := time.Since(start)
+		if err != nil {
+			b.log.Error().
+				Str("execution_node", execNode.String()).
+				Hex("block_id", blockID[:]).
+				Int("accounts", len(addresses)).
+				Int64("rtt_ms", duration.Milliseconds()).
+				Err(err).
+				Msg("failed to execute GetAccounts")
+			errors = multierror.Append(errors, err)
+			continue
+		}
+
+		b.log.Debug().
+			Str("execution_node", execNode.String()).
+			Hex("block_id", blockID[:]).
+			Int("accounts", len(addresses)).
+			Int64("rtt_ms", duration.Milliseconds()).
+			Msg("Successfully got accounts info")
+
+		if len(resp.GetResults()) != len(addresses) {
+			return nil, status.Errorf(codes.Internal, "execution node returned %d results for %d accounts", len(resp.GetResults()), len(addresses))
+		}
+
+		results := make([]access.AccountResult, len(addresses))
+		for i, result := range resp.GetResults() {
+			if result.GetErrorMessage() != "" {
+				results[i] = access.AccountResult{ErrorMessage: result.GetErrorMessage()}
+				continue
+			}
+
+			account, err := convert.MessageToAccount(result.GetAccount())
+			if err != nil {
+				return nil, status.Errorf(codes.Internal, "failed to convert account message: %v", err)
+			}
+			results[i] = access.AccountResult{Account: account}
+		}
+
+		return results, nil
+	}
+
+	return nil, status.Errorf(codes.Internal, "failed to get accounts from the execution node: %v", errors.ErrorOrNil())
+}
+
+func (b *backendAccounts) tryGetAccounts(ctx context.Context, execNode *flow.Identity, req exeapi.GetAccountsAtBlockIDRequest) (*exeapi.AccountsResponse, error) {
+	execRPCClient, closer, err := b.connFactory.GetExecutionBatchAPIClient(execNode.Address)
+	if err != nil {
+		return nil, err
+	}
+	defer closer.Close()
+	return execRPCClient.GetAccountsAtBlockID(ctx, &req)
+}
+
 func (b *backendAccounts) getAccountAtBlockID(
 	ctx context.Context,
 	address flow.Address,
@@ -175,3 +297,209 @@ func (b *backendAccounts) tryGetAccount(ctx context.Context, execNode *flow.Iden
 	}
 	return resp, nil
 }
+
+// GetAccountKeyAtIndex returns the key with the given index of the account at the given height.
+func (b *backendAccounts) GetAccountKeyAtIndex(
+	ctx context.Context,
+	address flow.Address,
+	keyIndex uint64,
+	height uint64,
+) (*flow.AccountPublicKey, error) {
+	req := &exeapi.GetAccountKeyAtIndexRequest{
+		Address: address.Bytes(),
+		Index:   keyIndex,
+	}
+
+	var resp *exeapi.AccountKeyResponse
+	err := b.lookupAccount(height, func(client exeapi.ExecutionAccountAPIClient, blockID flow.Identifier) error {
+		req.BlockId = blockID[:]
+		var err error
+		resp, err = client.GetAccountKeyAtIndex(ctx, req)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := convert.MessageToAccountKey(resp.GetAccountKey())
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to convert account key: %v", err)
+	}
+
+	return key, nil
+}
+
+// ListAccountKeys returns up to limit keys of the account at the given height, starting with the key at index
+// offset, and the total number of keys of the account.
+func (b *backendAccounts) ListAccountKeys(
+	ctx context.Context,
+	address flow.Address,
+	offset uint64,
+	limit uint64,
+	height uint64,
+) ([]flow.AccountPublicKey, uint64, error) {
+	if limit == 0 || limit > exeapi.MaxAccountKeysPageSize {
+		return nil, 0, status.Errorf(codes.InvalidArgument, "limit must be between 1 and %d", exeapi.MaxAccountKeysPageSize)
+	}
+
+	req := &exeapi.ListAccountKeysRequest{
+		Address: address.Bytes(),
+		Offset:  offset,
+		Limit:   limit,
+	}
+
+	var resp *exeapi.AccountKeysResponse
+	err := b.lookupAccount(height, func(client exeapi.ExecutionAccountAPIClient, blockID flow.Identifier) error {
+		req.BlockId = blockID[:]
+		var err error
+		resp, err = client.ListAccountKeys(ctx, req)
+		return err
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+
+	keys := make([]flow.AccountPublicKey, len(resp.GetAccountKeys()))
+	for i, message := range resp.GetAccountKeys() {
+		key, err := convert.MessageToAccountKey(message)
+		if err != nil {
+			return nil, 0, status.Errorf(codes.Internal, "failed to convert account key: %v", err)
+		}
+		keys[i] = *key
+	}
+
+	return keys, resp.GetTotalCount(), nil
+}
+
+// GetAccountContractNames returns the sorted names of the contracts deployed to the account at the given height.
+func (b *backendAccounts) GetAccountContractNames(
+	ctx context.Context,
+	address flow.Address,
+	height uint64,
+) ([]string, error) {
+	req := &exeapi.GetAccountContractNamesRequest{
+		Address: address.Bytes(),
+	}
+
+	var resp *exeapi.AccountContractNamesResponse
+	err := b.lookupAccount(height, func(client exeapi.ExecutionAccountAPIClient, blockID flow.Identifier) error {
+		req.BlockId = blockID[:]
+		var err error
+		resp, err = client.GetAccountContractNames(ctx, req)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.GetNames(), nil
+}
+
+// GetAccountContract returns the code of the contract with the given name deployed to the account at the given height.
+func (b *backendAccounts) GetAccountContract(
+	ctx context.Context,
+	address flow.Address,
+	name string,
+	height uint64,
+) ([]byte, error) {
+	req := &exeapi.GetAccountContractRequest{
+		Address: address.Bytes(),
+		Name:    name,
+	}
+
+	var resp *exeapi.AccountContractResponse
+	err := b.lookupAccount(height, func(client exeapi.ExecutionAccountAPIClient, blockID flow.Identifier) error {
+		req.BlockId = blockID[:]
+		var err error
+		resp, err = client.GetAccountContract(ctx, req)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.GetCode(), nil
+}
+
+// GetAccountStorageUsed returns the storage used by the account at the given height, in bytes.
+func (b *backendAccounts) GetAccountStorageUsed(
+	ctx context.Context,
+	address flow.Address,
+	height uint64,
+) (uint64, error) {
+	req := &exeapi.GetAccountStorageUsedRequest{
+		Address: address.Bytes(),
+	}
+
+	var resp *exeapi.AccountStorageUsedResponse
+	err := b.lookupAccount(height, func(client exeapi.ExecutionAccountAPIClient, blockID flow.Identifier) error {
+		req.BlockId = blockID[:]
+		var err error
+		resp, err = client.GetAccountStorageUsed(ctx, req)
+		return err
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return resp.GetStorageUsed(), nil
+}
+
+// lookupAccount calls the account API of the execution nodes that executed the block at the given height in turn,
+// until one of them succeeds or reports that the requested data does not exist.
+func (b *backendAccounts) lookupAccount(
+	height uint64,
+	call func(client exeapi.ExecutionAccountAPIClient, blockID flow.Identifier) error,
+) error {
+	header, err := b.headers.ByHeight(height)
+	if err != nil {
+		return convertStorageError(err)
+	}
+	id := header.ID()
+
+	execNodes, err := executionNodesForBlockID(id, b.executionReceipts, b.state, b.log)
+	if err != nil {
+		return status.Errorf(codes.Internal, "failed to get account from the execution node: %v", err)
+	}
+	if len(execNodes) == 0 {
+		return status.Errorf(codes.Unavailable, "no execution node found for block %s", id)
+	}
+
+	var errors *multierror.Error
+	for _, execNode := range execNodes {
+		err := b.tryLookupAccount(execNode, id, call)
+		if err == nil {
+			return nil
+		}
+
+		// the account, key or contract does not exist, the other execution nodes would report the same
+		if code := status.Code(err); code == codes.NotFound || code == codes.InvalidArgument {
+			return err
+		}
+
+		errors = multierror.Append(errors, err)
+	}
+
+	return status.Errorf(codes.Internal, "failed to get account from the execution node: %v", errors.ErrorOrNil())
+}
+
+func (b *backendAccounts) tryLookupAccount(
+	execNode *flow.Identity,
+	blockID flow.Identifier,
+	call func(client exeapi.ExecutionAccountAPIClient, blockID flow.Identifier) error,
+) error {
+	execRPCClient, closer, err := b.connFactory.GetExecutionAccountAPIClient(execNode.Address)
+	if err != nil {
+		return fmt.Errorf("failed to connect to the execution node %s: %w", execNode.String(), err)
+	}
+	defer closer.Close()
+
+	err = call(execRPCClient, blockID)
+	if err != nil {
+		if code := status.Code(err); code == codes.NotFound || code == codes.InvalidArgument {
+			return err
+		}
+		return fmt.Errorf("failed to get account from the execution node %s: %w", execNode.String(), err)
+	}
+	return nil
+}
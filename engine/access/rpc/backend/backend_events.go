@@ -26,6 +26,7 @@ type backendEvents struct {
 	connFactory        ConnectionFactory
 	log                zerolog.Logger
 	maxHeightRange     uint
+	index              *LocalIndex
 }
 
 // GetEventsForHeightRange retrieves events for all sealed blocks between the start block height and
@@ -74,7 +75,7 @@ func (b *backendEvents) GetEventsForHeightRange(
 		blockHeaders = append(blockHeaders, header)
 	}
 
-	return b.getBlockEventsFromExecutionNode(ctx, blockHeaders, eventType)
+	return b.getBlockEvents(ctx, blockHeaders, eventType)
 }
 
 // GetEventsForBlockIDs retrieves events for all the specified block IDs that have the given type
@@ -100,7 +101,67 @@ func (b *backendEvents) GetEventsForBlockIDs(
 	}
 
 	// forward the request to the execution node
-	return b.getBlockEventsFromExecutionNode(ctx, blockHeaders, eventType)
+	return b.getBlockEvents(ctx, blockHeaders, eventType)
+}
+
+// getBlockEvents returns the events of the given type for the given blocks. The events of blocks that
+// are indexed locally are read from the local index, the events of all other blocks are retrieved from
+// an execution node.
+func (b *backendEvents) getBlockEvents(
+	ctx context.Context,
+	blockHeaders []*flow.Header,
+	eventType string,
+) ([]flow.BlockEvents, error) {
+
+	results := make([]flow.BlockEvents, len(blockHeaders))
+
+	// the blocks that are not indexed locally and their position in the results
+	var missingHeaders []*flow.Header
+	var missingPositions []int
+
+	for i, header := range blockHeaders {
+		indexed, err := b.index.Indexed(header)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to get events: %v", err)
+		}
+		if !indexed {
+			missingHeaders = append(missingHeaders, header)
+			missingPositions = append(missingPositions, i)
+			continue
+		}
+
+		blockID := header.ID()
+		events, err := b.index.EventsByBlockIDEventType(blockID, flow.EventType(eventType))
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to get events from local index: %v", err)
+		}
+
+		results[i] = flow.BlockEvents{
+			BlockID:        blockID,
+			BlockHeight:    header.Height,
+			BlockTimestamp: header.Timestamp,
+			Events:         events,
+		}
+	}
+
+	if len(missingHeaders) == 0 {
+		return results, nil
+	}
+
+	missingResults, err := b.getBlockEventsFromExecutionNode(ctx, missingHeaders, eventType)
+	if err != nil {
+		return nil, err
+	}
+
+	missingByID := make(map[flow.Identifier]flow.BlockEvents, len(missingResults))
+	for _, result := range missingResults {
+		missingByID[result.BlockID] = result
+	}
+	for i, header := range missingHeaders {
+		results[missingPositions[i]] = missingByID[header.ID()]
+	}
+
+	return results, nil
 }
 
 func (b *backendEvents) getBlockEventsFromExecutionNode(
@@ -2,6 +2,7 @@ package backend
 
 import (
 	"context"
+	"errors"
 
 	"github.com/hashicorp/go-multierror"
 	execproto "github.com/onflow/flow/protobuf/go/flow/execution"
@@ -9,6 +10,8 @@ import (
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 
+	"github.com/onflow/flow-go/access"
+	exeapi "github.com/onflow/flow-go/engine/execution"
 	"github.com/onflow/flow-go/model/flow"
 	"github.com/onflow/flow-go/state/protocol"
 	"github.com/onflow/flow-go/storage"
@@ -20,6 +23,7 @@ type backendScripts struct {
 	state              protocol.State
 	staticExecutionRPC execproto.ExecutionAPIClient
 	connFactory        ConnectionFactory
+	scriptExecutor     *ScriptExecutor // executes scripts locally, nil if local script execution is disabled
 	log                zerolog.Logger
 }
 
@@ -38,8 +42,8 @@ func (b *backendScripts) ExecuteScriptAtLatestBlock(
 	// get the block id of the latest sealed header
 	latestBlockID := latestHeader.ID()
 
-	// execute script on the execution node at that block id
-	return b.executeScriptOnExecutionNode(ctx, latestBlockID, script, arguments)
+	// execute script at that block id
+	return b.executeScript(ctx, latestBlockID, script, arguments)
 }
 
 func (b *backendScripts) ExecuteScriptAtBlockID(
@@ -48,8 +52,8 @@ func (b *backendScripts) ExecuteScriptAtBlockID(
 	script []byte,
 	arguments [][]byte,
 ) ([]byte, error) {
-	// execute script on the execution node at that block id
-	return b.executeScriptOnExecutionNode(ctx, blockID, script, arguments)
+	// execute script at that block id
+	return b.executeScript(ctx, blockID, script, arguments)
 }
 
 func (b *backendScripts) ExecuteScriptAtBlockHeight(
@@ -67,7 +71,124 @@ func (b *backendScripts) ExecuteScriptAtBlockHeight(
 
 	blockID := header.ID()
 
-	// execute script on the execution node at that block id
+	// execute script at that block id
+	return b.executeScript(ctx, blockID, script, arguments)
+}
+
+// ExecuteScriptsAtBlockID executes a batch of scripts at the given block. The batch is forwarded as a single request
+// to one execution node, which executes all scripts against the same view of the execution state. Scripts that
+// failed are reported in their result and do not fail the whole batch.
+func (b *backendScripts) ExecuteScriptsAtBlockID(
+	ctx context.Context,
+	blockID flow.Identifier,
+	scripts []access.Script,
+) ([]access.ScriptResult, error) {
+	if len(scripts) == 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "no scripts given")
+	}
+	if len(scripts) > MaxBatchSize {
+		return nil, status.Errorf(codes.InvalidArgument, "requested batch size (%d) exceeded maximum (%d)", len(scripts), MaxBatchSize)
+	}
+
+	execNodes, err := executionNodesForBlockID(blockID, b.executionReceipts, b.state, b.log)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to execute the scripts on the execution node: %v", err)
+	}
+
+	// the static execution node is not known to serve batch requests, so it is sent each script separately
+	if len(execNodes) == 0 {
+		results := make([]access.ScriptResult, len(scripts))
+		for i, script := range scripts {
+			value, err := b.executeScriptOnExecutionNode(ctx, blockID, script.Code, script.Arguments)
+			if err != nil {
+				results[i] = access.ScriptResult{ErrorMessage: err.Error()}
+				continue
+			}
+			results[i] = access.ScriptResult{Value: value}
+		}
+		return results, nil
+	}
+
+	execReq := exeapi.ExecuteScriptsAtBlockIDRequest{
+		BlockId: blockID[:],
+		Scripts: make([]*exeapi.Script, len(scripts)),
+	}
+	for i, script := range scripts {
+		execReq.Scripts[i] = &exeapi.Script{
+			Script:    script.Code,
+			Arguments: script.Arguments,
+		}
+	}
+
+	// try each of the execution nodes found
+	var errors *multierror.Error
+	for _, execNode := range execNodes {
+		resp, err := b.tryExecuteScripts(ctx, execNode, execReq)
+		if err != nil {
+			errors = multierror.Append(errors, err)
+			continue
+		}
+
+		b.log.Debug().
+			Str("execution_node", execNode.String()).
+			Hex("block_id", blockID[:]).
+			Int("scripts", len(scripts)).
+			Msg("Successfully executed scripts")
+
+		if len(resp.GetResults()) != len(scripts) {
+			return nil, status.Errorf(codes.Internal, "execution node returned %d results for %d scripts", len(resp.GetResults()), len(scripts))
+		}
+
+		results := make([]access.ScriptResult, len(scripts))
+		for i, result := range resp.GetResults() {
+			results[i] = access.ScriptResult{
+				Value:        result.GetValue(),
+				ErrorMessage: result.GetErrorMessage(),
+			}
+		}
+		return results, nil
+	}
+	return nil, errors.ErrorOrNil()
+}
+
+func (b *backendScripts) tryExecuteScripts(ctx context.Context, execNode *flow.Identity, req exeapi.ExecuteScriptsAtBlockIDRequest) (*exeapi.ScriptsResponse, error) {
+	execRPCClient, closer, err := b.connFactory.GetExecutionBatchAPIClient(execNode.Address)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to execute the scripts on the execution node %s: %v", execNode.String(), err)
+	}
+	defer closer.Close()
+	execResp, err := execRPCClient.ExecuteScriptsAtBlockID(ctx, &req)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to execute the scripts on the execution node %s: %v", execNode.String(), err)
+	}
+	return execResp, nil
+}
+
+// executeScript executes the script locally if local script execution is enabled, and falls back to executing
+// it on an execution node if the script cannot be executed locally.
+func (b *backendScripts) executeScript(
+	ctx context.Context,
+	blockID flow.Identifier,
+	script []byte,
+	arguments [][]byte,
+) ([]byte, error) {
+
+	if b.scriptExecutor != nil {
+		value, err := b.scriptExecutor.ExecuteScript(ctx, blockID, script, arguments)
+		if err == nil {
+			return value, nil
+		}
+
+		var scriptErr *ScriptError
+		if errors.As(err, &scriptErr) {
+			return nil, status.Errorf(codes.InvalidArgument, "failed to execute script: %v", scriptErr)
+		}
+
+		b.log.Debug().Err(err).
+			Hex("block_id", blockID[:]).
+			Msg("could not execute script locally, falling back to execution node")
+	}
+
 	return b.executeScriptOnExecutionNode(ctx, blockID, script, arguments)
 }
 
@@ -0,0 +1,321 @@
+package backend
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/rs/zerolog"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/onflow/flow-go/access"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/state/protocol"
+	"github.com/onflow/flow-go/storage"
+)
+
+// heightNotifier wakes up all subscriptions waiting for a new block height.
+type heightNotifier struct {
+	mu     sync.RWMutex
+	notify chan struct{}
+}
+
+func newHeightNotifier() *heightNotifier {
+	return &heightNotifier{
+		notify: make(chan struct{}),
+	}
+}
+
+// Channel returns a channel that is closed on the next call to Notify.
+func (n *heightNotifier) Channel() <-chan struct{} {
+	n.mu.RLock()
+	defer n.mu.RUnlock()
+	return n.notify
+}
+
+// Notify wakes up everyone waiting on a channel returned by Channel.
+func (n *heightNotifier) Notify() {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	close(n.notify)
+	n.notify = make(chan struct{})
+}
+
+// eventsBatchSize is the maximum number of sealed blocks whose events are retrieved together by an event
+// subscription. Execution nodes serve the events of a single type per request, so a subscription retrieves each
+// event type once per batch of blocks, rather than once per block.
+const eventsBatchSize = 50
+
+type backendStream struct {
+	headers      storage.Headers
+	state        protocol.State
+	events       *backendEvents
+	transactions *backendTransactions
+	notifier     *heightNotifier
+	log          zerolog.Logger
+}
+
+// SubscribeBlockHeaders streams the headers of finalized blocks starting at startHeight.
+func (b *backendStream) SubscribeBlockHeaders(
+	ctx context.Context,
+	startHeight uint64,
+	handler func(*flow.Header) error,
+) error {
+
+	next, err := b.startHeight(startHeight, b.finalizedHeight)
+	if err != nil {
+		return err
+	}
+
+	return b.stream(ctx, next, b.finalizedHeight, func(height uint64) error {
+		header, err := b.headers.ByHeight(height)
+		if err != nil {
+			return status.Errorf(codes.Internal, "failed to get block header at height %d: %v", height, err)
+		}
+
+		return handler(header)
+	})
+}
+
+// SubscribeEvents streams the events matching the filter for every sealed block starting at startHeight.
+func (b *backendStream) SubscribeEvents(
+	ctx context.Context,
+	startHeight uint64,
+	filter access.EventFilter,
+	handler func(flow.BlockEvents) error,
+) error {
+
+	if len(filter.EventTypes) == 0 {
+		return status.Error(codes.InvalidArgument, "at least one event type is required")
+	}
+
+	next, err := b.startHeight(startHeight, b.sealedHeight)
+	if err != nil {
+		return err
+	}
+
+	// the events of the blocks are retrieved a batch ahead of delivering them, the heights are delivered in
+	// order so the next height is always the first one of the batch
+	var batch []flow.BlockEvents
+	return b.stream(ctx, next, b.sealedHeight, func(height uint64) error {
+		if len(batch) == 0 {
+			batch, err = b.eventsBatch(ctx, height, filter)
+			if err != nil {
+				return err
+			}
+		}
+
+		blockEvents := batch[0]
+		batch = batch[1:]
+
+		return handler(blockEvents)
+	})
+}
+
+// SubscribeTransactionStatus streams the result of the given transaction every time its status changes.
+func (b *backendStream) SubscribeTransactionStatus(
+	ctx context.Context,
+	txID flow.Identifier,
+	handler func(*access.TransactionResult) error,
+) error {
+
+	var delivered bool
+	var lastStatus flow.TransactionStatus
+
+	for {
+		// get the notification channel before deriving the status, so that a block
+		// that arrives in the meantime is not missed
+		notified := b.notifier.Channel()
+
+		// the status is derived the same way as for clients polling the transaction result
+		result, err := b.transactions.GetTransactionResult(ctx, txID)
+		if err != nil {
+			return err
+		}
+
+		if !delivered || result.Status != lastStatus {
+			err = handler(result)
+			if err != nil {
+				return err
+			}
+			delivered = true
+			lastStatus = result.Status
+		}
+
+		// the status of a sealed or expired transaction does not change anymore
+		if result.Status == flow.TransactionStatusSealed || result.Status == flow.TransactionStatusExpired {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return status.FromContextError(ctx.Err()).Err()
+		case <-notified:
+		}
+	}
+}
+
+// NotifyFinalizedBlockHeight wakes up the subscriptions that are waiting for new blocks. Since a newly
+// finalized block may also seal blocks, both header and event subscriptions are woken up.
+func (b *backendStream) NotifyFinalizedBlockHeight(_ uint64) {
+	b.notifier.Notify()
+}
+
+// stream delivers every height starting at next, until the latest height returned by highest,
+// then waits for a notification of a new block before trying again.
+func (b *backendStream) stream(
+	ctx context.Context,
+	next uint64,
+	highest func() (uint64, error),
+	deliver func(height uint64) error,
+) error {
+	for {
+		// get the notification channel before reading the latest height, so that a block
+		// that arrives while we are delivering is not missed
+		notified := b.notifier.Channel()
+
+		latest, err := highest()
+		if err != nil {
+			return err
+		}
+
+		for ; next <= latest; next++ {
+			if ctx.Err() != nil {
+				return status.FromContextError(ctx.Err()).Err()
+			}
+
+			err = deliver(next)
+			if err != nil {
+				return err
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return status.FromContextError(ctx.Err()).Err()
+		case <-notified:
+		}
+	}
+}
+
+// startHeight validates the requested start height of a subscription. A start height of 0
+// starts the subscription at the latest available height.
+func (b *backendStream) startHeight(startHeight uint64, latest func() (uint64, error)) (uint64, error) {
+	if startHeight == 0 {
+		return latest()
+	}
+
+	root, err := b.state.Params().Root()
+	if err != nil {
+		return 0, status.Errorf(codes.Internal, "failed to get root block: %v", err)
+	}
+
+	if startHeight < root.Height {
+		return 0, status.Errorf(codes.InvalidArgument,
+			"start height %d is lower than the root block height %d", startHeight, root.Height)
+	}
+
+	return startHeight, nil
+}
+
+func (b *backendStream) finalizedHeight() (uint64, error) {
+	head, err := b.state.Final().Head()
+	if err != nil {
+		return 0, status.Errorf(codes.Internal, "failed to get latest finalized block: %v", err)
+	}
+	return head.Height, nil
+}
+
+func (b *backendStream) sealedHeight() (uint64, error) {
+	head, err := b.state.Sealed().Head()
+	if err != nil {
+		return 0, status.Errorf(codes.Internal, "failed to get latest sealed block: %v", err)
+	}
+	return head.Height, nil
+}
+
+// eventsBatch retrieves the events matching the filter for the sealed blocks starting at the given height, at most
+// eventsBatchSize of them, in the order of their heights.
+func (b *backendStream) eventsBatch(
+	ctx context.Context,
+	startHeight uint64,
+	filter access.EventFilter,
+) ([]flow.BlockEvents, error) {
+
+	sealed, err := b.sealedHeight()
+	if err != nil {
+		return nil, err
+	}
+
+	endHeight := startHeight + eventsBatchSize - 1
+	if endHeight > sealed {
+		endHeight = sealed
+	}
+
+	headers := make([]*flow.Header, 0, endHeight-startHeight+1)
+	for height := startHeight; height <= endHeight; height++ {
+		header, err := b.headers.ByHeight(height)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to get block header at height %d: %v", height, err)
+		}
+		headers = append(headers, header)
+	}
+
+	return b.blocksEvents(ctx, headers, filter)
+}
+
+// blocksEvents retrieves the events of the given blocks for each event type of the filter, with a single
+// request per event type for all the blocks, and returns the ones matching the filter for each block in the
+// order they were emitted.
+func (b *backendStream) blocksEvents(
+	ctx context.Context,
+	headers []*flow.Header,
+	filter access.EventFilter,
+) ([]flow.BlockEvents, error) {
+
+	blocksEvents := make([]flow.BlockEvents, 0, len(headers))
+	positions := make(map[flow.Identifier]int, len(headers))
+	for i, header := range headers {
+		blockID := header.ID()
+		positions[blockID] = i
+		blocksEvents = append(blocksEvents, flow.BlockEvents{
+			BlockID:        blockID,
+			BlockHeight:    header.Height,
+			BlockTimestamp: header.Timestamp,
+			Events:         []flow.Event{},
+		})
+	}
+
+	for _, eventType := range filter.EventTypes {
+		results, err := b.events.getBlockEvents(ctx, headers, string(eventType))
+		if err != nil {
+			return nil, err
+		}
+
+		for _, result := range results {
+			i, ok := positions[result.BlockID]
+			if !ok {
+				return nil, status.Errorf(codes.Internal, "unexpected events for block %x", result.BlockID)
+			}
+			for _, event := range result.Events {
+				if filter.Match(event) {
+					blocksEvents[i].Events = append(blocksEvents[i].Events, event)
+				}
+			}
+		}
+	}
+
+	for _, blockEvents := range blocksEvents {
+		events := blockEvents.Events
+		sort.Slice(events, func(i, j int) bool {
+			ei, ej := events[i], events[j]
+			if ei.TransactionIndex != ej.TransactionIndex {
+				return ei.TransactionIndex < ej.TransactionIndex
+			}
+			return ei.EventIndex < ej.EventIndex
+		})
+	}
+
+	return blocksEvents, nil
+}
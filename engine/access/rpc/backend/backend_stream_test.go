@@ -0,0 +1,374 @@
+package backend
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	execproto "github.com/onflow/flow/protobuf/go/flow/execution"
+	"github.com/stretchr/testify/mock"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	accessapi "github.com/onflow/flow-go/access"
+	"github.com/onflow/flow-go/engine/common/rpc/convert"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module/metrics"
+	protocol "github.com/onflow/flow-go/state/protocol/mock"
+	"github.com/onflow/flow-go/storage"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+// streamChain sets up a chain of headers whose finalized and sealed heads can be advanced during a test.
+type streamChain struct {
+	sync.Mutex
+	headers   map[uint64]*flow.Header
+	finalized uint64
+	sealed    uint64
+}
+
+func (suite *Suite) setupStreamChain(root uint64, count int) *streamChain {
+	chain := &streamChain{
+		headers:   make(map[uint64]*flow.Header),
+		finalized: root,
+		sealed:    root,
+	}
+
+	parent := unittest.BlockHeaderFixture()
+	parent.Height = root
+	chain.headers[root] = &parent
+	for i := 1; i < count; i++ {
+		header := unittest.BlockHeaderWithParentFixture(chain.headers[root+uint64(i)-1])
+		chain.headers[header.Height] = &header
+	}
+
+	sealedSnapshot := new(protocol.Snapshot)
+	suite.state.On("Sealed").Return(sealedSnapshot).Maybe()
+	params := new(protocol.Params)
+	params.On("Root").Return(chain.headers[root], nil).Maybe()
+	suite.state.On("Params").Return(params).Maybe()
+
+	suite.snapshot.On("Head").Return(
+		func() *flow.Header {
+			chain.Lock()
+			defer chain.Unlock()
+			return chain.headers[chain.finalized]
+		},
+		func() error { return nil },
+	).Maybe()
+	sealedSnapshot.On("Head").Return(
+		func() *flow.Header {
+			chain.Lock()
+			defer chain.Unlock()
+			return chain.headers[chain.sealed]
+		},
+		func() error { return nil },
+	).Maybe()
+
+	suite.headers.On("ByHeight", mock.Anything).Return(
+		func(height uint64) *flow.Header {
+			chain.Lock()
+			defer chain.Unlock()
+			return chain.headers[height]
+		},
+		func(height uint64) error {
+			chain.Lock()
+			defer chain.Unlock()
+			if _, ok := chain.headers[height]; !ok {
+				return storage.ErrNotFound
+			}
+			return nil
+		}).Maybe()
+
+	return chain
+}
+
+func (suite *Suite) streamBackend() *Backend {
+	return New(
+		suite.state,
+		suite.execClient,
+		nil, nil, suite.blocks, suite.headers, suite.collections, suite.transactions, suite.receipts,
+		suite.chainID,
+		metrics.NewNoopCollector(),
+		nil,
+		false,
+		DefaultMaxHeightRange,
+		nil,
+		nil,
+		nil,
+		nil,
+		suite.log,
+	)
+}
+
+func (suite *Suite) TestSubscribeBlockHeaders() {
+	chain := suite.setupStreamChain(10, 6)
+	chain.finalized = 12
+	backend := suite.streamBackend()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	received := make(chan *flow.Header, 10)
+	done := make(chan error)
+	go func() {
+		done <- backend.SubscribeBlockHeaders(ctx, 11, func(header *flow.Header) error {
+			received <- header
+			return nil
+		})
+	}()
+
+	// the already finalized blocks are delivered right away
+	for height := uint64(11); height <= 12; height++ {
+		header := <-received
+		suite.Require().Equal(chain.headers[height].ID(), header.ID())
+	}
+
+	// newly finalized blocks are delivered once the backend is notified
+	chain.Lock()
+	chain.finalized = 15
+	chain.Unlock()
+	backend.NotifyFinalizedBlockHeight(15)
+
+	for height := uint64(13); height <= 15; height++ {
+		select {
+		case header := <-received:
+			suite.Require().Equal(chain.headers[height].ID(), header.ID())
+		case <-time.After(time.Second):
+			suite.FailNow("header for height %d not delivered", height)
+		}
+	}
+
+	cancel()
+	err := <-done
+	suite.Require().Equal(codes.Canceled, status.Code(err))
+	suite.Require().Empty(received)
+}
+
+func (suite *Suite) TestSubscribeBlockHeadersBelowRoot() {
+	suite.setupStreamChain(10, 2)
+	backend := suite.streamBackend()
+
+	err := backend.SubscribeBlockHeaders(context.Background(), 9, func(*flow.Header) error {
+		suite.FailNow("no header should be delivered")
+		return nil
+	})
+	suite.Require().Equal(codes.InvalidArgument, status.Code(err))
+}
+
+func (suite *Suite) TestSubscribeEvents() {
+	chain := suite.setupStreamChain(10, 3)
+	chain.finalized = 12
+	chain.sealed = 11
+
+	// use the static execution node
+	suite.receipts.
+		On("ByBlockID", mock.Anything).
+		Return(flow.ExecutionReceiptList{}, nil)
+
+	address := unittest.AddressFixture()
+	otherAddress := unittest.RandomAddressFixture()
+	depositType := flow.EventType("A." + address.Hex() + ".FlowToken.TokensDeposited")
+	withdrawType := flow.EventType("A." + address.Hex() + ".FlowToken.TokensWithdrawn")
+	otherType := flow.EventType("A." + otherAddress.Hex() + ".FlowToken.TokensWithdrawn")
+
+	expected := make(map[uint64][]flow.Event)
+	emitted := make(map[flow.EventType]map[uint64]flow.Event)
+	for _, eventType := range []flow.EventType{depositType, withdrawType, otherType} {
+		emitted[eventType] = make(map[uint64]flow.Event)
+	}
+	for height := uint64(10); height <= 12; height++ {
+		deposit := unittest.EventFixture(depositType, 1, 0, unittest.IdentifierFixture())
+		withdraw := unittest.EventFixture(withdrawType, 0, 0, unittest.IdentifierFixture())
+		expected[height] = []flow.Event{withdraw, deposit}
+
+		emitted[depositType][height] = deposit
+		emitted[withdrawType][height] = withdraw
+		emitted[otherType][height] = unittest.EventFixture(otherType, 0, 1, unittest.IdentifierFixture())
+	}
+
+	// the events of the sealed blocks are requested once per event type, for all the blocks sealed at once
+	for _, heights := range [][]uint64{{10, 11}, {12}} {
+		for eventType, events := range emitted {
+			blockIDs := make([]flow.Identifier, 0, len(heights))
+			results := make([]*execproto.GetEventsForBlockIDsResponse_Result, 0, len(heights))
+			for _, height := range heights {
+				header := chain.headers[height]
+				blockIDs = append(blockIDs, header.ID())
+				results = append(results, &execproto.GetEventsForBlockIDsResponse_Result{
+					BlockId:     convert.IdentifierToMessage(header.ID()),
+					BlockHeight: header.Height,
+					Events:      convert.EventsToMessages([]flow.Event{events[height]}),
+				})
+			}
+
+			suite.execClient.
+				On("GetEventsForBlockIDs", mock.Anything, &execproto.GetEventsForBlockIDsRequest{
+					Type:     string(eventType),
+					BlockIds: convert.IdentifiersToMessages(blockIDs),
+				}).
+				Return(&execproto.GetEventsForBlockIDsResponse{Results: results}, nil).
+				Once()
+		}
+	}
+
+	backend := suite.streamBackend()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	filter := accessapi.EventFilter{
+		EventTypes: []flow.EventType{depositType, withdrawType, otherType},
+		Addresses:  []flow.Address{address},
+	}
+
+	received := make(chan flow.BlockEvents, 10)
+	done := make(chan error)
+	go func() {
+		done <- backend.SubscribeEvents(ctx, 10, filter, func(events flow.BlockEvents) error {
+			received <- events
+			return nil
+		})
+	}()
+
+	check := func(height uint64) {
+		select {
+		case events := <-received:
+			suite.Require().Equal(height, events.BlockHeight)
+			suite.Require().Equal(chain.headers[height].ID(), events.BlockID)
+			suite.Require().Equal(expected[height], events.Events)
+		case <-time.After(time.Second):
+			suite.FailNow("events for height %d not delivered", height)
+		}
+	}
+
+	// only the sealed blocks are delivered
+	check(10)
+	check(11)
+	suite.Require().Empty(received)
+
+	chain.Lock()
+	chain.sealed = 12
+	chain.Unlock()
+	backend.NotifyFinalizedBlockHeight(13)
+	check(12)
+
+	cancel()
+	err := <-done
+	suite.Require().Equal(codes.Canceled, status.Code(err))
+	suite.execClient.AssertExpectations(suite.T())
+}
+
+func (suite *Suite) TestSubscribeEventsWithoutEventTypes() {
+	backend := suite.streamBackend()
+
+	err := backend.SubscribeEvents(context.Background(), 0, accessapi.EventFilter{}, func(flow.BlockEvents) error {
+		suite.FailNow("no events should be delivered")
+		return nil
+	})
+	suite.Require().Equal(codes.InvalidArgument, status.Code(err))
+}
+
+func (suite *Suite) TestSubscribeTransactionStatus() {
+	chain := suite.setupStreamChain(10, 3)
+	chain.finalized = 10
+
+	// use the static execution node
+	suite.receipts.
+		On("ByBlockID", mock.Anything).
+		Return(flow.ExecutionReceiptList{}, nil)
+
+	collection := unittest.CollectionFixture(1)
+	light := collection.Light()
+	tx := collection.Transactions[0]
+	tx.ReferenceBlockID = chain.headers[10].ID()
+	txID := tx.ID()
+	block := unittest.BlockWithParentFixture(chain.headers[10])
+	block.Header = chain.headers[11]
+
+	refSnapshot := new(protocol.Snapshot)
+	refSnapshot.On("Head").Return(chain.headers[10], nil)
+	suite.state.On("AtBlockID", tx.ReferenceBlockID).Return(refSnapshot)
+
+	// the transaction is included in a block and executed once the test says so
+	var included, executed bool
+	suite.transactions.On("ByID", txID).Return(tx, nil)
+	suite.collections.On("LightByTransactionID", txID).Return(
+		func(flow.Identifier) *flow.LightCollection {
+			return &light
+		},
+		func(flow.Identifier) error {
+			chain.Lock()
+			defer chain.Unlock()
+			if !included {
+				return storage.ErrNotFound
+			}
+			return nil
+		})
+	suite.blocks.On("ByCollectionID", light.ID()).Return(&block, nil)
+	suite.execClient.On("GetTransactionResult", mock.Anything, mock.Anything).Return(
+		&execproto.GetTransactionResultResponse{},
+		func(context.Context, *execproto.GetTransactionResultRequest, ...grpc.CallOption) error {
+			chain.Lock()
+			defer chain.Unlock()
+			if !executed {
+				return status.Error(codes.NotFound, "not executed")
+			}
+			return nil
+		})
+
+	backend := suite.streamBackend()
+
+	received := make(chan flow.TransactionStatus, 10)
+	done := make(chan error)
+	go func() {
+		done <- backend.SubscribeTransactionStatus(context.Background(), txID, func(result *accessapi.TransactionResult) error {
+			received <- result.Status
+			return nil
+		})
+	}()
+
+	check := func(expected flow.TransactionStatus) {
+		select {
+		case actual := <-received:
+			suite.Require().Equal(expected, actual)
+		case <-time.After(time.Second):
+			suite.FailNow("status %s not delivered", expected)
+		}
+	}
+
+	advance := func(update func()) {
+		chain.Lock()
+		update()
+		chain.Unlock()
+		backend.NotifyFinalizedBlockHeight(chain.finalized)
+	}
+
+	// the current status is delivered right away
+	check(flow.TransactionStatusPending)
+
+	// a new block that does not change the status is not delivered
+	advance(func() { chain.finalized = 11 })
+
+	advance(func() { included = true })
+	check(flow.TransactionStatusFinalized)
+
+	advance(func() { executed = true })
+	check(flow.TransactionStatusExecuted)
+
+	advance(func() {
+		chain.finalized = 12
+		chain.sealed = 11
+	})
+	check(flow.TransactionStatusSealed)
+
+	// the stream ends once the transaction is sealed
+	select {
+	case err := <-done:
+		suite.Require().NoError(err)
+	case <-time.After(time.Second):
+		suite.FailNow("subscription did not end")
+	}
+	suite.Require().Empty(received)
+}
@@ -16,9 +16,12 @@ import (
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 
+	accessapi "github.com/onflow/flow-go/access"
 	access "github.com/onflow/flow-go/engine/access/mock"
 	backendmock "github.com/onflow/flow-go/engine/access/rpc/backend/mock"
 	"github.com/onflow/flow-go/engine/common/rpc/convert"
+	exeapi "github.com/onflow/flow-go/engine/execution"
+	fvmEvent "github.com/onflow/flow-go/fvm/event"
 	"github.com/onflow/flow-go/model/flow"
 	"github.com/onflow/flow-go/module/metrics"
 	protocol "github.com/onflow/flow-go/state/protocol/mock"
@@ -89,6 +92,8 @@ func (suite *Suite) TestPing() {
 		DefaultMaxHeightRange,
 		nil,
 		nil,
+		nil,
+		nil,
 		suite.log,
 	)
 
@@ -114,6 +119,8 @@ func (suite *Suite) TestGetLatestFinalizedBlockHeader() {
 		DefaultMaxHeightRange,
 		nil,
 		nil,
+		nil,
+		nil,
 		suite.log,
 	)
 
@@ -146,6 +153,8 @@ func (suite *Suite) TestGetLatestProtocolStateSnapshot() {
 		100,
 		nil,
 		nil,
+		nil,
+		nil,
 		suite.log,
 	)
 
@@ -179,6 +188,8 @@ func (suite *Suite) TestGetLatestSealedBlockHeader() {
 		DefaultMaxHeightRange,
 		nil,
 		nil,
+		nil,
+		nil,
 		suite.log,
 	)
 
@@ -217,6 +228,8 @@ func (suite *Suite) TestGetTransaction() {
 		DefaultMaxHeightRange,
 		nil,
 		nil,
+		nil,
+		nil,
 		suite.log,
 	)
 
@@ -251,6 +264,8 @@ func (suite *Suite) TestGetCollection() {
 		DefaultMaxHeightRange,
 		nil,
 		nil,
+		nil,
+		nil,
 		suite.log,
 	)
 
@@ -334,6 +349,8 @@ func (suite *Suite) TestTransactionStatusTransition() {
 		DefaultMaxHeightRange,
 		nil,
 		nil,
+		nil,
+		nil,
 		suite.log,
 	)
 
@@ -453,6 +470,8 @@ func (suite *Suite) TestTransactionExpiredStatusTransition() {
 		DefaultMaxHeightRange,
 		nil,
 		nil,
+		nil,
+		nil,
 		suite.log,
 	)
 
@@ -614,6 +633,8 @@ func (suite *Suite) TestTransactionPendingToFinalizedStatusTransition() {
 		100,
 		nil,
 		nil,
+		nil,
+		nil,
 		suite.log,
 	)
 
@@ -668,6 +689,8 @@ func (suite *Suite) TestTransactionResultUnknown() {
 		DefaultMaxHeightRange,
 		nil,
 		nil,
+		nil,
+		nil,
 		suite.log,
 	)
 
@@ -708,6 +731,8 @@ func (suite *Suite) TestGetLatestFinalizedBlock() {
 		DefaultMaxHeightRange,
 		nil,
 		nil,
+		nil,
+		nil,
 		suite.log,
 	)
 
@@ -831,6 +856,8 @@ func (suite *Suite) TestGetEventsForBlockIDs() {
 			DefaultMaxHeightRange,
 			nil,
 			nil,
+			nil,
+			nil,
 			suite.log,
 		)
 
@@ -856,7 +883,7 @@ func (suite *Suite) TestGetEventsForBlockIDs() {
 			false,
 			DefaultMaxHeightRange,
 			nil,
-			validENIDs.Strings(), // set the fixed EN Identifiers to the generated execution IDs
+			validENIDs.Strings(), nil, nil, // set the fixed EN Identifiers to the generated execution IDs
 			suite.log,
 		)
 
@@ -884,6 +911,8 @@ func (suite *Suite) TestGetEventsForBlockIDs() {
 			DefaultMaxHeightRange,
 			nil,
 			validENIDs.Strings(),
+			nil,
+			nil,
 			suite.log,
 		)
 
@@ -1006,6 +1035,8 @@ func (suite *Suite) TestGetEventsForHeightRange() {
 			DefaultMaxHeightRange,
 			nil,
 			nil,
+			nil,
+			nil,
 			suite.log,
 		)
 
@@ -1040,6 +1071,8 @@ func (suite *Suite) TestGetEventsForHeightRange() {
 			DefaultMaxHeightRange,
 			nil,
 			nil,
+			nil,
+			nil,
 			suite.log,
 		)
 
@@ -1073,6 +1106,8 @@ func (suite *Suite) TestGetEventsForHeightRange() {
 			DefaultMaxHeightRange,
 			nil,
 			nil,
+			nil,
+			nil,
 			suite.log,
 		)
 
@@ -1105,6 +1140,8 @@ func (suite *Suite) TestGetEventsForHeightRange() {
 			1, // set maximum range to 1
 			nil,
 			nil,
+			nil,
+			nil,
 			suite.log,
 		)
 
@@ -1137,6 +1174,8 @@ func (suite *Suite) TestGetEventsForHeightRange() {
 			DefaultMaxHeightRange,
 			nil,
 			nil,
+			nil,
+			nil,
 			suite.log,
 		)
 
@@ -1206,6 +1245,8 @@ func (suite *Suite) TestGetAccount() {
 		DefaultMaxHeightRange,
 		nil,
 		nil,
+		nil,
+		nil,
 		suite.log,
 	)
 
@@ -1277,6 +1318,8 @@ func (suite *Suite) TestGetAccountAtBlockHeight() {
 		DefaultMaxHeightRange,
 		nil,
 		nil,
+		nil,
+		nil,
 		suite.log,
 	)
 
@@ -1290,6 +1333,440 @@ func (suite *Suite) TestGetAccountAtBlockHeight() {
 	})
 }
 
+func (suite *Suite) TestGetAccountsAtBlockHeight() {
+	suite.state.On("Sealed").Return(suite.snapshot, nil).Maybe()
+
+	height := uint64(5)
+	addresses := []flow.Address{unittest.AddressFixture(), unittest.RandomAddressFixture()}
+	ctx := context.Background()
+
+	block := unittest.BlockFixture()
+	block.Header.Height = height
+	blockID := block.ID()
+
+	// setup headers storage to return the header when queried by height
+	suite.headers.
+		On("ByHeight", height).
+		Return(block.Header, nil)
+
+	receipts := suite.setupReceipts(&block)
+	preferredENIdentifiers = flow.IdentifierList{receipts[0].ExecutorID}
+
+	// all accounts are requested from one execution node with a single request
+	exeReq := &exeapi.GetAccountsAtBlockIDRequest{
+		BlockId:   blockID[:],
+		Addresses: [][]byte{addresses[0].Bytes(), addresses[1].Bytes()},
+	}
+	exeResp := &exeapi.AccountsResponse{
+		Results: []*exeapi.AccountResult{
+			{Account: &entitiesproto.Account{Address: addresses[0].Bytes()}},
+			{ErrorMessage: "account does not exist"},
+		},
+	}
+
+	batchClient := new(access.ExecutionBatchAPIClient)
+	batchClient.
+		On("GetAccountsAtBlockID", ctx, exeReq).
+		Return(exeResp, nil).
+		Once()
+
+	connFactory := new(backendmock.ConnectionFactory)
+	connFactory.On("GetExecutionBatchAPIClient", mock.Anything).Return(batchClient, &mockCloser{}, nil)
+
+	backend := New(
+		suite.state,
+		nil,
+		nil, nil, nil,
+		suite.headers,
+		nil, nil,
+		suite.receipts,
+		suite.chainID,
+		metrics.NewNoopCollector(),
+		connFactory,
+		false,
+		DefaultMaxHeightRange,
+		nil,
+		nil,
+		nil,
+		nil,
+		suite.log,
+	)
+
+	suite.Run("happy path - results and errors per account", func() {
+		results, err := backend.GetAccountsAtBlockHeight(ctx, addresses, height)
+		suite.Require().NoError(err)
+		suite.Require().Len(results, 2)
+
+		suite.Require().Equal(addresses[0], results[0].Account.Address)
+		suite.Require().Empty(results[0].ErrorMessage)
+
+		suite.Require().Nil(results[1].Account)
+		suite.Require().Equal("account does not exist", results[1].ErrorMessage)
+
+		batchClient.AssertExpectations(suite.T())
+	})
+
+	suite.Run("batch too large", func() {
+		_, err := backend.GetAccountsAtBlockHeight(ctx, make([]flow.Address, MaxBatchSize+1), height)
+		suite.Require().Error(err)
+		suite.Require().Equal(codes.InvalidArgument, status.Code(err))
+	})
+
+	suite.Run("execution node returning too few results", func() {
+		batchClient.
+			On("GetAccountsAtBlockID", ctx, exeReq).
+			Return(&exeapi.AccountsResponse{Results: exeResp.Results[:1]}, nil).
+			Once()
+
+		_, err := backend.GetAccountsAtBlockHeight(ctx, addresses, height)
+		suite.Require().Error(err)
+		suite.Require().Equal(codes.Internal, status.Code(err))
+	})
+}
+
+func (suite *Suite) TestGetTransactionResultsByBlockIDFromExecutionNode() {
+	suite.state.On("Sealed").Return(suite.snapshot, nil).Maybe()
+
+	ctx := context.Background()
+	block := unittest.BlockFixture()
+	blockID := block.ID()
+
+	receipts := suite.setupReceipts(&block)
+	preferredENIdentifiers = flow.IdentifierList{receipts[0].ExecutorID}
+
+	txID := unittest.IdentifierFixture()
+	systemTxID := unittest.IdentifierFixture()
+	event := unittest.EventFixture(flow.EventAccountCreated, 0, 0, txID)
+	systemEvent := unittest.EventFixture(fvmEvent.ServiceEventTypes(suite.chainID.Chain())[0], 1, 0, systemTxID)
+
+	// the results of all transactions of the block, including the system transaction, are requested at once
+	exeReq := &exeapi.GetTransactionResultsByBlockIDRequest{
+		BlockId: blockID[:],
+	}
+	exeResp := &exeapi.TransactionResultsResponse{
+		Results: []*exeapi.TransactionResult{
+			{
+				TransactionId: txID[:],
+				StatusCode:    1,
+				ErrorMessage:  "execution failed",
+				Events:        convert.EventsToMessages([]flow.Event{event}),
+			},
+			{
+				TransactionId: systemTxID[:],
+				Events:        convert.EventsToMessages([]flow.Event{systemEvent}),
+			},
+		},
+	}
+
+	batchClient := new(access.ExecutionBatchAPIClient)
+	batchClient.
+		On("GetTransactionResultsByBlockID", ctx, exeReq).
+		Return(exeResp, nil).
+		Once()
+
+	connFactory := new(backendmock.ConnectionFactory)
+	connFactory.On("GetExecutionBatchAPIClient", mock.Anything).Return(batchClient, &mockCloser{}, nil)
+
+	backend := New(
+		suite.state,
+		nil,
+		nil, nil, nil,
+		suite.headers,
+		nil, nil,
+		suite.receipts,
+		suite.chainID,
+		metrics.NewNoopCollector(),
+		connFactory,
+		false,
+		DefaultMaxHeightRange,
+		nil,
+		nil,
+		nil,
+		nil,
+		suite.log,
+	)
+
+	suite.Run("happy path - results with status codes and events of all transactions", func() {
+		results, events, err := backend.getTransactionResultsByBlockIDFromExecutionNode(ctx, block.Header, []flow.Identifier{txID})
+		suite.Require().NoError(err)
+
+		suite.Require().Equal([]flow.TransactionResult{
+			{TransactionID: txID, ErrorMessage: "execution failed", StatusCode: 1},
+			{TransactionID: systemTxID},
+		}, results)
+		suite.Require().Equal([]flow.Event{event, systemEvent}, events)
+
+		batchClient.AssertExpectations(suite.T())
+	})
+
+	suite.Run("block not executed", func() {
+		batchClient.
+			On("GetTransactionResultsByBlockID", ctx, exeReq).
+			Return(nil, status.Error(codes.NotFound, "not found"))
+
+		_, _, err := backend.getTransactionResultsByBlockIDFromExecutionNode(ctx, block.Header, []flow.Identifier{txID})
+		suite.Require().Error(err)
+		suite.Require().Equal(codes.NotFound, status.Code(err))
+	})
+}
+
+func (suite *Suite) TestAccountLookups() {
+	suite.state.On("Sealed").Return(suite.snapshot, nil).Maybe()
+
+	height := uint64(5)
+	address := unittest.AddressFixture()
+	ctx := context.Background()
+
+	block := unittest.BlockFixture()
+	block.Header.Height = height
+	blockID := block.ID()
+
+	suite.headers.
+		On("ByHeight", height).
+		Return(block.Header, nil)
+
+	receipts := suite.setupReceipts(&block)
+	preferredENIdentifiers = flow.IdentifierList{receipts[0].ExecutorID}
+
+	accountClient := new(access.ExecutionAccountAPIClient)
+	connFactory := new(backendmock.ConnectionFactory)
+	connFactory.On("GetExecutionAccountAPIClient", mock.Anything).Return(accountClient, &mockCloser{}, nil)
+
+	backend := New(
+		suite.state,
+		nil,
+		nil, nil, nil,
+		suite.headers,
+		nil, nil,
+		suite.receipts,
+		suite.chainID,
+		metrics.NewNoopCollector(),
+		connFactory,
+		false,
+		DefaultMaxHeightRange,
+		nil,
+		nil,
+		nil,
+		nil,
+		suite.log,
+	)
+
+	suite.Run("falls back to the next execution node", func() {
+		exeReq := &exeapi.GetAccountStorageUsedRequest{
+			BlockId: blockID[:],
+			Address: address.Bytes(),
+		}
+		accountClient.
+			On("GetAccountStorageUsed", ctx, exeReq).
+			Return(nil, status.Error(codes.Unavailable, "unavailable")).
+			Once()
+		accountClient.
+			On("GetAccountStorageUsed", ctx, exeReq).
+			Return(&exeapi.AccountStorageUsedResponse{StorageUsed: 42}, nil).
+			Once()
+
+		storageUsed, err := backend.GetAccountStorageUsed(ctx, address, height)
+		suite.Require().NoError(err)
+		suite.Require().Equal(uint64(42), storageUsed)
+		accountClient.AssertExpectations(suite.T())
+	})
+
+	suite.Run("missing contract is reported without asking other execution nodes", func() {
+		exeReq := &exeapi.GetAccountContractRequest{
+			BlockId: blockID[:],
+			Address: address.Bytes(),
+			Name:    "Missing",
+		}
+		accountClient.
+			On("GetAccountContract", ctx, exeReq).
+			Return(nil, status.Error(codes.NotFound, "contract does not exist")).
+			Once()
+
+		_, err := backend.GetAccountContract(ctx, address, "Missing", height)
+		suite.Require().Error(err)
+		suite.Require().Equal(codes.NotFound, status.Code(err))
+		accountClient.AssertNumberOfCalls(suite.T(), "GetAccountContract", 1)
+	})
+
+	suite.Run("page of keys", func() {
+		key, err := unittest.AccountKeyDefaultFixture()
+		suite.Require().NoError(err)
+		message, err := convert.AccountKeyToMessage(key.PublicKey(1000))
+		suite.Require().NoError(err)
+
+		exeReq := &exeapi.ListAccountKeysRequest{
+			BlockId: blockID[:],
+			Address: address.Bytes(),
+			Offset:  1,
+			Limit:   1,
+		}
+		accountClient.
+			On("ListAccountKeys", ctx, exeReq).
+			Return(&exeapi.AccountKeysResponse{AccountKeys: []*entitiesproto.AccountKey{message}, TotalCount: 3}, nil).
+			Once()
+
+		keys, total, err := backend.ListAccountKeys(ctx, address, 1, 1, height)
+		suite.Require().NoError(err)
+		suite.Require().Len(keys, 1)
+		suite.Require().Equal(uint64(3), total)
+	})
+
+	suite.Run("page size out of range", func() {
+		_, _, err := backend.ListAccountKeys(ctx, address, 0, exeapi.MaxAccountKeysPageSize+1, height)
+		suite.Require().Error(err)
+		suite.Require().Equal(codes.InvalidArgument, status.Code(err))
+	})
+}
+
+func (suite *Suite) TestDryRunTransaction() {
+	ctx := context.Background()
+
+	// the transaction is executed at the latest sealed block
+	block := unittest.BlockFixture()
+	blockID := block.ID()
+	suite.state.On("Sealed").Return(suite.snapshot, nil)
+	suite.snapshot.On("Head").Return(block.Header, nil)
+
+	receipts := suite.setupReceipts(&block)
+	preferredENIdentifiers = flow.IdentifierList{receipts[0].ExecutorID}
+
+	tx := unittest.TransactionBodyFixture()
+	exeReq := &exeapi.DryRunTransactionRequest{
+		BlockId:            blockID[:],
+		Transaction:        convert.TransactionToMessage(tx),
+		SkipSignatureCheck: true,
+	}
+
+	address := unittest.AddressFixture()
+	exeResp := &exeapi.DryRunTransactionResponse{
+		ComputationUsed: 12,
+		StorageDeltas:   []*exeapi.AccountStorageDelta{{Address: address.Bytes(), Delta: 100}},
+		FeeEstimate:     1000,
+		ErrorCode:       1006,
+		ErrorMessage:    "invalid proposal signature",
+	}
+
+	dryRunClient := new(access.ExecutionDryRunAPIClient)
+	connFactory := new(backendmock.ConnectionFactory)
+	connFactory.On("GetExecutionDryRunAPIClient", mock.Anything).Return(dryRunClient, &mockCloser{}, nil)
+
+	backend := New(
+		suite.state,
+		nil,
+		nil, nil, nil,
+		suite.headers,
+		nil, nil,
+		suite.receipts,
+		suite.chainID,
+		metrics.NewNoopCollector(),
+		connFactory,
+		false,
+		DefaultMaxHeightRange,
+		nil,
+		nil,
+		nil,
+		nil,
+		suite.log,
+	)
+
+	suite.Run("falls back to the next execution node", func() {
+		dryRunClient.
+			On("DryRunTransaction", ctx, exeReq).
+			Return(nil, status.Error(codes.Unavailable, "unavailable")).
+			Once()
+		dryRunClient.
+			On("DryRunTransaction", ctx, exeReq).
+			Return(exeResp, nil).
+			Once()
+
+		result, err := backend.DryRunTransaction(ctx, &tx, true)
+		suite.Require().NoError(err)
+		suite.Require().Equal(blockID, result.BlockID)
+		suite.Require().Equal(uint64(12), result.ComputationUsed)
+		suite.Require().Equal(uint64(1000), result.FeeEstimate)
+		suite.Require().Equal(uint32(1006), result.ErrorCode)
+		suite.Require().Equal([]accessapi.StorageDelta{{Address: address, Delta: 100}}, result.StorageDeltas)
+		dryRunClient.AssertExpectations(suite.T())
+	})
+
+	suite.Run("all execution nodes failing", func() {
+		dryRunClient.
+			On("DryRunTransaction", ctx, exeReq).
+			Return(nil, status.Error(codes.Unavailable, "unavailable")).
+			Twice()
+
+		_, err := backend.DryRunTransaction(ctx, &tx, true)
+		suite.Require().Error(err)
+		suite.Require().Equal(codes.Internal, status.Code(err))
+	})
+}
+
+func (suite *Suite) TestExecuteScriptsAtBlockID() {
+	suite.state.On("Sealed").Return(suite.snapshot, nil).Maybe()
+
+	ctx := context.Background()
+	block := unittest.BlockFixture()
+	blockID := block.ID()
+
+	receipts := suite.setupReceipts(&block)
+	preferredENIdentifiers = flow.IdentifierList{receipts[0].ExecutorID}
+
+	scripts := []accessapi.Script{
+		{Code: []byte("script 1"), Arguments: [][]byte{[]byte("argument")}},
+		{Code: []byte("script 2")},
+	}
+
+	// all scripts are sent to one execution node with a single request
+	exeReq := &exeapi.ExecuteScriptsAtBlockIDRequest{
+		BlockId: blockID[:],
+		Scripts: []*exeapi.Script{
+			{Script: scripts[0].Code, Arguments: scripts[0].Arguments},
+			{Script: scripts[1].Code},
+		},
+	}
+	exeResp := &exeapi.ScriptsResponse{
+		Results: []*exeapi.ScriptResult{
+			{Value: []byte("value")},
+			{ErrorMessage: "script failed"},
+		},
+	}
+
+	batchClient := new(access.ExecutionBatchAPIClient)
+	batchClient.
+		On("ExecuteScriptsAtBlockID", ctx, exeReq).
+		Return(exeResp, nil).
+		Once()
+
+	connFactory := new(backendmock.ConnectionFactory)
+	connFactory.On("GetExecutionBatchAPIClient", mock.Anything).Return(batchClient, &mockCloser{}, nil)
+
+	backend := New(
+		suite.state,
+		nil,
+		nil, nil, nil, nil, nil, nil,
+		suite.receipts,
+		suite.chainID,
+		metrics.NewNoopCollector(),
+		connFactory,
+		false,
+		DefaultMaxHeightRange,
+		nil,
+		nil,
+		nil,
+		nil,
+		suite.log,
+	)
+
+	results, err := backend.ExecuteScriptsAtBlockID(ctx, blockID, scripts)
+	suite.Require().NoError(err)
+	suite.Require().Equal([]accessapi.ScriptResult{
+		{Value: []byte("value")},
+		{ErrorMessage: "script failed"},
+	}, results)
+
+	batchClient.AssertExpectations(suite.T())
+}
+
 func (suite *Suite) TestGetNetworkParameters() {
 	suite.state.On("Sealed").Return(suite.snapshot, nil).Maybe()
 
@@ -1305,6 +1782,8 @@ func (suite *Suite) TestGetNetworkParameters() {
 		DefaultMaxHeightRange,
 		nil,
 		nil,
+		nil,
+		nil,
 		suite.log,
 	)
 
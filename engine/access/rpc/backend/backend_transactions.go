@@ -16,6 +16,8 @@ import (
 
 	"github.com/onflow/flow-go/access"
 	"github.com/onflow/flow-go/engine/common/rpc/convert"
+	exeapi "github.com/onflow/flow-go/engine/execution"
+	fvmEvent "github.com/onflow/flow-go/fvm/event"
 	"github.com/onflow/flow-go/model/flow"
 	"github.com/onflow/flow-go/module"
 	"github.com/onflow/flow-go/state/protocol"
@@ -37,6 +39,7 @@ type backendTransactions struct {
 	transactionValidator *access.TransactionValidator
 	retry                *Retry
 	connFactory          ConnectionFactory
+	index                *LocalIndex
 
 	previousAccessNodes []accessproto.AccessAPIClient
 	log                 zerolog.Logger
@@ -234,7 +237,7 @@ func (b *backendTransactions) GetTransactionResult(
 	// access node may not have the block if it hasn't yet been finalized, hence block can be nil at this point
 	if block != nil {
 		blockID = block.ID()
-		transactionWasExecuted, events, statusCode, txError, err = b.lookupTransactionResult(ctx, txID, blockID)
+		transactionWasExecuted, events, statusCode, txError, err = b.lookupTransactionResult(ctx, txID, block.Header)
 		if err != nil {
 			return nil, convertStorageError(err)
 		}
@@ -355,9 +358,25 @@ func (b *backendTransactions) lookupBlock(txID flow.Identifier) (*flow.Block, er
 func (b *backendTransactions) lookupTransactionResult(
 	ctx context.Context,
 	txID flow.Identifier,
-	blockID flow.Identifier,
+	header *flow.Header,
 ) (bool, []flow.Event, uint32, string, error) {
 
+	blockID := header.ID()
+
+	// serve the result from the local index if the block has been indexed
+	indexed, err := b.index.Indexed(header)
+	if err != nil {
+		return false, nil, 0, "", err
+	}
+	if indexed {
+		result, events, err := b.index.TransactionResult(blockID, txID)
+		if err != nil {
+			return false, nil, 0, "", err
+		}
+
+		return true, events, result.StatusCode, result.ErrorMessage, nil
+	}
+
 	events, txStatus, message, err := b.getTransactionResultFromExecutionNode(ctx, blockID, txID[:])
 	if err != nil {
 		if status.Code(err) == codes.NotFound {
@@ -476,6 +495,126 @@ func (b *backendTransactions) getTransactionResultFromExecutionNode(
 	return events, resp.GetStatusCode(), resp.GetErrorMessage(), nil
 }
 
+// getTransactionResultsByBlockIDFromExecutionNode returns the results of all transactions of the given block, with the
+// given collection transactions, and all the events of the block. It returns a NotFound error if no execution node serves
+// the results of the block, either because it has not been executed yet or because the execution nodes are of a version
+// that does not serve the results of a block at once.
+func (b *backendTransactions) getTransactionResultsByBlockIDFromExecutionNode(
+	ctx context.Context,
+	header *flow.Header,
+	txIDs []flow.Identifier,
+) ([]flow.TransactionResult, []flow.Event, error) {
+
+	blockID := header.ID()
+	execNodes, err := executionNodesForBlockID(blockID, b.executionReceipts, b.state, b.log)
+	if err != nil {
+		return nil, nil, status.Errorf(codes.Internal, "failed to retrieve results from any execution node: %v", err)
+	}
+
+	// the static execution node is not known to serve batch requests, so it is asked for each transaction separately
+	if len(execNodes) == 0 {
+		return b.getTransactionResultsOneByOne(ctx, header, txIDs)
+	}
+
+	req := exeapi.GetTransactionResultsByBlockIDRequest{
+		BlockId: blockID[:],
+	}
+
+	var errors *multierror.Error
+	for _, execNode := range execNodes {
+		resp, err := b.tryGetTransactionResults(ctx, execNode, req)
+		if err != nil {
+			switch status.Code(err) {
+			case codes.NotFound, codes.Unimplemented:
+			default:
+				errors = multierror.Append(errors, err)
+			}
+			continue
+		}
+
+		results := make([]flow.TransactionResult, 0, len(resp.GetResults()))
+		var events []flow.Event
+		for _, result := range resp.GetResults() {
+			txID, err := convert.TransactionID(result.GetTransactionId())
+			if err != nil {
+				return nil, nil, status.Errorf(codes.Internal, "execution node returned invalid transaction ID: %v", err)
+			}
+			results = append(results, flow.TransactionResult{
+				TransactionID: txID,
+				ErrorMessage:  result.GetErrorMessage(),
+				StatusCode:    result.GetStatusCode(),
+			})
+			events = append(events, convert.MessagesToEvents(result.GetEvents())...)
+		}
+
+		return results, events, nil
+	}
+
+	if errors.ErrorOrNil() != nil {
+		return nil, nil, status.Errorf(codes.Internal, "failed to retrieve results from execution node: %v", errors.ErrorOrNil())
+	}
+	return nil, nil, status.Errorf(codes.NotFound, "no execution node serving the results of block %v", blockID)
+}
+
+// getTransactionResultsOneByOne returns the results of the given collection transactions of the given block, and
+// their events along with the events of the system chunk, by requesting them separately from the static execution node.
+func (b *backendTransactions) getTransactionResultsOneByOne(
+	ctx context.Context,
+	header *flow.Header,
+	txIDs []flow.Identifier,
+) ([]flow.TransactionResult, []flow.Event, error) {
+
+	if b.executionRPC == nil {
+		return nil, nil, status.Errorf(codes.Internal, "failed to retrieve results from execution node")
+	}
+
+	blockID := header.ID()
+	results := make([]flow.TransactionResult, 0, len(txIDs))
+	var events []flow.Event
+	for _, txID := range txIDs {
+		txEvents, statusCode, errorMessage, err := b.getTransactionResultFromExecutionNode(ctx, blockID, txID[:])
+		if err != nil {
+			return nil, nil, err
+		}
+
+		results = append(results, flow.TransactionResult{
+			TransactionID: txID,
+			ErrorMessage:  errorMessage,
+			StatusCode:    statusCode,
+		})
+		events = append(events, txEvents...)
+	}
+
+	// the system transaction is not part of any collection, so its service events are retrieved by type
+	for _, eventType := range fvmEvent.ServiceEventTypes(b.chainID.Chain()) {
+		req := execproto.GetEventsForBlockIDsRequest{
+			Type:     string(eventType),
+			BlockIds: [][]byte{blockID[:]},
+		}
+		resp, err := b.executionRPC.GetEventsForBlockIDs(ctx, &req)
+		if err != nil {
+			if status.Code(err) == codes.NotFound {
+				return nil, nil, err
+			}
+			return nil, nil, status.Errorf(codes.Internal, "failed to retrieve events from execution node: %v", err)
+		}
+		for _, result := range resp.GetResults() {
+			events = append(events, convert.MessagesToEvents(result.GetEvents())...)
+		}
+	}
+
+	return results, events, nil
+}
+
+func (b *backendTransactions) tryGetTransactionResults(ctx context.Context, execNode *flow.Identity, req exeapi.GetTransactionResultsByBlockIDRequest) (*exeapi.TransactionResultsResponse, error) {
+	execRPCClient, closer, err := b.connFactory.GetExecutionBatchAPIClient(execNode.Address)
+	if err != nil {
+		return nil, err
+	}
+	defer closer.Close()
+	return execRPCClient.GetTransactionResultsByBlockID(ctx, &req)
+}
+
 func (b *backendTransactions) NotifyFinalizedBlockHeight(height uint64) {
 	b.retry.Retry(height)
 }
@@ -513,3 +652,90 @@ func (b *backendTransactions) tryGetTransactionResult(ctx context.Context, execN
 	}
 	return resp, nil
 }
+
+// DryRunTransaction executes the transaction against the state of the latest sealed block on an execution node,
+// without committing its changes. Signatures are not verified if skipSignatureCheck is set.
+func (b *backendTransactions) DryRunTransaction(
+	ctx context.Context,
+	tx *flow.TransactionBody,
+	skipSignatureCheck bool,
+) (*access.DryRunResult, error) {
+
+	sealed, err := b.state.Sealed().Head()
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get latest sealed header: %v", err)
+	}
+	blockID := sealed.ID()
+
+	execNodes, err := executionNodesForBlockID(blockID, b.executionReceipts, b.state, b.log)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to dry run transaction on the execution node: %v", err)
+	}
+	if len(execNodes) == 0 {
+		return nil, status.Errorf(codes.Unavailable, "no execution node found for block %s", blockID)
+	}
+
+	req := &exeapi.DryRunTransactionRequest{
+		BlockId:            blockID[:],
+		Transaction:        convert.TransactionToMessage(*tx),
+		SkipSignatureCheck: skipSignatureCheck,
+	}
+
+	var errors *multierror.Error
+	for _, execNode := range execNodes {
+		resp, err := b.tryDryRunTransaction(ctx, execNode, req)
+		if err == nil {
+			return dryRunResult(blockID, resp), nil
+		}
+
+		// the transaction is malformed, the other execution nodes would reject it as well
+		if status.Code(err) == codes.InvalidArgument {
+			return nil, err
+		}
+
+		errors = multierror.Append(errors, err)
+	}
+
+	return nil, status.Errorf(codes.Internal, "failed to dry run transaction on the execution nodes: %v", errors.ErrorOrNil())
+}
+
+func (b *backendTransactions) tryDryRunTransaction(
+	ctx context.Context,
+	execNode *flow.Identity,
+	req *exeapi.DryRunTransactionRequest,
+) (*exeapi.DryRunTransactionResponse, error) {
+	execRPCClient, closer, err := b.connFactory.GetExecutionDryRunAPIClient(execNode.Address)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to the execution node %s: %w", execNode.String(), err)
+	}
+	defer closer.Close()
+
+	resp, err := execRPCClient.DryRunTransaction(ctx, req)
+	if err != nil {
+		if status.Code(err) == codes.InvalidArgument {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to dry run transaction on the execution node %s: %w", execNode.String(), err)
+	}
+	return resp, nil
+}
+
+func dryRunResult(blockID flow.Identifier, resp *exeapi.DryRunTransactionResponse) *access.DryRunResult {
+	deltas := make([]access.StorageDelta, len(resp.GetStorageDeltas()))
+	for i, delta := range resp.GetStorageDeltas() {
+		deltas[i] = access.StorageDelta{
+			Address: flow.BytesToAddress(delta.GetAddress()),
+			Delta:   delta.GetDelta(),
+		}
+	}
+
+	return &access.DryRunResult{
+		BlockID:         blockID,
+		ComputationUsed: resp.GetComputationUsed(),
+		Events:          convert.MessagesToEvents(resp.GetEvents()),
+		StorageDeltas:   deltas,
+		FeeEstimate:     resp.GetFeeEstimate(),
+		ErrorCode:       resp.GetErrorCode(),
+		ErrorMessage:    resp.GetErrorMessage(),
+	}
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/onflow/flow/protobuf/go/flow/execution"
 	"google.golang.org/grpc"
 
+	exeapi "github.com/onflow/flow-go/engine/execution"
 	grpcutils "github.com/onflow/flow-go/utils/grpc"
 )
 
@@ -21,6 +22,9 @@ const defaultClientTimeout = 3 * time.Second
 type ConnectionFactory interface {
 	GetAccessAPIClient(address string) (access.AccessAPIClient, io.Closer, error)
 	GetExecutionAPIClient(address string) (execution.ExecutionAPIClient, io.Closer, error)
+	GetExecutionBatchAPIClient(address string) (exeapi.ExecutionBatchAPIClient, io.Closer, error)
+	GetExecutionAccountAPIClient(address string) (exeapi.ExecutionAccountAPIClient, io.Closer, error)
+	GetExecutionDryRunAPIClient(address string) (exeapi.ExecutionDryRunAPIClient, io.Closer, error)
 }
 
 type ConnectionFactoryImpl struct {
@@ -79,6 +83,54 @@ func (cf *ConnectionFactoryImpl) GetExecutionAPIClient(address string) (executio
 	return executionAPIClient, closer, nil
 }
 
+func (cf *ConnectionFactoryImpl) GetExecutionBatchAPIClient(address string) (exeapi.ExecutionBatchAPIClient, io.Closer, error) {
+
+	grpcAddress, err := getGRPCAddress(address, cf.ExecutionGRPCPort)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	conn, err := cf.createConnection(grpcAddress, cf.ExecutionNodeGRPCTimeout)
+	if err != nil {
+		return nil, nil, err
+	}
+	executionBatchAPIClient := exeapi.NewExecutionBatchAPIClient(conn)
+	closer := io.Closer(conn)
+	return executionBatchAPIClient, closer, nil
+}
+
+func (cf *ConnectionFactoryImpl) GetExecutionAccountAPIClient(address string) (exeapi.ExecutionAccountAPIClient, io.Closer, error) {
+
+	grpcAddress, err := getGRPCAddress(address, cf.ExecutionGRPCPort)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	conn, err := cf.createConnection(grpcAddress, cf.ExecutionNodeGRPCTimeout)
+	if err != nil {
+		return nil, nil, err
+	}
+	executionAccountAPIClient := exeapi.NewExecutionAccountAPIClient(conn)
+	closer := io.Closer(conn)
+	return executionAccountAPIClient, closer, nil
+}
+
+func (cf *ConnectionFactoryImpl) GetExecutionDryRunAPIClient(address string) (exeapi.ExecutionDryRunAPIClient, io.Closer, error) {
+
+	grpcAddress, err := getGRPCAddress(address, cf.ExecutionGRPCPort)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	conn, err := cf.createConnection(grpcAddress, cf.ExecutionNodeGRPCTimeout)
+	if err != nil {
+		return nil, nil, err
+	}
+	executionDryRunAPIClient := exeapi.NewExecutionDryRunAPIClient(conn)
+	closer := io.Closer(conn)
+	return executionDryRunAPIClient, closer, nil
+}
+
 // getExecutionNodeAddress translates flow.Identity address to the GRPC address of the node by switching the port to the
 // GRPC port from the libp2p port
 func getGRPCAddress(address string, grpcPort uint) (string, error) {
@@ -54,6 +54,8 @@ func (suite *Suite) TestHistoricalTransactionResult() {
 		DefaultMaxHeightRange,
 		nil,
 		nil,
+		nil,
+		nil,
 		suite.log,
 	)
 
@@ -111,6 +113,8 @@ func (suite *Suite) TestHistoricalTransaction() {
 		DefaultMaxHeightRange,
 		nil,
 		nil,
+		nil,
+		nil,
 		suite.log,
 	)
 
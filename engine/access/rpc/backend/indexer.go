@@ -0,0 +1,152 @@
+package backend
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/rs/zerolog"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/state/protocol"
+	"github.com/onflow/flow-go/storage"
+	bstorage "github.com/onflow/flow-go/storage/badger"
+)
+
+// Indexer ingests the events and transaction results of sealed blocks from execution nodes into
+// the local index, so that the backend can serve them without contacting execution nodes.
+type Indexer struct {
+	log         zerolog.Logger
+	db          *badger.DB
+	state       protocol.State
+	blocks      storage.Blocks
+	collections storage.Collections
+	index       *LocalIndex
+	backend     *Backend // used to retrieve the execution results from execution nodes
+}
+
+func NewIndexer(
+	log zerolog.Logger,
+	db *badger.DB,
+	state protocol.State,
+	blocks storage.Blocks,
+	collections storage.Collections,
+	index *LocalIndex,
+	backend *Backend,
+) *Indexer {
+	return &Indexer{
+		log:         log.With().Str("component", "indexer").Logger(),
+		db:          db,
+		state:       state,
+		blocks:      blocks,
+		collections: collections,
+		index:       index,
+		backend:     backend,
+	}
+}
+
+// IndexSealedBlocks indexes the sealed blocks above the last indexed height in height order. It stops
+// at the first block whose collections or execution results are not available yet, so calling it again
+// later resumes from that block.
+func (i *Indexer) IndexSealedBlocks(ctx context.Context) error {
+
+	indexedHeight, err := i.index.IndexedHeight()
+	if errors.Is(err, storage.ErrNotFound) {
+		// start with the root block, which has no transactions to index
+		root, err := i.state.Params().Root()
+		if err != nil {
+			return fmt.Errorf("could not get root block: %w", err)
+		}
+		err = i.index.progress.InitProcessedIndex(root.Height)
+		if err != nil {
+			return fmt.Errorf("could not initialize indexed height: %w", err)
+		}
+		indexedHeight = root.Height
+	} else if err != nil {
+		return fmt.Errorf("could not get indexed height: %w", err)
+	}
+
+	sealed, err := i.state.Sealed().Head()
+	if err != nil {
+		return fmt.Errorf("could not get last sealed block: %w", err)
+	}
+
+	for height := indexedHeight + 1; height <= sealed.Height; height++ {
+		if ctx.Err() != nil {
+			return nil
+		}
+
+		indexed, err := i.indexBlock(ctx, height)
+		if err != nil {
+			return fmt.Errorf("could not index block at height %d: %w", height, err)
+		}
+		if !indexed {
+			i.log.Debug().Uint64("height", height).Msg("execution results not available yet, pausing indexing")
+			return nil
+		}
+
+		err = i.index.progress.SetProcessedIndex(height)
+		if err != nil {
+			return fmt.Errorf("could not update indexed height to %d: %w", height, err)
+		}
+
+		i.log.Debug().Uint64("height", height).Msg("indexed execution results")
+	}
+
+	return nil
+}
+
+// indexBlock retrieves the events and transaction results of the finalized block at the given height
+// and stores them in the local index. It returns false if the block cannot be indexed yet because its
+// collections have not been received or no execution node has its results.
+func (i *Indexer) indexBlock(ctx context.Context, height uint64) (bool, error) {
+
+	block, err := i.blocks.ByHeight(height)
+	if err != nil {
+		return false, fmt.Errorf("could not get block: %w", err)
+	}
+	blockID := block.ID()
+
+	var txIDs []flow.Identifier
+	for _, guarantee := range block.Payload.Guarantees {
+		collection, err := i.collections.LightByID(guarantee.CollectionID)
+		if errors.Is(err, storage.ErrNotFound) {
+			return false, nil
+		}
+		if err != nil {
+			return false, fmt.Errorf("could not get collection %v: %w", guarantee.CollectionID, err)
+		}
+		txIDs = append(txIDs, collection.Transactions...)
+	}
+
+	results, events, err := i.backend.getTransactionResultsByBlockIDFromExecutionNode(ctx, block.Header, txIDs)
+	if status.Code(err) == codes.NotFound {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("could not get transaction results: %w", err)
+	}
+
+	// store all events of the block at once, since the events storage caches them by block
+	batch := bstorage.NewBatch(i.db)
+
+	err = i.index.events.BatchStore(blockID, events, batch)
+	if err != nil {
+		return false, fmt.Errorf("could not store events: %w", err)
+	}
+
+	err = i.index.transactionResults.BatchStore(blockID, results, batch)
+	if err != nil {
+		return false, fmt.Errorf("could not store transaction results: %w", err)
+	}
+
+	err = batch.Flush()
+	if err != nil {
+		return false, fmt.Errorf("could not flush batch: %w", err)
+	}
+
+	return true, nil
+}
@@ -0,0 +1,89 @@
+package backend
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/storage"
+)
+
+// LocalIndex gives access to the events and transaction results of sealed blocks that were ingested
+// into the local storage of the access node by the Indexer.
+//
+// Blocks are indexed in height order, so a finalized block is indexed if its height is not above
+// the last indexed height.
+type LocalIndex struct {
+	headers            storage.Headers
+	events             storage.Events
+	transactionResults storage.TransactionResults
+	progress           storage.ConsumerProgress // the last indexed height
+}
+
+func NewLocalIndex(
+	headers storage.Headers,
+	events storage.Events,
+	transactionResults storage.TransactionResults,
+	progress storage.ConsumerProgress,
+) *LocalIndex {
+	return &LocalIndex{
+		headers:            headers,
+		events:             events,
+		transactionResults: transactionResults,
+		progress:           progress,
+	}
+}
+
+// IndexedHeight returns the height up to which all blocks have been indexed. It returns
+// storage.ErrNotFound if indexing has not started yet.
+func (i *LocalIndex) IndexedHeight() (uint64, error) {
+	return i.progress.ProcessedIndex()
+}
+
+// Indexed returns true if the execution results of the given block are available locally.
+// A nil LocalIndex never has any block indexed, which is the case if local indexing is disabled.
+func (i *LocalIndex) Indexed(header *flow.Header) (bool, error) {
+	if i == nil {
+		return false, nil
+	}
+
+	indexedHeight, err := i.IndexedHeight()
+	if errors.Is(err, storage.ErrNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("could not get indexed height: %w", err)
+	}
+
+	if header.Height > indexedHeight {
+		return false, nil
+	}
+
+	// only the finalized block at each height is indexed
+	finalized, err := i.headers.ByHeight(header.Height)
+	if err != nil {
+		return false, fmt.Errorf("could not get finalized block at height %d: %w", header.Height, err)
+	}
+
+	return finalized.ID() == header.ID(), nil
+}
+
+// EventsByBlockIDEventType returns the indexed events of the given type for the given block.
+func (i *LocalIndex) EventsByBlockIDEventType(blockID flow.Identifier, eventType flow.EventType) ([]flow.Event, error) {
+	return i.events.ByBlockIDEventType(blockID, eventType)
+}
+
+// TransactionResult returns the indexed result and events of the given transaction in the given block.
+func (i *LocalIndex) TransactionResult(blockID flow.Identifier, txID flow.Identifier) (*flow.TransactionResult, []flow.Event, error) {
+	result, err := i.transactionResults.ByBlockIDTransactionID(blockID, txID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("could not get transaction result: %w", err)
+	}
+
+	events, err := i.events.ByBlockIDTransactionID(blockID, txID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("could not get transaction events: %w", err)
+	}
+
+	return result, events, nil
+}
@@ -0,0 +1,179 @@
+package backend
+
+import (
+	"context"
+
+	execproto "github.com/onflow/flow/protobuf/go/flow/execution"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/onflow/flow-go/engine/common/rpc/convert"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module/metrics"
+	protocol "github.com/onflow/flow-go/state/protocol/mock"
+	"github.com/onflow/flow-go/storage"
+	storagemock "github.com/onflow/flow-go/storage/mock"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+// localIndexBackend returns a backend serving execution results from a local index that has
+// indexed all blocks up to the given height.
+func (suite *Suite) localIndexBackend(indexedHeight uint64) (*Backend, *storagemock.Events, *storagemock.TransactionResults) {
+	events := new(storagemock.Events)
+	results := new(storagemock.TransactionResults)
+	progress := new(storagemock.ConsumerProgress)
+	progress.On("ProcessedIndex").Return(indexedHeight, nil)
+
+	index := NewLocalIndex(suite.headers, events, results, progress)
+
+	backend := New(
+		suite.state,
+		suite.execClient,
+		nil, nil,
+		suite.blocks,
+		suite.headers,
+		suite.collections,
+		suite.transactions,
+		suite.receipts,
+		suite.chainID,
+		metrics.NewNoopCollector(),
+		nil,
+		false,
+		DefaultMaxHeightRange,
+		nil,
+		nil,
+		index,
+		nil,
+		suite.log,
+	)
+
+	return backend, events, results
+}
+
+func (suite *Suite) TestGetEventsForBlockIDsFromLocalIndex() {
+	suite.state.On("Sealed").Return(suite.snapshot, nil).Maybe()
+
+	// the first block is indexed, the second one is not
+	indexed := unittest.BlockHeaderFixture()
+	indexed.Height = 10
+	notIndexed := unittest.BlockHeaderWithParentFixture(&indexed)
+
+	for _, header := range []*flow.Header{&indexed, &notIndexed} {
+		suite.headers.On("ByBlockID", header.ID()).Return(header, nil)
+		suite.headers.On("ByHeight", header.Height).Return(header, nil).Maybe()
+	}
+
+	// use the static execution node
+	suite.receipts.
+		On("ByBlockID", mock.Anything).
+		Return(flow.ExecutionReceiptList{}, nil)
+
+	backend, events, _ := suite.localIndexBackend(indexed.Height)
+
+	localEvents := []flow.Event{unittest.EventFixture(flow.EventAccountCreated, 0, 0, unittest.IdentifierFixture())}
+	events.
+		On("ByBlockIDEventType", indexed.ID(), flow.EventAccountCreated).
+		Return(localEvents, nil).
+		Once()
+
+	// only the events of the block that is not indexed are requested from the execution node
+	remoteEvents := []flow.Event{unittest.EventFixture(flow.EventAccountCreated, 1, 0, unittest.IdentifierFixture())}
+	suite.execClient.
+		On("GetEventsForBlockIDs", mock.Anything, &execproto.GetEventsForBlockIDsRequest{
+			Type:     string(flow.EventAccountCreated),
+			BlockIds: convert.IdentifiersToMessages([]flow.Identifier{notIndexed.ID()}),
+		}).
+		Return(&execproto.GetEventsForBlockIDsResponse{
+			Results: []*execproto.GetEventsForBlockIDsResponse_Result{{
+				BlockId:     convert.IdentifierToMessage(notIndexed.ID()),
+				BlockHeight: notIndexed.Height,
+				Events:      convert.EventsToMessages(remoteEvents),
+			}},
+		}, nil).
+		Once()
+
+	actual, err := backend.GetEventsForBlockIDs(
+		context.Background(),
+		string(flow.EventAccountCreated),
+		[]flow.Identifier{indexed.ID(), notIndexed.ID()},
+	)
+	suite.Require().NoError(err)
+
+	// the results are returned in the order of the requested blocks
+	suite.Require().Equal([]flow.BlockEvents{
+		{
+			BlockID:        indexed.ID(),
+			BlockHeight:    indexed.Height,
+			BlockTimestamp: indexed.Timestamp,
+			Events:         localEvents,
+		},
+		{
+			BlockID:        notIndexed.ID(),
+			BlockHeight:    notIndexed.Height,
+			BlockTimestamp: notIndexed.Timestamp,
+			Events:         remoteEvents,
+		},
+	}, actual)
+
+	events.AssertExpectations(suite.T())
+	suite.execClient.AssertExpectations(suite.T())
+}
+
+func (suite *Suite) TestGetTransactionResultFromLocalIndex() {
+	block := unittest.BlockFixture()
+	block.Header.Height = 10
+	blockID := block.ID()
+
+	collection := unittest.CollectionFixture(1)
+	light := collection.Light()
+	tx := collection.Transactions[0]
+	txID := tx.ID()
+
+	suite.transactions.On("ByID", txID).Return(tx, nil)
+	suite.collections.On("LightByTransactionID", txID).Return(&light, nil)
+	suite.blocks.On("ByCollectionID", light.ID()).Return(&block, nil)
+	suite.headers.On("ByHeight", block.Header.Height).Return(block.Header, nil)
+
+	sealedSnapshot := new(protocol.Snapshot)
+	sealedSnapshot.On("Head").Return(block.Header, nil)
+	suite.state.On("Sealed").Return(sealedSnapshot)
+
+	backend, events, results := suite.localIndexBackend(block.Header.Height)
+
+	txEvents := []flow.Event{unittest.EventFixture(flow.EventAccountCreated, 0, 0, txID)}
+	results.
+		On("ByBlockIDTransactionID", blockID, txID).
+		Return(&flow.TransactionResult{TransactionID: txID, ErrorMessage: "failed", StatusCode: 1}, nil)
+	events.
+		On("ByBlockIDTransactionID", blockID, txID).
+		Return(txEvents, nil)
+
+	// the execution node is not contacted
+	result, err := backend.GetTransactionResult(context.Background(), txID)
+	suite.Require().NoError(err)
+
+	suite.Require().Equal(flow.TransactionStatusSealed, result.Status)
+	suite.Require().Equal(uint(1), result.StatusCode)
+	suite.Require().Equal("failed", result.ErrorMessage)
+	suite.Require().Equal(txEvents, result.Events)
+	suite.Require().Equal(blockID, result.BlockID)
+
+	suite.execClient.AssertNotCalled(suite.T(), "GetTransactionResult", mock.Anything, mock.Anything)
+}
+
+func (suite *Suite) TestLocalIndexNotStarted() {
+	header := unittest.BlockHeaderFixture()
+
+	progress := new(storagemock.ConsumerProgress)
+	progress.On("ProcessedIndex").Return(uint64(0), storage.ErrNotFound)
+	index := NewLocalIndex(suite.headers, nil, nil, progress)
+
+	indexed, err := index.Indexed(&header)
+	suite.Require().NoError(err)
+	suite.Require().False(indexed)
+
+	// a disabled index has no blocks indexed
+	var disabled *LocalIndex
+	indexed, err = disabled.Indexed(&header)
+	suite.Require().NoError(err)
+	suite.Require().False(indexed)
+}
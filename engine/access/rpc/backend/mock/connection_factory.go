@@ -7,6 +7,8 @@ import (
 
 	execution "github.com/onflow/flow/protobuf/go/flow/execution"
 
+	exeapi "github.com/onflow/flow-go/engine/execution"
+
 	io "io"
 
 	mock "github.com/stretchr/testify/mock"
@@ -80,3 +82,99 @@ func (_m *ConnectionFactory) GetExecutionAPIClient(address string) (execution.Ex
 
 	return r0, r1, r2
 }
+
+// GetExecutionAccountAPIClient provides a mock function with given fields: address
+func (_m *ConnectionFactory) GetExecutionAccountAPIClient(address string) (exeapi.ExecutionAccountAPIClient, io.Closer, error) {
+	ret := _m.Called(address)
+
+	var r0 exeapi.ExecutionAccountAPIClient
+	if rf, ok := ret.Get(0).(func(string) exeapi.ExecutionAccountAPIClient); ok {
+		r0 = rf(address)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(exeapi.ExecutionAccountAPIClient)
+		}
+	}
+
+	var r1 io.Closer
+	if rf, ok := ret.Get(1).(func(string) io.Closer); ok {
+		r1 = rf(address)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(io.Closer)
+		}
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(string) error); ok {
+		r2 = rf(address)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// GetExecutionBatchAPIClient provides a mock function with given fields: address
+func (_m *ConnectionFactory) GetExecutionBatchAPIClient(address string) (exeapi.ExecutionBatchAPIClient, io.Closer, error) {
+	ret := _m.Called(address)
+
+	var r0 exeapi.ExecutionBatchAPIClient
+	if rf, ok := ret.Get(0).(func(string) exeapi.ExecutionBatchAPIClient); ok {
+		r0 = rf(address)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(exeapi.ExecutionBatchAPIClient)
+		}
+	}
+
+	var r1 io.Closer
+	if rf, ok := ret.Get(1).(func(string) io.Closer); ok {
+		r1 = rf(address)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(io.Closer)
+		}
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(string) error); ok {
+		r2 = rf(address)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// GetExecutionDryRunAPIClient provides a mock function with given fields: address
+func (_m *ConnectionFactory) GetExecutionDryRunAPIClient(address string) (exeapi.ExecutionDryRunAPIClient, io.Closer, error) {
+	ret := _m.Called(address)
+
+	var r0 exeapi.ExecutionDryRunAPIClient
+	if rf, ok := ret.Get(0).(func(string) exeapi.ExecutionDryRunAPIClient); ok {
+		r0 = rf(address)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(exeapi.ExecutionDryRunAPIClient)
+		}
+	}
+
+	var r1 io.Closer
+	if rf, ok := ret.Get(1).(func(string) io.Closer); ok {
+		r1 = rf(address)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(io.Closer)
+		}
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(string) error); ok {
+		r2 = rf(address)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
@@ -0,0 +1,318 @@
+package backend
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	lru "github.com/hashicorp/golang-lru"
+	jsoncdc "github.com/onflow/cadence/encoding/json"
+	"github.com/rs/zerolog"
+
+	exeapi "github.com/onflow/flow-go/engine/execution"
+	executionState "github.com/onflow/flow-go/engine/execution/state"
+	"github.com/onflow/flow-go/engine/execution/state/delta"
+	"github.com/onflow/flow-go/fvm"
+	"github.com/onflow/flow-go/fvm/programs"
+	"github.com/onflow/flow-go/ledger"
+	"github.com/onflow/flow-go/ledger/partial"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/state/protocol"
+	"github.com/onflow/flow-go/storage"
+)
+
+// maxSealSearchDepth is the number of finalized blocks following a block that are searched for its seal.
+const maxSealSearchDepth = 1000
+
+// the sizes of the caches of the sealed state commitments and of the verified register values
+const commitCacheSize = 1000
+const registerCacheSize = 10_000
+
+// errBlockNotSealed is returned if a script cannot be executed locally, because the state commitment of the
+// block is not known from a seal.
+var errBlockNotSealed = errors.New("block is not sealed")
+
+// ScriptError is returned by the ScriptExecutor if the script was executed and failed, as opposed to the
+// executor not being able to execute it.
+type ScriptError struct {
+	Err error
+}
+
+func (e *ScriptError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *ScriptError) Unwrap() error {
+	return e.Err
+}
+
+// ScriptExecutor executes scripts on the access node instead of forwarding them to execution nodes.
+//
+// The registers read by a script are fetched on demand from an execution node that executed the block,
+// together with a proof against the state commitment of the block. The state commitment is taken from the
+// seal of the block, so each register value is verified against the sealed state and execution nodes do not
+// need to be trusted. Scripts can therefore only be executed locally at sealed blocks.
+type ScriptExecutor struct {
+	log               zerolog.Logger
+	vm                *fvm.VirtualMachine
+	vmCtx             fvm.Context
+	state             protocol.State
+	headers           storage.Headers
+	blocks            storage.Blocks
+	executionReceipts storage.ExecutionReceipts
+	connFactory       ConnectionFactory
+	commits           *lru.Cache // sealed state commitments by block ID
+	registers         *lru.Cache // verified register values by state commitment and register ID
+}
+
+func NewScriptExecutor(
+	log zerolog.Logger,
+	vmCtx fvm.Context,
+	state protocol.State,
+	headers storage.Headers,
+	blocks storage.Blocks,
+	executionReceipts storage.ExecutionReceipts,
+	connFactory ConnectionFactory,
+) *ScriptExecutor {
+
+	commits, _ := lru.New(commitCacheSize)
+	registers, _ := lru.New(registerCacheSize)
+
+	return &ScriptExecutor{
+		log:               log.With().Str("component", "script_executor").Logger(),
+		vm:                fvm.NewVirtualMachine(fvm.NewInterpreterRuntime()),
+		vmCtx:             vmCtx,
+		state:             state,
+		headers:           headers,
+		blocks:            blocks,
+		executionReceipts: executionReceipts,
+		connFactory:       connFactory,
+		commits:           commits,
+		registers:         registers,
+	}
+}
+
+// ExecuteScript executes the script at the given sealed block and returns its JSON-Cadence encoded value.
+// A *ScriptError is returned if the script failed. Any other error means the script could not be executed
+// locally, e.g. because the block is not sealed or no execution node provided a valid register proof.
+func (e *ScriptExecutor) ExecuteScript(
+	ctx context.Context,
+	blockID flow.Identifier,
+	script []byte,
+	arguments [][]byte,
+) ([]byte, error) {
+
+	header, err := e.headers.ByBlockID(blockID)
+	if err != nil {
+		return nil, fmt.Errorf("could not get header: %w", err)
+	}
+
+	commit, err := e.sealedStateCommitment(header)
+	if err != nil {
+		return nil, err
+	}
+
+	execNodes, err := executionNodesForBlockID(blockID, e.executionReceipts, e.state, e.log)
+	if err != nil {
+		return nil, fmt.Errorf("could not find execution nodes: %w", err)
+	}
+	if len(execNodes) == 0 {
+		return nil, fmt.Errorf("no execution node found for block %s", blockID)
+	}
+
+	reader := &registerReader{
+		ctx:       ctx,
+		executor:  e,
+		blockID:   blockID,
+		commit:    commit,
+		execNodes: execNodes,
+	}
+	defer reader.close()
+
+	blockCtx := fvm.NewContextFromParent(e.vmCtx, fvm.WithBlockHeader(header))
+	proc := fvm.Script(script).WithArguments(arguments...)
+
+	err = func() (err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				err = fmt.Errorf("cadence runtime error: %s", r)
+			}
+		}()
+
+		return e.vm.Run(blockCtx, proc, delta.NewView(reader.get), programs.NewEmptyPrograms())
+	}()
+
+	// a register that could not be read fails the script, which is not the fault of the script
+	if reader.err != nil {
+		return nil, fmt.Errorf("could not read register: %w", reader.err)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute script (internal error): %w", err)
+	}
+	if proc.Err != nil {
+		return nil, &ScriptError{Err: fmt.Errorf("failed to execute script at block (%s): %s", blockID, proc.Err.Error())}
+	}
+
+	value, err := jsoncdc.Encode(proc.Value)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode runtime value: %w", err)
+	}
+
+	return value, nil
+}
+
+// sealedStateCommitment returns the state commitment of the given finalized block from its seal. The seal of a
+// block is included in the payload of one of the finalized blocks following it.
+func (e *ScriptExecutor) sealedStateCommitment(header *flow.Header) (flow.StateCommitment, error) {
+	blockID := header.ID()
+	if commit, ok := e.commits.Get(blockID); ok {
+		return commit.(flow.StateCommitment), nil
+	}
+
+	sealed, err := e.state.Sealed().Head()
+	if err != nil {
+		return nil, fmt.Errorf("could not get latest sealed header: %w", err)
+	}
+	if header.Height > sealed.Height {
+		return nil, errBlockNotSealed
+	}
+
+	final, err := e.state.Final().Head()
+	if err != nil {
+		return nil, fmt.Errorf("could not get latest finalized header: %w", err)
+	}
+
+	for height := header.Height + 1; height <= final.Height && height <= header.Height+maxSealSearchDepth; height++ {
+		block, err := e.blocks.ByHeight(height)
+		if err != nil {
+			return nil, fmt.Errorf("could not get block at height %d: %w", height, err)
+		}
+
+		for _, seal := range block.Payload.Seals {
+			if seal.BlockID == blockID {
+				e.commits.Add(blockID, seal.FinalState)
+				return seal.FinalState, nil
+			}
+		}
+	}
+
+	// the block is not finalized, or it was sealed too long ago to find its seal
+	return nil, errBlockNotSealed
+}
+
+// registerReader reads the registers of a single script execution. Registers are fetched from the execution nodes
+// in turn, keeping the connection to the current node open until the script completes.
+type registerReader struct {
+	ctx       context.Context
+	executor  *ScriptExecutor
+	blockID   flow.Identifier
+	commit    flow.StateCommitment
+	execNodes flow.IdentityList
+
+	client exeapi.ExecutionBatchAPIClient // the client of the current execution node, nil if not connected
+	closer io.Closer
+	err    error // the first error reading a register
+}
+
+// get returns the verified value of the given register at the state commitment of the block.
+func (r *registerReader) get(owner, controller, key string) (flow.RegisterValue, error) {
+	registerID := flow.NewRegisterID(owner, controller, key)
+	cacheKey := string(r.commit) + registerID.String()
+
+	if value, ok := r.executor.registers.Get(cacheKey); ok {
+		return value.(flow.RegisterValue), nil
+	}
+
+	value, err := r.fetch(registerID)
+	if err != nil {
+		if r.err == nil {
+			r.err = err
+		}
+		return nil, err
+	}
+
+	r.executor.registers.Add(cacheKey, value)
+	return value, nil
+}
+
+// fetch gets the proof of the given register from the execution nodes until one of them provides a valid proof.
+func (r *registerReader) fetch(registerID flow.RegisterID) (flow.RegisterValue, error) {
+	req := &exeapi.GetRegisterProofAtBlockIDRequest{
+		BlockId: r.blockID[:],
+		Registers: []*exeapi.RegisterID{{
+			Owner:      []byte(registerID.Owner),
+			Controller: []byte(registerID.Controller),
+			Key:        []byte(registerID.Key),
+		}},
+	}
+
+	for len(r.execNodes) > 0 {
+		execNode := r.execNodes[0]
+
+		value, err := r.tryFetch(execNode, registerID, req)
+		if err == nil {
+			return value, nil
+		}
+
+		r.executor.log.Warn().Err(err).
+			Str("execution_node", execNode.String()).
+			Hex("block_id", r.blockID[:]).
+			Str("register", registerID.String()).
+			Msg("could not fetch register")
+
+		// move on to the next execution node
+		r.close()
+		r.execNodes = r.execNodes[1:]
+	}
+
+	return nil, fmt.Errorf("no execution node provided a valid proof of register %s", registerID.String())
+}
+
+func (r *registerReader) tryFetch(execNode *flow.Identity, registerID flow.RegisterID, req *exeapi.GetRegisterProofAtBlockIDRequest) (flow.RegisterValue, error) {
+	if r.client == nil {
+		client, closer, err := r.executor.connFactory.GetExecutionBatchAPIClient(execNode.Address)
+		if err != nil {
+			return nil, fmt.Errorf("could not connect to execution node: %w", err)
+		}
+		r.client = client
+		r.closer = closer
+	}
+
+	resp, err := r.client.GetRegisterProofAtBlockID(r.ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("could not get register proof: %w", err)
+	}
+
+	return verifiedRegisterValue(resp.GetProof(), r.commit, registerID)
+}
+
+func (r *registerReader) close() {
+	if r.closer != nil {
+		_ = r.closer.Close()
+	}
+	r.client = nil
+	r.closer = nil
+}
+
+// verifiedRegisterValue returns the value of the given register from the proof, after checking the proof against
+// the given state commitment.
+func verifiedRegisterValue(proof flow.StorageProof, commit flow.StateCommitment, registerID flow.RegisterID) (flow.RegisterValue, error) {
+	// building the partial ledger fails if the root hash of the proof does not match the state commitment
+	psmt, err := partial.NewLedger(proof, ledger.State(commit), partial.DefaultPathFinderVersion)
+	if err != nil {
+		return nil, fmt.Errorf("invalid register proof: %w", err)
+	}
+
+	query, err := ledger.NewQuery(ledger.State(commit), []ledger.Key{executionState.RegisterIDToKey(registerID)})
+	if err != nil {
+		return nil, fmt.Errorf("cannot create query: %w", err)
+	}
+
+	values, err := psmt.Get(query)
+	if err != nil {
+		return nil, fmt.Errorf("register proof does not include the register: %w", err)
+	}
+
+	return flow.RegisterValue(values[0]), nil
+}
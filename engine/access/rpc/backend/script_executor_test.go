@@ -0,0 +1,197 @@
+package backend
+
+import (
+	"context"
+	"encoding/binary"
+
+	execproto "github.com/onflow/flow/protobuf/go/flow/execution"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/mock"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	access "github.com/onflow/flow-go/engine/access/mock"
+	exeapi "github.com/onflow/flow-go/engine/execution"
+	executionState "github.com/onflow/flow-go/engine/execution/state"
+	"github.com/onflow/flow-go/fvm"
+	"github.com/onflow/flow-go/ledger"
+	"github.com/onflow/flow-go/ledger/complete"
+	"github.com/onflow/flow-go/ledger/complete/wal/fixtures"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module/metrics"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+// storageUsedScript returns the storage used by the account 0x01, which is read from a single register.
+const storageUsedScript = "pub fun main(): UInt64 { return getAccount(0x01).storageUsed }"
+
+// newRegisterLedger returns a ledger holding the given registers and its state commitment.
+func (suite *Suite) newRegisterLedger(registers map[flow.RegisterID]flow.RegisterValue) (*complete.Ledger, flow.StateCommitment) {
+	l, err := complete.NewLedger(&fixtures.NoopWAL{}, 100, &metrics.NoopCollector{}, zerolog.Nop(), complete.DefaultPathFinderVersion)
+	suite.Require().NoError(err)
+
+	keys := make([]ledger.Key, 0, len(registers))
+	values := make([]ledger.Value, 0, len(registers))
+	for registerID, value := range registers {
+		keys = append(keys, executionState.RegisterIDToKey(registerID))
+		values = append(values, ledger.Value(value))
+	}
+
+	update, err := ledger.NewUpdate(l.InitialState(), keys, values)
+	suite.Require().NoError(err)
+	commit, err := l.Set(update)
+	suite.Require().NoError(err)
+
+	return l, flow.StateCommitment(commit)
+}
+
+// registerProofs returns a function serving the register proofs of the given ledger state, like an execution node does.
+func (suite *Suite) registerProofs(l *complete.Ledger, commit flow.StateCommitment) func(context.Context, *exeapi.GetRegisterProofAtBlockIDRequest, ...grpc.CallOption) *exeapi.RegisterProofResponse {
+	return func(_ context.Context, req *exeapi.GetRegisterProofAtBlockIDRequest, _ ...grpc.CallOption) *exeapi.RegisterProofResponse {
+		keys := make([]ledger.Key, len(req.GetRegisters()))
+		for i, register := range req.GetRegisters() {
+			keys[i] = executionState.RegisterIDToKey(flow.NewRegisterID(
+				string(register.GetOwner()),
+				string(register.GetController()),
+				string(register.GetKey()),
+			))
+		}
+
+		query, err := ledger.NewQuery(ledger.State(commit), keys)
+		suite.Require().NoError(err)
+		proof, err := l.Prove(query)
+		suite.Require().NoError(err)
+
+		return &exeapi.RegisterProofResponse{Proof: proof}
+	}
+}
+
+// scriptExecutorBackend returns a backend executing scripts locally, and a sealed block whose seal commits to the
+// given state. The execution nodes of the block are served by the returned batch client.
+func (suite *Suite) scriptExecutorBackend(commit flow.StateCommitment) (*Backend, flow.Identifier, *access.ExecutionBatchAPIClient) {
+	block := unittest.BlockFixture()
+	blockID := block.ID()
+	suite.headers.On("ByBlockID", blockID).Return(block.Header, nil)
+
+	// the seal of the block is included in its child, which is finalized
+	child := unittest.BlockWithParentFixture(block.Header)
+	child.Payload.Seals = []*flow.Seal{{BlockID: blockID, FinalState: commit}}
+	suite.blocks.On("ByHeight", child.Header.Height).Return(&child, nil)
+
+	suite.state.On("Sealed").Return(suite.snapshot, nil)
+	suite.snapshot.On("Head").Return(child.Header, nil)
+
+	ids := unittest.IdentityListFixture(2)
+	receipt1 := unittest.ReceiptForBlockFixture(&block)
+	receipt1.ExecutorID = ids[0].NodeID
+	receipt2 := unittest.ReceiptForBlockFixture(&block)
+	receipt2.ExecutorID = ids[1].NodeID
+	receipt1.ExecutionResult = receipt2.ExecutionResult
+	suite.receipts.On("ByBlockID", blockID).Return(flow.ExecutionReceiptList{receipt1, receipt2}, nil)
+	suite.snapshot.On("Identities", mock.Anything).Return(ids, nil)
+
+	batchClient := new(access.ExecutionBatchAPIClient)
+	suite.connectionFactory.On("GetExecutionBatchAPIClient", mock.Anything).Return(batchClient, &mockCloser{}, nil)
+	suite.connectionFactory.On("GetExecutionAPIClient", mock.Anything).Return(suite.execClient, &mockCloser{}, nil).Maybe()
+
+	vmCtx := fvm.NewContext(suite.log, fvm.WithChain(suite.chainID.Chain()))
+	executor := NewScriptExecutor(suite.log, vmCtx, suite.state, suite.headers, suite.blocks, suite.receipts, suite.connectionFactory)
+
+	backend := New(
+		suite.state,
+		nil,
+		nil, nil,
+		suite.blocks,
+		suite.headers,
+		suite.collections,
+		suite.transactions,
+		suite.receipts,
+		suite.chainID,
+		metrics.NewNoopCollector(),
+		suite.connectionFactory,
+		false,
+		DefaultMaxHeightRange,
+		nil,
+		nil,
+		nil,
+		executor,
+		suite.log,
+	)
+
+	return backend, blockID, batchClient
+}
+
+func storageUsedRegister(used uint64) (flow.RegisterID, flow.RegisterValue) {
+	address := flow.HexToAddress("01")
+	value := make([]byte, 8)
+	binary.BigEndian.PutUint64(value, used)
+	return flow.NewRegisterID(string(address.Bytes()), "", "storage_used"), value
+}
+
+// TestExecuteScriptLocally tests executing a script on the access node over registers verified against the seal.
+func (suite *Suite) TestExecuteScriptLocally() {
+	registerID, value := storageUsedRegister(42)
+	l, commit := suite.newRegisterLedger(map[flow.RegisterID]flow.RegisterValue{registerID: value})
+
+	backend, blockID, batchClient := suite.scriptExecutorBackend(commit)
+	batchClient.On("GetRegisterProofAtBlockID", mock.Anything, mock.Anything).Return(suite.registerProofs(l, commit), nil)
+
+	result, err := backend.ExecuteScriptAtBlockID(context.Background(), blockID, []byte(storageUsedScript), nil)
+	suite.Require().NoError(err)
+	suite.Require().JSONEq(`{"type":"UInt64","value":"42"}`, string(result))
+
+	// the verified registers are cached, so executing the script again does not fetch them again
+	fetched := len(batchClient.Calls)
+	suite.Require().NotZero(fetched)
+
+	result, err = backend.ExecuteScriptAtBlockID(context.Background(), blockID, []byte(storageUsedScript), nil)
+	suite.Require().NoError(err)
+	suite.Require().JSONEq(`{"type":"UInt64","value":"42"}`, string(result))
+	suite.Require().Len(batchClient.Calls, fetched)
+
+	// the script never reached the execution nodes
+	suite.execClient.AssertNotCalled(suite.T(), "ExecuteScriptAtBlockID", mock.Anything, mock.Anything)
+}
+
+// TestExecuteScriptLocallyInvalidProof tests that register values that do not match the sealed state are rejected,
+// and the script is executed on an execution node instead.
+func (suite *Suite) TestExecuteScriptLocallyInvalidProof() {
+	registerID, value := storageUsedRegister(42)
+	_, commit := suite.newRegisterLedger(map[flow.RegisterID]flow.RegisterValue{registerID: value})
+
+	// the execution nodes serve proofs of a state in which the account uses a different amount of storage
+	registerID, value = storageUsedRegister(1000)
+	forged, forgedCommit := suite.newRegisterLedger(map[flow.RegisterID]flow.RegisterValue{registerID: value})
+
+	backend, blockID, batchClient := suite.scriptExecutorBackend(commit)
+	batchClient.On("GetRegisterProofAtBlockID", mock.Anything, mock.Anything).Return(suite.registerProofs(forged, forgedCommit), nil)
+
+	expected := []byte(`{"type":"UInt64","value":"42"}`)
+	suite.execClient.On("ExecuteScriptAtBlockID", mock.Anything, mock.Anything).
+		Return(&execproto.ExecuteScriptAtBlockIDResponse{Value: expected}, nil).Once()
+
+	result, err := backend.ExecuteScriptAtBlockID(context.Background(), blockID, []byte(storageUsedScript), nil)
+	suite.Require().NoError(err)
+	suite.Require().Equal(expected, result)
+
+	// both execution nodes were asked for a proof before falling back
+	batchClient.AssertNumberOfCalls(suite.T(), "GetRegisterProofAtBlockID", 2)
+	suite.execClient.AssertExpectations(suite.T())
+}
+
+// TestExecuteScriptLocallyFailure tests that a failing script is reported without executing it on an execution node.
+func (suite *Suite) TestExecuteScriptLocallyFailure() {
+	registerID, value := storageUsedRegister(42)
+	l, commit := suite.newRegisterLedger(map[flow.RegisterID]flow.RegisterValue{registerID: value})
+
+	backend, blockID, batchClient := suite.scriptExecutorBackend(commit)
+	batchClient.On("GetRegisterProofAtBlockID", mock.Anything, mock.Anything).Return(suite.registerProofs(l, commit), nil)
+
+	script := []byte(`pub fun main(): Int { panic("failed") }`)
+	_, err := backend.ExecuteScriptAtBlockID(context.Background(), blockID, script, nil)
+	suite.Require().Error(err)
+	suite.Require().Equal(codes.InvalidArgument, status.Code(err))
+
+	suite.execClient.AssertNotCalled(suite.T(), "ExecuteScriptAtBlockID", mock.Anything, mock.Anything)
+}
@@ -8,6 +8,7 @@ import (
 	"net/http"
 	"time"
 
+	"github.com/dgraph-io/badger/v2"
 	grpc_prometheus "github.com/grpc-ecosystem/go-grpc-prometheus"
 	accessproto "github.com/onflow/flow/protobuf/go/flow/access"
 	execproto "github.com/onflow/flow/protobuf/go/flow/execution"
@@ -18,27 +19,38 @@ import (
 	"github.com/onflow/flow-go/access"
 	legacyaccess "github.com/onflow/flow-go/access/legacy"
 	"github.com/onflow/flow-go/engine"
+	"github.com/onflow/flow-go/engine/access/rest"
 	"github.com/onflow/flow-go/engine/access/rpc/backend"
+	"github.com/onflow/flow-go/fvm"
 	"github.com/onflow/flow-go/model/flow"
 	"github.com/onflow/flow-go/module"
 	"github.com/onflow/flow-go/state/protocol"
 	"github.com/onflow/flow-go/storage"
+	bstorage "github.com/onflow/flow-go/storage/badger"
 	grpcutils "github.com/onflow/flow-go/utils/grpc"
 )
 
 // Config defines the configurable options for the access node server
 type Config struct {
-	GRPCListenAddr            string        // the GRPC server address as ip:port
-	HTTPListenAddr            string        // the HTTP web proxy address as ip:port
-	ExecutionAddr             string        // the address of the upstream execution node
-	CollectionAddr            string        // the address of the upstream collection node
-	HistoricalAccessAddrs     string        // the list of all access nodes from previous spork
-	MaxMsgSize                int           // GRPC max message size
-	ExecutionClientTimeout    time.Duration // execution API GRPC client timeout
-	CollectionClientTimeout   time.Duration // collection API GRPC client timeout
-	MaxHeightRange            uint          // max size of height range requests
-	PreferredExecutionNodeIDs []string      // preferred list of upstream execution node IDs
-	FixedExecutionNodeIDs     []string      // fixed list of execution node IDs to choose from if no node node ID can be chosen from the PreferredExecutionNodeIDs
+	GRPCListenAddr              string        // the GRPC server address as ip:port
+	HTTPListenAddr              string        // the HTTP web proxy address as ip:port
+	RESTListenAddr              string        // the REST API server address as ip:port, the REST API is disabled if empty
+	ExecutionAddr               string        // the address of the upstream execution node
+	CollectionAddr              string        // the address of the upstream collection node
+	HistoricalAccessAddrs       string        // the list of all access nodes from previous spork
+	HistoricalAccessHeights     string        // the height ranges of the previous sporks as start-end, in the order of HistoricalAccessAddrs, queries at these heights are routed to the historical access nodes
+	MaxMsgSize                  int           // GRPC max message size
+	ExecutionClientTimeout      time.Duration // execution API GRPC client timeout
+	CollectionClientTimeout     time.Duration // collection API GRPC client timeout
+	MaxHeightRange              uint          // max size of height range requests
+	PreferredExecutionNodeIDs   []string      // preferred list of upstream execution node IDs
+	FixedExecutionNodeIDs       []string      // fixed list of execution node IDs to choose from if no node node ID can be chosen from the PreferredExecutionNodeIDs
+	LocalIndexEnabled           bool          // index the events and transaction results of sealed blocks locally
+	LocalIndexInterval          time.Duration // how often the local index is updated with newly sealed blocks
+	LocalScriptExecutionEnabled bool          // execute scripts locally over register values verified against the sealed state
+	APIKeyHeader                string        // the request header holding the API key that identifies clients for rate limiting, clients are identified by IP address if not set or absent
+	APIKeys                     []string      // the API keys accepted in the API key header, clients sending any other key are identified by IP address
+	APIQuota                    QuotaConfig   // the per client quota for expensive API calls
 }
 
 // Engine implements a gRPC server with a simplified version of the Observation API.
@@ -46,8 +58,10 @@ type Engine struct {
 	unit        *engine.Unit
 	log         zerolog.Logger
 	backend     *backend.Backend // the gRPC service implementation
+	indexer     *backend.Indexer // indexes execution results locally, nil if local indexing is disabled
 	grpcServer  *grpc.Server     // the gRPC server
 	httpServer  *http.Server
+	restServer  *http.Server // the REST API server, nil if the REST API is disabled
 	config      Config
 	grpcAddress net.Addr
 }
@@ -64,14 +78,18 @@ func New(log zerolog.Logger,
 	collections storage.Collections,
 	transactions storage.Transactions,
 	executionReceipts storage.ExecutionReceipts,
+	db *badger.DB,
+	events storage.Events,
+	transactionResults storage.TransactionResults,
 	chainID flow.ChainID,
 	transactionMetrics module.TransactionMetrics,
+	rateLimitMetrics module.RateLimitMetrics,
 	collectionGRPCPort uint,
 	executionGRPCPort uint,
 	retryEnabled bool,
 	rpcMetricsEnabled bool,
-	apiRatelimits map[string]int, // the api rate limit (max calls per second) for each of the Access API e.g. Ping->100, GetTransaction->300
-	apiBurstLimits map[string]int, // the api burst limit (max calls at the same time) for each of the Access API e.g. Ping->50, GetTransaction->10
+	apiRatelimits map[string]int, // the per client api rate limit (max calls per second) for each of the Access API e.g. Ping->100, GetTransaction->300
+	apiBurstLimits map[string]int, // the per client api burst limit (max calls at the same time) for each of the Access API e.g. Ping->50, GetTransaction->10
 ) *Engine {
 
 	log = log.With().Str("engine", "rpc").Logger()
@@ -86,20 +104,33 @@ func New(log zerolog.Logger,
 		grpc.MaxSendMsgSize(config.MaxMsgSize),
 	}
 
-	var interceptors []grpc.UnaryServerInterceptor // ordered list of interceptors
+	var interceptors []grpc.UnaryServerInterceptor        // ordered list of interceptors
+	var streamInterceptors []grpc.StreamServerInterceptor // ordered list of stream interceptors
 	// if rpc metrics is enabled, first create the grpc metrics interceptor
 	if rpcMetricsEnabled {
 		interceptors = append(interceptors, grpc_prometheus.UnaryServerInterceptor)
+		streamInterceptors = append(streamInterceptors, grpc_prometheus.StreamServerInterceptor)
 	}
 
 	// add the logging interceptor
 	interceptors = append(interceptors, loggingInterceptor(log)...)
 
-	if len(apiRatelimits) > 0 {
+	// the rate limiter is shared by the gRPC API and the REST API, so that a client has the same limits on both
+	var rateLimiter *rateLimiterInterceptor
+	if len(apiRatelimits) > 0 || config.APIQuota.Limit > 0 {
 		// create a rate limit interceptor
-		rateLimitInterceptor := NewRateLimiterInterceptor(log, apiRatelimits, apiBurstLimits).unaryServerInterceptor
+		rateLimiter = NewRateLimiterInterceptor(
+			log,
+			rateLimitMetrics,
+			config.APIKeyHeader,
+			config.APIKeys,
+			apiRatelimits,
+			apiBurstLimits,
+			config.APIQuota,
+		)
 		// append the rate limit interceptor to the list of interceptors
-		interceptors = append(interceptors, rateLimitInterceptor)
+		interceptors = append(interceptors, rateLimiter.unaryServerInterceptor)
+		streamInterceptors = append(streamInterceptors, rateLimiter.streamServerInterceptor)
 	}
 
 	if len(interceptors) > 0 {
@@ -108,6 +139,10 @@ func New(log zerolog.Logger,
 		grpcOpts = append(grpcOpts, chainedInterceptors)
 	}
 
+	if len(streamInterceptors) > 0 {
+		grpcOpts = append(grpcOpts, grpc.ChainStreamInterceptor(streamInterceptors...))
+	}
+
 	grpcServer := grpc.NewServer(grpcOpts...)
 
 	// wrap the GRPC server with an HTTP proxy server to serve HTTP clients
@@ -120,7 +155,31 @@ func New(log zerolog.Logger,
 		ExecutionNodeGRPCTimeout:  config.ExecutionClientTimeout,
 	}
 
-	backend := backend.New(
+	var localIndex *backend.LocalIndex
+	if config.LocalIndexEnabled {
+		localIndex = backend.NewLocalIndex(
+			headers,
+			events,
+			transactionResults,
+			bstorage.NewConsumerProgress(db, module.ConsumeProgressAccessIndexedHeight),
+		)
+	}
+
+	var scriptExecutor *backend.ScriptExecutor
+	if config.LocalScriptExecutionEnabled {
+		vmCtx := fvm.NewContext(log, fvm.WithChain(chainID.Chain()), fvm.WithBlocks(fvm.NewBlockFinder(headers)))
+		scriptExecutor = backend.NewScriptExecutor(
+			log,
+			vmCtx,
+			state,
+			headers,
+			blocks,
+			executionReceipts,
+			connectionFactory,
+		)
+	}
+
+	accessBackend := backend.New(
 		state,
 		executionRPC,
 		collectionRPC,
@@ -137,21 +196,73 @@ func New(log zerolog.Logger,
 		config.MaxHeightRange,
 		config.PreferredExecutionNodeIDs,
 		config.FixedExecutionNodeIDs,
+		localIndex,
+		scriptExecutor,
 		log,
 	)
 
+	sporks, err := parseHistoricalSporks(config.HistoricalAccessHeights, historicalAccessNodes)
+	if err != nil {
+		log.Fatal().Err(err).Msg("invalid historical access node height ranges")
+	}
+
+	var accessAPI access.API = accessBackend
+	if len(sporks) > 0 {
+		accessAPI = newHistoricalAPI(log, accessBackend, sporks, config.MaxHeightRange)
+	}
+
 	eng := &Engine{
 		log:        log,
 		unit:       engine.NewUnit(),
-		backend:    backend,
+		backend:    accessBackend,
 		grpcServer: grpcServer,
 		httpServer: httpServer,
 		config:     config,
 	}
 
+	if config.RESTListenAddr != "" {
+		var restLimiter rest.RateLimiter
+		if rateLimiter != nil {
+			restLimiter = rateLimiter
+		}
+		eng.restServer = rest.NewServer(accessAPI, config.RESTListenAddr, chainID.Chain(), restLimiter, log)
+	}
+
+	if config.LocalIndexEnabled {
+		eng.indexer = backend.NewIndexer(log, db, state, blocks, collections, localIndex, accessBackend)
+	}
+
+	var accessHandler accessproto.AccessAPIServer = access.NewHandler(accessAPI, chainID.Chain())
+	if len(sporks) > 0 {
+		accessHandler = newHistoricalRouter(accessHandler, sporks)
+	}
+
 	accessproto.RegisterAccessAPIServer(
 		eng.grpcServer,
-		access.NewHandler(backend, chainID.Chain()),
+		accessHandler,
+	)
+
+	// The following services are not part of the published Flow protobuf definitions yet, so their service
+	// descriptors and the messages without a published counterpart are declared by hand, in the access package
+	// and, for the messages shared with the Execution API, in the execution engine package.
+	access.RegisterAccessStreamAPIServer(
+		eng.grpcServer,
+		access.NewStreamHandler(accessBackend, chainID.Chain()),
+	)
+
+	access.RegisterAccessBatchAPIServer(
+		eng.grpcServer,
+		access.NewBatchHandler(accessAPI, chainID.Chain()),
+	)
+
+	access.RegisterAccessAccountAPIServer(
+		eng.grpcServer,
+		access.NewAccountHandler(accessAPI, chainID.Chain()),
+	)
+
+	access.RegisterAccessDryRunAPIServer(
+		eng.grpcServer,
+		access.NewDryRunHandler(accessAPI, chainID.Chain()),
 	)
 
 	if rpcMetricsEnabled {
@@ -163,7 +274,7 @@ func New(log zerolog.Logger,
 	// Register legacy gRPC handlers for backwards compatibility, to be removed at a later date
 	legacyaccessproto.RegisterAccessAPIServer(
 		eng.grpcServer,
-		legacyaccess.NewHandler(backend, chainID.Chain()),
+		legacyaccess.NewHandler(accessAPI, chainID.Chain()),
 	)
 
 	return eng
@@ -175,6 +286,12 @@ func New(log zerolog.Logger,
 func (e *Engine) Ready() <-chan struct{} {
 	e.unit.Launch(e.serveGRPC)
 	e.unit.Launch(e.serveGRPCWebProxy)
+	if e.restServer != nil {
+		e.unit.Launch(e.serveREST)
+	}
+	if e.indexer != nil {
+		e.unit.LaunchPeriodically(e.indexSealedBlocks, e.config.LocalIndexInterval, 0)
+	}
 	return e.unit.Ready()
 }
 
@@ -188,6 +305,15 @@ func (e *Engine) Done() <-chan struct{} {
 			if err != nil {
 				e.log.Error().Err(err).Msg("error stopping http server")
 			}
+		},
+		func() {
+			if e.restServer == nil {
+				return
+			}
+			err := e.restServer.Shutdown(context.Background())
+			if err != nil {
+				e.log.Error().Err(err).Msg("error stopping rest server")
+			}
 		})
 }
 
@@ -242,6 +368,14 @@ func (e *Engine) serveGRPC() {
 	}
 }
 
+// indexSealedBlocks adds the execution results of newly sealed blocks to the local index
+func (e *Engine) indexSealedBlocks() {
+	err := e.indexer.IndexSealedBlocks(e.unit.Ctx())
+	if err != nil {
+		e.log.Error().Err(err).Msg("failed to index sealed blocks")
+	}
+}
+
 // serveGRPCWebProxy starts the gRPC web proxy server
 func (e *Engine) serveGRPCWebProxy() {
 	log := e.log.With().Str("http_proxy_address", e.config.HTTPListenAddr).Logger()
@@ -256,3 +390,18 @@ func (e *Engine) serveGRPCWebProxy() {
 		e.log.Err(err).Msg("failed to start the http proxy server")
 	}
 }
+
+// serveREST starts the REST API server
+func (e *Engine) serveREST() {
+	log := e.log.With().Str("rest_api_address", e.config.RESTListenAddr).Logger()
+
+	log.Info().Msg("starting rest server on address")
+
+	err := e.restServer.ListenAndServe()
+	if errors.Is(err, http.ErrServerClosed) {
+		return
+	}
+	if err != nil {
+		e.log.Err(err).Msg("failed to start the rest server")
+	}
+}
@@ -0,0 +1,257 @@
+package rpc
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/golang/protobuf/ptypes"
+	accessproto "github.com/onflow/flow/protobuf/go/flow/access"
+	"github.com/onflow/flow/protobuf/go/flow/entities"
+	"github.com/rs/zerolog"
+
+	"github.com/onflow/flow-go/access"
+	"github.com/onflow/flow-go/engine/common/rpc/convert"
+	"github.com/onflow/flow-go/model/flow"
+)
+
+// historicalAPI serves the block, account, script and event queries at the heights of previous sporks from the
+// access nodes of those sporks, and all other queries from the local API. It backs every API server of this node, so
+// the queries are routed the same way over gRPC, gRPC-web, REST and the batch endpoints.
+type historicalAPI struct {
+	access.API     // serves the queries at the heights of the current spork
+	log            zerolog.Logger
+	sporks         historicalSporks
+	maxHeightRange uint // max size of height range requests, across sporks
+}
+
+func newHistoricalAPI(log zerolog.Logger, local access.API, sporks historicalSporks, maxHeightRange uint) *historicalAPI {
+	return &historicalAPI{
+		API:            local,
+		log:            log.With().Str("component", "historical_api").Logger(),
+		sporks:         sporks,
+		maxHeightRange: maxHeightRange,
+	}
+}
+
+// GetBlockHeaderByHeight returns the block header at the height of a previous spork as returned by the access node
+// of the spork, which lacks the fields its ID is computed from. The servers of this node get its ID from
+// GetHistoricalBlockHeaderByHeight.
+func (a *historicalAPI) GetBlockHeaderByHeight(ctx context.Context, height uint64) (*flow.Header, error) {
+	header, _, ok, err := a.GetHistoricalBlockHeaderByHeight(ctx, height)
+	if !ok {
+		return a.API.GetBlockHeaderByHeight(ctx, height)
+	}
+	return header, err
+}
+
+func (a *historicalAPI) GetHistoricalBlockHeaderByHeight(ctx context.Context, height uint64) (*flow.Header, flow.Identifier, bool, error) {
+	spork := a.sporks.atHeight(height)
+	if spork == nil {
+		return nil, flow.ZeroID, false, nil
+	}
+
+	resp, err := spork.client.GetBlockHeaderByHeight(ctx, &accessproto.GetBlockHeaderByHeightRequest{Height: height})
+	if err != nil {
+		return nil, flow.ZeroID, true, err
+	}
+	header, err := messageToBlockHeader(resp.GetBlock())
+	if err != nil {
+		return nil, flow.ZeroID, true, err
+	}
+	return header, flow.HashToID(resp.GetBlock().GetId()), true, nil
+}
+
+// GetBlockByHeight returns the block at the height of a previous spork as returned by the access node of the spork,
+// which lacks the fields its ID is computed from. The servers of this node get its ID from GetHistoricalBlockByHeight.
+func (a *historicalAPI) GetBlockByHeight(ctx context.Context, height uint64) (*flow.Block, error) {
+	block, _, ok, err := a.GetHistoricalBlockByHeight(ctx, height)
+	if !ok {
+		return a.API.GetBlockByHeight(ctx, height)
+	}
+	return block, err
+}
+
+func (a *historicalAPI) GetHistoricalBlockByHeight(ctx context.Context, height uint64) (*flow.Block, flow.Identifier, bool, error) {
+	spork := a.sporks.atHeight(height)
+	if spork == nil {
+		return nil, flow.ZeroID, false, nil
+	}
+
+	resp, err := spork.client.GetBlockByHeight(ctx, &accessproto.GetBlockByHeightRequest{Height: height})
+	if err != nil {
+		return nil, flow.ZeroID, true, err
+	}
+	block, err := messageToBlock(resp.GetBlock())
+	if err != nil {
+		return nil, flow.ZeroID, true, err
+	}
+	return block, flow.HashToID(resp.GetBlock().GetId()), true, nil
+}
+
+func (a *historicalAPI) GetAccountAtBlockHeight(ctx context.Context, address flow.Address, height uint64) (*flow.Account, error) {
+	spork := a.sporks.atHeight(height)
+	if spork == nil {
+		return a.API.GetAccountAtBlockHeight(ctx, address, height)
+	}
+
+	resp, err := spork.client.GetAccountAtBlockHeight(ctx, &accessproto.GetAccountAtBlockHeightRequest{
+		Address:     address.Bytes(),
+		BlockHeight: height,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return convert.MessageToAccount(resp.GetAccount())
+}
+
+// GetAccountsAtBlockHeight looks up the accounts of a batch at the height of a previous spork one by one, as the
+// access nodes of previous sporks have no batch endpoint.
+func (a *historicalAPI) GetAccountsAtBlockHeight(ctx context.Context, addresses []flow.Address, height uint64) ([]access.AccountResult, error) {
+	if a.sporks.atHeight(height) == nil || len(addresses) == 0 {
+		return a.API.GetAccountsAtBlockHeight(ctx, addresses, height)
+	}
+
+	results := make([]access.AccountResult, len(addresses))
+	for i, address := range addresses {
+		account, err := a.GetAccountAtBlockHeight(ctx, address, height)
+		if err != nil {
+			results[i] = access.AccountResult{ErrorMessage: err.Error()}
+			continue
+		}
+		results[i] = access.AccountResult{Account: account}
+	}
+	return results, nil
+}
+
+func (a *historicalAPI) ExecuteScriptAtBlockHeight(ctx context.Context, blockHeight uint64, script []byte, arguments [][]byte) ([]byte, error) {
+	spork := a.sporks.atHeight(blockHeight)
+	if spork == nil {
+		return a.API.ExecuteScriptAtBlockHeight(ctx, blockHeight, script, arguments)
+	}
+
+	resp, err := spork.client.ExecuteScriptAtBlockHeight(ctx, &accessproto.ExecuteScriptAtBlockHeightRequest{
+		BlockHeight: blockHeight,
+		Script:      script,
+		Arguments:   arguments,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp.GetValue(), nil
+}
+
+// GetEventsForHeightRange requests the events of each part of the height range from the node serving it, and
+// merges the results in height order. A range spanning a spork boundary is served by more than one node, the max
+// height range applies to the whole range.
+func (a *historicalAPI) GetEventsForHeightRange(ctx context.Context, eventType string, startHeight, endHeight uint64) ([]flow.BlockEvents, error) {
+	if endHeight < startHeight {
+		return a.API.GetEventsForHeightRange(ctx, eventType, startHeight, endHeight)
+	}
+
+	rangeSize := endHeight - startHeight + 1 // range is inclusive on both ends
+	if rangeSize > uint64(a.maxHeightRange) {
+		return nil, fmt.Errorf("requested block range (%d) exceeded maximum (%d)", rangeSize, a.maxHeightRange)
+	}
+
+	parts := a.sporks.split(startHeight, endHeight)
+	if len(parts) == 1 && parts[0].spork == nil {
+		return a.API.GetEventsForHeightRange(ctx, eventType, startHeight, endHeight)
+	}
+
+	var results []flow.BlockEvents
+	for _, part := range parts {
+		var partResults []flow.BlockEvents
+		var err error
+		if part.spork != nil {
+			partResults, err = historicalEvents(ctx, part.spork, eventType, part.startHeight, part.endHeight)
+		} else {
+			partResults, err = a.API.GetEventsForHeightRange(ctx, eventType, part.startHeight, part.endHeight)
+		}
+		if err != nil {
+			a.log.Debug().Err(err).
+				Uint64("start_height", part.startHeight).
+				Uint64("end_height", part.endHeight).
+				Bool("historical", part.spork != nil).
+				Msg("could not get events for part of height range")
+			return nil, err
+		}
+
+		results = append(results, partResults...)
+	}
+
+	return results, nil
+}
+
+// historicalEvents requests the events of a height range within the given previous spork from its access node.
+func historicalEvents(ctx context.Context, spork *historicalSpork, eventType string, startHeight, endHeight uint64) ([]flow.BlockEvents, error) {
+	resp, err := spork.client.GetEventsForHeightRange(ctx, &accessproto.GetEventsForHeightRangeRequest{
+		Type:        eventType,
+		StartHeight: startHeight,
+		EndHeight:   endHeight,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]flow.BlockEvents, len(resp.GetResults()))
+	for i, result := range resp.GetResults() {
+		timestamp, err := ptypes.Timestamp(result.GetBlockTimestamp())
+		if err != nil {
+			return nil, err
+		}
+		results[i] = flow.BlockEvents{
+			BlockID:        flow.HashToID(result.GetBlockId()),
+			BlockHeight:    result.GetBlockHeight(),
+			BlockTimestamp: timestamp,
+			Events:         convert.MessagesToEvents(result.GetEvents()),
+		}
+	}
+	return results, nil
+}
+
+// messageToBlockHeader returns the block header of a previous spork from the fields returned by its access node.
+func messageToBlockHeader(m *entities.BlockHeader) (*flow.Header, error) {
+	timestamp, err := ptypes.Timestamp(m.GetTimestamp())
+	if err != nil {
+		return nil, err
+	}
+	return &flow.Header{
+		ParentID:  flow.HashToID(m.GetParentId()),
+		Height:    m.GetHeight(),
+		Timestamp: timestamp,
+	}, nil
+}
+
+// messageToBlock returns the block of a previous spork from the fields returned by its access node.
+func messageToBlock(m *entities.Block) (*flow.Block, error) {
+	timestamp, err := ptypes.Timestamp(m.GetTimestamp())
+	if err != nil {
+		return nil, err
+	}
+
+	header := &flow.Header{
+		ParentID:  flow.HashToID(m.GetParentId()),
+		Height:    m.GetHeight(),
+		Timestamp: timestamp,
+	}
+	if len(m.GetSignatures()) > 0 {
+		header.ParentVoterSig = m.GetSignatures()[0]
+	}
+
+	payload := &flow.Payload{}
+	for _, g := range m.GetCollectionGuarantees() {
+		guarantee := &flow.CollectionGuarantee{CollectionID: flow.HashToID(g.GetCollectionId())}
+		if len(g.GetSignatures()) > 0 {
+			guarantee.Signature = g.GetSignatures()[0]
+		}
+		payload.Guarantees = append(payload.Guarantees, guarantee)
+	}
+	for _, s := range m.GetBlockSeals() {
+		payload.Seals = append(payload.Seals, &flow.Seal{
+			BlockID:  flow.HashToID(s.GetBlockId()),
+			ResultID: flow.HashToID(s.GetExecutionReceiptId()),
+		})
+	}
+
+	return &flow.Block{Header: header, Payload: payload}, nil
+}
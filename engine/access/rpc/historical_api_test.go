@@ -0,0 +1,212 @@
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/onflow/flow/protobuf/go/flow/access"
+	"github.com/onflow/flow/protobuf/go/flow/entities"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	apimock "github.com/onflow/flow-go/access/mock"
+	accessmock "github.com/onflow/flow-go/engine/access/mock"
+	"github.com/onflow/flow-go/engine/access/rest"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+// eventsAtHeights returns an events response with a result for each height of the given range.
+func eventsAtHeights(start, end uint64) *access.EventsResponse {
+	resp := &access.EventsResponse{}
+	for height := start; height <= end; height++ {
+		resp.Results = append(resp.Results, &access.EventsResponse_Result{BlockHeight: height, BlockTimestamp: ptypes.TimestampNow()})
+	}
+	return resp
+}
+
+// blockEventsAtHeights returns the block events of each height of the given range.
+func blockEventsAtHeights(start, end uint64) []flow.BlockEvents {
+	var results []flow.BlockEvents
+	for height := start; height <= end; height++ {
+		results = append(results, flow.BlockEvents{BlockHeight: height})
+	}
+	return results
+}
+
+func blockEventsHeights(results []flow.BlockEvents) []uint64 {
+	heights := make([]uint64, len(results))
+	for i, result := range results {
+		heights[i] = result.BlockHeight
+	}
+	return heights
+}
+
+// newTestHistoricalAPI returns an API with two previous sporks at the heights 0-99 and 100-199, and a max height range
+// of 250.
+func newTestHistoricalAPI(t *testing.T) (*historicalAPI, *apimock.API, []*accessmock.AccessAPIClient) {
+	local := new(apimock.API)
+	clients := []*accessmock.AccessAPIClient{new(accessmock.AccessAPIClient), new(accessmock.AccessAPIClient)}
+
+	sporks, err := parseHistoricalSporks("0-99,100-199", []access.AccessAPIClient{clients[0], clients[1]})
+	require.NoError(t, err)
+
+	return newHistoricalAPI(zerolog.Nop(), local, sporks, 250), local, clients
+}
+
+// TestHistoricalAPIByHeight tests that account and script queries at a height are served by the node of the spork
+// of the height.
+func TestHistoricalAPIByHeight(t *testing.T) {
+	api, local, clients := newTestHistoricalAPI(t)
+	ctx := context.Background()
+	address := flow.HexToAddress("01")
+
+	clients[0].On("GetAccountAtBlockHeight", ctx, &access.GetAccountAtBlockHeightRequest{Address: address.Bytes(), BlockHeight: 50}).
+		Return(&access.AccountResponse{Account: &entities.Account{Address: address.Bytes(), Balance: 10}}, nil).Once()
+	account, err := api.GetAccountAtBlockHeight(ctx, address, 50)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(10), account.Balance)
+
+	clients[1].On("ExecuteScriptAtBlockHeight", ctx, &access.ExecuteScriptAtBlockHeightRequest{BlockHeight: 150, Script: []byte("script")}).
+		Return(&access.ExecuteScriptResponse{Value: []byte("value")}, nil).Once()
+	value, err := api.ExecuteScriptAtBlockHeight(ctx, 150, []byte("script"), nil)
+	require.NoError(t, err)
+	assert.Equal(t, []byte("value"), value)
+
+	// a batch at a height of a previous spork is looked up account by account, failures are reported per account
+	other := flow.HexToAddress("02")
+	clients[1].On("GetAccountAtBlockHeight", ctx, &access.GetAccountAtBlockHeightRequest{Address: address.Bytes(), BlockHeight: 150}).
+		Return(&access.AccountResponse{Account: &entities.Account{Address: address.Bytes()}}, nil).Once()
+	clients[1].On("GetAccountAtBlockHeight", ctx, &access.GetAccountAtBlockHeightRequest{Address: other.Bytes(), BlockHeight: 150}).
+		Return(nil, assert.AnError).Once()
+	results, err := api.GetAccountsAtBlockHeight(ctx, []flow.Address{address, other}, 150)
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+	assert.NotNil(t, results[0].Account)
+	assert.NotEmpty(t, results[1].ErrorMessage)
+
+	// block headers are returned along with the IDs returned by the node
+	id := unittest.IdentifierFixture()
+	clients[0].On("GetBlockHeaderByHeight", ctx, &access.GetBlockHeaderByHeightRequest{Height: 50}).
+		Return(&access.BlockHeaderResponse{Block: &entities.BlockHeader{Id: id[:], Height: 50, Timestamp: ptypes.TimestampNow()}}, nil).Once()
+	header, headerID, ok, err := api.GetHistoricalBlockHeaderByHeight(ctx, 50)
+	require.NoError(t, err)
+	require.True(t, ok)
+	assert.Equal(t, id, headerID)
+	assert.Equal(t, uint64(50), header.Height)
+
+	// heights of the current spork are served locally
+	local.On("GetAccountAtBlockHeight", ctx, address, uint64(200)).Return(&flow.Account{}, nil).Once()
+	_, err = api.GetAccountAtBlockHeight(ctx, address, 200)
+	require.NoError(t, err)
+
+	local.AssertExpectations(t)
+	clients[0].AssertExpectations(t)
+	clients[1].AssertExpectations(t)
+}
+
+// TestHistoricalAPIEventsAcrossSporks tests that the events of a height range spanning spork boundaries are
+// requested from the node of each spork and merged in height order.
+func TestHistoricalAPIEventsAcrossSporks(t *testing.T) {
+	api, local, clients := newTestHistoricalAPI(t)
+	ctx := context.Background()
+
+	clients[0].On("GetEventsForHeightRange", ctx, &access.GetEventsForHeightRangeRequest{Type: "A.Event", StartHeight: 95, EndHeight: 99}).
+		Return(eventsAtHeights(95, 99), nil).Once()
+	clients[1].On("GetEventsForHeightRange", ctx, &access.GetEventsForHeightRangeRequest{Type: "A.Event", StartHeight: 100, EndHeight: 199}).
+		Return(eventsAtHeights(100, 199), nil).Once()
+	local.On("GetEventsForHeightRange", ctx, "A.Event", uint64(200), uint64(204)).
+		Return(blockEventsAtHeights(200, 204), nil).Once()
+
+	results, err := api.GetEventsForHeightRange(ctx, "A.Event", 95, 204)
+	require.NoError(t, err)
+	assert.Equal(t, blockEventsHeights(blockEventsAtHeights(95, 204)), blockEventsHeights(results))
+
+	// a range within a single spork is requested as it is
+	clients[0].On("GetEventsForHeightRange", ctx, &access.GetEventsForHeightRangeRequest{Type: "A.Event", StartHeight: 10, EndHeight: 20}).
+		Return(eventsAtHeights(10, 20), nil).Once()
+	results, err = api.GetEventsForHeightRange(ctx, "A.Event", 10, 20)
+	require.NoError(t, err)
+	assert.Len(t, results, 11)
+
+	local.AssertExpectations(t)
+	clients[0].AssertExpectations(t)
+	clients[1].AssertExpectations(t)
+}
+
+// TestHistoricalAPIEventsError tests that the events of a height range are not returned if a spork node fails.
+func TestHistoricalAPIEventsError(t *testing.T) {
+	api, local, clients := newTestHistoricalAPI(t)
+	ctx := context.Background()
+
+	clients[1].On("GetEventsForHeightRange", ctx, mock.Anything).Return(nil, assert.AnError).Once()
+
+	_, err := api.GetEventsForHeightRange(ctx, "A.Event", 150, 250)
+	require.Equal(t, assert.AnError, err)
+
+	local.AssertNotCalled(t, "GetEventsForHeightRange", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+// TestHistoricalAPIEventsMaxHeightRange tests that the max height range applies to a height range spanning sporks,
+// before any node is requested.
+func TestHistoricalAPIEventsMaxHeightRange(t *testing.T) {
+	api, local, clients := newTestHistoricalAPI(t)
+	ctx := context.Background()
+
+	_, err := api.GetEventsForHeightRange(ctx, "A.Event", 50, 300)
+	require.Error(t, err)
+
+	local.AssertNotCalled(t, "GetEventsForHeightRange", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	clients[0].AssertNotCalled(t, "GetEventsForHeightRange", mock.Anything, mock.Anything)
+	clients[1].AssertNotCalled(t, "GetEventsForHeightRange", mock.Anything, mock.Anything)
+}
+
+// TestHistoricalAPIBlockByHeight tests that the REST API serves the blocks at the heights of previous sporks from the
+// node of the spork, with the IDs returned by the node.
+func TestHistoricalAPIBlockByHeight(t *testing.T) {
+	api, local, clients := newTestHistoricalAPI(t)
+	server := rest.NewServer(api, "", flow.Testnet.Chain(), nil, zerolog.Nop())
+
+	getBlock := func(height string) rest.Block {
+		req := httptest.NewRequest(http.MethodGet, "/v1/blocks?height="+height, nil)
+		rec := httptest.NewRecorder()
+		server.Handler.ServeHTTP(rec, req)
+		require.Equal(t, http.StatusOK, rec.Code)
+
+		var block rest.Block
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &block))
+		return block
+	}
+
+	id := unittest.IdentifierFixture()
+	parentID := unittest.IdentifierFixture()
+	clients[1].On("GetBlockByHeight", mock.Anything, &access.GetBlockByHeightRequest{Height: 150}).
+		Return(&access.BlockResponse{Block: &entities.Block{
+			Id:        id[:],
+			ParentId:  parentID[:],
+			Height:    150,
+			Timestamp: ptypes.TimestampNow(),
+		}}, nil).Once()
+
+	block := getBlock("150")
+	assert.Equal(t, id, block.ID)
+	assert.Equal(t, parentID, block.ParentID)
+	assert.Equal(t, uint64(150), block.Height)
+
+	// heights of the current spork are served locally
+	current := unittest.BlockFixture()
+	current.Header.Height = 200
+	local.On("GetBlockByHeight", mock.Anything, uint64(200)).Return(&current, nil).Once()
+
+	block = getBlock("200")
+	assert.Equal(t, current.ID(), block.ID)
+
+	local.AssertExpectations(t)
+	clients[1].AssertExpectations(t)
+}
@@ -0,0 +1,162 @@
+package rpc
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	accessproto "github.com/onflow/flow/protobuf/go/flow/access"
+)
+
+// historicalSpork is the access node of a previous spork, and the heights of the blocks of that spork.
+type historicalSpork struct {
+	client      accessproto.AccessAPIClient
+	startHeight uint64
+	endHeight   uint64
+}
+
+// heightRange is a part of a requested height range, served by the access node of a previous spork, or by
+// this node if spork is nil.
+type heightRange struct {
+	startHeight uint64
+	endHeight   uint64
+	spork       *historicalSpork
+}
+
+// historicalSporks are the previous sporks, sorted by start height, their height ranges do not overlap.
+type historicalSporks []historicalSpork
+
+// historicalRouter serves the block and block header requests at the heights of previous sporks from the access nodes
+// of those sporks, and all other requests from this node. The responses of the historical access nodes are returned
+// as they are, so blocks keep the IDs they were created with, which can not be recomputed from the responses. The
+// other queries at the heights of previous sporks are routed by the historicalAPI the local server is backed by.
+type historicalRouter struct {
+	accessproto.AccessAPIServer // serves the requests at the heights of the current spork
+	sporks                      historicalSporks
+}
+
+func newHistoricalRouter(local accessproto.AccessAPIServer, sporks historicalSporks) *historicalRouter {
+	return &historicalRouter{
+		AccessAPIServer: local,
+		sporks:          sporks,
+	}
+}
+
+// parseHistoricalSporks returns the previous sporks from the comma separated height ranges of their access nodes,
+// given as start-end in the same order as the clients. There are no sporks if no height ranges are given.
+func parseHistoricalSporks(heights string, clients []accessproto.AccessAPIClient) (historicalSporks, error) {
+	if strings.TrimSpace(heights) == "" {
+		return nil, nil
+	}
+
+	ranges := strings.Split(heights, ",")
+	if len(ranges) != len(clients) {
+		return nil, fmt.Errorf("got %d historical height ranges for %d historical access nodes", len(ranges), len(clients))
+	}
+
+	sporks := make(historicalSporks, len(ranges))
+	for i, r := range ranges {
+		bounds := strings.Split(strings.TrimSpace(r), "-")
+		if len(bounds) != 2 {
+			return nil, fmt.Errorf("invalid historical height range %q, expected start-end", r)
+		}
+
+		start, err := strconv.ParseUint(bounds[0], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid start height in historical height range %q: %w", r, err)
+		}
+		end, err := strconv.ParseUint(bounds[1], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid end height in historical height range %q: %w", r, err)
+		}
+		if end < start {
+			return nil, fmt.Errorf("invalid historical height range %q, end is below start", r)
+		}
+
+		sporks[i] = historicalSpork{
+			client:      clients[i],
+			startHeight: start,
+			endHeight:   end,
+		}
+	}
+
+	sort.Slice(sporks, func(i, j int) bool {
+		return sporks[i].startHeight < sporks[j].startHeight
+	})
+	for i := 1; i < len(sporks); i++ {
+		if sporks[i].startHeight <= sporks[i-1].endHeight {
+			return nil, fmt.Errorf("historical height ranges %d-%d and %d-%d overlap",
+				sporks[i-1].startHeight, sporks[i-1].endHeight, sporks[i].startHeight, sporks[i].endHeight)
+		}
+	}
+
+	return sporks, nil
+}
+
+// atHeight returns the previous spork the given height belongs to, or nil if it belongs to the current spork.
+func (s historicalSporks) atHeight(height uint64) *historicalSpork {
+	i := sort.Search(len(s), func(i int) bool {
+		return s[i].endHeight >= height
+	})
+	if i < len(s) && s[i].startHeight <= height {
+		return &s[i]
+	}
+	return nil
+}
+
+// split splits the given height range into consecutive parts that are served by the same node.
+func (s historicalSporks) split(startHeight, endHeight uint64) []heightRange {
+	var parts []heightRange
+
+	next := startHeight
+	for i := range s {
+		spork := &s[i]
+		if spork.endHeight < next {
+			continue
+		}
+		if spork.startHeight > endHeight {
+			break
+		}
+
+		// the heights before this spork belong to the current spork
+		if spork.startHeight > next {
+			parts = append(parts, heightRange{startHeight: next, endHeight: spork.startHeight - 1})
+			next = spork.startHeight
+		}
+
+		end := spork.endHeight
+		if end > endHeight {
+			end = endHeight
+		}
+		parts = append(parts, heightRange{startHeight: next, endHeight: end, spork: spork})
+
+		if end == endHeight {
+			return parts
+		}
+		next = end + 1
+	}
+
+	return append(parts, heightRange{startHeight: next, endHeight: endHeight})
+}
+
+func (r *historicalRouter) GetBlockHeaderByHeight(
+	ctx context.Context,
+	req *accessproto.GetBlockHeaderByHeightRequest,
+) (*accessproto.BlockHeaderResponse, error) {
+	if spork := r.sporks.atHeight(req.GetHeight()); spork != nil {
+		return spork.client.GetBlockHeaderByHeight(ctx, req)
+	}
+	return r.AccessAPIServer.GetBlockHeaderByHeight(ctx, req)
+}
+
+func (r *historicalRouter) GetBlockByHeight(
+	ctx context.Context,
+	req *accessproto.GetBlockByHeightRequest,
+) (*accessproto.BlockResponse, error) {
+	if spork := r.sporks.atHeight(req.GetHeight()); spork != nil {
+		return spork.client.GetBlockByHeight(ctx, req)
+	}
+	return r.AccessAPIServer.GetBlockByHeight(ctx, req)
+}
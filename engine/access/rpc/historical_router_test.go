@@ -0,0 +1,75 @@
+package rpc
+
+import (
+	"context"
+	"testing"
+
+	"github.com/onflow/flow/protobuf/go/flow/access"
+	"github.com/onflow/flow/protobuf/go/flow/entities"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	accessmock "github.com/onflow/flow-go/engine/access/mock"
+)
+
+// newTestRouter returns a router with two previous sporks at the heights 0-99 and 100-199.
+func newTestRouter(t *testing.T) (*historicalRouter, *accessmock.AccessAPIServer, []*accessmock.AccessAPIClient) {
+	local := new(accessmock.AccessAPIServer)
+	clients := []*accessmock.AccessAPIClient{new(accessmock.AccessAPIClient), new(accessmock.AccessAPIClient)}
+
+	// the height ranges do not need to be given in order
+	sporks, err := parseHistoricalSporks("100-199, 0-99", []access.AccessAPIClient{clients[1], clients[0]})
+	require.NoError(t, err)
+
+	return newHistoricalRouter(local, sporks), local, clients
+}
+
+// TestHistoricalRouterByHeight tests that queries at a height are served by the node of the spork of the height.
+func TestHistoricalRouterByHeight(t *testing.T) {
+	router, local, clients := newTestRouter(t)
+	ctx := context.Background()
+
+	for height, expected := range map[uint64]*accessmock.AccessAPIClient{0: clients[0], 99: clients[0], 100: clients[1], 199: clients[1]} {
+		req := &access.GetBlockByHeightRequest{Height: height}
+		resp := &access.BlockResponse{Block: &entities.Block{Height: height}}
+		expected.On("GetBlockByHeight", ctx, req).Return(resp, nil).Once()
+
+		actual, err := router.GetBlockByHeight(ctx, req)
+		require.NoError(t, err)
+		assert.Equal(t, resp, actual)
+	}
+
+	headerReq := &access.GetBlockHeaderByHeightRequest{Height: 150}
+	clients[1].On("GetBlockHeaderByHeight", ctx, headerReq).Return(&access.BlockHeaderResponse{}, nil).Once()
+	_, err := router.GetBlockHeaderByHeight(ctx, headerReq)
+	require.NoError(t, err)
+
+	// heights of the current spork are served locally
+	localReq := &access.GetBlockByHeightRequest{Height: 200}
+	local.On("GetBlockByHeight", ctx, localReq).Return(&access.BlockResponse{}, nil).Once()
+	_, err = router.GetBlockByHeight(ctx, localReq)
+	require.NoError(t, err)
+
+	local.AssertExpectations(t)
+	clients[0].AssertExpectations(t)
+	clients[1].AssertExpectations(t)
+}
+
+func TestParseHistoricalSporks(t *testing.T) {
+	clients := []access.AccessAPIClient{new(accessmock.AccessAPIClient), new(accessmock.AccessAPIClient)}
+
+	sporks, err := parseHistoricalSporks("", clients)
+	require.NoError(t, err)
+	assert.Empty(t, sporks)
+
+	for _, heights := range []string{
+		"0-99",          // fewer ranges than nodes
+		"0-99,100",      // missing end height
+		"0-99,100-x",    // invalid end height
+		"0-99,199-100",  // end below start
+		"0-100,100-199", // overlapping ranges
+	} {
+		_, err := parseHistoricalSporks(heights, clients)
+		assert.Error(t, err, heights)
+	}
+}
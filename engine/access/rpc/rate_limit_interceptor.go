@@ -2,54 +2,134 @@ package rpc
 
 import (
 	"context"
+	"net"
+	"net/http"
 	"path/filepath"
+	"strings"
+	"sync"
+	"time"
 
+	lru "github.com/hashicorp/golang-lru"
+	accessproto "github.com/onflow/flow/protobuf/go/flow/access"
+	legacyaccessproto "github.com/onflow/flow/protobuf/go/flow/legacy/access"
 	"github.com/rs/zerolog"
 	"golang.org/x/time/rate"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
 	"google.golang.org/grpc/status"
+
+	"github.com/onflow/flow-go/access"
+	exeapi "github.com/onflow/flow-go/engine/execution"
+	"github.com/onflow/flow-go/module"
+	"github.com/onflow/flow-go/module/metrics"
 )
 
 const defaultRateLimit = 1000 // aggregate default rate limit for all unspecified API calls
 const defaultBurst = 100      // default burst limit (calls made at the same time) for an API
 
-// rateLimiterInterceptor rate limits the
+// maxRateLimitedClients is the number of clients whose limits are tracked. The limits of the least recently
+// seen clients are dropped when more clients are connected.
+const maxRateLimitedClients = 10_000
+
+// QuotaConfig defines the quota for expensive Access API calls. Each client may spend Limit quota units per
+// second with bursts of up to Burst units. A call costs one unit per block it covers, e.g. a request for the
+// events of a height range of 100 blocks costs 100 units, and a script execution or a transaction dry run costs
+// one unit. Batch calls cost one unit per account or script of the batch.
+type QuotaConfig struct {
+	Limit int // quota units per second per client, the quota is disabled if 0
+	Burst int // max quota units spent at once, also the max cost of a single call, defaults to Limit
+}
+
+// quotaStreams are the streaming APIs whose responses are charged to the quota, one unit per response. Each
+// response of these streams covers one block.
+var quotaStreams = map[string]bool{
+	"SubscribeEvents": true,
+}
+
+// rateLimiterInterceptor rate limits the Access API calls of each client, over gRPC as well as over the REST API.
+// Clients are identified by their API key if it is one of the authenticated keys, or by their IP address otherwise,
+// so that a client cannot escape its limits by sending a different key with each request.
 type rateLimiterInterceptor struct {
-	log zerolog.Logger
+	log     zerolog.Logger
+	metrics module.RateLimitMetrics
+
+	// the request header holding the API key of the client, empty if API keys are not used
+	apiKeyHeader string
 
-	// a shared default rate limiter for APIs whose rate limit is not explicitly defined
+	// the API keys identifying clients, any other key is ignored
+	apiKeys map[string]struct{}
+
+	// the per second rate limits and burst limits of each API
+	apiRateLimits  map[string]int
+	apiBurstLimits map[string]int
+
+	quota QuotaConfig
+
+	// the limiters of each client
+	clients *lru.Cache
+}
+
+// clientLimiters are the limiters of a single client.
+type clientLimiters struct {
+	sync.Mutex
+
+	// the default limiter for APIs whose rate limit is not explicitly defined
 	defaultLimiter *rate.Limiter
 
 	// a map of api and its limiter
 	methodLimiterMap map[string]*rate.Limiter
+
+	// the quota limiter for expensive calls, nil if the quota is disabled
+	quotaLimiter *rate.Limiter
 }
 
 // NewRateLimiterInterceptor creates a new rate limiter interceptor with the defined per second rate limits and the
-// optional burst limit for each API.
-func NewRateLimiterInterceptor(log zerolog.Logger, apiRateLimits map[string]int, apiBurstLimits map[string]int) *rateLimiterInterceptor {
+// optional burst limit for each API, and the quota for expensive API calls. The limits apply to each client
+// separately.
+func NewRateLimiterInterceptor(
+	log zerolog.Logger,
+	rateLimitMetrics module.RateLimitMetrics,
+	apiKeyHeader string,
+	apiKeys []string,
+	apiRateLimits map[string]int,
+	apiBurstLimits map[string]int,
+	quota QuotaConfig,
+) *rateLimiterInterceptor {
 
-	defaultLimiter := rate.NewLimiter(rate.Limit(defaultRateLimit), defaultBurst)
-	methodLimiterMap := make(map[string]*rate.Limiter, len(apiRateLimits))
+	if len(apiRateLimits) == 0 {
+		log.Info().Int("default_rate_limit", defaultRateLimit).Msg("no rate limits specified, using the default limit")
+	}
 
-	// read rate limit values for each API and create a limiter for each
-	for api, limit := range apiRateLimits {
-		// if a burst limit is defined for this api, use that else use the default
-		burst := defaultBurst
-		if b, ok := apiBurstLimits[api]; ok {
-			burst = b
-		}
-		methodLimiterMap[api] = rate.NewLimiter(rate.Limit(limit), burst)
+	// the burst defaults to one second worth of quota
+	if quota.Limit > 0 && quota.Burst == 0 {
+		quota.Burst = quota.Limit
 	}
 
-	if len(methodLimiterMap) == 0 {
-		log.Info().Int("default_rate_limit", defaultRateLimit).Msg("no rate limits specified, using the default limit")
+	if apiKeyHeader != "" && len(apiKeys) == 0 {
+		log.Warn().Str("api_key_header", apiKeyHeader).Msg("no API keys specified, identifying all clients by IP address")
 	}
 
+	keys := make(map[string]struct{}, len(apiKeys))
+	for _, key := range apiKeys {
+		if key != "" {
+			keys[key] = struct{}{}
+		}
+	}
+
+	// the cache size is a constant, so creating the cache cannot fail
+	clients, _ := lru.New(maxRateLimitedClients)
+
 	return &rateLimiterInterceptor{
-		defaultLimiter:   defaultLimiter,
-		methodLimiterMap: methodLimiterMap,
-		log:              log,
+		log:            log,
+		metrics:        rateLimitMetrics,
+		apiKeyHeader:   strings.ToLower(apiKeyHeader), // metadata keys are lower case
+		apiKeys:        keys,
+		apiRateLimits:  apiRateLimits,
+		apiBurstLimits: apiBurstLimits,
+		quota:          quota,
+		clients:        clients,
 	}
 }
 
@@ -62,34 +142,258 @@ func (interceptor *rateLimiterInterceptor) unaryServerInterceptor(ctx context.Co
 	// remove the package name (e.g. "/flow.access.AccessAPI/Ping" to "Ping")
 	methodName := filepath.Base(info.FullMethod)
 
-	// look up the limiter
-	limiter := interceptor.methodLimiterMap[methodName]
+	client := interceptor.grpcClientID(ctx)
+	err = interceptor.allow(client, methodName, requestCost(req))
+	if err != nil {
+		return nil, err
+	}
 
-	// if not found, use the default limiter
-	if limiter == nil {
+	// call the handler
+	h, err := handler(ctx, req)
+
+	return h, err
+}
+
+// streamServerInterceptor rate limits the opening of streams like unary calls, and throttles the responses of the
+// streams that are charged to the quota once the quota of the client is spent.
+func (interceptor *rateLimiterInterceptor) streamServerInterceptor(srv interface{},
+	stream grpc.ServerStream,
+	info *grpc.StreamServerInfo,
+	handler grpc.StreamHandler) error {
+
+	methodName := filepath.Base(info.FullMethod)
 
-		interceptor.log.Trace().Str("method", methodName).Msg("rate limit not defined, using default limit")
+	client := interceptor.grpcClientID(stream.Context())
+	err := interceptor.allow(client, methodName, 0)
+	if err != nil {
+		return err
+	}
+
+	limiters := interceptor.limiters(client)
+	if quotaStreams[methodName] && limiters.quotaLimiter != nil {
+		stream = &quotaServerStream{
+			ServerStream: stream,
+			quotaLimiter: limiters.quotaLimiter,
+		}
+	}
+
+	return handler(srv, stream)
+}
 
-		limiter = interceptor.defaultLimiter
+// Allow rate limits the REST API requests, under the name of the route they are sent to. It returns an error with the
+// ResourceExhausted code if the request is rejected.
+func (interceptor *rateLimiterInterceptor) Allow(r *http.Request, method string, cost int) error {
+	apiKey := ""
+	if interceptor.apiKeyHeader != "" {
+		apiKey = r.Header.Get(interceptor.apiKeyHeader)
 	}
 
+	return interceptor.allow(interceptor.clientID(apiKey, r.RemoteAddr), method, cost)
+}
+
+// allow checks that a call of the given method with the given quota cost is within the limits of the given client,
+// it returns an error with the ResourceExhausted code otherwise.
+func (interceptor *rateLimiterInterceptor) allow(client string, methodName string, cost int) error {
+	limiters := interceptor.limiters(client)
+
 	// check if request within limit
+	limiter := limiters.methodLimiter(methodName, interceptor.apiRateLimits, interceptor.apiBurstLimits)
 	if !limiter.Allow() {
 
 		// log the limit violation
 		interceptor.log.Trace().
 			Str("method", methodName).
-			Interface("request", req).
+			Str("client", client).
 			Float64("limit", float64(limiter.Limit())).
 			Msg("rate limit exceeded")
 
+		interceptor.metrics.RequestRejected(methodName, metrics.RateLimitReasonRate)
+
 		// reject the request
-		return nil, status.Errorf(codes.ResourceExhausted, "%s rate limit reached, please retry later.",
-			info.FullMethod)
+		return status.Errorf(codes.ResourceExhausted, "%s rate limit reached, please retry later.", methodName)
 	}
 
-	// call the handler
-	h, err := handler(ctx, req)
+	// check if the cost of an expensive request is within the quota
+	if cost > 0 && limiters.quotaLimiter != nil {
+		if cost > interceptor.quota.Burst {
+			interceptor.metrics.RequestRejected(methodName, metrics.RateLimitReasonQuota)
+			return status.Errorf(codes.ResourceExhausted, "%s request costs %d quota units, which exceeds the quota of %d per request",
+				methodName, cost, interceptor.quota.Burst)
+		}
 
-	return h, err
+		if !limiters.quotaLimiter.AllowN(time.Now(), cost) {
+
+			interceptor.log.Trace().
+				Str("method", methodName).
+				Str("client", client).
+				Int("cost", cost).
+				Msg("quota exceeded")
+
+			interceptor.metrics.RequestRejected(methodName, metrics.RateLimitReasonQuota)
+
+			return status.Errorf(codes.ResourceExhausted, "%s quota reached, please retry later.", methodName)
+		}
+	}
+
+	return nil
+}
+
+// grpcClientID returns the identity of the client sending the gRPC request.
+func (interceptor *rateLimiterInterceptor) grpcClientID(ctx context.Context) string {
+	apiKey := ""
+	if interceptor.apiKeyHeader != "" {
+		md, ok := metadata.FromIncomingContext(ctx)
+		if ok {
+			keys := md.Get(interceptor.apiKeyHeader)
+			if len(keys) > 0 {
+				apiKey = keys[0]
+			}
+		}
+	}
+
+	address := ""
+	p, ok := peer.FromContext(ctx)
+	if ok && p.Addr != nil {
+		address = p.Addr.String()
+	}
+
+	return interceptor.clientID(apiKey, address)
+}
+
+// clientID returns the identity of a client given its API key and its address. The client is identified by its API
+// key if it is authenticated, and by the host of its address otherwise.
+func (interceptor *rateLimiterInterceptor) clientID(apiKey string, address string) string {
+	if _, ok := interceptor.apiKeys[apiKey]; ok {
+		return "key:" + apiKey
+	}
+
+	if address == "" {
+		return "unknown"
+	}
+
+	// all connections from the same host share the limits
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return address
+	}
+
+	return host
+}
+
+// limiters returns the limiters of the given client, creating them on first use.
+func (interceptor *rateLimiterInterceptor) limiters(client string) *clientLimiters {
+	if limiters, ok := interceptor.clients.Get(client); ok {
+		return limiters.(*clientLimiters)
+	}
+
+	limiters := &clientLimiters{
+		defaultLimiter:   rate.NewLimiter(rate.Limit(defaultRateLimit), defaultBurst),
+		methodLimiterMap: make(map[string]*rate.Limiter),
+	}
+	if interceptor.quota.Limit > 0 {
+		limiters.quotaLimiter = rate.NewLimiter(rate.Limit(interceptor.quota.Limit), interceptor.quota.Burst)
+	}
+
+	// another request of the same client may have created the limiters in the meantime
+	existing, ok, _ := interceptor.clients.PeekOrAdd(client, limiters)
+	if ok {
+		return existing.(*clientLimiters)
+	}
+
+	return limiters
+}
+
+// methodLimiter returns the limiter of the given API, or the default limiter if the rate limit of the
+// API is not defined.
+func (c *clientLimiters) methodLimiter(methodName string, apiRateLimits map[string]int, apiBurstLimits map[string]int) *rate.Limiter {
+	limit, ok := apiRateLimits[methodName]
+	if !ok {
+		return c.defaultLimiter
+	}
+
+	c.Lock()
+	defer c.Unlock()
+
+	limiter, ok := c.methodLimiterMap[methodName]
+	if !ok {
+		// if a burst limit is defined for this api, use that else use the default
+		burst := defaultBurst
+		if b, ok := apiBurstLimits[methodName]; ok {
+			burst = b
+		}
+		limiter = rate.NewLimiter(rate.Limit(limit), burst)
+		c.methodLimiterMap[methodName] = limiter
+	}
+
+	return limiter
+}
+
+// quotaServerStream is a server stream that charges each response it sends to the quota of the client, and waits
+// for the quota to be replenished before sending a response once it is spent.
+type quotaServerStream struct {
+	grpc.ServerStream
+	quotaLimiter *rate.Limiter
+}
+
+func (s *quotaServerStream) SendMsg(m interface{}) error {
+	// waiting only fails once the stream is closed
+	err := s.quotaLimiter.Wait(s.Context())
+	if err != nil {
+		return err
+	}
+	return s.ServerStream.SendMsg(m)
 }
+
+// requestCost returns the quota cost of the given request, which is the number of blocks it covers, or the
+// number of accounts or scripts of a batch request. Requests that are not subject to the quota cost 0. The requests
+// of the legacy Access API cost the same as their current counterparts.
+func requestCost(req interface{}) int {
+	switch r := req.(type) {
+	case *accessproto.GetEventsForHeightRangeRequest:
+		return heightRangeCost(r.GetStartHeight(), r.GetEndHeight())
+	case *legacyaccessproto.GetEventsForHeightRangeRequest:
+		return heightRangeCost(r.GetStartHeight(), r.GetEndHeight())
+	case *accessproto.GetEventsForBlockIDsRequest:
+		return countCost(len(r.GetBlockIds()))
+	case *legacyaccessproto.GetEventsForBlockIDsRequest:
+		return countCost(len(r.GetBlockIds()))
+	case *accessproto.ExecuteScriptAtLatestBlockRequest,
+		*accessproto.ExecuteScriptAtBlockIDRequest,
+		*accessproto.ExecuteScriptAtBlockHeightRequest,
+		*legacyaccessproto.ExecuteScriptAtLatestBlockRequest,
+		*legacyaccessproto.ExecuteScriptAtBlockIDRequest,
+		*legacyaccessproto.ExecuteScriptAtBlockHeightRequest,
+		*access.DryRunTransactionRequest:
+		return 1
+	case *access.GetAccountsAtBlockHeightRequest:
+		return countCost(len(r.GetAddresses()))
+	case *exeapi.ExecuteScriptsAtBlockIDRequest:
+		return countCost(len(r.GetScripts()))
+	default:
+		return 0
+	}
+}
+
+// heightRangeCost returns the cost of a request covering the blocks of the given height range.
+func heightRangeCost(startHeight uint64, endHeight uint64) int {
+	if endHeight < startHeight {
+		return 1
+	}
+	span := endHeight - startHeight + 1
+	if span > uint64(maxCost) {
+		return maxCost
+	}
+	return int(span)
+}
+
+// countCost returns the cost of a request covering the given number of blocks, accounts or scripts, an empty
+// request costs as much as a single one.
+func countCost(count int) int {
+	if count == 0 {
+		return 1
+	}
+	return count
+}
+
+// maxCost caps the cost of a single request, any request this expensive exceeds every sensible quota.
+const maxCost = 1 << 30
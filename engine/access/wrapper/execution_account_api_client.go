@@ -0,0 +1,11 @@
+package wrapper
+
+import (
+	"github.com/onflow/flow-go/engine/execution"
+)
+
+// ExecutionAccountAPIClient allows for generation of a mock (via mockery) for the ExecutionAccountAPIClient, which is
+// declared in the execution engine
+type ExecutionAccountAPIClient interface {
+	execution.ExecutionAccountAPIClient
+}
@@ -0,0 +1,15 @@
+package wrapper
+
+import (
+	"github.com/onflow/flow-go/engine/execution"
+)
+
+// ExecutionBatchAPIClient allows for generation of a mock (via mockery) for the ExecutionBatchAPIClient, which is
+// declared in the execution engine
+type ExecutionBatchAPIClient interface {
+	execution.ExecutionBatchAPIClient
+}
+
+type ExecutionBatchAPIServer interface {
+	execution.ExecutionBatchAPIServer
+}
@@ -0,0 +1,11 @@
+package wrapper
+
+import (
+	"github.com/onflow/flow-go/engine/execution"
+)
+
+// ExecutionDryRunAPIClient allows for generation of a mock (via mockery) for the ExecutionDryRunAPIClient, which is
+// declared in the execution engine
+type ExecutionDryRunAPIClient interface {
+	execution.ExecutionDryRunAPIClient
+}
@@ -102,9 +102,10 @@ const (
 	consensusClusterPrefix = network.Channel("consensus-cluster") // dynamic channel, use ChannelConsensusCluster function
 
 	// Channels for protocols actively synchronizing state across nodes
-	SyncCommittee     = network.Channel("sync-committee")
-	syncClusterPrefix = network.Channel("sync-cluster") // dynamic channel, use ChannelSyncCluster function
-	SyncExecution     = network.Channel("sync-execution")
+	SyncCommittee      = network.Channel("sync-committee")
+	syncClusterPrefix  = network.Channel("sync-cluster") // dynamic channel, use ChannelSyncCluster function
+	SyncExecution      = network.Channel("sync-execution")
+	SyncExecutionState = network.Channel("sync-execution-state")
 
 	// Channels for actively pushing entities to subscribers
 	PushTransactions = network.Channel("push-transactions")
@@ -112,12 +113,14 @@ const (
 	PushBlocks       = network.Channel("push-blocks")
 	PushReceipts     = network.Channel("push-receipts")
 	PushApprovals    = network.Channel("push-approvals")
+	PushChallenges   = network.Channel("push-challenges")
 
 	// Channels for actively requesting missing entities
 	RequestCollections       = network.Channel("request-collections")
 	RequestChunks            = network.Channel("request-chunks")
 	RequestReceiptsByBlockID = network.Channel("request-receipts-by-block-id")
 	RequestApprovalsByChunk  = network.Channel("request-approvals-by-chunk")
+	RequestExecutionData     = network.Channel("request-execution-data")
 
 	// Channel aliases to make the code more readable / more robust to errors
 	ReceiveTransactions = PushTransactions
@@ -125,11 +128,13 @@ const (
 	ReceiveBlocks       = PushBlocks
 	ReceiveReceipts     = PushReceipts
 	ReceiveApprovals    = PushApprovals
+	ReceiveChallenges   = PushChallenges
 
 	ProvideCollections       = RequestCollections
 	ProvideChunks            = RequestChunks
 	ProvideReceiptsByBlockID = RequestReceiptsByBlockID
 	ProvideApprovalsByChunk  = RequestApprovalsByChunk
+	ProvideExecutionData     = RequestExecutionData
 )
 
 // initializeChannelRoleMap initializes an instance of channelRoleMap and populates it with the channels and their
@@ -150,6 +155,7 @@ func initializeChannelRoleMap() {
 	// Channels for protocols actively synchronizing state across nodes
 	channelRoleMap[SyncCommittee] = flow.RoleList{flow.RoleConsensus}
 	channelRoleMap[SyncExecution] = flow.RoleList{flow.RoleExecution}
+	channelRoleMap[SyncExecutionState] = flow.RoleList{flow.RoleExecution}
 
 	// Channels for actively pushing entities to subscribers
 	channelRoleMap[PushTransactions] = flow.RoleList{flow.RoleCollection}
@@ -159,12 +165,15 @@ func initializeChannelRoleMap() {
 	channelRoleMap[PushReceipts] = flow.RoleList{flow.RoleConsensus, flow.RoleExecution, flow.RoleVerification,
 		flow.RoleAccess}
 	channelRoleMap[PushApprovals] = flow.RoleList{flow.RoleConsensus, flow.RoleVerification}
+	channelRoleMap[PushChallenges] = flow.RoleList{flow.RoleConsensus, flow.RoleVerification}
 
 	// Channels for actively requesting missing entities
 	channelRoleMap[RequestCollections] = flow.RoleList{flow.RoleCollection, flow.RoleExecution}
 	channelRoleMap[RequestChunks] = flow.RoleList{flow.RoleExecution, flow.RoleVerification}
 	channelRoleMap[RequestReceiptsByBlockID] = flow.RoleList{flow.RoleConsensus, flow.RoleExecution}
 	channelRoleMap[RequestApprovalsByChunk] = flow.RoleList{flow.RoleConsensus, flow.RoleVerification}
+	channelRoleMap[RequestExecutionData] = flow.RoleList{flow.RoleCollection, flow.RoleConsensus, flow.RoleExecution,
+		flow.RoleVerification, flow.RoleAccess}
 
 	// Channel aliases to make the code more readable / more robust to errors
 	channelRoleMap[ReceiveGuarantees] = flow.RoleList{flow.RoleCollection, flow.RoleConsensus}
@@ -173,11 +182,14 @@ func initializeChannelRoleMap() {
 	channelRoleMap[ReceiveReceipts] = flow.RoleList{flow.RoleConsensus, flow.RoleExecution, flow.RoleVerification,
 		flow.RoleAccess}
 	channelRoleMap[ReceiveApprovals] = flow.RoleList{flow.RoleConsensus, flow.RoleVerification}
+	channelRoleMap[ReceiveChallenges] = flow.RoleList{flow.RoleConsensus, flow.RoleVerification}
 
 	channelRoleMap[ProvideCollections] = flow.RoleList{flow.RoleCollection, flow.RoleExecution}
 	channelRoleMap[ProvideChunks] = flow.RoleList{flow.RoleExecution, flow.RoleVerification}
 	channelRoleMap[ProvideReceiptsByBlockID] = flow.RoleList{flow.RoleConsensus, flow.RoleExecution}
 	channelRoleMap[ProvideApprovalsByChunk] = flow.RoleList{flow.RoleConsensus, flow.RoleVerification}
+	channelRoleMap[ProvideExecutionData] = flow.RoleList{flow.RoleCollection, flow.RoleConsensus, flow.RoleExecution,
+		flow.RoleVerification, flow.RoleAccess}
 
 	channelRoleMap[syncClusterPrefix] = flow.RoleList{flow.RoleCollection}
 	channelRoleMap[consensusClusterPrefix] = flow.RoleList{flow.RoleCollection}
@@ -18,6 +18,10 @@ import (
 	"github.com/onflow/flow-go/storage"
 )
 
+// maxResponseSize is the maximum total size of the entities of a response, well below the maximum size of unicast
+// messages. Entities beyond it are left out of the response, and are requested again by the requester.
+const maxResponseSize = 5 * 1024 * 1024
+
 // RetrieveFunc is a function provided to the provider engine upon construction.
 // It is used by the engine when receiving requests in order to retrieve the
 // related entities. It is important that the retrieve function return a
@@ -167,13 +171,23 @@ func (e *Engine) onEntityRequest(originID flow.Identifier, req *messages.EntityR
 		entityIDs = append(entityIDs, entityID)
 	}
 
-	// encode all of the entities
+	// encode the entities, up to the maximum size of a response, but always at least one of them
 	blobs := make([][]byte, 0, len(entities))
-	for _, entity := range entities {
+	size := 0
+	for i, entity := range entities {
 		blob, err := msgpack.Marshal(entity)
 		if err != nil {
 			return fmt.Errorf("could not encode entity (%x): %w", entity.ID(), err)
 		}
+		size += len(blob)
+		if i > 0 && size > maxResponseSize {
+			e.log.Debug().
+				Int("entities", len(entities)).
+				Int("sent", len(blobs)).
+				Msg("response exceeds the maximum size, leaving out remaining entities")
+			entityIDs = entityIDs[:len(blobs)]
+			break
+		}
 		blobs = append(blobs, blob)
 	}
 
@@ -165,6 +165,71 @@ func TestOnEntityRequestPartial(t *testing.T) {
 	con.AssertExpectations(t)
 }
 
+func TestOnEntityRequestExceedingMaxSize(t *testing.T) {
+
+	entities := make(map[flow.Identifier]flow.Entity)
+
+	identities := unittest.IdentityListFixture(8)
+	selector := filter.HasNodeID(identities.NodeIDs()...)
+	originID := identities[0].NodeID
+
+	// each entity takes more than half of the maximum size of a response
+	chunks := make([]*flow.ChunkExecutionData, 3)
+	for i := range chunks {
+		chunks[i] = &flow.ChunkExecutionData{
+			Index:      uint64(i),
+			TrieUpdate: flow.RegisterEntries{{Value: make([]byte, maxResponseSize/2+1)}},
+		}
+		entities[chunks[i].ID()] = chunks[i]
+	}
+
+	retrieve := func(entityID flow.Identifier) (flow.Entity, error) {
+		entity, ok := entities[entityID]
+		if !ok {
+			return nil, storage.ErrNotFound
+		}
+		return entity, nil
+	}
+
+	final := &protocol.Snapshot{}
+	final.On("Identities", mock.Anything).Return(
+		func(selector flow.IdentityFilter) flow.IdentityList {
+			return identities.Filter(selector)
+		},
+		nil,
+	)
+
+	state := &protocol.State{}
+	state.On("Final").Return(final, nil)
+
+	// only the first entity fits in the response, the others are requested again
+	con := &mocknetwork.Conduit{}
+	con.On("Unicast", mock.Anything, originID).Run(
+		func(args mock.Arguments) {
+			response := args.Get(0).(*messages.EntityResponse)
+			assert.Equal(t, []flow.Identifier{chunks[0].ID()}, response.EntityIDs)
+			assert.Len(t, response.Blobs, 1)
+		},
+	).Return(nil)
+
+	provide := Engine{
+		metrics:  metrics.NewNoopCollector(),
+		state:    state,
+		con:      con,
+		selector: selector,
+		retrieve: retrieve,
+	}
+
+	request := &messages.EntityRequest{
+		Nonce:     rand.Uint64(),
+		EntityIDs: []flow.Identifier{chunks[0].ID(), chunks[1].ID(), chunks[2].ID()},
+	}
+	err := provide.onEntityRequest(originID, request)
+	assert.NoError(t, err, "should not error on response exceeding the maximum size")
+
+	con.AssertExpectations(t)
+}
+
 func TestOnEntityRequestEmpty(t *testing.T) {
 
 	entities := make(map[flow.Identifier]flow.Entity)
@@ -0,0 +1,106 @@
+package sealing
+
+import (
+	"github.com/onflow/flow-go/model/flow"
+)
+
+// ChallengeTracker is an index of the verifiers that validly challenged the
+// chunks of execution results, indexed by execution result ID and chunk index.
+// A result is withheld from sealing once any of its chunks is challenged by at
+// least `threshold` distinct verifiers, which proves it faulty. The challenges
+// of a result are resolved once its block is sealed, which for a withheld
+// result means that another result for its block is sealed. The challenges of
+// a result that is not withheld expire once `timeout` blocks are finalized on
+// top of its block without it being sealed, a withheld result stays withheld.
+// It is not concurrency-safe.
+type ChallengeTracker struct {
+	index     map[flow.Identifier]*challengedResult
+	threshold uint
+	timeout   uint64
+}
+
+// challengedResult holds the challengers of the chunks of a result.
+type challengedResult struct {
+	height      uint64                                  // height of the block the result is for
+	challengers map[uint64]map[flow.Identifier]struct{} // challengers by chunk index
+}
+
+// NewChallengeTracker instantiates a new ChallengeTracker withholding results
+// challenged by at least threshold verifiers, for at most timeout finalized
+// blocks.
+func NewChallengeTracker(threshold uint, timeout uint64) *ChallengeTracker {
+	return &ChallengeTracker{
+		index:     make(map[flow.Identifier]*challengedResult),
+		threshold: threshold,
+		timeout:   timeout,
+	}
+}
+
+// Add tracks the challenge of a verifier against the chunk of a result for
+// the block at the given height. It returns false if the verifier already
+// challenged the chunk.
+func (ct *ChallengeTracker) Add(resultID flow.Identifier, height uint64, chunkIndex uint64, challengerID flow.Identifier) bool {
+	result, ok := ct.index[resultID]
+	if !ok {
+		result = &challengedResult{
+			height:      height,
+			challengers: make(map[uint64]map[flow.Identifier]struct{}),
+		}
+		ct.index[resultID] = result
+	}
+	challengers, ok := result.challengers[chunkIndex]
+	if !ok {
+		challengers = make(map[flow.Identifier]struct{})
+		result.challengers[chunkIndex] = challengers
+	}
+	if _, ok := challengers[challengerID]; ok {
+		return false
+	}
+	challengers[challengerID] = struct{}{}
+	return true
+}
+
+// Has returns whether the verifier challenged the chunk of the result.
+func (ct *ChallengeTracker) Has(resultID flow.Identifier, chunkIndex uint64, challengerID flow.Identifier) bool {
+	result, ok := ct.index[resultID]
+	if !ok {
+		return false
+	}
+	_, ok = result.challengers[chunkIndex][challengerID]
+	return ok
+}
+
+// IsWithheld returns whether any chunk of the result is challenged by at least
+// threshold verifiers.
+func (ct *ChallengeTracker) IsWithheld(resultID flow.Identifier) bool {
+	result, ok := ct.index[resultID]
+	if !ok {
+		return false
+	}
+	for _, challengers := range result.challengers {
+		if uint(len(challengers)) >= ct.threshold {
+			return true
+		}
+	}
+	return false
+}
+
+// Prune removes the challenges of the results for blocks at or below the
+// sealed height, which are resolved, and of the results that are not withheld
+// and whose challenges timed out at the finalized height, which are expired.
+// It returns the IDs of the results of both.
+func (ct *ChallengeTracker) Prune(sealedHeight uint64, finalizedHeight uint64) ([]flow.Identifier, []flow.Identifier) {
+	var resolved, expired []flow.Identifier
+	for resultID, result := range ct.index {
+		if result.height <= sealedHeight {
+			resolved = append(resolved, resultID)
+			delete(ct.index, resultID)
+			continue
+		}
+		if result.height+ct.timeout <= finalizedHeight && !ct.IsWithheld(resultID) {
+			expired = append(expired, resultID)
+			delete(ct.index, resultID)
+		}
+	}
+	return resolved, expired
+}
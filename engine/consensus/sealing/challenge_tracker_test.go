@@ -0,0 +1,66 @@
+package sealing
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+// TestChallengeTracker_Withheld tests that a result is withheld once one of its chunks is challenged by the threshold
+// number of distinct verifiers.
+func TestChallengeTracker_Withheld(t *testing.T) {
+	tracker := NewChallengeTracker(2, 100)
+	resultID := unittest.IdentifierFixture()
+	verifiers := unittest.IdentifierListFixture(2)
+
+	assert.True(t, tracker.Add(resultID, 10, 0, verifiers[0]))
+	assert.False(t, tracker.IsWithheld(resultID))
+
+	// the same verifier challenging again does not count
+	assert.False(t, tracker.Add(resultID, 10, 0, verifiers[0]))
+	assert.False(t, tracker.IsWithheld(resultID))
+
+	// challenges of distinct chunks do not add up
+	assert.True(t, tracker.Add(resultID, 10, 1, verifiers[1]))
+	assert.False(t, tracker.IsWithheld(resultID))
+
+	assert.True(t, tracker.Add(resultID, 10, 0, verifiers[1]))
+	assert.True(t, tracker.IsWithheld(resultID))
+	assert.True(t, tracker.Has(resultID, 0, verifiers[1]))
+	assert.False(t, tracker.Has(unittest.IdentifierFixture(), 0, verifiers[1]))
+}
+
+// TestChallengeTracker_Prune tests that the challenges of a result are resolved once its block is sealed, and that
+// the challenges of a result that is not withheld expire once the timeout is finalized on top of its block, while a
+// withheld result stays withheld until its block is sealed.
+func TestChallengeTracker_Prune(t *testing.T) {
+	tracker := NewChallengeTracker(2, 100)
+	sealed := unittest.IdentifierFixture()
+	expired := unittest.IdentifierFixture()
+	withheld := unittest.IdentifierFixture()
+	for _, challengerID := range unittest.IdentifierListFixture(2) {
+		tracker.Add(sealed, 10, 0, challengerID)
+		tracker.Add(withheld, 11, 0, challengerID)
+	}
+	tracker.Add(expired, 11, 0, unittest.IdentifierFixture())
+
+	resolvedIDs, expiredIDs := tracker.Prune(10, 111)
+	require.Equal(t, []flow.Identifier{sealed}, resolvedIDs)
+	require.Equal(t, []flow.Identifier{expired}, expiredIDs)
+	assert.False(t, tracker.IsWithheld(sealed))
+	assert.True(t, tracker.IsWithheld(withheld))
+
+	// the withheld result never expires, and is resolved once its block is sealed
+	resolvedIDs, expiredIDs = tracker.Prune(10, 10_000)
+	require.Empty(t, resolvedIDs)
+	require.Empty(t, expiredIDs)
+	assert.True(t, tracker.IsWithheld(withheld))
+
+	resolvedIDs, _ = tracker.Prune(11, 10_000)
+	require.Equal(t, []flow.Identifier{withheld}, resolvedIDs)
+	assert.False(t, tracker.IsWithheld(withheld))
+}
@@ -41,6 +41,14 @@ const DefaultRequiredApprovalsForSealConstruction = 0
 // sealing.
 const DefaultEmergencySealingThreshold = 400
 
+// DefaultRequiredChallengesForWithholding is the default number of assigned Verifiers that must validly challenge a
+// chunk of a result to withhold the result from sealing.
+const DefaultRequiredChallengesForWithholding = 2
+
+// DefaultChallengeTimeout is the default number of blocks finalized on top of the block of a challenged result, after
+// which the challenges of the result expire if the block is not sealed and the result is not withheld.
+const DefaultChallengeTimeout = 1000
+
 // DefaultEmergencySealingActive is a flag which indicates when emergency sealing is active, this is a temporary measure
 // to make fire fighting easier while seal & verification is under development.
 const DefaultEmergencySealingActive = false
@@ -55,6 +63,13 @@ const DefaultEmergencySealingActive = false
 //    Seal is generated and stored in the IncorporatedResultSeals mempool.
 //    Spwecifically, we require that each chunk must have a minimal number of
 //    approvals, `requiredApprovalsForSealConstruction`, from assigned Verifiers.
+//  * It processes the Challenges of Verifiers that found a chunk of a Result
+//    to be faulty. A Result with a chunk validly challenged by at least
+//    `requiredChallengesForWithholding` assigned Verifiers is not sealed,
+//    neither on the happy path nor by emergency sealing, until another Result
+//    for its block is sealed. Valid Challenges are persisted, and are dropped
+//    once the block of the Result is sealed, or, if they do not withhold the
+//    Result, once `challengeTimeout` blocks are finalized on top of it.
 // NOTE: Core is designed to be non-thread safe and cannot be used in concurrent environment
 // user of this object needs to ensure single thread access.
 type Core struct {
@@ -69,6 +84,7 @@ type Core struct {
 	approvalConduit                      network.Conduit                 // used to request missing approvals from verification nodes
 	receiptsDB                           storage.ExecutionReceipts       // to persist received execution receipts
 	headersDB                            storage.Headers                 // used to check sealed headers
+	challengesDB                         storage.Challenges              // to persist valid challenges
 	indexDB                              storage.Index                   // used to check payloads for results
 	incorporatedResults                  mempool.IncorporatedResults     // holds incorporated results waiting to be sealed (the payload construction algorithm guarantees that such incorporated are connected to sealed results)
 	receipts                             mempool.ExecutionTree           // holds execution receipts; indexes them by height; can search all receipts derived from a given parent result
@@ -82,6 +98,8 @@ type Core struct {
 	requiredApprovalsForSealConstruction uint                            // min number of approvals required for constructing a candidate seal
 	receiptValidator                     module.ReceiptValidator         // used to validate receipts
 	approvalValidator                    module.ApprovalValidator        // used to validate ResultApprovals
+	challengeValidator                   module.ChallengeValidator       // used to validate Challenges
+	challenges                           *ChallengeTracker               // used to keep track of the valid challenges, by chunk, and the results they withhold from sealing
 	requestTracker                       *RequestTracker                 // used to keep track of number of approval requests, and blackout periods, by chunk
 	approvalRequestsThreshold            uint64                          // threshold for re-requesting approvals: min height difference between the latest finalized block and the block incorporating a result
 	emergencySealingActive               bool                            // flag which indicates if emergency sealing is active or not. NOTE: this is temporary while sealing & verification is under development
@@ -99,6 +117,7 @@ func NewCore(
 	receiptsDB storage.ExecutionReceipts,
 	headersDB storage.Headers,
 	indexDB storage.Index,
+	challengesDB storage.Challenges,
 	incorporatedResults mempool.IncorporatedResults,
 	receipts mempool.ExecutionTree,
 	approvals mempool.Approvals,
@@ -107,7 +126,10 @@ func NewCore(
 	assigner module.ChunkAssigner,
 	receiptValidator module.ReceiptValidator,
 	approvalValidator module.ApprovalValidator,
+	challengeValidator module.ChallengeValidator,
 	requiredApprovalsForSealConstruction uint,
+	requiredChallengesForWithholding uint,
+	challengeTimeout uint64,
 	emergencySealingActive bool,
 	approvalConduit network.Conduit,
 ) (*Core, error) {
@@ -123,6 +145,7 @@ func NewCore(
 		receiptsDB:                           receiptsDB,
 		headersDB:                            headersDB,
 		indexDB:                              indexDB,
+		challengesDB:                         challengesDB,
 		incorporatedResults:                  incorporatedResults,
 		receipts:                             receipts,
 		approvals:                            approvals,
@@ -135,6 +158,8 @@ func NewCore(
 		requiredApprovalsForSealConstruction: requiredApprovalsForSealConstruction,
 		receiptValidator:                     receiptValidator,
 		approvalValidator:                    approvalValidator,
+		challengeValidator:                   challengeValidator,
+		challenges:                           NewChallengeTracker(requiredChallengesForWithholding, challengeTimeout),
 		requestTracker:                       NewRequestTracker(10, 30),
 		approvalRequestsThreshold:            10,
 		emergencySealingActive:               emergencySealingActive,
@@ -146,9 +171,31 @@ func NewCore(
 	c.mempool.MempoolEntries(metrics.ResourceApproval, c.approvals.Size())
 	c.mempool.MempoolEntries(metrics.ResourceSeal, c.seals.Size())
 
+	err := c.loadChallenges()
+	if err != nil {
+		return nil, fmt.Errorf("could not load challenges: %w", err)
+	}
+
 	return c, nil
 }
 
+// loadChallenges tracks the valid challenges persisted before a restart.
+func (c *Core) loadChallenges() error {
+	challenges, err := c.challengesDB.All()
+	if err != nil {
+		return fmt.Errorf("could not retrieve challenges: %w", err)
+	}
+	for _, challenge := range challenges {
+		proof := challenge.Body.FaultProof
+		header, err := c.headersDB.ByBlockID(proof.BlockID)
+		if err != nil {
+			return fmt.Errorf("could not retrieve header of challenged block %v: %w", proof.BlockID, err)
+		}
+		c.challenges.Add(proof.ExecutionResultID, header.Height, proof.ChunkIndex, challenge.Body.ChallengerID)
+	}
+	return nil
+}
+
 // OnReceipt processes a new execution receipt.
 // Any error indicates an unexpected problem in the protocol logic. The node's
 // internal state might be corrupted. Hence, returned errors should be treated as fatal.
@@ -433,6 +480,138 @@ func (c *Core) onApproval(originID flow.Identifier, approval *flow.ResultApprova
 	return nil
 }
 
+// OnChallenge processes a new challenge.
+func (c *Core) OnChallenge(originID flow.Identifier, challenge *flow.Challenge) error {
+	err := c.onChallenge(originID, challenge)
+	if err != nil {
+		marshalled, encErr := json.Marshal(challenge)
+		if encErr != nil {
+			marshalled = []byte("json_marshalling_failed")
+		}
+		c.log.Error().Err(err).
+			Hex("origin", logging.ID(originID)).
+			Hex("challenge_id", logging.Entity(challenge)).
+			Str("challenge", string(marshalled)).
+			Msgf("unexpected error processing challenge")
+		return fmt.Errorf("internal error processing challenge %x: %w", challenge.ID(), err)
+	}
+	return nil
+}
+
+// onChallenge processes a new challenge. Valid challenges from Verifiers assigned to the challenged chunk are
+// persisted, and once the chunk is challenged by enough of them, the challenged result is withheld from sealing, and
+// its candidate seals are dropped.
+func (c *Core) onChallenge(originID flow.Identifier, challenge *flow.Challenge) error {
+	proof := challenge.Body.FaultProof
+	log := c.log.With().
+		Hex("origin_id", originID[:]).
+		Hex("challenge_id", logging.Entity(challenge)).
+		Hex("block_id", proof.BlockID[:]).
+		Hex("result_id", proof.ExecutionResultID[:]).
+		Uint64("chunk_index", proof.ChunkIndex).
+		Str("fault_kind", proof.Kind.String()).
+		Logger()
+	log.Info().Msg("challenge received")
+
+	// Check that the message's origin (as established by the networking layer) is
+	// equal to the message's creator as reported by the message itself.
+	if challenge.Body.ChallengerID != originID {
+		log.Debug().Msg("discarding challenge from invalid origin")
+		return nil
+	}
+
+	if c.challenges.IsWithheld(proof.ExecutionResultID) {
+		log.Debug().Msg("skipping challenge of already withheld result")
+		return nil
+	}
+	if c.challenges.Has(proof.ExecutionResultID, proof.ChunkIndex, challenge.Body.ChallengerID) {
+		log.Debug().Msg("skipping challenge of chunk already challenged by verifier")
+		return nil
+	}
+
+	// only results waiting to be sealed can be challenged
+	result, incorporatedResults, ok := c.incorporatedResults.ByResultID(proof.ExecutionResultID)
+	if !ok {
+		log.Debug().Msg("discarding challenge of unknown result")
+		return nil
+	}
+
+	err := c.challengeValidator.Validate(challenge, result)
+	if err != nil {
+		if engine.IsOutdatedInputError(err) {
+			log.Debug().Msg("discarding challenge for already sealed and finalized block height")
+			return nil
+		} else if engine.IsUnverifiableInputError(err) {
+			log.Debug().Msg("discarding unverifiable challenge")
+			return nil
+		} else if engine.IsInvalidInputError(err) {
+			log.Err(err).Msg("discarding invalid challenge")
+			return nil
+		} else {
+			return err
+		}
+	}
+
+	// the challenger must be assigned to the chunk, in one of the forks incorporating the result
+	assigned, err := c.isAssigned(result, incorporatedResults, challenge.Body.ChallengerID, proof.ChunkIndex)
+	if err != nil {
+		return fmt.Errorf("could not check chunk assignment of challenger: %w", err)
+	}
+	if !assigned {
+		log.Debug().Msg("discarding challenge from verifier not assigned to the chunk")
+		return nil
+	}
+
+	header, err := c.headersDB.ByBlockID(proof.BlockID)
+	if err != nil {
+		return fmt.Errorf("could not retrieve header of challenged block: %w", err)
+	}
+	err = c.challengesDB.Store(challenge)
+	if err != nil {
+		return fmt.Errorf("could not store challenge: %w", err)
+	}
+	c.challenges.Add(proof.ExecutionResultID, header.Height, proof.ChunkIndex, challenge.Body.ChallengerID)
+
+	if !c.challenges.IsWithheld(proof.ExecutionResultID) {
+		log.Warn().Msg("execution result challenged, result is withheld once enough verifiers challenge the chunk")
+		return nil
+	}
+
+	for _, incorporatedResult := range incorporatedResults {
+		c.seals.Rem(incorporatedResult.ID())
+	}
+	c.mempool.MempoolEntries(metrics.ResourceSeal, c.seals.Size())
+
+	log.Warn().Msg("execution result challenged by enough verifiers, result will not be sealed")
+
+	return nil
+}
+
+// isAssigned returns whether the verifier is assigned to the chunk of the result, in any of the blocks
+// incorporating the result. The assignment at a block incorporating the result can not be computed before the block
+// has a child, such blocks are skipped.
+func (c *Core) isAssigned(result *flow.ExecutionResult, incorporatedResults map[flow.Identifier]*flow.IncorporatedResult, verifierID flow.Identifier, chunkIndex uint64) (bool, error) {
+	chunk, ok := result.Chunks.ByIndex(chunkIndex)
+	if !ok {
+		return false, nil
+	}
+
+	for incorporatedBlockID := range incorporatedResults {
+		assignment, err := c.assigner.Assign(result, incorporatedBlockID)
+		if state.IsNoValidChildBlockError(err) {
+			continue
+		}
+		if err != nil {
+			return false, fmt.Errorf("could not determine chunk assignment: %w", err)
+		}
+		if assignment.HasVerifier(chunk, verifierID) {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
 // CheckSealing checks if there is anything worth sealing at the moment.
 func (c *Core) CheckSealing() error {
 	startTime := time.Now()
@@ -575,6 +754,11 @@ func (c *Core) sealableResults() (flow.IncorporatedResultList, *tracker.SealingT
 	// go through the results mempool and check which ones we can construct a candidate seal for
 	var results []*flow.IncorporatedResult
 	for _, incorporatedResult := range c.incorporatedResults.All() {
+		// results withheld by challenges are not sealed
+		if c.challenges.IsWithheld(incorporatedResult.Result.ID()) {
+			continue
+		}
+
 		// Can we seal following the happy-path protocol, i.e. do we have sufficient approvals?
 		sealingStatus, err := c.hasEnoughApprovals(incorporatedResult)
 		if state.IsNoValidChildBlockError(err) {
@@ -888,6 +1072,24 @@ func (c *Core) clearPools(sealedIDs []flow.Identifier) error {
 		}
 	}
 
+	// forget the challenges of results for sealed blocks, and the expired challenges
+	final, err := c.state.Final().Head()
+	if err != nil {
+		return fmt.Errorf("could not get finalized head: %w", err)
+	}
+	resolved, expired := c.challenges.Prune(sealed.Height, final.Height)
+	for _, resultID := range expired {
+		c.log.Warn().
+			Hex("result_id", resultID[:]).
+			Msg("challenges of execution result expired before enough verifiers challenged it")
+	}
+	for _, resultID := range append(resolved, expired...) {
+		err = c.challengesDB.RemoveByResultID(resultID)
+		if err != nil {
+			return fmt.Errorf("could not remove challenges: %w", err)
+		}
+	}
+
 	// for each missing block that we are tracking, remove it from tracking if
 	// we now know that block or if we have just cleared related resources; then
 	// increase the count for the remaining missing blocks
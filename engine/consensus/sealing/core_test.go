@@ -23,6 +23,7 @@ import (
 	mockmodule "github.com/onflow/flow-go/module/mock"
 	"github.com/onflow/flow-go/module/trace"
 	"github.com/onflow/flow-go/network/mocknetwork"
+	mockstorage "github.com/onflow/flow-go/storage/mock"
 	"github.com/onflow/flow-go/utils/unittest"
 )
 
@@ -31,6 +32,10 @@ import (
 // can be set independently without changing test behaviour.
 const RequiredApprovalsForSealConstructionTestingValue = 1
 
+// RequiredChallengesForWithholdingTestingValue defines the number of challenges of a chunk that
+// are required to withhold a result from sealing for testing purposes.
+const RequiredChallengesForWithholdingTestingValue = 2
+
 // 1. Sealing Core should validate the incoming receipt (aka ExecutionReceipt):
 //     1. it should stores it to the mempool if valid
 //     2. it should ignore it when:
@@ -66,9 +71,11 @@ func TestSealingCore(t *testing.T) {
 type SealingSuite struct {
 	unittest.BaseChainSuite
 	// misc SERVICE COMPONENTS which are injected into Sealing Core
-	requester         *mockmodule.Requester
-	receiptValidator  *mockmodule.ReceiptValidator
-	approvalValidator *mockmodule.ApprovalValidator
+	requester          *mockmodule.Requester
+	receiptValidator   *mockmodule.ReceiptValidator
+	approvalValidator  *mockmodule.ApprovalValidator
+	challengeValidator *mockmodule.ChallengeValidator
+	challengesDB       *mockstorage.Challenges
 
 	// MATCHING CORE
 	sealing *Core
@@ -86,6 +93,9 @@ func (ms *SealingSuite) SetupTest() {
 	ms.requester = new(mockmodule.Requester)
 	ms.receiptValidator = &mockmodule.ReceiptValidator{}
 	ms.approvalValidator = &mockmodule.ApprovalValidator{}
+	ms.challengeValidator = &mockmodule.ChallengeValidator{}
+	ms.challengesDB = &mockstorage.Challenges{}
+	ms.challengesDB.On("Store", mock.Anything).Return(nil)
 
 	ms.sealing = &Core{
 		log:                                  log,
@@ -98,6 +108,7 @@ func (ms *SealingSuite) SetupTest() {
 		receiptsDB:                           ms.ReceiptsDB,
 		headersDB:                            ms.HeadersDB,
 		indexDB:                              ms.IndexDB,
+		challengesDB:                         ms.challengesDB,
 		incorporatedResults:                  ms.ResultsPL,
 		receipts:                             ms.ReceiptsPL,
 		approvals:                            ms.ApprovalsPL,
@@ -112,6 +123,8 @@ func (ms *SealingSuite) SetupTest() {
 		requiredApprovalsForSealConstruction: RequiredApprovalsForSealConstructionTestingValue,
 		emergencySealingActive:               false,
 		approvalValidator:                    ms.approvalValidator,
+		challengeValidator:                   ms.challengeValidator,
+		challenges:                           NewChallengeTracker(RequiredChallengesForWithholdingTestingValue, 100),
 	}
 }
 
@@ -376,6 +389,138 @@ func (ms *SealingSuite) TestOnApprovalPendingApproval() {
 	ms.ApprovalsPL.AssertExpectations(ms.T())
 }
 
+// challengedResultFixture adds a valid subgraph to the mempools, and returns its incorporated result together with
+// challenges of its first chunk, raised by the verifiers assigned to the chunk.
+func (ms *SealingSuite) challengedResultFixture() (*flow.IncorporatedResult, []*flow.Challenge) {
+	valSubgrph := ms.ValidSubgraphFixture()
+	valSubgrph.IncorporatedResult.IncorporatedBlockID = valSubgrph.IncorporatedResult.Result.BlockID
+	ms.AddSubgraphFixtureToMempools(valSubgrph)
+
+	incorporatedResults := map[flow.Identifier]*flow.IncorporatedResult{
+		valSubgrph.IncorporatedResult.IncorporatedBlockID: valSubgrph.IncorporatedResult,
+	}
+	ms.ResultsPL.On("ByResultID", valSubgrph.Result.ID()).Return(valSubgrph.Result, incorporatedResults, true).Maybe()
+
+	chunk := valSubgrph.Result.Chunks[0]
+	var challenges []*flow.Challenge
+	for _, challengerID := range valSubgrph.Assignment.Verifiers(chunk) {
+		challenges = append(challenges, unittest.ChallengeFixture(valSubgrph.Result, chunk.Index, challengerID))
+	}
+	return valSubgrph.IncorporatedResult, challenges
+}
+
+// TestOnChallengeValid tests that valid challenges are persisted, and that once enough assigned verifiers challenged
+// a chunk, the challenged result is withheld from sealing, and its candidate seals are dropped.
+func (ms *SealingSuite) TestOnChallengeValid() {
+	incorporatedResult, challenges := ms.challengedResultFixture()
+	ms.Require().Len(challenges, RequiredChallengesForWithholdingTestingValue)
+	resultID := incorporatedResult.Result.ID()
+	receipt1 := unittest.ExecutionReceiptFixture(unittest.WithResult(incorporatedResult.Result))
+	receipt2 := unittest.ExecutionReceiptFixture(unittest.WithResult(incorporatedResult.Result))
+	ms.ReceiptsDB.On("ByBlockID", incorporatedResult.Result.BlockID).Return(flow.ExecutionReceiptList{receipt1, receipt2}, nil)
+
+	// a single challenge does not withhold the result
+	ms.challengeValidator.On("Validate", challenges[0], incorporatedResult.Result).Return(nil).Once()
+	err := ms.sealing.OnChallenge(challenges[0].Body.ChallengerID, challenges[0])
+	ms.Require().NoError(err)
+	ms.challengesDB.AssertCalled(ms.T(), "Store", challenges[0])
+	ms.Assert().False(ms.sealing.challenges.IsWithheld(resultID))
+	ms.SealsPL.AssertNumberOfCalls(ms.T(), "Rem", 0)
+
+	// the same verifier challenging the chunk again has no effect
+	err = ms.sealing.OnChallenge(challenges[0].Body.ChallengerID, challenges[0])
+	ms.Require().NoError(err)
+	ms.Assert().False(ms.sealing.challenges.IsWithheld(resultID))
+
+	// the challenge of another assigned verifier withholds the result
+	ms.challengeValidator.On("Validate", challenges[1], incorporatedResult.Result).Return(nil).Once()
+	ms.SealsPL.On("Rem", incorporatedResult.ID()).Return(true).Once()
+	err = ms.sealing.OnChallenge(challenges[1].Body.ChallengerID, challenges[1])
+	ms.Require().NoError(err)
+	ms.challengesDB.AssertCalled(ms.T(), "Store", challenges[1])
+	ms.Assert().True(ms.sealing.challenges.IsWithheld(resultID))
+
+	results, _, err := ms.sealing.sealableResults()
+	ms.Require().NoError(err)
+	ms.Assert().Empty(results, "challenged result should not be sealable")
+
+	ms.challengeValidator.AssertExpectations(ms.T())
+	ms.SealsPL.AssertExpectations(ms.T())
+}
+
+// TestLoadChallenges tests that the challenges persisted before a restart withhold the challenged result.
+func (ms *SealingSuite) TestLoadChallenges() {
+	incorporatedResult, challenges := ms.challengedResultFixture()
+	ms.challengesDB.On("All").Return(challenges, nil).Once()
+
+	err := ms.sealing.loadChallenges()
+	ms.Require().NoError(err)
+	ms.Assert().True(ms.sealing.challenges.IsWithheld(incorporatedResult.Result.ID()))
+}
+
+// TestProvenResultNeverSealed tests that a result withheld by enough challenges is not sealable, however many blocks
+// are finalized on top of its block, as long as its block is not sealed.
+func (ms *SealingSuite) TestProvenResultNeverSealed() {
+	incorporatedResult, challenges := ms.challengedResultFixture()
+	ms.challengesDB.On("All").Return(challenges, nil).Once()
+	err := ms.sealing.loadChallenges()
+	ms.Require().NoError(err)
+
+	block, ok := ms.Blocks[incorporatedResult.Result.BlockID]
+	ms.Require().True(ok)
+	_, expired := ms.sealing.challenges.Prune(block.Header.Height-1, block.Header.Height+10*DefaultChallengeTimeout)
+	ms.Require().Empty(expired)
+
+	results, _, err := ms.sealing.sealableResults()
+	ms.Require().NoError(err)
+	ms.Assert().Empty(results, "proven faulty result should never be sealable")
+}
+
+// TestOnChallengeInvalid tests that an invalid challenge is dropped, and that unknown failures are escalated.
+func (ms *SealingSuite) TestOnChallengeInvalid() {
+	incorporatedResult, challenges := ms.challengedResultFixture()
+	challenge := challenges[0]
+
+	ms.challengeValidator.On("Validate", challenge, incorporatedResult.Result).Return(engine.NewInvalidInputError("")).Once()
+	err := ms.sealing.OnChallenge(challenge.Body.ChallengerID, challenge)
+	ms.Require().NoError(err, "invalid challenge should be dropped but not error")
+
+	ms.challengeValidator.On("Validate", challenge, incorporatedResult.Result).Return(fmt.Errorf("")).Once()
+	err = ms.sealing.OnChallenge(challenge.Body.ChallengerID, challenge)
+	ms.Require().Error(err, "unexpected errors should be escalated")
+
+	ms.challengeValidator.AssertExpectations(ms.T())
+	ms.SealsPL.AssertNumberOfCalls(ms.T(), "Rem", 0)
+	ms.challengesDB.AssertNotCalled(ms.T(), "Store", mock.Anything)
+	ms.Assert().False(ms.sealing.challenges.Has(incorporatedResult.Result.ID(), challenge.Body.ChunkIndex, challenge.Body.ChallengerID))
+}
+
+// TestOnChallengeInvalidOrigin tests that a challenge whose origin is not its challenger is dropped.
+func (ms *SealingSuite) TestOnChallengeInvalidOrigin() {
+	_, challenges := ms.challengedResultFixture()
+
+	err := ms.sealing.OnChallenge(unittest.IdentifierFixture(), challenges[0])
+	ms.Require().NoError(err, "challenge from invalid origin should be dropped but not error")
+
+	ms.challengeValidator.AssertNumberOfCalls(ms.T(), "Validate", 0)
+	ms.challengesDB.AssertNotCalled(ms.T(), "Store", mock.Anything)
+}
+
+// TestOnChallengeNotAssigned tests that a challenge from a verifier not assigned to the challenged chunk is dropped.
+func (ms *SealingSuite) TestOnChallengeNotAssigned() {
+	incorporatedResult, challenges := ms.challengedResultFixture()
+	challenge := challenges[0]
+	challenge.Body.ChallengerID = unittest.IdentifierFixture()
+
+	ms.challengeValidator.On("Validate", challenge, incorporatedResult.Result).Return(nil).Once()
+	err := ms.sealing.OnChallenge(challenge.Body.ChallengerID, challenge)
+	ms.Require().NoError(err, "challenge from unassigned verifier should be dropped but not error")
+
+	ms.challengeValidator.AssertExpectations(ms.T())
+	ms.SealsPL.AssertNumberOfCalls(ms.T(), "Rem", 0)
+	ms.challengesDB.AssertNotCalled(ms.T(), "Store", mock.Anything)
+}
+
 // try to get matched results with nothing in memory pools
 func (ms *SealingSuite) TestSealableResultsEmptyMempools() {
 	results, _, err := ms.sealing.sealableResults()
@@ -32,6 +32,9 @@ const defaultApprovalQueueCapacity = 10000
 // defaultApprovalResponseQueueCapacity maximum capacity of approval requests queue
 const defaultApprovalResponseQueueCapacity = 10000
 
+// defaultChallengeQueueCapacity maximum capacity of challenges queue
+const defaultChallengeQueueCapacity = 1000
+
 type (
 	EventSink chan *Event // Channel to push pending events
 )
@@ -50,9 +53,11 @@ type Engine struct {
 	receiptSink                          EventSink
 	approvalSink                         EventSink
 	requestedApprovalSink                EventSink
+	challengeSink                        EventSink
 	pendingReceipts                      *fifoqueue.FifoQueue
 	pendingApprovals                     *fifoqueue.FifoQueue
 	pendingRequestedApprovals            *fifoqueue.FifoQueue
+	pendingChallenges                    *fifoqueue.FifoQueue
 	pendingEventSink                     EventSink
 	requiredApprovalsForSealConstruction uint
 }
@@ -70,6 +75,7 @@ func NewEngine(log zerolog.Logger,
 	receiptsDB storage.ExecutionReceipts,
 	headersDB storage.Headers,
 	indexDB storage.Index,
+	challengesDB storage.Challenges,
 	incorporatedResults mempool.IncorporatedResults,
 	receipts mempool.ExecutionTree,
 	approvals mempool.Approvals,
@@ -78,7 +84,10 @@ func NewEngine(log zerolog.Logger,
 	assigner module.ChunkAssigner,
 	receiptValidator module.ReceiptValidator,
 	approvalValidator module.ApprovalValidator,
+	challengeValidator module.ChallengeValidator,
 	requiredApprovalsForSealConstruction uint,
+	requiredChallengesForWithholding uint,
+	challengeTimeout uint64,
 	emergencySealingActive bool) (*Engine, error) {
 	e := &Engine{
 		unit:                                 engine.NewUnit(),
@@ -90,6 +99,7 @@ func NewEngine(log zerolog.Logger,
 		receiptSink:                          make(EventSink),
 		approvalSink:                         make(EventSink),
 		requestedApprovalSink:                make(EventSink),
+		challengeSink:                        make(EventSink),
 		pendingEventSink:                     make(EventSink),
 		requiredApprovalsForSealConstruction: requiredApprovalsForSealConstruction,
 	}
@@ -122,6 +132,15 @@ func NewEngine(log zerolog.Logger,
 		return nil, fmt.Errorf("failed to create queue for requested approvals: %w", err)
 	}
 
+	// FIFO queue for broadcasted challenges
+	e.pendingChallenges, err = fifoqueue.NewFifoQueue(
+		fifoqueue.WithCapacity(defaultChallengeQueueCapacity),
+		fifoqueue.WithLengthObserver(func(len int) { mempool.MempoolEntries(metrics.ResourceChallengeQueue, uint(len)) }),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create queue for inbound challenges: %w", err)
+	}
+
 	// register engine with the receipt provider
 	_, err = net.Register(engine.ReceiveReceipts, e)
 	if err != nil {
@@ -134,6 +153,12 @@ func NewEngine(log zerolog.Logger,
 		return nil, fmt.Errorf("could not register for approvals: %w", err)
 	}
 
+	// register engine with the challenge provider
+	_, err = net.Register(engine.ReceiveChallenges, e)
+	if err != nil {
+		return nil, fmt.Errorf("could not register for challenges: %w", err)
+	}
+
 	// register engine to the channel for requesting missing approvals
 	approvalConduit, err := net.Register(engine.RequestApprovalsByChunk, e)
 	if err != nil {
@@ -141,8 +166,9 @@ func NewEngine(log zerolog.Logger,
 	}
 
 	e.core, err = NewCore(log, engineMetrics, tracer, mempool, conMetrics, state, me, receiptRequester, receiptsDB, headersDB,
-		indexDB, incorporatedResults, receipts, approvals, seals, pendingReceipts, assigner, receiptValidator, approvalValidator,
-		requiredApprovalsForSealConstruction, emergencySealingActive, approvalConduit)
+		indexDB, challengesDB, incorporatedResults, receipts, approvals, seals, pendingReceipts, assigner, receiptValidator,
+		approvalValidator, challengeValidator, requiredApprovalsForSealConstruction, requiredChallengesForWithholding,
+		challengeTimeout, emergencySealingActive, approvalConduit)
 	if err != nil {
 		return nil, fmt.Errorf("failed to init sealing engine: %w", err)
 	}
@@ -169,6 +195,9 @@ func (e *Engine) processEvents() {
 		if val, ok := e.pendingReceipts.Front(); ok {
 			return val.(*Event), e.receiptSink, e.pendingReceipts
 		}
+		if val, ok := e.pendingChallenges.Front(); ok {
+			return val.(*Event), e.challengeSink, e.pendingChallenges
+		}
 		if val, ok := e.pendingRequestedApprovals.Front(); ok {
 			return val.(*Event), e.requestedApprovalSink, e.pendingRequestedApprovals
 		}
@@ -213,6 +242,9 @@ func (e *Engine) processPendingEvent(event *Event) {
 			return
 		}
 		e.pendingRequestedApprovals.Push(event)
+	case *flow.Challenge:
+		e.engineMetrics.MessageReceived(metrics.EngineSealing, metrics.MessageChallenge)
+		e.pendingChallenges.Push(event)
 	}
 }
 
@@ -245,6 +277,9 @@ func (e *Engine) consumeEvents() {
 		case event := <-e.requestedApprovalSink:
 			err = e.core.OnApproval(event.OriginID, &event.Msg.(*messages.ApprovalResponse).Approval)
 			e.engineMetrics.MessageHandled(metrics.EngineSealing, metrics.MessageResultApproval)
+		case event := <-e.challengeSink:
+			err = e.core.OnChallenge(event.OriginID, event.Msg.(*flow.Challenge))
+			e.engineMetrics.MessageHandled(metrics.EngineSealing, metrics.MessageChallenge)
 		case <-checkSealingTicker:
 			err = e.core.CheckSealing()
 		case <-e.unit.Quit():
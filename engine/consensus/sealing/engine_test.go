@@ -26,9 +26,10 @@ func TestSealingEngineContext(t *testing.T) {
 type SealingEngineSuite struct {
 	unittest.BaseChainSuite
 	// misc SERVICE COMPONENTS which are injected into Sealing Core
-	requester         *mockmodule.Requester
-	receiptValidator  *mockmodule.ReceiptValidator
-	approvalValidator *mockmodule.ApprovalValidator
+	requester          *mockmodule.Requester
+	receiptValidator   *mockmodule.ReceiptValidator
+	approvalValidator  *mockmodule.ApprovalValidator
+	challengeValidator *mockmodule.ChallengeValidator
 
 	// Sealing Engine
 	engine *Engine
@@ -46,10 +47,12 @@ func (ms *SealingEngineSuite) SetupTest() {
 	ms.requester = new(mockmodule.Requester)
 	ms.receiptValidator = &mockmodule.ReceiptValidator{}
 	ms.approvalValidator = &mockmodule.ApprovalValidator{}
+	ms.challengeValidator = &mockmodule.ChallengeValidator{}
 
 	approvalsProvider := make(chan *Event)
 	approvalResponseProvider := make(chan *Event)
 	receiptsProvider := make(chan *Event)
+	challengesProvider := make(chan *Event)
 
 	ms.engine = &Engine{
 		log:  log,
@@ -75,6 +78,8 @@ func (ms *SealingEngineSuite) SetupTest() {
 			assigner:                             ms.Assigner,
 			receiptValidator:                     ms.receiptValidator,
 			approvalValidator:                    ms.approvalValidator,
+			challengeValidator:                   ms.challengeValidator,
+			challenges:                           NewChallengeTracker(RequiredChallengesForWithholdingTestingValue, 100),
 			requestTracker:                       NewRequestTracker(1, 3),
 			approvalRequestsThreshold:            10,
 			requiredApprovalsForSealConstruction: RequiredApprovalsForSealConstructionTestingValue,
@@ -83,6 +88,7 @@ func (ms *SealingEngineSuite) SetupTest() {
 		approvalSink:                         approvalsProvider,
 		requestedApprovalSink:                approvalResponseProvider,
 		receiptSink:                          receiptsProvider,
+		challengeSink:                        challengesProvider,
 		pendingEventSink:                     make(chan *Event),
 		engineMetrics:                        metrics,
 		cacheMetrics:                         metrics,
@@ -92,6 +98,7 @@ func (ms *SealingEngineSuite) SetupTest() {
 	ms.engine.pendingReceipts, _ = fifoqueue.NewFifoQueue()
 	ms.engine.pendingApprovals, _ = fifoqueue.NewFifoQueue()
 	ms.engine.pendingRequestedApprovals, _ = fifoqueue.NewFifoQueue()
+	ms.engine.pendingChallenges, _ = fifoqueue.NewFifoQueue()
 
 	<-ms.engine.Ready()
 }
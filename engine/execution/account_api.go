@@ -0,0 +1,450 @@
+package execution
+
+import (
+	"context"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/onflow/flow/protobuf/go/flow/entities"
+	"google.golang.org/grpc"
+)
+
+// ExecutionAccountAPI looks up single parts of an account, so clients do not need to fetch the full account with all
+// its keys and contracts.
+
+// MaxAccountKeysPageSize is the max number of keys that can be listed at once with ListAccountKeys.
+const MaxAccountKeysPageSize = 100
+
+// GetAccountKeyAtIndexRequest is the request message of ExecutionAccountAPI.GetAccountKeyAtIndex.
+type GetAccountKeyAtIndexRequest struct {
+	BlockId []byte `protobuf:"bytes,1,opt,name=block_id,json=blockId,proto3" json:"block_id,omitempty"`
+	Address []byte `protobuf:"bytes,2,opt,name=address,proto3" json:"address,omitempty"`
+	Index   uint64 `protobuf:"varint,3,opt,name=index,proto3" json:"index,omitempty"`
+}
+
+func (m *GetAccountKeyAtIndexRequest) Reset()         { *m = GetAccountKeyAtIndexRequest{} }
+func (m *GetAccountKeyAtIndexRequest) String() string { return proto.CompactTextString(m) }
+func (*GetAccountKeyAtIndexRequest) ProtoMessage()    {}
+
+func (m *GetAccountKeyAtIndexRequest) GetBlockId() []byte {
+	if m != nil {
+		return m.BlockId
+	}
+	return nil
+}
+
+func (m *GetAccountKeyAtIndexRequest) GetAddress() []byte {
+	if m != nil {
+		return m.Address
+	}
+	return nil
+}
+
+func (m *GetAccountKeyAtIndexRequest) GetIndex() uint64 {
+	if m != nil {
+		return m.Index
+	}
+	return 0
+}
+
+// AccountKeyResponse holds a single key of an account.
+type AccountKeyResponse struct {
+	AccountKey *entities.AccountKey `protobuf:"bytes,1,opt,name=account_key,json=accountKey,proto3" json:"account_key,omitempty"`
+}
+
+func (m *AccountKeyResponse) Reset()         { *m = AccountKeyResponse{} }
+func (m *AccountKeyResponse) String() string { return proto.CompactTextString(m) }
+func (*AccountKeyResponse) ProtoMessage()    {}
+
+func (m *AccountKeyResponse) GetAccountKey() *entities.AccountKey {
+	if m != nil {
+		return m.AccountKey
+	}
+	return nil
+}
+
+// ListAccountKeysRequest is the request message of ExecutionAccountAPI.ListAccountKeys. It requests up to
+// limit keys, starting with the key at index offset.
+type ListAccountKeysRequest struct {
+	BlockId []byte `protobuf:"bytes,1,opt,name=block_id,json=blockId,proto3" json:"block_id,omitempty"`
+	Address []byte `protobuf:"bytes,2,opt,name=address,proto3" json:"address,omitempty"`
+	Offset  uint64 `protobuf:"varint,3,opt,name=offset,proto3" json:"offset,omitempty"`
+	Limit   uint64 `protobuf:"varint,4,opt,name=limit,proto3" json:"limit,omitempty"`
+}
+
+func (m *ListAccountKeysRequest) Reset()         { *m = ListAccountKeysRequest{} }
+func (m *ListAccountKeysRequest) String() string { return proto.CompactTextString(m) }
+func (*ListAccountKeysRequest) ProtoMessage()    {}
+
+func (m *ListAccountKeysRequest) GetBlockId() []byte {
+	if m != nil {
+		return m.BlockId
+	}
+	return nil
+}
+
+func (m *ListAccountKeysRequest) GetAddress() []byte {
+	if m != nil {
+		return m.Address
+	}
+	return nil
+}
+
+func (m *ListAccountKeysRequest) GetOffset() uint64 {
+	if m != nil {
+		return m.Offset
+	}
+	return 0
+}
+
+func (m *ListAccountKeysRequest) GetLimit() uint64 {
+	if m != nil {
+		return m.Limit
+	}
+	return 0
+}
+
+// AccountKeysResponse holds a page of the keys of an account, and the total number of keys of the account.
+type AccountKeysResponse struct {
+	AccountKeys []*entities.AccountKey `protobuf:"bytes,1,rep,name=account_keys,json=accountKeys,proto3" json:"account_keys,omitempty"`
+	TotalCount  uint64                 `protobuf:"varint,2,opt,name=total_count,json=totalCount,proto3" json:"total_count,omitempty"`
+}
+
+func (m *AccountKeysResponse) Reset()         { *m = AccountKeysResponse{} }
+func (m *AccountKeysResponse) String() string { return proto.CompactTextString(m) }
+func (*AccountKeysResponse) ProtoMessage()    {}
+
+func (m *AccountKeysResponse) GetAccountKeys() []*entities.AccountKey {
+	if m != nil {
+		return m.AccountKeys
+	}
+	return nil
+}
+
+func (m *AccountKeysResponse) GetTotalCount() uint64 {
+	if m != nil {
+		return m.TotalCount
+	}
+	return 0
+}
+
+// GetAccountContractNamesRequest is the request message of ExecutionAccountAPI.GetAccountContractNames.
+type GetAccountContractNamesRequest struct {
+	BlockId []byte `protobuf:"bytes,1,opt,name=block_id,json=blockId,proto3" json:"block_id,omitempty"`
+	Address []byte `protobuf:"bytes,2,opt,name=address,proto3" json:"address,omitempty"`
+}
+
+func (m *GetAccountContractNamesRequest) Reset()         { *m = GetAccountContractNamesRequest{} }
+func (m *GetAccountContractNamesRequest) String() string { return proto.CompactTextString(m) }
+func (*GetAccountContractNamesRequest) ProtoMessage()    {}
+
+func (m *GetAccountContractNamesRequest) GetBlockId() []byte {
+	if m != nil {
+		return m.BlockId
+	}
+	return nil
+}
+
+func (m *GetAccountContractNamesRequest) GetAddress() []byte {
+	if m != nil {
+		return m.Address
+	}
+	return nil
+}
+
+// AccountContractNamesResponse holds the sorted names of the contracts deployed to an account.
+type AccountContractNamesResponse struct {
+	Names []string `protobuf:"bytes,1,rep,name=names,proto3" json:"names,omitempty"`
+}
+
+func (m *AccountContractNamesResponse) Reset()         { *m = AccountContractNamesResponse{} }
+func (m *AccountContractNamesResponse) String() string { return proto.CompactTextString(m) }
+func (*AccountContractNamesResponse) ProtoMessage()    {}
+
+func (m *AccountContractNamesResponse) GetNames() []string {
+	if m != nil {
+		return m.Names
+	}
+	return nil
+}
+
+// GetAccountContractRequest is the request message of ExecutionAccountAPI.GetAccountContract.
+type GetAccountContractRequest struct {
+	BlockId []byte `protobuf:"bytes,1,opt,name=block_id,json=blockId,proto3" json:"block_id,omitempty"`
+	Address []byte `protobuf:"bytes,2,opt,name=address,proto3" json:"address,omitempty"`
+	Name    string `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+}
+
+func (m *GetAccountContractRequest) Reset()         { *m = GetAccountContractRequest{} }
+func (m *GetAccountContractRequest) String() string { return proto.CompactTextString(m) }
+func (*GetAccountContractRequest) ProtoMessage()    {}
+
+func (m *GetAccountContractRequest) GetBlockId() []byte {
+	if m != nil {
+		return m.BlockId
+	}
+	return nil
+}
+
+func (m *GetAccountContractRequest) GetAddress() []byte {
+	if m != nil {
+		return m.Address
+	}
+	return nil
+}
+
+func (m *GetAccountContractRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+// AccountContractResponse holds the code of a contract.
+type AccountContractResponse struct {
+	Code []byte `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
+}
+
+func (m *AccountContractResponse) Reset()         { *m = AccountContractResponse{} }
+func (m *AccountContractResponse) String() string { return proto.CompactTextString(m) }
+func (*AccountContractResponse) ProtoMessage()    {}
+
+func (m *AccountContractResponse) GetCode() []byte {
+	if m != nil {
+		return m.Code
+	}
+	return nil
+}
+
+// GetAccountStorageUsedRequest is the request message of ExecutionAccountAPI.GetAccountStorageUsed.
+type GetAccountStorageUsedRequest struct {
+	BlockId []byte `protobuf:"bytes,1,opt,name=block_id,json=blockId,proto3" json:"block_id,omitempty"`
+	Address []byte `protobuf:"bytes,2,opt,name=address,proto3" json:"address,omitempty"`
+}
+
+func (m *GetAccountStorageUsedRequest) Reset()         { *m = GetAccountStorageUsedRequest{} }
+func (m *GetAccountStorageUsedRequest) String() string { return proto.CompactTextString(m) }
+func (*GetAccountStorageUsedRequest) ProtoMessage()    {}
+
+func (m *GetAccountStorageUsedRequest) GetBlockId() []byte {
+	if m != nil {
+		return m.BlockId
+	}
+	return nil
+}
+
+func (m *GetAccountStorageUsedRequest) GetAddress() []byte {
+	if m != nil {
+		return m.Address
+	}
+	return nil
+}
+
+// AccountStorageUsedResponse holds the storage used by an account, in bytes.
+type AccountStorageUsedResponse struct {
+	StorageUsed uint64 `protobuf:"varint,1,opt,name=storage_used,json=storageUsed,proto3" json:"storage_used,omitempty"`
+}
+
+func (m *AccountStorageUsedResponse) Reset()         { *m = AccountStorageUsedResponse{} }
+func (m *AccountStorageUsedResponse) String() string { return proto.CompactTextString(m) }
+func (*AccountStorageUsedResponse) ProtoMessage()    {}
+
+func (m *AccountStorageUsedResponse) GetStorageUsed() uint64 {
+	if m != nil {
+		return m.StorageUsed
+	}
+	return 0
+}
+
+// ExecutionAccountAPIServer is the server API for the ExecutionAccountAPI service.
+type ExecutionAccountAPIServer interface {
+	GetAccountKeyAtIndex(context.Context, *GetAccountKeyAtIndexRequest) (*AccountKeyResponse, error)
+	ListAccountKeys(context.Context, *ListAccountKeysRequest) (*AccountKeysResponse, error)
+	GetAccountContractNames(context.Context, *GetAccountContractNamesRequest) (*AccountContractNamesResponse, error)
+	GetAccountContract(context.Context, *GetAccountContractRequest) (*AccountContractResponse, error)
+	GetAccountStorageUsed(context.Context, *GetAccountStorageUsedRequest) (*AccountStorageUsedResponse, error)
+}
+
+func getAccountKeyAtIndexHandler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAccountKeyAtIndexRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExecutionAccountAPIServer).GetAccountKeyAtIndex(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/flow.execution.ExecutionAccountAPI/GetAccountKeyAtIndex",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExecutionAccountAPIServer).GetAccountKeyAtIndex(ctx, req.(*GetAccountKeyAtIndexRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func listAccountKeysHandler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListAccountKeysRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExecutionAccountAPIServer).ListAccountKeys(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/flow.execution.ExecutionAccountAPI/ListAccountKeys",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExecutionAccountAPIServer).ListAccountKeys(ctx, req.(*ListAccountKeysRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func getAccountContractNamesHandler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAccountContractNamesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExecutionAccountAPIServer).GetAccountContractNames(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/flow.execution.ExecutionAccountAPI/GetAccountContractNames",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExecutionAccountAPIServer).GetAccountContractNames(ctx, req.(*GetAccountContractNamesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func getAccountContractHandler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAccountContractRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExecutionAccountAPIServer).GetAccountContract(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/flow.execution.ExecutionAccountAPI/GetAccountContract",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExecutionAccountAPIServer).GetAccountContract(ctx, req.(*GetAccountContractRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func getAccountStorageUsedHandler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAccountStorageUsedRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExecutionAccountAPIServer).GetAccountStorageUsed(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/flow.execution.ExecutionAccountAPI/GetAccountStorageUsed",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExecutionAccountAPIServer).GetAccountStorageUsed(ctx, req.(*GetAccountStorageUsedRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var executionAccountAPIServiceDesc = grpc.ServiceDesc{
+	ServiceName: "flow.execution.ExecutionAccountAPI",
+	HandlerType: (*ExecutionAccountAPIServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetAccountKeyAtIndex",
+			Handler:    getAccountKeyAtIndexHandler,
+		},
+		{
+			MethodName: "ListAccountKeys",
+			Handler:    listAccountKeysHandler,
+		},
+		{
+			MethodName: "GetAccountContractNames",
+			Handler:    getAccountContractNamesHandler,
+		},
+		{
+			MethodName: "GetAccountContract",
+			Handler:    getAccountContractHandler,
+		},
+		{
+			MethodName: "GetAccountStorageUsed",
+			Handler:    getAccountStorageUsedHandler,
+		},
+	},
+	Streams: []grpc.StreamDesc{},
+}
+
+// RegisterExecutionAccountAPIServer registers the account endpoints of the Execution API with the gRPC server.
+func RegisterExecutionAccountAPIServer(s *grpc.Server, srv ExecutionAccountAPIServer) {
+	s.RegisterService(&executionAccountAPIServiceDesc, srv)
+}
+
+// ExecutionAccountAPIClient is the client API for the ExecutionAccountAPI service.
+type ExecutionAccountAPIClient interface {
+	GetAccountKeyAtIndex(ctx context.Context, in *GetAccountKeyAtIndexRequest, opts ...grpc.CallOption) (*AccountKeyResponse, error)
+	ListAccountKeys(ctx context.Context, in *ListAccountKeysRequest, opts ...grpc.CallOption) (*AccountKeysResponse, error)
+	GetAccountContractNames(ctx context.Context, in *GetAccountContractNamesRequest, opts ...grpc.CallOption) (*AccountContractNamesResponse, error)
+	GetAccountContract(ctx context.Context, in *GetAccountContractRequest, opts ...grpc.CallOption) (*AccountContractResponse, error)
+	GetAccountStorageUsed(ctx context.Context, in *GetAccountStorageUsedRequest, opts ...grpc.CallOption) (*AccountStorageUsedResponse, error)
+}
+
+type executionAccountAPIClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewExecutionAccountAPIClient(cc grpc.ClientConnInterface) ExecutionAccountAPIClient {
+	return &executionAccountAPIClient{cc}
+}
+
+func (c *executionAccountAPIClient) GetAccountKeyAtIndex(ctx context.Context, in *GetAccountKeyAtIndexRequest, opts ...grpc.CallOption) (*AccountKeyResponse, error) {
+	out := new(AccountKeyResponse)
+	err := c.cc.Invoke(ctx, "/flow.execution.ExecutionAccountAPI/GetAccountKeyAtIndex", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *executionAccountAPIClient) ListAccountKeys(ctx context.Context, in *ListAccountKeysRequest, opts ...grpc.CallOption) (*AccountKeysResponse, error) {
+	out := new(AccountKeysResponse)
+	err := c.cc.Invoke(ctx, "/flow.execution.ExecutionAccountAPI/ListAccountKeys", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *executionAccountAPIClient) GetAccountContractNames(ctx context.Context, in *GetAccountContractNamesRequest, opts ...grpc.CallOption) (*AccountContractNamesResponse, error) {
+	out := new(AccountContractNamesResponse)
+	err := c.cc.Invoke(ctx, "/flow.execution.ExecutionAccountAPI/GetAccountContractNames", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *executionAccountAPIClient) GetAccountContract(ctx context.Context, in *GetAccountContractRequest, opts ...grpc.CallOption) (*AccountContractResponse, error) {
+	out := new(AccountContractResponse)
+	err := c.cc.Invoke(ctx, "/flow.execution.ExecutionAccountAPI/GetAccountContract", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *executionAccountAPIClient) GetAccountStorageUsed(ctx context.Context, in *GetAccountStorageUsedRequest, opts ...grpc.CallOption) (*AccountStorageUsedResponse, error) {
+	out := new(AccountStorageUsedResponse)
+	err := c.cc.Invoke(ctx, "/flow.execution.ExecutionAccountAPI/GetAccountStorageUsed", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
@@ -0,0 +1,172 @@
+package execution
+
+import (
+	"context"
+
+	"github.com/golang/protobuf/proto"
+	"google.golang.org/grpc"
+)
+
+// The admin endpoints are meant for the operator of the node, and are served on their own address instead of the
+// Execution API. They control the execution of the node, for example to stop it at a given height before a spork.
+
+// SetStopHeightRequest is the request message of ExecutionAdminAPI.SetStopHeight. A height of 0 removes the stop
+// height.
+type SetStopHeightRequest struct {
+	Height uint64 `protobuf:"varint,1,opt,name=height,proto3" json:"height,omitempty"`
+	Crash  bool   `protobuf:"varint,2,opt,name=crash,proto3" json:"crash,omitempty"`
+}
+
+func (m *SetStopHeightRequest) Reset()         { *m = SetStopHeightRequest{} }
+func (m *SetStopHeightRequest) String() string { return proto.CompactTextString(m) }
+func (*SetStopHeightRequest) ProtoMessage()    {}
+
+func (m *SetStopHeightRequest) GetHeight() uint64 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+func (m *SetStopHeightRequest) GetCrash() bool {
+	if m != nil {
+		return m.Crash
+	}
+	return false
+}
+
+// GetStopHeightRequest is the request message of ExecutionAdminAPI.GetStopHeight.
+type GetStopHeightRequest struct {
+}
+
+func (m *GetStopHeightRequest) Reset()         { *m = GetStopHeightRequest{} }
+func (m *GetStopHeightRequest) String() string { return proto.CompactTextString(m) }
+func (*GetStopHeightRequest) ProtoMessage()    {}
+
+// StopHeightResponse holds the stop height of the node, 0 if not set, whether the node crashes once stopped, and
+// whether the execution is stopped.
+type StopHeightResponse struct {
+	Height  uint64 `protobuf:"varint,1,opt,name=height,proto3" json:"height,omitempty"`
+	Crash   bool   `protobuf:"varint,2,opt,name=crash,proto3" json:"crash,omitempty"`
+	Stopped bool   `protobuf:"varint,3,opt,name=stopped,proto3" json:"stopped,omitempty"`
+}
+
+func (m *StopHeightResponse) Reset()         { *m = StopHeightResponse{} }
+func (m *StopHeightResponse) String() string { return proto.CompactTextString(m) }
+func (*StopHeightResponse) ProtoMessage()    {}
+
+func (m *StopHeightResponse) GetHeight() uint64 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+func (m *StopHeightResponse) GetCrash() bool {
+	if m != nil {
+		return m.Crash
+	}
+	return false
+}
+
+func (m *StopHeightResponse) GetStopped() bool {
+	if m != nil {
+		return m.Stopped
+	}
+	return false
+}
+
+// ExecutionAdminAPIServer is the server API for the ExecutionAdminAPI service.
+type ExecutionAdminAPIServer interface {
+	SetStopHeight(context.Context, *SetStopHeightRequest) (*StopHeightResponse, error)
+	GetStopHeight(context.Context, *GetStopHeightRequest) (*StopHeightResponse, error)
+}
+
+func setStopHeightHandler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetStopHeightRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExecutionAdminAPIServer).SetStopHeight(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/flow.execution.ExecutionAdminAPI/SetStopHeight",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExecutionAdminAPIServer).SetStopHeight(ctx, req.(*SetStopHeightRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func getStopHeightHandler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetStopHeightRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExecutionAdminAPIServer).GetStopHeight(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/flow.execution.ExecutionAdminAPI/GetStopHeight",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExecutionAdminAPIServer).GetStopHeight(ctx, req.(*GetStopHeightRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var executionAdminAPIServiceDesc = grpc.ServiceDesc{
+	ServiceName: "flow.execution.ExecutionAdminAPI",
+	HandlerType: (*ExecutionAdminAPIServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "SetStopHeight",
+			Handler:    setStopHeightHandler,
+		},
+		{
+			MethodName: "GetStopHeight",
+			Handler:    getStopHeightHandler,
+		},
+	},
+	Streams: []grpc.StreamDesc{},
+}
+
+// RegisterExecutionAdminAPIServer registers the admin endpoints of the execution node with the gRPC server.
+func RegisterExecutionAdminAPIServer(s *grpc.Server, srv ExecutionAdminAPIServer) {
+	s.RegisterService(&executionAdminAPIServiceDesc, srv)
+}
+
+// ExecutionAdminAPIClient is the client API for the ExecutionAdminAPI service.
+type ExecutionAdminAPIClient interface {
+	SetStopHeight(ctx context.Context, in *SetStopHeightRequest, opts ...grpc.CallOption) (*StopHeightResponse, error)
+	GetStopHeight(ctx context.Context, in *GetStopHeightRequest, opts ...grpc.CallOption) (*StopHeightResponse, error)
+}
+
+type executionAdminAPIClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewExecutionAdminAPIClient(cc grpc.ClientConnInterface) ExecutionAdminAPIClient {
+	return &executionAdminAPIClient{cc}
+}
+
+func (c *executionAdminAPIClient) SetStopHeight(ctx context.Context, in *SetStopHeightRequest, opts ...grpc.CallOption) (*StopHeightResponse, error) {
+	out := new(StopHeightResponse)
+	err := c.cc.Invoke(ctx, "/flow.execution.ExecutionAdminAPI/SetStopHeight", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *executionAdminAPIClient) GetStopHeight(ctx context.Context, in *GetStopHeightRequest, opts ...grpc.CallOption) (*StopHeightResponse, error) {
+	out := new(StopHeightResponse)
+	err := c.cc.Invoke(ctx, "/flow.execution.ExecutionAdminAPI/GetStopHeight", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
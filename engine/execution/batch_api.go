@@ -0,0 +1,475 @@
+package execution
+
+import (
+	"context"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/onflow/flow/protobuf/go/flow/entities"
+	"google.golang.org/grpc"
+)
+
+// ExecutionBatchAPI serves batch requests against a single view of the execution state. Every item of a batch has
+// its own result, so a failed lookup does not fail the whole batch.
+
+// GetAccountsAtBlockIDRequest is the request message of ExecutionBatchAPI.GetAccountsAtBlockID.
+type GetAccountsAtBlockIDRequest struct {
+	BlockId   []byte   `protobuf:"bytes,1,opt,name=block_id,json=blockId,proto3" json:"block_id,omitempty"`
+	Addresses [][]byte `protobuf:"bytes,2,rep,name=addresses,proto3" json:"addresses,omitempty"`
+}
+
+func (m *GetAccountsAtBlockIDRequest) Reset()         { *m = GetAccountsAtBlockIDRequest{} }
+func (m *GetAccountsAtBlockIDRequest) String() string { return proto.CompactTextString(m) }
+func (*GetAccountsAtBlockIDRequest) ProtoMessage()    {}
+
+func (m *GetAccountsAtBlockIDRequest) GetBlockId() []byte {
+	if m != nil {
+		return m.BlockId
+	}
+	return nil
+}
+
+func (m *GetAccountsAtBlockIDRequest) GetAddresses() [][]byte {
+	if m != nil {
+		return m.Addresses
+	}
+	return nil
+}
+
+// AccountResult is the result of a single account lookup of a batch. The error message is set if the
+// lookup failed, the account is set otherwise.
+type AccountResult struct {
+	Account      *entities.Account `protobuf:"bytes,1,opt,name=account,proto3" json:"account,omitempty"`
+	ErrorMessage string            `protobuf:"bytes,2,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
+}
+
+func (m *AccountResult) Reset()         { *m = AccountResult{} }
+func (m *AccountResult) String() string { return proto.CompactTextString(m) }
+func (*AccountResult) ProtoMessage()    {}
+
+func (m *AccountResult) GetAccount() *entities.Account {
+	if m != nil {
+		return m.Account
+	}
+	return nil
+}
+
+func (m *AccountResult) GetErrorMessage() string {
+	if m != nil {
+		return m.ErrorMessage
+	}
+	return ""
+}
+
+// AccountsResponse holds the results of a batch of account lookups, in the order of the requested addresses.
+type AccountsResponse struct {
+	Results []*AccountResult `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+}
+
+func (m *AccountsResponse) Reset()         { *m = AccountsResponse{} }
+func (m *AccountsResponse) String() string { return proto.CompactTextString(m) }
+func (*AccountsResponse) ProtoMessage()    {}
+
+func (m *AccountsResponse) GetResults() []*AccountResult {
+	if m != nil {
+		return m.Results
+	}
+	return nil
+}
+
+// Script is a script and its arguments.
+type Script struct {
+	Script    []byte   `protobuf:"bytes,1,opt,name=script,proto3" json:"script,omitempty"`
+	Arguments [][]byte `protobuf:"bytes,2,rep,name=arguments,proto3" json:"arguments,omitempty"`
+}
+
+func (m *Script) Reset()         { *m = Script{} }
+func (m *Script) String() string { return proto.CompactTextString(m) }
+func (*Script) ProtoMessage()    {}
+
+func (m *Script) GetScript() []byte {
+	if m != nil {
+		return m.Script
+	}
+	return nil
+}
+
+func (m *Script) GetArguments() [][]byte {
+	if m != nil {
+		return m.Arguments
+	}
+	return nil
+}
+
+// ExecuteScriptsAtBlockIDRequest is the request message of ExecutionBatchAPI.ExecuteScriptsAtBlockID.
+type ExecuteScriptsAtBlockIDRequest struct {
+	BlockId []byte    `protobuf:"bytes,1,opt,name=block_id,json=blockId,proto3" json:"block_id,omitempty"`
+	Scripts []*Script `protobuf:"bytes,2,rep,name=scripts,proto3" json:"scripts,omitempty"`
+}
+
+func (m *ExecuteScriptsAtBlockIDRequest) Reset()         { *m = ExecuteScriptsAtBlockIDRequest{} }
+func (m *ExecuteScriptsAtBlockIDRequest) String() string { return proto.CompactTextString(m) }
+func (*ExecuteScriptsAtBlockIDRequest) ProtoMessage()    {}
+
+func (m *ExecuteScriptsAtBlockIDRequest) GetBlockId() []byte {
+	if m != nil {
+		return m.BlockId
+	}
+	return nil
+}
+
+func (m *ExecuteScriptsAtBlockIDRequest) GetScripts() []*Script {
+	if m != nil {
+		return m.Scripts
+	}
+	return nil
+}
+
+// ScriptResult is the result of a single script of a batch. The error message is set if the script
+// failed, the JSON-Cadence encoded value is set otherwise.
+type ScriptResult struct {
+	Value        []byte `protobuf:"bytes,1,opt,name=value,proto3" json:"value,omitempty"`
+	ErrorMessage string `protobuf:"bytes,2,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
+}
+
+func (m *ScriptResult) Reset()         { *m = ScriptResult{} }
+func (m *ScriptResult) String() string { return proto.CompactTextString(m) }
+func (*ScriptResult) ProtoMessage()    {}
+
+func (m *ScriptResult) GetValue() []byte {
+	if m != nil {
+		return m.Value
+	}
+	return nil
+}
+
+func (m *ScriptResult) GetErrorMessage() string {
+	if m != nil {
+		return m.ErrorMessage
+	}
+	return ""
+}
+
+// ScriptsResponse holds the results of a batch of scripts, in the order of the requested scripts.
+type ScriptsResponse struct {
+	Results []*ScriptResult `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+}
+
+func (m *ScriptsResponse) Reset()         { *m = ScriptsResponse{} }
+func (m *ScriptsResponse) String() string { return proto.CompactTextString(m) }
+func (*ScriptsResponse) ProtoMessage()    {}
+
+func (m *ScriptsResponse) GetResults() []*ScriptResult {
+	if m != nil {
+		return m.Results
+	}
+	return nil
+}
+
+// RegisterID identifies a register of the execution state.
+type RegisterID struct {
+	Owner      []byte `protobuf:"bytes,1,opt,name=owner,proto3" json:"owner,omitempty"`
+	Controller []byte `protobuf:"bytes,2,opt,name=controller,proto3" json:"controller,omitempty"`
+	Key        []byte `protobuf:"bytes,3,opt,name=key,proto3" json:"key,omitempty"`
+}
+
+func (m *RegisterID) Reset()         { *m = RegisterID{} }
+func (m *RegisterID) String() string { return proto.CompactTextString(m) }
+func (*RegisterID) ProtoMessage()    {}
+
+func (m *RegisterID) GetOwner() []byte {
+	if m != nil {
+		return m.Owner
+	}
+	return nil
+}
+
+func (m *RegisterID) GetController() []byte {
+	if m != nil {
+		return m.Controller
+	}
+	return nil
+}
+
+func (m *RegisterID) GetKey() []byte {
+	if m != nil {
+		return m.Key
+	}
+	return nil
+}
+
+// GetRegisterProofAtBlockIDRequest is the request message of ExecutionBatchAPI.GetRegisterProofAtBlockID.
+type GetRegisterProofAtBlockIDRequest struct {
+	BlockId   []byte        `protobuf:"bytes,1,opt,name=block_id,json=blockId,proto3" json:"block_id,omitempty"`
+	Registers []*RegisterID `protobuf:"bytes,2,rep,name=registers,proto3" json:"registers,omitempty"`
+}
+
+func (m *GetRegisterProofAtBlockIDRequest) Reset()         { *m = GetRegisterProofAtBlockIDRequest{} }
+func (m *GetRegisterProofAtBlockIDRequest) String() string { return proto.CompactTextString(m) }
+func (*GetRegisterProofAtBlockIDRequest) ProtoMessage()    {}
+
+func (m *GetRegisterProofAtBlockIDRequest) GetBlockId() []byte {
+	if m != nil {
+		return m.BlockId
+	}
+	return nil
+}
+
+func (m *GetRegisterProofAtBlockIDRequest) GetRegisters() []*RegisterID {
+	if m != nil {
+		return m.Registers
+	}
+	return nil
+}
+
+// RegisterProofResponse holds the encoded batch proof of the requested registers against the state
+// commitment of the requested block. The proof includes the register values, so it can be verified
+// and read by building a partial ledger from it.
+type RegisterProofResponse struct {
+	Proof []byte `protobuf:"bytes,1,opt,name=proof,proto3" json:"proof,omitempty"`
+}
+
+func (m *RegisterProofResponse) Reset()         { *m = RegisterProofResponse{} }
+func (m *RegisterProofResponse) String() string { return proto.CompactTextString(m) }
+func (*RegisterProofResponse) ProtoMessage()    {}
+
+func (m *RegisterProofResponse) GetProof() []byte {
+	if m != nil {
+		return m.Proof
+	}
+	return nil
+}
+
+// GetTransactionResultsByBlockIDRequest is the request message of ExecutionBatchAPI.GetTransactionResultsByBlockID.
+type GetTransactionResultsByBlockIDRequest struct {
+	BlockId []byte `protobuf:"bytes,1,opt,name=block_id,json=blockId,proto3" json:"block_id,omitempty"`
+}
+
+func (m *GetTransactionResultsByBlockIDRequest) Reset()         { *m = GetTransactionResultsByBlockIDRequest{} }
+func (m *GetTransactionResultsByBlockIDRequest) String() string { return proto.CompactTextString(m) }
+func (*GetTransactionResultsByBlockIDRequest) ProtoMessage()    {}
+
+func (m *GetTransactionResultsByBlockIDRequest) GetBlockId() []byte {
+	if m != nil {
+		return m.BlockId
+	}
+	return nil
+}
+
+// TransactionResult is the result of a single transaction of a block, with the events it emitted. A status code
+// of 1 indicates an error, 0 no error.
+type TransactionResult struct {
+	TransactionId []byte            `protobuf:"bytes,1,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"`
+	StatusCode    uint32            `protobuf:"varint,2,opt,name=status_code,json=statusCode,proto3" json:"status_code,omitempty"`
+	ErrorMessage  string            `protobuf:"bytes,3,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
+	Events        []*entities.Event `protobuf:"bytes,4,rep,name=events,proto3" json:"events,omitempty"`
+}
+
+func (m *TransactionResult) Reset()         { *m = TransactionResult{} }
+func (m *TransactionResult) String() string { return proto.CompactTextString(m) }
+func (*TransactionResult) ProtoMessage()    {}
+
+func (m *TransactionResult) GetTransactionId() []byte {
+	if m != nil {
+		return m.TransactionId
+	}
+	return nil
+}
+
+func (m *TransactionResult) GetStatusCode() uint32 {
+	if m != nil {
+		return m.StatusCode
+	}
+	return 0
+}
+
+func (m *TransactionResult) GetErrorMessage() string {
+	if m != nil {
+		return m.ErrorMessage
+	}
+	return ""
+}
+
+func (m *TransactionResult) GetEvents() []*entities.Event {
+	if m != nil {
+		return m.Events
+	}
+	return nil
+}
+
+// TransactionResultsResponse holds the results of all transactions of a block, including the system transaction,
+// so the events of the system chunk are included as well.
+type TransactionResultsResponse struct {
+	Results []*TransactionResult `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+}
+
+func (m *TransactionResultsResponse) Reset()         { *m = TransactionResultsResponse{} }
+func (m *TransactionResultsResponse) String() string { return proto.CompactTextString(m) }
+func (*TransactionResultsResponse) ProtoMessage()    {}
+
+func (m *TransactionResultsResponse) GetResults() []*TransactionResult {
+	if m != nil {
+		return m.Results
+	}
+	return nil
+}
+
+// ExecutionBatchAPIServer is the server API for the ExecutionBatchAPI service.
+type ExecutionBatchAPIServer interface {
+	GetAccountsAtBlockID(context.Context, *GetAccountsAtBlockIDRequest) (*AccountsResponse, error)
+	ExecuteScriptsAtBlockID(context.Context, *ExecuteScriptsAtBlockIDRequest) (*ScriptsResponse, error)
+	GetRegisterProofAtBlockID(context.Context, *GetRegisterProofAtBlockIDRequest) (*RegisterProofResponse, error)
+	GetTransactionResultsByBlockID(context.Context, *GetTransactionResultsByBlockIDRequest) (*TransactionResultsResponse, error)
+}
+
+func getAccountsAtBlockIDHandler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAccountsAtBlockIDRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExecutionBatchAPIServer).GetAccountsAtBlockID(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/flow.execution.ExecutionBatchAPI/GetAccountsAtBlockID",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExecutionBatchAPIServer).GetAccountsAtBlockID(ctx, req.(*GetAccountsAtBlockIDRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func executeScriptsAtBlockIDHandler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExecuteScriptsAtBlockIDRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExecutionBatchAPIServer).ExecuteScriptsAtBlockID(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/flow.execution.ExecutionBatchAPI/ExecuteScriptsAtBlockID",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExecutionBatchAPIServer).ExecuteScriptsAtBlockID(ctx, req.(*ExecuteScriptsAtBlockIDRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func getRegisterProofAtBlockIDHandler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRegisterProofAtBlockIDRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExecutionBatchAPIServer).GetRegisterProofAtBlockID(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/flow.execution.ExecutionBatchAPI/GetRegisterProofAtBlockID",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExecutionBatchAPIServer).GetRegisterProofAtBlockID(ctx, req.(*GetRegisterProofAtBlockIDRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func getTransactionResultsByBlockIDHandler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTransactionResultsByBlockIDRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExecutionBatchAPIServer).GetTransactionResultsByBlockID(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/flow.execution.ExecutionBatchAPI/GetTransactionResultsByBlockID",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExecutionBatchAPIServer).GetTransactionResultsByBlockID(ctx, req.(*GetTransactionResultsByBlockIDRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var executionBatchAPIServiceDesc = grpc.ServiceDesc{
+	ServiceName: "flow.execution.ExecutionBatchAPI",
+	HandlerType: (*ExecutionBatchAPIServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetAccountsAtBlockID",
+			Handler:    getAccountsAtBlockIDHandler,
+		},
+		{
+			MethodName: "ExecuteScriptsAtBlockID",
+			Handler:    executeScriptsAtBlockIDHandler,
+		},
+		{
+			MethodName: "GetRegisterProofAtBlockID",
+			Handler:    getRegisterProofAtBlockIDHandler,
+		},
+		{
+			MethodName: "GetTransactionResultsByBlockID",
+			Handler:    getTransactionResultsByBlockIDHandler,
+		},
+	},
+	Streams: []grpc.StreamDesc{},
+}
+
+// RegisterExecutionBatchAPIServer registers the batch endpoints of the Execution API with the gRPC server.
+func RegisterExecutionBatchAPIServer(s *grpc.Server, srv ExecutionBatchAPIServer) {
+	s.RegisterService(&executionBatchAPIServiceDesc, srv)
+}
+
+// ExecutionBatchAPIClient is the client API for the ExecutionBatchAPI service.
+type ExecutionBatchAPIClient interface {
+	GetAccountsAtBlockID(ctx context.Context, in *GetAccountsAtBlockIDRequest, opts ...grpc.CallOption) (*AccountsResponse, error)
+	ExecuteScriptsAtBlockID(ctx context.Context, in *ExecuteScriptsAtBlockIDRequest, opts ...grpc.CallOption) (*ScriptsResponse, error)
+	GetRegisterProofAtBlockID(ctx context.Context, in *GetRegisterProofAtBlockIDRequest, opts ...grpc.CallOption) (*RegisterProofResponse, error)
+	GetTransactionResultsByBlockID(ctx context.Context, in *GetTransactionResultsByBlockIDRequest, opts ...grpc.CallOption) (*TransactionResultsResponse, error)
+}
+
+type executionBatchAPIClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewExecutionBatchAPIClient(cc grpc.ClientConnInterface) ExecutionBatchAPIClient {
+	return &executionBatchAPIClient{cc}
+}
+
+func (c *executionBatchAPIClient) GetAccountsAtBlockID(ctx context.Context, in *GetAccountsAtBlockIDRequest, opts ...grpc.CallOption) (*AccountsResponse, error) {
+	out := new(AccountsResponse)
+	err := c.cc.Invoke(ctx, "/flow.execution.ExecutionBatchAPI/GetAccountsAtBlockID", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *executionBatchAPIClient) ExecuteScriptsAtBlockID(ctx context.Context, in *ExecuteScriptsAtBlockIDRequest, opts ...grpc.CallOption) (*ScriptsResponse, error) {
+	out := new(ScriptsResponse)
+	err := c.cc.Invoke(ctx, "/flow.execution.ExecutionBatchAPI/ExecuteScriptsAtBlockID", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *executionBatchAPIClient) GetRegisterProofAtBlockID(ctx context.Context, in *GetRegisterProofAtBlockIDRequest, opts ...grpc.CallOption) (*RegisterProofResponse, error) {
+	out := new(RegisterProofResponse)
+	err := c.cc.Invoke(ctx, "/flow.execution.ExecutionBatchAPI/GetRegisterProofAtBlockID", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *executionBatchAPIClient) GetTransactionResultsByBlockID(ctx context.Context, in *GetTransactionResultsByBlockIDRequest, opts ...grpc.CallOption) (*TransactionResultsResponse, error) {
+	out := new(TransactionResultsResponse)
+	err := c.cc.Invoke(ctx, "/flow.execution.ExecutionBatchAPI/GetTransactionResultsByBlockID", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
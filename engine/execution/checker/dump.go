@@ -0,0 +1,128 @@
+package checker
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/model/messages"
+)
+
+// ForkDump is the diagnostic dump of an execution fork, written when the result of a sealed block differs from its
+// sealed result. Parts that could not be retrieved are left empty.
+type ForkDump struct {
+	BlockID      flow.Identifier
+	Height       uint64
+	Seal         *flow.Seal
+	SealedResult *flow.ExecutionResult
+	MyCommit     flow.StateCommitment
+	MyResult     *flow.ExecutionResult
+	MyDelta      *messages.ExecutionStateDelta
+	Chunks       []ChunkEndStates
+	Errors       []string // the errors retrieving the parts of the dump
+}
+
+// ChunkEndStates are the end states of a chunk in this node's result and in the sealed result. For a chunk whose
+// end states differ, the registers updated by this node's execution of the chunk are included. The sealed values of
+// the registers are not known to this node.
+type ChunkEndStates struct {
+	Index          uint64
+	MyEndState     flow.StateCommitment
+	SealedEndState flow.StateCommitment
+	Differs        bool
+	Registers      flow.RegisterEntries
+}
+
+// forkDump collects the diagnostic dump of the execution fork at the given sealed block.
+func (e *Engine) forkDump(sealed *flow.Header, seal *flow.Seal, mycommit flow.StateCommitment) *ForkDump {
+	dump := &ForkDump{
+		BlockID:  seal.BlockID,
+		Height:   sealed.Height,
+		Seal:     seal,
+		MyCommit: mycommit,
+	}
+
+	var err error
+	dump.SealedResult, err = e.results.ByID(seal.ResultID)
+	if err != nil {
+		dump.Errors = append(dump.Errors, fmt.Sprintf("could not get sealed result %v: %v", seal.ResultID, err))
+	}
+
+	myResultID, err := e.execState.GetExecutionResultID(e.unit.Ctx(), seal.BlockID)
+	if err == nil {
+		dump.MyResult, err = e.results.ByID(myResultID)
+	}
+	if err != nil {
+		dump.Errors = append(dump.Errors, fmt.Sprintf("could not get my result: %v", err))
+	}
+
+	dump.MyDelta, err = e.execState.RetrieveStateDelta(e.unit.Ctx(), seal.BlockID)
+	if err != nil {
+		dump.Errors = append(dump.Errors, fmt.Sprintf("could not get my state delta: %v", err))
+	}
+
+	dump.Chunks = chunkEndStates(dump.MyResult, dump.SealedResult, dump.MyDelta)
+
+	return dump
+}
+
+// chunkEndStates compares the end states of the chunks of this node's result and the sealed result.
+func chunkEndStates(myResult, sealedResult *flow.ExecutionResult, myDelta *messages.ExecutionStateDelta) []ChunkEndStates {
+	if myResult == nil || sealedResult == nil {
+		return nil
+	}
+
+	count := len(myResult.Chunks)
+	if len(sealedResult.Chunks) > count {
+		count = len(sealedResult.Chunks)
+	}
+
+	chunks := make([]ChunkEndStates, 0, count)
+	for i := 0; i < count; i++ {
+		chunk := ChunkEndStates{Index: uint64(i)}
+		if i < len(myResult.Chunks) {
+			chunk.MyEndState = myResult.Chunks[i].EndState
+		}
+		if i < len(sealedResult.Chunks) {
+			chunk.SealedEndState = sealedResult.Chunks[i].EndState
+		}
+		chunk.Differs = !bytes.Equal(chunk.MyEndState, chunk.SealedEndState)
+
+		// the state interactions of the delta are in the order of the chunks
+		if chunk.Differs && myDelta != nil && i < len(myDelta.StateInteractions) {
+			ids, values := myDelta.StateInteractions[i].Delta.RegisterUpdates()
+			for j, id := range ids {
+				chunk.Registers = append(chunk.Registers, flow.RegisterEntry{Key: id, Value: values[j]})
+			}
+		}
+
+		chunks = append(chunks, chunk)
+	}
+
+	return chunks
+}
+
+// writeForkDump writes the dump as JSON to a file in the given directory, and returns the path of the file.
+func writeForkDump(dir string, dump *ForkDump) (string, error) {
+	err := os.MkdirAll(dir, 0700)
+	if err != nil {
+		return "", fmt.Errorf("could not create dump directory: %w", err)
+	}
+
+	data, err := json.MarshalIndent(dump, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("could not encode dump: %w", err)
+	}
+
+	path := filepath.Join(dir, fmt.Sprintf("execution-fork-%d-%v.json", dump.Height, dump.BlockID))
+	err = ioutil.WriteFile(path, data, 0600)
+	if err != nil {
+		return "", fmt.Errorf("could not write dump: %w", err)
+	}
+
+	return path, nil
+}
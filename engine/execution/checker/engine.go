@@ -4,7 +4,9 @@ import (
 	"bytes"
 	"errors"
 	"fmt"
+	"sync/atomic"
 
+	"github.com/dgraph-io/badger/v2"
 	"github.com/rs/zerolog"
 
 	"github.com/onflow/flow-go/consensus/hotstuff/model"
@@ -14,8 +16,13 @@ import (
 	"github.com/onflow/flow-go/model/flow"
 	"github.com/onflow/flow-go/state/protocol"
 	"github.com/onflow/flow-go/storage"
+	"github.com/onflow/flow-go/storage/badger/operation"
 )
 
+// Engine checks that the results of this execution node match the sealed results. If the result of a sealed block
+// differs from its sealed result, the execution of this node forked from the sealed execution. The engine then
+// writes a diagnostic dump of the fork and halts the execution, so blocks descending from the forked state are not
+// executed. The halt is stored in the database, so it survives restarts until removed with remove-execution-fork.
 type Engine struct {
 	notifications.NoopConsumer // satisfy the FinalizationConsumer interface
 
@@ -24,6 +31,10 @@ type Engine struct {
 	state     protocol.State
 	execState state.ExecutionState
 	sealsDB   storage.Seals
+	results   storage.ExecutionResults
+	db        *badger.DB
+	dumpDir   string // directory the diagnostic dumps of execution forks are written to
+	halted    uint32 // 1 if the execution is halted, accessed atomically
 }
 
 func New(
@@ -31,6 +42,9 @@ func New(
 	state protocol.State,
 	execState state.ExecutionState,
 	sealsDB storage.Seals,
+	results storage.ExecutionResults,
+	db *badger.DB,
+	dumpDir string,
 ) *Engine {
 	return &Engine{
 		unit:      engine.NewUnit(),
@@ -38,12 +52,28 @@ func New(
 		state:     state,
 		execState: execState,
 		sealsDB:   sealsDB,
+		results:   results,
+		db:        db,
+		dumpDir:   dumpDir,
 	}
 }
 
 func (e *Engine) Ready() <-chan struct{} {
-	// make sure we will run into a crashloop if result gets inconsistent
-	// with sealed result.
+	// make sure we will run into a crashloop if the check itself fails
+
+	var forkedID flow.Identifier
+	err := e.db.View(operation.RetrieveExecutionForkHalt(&forkedID))
+	if err != nil && !errors.Is(err, storage.ErrNotFound) {
+		e.log.Fatal().Err(err).Msg("could not check whether execution is halted on startup")
+	}
+	if err == nil {
+		atomic.StoreUint32(&e.halted, 1)
+		e.log.Error().
+			Hex("block_id", forkedID[:]).
+			Msg("execution is halted, because the execution result of the block differs from its sealed result, " +
+				"run remove-execution-fork to resume execution")
+		return e.unit.Ready()
+	}
 
 	finalized, err := e.state.Final().Head()
 
@@ -62,10 +92,20 @@ func (e *Engine) Done() <-chan struct{} {
 	return e.unit.Done()
 }
 
+// Halted returns whether the execution is halted, because the execution of this node forked from the sealed
+// execution. Blocks must not be executed while halted.
+func (e *Engine) Halted() bool {
+	return atomic.LoadUint32(&e.halted) == 1
+}
+
 // when a block is finalized check if the last sealed has been executed,
 // if it has been executed, check whether if the sealed result is consistent
 // with the executed result
 func (e *Engine) OnFinalizedBlock(block *model.Block) {
+	if e.Halted() {
+		return
+	}
+
 	err := e.checkLastSealed(block.BlockID)
 	if err != nil {
 		e.log.Fatal().Err(err).Msg("execution consistency check failed")
@@ -95,15 +135,51 @@ func (e *Engine) checkLastSealed(finalizedID flow.Identifier) error {
 		return fmt.Errorf("could not get my state commitment OnFinalizedBlock, blockID: %v", blockID)
 	}
 
-	if !bytes.Equal(mycommit, sealedCommit) {
-		sealed, err := e.state.AtBlockID(blockID).Head()
-		if err != nil {
-			return fmt.Errorf("could not get sealed block when checkLastSealed: %v, err: %w", blockID, err)
-		}
+	// blocks that were bootstrapped instead of executed have no execution result of this node
+	myResultID, err := e.execState.GetExecutionResultID(e.unit.Ctx(), blockID)
+	if err != nil && !errors.Is(err, storage.ErrNotFound) {
+		return fmt.Errorf("could not get my execution result, blockID: %v: %w", blockID, err)
+	}
 
-		return fmt.Errorf("execution result is different from the sealed result, height: %v, block_id: %v, sealed_commit: %x, my_commit: %x",
-			sealed.Height, blockID, sealedCommit, mycommit)
+	if bytes.Equal(mycommit, sealedCommit) && (err != nil || myResultID == seal.ResultID) {
+		return nil
 	}
 
+	sealed, err := e.state.AtBlockID(blockID).Head()
+	if err != nil {
+		return fmt.Errorf("could not get sealed block when checkLastSealed: %v, err: %w", blockID, err)
+	}
+
+	return e.halt(sealed, seal, mycommit)
+}
+
+// halt writes the diagnostic dump of the execution fork at the given sealed block, and halts the execution. Failing
+// to write the dump does not prevent the halt.
+func (e *Engine) halt(sealed *flow.Header, seal *flow.Seal, mycommit flow.StateCommitment) error {
+	log := e.log.With().
+		Uint64("height", sealed.Height).
+		Hex("block_id", seal.BlockID[:]).
+		Hex("sealed_commit", seal.FinalState).
+		Hex("my_commit", mycommit).
+		Logger()
+
+	dump := e.forkDump(sealed, seal, mycommit)
+	path, err := writeForkDump(e.dumpDir, dump)
+	if err != nil {
+		log.Error().Err(err).Msg("could not write execution fork dump")
+	}
+
+	err = operation.RetryOnConflict(e.db.Update, operation.InsertExecutionForkHalt(seal.BlockID))
+	if err != nil && !errors.Is(err, storage.ErrAlreadyExists) {
+		return fmt.Errorf("could not store execution halt: %w", err)
+	}
+
+	atomic.StoreUint32(&e.halted, 1)
+
+	log.Error().
+		Str("dump", path).
+		Msg("execution result is different from the sealed result, execution halted, " +
+			"run remove-execution-fork to resume execution")
+
 	return nil
 }
@@ -0,0 +1,177 @@
+package checker
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/consensus/hotstuff/model"
+	"github.com/onflow/flow-go/engine/execution/state/delta"
+	statemock "github.com/onflow/flow-go/engine/execution/state/mock"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/model/messages"
+	protocol "github.com/onflow/flow-go/state/protocol/mock"
+	"github.com/onflow/flow-go/storage"
+	"github.com/onflow/flow-go/storage/badger/operation"
+	storagemock "github.com/onflow/flow-go/storage/mock"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+type testSetup struct {
+	engine    *Engine
+	state     *protocol.State
+	execState *statemock.ExecutionState
+	seals     *storagemock.Seals
+	results   *storagemock.ExecutionResults
+}
+
+func newTestSetup(db *badger.DB, dumpDir string) *testSetup {
+	s := &testSetup{
+		state:     new(protocol.State),
+		execState: new(statemock.ExecutionState),
+		seals:     new(storagemock.Seals),
+		results:   new(storagemock.ExecutionResults),
+	}
+	s.engine = New(zerolog.Nop(), s.state, s.execState, s.seals, s.results, db, dumpDir)
+	return s
+}
+
+// sealResult seals the given result in the given finalized block, and returns the seal.
+func (s *testSetup) sealResult(finalizedID flow.Identifier, result *flow.ExecutionResult) *flow.Seal {
+	seal := unittest.Seal.Fixture(unittest.Seal.WithResult(result))
+	s.seals.On("ByBlockID", finalizedID).Return(seal, nil)
+	s.results.On("ByID", result.ID()).Return(result, nil)
+	return seal
+}
+
+// executeResult makes the given result the result of this node.
+func (s *testSetup) executeResult(result *flow.ExecutionResult, stateDelta *messages.ExecutionStateDelta) {
+	commit, _ := result.FinalStateCommitment()
+	s.execState.On("StateCommitmentByBlockID", mock.Anything, result.BlockID).Return(commit, nil)
+	s.execState.On("GetExecutionResultID", mock.Anything, result.BlockID).Return(result.ID(), nil)
+	s.execState.On("RetrieveStateDelta", mock.Anything, result.BlockID).Return(stateDelta, nil)
+	s.results.On("ByID", result.ID()).Return(result, nil)
+}
+
+// forkedResult returns a copy of the given result, whose last chunk has a different end state.
+func forkedResult(result *flow.ExecutionResult) *flow.ExecutionResult {
+	forked := *result
+	forked.Chunks = make(flow.ChunkList, 0, len(result.Chunks))
+	for _, chunk := range result.Chunks {
+		c := *chunk
+		forked.Chunks = append(forked.Chunks, &c)
+	}
+	forked.Chunks[len(forked.Chunks)-1].EndState = unittest.StateCommitmentFixture()
+	return &forked
+}
+
+// TestCheckMatchingResult tests that execution is not halted if the result matches the sealed result.
+func TestCheckMatchingResult(t *testing.T) {
+	unittest.RunWithBadgerDB(t, func(db *badger.DB) {
+		s := newTestSetup(db, unittest.TempDir(t))
+
+		finalizedID := unittest.IdentifierFixture()
+		result := unittest.ExecutionResultFixture()
+		s.sealResult(finalizedID, result)
+		s.executeResult(result, nil)
+
+		s.engine.OnFinalizedBlock(&model.Block{BlockID: finalizedID})
+
+		assert.False(t, s.engine.Halted())
+		var forkedID flow.Identifier
+		err := db.View(operation.RetrieveExecutionForkHalt(&forkedID))
+		assert.ErrorIs(t, err, storage.ErrNotFound)
+	})
+}
+
+// TestCheckUnexecutedBlock tests that a sealed block that is not executed yet is not checked.
+func TestCheckUnexecutedBlock(t *testing.T) {
+	unittest.RunWithBadgerDB(t, func(db *badger.DB) {
+		s := newTestSetup(db, unittest.TempDir(t))
+
+		finalizedID := unittest.IdentifierFixture()
+		seal := s.sealResult(finalizedID, unittest.ExecutionResultFixture())
+		s.execState.On("StateCommitmentByBlockID", mock.Anything, seal.BlockID).Return(nil, storage.ErrNotFound)
+
+		s.engine.OnFinalizedBlock(&model.Block{BlockID: finalizedID})
+
+		assert.False(t, s.engine.Halted())
+	})
+}
+
+// TestHaltOnForkedResult tests that execution is halted and a diagnostic dump is written if the result differs from
+// the sealed result, and that the halt survives restarts.
+func TestHaltOnForkedResult(t *testing.T) {
+	unittest.RunWithBadgerDB(t, func(db *badger.DB) {
+		dumpDir := unittest.TempDir(t)
+		s := newTestSetup(db, dumpDir)
+
+		finalizedID := unittest.IdentifierFixture()
+		sealed := unittest.ExecutionResultFixture()
+		seal := s.sealResult(finalizedID, sealed)
+
+		// the registers updated by each chunk
+		stateDelta := &messages.ExecutionStateDelta{}
+		for i := range sealed.Chunks {
+			snapshot := &delta.Snapshot{Delta: delta.NewDelta()}
+			snapshot.Delta.Set("owner", "", "key", []byte{byte(i)})
+			stateDelta.StateInteractions = append(stateDelta.StateInteractions, snapshot)
+		}
+		mine := forkedResult(sealed)
+		s.executeResult(mine, stateDelta)
+
+		header := unittest.BlockHeaderFixture()
+		snapshot := new(protocol.Snapshot)
+		snapshot.On("Head").Return(&header, nil)
+		s.state.On("AtBlockID", seal.BlockID).Return(snapshot)
+
+		s.engine.OnFinalizedBlock(&model.Block{BlockID: finalizedID})
+		require.True(t, s.engine.Halted())
+
+		var forkedID flow.Identifier
+		err := db.View(operation.RetrieveExecutionForkHalt(&forkedID))
+		require.NoError(t, err)
+		assert.Equal(t, seal.BlockID, forkedID)
+
+		// the dump holds both results, and the registers of the differing chunk
+		files, err := filepath.Glob(filepath.Join(dumpDir, "execution-fork-*.json"))
+		require.NoError(t, err)
+		require.Len(t, files, 1)
+		data, err := ioutil.ReadFile(files[0])
+		require.NoError(t, err)
+
+		var dump ForkDump
+		err = json.Unmarshal(data, &dump)
+		require.NoError(t, err)
+		assert.Equal(t, seal.BlockID, dump.BlockID)
+		assert.Equal(t, header.Height, dump.Height)
+		assert.Equal(t, sealed.ID(), dump.SealedResult.ID())
+		assert.Equal(t, mine.ID(), dump.MyResult.ID())
+		assert.Empty(t, dump.Errors)
+
+		last := len(sealed.Chunks) - 1
+		require.Len(t, dump.Chunks, len(sealed.Chunks))
+		for i, chunk := range dump.Chunks {
+			assert.Equal(t, i == last, chunk.Differs)
+		}
+		assert.Empty(t, dump.Chunks[0].Registers)
+		require.Len(t, dump.Chunks[last].Registers, 1)
+		assert.Equal(t, flow.RegisterValue{byte(last)}, dump.Chunks[last].Registers[0].Value)
+
+		// once halted, further finalized blocks are not checked
+		s.engine.OnFinalizedBlock(&model.Block{BlockID: unittest.IdentifierFixture()})
+
+		// a restarted node stays halted, without checking the sealed result
+		restarted := newTestSetup(db, dumpDir)
+		<-restarted.engine.Ready()
+		assert.True(t, restarted.engine.Halted())
+		restarted.state.AssertNotCalled(t, "Final")
+	})
+}
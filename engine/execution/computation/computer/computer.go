@@ -47,6 +47,20 @@ type blockComputer struct {
 	log            zerolog.Logger
 	systemChunkCtx fvm.Context
 	committer      ViewCommitter
+	workers        uint // the number of transactions of a collection executed in parallel, 0 or 1 for sequential execution
+
+	traceTransactions bool // whether to record the execution trace of every transaction
+}
+
+// BlockComputerOption configures optional behaviour of a block computer.
+type BlockComputerOption func(*blockComputer)
+
+// WithParallelExecution executes the transactions of a collection speculatively in parallel on the given number of
+// workers. The results are the same as with sequential execution.
+func WithParallelExecution(workers uint) BlockComputerOption {
+	return func(e *blockComputer) {
+		e.workers = workers
+	}
 }
 
 // NewBlockComputer creates a new block executor.
@@ -57,6 +71,7 @@ func NewBlockComputer(
 	tracer module.Tracer,
 	logger zerolog.Logger,
 	committer ViewCommitter,
+	opts ...BlockComputerOption,
 ) (BlockComputer, error) {
 
 	systemChunkCtx := fvm.NewContextFromParent(
@@ -66,7 +81,7 @@ func NewBlockComputer(
 		fvm.WithTransactionProcessors(fvm.NewTransactionInvocator(logger)),
 	)
 
-	return &blockComputer{
+	e := &blockComputer{
 		vm:             vm,
 		vmCtx:          vmCtx,
 		metrics:        metrics,
@@ -74,7 +89,13 @@ func NewBlockComputer(
 		log:            logger,
 		systemChunkCtx: systemChunkCtx,
 		committer:      committer,
-	}, nil
+	}
+
+	for _, opt := range opts {
+		opt(e)
+	}
+
+	return e, nil
 }
 
 // ExecuteBlock executes a block and returns the resulting chunks.
@@ -132,11 +153,12 @@ func (e *blockComputer) executeBlock(
 	proofs := make([][]byte, 0, len(collections)+1)
 
 	bc := blockCommitter{
-		committer: e.committer,
-		blockSpan: blockSpan,
-		tracer:    e.tracer,
-		state:     block.StartState,
-		views:     make(chan state.View, len(collections)+1),
+		committer:  e.committer,
+		blockSpan:  blockSpan,
+		tracer:     e.tracer,
+		state:      block.StartState,
+		awaitState: block.AwaitStartState,
+		views:      make(chan state.View, len(collections)+1),
 		callBack: func(state flow.StateCommitment, proof []byte, err error) {
 			if err != nil {
 				panic(err)
@@ -177,9 +199,17 @@ func (e *blockComputer) executeBlock(
 		return nil, fmt.Errorf("cannot merge view: %w", err)
 	}
 
+	// the state view holds all updates of the block now, so children of the block can start executing on it
+	if block.Executed != nil {
+		block.Executed()
+	}
+
 	// close the views and wait for all views to be committed
 	close(bc.views)
 	wg.Wait()
+	if bc.err != nil {
+		return nil, bc.err
+	}
 	res.StateReads = stateView.(*delta.View).ReadsCount()
 	res.StateCommitments = stateCommitments
 	res.Proofs = proofs
@@ -236,14 +266,22 @@ func (e *blockComputer) executeCollection(
 		colSpan.Finish()
 	}()
 
-	txMetrics := fvm.NewMetricsCollector()
-	txCtx := fvm.NewContextFromParent(blockCtx, fvm.WithMetricsCollector(txMetrics), fvm.WithTracer(e.tracer))
-	for _, txBody := range collection.Transactions {
-		err := e.executeTransaction(txBody, colSpan, txMetrics, collectionView, programs, txCtx, txIndex, res)
-		txIndex++
+	if e.workers > 1 && len(collection.Transactions) > 1 {
+		var err error
+		txIndex, err = e.executeTransactionsInParallel(colSpan, txIndex, blockCtx, collectionView, programs, collection.Transactions, res)
 		if err != nil {
 			return txIndex, err
 		}
+	} else {
+		txMetrics := fvm.NewMetricsCollector()
+		txCtx := fvm.NewContextFromParent(blockCtx, fvm.WithMetricsCollector(txMetrics), fvm.WithTracer(e.tracer))
+		for _, txBody := range collection.Transactions {
+			err := e.executeTransaction(txBody, colSpan, txMetrics, collectionView, programs, txCtx, txIndex, res)
+			txIndex++
+			if err != nil {
+				return txIndex, err
+			}
+		}
 	}
 	res.AddStateSnapshot(collectionView.(*delta.View).Interactions())
 	e.log.Info().Str("collectionID", collection.Guarantee.CollectionID.String()).
@@ -267,36 +305,84 @@ func (e *blockComputer) executeTransaction(
 ) error {
 
 	startedAt := time.Now()
-	var txSpan opentracing.Span
+	txSpan, traceID := e.startTransactionSpan(colSpan, txBody)
+	defer txSpan.Finish()
+
+	txView := collectionView.NewChild()
+
+	if e.traceTransactions {
+		programs.TrackLoaded()
+	}
+
+	tx, err := e.runTransaction(txBody, txSpan, txView, programs, ctx, txIndex)
+	if err != nil {
+		return err
+	}
+
+	var loaded []string
+	if e.traceTransactions {
+		loaded = programs.Loaded()
+	}
+
+	return e.mergeTransaction(tx, txSpan, txMetrics, txView, collectionView, res, traceID, startedAt, loaded)
+}
+
+// startTransactionSpan starts the span of executing the given transaction, and returns it with its trace ID.
+func (e *blockComputer) startTransactionSpan(colSpan opentracing.Span, txBody *flow.TransactionBody) (opentracing.Span, string) {
 	var traceID string
 	// call tracing
-	txSpan = e.tracer.StartSpanFromParent(colSpan, trace.EXEComputeTransaction)
+	txSpan := e.tracer.StartSpanFromParent(colSpan, trace.EXEComputeTransaction)
 
 	if sc, ok := txSpan.Context().(jaeger.SpanContext); ok {
 		traceID = sc.TraceID().String()
 	}
 
-	defer func() {
-		txSpan.LogFields(
-			log.String("transaction.ID", txBody.ID().String()),
-		)
-		txSpan.Finish()
-	}()
+	txSpan.LogFields(
+		log.String("transaction.ID", txBody.ID().String()),
+	)
+
+	return txSpan, traceID
+}
+
+// runTransaction executes the given transaction on the given view, without merging the view.
+func (e *blockComputer) runTransaction(
+	txBody *flow.TransactionBody,
+	txSpan opentracing.Span,
+	txView state.View,
+	programs *programs.Programs,
+	ctx fvm.Context,
+	txIndex uint32,
+) (*fvm.TransactionProcedure, error) {
 
 	e.log.Debug().
 		Hex("tx_id", logging.Entity(txBody)).
 		Msg("executing transaction")
 
-	txView := collectionView.NewChild()
-
 	tx := fvm.Transaction(txBody, txIndex)
 	tx.SetTraceSpan(txSpan)
 
 	err := e.vm.Run(ctx, tx, txView, programs)
 	if err != nil {
-		return fmt.Errorf("failed to execute transaction: %w", err)
+		return nil, fmt.Errorf("failed to execute transaction: %w", err)
 	}
 
+	return tx, nil
+}
+
+// mergeTransaction merges the view of an executed transaction into the collection view, and adds its results to
+// the computation result.
+func (e *blockComputer) mergeTransaction(
+	tx *fvm.TransactionProcedure,
+	parentSpan opentracing.Span,
+	txMetrics *fvm.MetricsCollector,
+	txView state.View,
+	collectionView state.View,
+	res *execution.ComputationResult,
+	traceID string,
+	startedAt time.Time,
+	loaded []string, // the programs loaded by the transaction, if traced
+) error {
+
 	if e.metrics != nil {
 		e.metrics.TransactionParsed(txMetrics.Parsed())
 		e.metrics.TransactionChecked(txMetrics.Checked())
@@ -309,22 +395,31 @@ func (e *blockComputer) executeTransaction(
 	if tx.Err != nil {
 		txResult.ErrorMessage = tx.Err.Error()
 		e.log.Debug().
-			Hex("tx_id", logging.Entity(txBody)).
+			Hex("tx_id", tx.ID[:]).
 			Str("error_message", tx.Err.Error()).
 			Uint16("error_code", uint16(tx.Err.Code())).
 			Msg("transaction execution failed")
 	} else {
 		e.log.Debug().
-			Hex("tx_id", logging.Entity(txBody)).
+			Hex("tx_id", tx.ID[:]).
 			Msg("transaction executed successfully")
 	}
 
-	mergeSpan := e.tracer.StartSpanFromParent(txSpan, trace.EXEMergeTransactionView)
+	// the trace reads the values of the registers before the transaction, so it is taken before merging
+	if e.traceTransactions {
+		txTrace, err := traceTransaction(res.ExecutableBlock.ID(), tx, loaded, txView, collectionView)
+		if err != nil {
+			return fmt.Errorf("could not trace transaction: %w", err)
+		}
+		res.AddTransactionTrace(txTrace)
+	}
+
+	mergeSpan := e.tracer.StartSpanFromParent(parentSpan, trace.EXEMergeTransactionView)
 	defer mergeSpan.Finish()
 
 	// always merge the view, fvm take cares of reverting changes
 	// of failed transaction invocation
-	err = collectionView.MergeView(txView)
+	err := collectionView.MergeView(txView)
 	if err != nil {
 		return fmt.Errorf("merging tx view to collection view failed: %w", err)
 	}
@@ -342,6 +437,164 @@ func (e *blockComputer) executeTransaction(
 	return nil
 }
 
+// speculativeTransaction is a transaction executed in parallel with the other transactions of its collection, on a
+// view of the collection state before any of them was executed.
+type speculativeTransaction struct {
+	tx        *fvm.TransactionProcedure
+	view      *delta.View
+	programs  *programs.Programs
+	loaded    []string // the programs loaded by the transaction, if traced
+	metrics   *fvm.MetricsCollector
+	traceID   string
+	startedAt time.Time
+	err       error // the error of the speculative execution, the transaction is executed again if set
+}
+
+// conflicts returns true if the transaction touched a register written by one of the transactions merged into the
+// collection view, so it may have read a different value than when executed after them.
+func (s *speculativeTransaction) conflicts(collectionView *delta.View) bool {
+	if s.err != nil {
+		return true
+	}
+
+	written := collectionView.Delta().Data
+	for key := range s.view.Interactions().RegisterTouches() {
+		if _, ok := written[key]; ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+// executeTransactionsInParallel executes the transactions of a collection speculatively in parallel, each on its own
+// child of the collection view as it is before any of them is executed, and with its own child of the programs.
+//
+// The speculative results are then merged in transaction order. A transaction that touched a register written by a
+// transaction merged before it may have read a different value than when executed sequentially, so it is executed
+// again on the collection view at that point. All other transactions read the same values as when executed
+// sequentially, so their views, events and results are identical, and so are the resulting state commitment and
+// SPoCK secret of the collection.
+func (e *blockComputer) executeTransactionsInParallel(
+	colSpan opentracing.Span,
+	txIndex uint32,
+	blockCtx fvm.Context,
+	collectionView state.View,
+	programs *programs.Programs,
+	transactions []*flow.TransactionBody,
+	res *execution.ComputationResult,
+) (uint32, error) {
+
+	colView, ok := collectionView.(*delta.View)
+	if !ok {
+		return txIndex, fmt.Errorf("cannot execute transactions in parallel: view type mismatch (given: %T, expected:delta.View)", collectionView)
+	}
+
+	speculative := make([]*speculativeTransaction, len(transactions))
+
+	// the collection view and the programs are only read until all speculative executions are done
+	indices := make(chan int, len(transactions))
+	for i := range transactions {
+		indices <- i
+	}
+	close(indices)
+
+	var wg sync.WaitGroup
+	for w := uint(0); w < e.workers && int(w) < len(transactions); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indices {
+				speculative[i] = e.speculateTransaction(transactions[i], colSpan, blockCtx, colView, programs, txIndex+uint32(i))
+			}
+		}()
+	}
+	wg.Wait()
+
+	conflicts := 0
+	txMetrics := fvm.NewMetricsCollector()
+	txCtx := fvm.NewContextFromParent(blockCtx, fvm.WithMetricsCollector(txMetrics), fvm.WithTracer(e.tracer))
+	for i, txBody := range transactions {
+		s := speculative[i]
+
+		if s.conflicts(colView) {
+			conflicts++
+			e.log.Debug().
+				Hex("tx_id", logging.Entity(txBody)).
+				AnErr("speculative_error", s.err).
+				Msg("executing conflicting transaction again")
+
+			err := e.executeTransaction(txBody, colSpan, txMetrics, collectionView, programs, txCtx, txIndex, res)
+			txIndex++
+			if err != nil {
+				return txIndex, err
+			}
+			continue
+		}
+
+		// a contract update invalidates the programs, as it would have when executed with the collection programs
+		if s.programs.Cleaned() {
+			programs.ForceCleanup()
+		}
+
+		err := e.mergeTransaction(s.tx, colSpan, s.metrics, s.view, collectionView, res, s.traceID, s.startedAt, s.loaded)
+		txIndex++
+		if err != nil {
+			return txIndex, err
+		}
+	}
+
+	e.log.Debug().
+		Int("transactions", len(transactions)).
+		Int("conflicts", conflicts).
+		Msg("transactions executed in parallel")
+
+	return txIndex, nil
+}
+
+// speculateTransaction executes the given transaction on a child of the collection view. Any failure of the
+// speculative execution is recorded instead of returned, as the transaction is then executed again sequentially.
+func (e *blockComputer) speculateTransaction(
+	txBody *flow.TransactionBody,
+	colSpan opentracing.Span,
+	blockCtx fvm.Context,
+	collectionView *delta.View,
+	programs *programs.Programs,
+	txIndex uint32,
+) (s *speculativeTransaction) {
+
+	s = &speculativeTransaction{
+		view:      collectionView.NewChild().(*delta.View),
+		programs:  programs.ChildPrograms(),
+		metrics:   fvm.NewMetricsCollector(),
+		startedAt: time.Now(),
+	}
+
+	txSpan, traceID := e.startTransactionSpan(colSpan, txBody)
+	defer txSpan.Finish()
+	s.traceID = traceID
+
+	// reading registers in a state that cannot occur sequentially must not crash the node
+	defer func() {
+		if r := recover(); r != nil {
+			s.err = fmt.Errorf("speculative execution panicked: %v", r)
+		}
+	}()
+
+	if e.traceTransactions {
+		s.programs.TrackLoaded()
+	}
+
+	txCtx := fvm.NewContextFromParent(blockCtx, fvm.WithMetricsCollector(s.metrics), fvm.WithTracer(e.tracer))
+	s.tx, s.err = e.runTransaction(txBody, txSpan, s.view, s.programs, txCtx, txIndex)
+
+	if e.traceTransactions {
+		s.loaded = s.programs.Loaded()
+	}
+
+	return s
+}
+
 type blockCommitter struct {
 	tracer    module.Tracer
 	committer ViewCommitter
@@ -349,10 +602,24 @@ type blockCommitter struct {
 	state     flow.StateCommitment
 	views     chan state.View
 	blockSpan opentracing.Span
+
+	awaitState func() (flow.StateCommitment, error) // waits for the start state, if it is not known yet
+	err        error                                // the error waiting for the start state
 }
 
 func (bc *blockCommitter) Run() {
 	for view := range bc.views {
+		// the views are drained, even if they can't be committed
+		if bc.err != nil {
+			continue
+		}
+		if bc.state == nil && bc.awaitState != nil {
+			bc.state, bc.err = bc.awaitState()
+			if bc.err != nil {
+				bc.err = fmt.Errorf("could not get start state: %w", bc.err)
+				continue
+			}
+		}
 		span := bc.tracer.StartSpanFromParent(bc.blockSpan, trace.EXECommitDelta)
 		stateCommit, proof, err := bc.committer.CommitView(view, bc.state)
 		bc.callBack(stateCommit, proof, err)
@@ -16,6 +16,7 @@ import (
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 
+	"github.com/onflow/flow-go/engine/execution"
 	"github.com/onflow/flow-go/engine/execution/computation/committer"
 	"github.com/onflow/flow-go/engine/execution/computation/computer"
 	computermock "github.com/onflow/flow-go/engine/execution/computation/computer/mock"
@@ -101,6 +102,79 @@ func TestBlockExecutor_ExecuteBlock(t *testing.T) {
 		vm.AssertExpectations(t)
 	})
 
+	t.Run("block executes before its start state is known", func(t *testing.T) {
+
+		execCtx := fvm.NewContext(zerolog.Nop())
+
+		vm := new(computermock.VirtualMachine)
+		vm.On("Run", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+			Return(nil).
+			Times(2 + 1) // 2 txs in collection + system chunk
+
+		startState := unittest.StateCommitmentFixture()
+		committer := new(computermock.ViewCommitter)
+		committer.On("CommitView", mock.Anything, startState).
+			Return(unittest.StateCommitmentFixture(), nil, nil).
+			Once()
+		committer.On("CommitView", mock.Anything, mock.Anything).
+			Return(unittest.StateCommitmentFixture(), nil, nil).
+			Once()
+
+		exe, err := computer.NewBlockComputer(vm, execCtx, nil, trace.NewNoopTracer(), zerolog.Nop(), committer)
+		require.NoError(t, err)
+
+		block := generateBlock(1, 2, rag)
+
+		// the start state is only known once the transactions of the block are executed
+		executed := make(chan struct{})
+		block.Executed = func() {
+			close(executed)
+		}
+		block.AwaitStartState = func() (flow.StateCommitment, error) {
+			<-executed
+			return startState, nil
+		}
+
+		view := delta.NewView(func(owner, controller, key string) (flow.RegisterValue, error) {
+			return nil, nil
+		})
+
+		result, err := exe.ExecuteBlock(context.Background(), block, view, programs.NewEmptyPrograms())
+		require.NoError(t, err)
+		assert.Len(t, result.StateCommitments, 1+1) // +1 system chunk
+
+		vm.AssertExpectations(t)
+		committer.AssertExpectations(t)
+	})
+
+	t.Run("block fails if its start state is not known", func(t *testing.T) {
+
+		execCtx := fvm.NewContext(zerolog.Nop())
+
+		vm := new(computermock.VirtualMachine)
+		vm.On("Run", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+			Return(nil)
+
+		committer := new(computermock.ViewCommitter)
+
+		exe, err := computer.NewBlockComputer(vm, execCtx, nil, trace.NewNoopTracer(), zerolog.Nop(), committer)
+		require.NoError(t, err)
+
+		block := generateBlock(1, 2, rag)
+		block.AwaitStartState = func() (flow.StateCommitment, error) {
+			return nil, fmt.Errorf("parent failed")
+		}
+
+		view := delta.NewView(func(owner, controller, key string) (flow.RegisterValue, error) {
+			return nil, nil
+		})
+
+		_, err = exe.ExecuteBlock(context.Background(), block, view, programs.NewEmptyPrograms())
+		require.Error(t, err)
+
+		committer.AssertNotCalled(t, "CommitView", mock.Anything, mock.Anything)
+	})
+
 	t.Run("multiple collections", func(t *testing.T) {
 		execCtx := fvm.NewContext(zerolog.Nop())
 
@@ -380,6 +454,191 @@ func TestBlockExecutor_ExecuteBlock(t *testing.T) {
 	})
 }
 
+// TestBlockExecutor_ExecuteBlockInParallel tests that executing the transactions of a collection in parallel
+// results in the same state, SPoCK secrets and transaction results as executing them sequentially, also if some of
+// the transactions conflict.
+func TestBlockExecutor_ExecuteBlockInParallel(t *testing.T) {
+
+	logger := zerolog.Nop()
+
+	execCtx := fvm.NewContext(
+		logger,
+		fvm.WithTransactionProcessors(
+			fvm.NewTransactionInvocator(logger),
+		),
+	)
+
+	// each transaction increments a counter in the storage of the account given by its script, the transactions
+	// incrementing the counter of the same account conflict
+	rt := &testRuntime{
+		executeTransaction: func(script runtime.Script, r runtime.Context) error {
+			owner := flow.HexToAddress(string(script.Source)).Bytes()
+
+			value, err := r.Interface.GetValue(owner, []byte("counter"))
+			if err != nil {
+				return err
+			}
+
+			var counter byte
+			if len(value) > 0 {
+				counter = value[0]
+			}
+
+			return r.Interface.SetValue(owner, []byte("counter"), []byte{counter + 1})
+		},
+	}
+
+	vm := fvm.NewVirtualMachine(rt)
+
+	const collectionCount = 2
+	const transactionCount = 10
+
+	// every other transaction increments the counter of the shared account 0x01
+	var txCount int
+	block := generateBlockWithVisitor(collectionCount, transactionCount, &RandomAddressGenerator{}, func(txBody *flow.TransactionBody) {
+		if txCount%2 == 0 {
+			txBody.Script = []byte("01")
+		} else {
+			txBody.Script = []byte(fmt.Sprintf("%02x", txCount+2))
+		}
+		txCount++
+	})
+
+	execute := func(opts ...computer.BlockComputerOption) *execution.ComputationResult {
+		exe, err := computer.NewBlockComputer(vm, execCtx, nil, trace.NewNoopTracer(), logger, committer.NewNoopViewCommitter(), opts...)
+		require.NoError(t, err)
+
+		// all accounts exist and use no storage
+		view := delta.NewView(func(owner, controller, key string) (flow.RegisterValue, error) {
+			if key == state.KeyStorageUsed {
+				return make([]byte, 8), nil
+			}
+			return nil, nil
+		})
+
+		result, err := exe.ExecuteBlock(context.Background(), block, view, programs.NewEmptyPrograms())
+		require.NoError(t, err)
+		return result
+	}
+
+	sequential := execute()
+	parallel := execute(computer.WithParallelExecution(4))
+
+	require.Len(t, parallel.StateSnapshots, collectionCount+1) // +1 system chunk
+	for i := range sequential.StateSnapshots {
+		assert.Equal(t, sequential.StateSnapshots[i].Delta, parallel.StateSnapshots[i].Delta)
+		assert.Equal(t, sequential.StateSnapshots[i].Reads, parallel.StateSnapshots[i].Reads)
+		assert.Equal(t, sequential.StateSnapshots[i].SpockSecret, parallel.StateSnapshots[i].SpockSecret)
+	}
+	assert.Equal(t, sequential.TransactionResults, parallel.TransactionResults)
+	assert.Equal(t, sequential.StateReads, parallel.StateReads)
+
+	// the conflicting transactions were executed after each other
+	shared := flow.HexToAddress("01")
+	counter, ok := parallel.StateSnapshots[0].Delta.Get(string(shared.Bytes()), "", "counter")
+	require.True(t, ok)
+	assert.Equal(t, flow.RegisterValue{transactionCount / 2}, counter)
+}
+
+// TestBlockExecutor_TransactionTraces tests that the execution traces of transactions record the registers they
+// read and wrote and the programs they loaded, also when executed in parallel.
+func TestBlockExecutor_TransactionTraces(t *testing.T) {
+
+	logger := zerolog.Nop()
+
+	execCtx := fvm.NewContext(
+		logger,
+		fvm.WithTransactionProcessors(
+			fvm.NewTransactionInvocator(logger),
+		),
+	)
+
+	location := common.IdentifierLocation("Counter")
+
+	// each transaction loads the counter program, and increments a counter in the storage of the account given by
+	// its script
+	rt := &testRuntime{
+		executeTransaction: func(script runtime.Script, r runtime.Context) error {
+			program, err := r.Interface.GetProgram(location)
+			if err != nil {
+				return err
+			}
+			if program == nil {
+				err = r.Interface.SetProgram(location, &interpreter.Program{})
+				if err != nil {
+					return err
+				}
+			}
+
+			owner := flow.HexToAddress(string(script.Source)).Bytes()
+
+			value, err := r.Interface.GetValue(owner, []byte("counter"))
+			if err != nil {
+				return err
+			}
+
+			var counter byte
+			if len(value) > 0 {
+				counter = value[0]
+			}
+
+			return r.Interface.SetValue(owner, []byte("counter"), []byte{counter + 1})
+		},
+	}
+
+	vm := fvm.NewVirtualMachine(rt)
+
+	const transactionCount = 4
+
+	// all transactions increment the counter of the account 0x01
+	block := generateBlockWithVisitor(1, transactionCount, &RandomAddressGenerator{}, func(txBody *flow.TransactionBody) {
+		txBody.Script = []byte("01")
+	})
+
+	execute := func(opts ...computer.BlockComputerOption) *execution.ComputationResult {
+		exe, err := computer.NewBlockComputer(vm, execCtx, nil, trace.NewNoopTracer(), logger, committer.NewNoopViewCommitter(), opts...)
+		require.NoError(t, err)
+
+		// all accounts exist and use no storage
+		view := delta.NewView(func(owner, controller, key string) (flow.RegisterValue, error) {
+			if key == state.KeyStorageUsed {
+				return make([]byte, 8), nil
+			}
+			return nil, nil
+		})
+
+		result, err := exe.ExecuteBlock(context.Background(), block, view, programs.NewEmptyPrograms())
+		require.NoError(t, err)
+		return result
+	}
+
+	untraced := execute()
+	assert.Empty(t, untraced.TransactionTraces)
+
+	sequential := execute(computer.WithTransactionTraces())
+	parallel := execute(computer.WithTransactionTraces(), computer.WithParallelExecution(4))
+
+	require.Len(t, sequential.TransactionTraces, transactionCount+1) // +1 system transaction
+	assert.Equal(t, sequential.TransactionTraces, parallel.TransactionTraces)
+
+	counter := flow.NewRegisterID(string(flow.HexToAddress("01").Bytes()), "", "counter")
+	for i, txBody := range block.CompleteCollections[block.Block.Payload.Guarantees[0].ID()].Transactions {
+		txTrace := sequential.TransactionTraces[i]
+		assert.Equal(t, block.ID(), txTrace.BlockID)
+		assert.Equal(t, txBody.ID(), txTrace.TransactionID)
+		assert.Equal(t, uint32(i), txTrace.TransactionIndex)
+		assert.Equal(t, []string{string(location.ID())}, txTrace.Programs)
+
+		// the counter is absent before the first transaction, and a single byte after
+		var oldValueSize uint64
+		if i > 0 {
+			oldValueSize = 1
+		}
+		assert.Contains(t, txTrace.Reads, flow.RegisterTrace{Register: counter, OldValueSize: oldValueSize})
+		assert.Contains(t, txTrace.Writes, flow.RegisterTrace{Register: counter, OldValueSize: oldValueSize, NewValueSize: 1})
+	}
+}
+
 type testRuntime struct {
 	executeScript      func(runtime.Script, runtime.Context) (cadence.Value, error)
 	executeTransaction func(runtime.Script, runtime.Context) error
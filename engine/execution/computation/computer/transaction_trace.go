@@ -0,0 +1,91 @@
+package computer
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/onflow/flow-go/engine/execution/state/delta"
+	"github.com/onflow/flow-go/fvm"
+	"github.com/onflow/flow-go/fvm/state"
+	"github.com/onflow/flow-go/model/flow"
+)
+
+// WithTransactionTraces records the execution trace of every transaction in the computation result: the registers it
+// read and wrote, the programs it loaded, the computation it used and the events it emitted.
+func WithTransactionTraces() BlockComputerOption {
+	return func(e *blockComputer) {
+		e.traceTransactions = true
+	}
+}
+
+// traceTransaction returns the execution trace of the given transaction, executed on the given view. The collection
+// view is the view the transaction view is merged into, so it must not be merged yet, to read the values of the
+// registers before the transaction.
+func traceTransaction(
+	blockID flow.Identifier,
+	tx *fvm.TransactionProcedure,
+	loaded []string,
+	txView state.View,
+	collectionView state.View,
+) (*flow.TransactionTrace, error) {
+
+	view, ok := txView.(*delta.View)
+	if !ok {
+		return nil, fmt.Errorf("view type mismatch (given: %T, expected:delta.View)", txView)
+	}
+	colView, ok := collectionView.(*delta.View)
+	if !ok {
+		return nil, fmt.Errorf("view type mismatch (given: %T, expected:delta.View)", collectionView)
+	}
+
+	// the size of the value of a register before the transaction, read without touching the collection view
+	oldValueSize := func(id flow.RegisterID) (uint64, error) {
+		value, err := colView.Peek(id.Owner, id.Controller, id.Key)
+		if err != nil {
+			return 0, fmt.Errorf("could not read register %v: %w", id.String(), err)
+		}
+		return uint64(len(value)), nil
+	}
+
+	interactions := view.Interactions()
+
+	trace := &flow.TransactionTrace{
+		BlockID:          blockID,
+		TransactionID:    tx.ID,
+		TransactionIndex: tx.TxIndex,
+		Reads:            make([]flow.RegisterTrace, 0, len(interactions.Reads)),
+		Writes:           make([]flow.RegisterTrace, 0, len(interactions.Delta.Data)),
+		Programs:         loaded,
+		ComputationUsed:  tx.GasUsed,
+		Events:           tx.Events,
+	}
+
+	for _, id := range interactions.Reads {
+		size, err := oldValueSize(id)
+		if err != nil {
+			return nil, err
+		}
+		trace.Reads = append(trace.Reads, flow.RegisterTrace{Register: id, OldValueSize: size})
+	}
+
+	ids, values := interactions.Delta.RegisterUpdates()
+	for i, id := range ids {
+		size, err := oldValueSize(id)
+		if err != nil {
+			return nil, err
+		}
+		trace.Writes = append(trace.Writes, flow.RegisterTrace{Register: id, OldValueSize: size, NewValueSize: uint64(len(values[i]))})
+	}
+
+	// sort the registers, so the traces of the same execution are identical
+	sortRegisterTraces(trace.Reads)
+	sortRegisterTraces(trace.Writes)
+
+	return trace, nil
+}
+
+func sortRegisterTraces(traces []flow.RegisterTrace) {
+	sort.Slice(traces, func(i, j int) bool {
+		return traces[i].Register.String() < traces[j].Register.String()
+	})
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/onflow/cadence"
 	jsoncdc "github.com/onflow/cadence/encoding/json"
 	"github.com/rs/zerolog"
 
@@ -34,6 +35,7 @@ type ComputationManager interface {
 		view state.View,
 	) (*execution.ComputationResult, error)
 	GetAccount(addr flow.Address, header *flow.Header, view state.View) (*flow.Account, error)
+	DryRunTransaction(tx *flow.TransactionBody, skipSignatureCheck bool, header *flow.Header, view state.View) (*execution.DryRunResult, error)
 }
 
 // Manager manages computation and execution
@@ -57,9 +59,16 @@ func New(
 	vmCtx fvm.Context,
 	programsCacheSize uint,
 	committer computer.ViewCommitter,
+	parallelExecutionWorkers uint,
+	transactionTraces bool,
 ) (*Manager, error) {
 	log := logger.With().Str("engine", "computation").Logger()
 
+	opts := []computer.BlockComputerOption{computer.WithParallelExecution(parallelExecutionWorkers)}
+	if transactionTraces {
+		opts = append(opts, computer.WithTransactionTraces())
+	}
+
 	blockComputer, err := computer.NewBlockComputer(
 		vm,
 		vmCtx,
@@ -67,6 +76,7 @@ func New(
 		tracer,
 		log.With().Str("component", "block_computer").Logger(),
 		committer,
+		opts...,
 	)
 
 	if err != nil {
@@ -161,6 +171,15 @@ func (e *Manager) ComputeBlock(
 		blockPrograms = fromCache.ChildPrograms()
 	}
 
+	// with pipelined execution, the children of the block start executing once its transactions are executed, so
+	// the programs are cached before, for the children to use them
+	if executed := block.Executed; executed != nil {
+		block.Executed = func() {
+			e.cachePrograms(block.ID(), fromCache, blockPrograms)
+			executed()
+		}
+	}
+
 	result, err := e.blockComputer.ExecuteBlock(ctx, block, view, blockPrograms)
 	if err != nil {
 		e.log.Error().
@@ -170,6 +189,17 @@ func (e *Manager) ComputeBlock(
 		return nil, fmt.Errorf("failed to execute block: %w", err)
 	}
 
+	e.cachePrograms(block.ID(), fromCache, blockPrograms)
+
+	e.log.Debug().
+		Hex("block_id", logging.Entity(result.ExecutableBlock.Block)).
+		Msg("computed block result")
+
+	return result, nil
+}
+
+// cachePrograms caches the programs of the executed block.
+func (e *Manager) cachePrograms(blockID flow.Identifier, fromCache *programs.Programs, blockPrograms *programs.Programs) {
 	toInsert := blockPrograms
 
 	// if we have item from cache and there were no changes
@@ -178,13 +208,7 @@ func (e *Manager) ComputeBlock(
 		toInsert = fromCache
 	}
 
-	e.programsCache.Set(block.ID(), toInsert)
-
-	e.log.Debug().
-		Hex("block_id", logging.Entity(result.ExecutableBlock.Block)).
-		Msg("computed block result")
-
-	return result, nil
+	e.programsCache.Set(blockID, toInsert)
 }
 
 func (e *Manager) GetAccount(address flow.Address, blockHeader *flow.Header, view state.View) (*flow.Account, error) {
@@ -199,3 +223,135 @@ func (e *Manager) GetAccount(address flow.Address, blockHeader *flow.Header, vie
 
 	return account, nil
 }
+
+// DryRunTransaction executes the transaction on a child of the given view, so none of its changes are kept, and
+// returns the computation it used, the events it emitted, how it changed the storage used by accounts and the fee
+// its payer would be charged. Signatures are not verified if skipSignatureCheck is set, so a transaction can be
+// estimated before it is signed.
+func (e *Manager) DryRunTransaction(
+	tx *flow.TransactionBody,
+	skipSignatureCheck bool,
+	blockHeader *flow.Header,
+	view state.View,
+) (*execution.DryRunResult, error) {
+	blockCtx := fvm.NewContextFromParent(e.vmCtx, fvm.WithBlockHeader(blockHeader))
+	if skipSignatureCheck {
+		processors := make([]fvm.TransactionProcessor, 0, len(blockCtx.TransactionProcessors))
+		for _, processor := range blockCtx.TransactionProcessors {
+			if _, ok := processor.(*fvm.TransactionSignatureVerifier); !ok {
+				processors = append(processors, processor)
+			}
+		}
+		blockCtx = fvm.NewContextFromParent(blockCtx, fvm.WithTransactionProcessors(processors...))
+	}
+
+	programs := e.getChildProgramsOrEmpty(blockHeader.ID())
+	txView := view.NewChild()
+	proc := fvm.Transaction(tx, 0)
+
+	err := func() (err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				err = fmt.Errorf("cadence runtime error: %s", r)
+
+				e.log.Error().
+					Hex("tx_id", logging.Entity(tx)).
+					Interface("r", r).
+					Msg("transaction dry run caused runtime panic")
+			}
+		}()
+
+		return e.vm.Run(blockCtx, proc, txView, programs)
+	}()
+	if err != nil {
+		return nil, fmt.Errorf("failed to dry run transaction (internal error): %w", err)
+	}
+
+	storageDeltas, err := storageDeltas(view, txView)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compute storage deltas: %w", err)
+	}
+
+	fee, err := e.transactionFee(blockCtx, view.NewChild(), programs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to estimate transaction fee: %w", err)
+	}
+
+	return &execution.DryRunResult{
+		ComputationUsed: proc.GasUsed,
+		Events:          proc.Events,
+		StorageDeltas:   storageDeltas,
+		FeeEstimate:     fee,
+		Err:             proc.Err,
+	}, nil
+}
+
+// transactionFee returns the fee the service account deducts from the payer of each transaction. There is no fee
+// if the service account is disabled.
+func (e *Manager) transactionFee(ctx fvm.Context, view state.View, programs *programs.Programs) (uint64, error) {
+	if !ctx.ServiceAccountEnabled {
+		return 0, nil
+	}
+
+	script := fvm.TransactionFeeScript(ctx.Chain.ServiceAddress())
+	err := e.vm.Run(ctx, script, view, programs)
+	if err != nil {
+		return 0, err
+	}
+	if script.Err != nil {
+		return 0, script.Err
+	}
+
+	fee, ok := script.Value.(cadence.UFix64)
+	if !ok {
+		return 0, fmt.Errorf("unexpected transaction fee type: %T", script.Value)
+	}
+
+	return uint64(fee), nil
+}
+
+// storageDeltas returns the change of the storage used by each account with registers written in the child view.
+func storageDeltas(view state.View, child state.View) (map[flow.Address]int64, error) {
+	before := state.NewAccounts(state.NewStateHolder(state.NewState(view.NewChild())))
+	after := state.NewAccounts(state.NewStateHolder(state.NewState(child.NewChild())))
+
+	deltas := make(map[flow.Address]int64)
+	registerIDs, _ := child.RegisterUpdates()
+	for _, registerID := range registerIDs {
+		// registers that are not owned by an account, such as the address generator state, have no owner
+		if len(registerID.Owner) != flow.AddressLength {
+			continue
+		}
+
+		address := flow.BytesToAddress([]byte(registerID.Owner))
+		if _, ok := deltas[address]; ok {
+			continue
+		}
+
+		used, err := storageUsed(after, address)
+		if err != nil {
+			return nil, err
+		}
+		previous, err := storageUsed(before, address)
+		if err != nil {
+			return nil, err
+		}
+
+		deltas[address] = int64(used) - int64(previous)
+	}
+
+	return deltas, nil
+}
+
+// storageUsed returns the storage used by the account, which is 0 if the account does not exist (yet).
+func storageUsed(accounts *state.Accounts, address flow.Address) (uint64, error) {
+	exists, err := accounts.Exists(address)
+	if err != nil {
+		return 0, err
+	}
+	if !exists {
+		return 0, nil
+	}
+
+	return accounts.GetStorageUsed(address)
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"testing"
 
+	"github.com/onflow/cadence"
 	"github.com/rs/zerolog"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -15,8 +16,10 @@ import (
 	"github.com/onflow/flow-go/engine/execution/state/delta"
 	"github.com/onflow/flow-go/engine/execution/testutil"
 	"github.com/onflow/flow-go/fvm"
+	"github.com/onflow/flow-go/fvm/errors"
 	"github.com/onflow/flow-go/fvm/programs"
 	"github.com/onflow/flow-go/fvm/state"
+	"github.com/onflow/flow-go/fvm/utils"
 	"github.com/onflow/flow-go/model/flow"
 	"github.com/onflow/flow-go/module/mempool/entity"
 	module "github.com/onflow/flow-go/module/mock"
@@ -141,7 +144,7 @@ func TestExecuteScript(t *testing.T) {
 		fvm.FungibleTokenAddress(execCtx.Chain).HexWithPrefix(),
 	))
 
-	engine, err := New(logger, nil, nil, me, nil, vm, execCtx, DefaultProgramsCacheSize, committer.NewNoopViewCommitter())
+	engine, err := New(logger, nil, nil, me, nil, vm, execCtx, DefaultProgramsCacheSize, committer.NewNoopViewCommitter(), 0, false)
 	require.NoError(t, err)
 
 	header := unittest.BlockHeaderFixture()
@@ -149,6 +152,68 @@ func TestExecuteScript(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestDryRunTransaction(t *testing.T) {
+
+	logger := zerolog.Nop()
+	chain := flow.Mainnet.Chain()
+
+	execCtx := fvm.NewContext(logger, fvm.WithChain(chain))
+	vm := fvm.NewVirtualMachine(fvm.NewInterpreterRuntime())
+
+	// bootstrap with a transaction fee, so there is a fee to estimate
+	fee, err := cadence.NewUFix64("0.01")
+	require.NoError(t, err)
+
+	ledger := utils.NewSimpleView()
+	err = vm.Run(execCtx, fvm.Bootstrap(
+		unittest.ServiceAccountPublicKey,
+		fvm.WithInitialTokenSupply(unittest.GenesisTokenSupply),
+		fvm.WithTransactionFee(fee),
+	), ledger, programs.NewEmptyPrograms())
+	require.NoError(t, err)
+
+	view := delta.NewView(ledger.Get)
+
+	manager, err := New(logger, nil, nil, nil, nil, vm, execCtx, DefaultProgramsCacheSize, committer.NewNoopViewCommitter(), 0, false)
+	require.NoError(t, err)
+
+	// the transaction is not signed
+	_, tx := testutil.CreateAccountCreationTransaction(t, chain)
+	tx.SetProposalKey(chain.ServiceAddress(), 0, 0).
+		SetPayer(chain.ServiceAddress())
+
+	header := unittest.BlockHeaderFixture()
+
+	t.Run("without signature check", func(t *testing.T) {
+		result, err := manager.DryRunTransaction(tx, true, &header, view)
+		require.NoError(t, err)
+		require.Nil(t, result.Err)
+
+		assert.Equal(t, uint64(fee), result.FeeEstimate)
+		assert.NotEmpty(t, result.Events)
+
+		// the created account did not use any storage before
+		require.NotEmpty(t, result.StorageDeltas)
+		created := false
+		for address, delta := range result.StorageDeltas {
+			if address != chain.ServiceAddress() && delta > 0 {
+				created = true
+			}
+		}
+		assert.True(t, created)
+
+		// none of the changes are kept
+		assert.Empty(t, view.Delta().Data)
+	})
+
+	t.Run("with signature check", func(t *testing.T) {
+		result, err := manager.DryRunTransaction(tx, false, &header, view)
+		require.NoError(t, err)
+		require.NotNil(t, result.Err)
+		assert.Equal(t, errors.ErrCodeInvalidProposalSignatureError, result.Err.Code())
+	})
+}
+
 func TestExecuteScripPanicsAreHandled(t *testing.T) {
 
 	ctx := fvm.NewContext(zerolog.Nop())
@@ -163,7 +228,7 @@ func TestExecuteScripPanicsAreHandled(t *testing.T) {
 	})
 	header := unittest.BlockHeaderFixture()
 
-	manager, err := New(log, nil, nil, nil, nil, vm, ctx, DefaultProgramsCacheSize, committer.NewNoopViewCommitter())
+	manager, err := New(log, nil, nil, nil, nil, vm, ctx, DefaultProgramsCacheSize, committer.NewNoopViewCommitter(), 0, false)
 	require.NoError(t, err)
 
 	_, err = manager.ExecuteScript([]byte("whatever"), nil, &header, view)
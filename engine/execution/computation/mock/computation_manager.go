@@ -43,6 +43,29 @@ func (_m *ComputationManager) ComputeBlock(ctx context.Context, block *entity.Ex
 	return r0, r1
 }
 
+// DryRunTransaction provides a mock function with given fields: tx, skipSignatureCheck, header, view
+func (_m *ComputationManager) DryRunTransaction(tx *flow.TransactionBody, skipSignatureCheck bool, header *flow.Header, view state.View) (*execution.DryRunResult, error) {
+	ret := _m.Called(tx, skipSignatureCheck, header, view)
+
+	var r0 *execution.DryRunResult
+	if rf, ok := ret.Get(0).(func(*flow.TransactionBody, bool, *flow.Header, state.View) *execution.DryRunResult); ok {
+		r0 = rf(tx, skipSignatureCheck, header, view)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*execution.DryRunResult)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*flow.TransactionBody, bool, *flow.Header, state.View) error); ok {
+		r1 = rf(tx, skipSignatureCheck, header, view)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // ExecuteScript provides a mock function with given fields: _a0, _a1, _a2, _a3
 func (_m *ComputationManager) ExecuteScript(_a0 []byte, _a1 [][]byte, _a2 *flow.Header, _a3 state.View) ([]byte, error) {
 	ret := _m.Called(_a0, _a1, _a2, _a3)
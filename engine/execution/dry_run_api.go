@@ -0,0 +1,187 @@
+package execution
+
+import (
+	"context"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/onflow/flow/protobuf/go/flow/entities"
+	"google.golang.org/grpc"
+)
+
+// ExecutionDryRunAPI executes a transaction against the state of a block without committing any of its changes.
+
+// DryRunTransactionRequest is the request message of ExecutionDryRunAPI.DryRunTransaction. The signatures of
+// the transaction are not verified if skip_signature_check is set.
+type DryRunTransactionRequest struct {
+	BlockId            []byte                `protobuf:"bytes,1,opt,name=block_id,json=blockId,proto3" json:"block_id,omitempty"`
+	Transaction        *entities.Transaction `protobuf:"bytes,2,opt,name=transaction,proto3" json:"transaction,omitempty"`
+	SkipSignatureCheck bool                  `protobuf:"varint,3,opt,name=skip_signature_check,json=skipSignatureCheck,proto3" json:"skip_signature_check,omitempty"`
+}
+
+func (m *DryRunTransactionRequest) Reset()         { *m = DryRunTransactionRequest{} }
+func (m *DryRunTransactionRequest) String() string { return proto.CompactTextString(m) }
+func (*DryRunTransactionRequest) ProtoMessage()    {}
+
+func (m *DryRunTransactionRequest) GetBlockId() []byte {
+	if m != nil {
+		return m.BlockId
+	}
+	return nil
+}
+
+func (m *DryRunTransactionRequest) GetTransaction() *entities.Transaction {
+	if m != nil {
+		return m.Transaction
+	}
+	return nil
+}
+
+func (m *DryRunTransactionRequest) GetSkipSignatureCheck() bool {
+	if m != nil {
+		return m.SkipSignatureCheck
+	}
+	return false
+}
+
+// AccountStorageDelta is the change of the storage used by an account, in bytes.
+type AccountStorageDelta struct {
+	Address []byte `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	Delta   int64  `protobuf:"varint,2,opt,name=delta,proto3" json:"delta,omitempty"`
+}
+
+func (m *AccountStorageDelta) Reset()         { *m = AccountStorageDelta{} }
+func (m *AccountStorageDelta) String() string { return proto.CompactTextString(m) }
+func (*AccountStorageDelta) ProtoMessage()    {}
+
+func (m *AccountStorageDelta) GetAddress() []byte {
+	if m != nil {
+		return m.Address
+	}
+	return nil
+}
+
+func (m *AccountStorageDelta) GetDelta() int64 {
+	if m != nil {
+		return m.Delta
+	}
+	return 0
+}
+
+// DryRunTransactionResponse holds the outcome of a dry run. The error code and message are set if the
+// transaction would fail, the fee estimate is in the smallest unit of FLOW.
+type DryRunTransactionResponse struct {
+	ComputationUsed uint64                 `protobuf:"varint,1,opt,name=computation_used,json=computationUsed,proto3" json:"computation_used,omitempty"`
+	Events          []*entities.Event      `protobuf:"bytes,2,rep,name=events,proto3" json:"events,omitempty"`
+	StorageDeltas   []*AccountStorageDelta `protobuf:"bytes,3,rep,name=storage_deltas,json=storageDeltas,proto3" json:"storage_deltas,omitempty"`
+	FeeEstimate     uint64                 `protobuf:"varint,4,opt,name=fee_estimate,json=feeEstimate,proto3" json:"fee_estimate,omitempty"`
+	ErrorCode       uint32                 `protobuf:"varint,5,opt,name=error_code,json=errorCode,proto3" json:"error_code,omitempty"`
+	ErrorMessage    string                 `protobuf:"bytes,6,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
+}
+
+func (m *DryRunTransactionResponse) Reset()         { *m = DryRunTransactionResponse{} }
+func (m *DryRunTransactionResponse) String() string { return proto.CompactTextString(m) }
+func (*DryRunTransactionResponse) ProtoMessage()    {}
+
+func (m *DryRunTransactionResponse) GetComputationUsed() uint64 {
+	if m != nil {
+		return m.ComputationUsed
+	}
+	return 0
+}
+
+func (m *DryRunTransactionResponse) GetEvents() []*entities.Event {
+	if m != nil {
+		return m.Events
+	}
+	return nil
+}
+
+func (m *DryRunTransactionResponse) GetStorageDeltas() []*AccountStorageDelta {
+	if m != nil {
+		return m.StorageDeltas
+	}
+	return nil
+}
+
+func (m *DryRunTransactionResponse) GetFeeEstimate() uint64 {
+	if m != nil {
+		return m.FeeEstimate
+	}
+	return 0
+}
+
+func (m *DryRunTransactionResponse) GetErrorCode() uint32 {
+	if m != nil {
+		return m.ErrorCode
+	}
+	return 0
+}
+
+func (m *DryRunTransactionResponse) GetErrorMessage() string {
+	if m != nil {
+		return m.ErrorMessage
+	}
+	return ""
+}
+
+// ExecutionDryRunAPIServer is the server API for the ExecutionDryRunAPI service.
+type ExecutionDryRunAPIServer interface {
+	DryRunTransaction(context.Context, *DryRunTransactionRequest) (*DryRunTransactionResponse, error)
+}
+
+func dryRunTransactionHandler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DryRunTransactionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExecutionDryRunAPIServer).DryRunTransaction(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/flow.execution.ExecutionDryRunAPI/DryRunTransaction",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExecutionDryRunAPIServer).DryRunTransaction(ctx, req.(*DryRunTransactionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var executionDryRunAPIServiceDesc = grpc.ServiceDesc{
+	ServiceName: "flow.execution.ExecutionDryRunAPI",
+	HandlerType: (*ExecutionDryRunAPIServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "DryRunTransaction",
+			Handler:    dryRunTransactionHandler,
+		},
+	},
+	Streams: []grpc.StreamDesc{},
+}
+
+// RegisterExecutionDryRunAPIServer registers the dry run endpoint of the Execution API with the gRPC server.
+func RegisterExecutionDryRunAPIServer(s *grpc.Server, srv ExecutionDryRunAPIServer) {
+	s.RegisterService(&executionDryRunAPIServiceDesc, srv)
+}
+
+// ExecutionDryRunAPIClient is the client API for the ExecutionDryRunAPI service.
+type ExecutionDryRunAPIClient interface {
+	DryRunTransaction(ctx context.Context, in *DryRunTransactionRequest, opts ...grpc.CallOption) (*DryRunTransactionResponse, error)
+}
+
+type executionDryRunAPIClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewExecutionDryRunAPIClient(cc grpc.ClientConnInterface) ExecutionDryRunAPIClient {
+	return &executionDryRunAPIClient{cc}
+}
+
+func (c *executionDryRunAPIClient) DryRunTransaction(ctx context.Context, in *DryRunTransactionRequest, opts ...grpc.CallOption) (*DryRunTransactionResponse, error) {
+	out := new(DryRunTransactionResponse)
+	err := c.cc.Invoke(ctx, "/flow.execution.ExecutionDryRunAPI/DryRunTransaction", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
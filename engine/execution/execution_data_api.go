@@ -0,0 +1,115 @@
+package execution
+
+import (
+	"context"
+
+	"github.com/golang/protobuf/proto"
+	"google.golang.org/grpc"
+)
+
+// ExecutionDataAPI returns the IDs of the execution data the node published for a block. The ID of the execution data
+// is committed in the execution result of the block, which the IDs are checked against by the consumers.
+
+// GetExecutionDataByBlockIDRequest is the request message of ExecutionDataAPI.GetExecutionDataByBlockID.
+type GetExecutionDataByBlockIDRequest struct {
+	BlockId []byte `protobuf:"bytes,1,opt,name=block_id,json=blockId,proto3" json:"block_id,omitempty"`
+}
+
+func (m *GetExecutionDataByBlockIDRequest) Reset()         { *m = GetExecutionDataByBlockIDRequest{} }
+func (m *GetExecutionDataByBlockIDRequest) String() string { return proto.CompactTextString(m) }
+func (*GetExecutionDataByBlockIDRequest) ProtoMessage()    {}
+
+func (m *GetExecutionDataByBlockIDRequest) GetBlockId() []byte {
+	if m != nil {
+		return m.BlockId
+	}
+	return nil
+}
+
+// ExecutionDataResponse holds the ID of the execution data of a block, and the IDs of the execution data of its
+// chunks, in the order of the chunks of the execution result. The execution data of the chunks is requested by ID
+// on the request-execution-data channel.
+type ExecutionDataResponse struct {
+	ExecutionDataId       []byte   `protobuf:"bytes,1,opt,name=execution_data_id,json=executionDataId,proto3" json:"execution_data_id,omitempty"`
+	ChunkExecutionDataIds [][]byte `protobuf:"bytes,2,rep,name=chunk_execution_data_ids,json=chunkExecutionDataIds,proto3" json:"chunk_execution_data_ids,omitempty"`
+}
+
+func (m *ExecutionDataResponse) Reset()         { *m = ExecutionDataResponse{} }
+func (m *ExecutionDataResponse) String() string { return proto.CompactTextString(m) }
+func (*ExecutionDataResponse) ProtoMessage()    {}
+
+func (m *ExecutionDataResponse) GetExecutionDataId() []byte {
+	if m != nil {
+		return m.ExecutionDataId
+	}
+	return nil
+}
+
+func (m *ExecutionDataResponse) GetChunkExecutionDataIds() [][]byte {
+	if m != nil {
+		return m.ChunkExecutionDataIds
+	}
+	return nil
+}
+
+// ExecutionDataAPIServer is the server API for the ExecutionDataAPI service.
+type ExecutionDataAPIServer interface {
+	GetExecutionDataByBlockID(context.Context, *GetExecutionDataByBlockIDRequest) (*ExecutionDataResponse, error)
+}
+
+func getExecutionDataByBlockIDHandler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetExecutionDataByBlockIDRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExecutionDataAPIServer).GetExecutionDataByBlockID(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/flow.execution.ExecutionDataAPI/GetExecutionDataByBlockID",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExecutionDataAPIServer).GetExecutionDataByBlockID(ctx, req.(*GetExecutionDataByBlockIDRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var executionDataAPIServiceDesc = grpc.ServiceDesc{
+	ServiceName: "flow.execution.ExecutionDataAPI",
+	HandlerType: (*ExecutionDataAPIServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetExecutionDataByBlockID",
+			Handler:    getExecutionDataByBlockIDHandler,
+		},
+	},
+	Streams: []grpc.StreamDesc{},
+}
+
+// RegisterExecutionDataAPIServer registers the execution data endpoint of the Execution API with the gRPC server.
+func RegisterExecutionDataAPIServer(s *grpc.Server, srv ExecutionDataAPIServer) {
+	s.RegisterService(&executionDataAPIServiceDesc, srv)
+}
+
+// ExecutionDataAPIClient is the client API for the ExecutionDataAPI service.
+type ExecutionDataAPIClient interface {
+	GetExecutionDataByBlockID(ctx context.Context, in *GetExecutionDataByBlockIDRequest, opts ...grpc.CallOption) (*ExecutionDataResponse, error)
+}
+
+type executionDataAPIClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewExecutionDataAPIClient(cc grpc.ClientConnInterface) ExecutionDataAPIClient {
+	return &executionDataAPIClient{cc}
+}
+
+func (c *executionDataAPIClient) GetExecutionDataByBlockID(ctx context.Context, in *GetExecutionDataByBlockIDRequest, opts ...grpc.CallOption) (*ExecutionDataResponse, error) {
+	out := new(ExecutionDataResponse)
+	err := c.cc.Invoke(ctx, "/flow.execution.ExecutionDataAPI/GetExecutionDataByBlockID", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
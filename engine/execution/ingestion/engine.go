@@ -6,8 +6,10 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"sync"
 	"time"
 
+	"github.com/dgraph-io/badger/v2"
 	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
 
@@ -20,6 +22,8 @@ import (
 	"github.com/onflow/flow-go/engine/execution/state"
 	"github.com/onflow/flow-go/engine/execution/state/delta"
 	"github.com/onflow/flow-go/engine/execution/utils"
+	fvmerrors "github.com/onflow/flow-go/fvm/errors"
+	fvmstate "github.com/onflow/flow-go/fvm/state"
 	"github.com/onflow/flow-go/model/flow"
 	"github.com/onflow/flow-go/model/flow/filter"
 	"github.com/onflow/flow-go/module"
@@ -50,6 +54,8 @@ type Engine struct {
 	events             storage.Events
 	serviceEvents      storage.ServiceEvents
 	transactionResults storage.TransactionResults
+	transactionTraces  storage.TransactionTraces
+	executionDatas     storage.ExecutionDatas
 	computationManager computation.ComputationManager
 	providerEngine     provider.ProviderEngine
 	mempool            *Mempool
@@ -64,6 +70,12 @@ type Engine struct {
 	syncDeltas         mempool.Deltas      // storing the synced state deltas
 	syncFast           bool                // sync fast allows execution node to skip fetching collection during state syncing, and rely on state syncing to catch up
 	checkStakedAtBlock func(blockID flow.Identifier) (bool, error)
+	isHalted           func() bool // whether the execution is halted, blocks are not executed while halted
+	pipelined          bool        // whether children start executing before the state of their parent is persisted
+	commitExecData     bool        // whether execution results commit to the execution data of their block
+	inflightLock       sync.RWMutex
+	inflight           map[flow.Identifier]*inflightBlock // the executed blocks whose state is not persisted yet
+	stopControl        *stopControl                       // stops the execution at the height set by the operator
 }
 
 func New(
@@ -77,6 +89,9 @@ func New(
 	events storage.Events,
 	serviceEvents storage.ServiceEvents,
 	transactionResults storage.TransactionResults,
+	transactionTraces storage.TransactionTraces,
+	executionDatas storage.ExecutionDatas,
+	db *badger.DB,
 	executionEngine computation.ComputationManager,
 	providerEngine provider.ProviderEngine,
 	execState state.ExecutionState,
@@ -88,6 +103,9 @@ func New(
 	syncThreshold int,
 	syncFast bool,
 	checkStakedAtBlock func(blockID flow.Identifier) (bool, error),
+	isHalted func() bool,
+	pipelined bool,
+	commitExecData bool,
 ) (*Engine, error) {
 	log := logger.With().Str("engine", "ingestion").Logger()
 
@@ -106,6 +124,8 @@ func New(
 		events:             events,
 		serviceEvents:      serviceEvents,
 		transactionResults: transactionResults,
+		transactionTraces:  transactionTraces,
+		executionDatas:     executionDatas,
 		computationManager: executionEngine,
 		providerEngine:     providerEngine,
 		mempool:            mempool,
@@ -118,6 +138,11 @@ func New(
 		syncDeltas:         syncDeltas,
 		syncFast:           syncFast,
 		checkStakedAtBlock: checkStakedAtBlock,
+		isHalted:           isHalted,
+		pipelined:          pipelined,
+		commitExecData:     commitExecData,
+		inflight:           make(map[flow.Identifier]*inflightBlock),
+		stopControl:        newStopControl(log, metrics, db, state, execState),
 	}
 
 	// move to state syncing engine
@@ -134,7 +159,17 @@ func New(
 // Ready returns a channel that will close when the engine has
 // successfully started.
 func (e *Engine) Ready() <-chan struct{} {
-	err := e.reloadUnexecutedBlocks()
+	// the stop height can only be set above the blocks executed before the restart
+	highestExecuted, _, err := e.execState.GetHighestExecutedBlockID(e.unit.Ctx())
+	if err != nil {
+		e.log.Fatal().Err(err).Msg("failed to get highest executed block")
+	}
+	err = e.stopControl.load(highestExecuted)
+	if err != nil {
+		e.log.Fatal().Err(err).Msg("failed to load stop height")
+	}
+
+	err = e.reloadUnexecutedBlocks()
 	if err != nil {
 		e.log.Fatal().Err(err).Msg("failed to load all unexecuted blocks")
 	}
@@ -278,6 +313,19 @@ func (e *Engine) unexecutedBlocks() (finalized []flow.Identifier, pending []flow
 	return finalized, pending, nil
 }
 
+// isBootstrappedBlock returns whether the given executed block was bootstrapped instead of executed, which is the
+// case if its parent has not been executed.
+func (e *Engine) isBootstrappedBlock(header *flow.Header) (bool, error) {
+	_, err := e.execState.StateCommitmentByBlockID(e.unit.Ctx(), header.ParentID)
+	if errors.Is(err, storage.ErrNotFound) {
+		return true, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("could not get state commitment of parent block %v: %w", header.ParentID, err)
+	}
+	return false, nil
+}
+
 // on nodes startup, we need to load all the unexecuted blocks to the execution queues.
 // blocks have to be loaded in the way that the parent has been loaded before loading its children
 func (e *Engine) reloadUnexecutedBlocks() error {
@@ -317,8 +365,14 @@ func (e *Engine) reloadUnexecutedBlocks() error {
 			return fmt.Errorf("failed to retrieve root block: %w", err)
 		}
 
+		// a block bootstrapped from the execution state of other execution nodes is not re-executed either,
+		// because its parent has not been executed by this node.
 		isRoot := rootBlock.ID() == last.ID()
-		if !isRoot {
+		isBootstrapped, err := e.isBootstrappedBlock(last)
+		if err != nil {
+			return fmt.Errorf("could not check whether last executed block was bootstrapped: %w", err)
+		}
+		if !isRoot && !isBootstrapped {
 			err = e.reloadBlock(blockByCollection, executionQueues, lastExecutedID)
 			if err != nil {
 				return fmt.Errorf("could not reload the last executed final block: %v, %w", lastExecutedID, err)
@@ -375,6 +429,14 @@ func (e *Engine) reloadBlock(
 	return nil
 }
 
+// BlockFinalized stops the execution if the finalized block is the executed block at the stop height.
+func (e *Engine) BlockFinalized(h *flow.Header) {
+	err := e.stopControl.blockFinalized(h)
+	if err != nil {
+		e.log.Fatal().Err(err).Hex("block_id", logging.Entity(h)).Msg("could not check stop height of finalized block")
+	}
+}
+
 // BlockProcessable handles the new verified blocks (blocks that
 // have passed consensus validation) received from the consensus nodes
 // Note: BlockProcessable might be called multiple times for the same block.
@@ -517,7 +579,9 @@ func (e *Engine) enqueueBlockAndCheckExecutable(
 
 // executeBlock will execute the block.
 // When finish executing, it will check if the children becomes executable and execute them if yes.
-func (e *Engine) executeBlock(ctx context.Context, executableBlock *entity.ExecutableBlock) {
+// With pipelined execution, the children are checked once the transactions of the block are executed instead, and
+// a block whose parent is still in flight is executed on the view of its parent.
+func (e *Engine) executeBlock(ctx context.Context, executableBlock *entity.ExecutableBlock, parent *inflightBlock) {
 
 	e.log.Info().
 		Hex("block_id", logging.Entity(executableBlock)).
@@ -528,7 +592,23 @@ func (e *Engine) executeBlock(ctx context.Context, executableBlock *entity.Execu
 	span, ctx := e.tracer.StartSpanFromContext(ctx, trace.EXEExecuteBlock)
 	defer span.Finish()
 
-	view := e.execState.NewView(executableBlock.StartState)
+	startState := executableBlock.StartState
+	var view *delta.View
+	if parent != nil {
+		view = delta.NewView(parent.read)
+		executableBlock.AwaitStartState = parent.awaitCommitted
+	} else {
+		view = e.execState.NewView(startState)
+	}
+
+	var inflight *inflightBlock
+	if e.pipelined {
+		inflight = newInflightBlock(view)
+		executableBlock.Executed = func() {
+			e.onBlockComputed(executableBlock, inflight)
+		}
+		defer e.removeInflight(executableBlock.ID(), inflight)
+	}
 
 	computationResult, err := e.computationManager.ComputeBlock(ctx, executableBlock, view)
 	if err != nil {
@@ -542,7 +622,37 @@ func (e *Engine) executeBlock(ctx context.Context, executableBlock *entity.Execu
 	e.metrics.ExecutionGasUsedPerBlock(computationResult.GasUsed)
 	e.metrics.ExecutionStateReadsPerBlock(computationResult.StateReads)
 
-	finalState, receipt, err := e.handleComputationResult(ctx, computationResult, executableBlock.StartState)
+	if parent != nil {
+		// the state of the parent is committed already, as the state of the block was committed on top of it
+		startState, err = parent.awaitCommitted()
+		if err != nil {
+			e.log.Err(err).
+				Hex("block_id", logging.Entity(executableBlock)).
+				Msg("error while computing block")
+			return
+		}
+	}
+
+	if inflight != nil {
+		committed := startState
+		if len(computationResult.StateCommitments) > 0 {
+			committed = computationResult.StateCommitments[len(computationResult.StateCommitments)-1]
+		}
+		inflight.commit(committed)
+	}
+
+	// the execution of the parent is persisted first, so that the executed blocks are reloaded in order on restart
+	if parent != nil {
+		err = parent.awaitPersisted()
+		if err != nil {
+			e.log.Err(err).
+				Hex("block_id", logging.Entity(executableBlock)).
+				Msg("error while handing computation results")
+			return
+		}
+	}
+
+	finalState, receipt, err := e.handleComputationResult(ctx, computationResult, startState)
 	if errors.Is(err, storage.ErrDataMismatch) {
 		e.log.Fatal().Err(err).Msg("fatal: trying to store different results for the same block")
 	}
@@ -554,6 +664,10 @@ func (e *Engine) executeBlock(ctx context.Context, executableBlock *entity.Execu
 		return
 	}
 
+	if inflight != nil {
+		inflight.persist()
+	}
+
 	// if the receipt is for a sealed block, then no need to broadcast it.
 	lastSealed, err := e.state.Sealed().Head()
 	if err != nil {
@@ -583,7 +697,7 @@ func (e *Engine) executeBlock(ctx context.Context, executableBlock *entity.Execu
 		Hex("parent_block", executableBlock.Block.Header.ParentID[:]).
 		Uint64("block_height", executableBlock.Block.Header.Height).
 		Int("collections", len(executableBlock.Block.Payload.Guarantees)).
-		Hex("start_state", executableBlock.StartState).
+		Hex("start_state", startState).
 		Hex("final_state", finalState).
 		Hex("receipt_id", logging.Entity(receipt)).
 		Hex("result_id", logging.Entity(receipt.ExecutionResult)).
@@ -621,6 +735,21 @@ func (e *Engine) onBlockExecuted(executed *entity.ExecutableBlock, finalState fl
 
 	// e.checkStateSyncStop(executed.Block.Header.Height)
 
+	e.stopControl.blockExecuted(executed.Block.Header)
+
+	// with pipelined execution, the children were checked once the transactions of the block were executed
+	if e.pipelined {
+		return nil
+	}
+
+	e.executeChildren(executed, finalState)
+
+	return nil
+}
+
+// executeChildren removes the executed block from the execution queues, and executes its children that are ready.
+// The given final state of the block becomes the start state of its children, it is nil if not known yet.
+func (e *Engine) executeChildren(executed *entity.ExecutableBlock, finalState flow.StateCommitment) {
 	err := e.mempool.Run(
 		func(
 			blockByCollection *stdmap.BlockByCollectionBackdata,
@@ -684,16 +813,71 @@ func (e *Engine) onBlockExecuted(executed *entity.ExecutableBlock, finalState fl
 			Hex("block", logging.Entity(executed)).
 			Msg("error while requeueing blocks after execution")
 	}
+}
 
-	return nil
+// onBlockComputed is called with pipelined execution, once the transactions of the block are executed. The block is
+// in flight until its state is persisted, and its children start executing on its view meanwhile.
+func (e *Engine) onBlockComputed(computed *entity.ExecutableBlock, inflight *inflightBlock) {
+	e.inflightLock.Lock()
+	e.inflight[computed.ID()] = inflight
+	e.inflightLock.Unlock()
+
+	e.executeChildren(computed, nil)
+}
+
+// removeInflight fails the in-flight block, unless its state was persisted, and stops tracking it. A block is only
+// removed once its state is persisted or its execution failed, so a child always finds either its parent in flight,
+// or the state commitment of its parent.
+func (e *Engine) removeInflight(blockID flow.Identifier, inflight *inflightBlock) {
+	inflight.fail(fmt.Errorf("could not execute block %v", blockID))
+
+	e.inflightLock.Lock()
+	delete(e.inflight, blockID)
+	e.inflightLock.Unlock()
+}
+
+// executedParent returns whether the parent of a block without start state is executed, for pipelined execution.
+// If the parent is in flight, it is returned, otherwise its state is persisted already and set as the start state
+// of the block.
+func (e *Engine) executedParent(eb *entity.ExecutableBlock) (*inflightBlock, bool) {
+	parentID := eb.Block.Header.ParentID
+
+	// in-flight blocks are removed once their state is persisted, so they are checked first
+	e.inflightLock.RLock()
+	parent, ok := e.inflight[parentID]
+	e.inflightLock.RUnlock()
+	if ok {
+		return parent, true
+	}
+
+	parentCommitment, err := e.execState.StateCommitmentByBlockID(e.unit.Ctx(), parentID)
+	if errors.Is(err, storage.ErrNotFound) {
+		return nil, false
+	}
+	if err != nil {
+		e.log.Fatal().Err(err).Msg("unexpected error while accessing storage, shutting down")
+	}
+
+	eb.StartState = parentCommitment
+	return nil, true
 }
 
 // executeBlockIfComplete checks whether the block is ready to be executed.
 // if yes, execute the block
 // return a bool indicates whether the block was completed
 func (e *Engine) executeBlockIfComplete(eb *entity.ExecutableBlock) bool {
+	// with pipelined execution, a complete block can be executed once its parent is executed, even if the state of
+	// its parent is not persisted yet
+	var parent *inflightBlock
 	if !eb.HasStartState() {
-		return false
+		if !e.pipelined || !eb.HasAllTransactions() {
+			return false
+		}
+		var executed bool
+		parent, executed = e.executedParent(eb)
+		if !executed {
+			return false
+		}
 	}
 
 	// if the eb has parent statecommitment, and we have the delta for this block
@@ -719,15 +903,32 @@ func (e *Engine) executeBlockIfComplete(eb *entity.ExecutableBlock) bool {
 	// }
 
 	// if don't have the delta, then check if everything is ready for executing
-	// the block
-	if eb.IsComplete() {
+	// the block, a block executed on the view of its parent has no start state yet
+	if eb.IsComplete() || parent != nil {
+
+		// the block stays in the execution queues, and is reloaded once the halt is removed and the node restarted
+		if e.isHalted() {
+			e.log.Warn().
+				Hex("block_id", logging.Entity(eb)).
+				Msg("execution is halted, not executing block")
+			return false
+		}
+
+		// the blocks above the stop height stay in the execution queues as well
+		if !e.stopControl.startExecuting(eb.Block.Header) {
+			e.log.Debug().
+				Hex("block_id", logging.Entity(eb)).
+				Uint64("height", eb.Block.Header.Height).
+				Msg("block is above the stop height, not executing block")
+			return false
+		}
 
 		if e.extensiveLogging {
 			e.logExecutableBlock(eb)
 		}
 
 		e.unit.Launch(func() {
-			e.executeBlock(e.unit.Ctx(), eb)
+			e.executeBlock(e.unit.Ctx(), eb, parent)
 		})
 		return true
 	}
@@ -958,6 +1159,20 @@ func (e *Engine) matchOrRequestCollections(
 	return nil
 }
 
+// SetStopHeight sets the height to stop the execution after, or removes it if the height is 0. The blocks up to and
+// including the stop height are executed, and once the finalized block at the stop height is executed, the node
+// crashes if requested, otherwise it stays running without executing any further blocks. The stop height is persisted
+// until it is reached.
+func (e *Engine) SetStopHeight(height uint64, crash bool) error {
+	return e.stopControl.setHeight(height, crash)
+}
+
+// StopHeight returns the height to stop the execution after, 0 if not set, whether the node crashes once stopped,
+// and whether the execution is stopped.
+func (e *Engine) StopHeight() (uint64, bool, bool) {
+	return e.stopControl.get()
+}
+
 func (e *Engine) ExecuteScriptAtBlockID(ctx context.Context, script []byte, arguments [][]byte, blockID flow.Identifier) ([]byte, error) {
 
 	stateCommit, err := e.execState.StateCommitmentByBlockID(ctx, blockID)
@@ -988,6 +1203,38 @@ func (e *Engine) ExecuteScriptAtBlockID(ctx context.Context, script []byte, argu
 	return e.computationManager.ExecuteScript(script, arguments, block, blockView)
 }
 
+func (e *Engine) DryRunTransaction(
+	ctx context.Context,
+	tx *flow.TransactionBody,
+	skipSignatureCheck bool,
+	blockID flow.Identifier,
+) (*execution.DryRunResult, error) {
+
+	stateCommit, err := e.execState.StateCommitmentByBlockID(ctx, blockID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get state commitment for block (%s): %w", blockID, err)
+	}
+
+	block, err := e.state.AtBlockID(blockID).Head()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get block (%s): %w", blockID, err)
+	}
+
+	blockView := e.execState.NewView(stateCommit)
+
+	if e.extensiveLogging {
+		e.log.Debug().
+			Hex("block_id", logging.ID(blockID)).
+			Uint64("block_height", block.Height).
+			Hex("state_commitment", stateCommit).
+			Hex("tx_id", logging.Entity(tx)).
+			Bool("skip_signature_check", skipSignatureCheck).
+			Msg("extensive log: dry run transaction")
+	}
+
+	return e.computationManager.DryRunTransaction(tx, skipSignatureCheck, block, blockView)
+}
+
 func (e *Engine) GetAccount(ctx context.Context, addr flow.Address, blockID flow.Identifier) (*flow.Account, error) {
 	stateCommit, err := e.execState.StateCommitmentByBlockID(ctx, blockID)
 	if err != nil {
@@ -1004,6 +1251,165 @@ func (e *Engine) GetAccount(ctx context.Context, addr flow.Address, blockID flow
 	return e.computationManager.GetAccount(addr, block, blockView)
 }
 
+func (e *Engine) ExecuteScriptsAtBlockID(ctx context.Context, scripts [][]byte, arguments [][][]byte, blockID flow.Identifier) ([][]byte, []error, error) {
+	if len(arguments) != len(scripts) {
+		return nil, nil, fmt.Errorf("got arguments for %d scripts, expected %d", len(arguments), len(scripts))
+	}
+
+	block, blockView, err := e.viewAtBlockID(ctx, blockID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	values := make([][]byte, len(scripts))
+	errs := make([]error, len(scripts))
+	for i, script := range scripts {
+		// each script runs in a child view, so writes of one script are never seen by the others
+		values[i], errs[i] = e.computationManager.ExecuteScript(script, arguments[i], block, blockView.NewChild())
+	}
+
+	e.log.Debug().
+		Hex("block_id", logging.ID(blockID)).
+		Int("scripts", len(scripts)).
+		Msg("executed batch of scripts")
+
+	return values, errs, nil
+}
+
+func (e *Engine) GetAccounts(ctx context.Context, addrs []flow.Address, blockID flow.Identifier) ([]*flow.Account, []error, error) {
+	block, blockView, err := e.viewAtBlockID(ctx, blockID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	accounts := make([]*flow.Account, len(addrs))
+	errs := make([]error, len(addrs))
+	for i, addr := range addrs {
+		accounts[i], errs[i] = e.computationManager.GetAccount(addr, block, blockView.NewChild())
+	}
+
+	return accounts, errs, nil
+}
+
+func (e *Engine) GetRegisterProofAtBlockID(ctx context.Context, registerIDs []flow.RegisterID, blockID flow.Identifier) (flow.StorageProof, error) {
+	stateCommit, err := e.execState.StateCommitmentByBlockID(ctx, blockID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get state commitment for block (%s): %w", blockID, err)
+	}
+
+	proof, err := e.execState.GetProof(ctx, stateCommit, registerIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get register proof for block (%s): %w", blockID, err)
+	}
+
+	return proof, nil
+}
+
+func (e *Engine) GetAccountKeyAtIndex(ctx context.Context, addr flow.Address, keyIndex uint64, blockID flow.Identifier) (*flow.AccountPublicKey, error) {
+	accounts, err := e.accountsAtBlockID(ctx, addr, blockID)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := accounts.GetPublicKey(addr, keyIndex)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get account key: %w", err)
+	}
+
+	return &key, nil
+}
+
+func (e *Engine) ListAccountKeys(ctx context.Context, addr flow.Address, offset uint64, limit uint64, blockID flow.Identifier) ([]flow.AccountPublicKey, uint64, error) {
+	accounts, err := e.accountsAtBlockID(ctx, addr, blockID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	count, err := accounts.GetPublicKeyCount(addr)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get account key count: %w", err)
+	}
+
+	end := offset + limit
+	if end > count || end < offset {
+		end = count
+	}
+
+	keys := make([]flow.AccountPublicKey, 0)
+	for index := offset; index < end; index++ {
+		key, err := accounts.GetPublicKey(addr, index)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to get account key: %w", err)
+		}
+		keys = append(keys, key)
+	}
+
+	return keys, count, nil
+}
+
+func (e *Engine) GetAccountContractNames(ctx context.Context, addr flow.Address, blockID flow.Identifier) ([]string, error) {
+	accounts, err := e.accountsAtBlockID(ctx, addr, blockID)
+	if err != nil {
+		return nil, err
+	}
+
+	return accounts.GetContractNames(addr)
+}
+
+func (e *Engine) GetAccountContract(ctx context.Context, addr flow.Address, name string, blockID flow.Identifier) ([]byte, error) {
+	accounts, err := e.accountsAtBlockID(ctx, addr, blockID)
+	if err != nil {
+		return nil, err
+	}
+
+	return accounts.GetContract(name, addr)
+}
+
+func (e *Engine) GetAccountStorageUsed(ctx context.Context, addr flow.Address, blockID flow.Identifier) (uint64, error) {
+	accounts, err := e.accountsAtBlockID(ctx, addr, blockID)
+	if err != nil {
+		return 0, err
+	}
+
+	return accounts.GetStorageUsed(addr)
+}
+
+// accountsAtBlockID returns the accounts of the execution state at the given block. It fails with an
+// AccountNotFoundError if the given account does not exist at that block.
+func (e *Engine) accountsAtBlockID(ctx context.Context, addr flow.Address, blockID flow.Identifier) (*fvmstate.Accounts, error) {
+	_, blockView, err := e.viewAtBlockID(ctx, blockID)
+	if err != nil {
+		return nil, err
+	}
+
+	accounts := fvmstate.NewAccounts(fvmstate.NewStateHolder(fvmstate.NewState(blockView)))
+
+	exists, err := accounts.Exists(addr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check if account exists: %w", err)
+	}
+	if !exists {
+		return nil, fvmerrors.NewAccountNotFoundError(addr)
+	}
+
+	return accounts, nil
+}
+
+// viewAtBlockID returns the header of the given block and a read-only view of the execution state at that block.
+func (e *Engine) viewAtBlockID(ctx context.Context, blockID flow.Identifier) (*flow.Header, *delta.View, error) {
+	stateCommit, err := e.execState.StateCommitmentByBlockID(ctx, blockID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get state commitment for block (%s): %w", blockID, err)
+	}
+
+	block, err := e.state.AtBlockID(blockID).Head()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get block (%s): %w", blockID, err)
+	}
+
+	return block, e.execState.NewView(stateCommit), nil
+}
+
 func (e *Engine) handleComputationResult(
 	ctx context.Context,
 	result *execution.ComputationResult,
@@ -1061,17 +1467,20 @@ func (e *Engine) saveExecutionResults(
 
 		endState = result.StateCommitments[i]
 		var collectionID flow.Identifier
+		var txCount uint64
 
-		// account for system chunk being last
+		// account for system chunk being last, which only executes the system transaction
 		if i < len(result.StateCommitments)-1 {
 			collectionGuarantee := result.ExecutableBlock.Block.Payload.Guarantees[i]
 			completeCollection := result.ExecutableBlock.CompleteCollections[collectionGuarantee.ID()]
 			collectionID = completeCollection.Collection().ID()
+			txCount = uint64(len(completeCollection.Transactions))
 		} else {
 			collectionID = flow.ZeroID
+			txCount = 1
 		}
 
-		chunk := generateChunk(i, startState, endState, collectionID, blockID)
+		chunk := generateChunk(i, startState, endState, collectionID, blockID, txCount)
 
 		// chunkDataPack
 		chdps[i] = generateChunkDataPack(chunk, collectionID, result.Proofs[i])
@@ -1080,7 +1489,16 @@ func (e *Engine) saveExecutionResults(
 		startState = endState
 	}
 
-	executionResult, err := e.generateExecutionResultForBlock(childCtx, result.ExecutableBlock.Block, chunks, endState, result.ServiceEvents)
+	// the execution result commits to the ID of the execution data, so that consumers can authenticate it. Nodes not
+	// knowing the execution data ID compute other IDs for results committing to it, so it is only committed to once
+	// enabled, after a spork.
+	executionData, chunkExecutionDatas := generateExecutionData(result)
+	executionDataID := flow.ZeroID
+	if e.commitExecData {
+		executionDataID = executionData.ID()
+	}
+
+	executionResult, err := e.generateExecutionResultForBlock(childCtx, result.ExecutableBlock.Block, chunks, endState, result.ServiceEvents, executionDataID)
 	if err != nil {
 		return nil, fmt.Errorf("could not generate execution result: %w", err)
 	}
@@ -1090,11 +1508,26 @@ func (e *Engine) saveExecutionResults(
 		return nil, fmt.Errorf("could not generate execution receipt: %w", err)
 	}
 
+	// the execution data is stored before the block is marked as executed, so it is served once the receipt committing
+	// to it is broadcast, and stored again when the block is executed again after a crash
+	err = e.executionDatas.Store(executionData, chunkExecutionDatas)
+	if err != nil {
+		return nil, fmt.Errorf("cannot store execution data: %w", err)
+	}
+
 	err = e.execState.PersistExecutionState(childCtx, result.ExecutableBlock.Block.Header, endState, chdps, executionReceipt, result.Events, result.ServiceEvents, result.TransactionResults)
 	if err != nil {
 		return nil, fmt.Errorf("cannot persist execution state: %w", err)
 	}
 
+	// transaction traces are only recorded if enabled
+	if len(result.TransactionTraces) > 0 {
+		err = e.transactionTraces.Store(blockID, result.TransactionTraces)
+		if err != nil {
+			return nil, fmt.Errorf("cannot persist transaction traces: %w", err)
+		}
+	}
+
 	e.log.Debug().
 		Hex("block_id", logging.Entity(result.ExecutableBlock)).
 		Hex("start_state", originalState).
@@ -1137,7 +1570,8 @@ func (e *Engine) logExecutableBlock(eb *entity.ExecutableBlock) {
 // generateChunk creates a chunk from the provided computation data.
 func generateChunk(colIndex int,
 	startState, endState flow.StateCommitment,
-	colID, blockID flow.Identifier) *flow.Chunk {
+	colID, blockID flow.Identifier,
+	txCount uint64) *flow.Chunk {
 	return &flow.Chunk{
 		ChunkBody: flow.ChunkBody{
 			CollectionIndex: uint(colIndex),
@@ -1149,8 +1583,7 @@ func generateChunk(colIndex int,
 			BlockID:         blockID,
 			// TODO: record gas used
 			TotalComputationUsed: 0,
-			// TODO: record number of txs
-			NumberOfTransactions: 0,
+			NumberOfTransactions: txCount,
 		},
 		Index:    uint64(colIndex),
 		EndState: endState,
@@ -1158,13 +1591,14 @@ func generateChunk(colIndex int,
 }
 
 // generateExecutionResultForBlock creates new ExecutionResult for a block from
-// the provided chunk results.
+// the provided chunk results, committing to the ID of its execution data.
 func (e *Engine) generateExecutionResultForBlock(
 	ctx context.Context,
 	block *flow.Block,
 	chunks []*flow.Chunk,
 	endState flow.StateCommitment,
 	serviceEvents []flow.Event,
+	executionDataID flow.Identifier,
 ) (*flow.ExecutionResult, error) {
 
 	previousErID, err := e.execState.GetExecutionResultID(ctx, block.Header.ParentID)
@@ -1188,6 +1622,7 @@ func (e *Engine) generateExecutionResultForBlock(
 		BlockID:          block.ID(),
 		Chunks:           chunks,
 		ServiceEvents:    convertedServiceEvents,
+		ExecutionDataID:  executionDataID,
 	}
 
 	return er, nil
@@ -4,7 +4,9 @@ import (
 	"bytes"
 	"context"
 	"crypto/rand"
+	"errors"
 	mathRand "math/rand"
+	"os"
 	"sync"
 	"testing"
 	"time"
@@ -32,6 +34,7 @@ import (
 	stateProtocol "github.com/onflow/flow-go/state/protocol"
 	protocol "github.com/onflow/flow-go/state/protocol/mock"
 	storageerr "github.com/onflow/flow-go/storage"
+	storagemock "github.com/onflow/flow-go/storage/mock"
 	storage "github.com/onflow/flow-go/storage/mocks"
 	"github.com/onflow/flow-go/utils/unittest"
 	"github.com/onflow/flow-go/utils/unittest/mocks"
@@ -69,6 +72,16 @@ type testingContext struct {
 }
 
 func runWithEngine(t *testing.T, f func(testingContext)) {
+	runWithEngineAndPipelining(t, false, f)
+}
+
+// runWithPipelinedEngine runs the test with an engine that executes the children of a block before its state is
+// persisted.
+func runWithPipelinedEngine(t *testing.T, f func(testingContext)) {
+	runWithEngineAndPipelining(t, true, f)
+}
+
+func runWithEngineAndPipelining(t *testing.T, pipelined bool, f func(testingContext)) {
 
 	ctrl := gomock.NewController(t)
 
@@ -103,10 +116,14 @@ func runWithEngine(t *testing.T, f func(testingContext)) {
 	executionState := new(state.ExecutionState)
 	snapshot := new(protocol.Snapshot)
 
+	db, dbDir := unittest.TempBadgerDB(t)
+
 	var engine *Engine
 
 	defer func() {
 		<-engine.Done()
+		db.Close()
+		os.RemoveAll(dbDir)
 		ctrl.Finish()
 		computationManager.AssertExpectations(t)
 		protocolState.AssertExpectations(t)
@@ -148,6 +165,9 @@ func runWithEngine(t *testing.T, f func(testingContext)) {
 		return stateProtocol.IsNodeStakedAt(protocolState.AtBlockID(blockID), myIdentity.NodeID)
 	}
 
+	executionDatas := new(storagemock.ExecutionDatas)
+	executionDatas.On("Store", mock.Anything, mock.Anything).Return(nil)
+
 	engine, err = New(
 		log,
 		net,
@@ -159,6 +179,9 @@ func runWithEngine(t *testing.T, f func(testingContext)) {
 		events,
 		serviceEvents,
 		txResults,
+		new(storagemock.TransactionTraces),
+		executionDatas,
+		db,
 		computationManager,
 		providerEngine,
 		executionState,
@@ -170,6 +193,9 @@ func runWithEngine(t *testing.T, f func(testingContext)) {
 		10,
 		false,
 		checkStakedAtBlock,
+		func() bool { return false },
+		pipelined,
+		true,
 	)
 	require.NoError(t, err)
 
@@ -196,6 +222,7 @@ func runWithEngine(t *testing.T, f func(testingContext)) {
 func (ctx *testingContext) assertSuccessfulBlockComputation(commits map[flow.Identifier]flow.StateCommitment, onPersisted func(blockID flow.Identifier, commit flow.StateCommitment), executableBlock *entity.ExecutableBlock, previousExecutionResultID flow.Identifier, expectBroadcast bool) {
 	computationResult := executionUnittest.ComputationResultForBlockFixture(executableBlock)
 	newStateCommitment := executableBlock.StartState
+	executionData, _ := generateExecutionData(computationResult)
 
 	ctx.computationManager.
 		On("ComputeBlock", mock.Anything, executableBlock, mock.Anything).Run(func(args mock.Arguments) {
@@ -223,7 +250,8 @@ func (ctx *testingContext) assertSuccessfulBlockComputation(commits map[flow.Ide
 			}),
 			mock.MatchedBy(func(executionReceipt *flow.ExecutionReceipt) bool {
 				return executionReceipt.ExecutionResult.BlockID == executableBlock.Block.ID() &&
-					executionReceipt.ExecutionResult.PreviousResultID == previousExecutionResultID
+					executionReceipt.ExecutionResult.PreviousResultID == previousExecutionResultID &&
+					executionReceipt.ExecutionResult.ExecutionDataID == executionData.ID()
 			}),
 			mock.Anything,
 			mock.Anything,
@@ -295,6 +323,68 @@ func (ctx *testingContext) assertSuccessfulBlockComputation(commits map[flow.Ide
 
 }
 
+// mockPipelinedComputation mocks the computation of a block with pipelined execution, in which the computation
+// manager calls back once the transactions of the block are executed, so that its children start executing.
+func (ctx *testingContext) mockPipelinedComputation(onComputed func(computed *entity.ExecutableBlock), executableBlock *entity.ExecutableBlock) {
+	blockID := executableBlock.ID()
+	computationResult := executionUnittest.ComputationResultForBlockFixture(executableBlock)
+
+	ctx.computationManager.
+		On("ComputeBlock", mock.Anything, mock.MatchedBy(func(eb *entity.ExecutableBlock) bool {
+			return eb.ID() == blockID
+		}), mock.Anything).
+		Run(func(args mock.Arguments) {
+			computed := args[1].(*entity.ExecutableBlock)
+			onComputed(computed)
+			if computed.Executed != nil {
+				computed.Executed()
+			}
+		}).
+		Return(computationResult, nil).Once()
+}
+
+// mockPipelinedPersistence mocks persisting the state of an empty block, whose final state is its start state. The
+// state is persisted once onPersist returns, unless it returns an error.
+func (ctx *testingContext) mockPipelinedPersistence(
+	commits map[flow.Identifier]flow.StateCommitment,
+	onPersist func(persistCtx context.Context, blockID flow.Identifier) error,
+	executableBlock *entity.ExecutableBlock,
+	startState flow.StateCommitment,
+	previousExecutionResultID flow.Identifier,
+) {
+	blockID := executableBlock.ID()
+
+	ctx.executionState.
+		On("GetExecutionResultID", mock.Anything, executableBlock.Block.Header.ParentID).
+		Return(previousExecutionResultID, nil)
+
+	mocked := ctx.executionState.
+		On("PersistExecutionState",
+			mock.Anything,
+			executableBlock.Block.Header,
+			startState,
+			mock.Anything,
+			mock.MatchedBy(func(executionReceipt *flow.ExecutionReceipt) bool {
+				return executionReceipt.ExecutionResult.BlockID == blockID &&
+					executionReceipt.ExecutionResult.PreviousResultID == previousExecutionResultID
+			}),
+			mock.Anything,
+			mock.Anything,
+			mock.Anything,
+		).
+		Once()
+
+	mocked.RunFn = func(args mock.Arguments) {
+		err := onPersist(args[0].(context.Context), blockID)
+		if err != nil {
+			mocked.ReturnArguments = mock.Arguments{err}
+			return
+		}
+		commits[blockID] = args[2].(flow.StateCommitment)
+		mocked.ReturnArguments = mock.Arguments{nil}
+	}
+}
+
 func (ctx testingContext) mockStakedAtBlockID(blockID flow.Identifier, staked bool) {
 	identity := *ctx.identity
 	identity.Stake = 0
@@ -336,10 +426,11 @@ func (ctx *testingContext) mockStateCommitsWithMap(commits map[flow.Identifier]f
 func TestChunkIndexIsSet(t *testing.T) {
 
 	i := mathRand.Int()
-	chunk := generateChunk(i, unittest.StateCommitmentFixture(), unittest.StateCommitmentFixture(), unittest.IdentifierFixture(), unittest.IdentifierFixture())
+	chunk := generateChunk(i, unittest.StateCommitmentFixture(), unittest.StateCommitmentFixture(), unittest.IdentifierFixture(), unittest.IdentifierFixture(), 3)
 
 	assert.Equal(t, i, int(chunk.Index))
 	assert.Equal(t, i, int(chunk.CollectionIndex))
+	assert.Equal(t, uint64(3), chunk.NumberOfTransactions)
 }
 
 func TestExecuteOneBlock(t *testing.T) {
@@ -569,6 +660,196 @@ func TestExecuteBlockInOrder(t *testing.T) {
 	})
 }
 
+func TestExecutePipelinedChain(t *testing.T) {
+	runWithPipelinedEngine(t, func(ctx testingContext) {
+		// A <- B
+		blockSealed := unittest.BlockHeaderFixture()
+		blockA := unittest.ExecutableBlockFixtureWithParent(nil, &blockSealed)
+		blockB := unittest.ExecutableBlockFixtureWithParent(nil, blockA.Block.Header)
+		startState := unittest.StateCommitmentFixture()
+
+		logBlocks(map[string]*entity.ExecutableBlock{
+			"A": blockA,
+			"B": blockB,
+		})
+
+		commits := make(map[flow.Identifier]flow.StateCommitment)
+		commits[blockA.Block.Header.ParentID] = startState
+		ctx.mockStateCommitsWithMap(commits)
+
+		ctx.state.On("Sealed").Return(ctx.snapshot)
+		ctx.snapshot.On("Head").Return(&blockSealed, nil)
+		ctx.mockStakedAtBlockID(blockA.ID(), false)
+		ctx.mockStakedAtBlockID(blockB.ID(), false)
+
+		// only block A is executed on a view of the persisted execution state, block B is executed on the view of
+		// block A
+		ctx.executionState.On("NewView", startState).Return(new(delta.View)).Once()
+
+		bComputed := make(chan struct{})
+		ctx.mockPipelinedComputation(func(computed *entity.ExecutableBlock) {}, blockA)
+		ctx.mockPipelinedComputation(func(computed *entity.ExecutableBlock) {
+			assert.NotNil(t, computed.AwaitStartState, "block B is not executed on the view of block A")
+			close(bComputed)
+		}, blockB)
+
+		var lock sync.Mutex
+		var persisted []flow.Identifier
+		wg := sync.WaitGroup{}
+		onPersist := func(_ context.Context, blockID flow.Identifier) error {
+			lock.Lock()
+			defer lock.Unlock()
+			persisted = append(persisted, blockID)
+			wg.Done()
+			return nil
+		}
+
+		// block B is executed before the state of block A is persisted
+		ctx.mockPipelinedPersistence(commits, func(persistCtx context.Context, blockID flow.Identifier) error {
+			<-bComputed
+			return onPersist(persistCtx, blockID)
+		}, blockA, startState, unittest.IdentifierFixture())
+		blockAExecutionResultID := unittest.IdentifierFixture()
+		ctx.mockPipelinedPersistence(commits, onPersist, blockB, startState, blockAExecutionResultID)
+
+		wg.Add(2)
+		err := ctx.engine.handleBlock(context.Background(), blockA.Block)
+		require.NoError(t, err)
+		err = ctx.engine.handleBlock(context.Background(), blockB.Block)
+		require.NoError(t, err)
+
+		unittest.AssertReturnsBefore(t, wg.Wait, 5*time.Second)
+
+		_, more := <-ctx.engine.Done() //wait for all the blocks to be processed
+		require.False(t, more)
+
+		// the state of a block is persisted after the state of its parent
+		require.Equal(t, []flow.Identifier{blockA.ID(), blockB.ID()}, persisted)
+	})
+}
+
+func TestExecutePipelinedChainAfterRestart(t *testing.T) {
+	// A <- B <- C
+	blockSealed := unittest.BlockHeaderFixture()
+	blockA := unittest.ExecutableBlockFixtureWithParent(nil, &blockSealed)
+	blockB := unittest.ExecutableBlockFixtureWithParent(nil, blockA.Block.Header)
+	blockC := unittest.ExecutableBlockFixtureWithParent(nil, blockB.Block.Header)
+	startState := unittest.StateCommitmentFixture()
+
+	logBlocks(map[string]*entity.ExecutableBlock{
+		"A": blockA,
+		"B": blockB,
+		"C": blockC,
+	})
+
+	// the execution state persisted by the engine before and after the restart
+	commits := make(map[flow.Identifier]flow.StateCommitment)
+	commits[blockA.Block.Header.ParentID] = startState
+	blockAExecutionResultID := unittest.IdentifierFixture()
+	blockBExecutionResultID := unittest.IdentifierFixture()
+
+	// the engine stops while block B is being persisted, and block C is executed on the view of block B
+	runWithPipelinedEngine(t, func(ctx testingContext) {
+		ctx.mockStateCommitsWithMap(commits)
+
+		ctx.state.On("Sealed").Return(ctx.snapshot)
+		ctx.snapshot.On("Head").Return(&blockSealed, nil)
+		ctx.mockStakedAtBlockID(blockA.ID(), false)
+
+		// block B is executed on the view of block A, or on its persisted state if it is handled afterwards
+		ctx.executionState.On("NewView", startState).Return(new(delta.View))
+
+		cComputed := make(chan struct{})
+		ctx.mockPipelinedComputation(func(computed *entity.ExecutableBlock) {}, blockA)
+		ctx.mockPipelinedComputation(func(computed *entity.ExecutableBlock) {}, blockB)
+		ctx.mockPipelinedComputation(func(computed *entity.ExecutableBlock) {
+			close(cComputed)
+		}, blockC)
+
+		aPersisted := make(chan struct{})
+		bPersisting := make(chan struct{})
+		ctx.mockPipelinedPersistence(commits, func(_ context.Context, _ flow.Identifier) error {
+			close(aPersisted)
+			return nil
+		}, blockA, startState, unittest.IdentifierFixture())
+		ctx.mockPipelinedPersistence(commits, func(persistCtx context.Context, _ flow.Identifier) error {
+			close(bPersisting)
+			<-persistCtx.Done()
+			return persistCtx.Err()
+		}, blockB, startState, blockAExecutionResultID)
+
+		err := ctx.engine.handleBlock(context.Background(), blockA.Block)
+		require.NoError(t, err)
+		err = ctx.engine.handleBlock(context.Background(), blockB.Block)
+		require.NoError(t, err)
+		err = ctx.engine.handleBlock(context.Background(), blockC.Block)
+		require.NoError(t, err)
+
+		unittest.AssertClosesBefore(t, aPersisted, 5*time.Second)
+		unittest.AssertClosesBefore(t, bPersisting, 5*time.Second)
+		unittest.AssertClosesBefore(t, cComputed, 5*time.Second)
+
+		_, more := <-ctx.engine.Done() // stop the engine while block B is being persisted
+		require.False(t, more)
+	})
+
+	// only the state of block A is persisted, as the state of block C is persisted after the state of block B
+	require.Contains(t, commits, blockA.ID())
+	require.NotContains(t, commits, blockB.ID())
+	require.NotContains(t, commits, blockC.ID())
+
+	// after the restart, the unexecuted blocks B and C are reloaded and executed again, block B on the persisted
+	// state of block A
+	runWithPipelinedEngine(t, func(ctx testingContext) {
+		ctx.mockStateCommitsWithMap(commits)
+
+		ctx.state.On("Sealed").Return(ctx.snapshot)
+		ctx.snapshot.On("Head").Return(&blockSealed, nil)
+		ctx.mockStakedAtBlockID(blockB.ID(), false)
+		ctx.mockStakedAtBlockID(blockC.ID(), false)
+
+		ctx.executionState.On("NewView", startState).Return(new(delta.View)).Once()
+
+		ctx.mockPipelinedComputation(func(computed *entity.ExecutableBlock) {
+			assert.Nil(t, computed.AwaitStartState, "block B is not executed on the persisted state of block A")
+		}, blockB)
+		cComputed := make(chan struct{})
+		ctx.mockPipelinedComputation(func(computed *entity.ExecutableBlock) {
+			assert.NotNil(t, computed.AwaitStartState, "block C is not executed on the view of block B")
+			close(cComputed)
+		}, blockC)
+
+		var lock sync.Mutex
+		var persisted []flow.Identifier
+		wg := sync.WaitGroup{}
+		onPersist := func(_ context.Context, blockID flow.Identifier) error {
+			lock.Lock()
+			defer lock.Unlock()
+			persisted = append(persisted, blockID)
+			wg.Done()
+			return nil
+		}
+		ctx.mockPipelinedPersistence(commits, func(persistCtx context.Context, blockID flow.Identifier) error {
+			<-cComputed
+			return onPersist(persistCtx, blockID)
+		}, blockB, startState, blockAExecutionResultID)
+		ctx.mockPipelinedPersistence(commits, onPersist, blockC, startState, blockBExecutionResultID)
+
+		wg.Add(2)
+		err := ctx.engine.handleBlock(context.Background(), blockB.Block)
+		require.NoError(t, err)
+		err = ctx.engine.handleBlock(context.Background(), blockC.Block)
+		require.NoError(t, err)
+
+		unittest.AssertReturnsBefore(t, wg.Wait, 5*time.Second)
+
+		_, more := <-ctx.engine.Done() //wait for all the blocks to be processed
+		require.False(t, more)
+
+		require.Equal(t, []flow.Identifier{blockB.ID(), blockC.ID()}, persisted)
+	})
+}
+
 func TestExecutionGenerationResultsAreChained(t *testing.T) {
 
 	execState := new(state.ExecutionState)
@@ -585,10 +866,13 @@ func TestExecutionGenerationResultsAreChained(t *testing.T) {
 		On("GetExecutionResultID", mock.Anything, executableBlock.Block.Header.ParentID).
 		Return(previousExecutionResultID, nil)
 
-	er, err := e.generateExecutionResultForBlock(context.Background(), executableBlock.Block, nil, endState, nil)
+	executionDataID := unittest.IdentifierFixture()
+
+	er, err := e.generateExecutionResultForBlock(context.Background(), executableBlock.Block, nil, endState, nil, executionDataID)
 	assert.NoError(t, err)
 
 	assert.Equal(t, previousExecutionResultID, er.PreviousResultID)
+	assert.Equal(t, executionDataID, er.ExecutionDataID)
 
 	execState.AssertExpectations(t)
 }
@@ -632,6 +916,49 @@ func TestExecuteScriptAtBlockID(t *testing.T) {
 	})
 }
 
+func TestExecuteScriptsAtBlockID(t *testing.T) {
+	runWithEngine(t, func(ctx testingContext) {
+		// Meaningless scripts
+		scripts := [][]byte{{1, 1, 2}, {3, 5, 8}}
+		arguments := [][][]byte{nil, {{13}}}
+		scriptResult := []byte{1}
+		scriptErr := errors.New("script failed")
+
+		// Ensure block we're about to query against is executable
+		blockA := unittest.ExecutableBlockFixture(nil)
+		blockA.StartState = unittest.StateCommitmentFixture()
+
+		snapshot := new(protocol.Snapshot)
+		snapshot.On("Head").Return(blockA.Block.Header, nil)
+
+		ctx.stateCommitmentExist(blockA.ID(), blockA.StartState)
+
+		ctx.state.On("AtBlockID", blockA.Block.ID()).Return(snapshot)
+		view := delta.NewView(delta.AlwaysEmptyGetRegisterFunc)
+		// the view is created once for the whole batch
+		ctx.executionState.On("NewView", blockA.StartState).Return(view).Once()
+
+		// each script is executed in its own child view
+		ctx.computationManager.
+			On("ExecuteScript", scripts[0], arguments[0], blockA.Block.Header, mock.Anything).
+			Return(scriptResult, nil)
+		ctx.computationManager.
+			On("ExecuteScript", scripts[1], arguments[1], blockA.Block.Header, mock.Anything).
+			Return(nil, scriptErr)
+
+		// Execute our scripts and expect the result of each
+		values, errs, err := ctx.engine.ExecuteScriptsAtBlockID(context.Background(), scripts, arguments, blockA.Block.ID())
+		require.NoError(t, err)
+		assert.Equal(t, [][]byte{scriptResult, nil}, values)
+		assert.Equal(t, []error{nil, scriptErr}, errs)
+
+		// Assert other components were called as expected
+		ctx.computationManager.AssertExpectations(t)
+		ctx.executionState.AssertExpectations(t)
+		ctx.state.AssertExpectations(t)
+	})
+}
+
 func Test_SPOCKGeneration(t *testing.T) {
 	runWithEngine(t, func(ctx testingContext) {
 
@@ -824,6 +1151,9 @@ func newIngestionEngine(t *testing.T, ps *mocks.ProtocolState, es *mocks.Executi
 		return stateProtocol.IsNodeStakedAt(ps.AtBlockID(blockID), myIdentity.NodeID)
 	}
 
+	executionDatas := new(storagemock.ExecutionDatas)
+	executionDatas.On("Store", mock.Anything, mock.Anything).Return(nil)
+
 	engine, err = New(
 		log,
 		net,
@@ -835,6 +1165,9 @@ func newIngestionEngine(t *testing.T, ps *mocks.ProtocolState, es *mocks.Executi
 		events,
 		events,
 		txResults,
+		new(storagemock.TransactionTraces),
+		executionDatas,
+		nil,
 		computationManager,
 		providerEngine,
 		es,
@@ -846,6 +1179,9 @@ func newIngestionEngine(t *testing.T, ps *mocks.ProtocolState, es *mocks.Executi
 		10,
 		false,
 		checkStakedAtBlock,
+		func() bool { return false },
+		false,
+		true,
 	)
 
 	require.NoError(t, err)
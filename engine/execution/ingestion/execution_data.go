@@ -0,0 +1,58 @@
+package ingestion
+
+import (
+	"github.com/onflow/flow-go/engine/execution"
+	"github.com/onflow/flow-go/model/flow"
+)
+
+// generateExecutionData generates the execution data of an executed block, and the execution data of its chunks: for
+// each chunk, its collection, the events emitted by its transactions and the registers it updated.
+func generateExecutionData(result *execution.ComputationResult) (*flow.ExecutionData, []*flow.ChunkExecutionData) {
+	block := result.ExecutableBlock
+	chunks := make([]*flow.ChunkExecutionData, 0, len(result.StateSnapshots))
+	chunkIDs := make([]flow.Identifier, 0, len(result.StateSnapshots))
+
+	// the transactions are indexed across the chunks of the block, in the order of the chunks
+	var firstIndex, lastIndex uint32
+	events := result.Events
+	for i, snapshot := range result.StateSnapshots {
+		chunk := &flow.ChunkExecutionData{
+			BlockID: block.ID(),
+			Index:   uint64(i),
+		}
+
+		// the system chunk is last, its transaction is not part of the block payload
+		if i < len(block.Block.Payload.Guarantees) {
+			completeCollection := block.CompleteCollections[block.Block.Payload.Guarantees[i].ID()]
+			collection := completeCollection.Collection()
+			chunk.Collection = &collection
+			lastIndex = firstIndex + uint32(len(collection.Transactions))
+		} else {
+			lastIndex = ^uint32(0)
+		}
+
+		// the events are in the order of their transactions
+		chunk.Events = make([]flow.Event, 0)
+		for len(events) > 0 && events[0].TransactionIndex < lastIndex {
+			chunk.Events = append(chunk.Events, events[0])
+			events = events[1:]
+		}
+
+		ids, values := snapshot.Delta.RegisterUpdates()
+		chunk.TrieUpdate = make(flow.RegisterEntries, 0, len(ids))
+		for j, id := range ids {
+			chunk.TrieUpdate = append(chunk.TrieUpdate, flow.RegisterEntry{Key: id, Value: values[j]})
+		}
+
+		chunks = append(chunks, chunk)
+		chunkIDs = append(chunkIDs, chunk.ID())
+		firstIndex = lastIndex
+	}
+
+	data := &flow.ExecutionData{
+		BlockID:               block.ID(),
+		ChunkExecutionDataIDs: chunkIDs,
+	}
+
+	return data, chunks
+}
@@ -0,0 +1,65 @@
+package ingestion
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/engine/execution"
+	"github.com/onflow/flow-go/engine/execution/state/delta"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+func TestGenerateExecutionData(t *testing.T) {
+	// two collections of a transaction each, and the system chunk
+	executableBlock := unittest.ExecutableBlockFixture([][]flow.Identifier{{collection1Identity.NodeID}, {collection1Identity.NodeID}})
+	result := &execution.ComputationResult{
+		ExecutableBlock: executableBlock,
+	}
+
+	txIDs := make([]flow.Identifier, 0, 3)
+	for i, guarantee := range executableBlock.Block.Payload.Guarantees {
+		txIDs = append(txIDs, executableBlock.CompleteCollections[guarantee.ID()].Transactions[0].ID())
+
+		view := delta.NewView(delta.AlwaysEmptyGetRegisterFunc)
+		err := view.Set("owner", "", "key", []byte{byte(i)})
+		require.NoError(t, err)
+		result.AddStateSnapshot(view.Interactions())
+	}
+	txIDs = append(txIDs, unittest.IdentifierFixture())
+	result.AddStateSnapshot(delta.NewView(delta.AlwaysEmptyGetRegisterFunc).Interactions())
+
+	// the first transaction emits two events, the second none, the system transaction one
+	result.AddEvents([]flow.Event{
+		unittest.EventFixture(flow.EventAccountCreated, 0, 0, txIDs[0]),
+		unittest.EventFixture(flow.EventAccountUpdated, 0, 1, txIDs[0]),
+		unittest.EventFixture(flow.EventEpochSetup, 2, 0, txIDs[2]),
+	})
+
+	data, chunks := generateExecutionData(result)
+	assert.Equal(t, executableBlock.ID(), data.BlockID)
+	require.Len(t, chunks, 3)
+	require.Len(t, data.ChunkExecutionDataIDs, 3)
+
+	for i, chunk := range chunks {
+		assert.Equal(t, executableBlock.ID(), chunk.BlockID)
+		assert.Equal(t, uint64(i), chunk.Index)
+		assert.Equal(t, chunk.ID(), data.ChunkExecutionDataIDs[i])
+	}
+
+	for i, guarantee := range executableBlock.Block.Payload.Guarantees {
+		chunk := chunks[i]
+		require.NotNil(t, chunk.Collection)
+		assert.Equal(t, guarantee.CollectionID, chunk.Collection.ID())
+		assert.Equal(t, flow.RegisterEntries{{Key: flow.NewRegisterID("owner", "", "key"), Value: []byte{byte(i)}}}, chunk.TrieUpdate)
+	}
+	assert.Nil(t, chunks[2].Collection)
+	assert.Empty(t, chunks[2].TrieUpdate)
+
+	assert.Len(t, chunks[0].Events, 2)
+	assert.Empty(t, chunks[1].Events)
+	require.Len(t, chunks[2].Events, 1)
+	assert.Equal(t, txIDs[2], chunks[2].Events[0].TransactionID)
+}
@@ -0,0 +1,13 @@
+package ingestion
+
+// IngestAdmin represents the admin commands that the execution ingest engine exposes to node operators
+type IngestAdmin interface {
+
+	// SetStopHeight sets the height to stop the execution after, or removes it if the height is 0, and whether the
+	// node crashes once stopped instead of idling
+	SetStopHeight(height uint64, crash bool) error
+
+	// StopHeight returns the height to stop the execution after, 0 if not set, whether the node crashes once stopped,
+	// and whether the execution is stopped
+	StopHeight() (uint64, bool, bool)
+}
@@ -3,6 +3,7 @@ package ingestion
 import (
 	"context"
 
+	"github.com/onflow/flow-go/engine/execution"
 	"github.com/onflow/flow-go/model/flow"
 )
 
@@ -12,6 +13,39 @@ type IngestRPC interface {
 	// ExecuteScriptAtBlockID executes a script at the given Block id
 	ExecuteScriptAtBlockID(ctx context.Context, script []byte, arguments [][]byte, blockID flow.Identifier) ([]byte, error)
 
+	// ExecuteScriptsAtBlockID executes a batch of scripts against the same view at the given Block id. It returns
+	// the value or the error of each script, the error is only non-nil if the batch could not be executed at all.
+	ExecuteScriptsAtBlockID(ctx context.Context, scripts [][]byte, arguments [][][]byte, blockID flow.Identifier) ([][]byte, []error, error)
+
 	// GetAccount returns the Account details at the given Block id
 	GetAccount(ctx context.Context, address flow.Address, blockID flow.Identifier) (*flow.Account, error)
+
+	// GetAccounts returns the details of a batch of Accounts read from the same view at the given Block id. It returns
+	// the account or the error of each lookup, the error is only non-nil if the batch could not be looked up at all.
+	GetAccounts(ctx context.Context, addresses []flow.Address, blockID flow.Identifier) ([]*flow.Account, []error, error)
+
+	// GetRegisterProofAtBlockID returns a batch proof of the given registers, including their values, against the
+	// state commitment of the given Block id
+	GetRegisterProofAtBlockID(ctx context.Context, registerIDs []flow.RegisterID, blockID flow.Identifier) (flow.StorageProof, error)
+
+	// GetAccountKeyAtIndex returns the key with the given index of the Account at the given Block id
+	GetAccountKeyAtIndex(ctx context.Context, address flow.Address, keyIndex uint64, blockID flow.Identifier) (*flow.AccountPublicKey, error)
+
+	// ListAccountKeys returns up to limit keys of the Account at the given Block id, starting with the key at index offset,
+	// and the total number of keys of the Account
+	ListAccountKeys(ctx context.Context, address flow.Address, offset uint64, limit uint64, blockID flow.Identifier) ([]flow.AccountPublicKey, uint64, error)
+
+	// GetAccountContractNames returns the sorted names of the contracts deployed to the Account at the given Block id
+	GetAccountContractNames(ctx context.Context, address flow.Address, blockID flow.Identifier) ([]string, error)
+
+	// GetAccountContract returns the code of the contract with the given name deployed to the Account at the given
+	// Block id, or nil if the Account has no such contract
+	GetAccountContract(ctx context.Context, address flow.Address, name string, blockID flow.Identifier) ([]byte, error)
+
+	// GetAccountStorageUsed returns the storage used by the Account at the given Block id, in bytes
+	GetAccountStorageUsed(ctx context.Context, address flow.Address, blockID flow.Identifier) (uint64, error)
+
+	// DryRunTransaction executes a transaction at the given Block id without committing its changes, and returns the
+	// computation it used, its events, the storage it used per Account, its fee and the error it would fail with
+	DryRunTransaction(ctx context.Context, tx *flow.TransactionBody, skipSignatureCheck bool, blockID flow.Identifier) (*execution.DryRunResult, error)
 }
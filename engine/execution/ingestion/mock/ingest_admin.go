@@ -0,0 +1,52 @@
+// Code generated by mockery v1.0.0. DO NOT EDIT.
+
+package mock
+
+import mock "github.com/stretchr/testify/mock"
+
+// IngestAdmin is an autogenerated mock type for the IngestAdmin type
+type IngestAdmin struct {
+	mock.Mock
+}
+
+// SetStopHeight provides a mock function with given fields: height, crash
+func (_m *IngestAdmin) SetStopHeight(height uint64, crash bool) error {
+	ret := _m.Called(height, crash)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uint64, bool) error); ok {
+		r0 = rf(height, crash)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// StopHeight provides a mock function with given fields:
+func (_m *IngestAdmin) StopHeight() (uint64, bool, bool) {
+	ret := _m.Called()
+
+	var r0 uint64
+	if rf, ok := ret.Get(0).(func() uint64); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(uint64)
+	}
+
+	var r1 bool
+	if rf, ok := ret.Get(1).(func() bool); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+
+	var r2 bool
+	if rf, ok := ret.Get(2).(func() bool); ok {
+		r2 = rf()
+	} else {
+		r2 = ret.Get(2).(bool)
+	}
+
+	return r0, r1, r2
+}
@@ -5,6 +5,7 @@ package mock
 import (
 	context "context"
 
+	execution "github.com/onflow/flow-go/engine/execution"
 	flow "github.com/onflow/flow-go/model/flow"
 
 	mock "github.com/stretchr/testify/mock"
@@ -15,6 +16,29 @@ type IngestRPC struct {
 	mock.Mock
 }
 
+// DryRunTransaction provides a mock function with given fields: ctx, tx, skipSignatureCheck, blockID
+func (_m *IngestRPC) DryRunTransaction(ctx context.Context, tx *flow.TransactionBody, skipSignatureCheck bool, blockID flow.Identifier) (*execution.DryRunResult, error) {
+	ret := _m.Called(ctx, tx, skipSignatureCheck, blockID)
+
+	var r0 *execution.DryRunResult
+	if rf, ok := ret.Get(0).(func(context.Context, *flow.TransactionBody, bool, flow.Identifier) *execution.DryRunResult); ok {
+		r0 = rf(ctx, tx, skipSignatureCheck, blockID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*execution.DryRunResult)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *flow.TransactionBody, bool, flow.Identifier) error); ok {
+		r1 = rf(ctx, tx, skipSignatureCheck, blockID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // ExecuteScriptAtBlockID provides a mock function with given fields: ctx, script, arguments, blockID
 func (_m *IngestRPC) ExecuteScriptAtBlockID(ctx context.Context, script []byte, arguments [][]byte, blockID flow.Identifier) ([]byte, error) {
 	ret := _m.Called(ctx, script, arguments, blockID)
@@ -38,6 +62,38 @@ func (_m *IngestRPC) ExecuteScriptAtBlockID(ctx context.Context, script []byte,
 	return r0, r1
 }
 
+// ExecuteScriptsAtBlockID provides a mock function with given fields: ctx, scripts, arguments, blockID
+func (_m *IngestRPC) ExecuteScriptsAtBlockID(ctx context.Context, scripts [][]byte, arguments [][][]byte, blockID flow.Identifier) ([][]byte, []error, error) {
+	ret := _m.Called(ctx, scripts, arguments, blockID)
+
+	var r0 [][]byte
+	if rf, ok := ret.Get(0).(func(context.Context, [][]byte, [][][]byte, flow.Identifier) [][]byte); ok {
+		r0 = rf(ctx, scripts, arguments, blockID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([][]byte)
+		}
+	}
+
+	var r1 []error
+	if rf, ok := ret.Get(1).(func(context.Context, [][]byte, [][][]byte, flow.Identifier) []error); ok {
+		r1 = rf(ctx, scripts, arguments, blockID)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).([]error)
+		}
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(context.Context, [][]byte, [][][]byte, flow.Identifier) error); ok {
+		r2 = rf(ctx, scripts, arguments, blockID)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 // GetAccount provides a mock function with given fields: ctx, address, blockID
 func (_m *IngestRPC) GetAccount(ctx context.Context, address flow.Address, blockID flow.Identifier) (*flow.Account, error) {
 	ret := _m.Called(ctx, address, blockID)
@@ -60,3 +116,178 @@ func (_m *IngestRPC) GetAccount(ctx context.Context, address flow.Address, block
 
 	return r0, r1
 }
+
+// GetAccountContract provides a mock function with given fields: ctx, address, name, blockID
+func (_m *IngestRPC) GetAccountContract(ctx context.Context, address flow.Address, name string, blockID flow.Identifier) ([]byte, error) {
+	ret := _m.Called(ctx, address, name, blockID)
+
+	var r0 []byte
+	if rf, ok := ret.Get(0).(func(context.Context, flow.Address, string, flow.Identifier) []byte); ok {
+		r0 = rf(ctx, address, name, blockID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]byte)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, flow.Address, string, flow.Identifier) error); ok {
+		r1 = rf(ctx, address, name, blockID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetAccountContractNames provides a mock function with given fields: ctx, address, blockID
+func (_m *IngestRPC) GetAccountContractNames(ctx context.Context, address flow.Address, blockID flow.Identifier) ([]string, error) {
+	ret := _m.Called(ctx, address, blockID)
+
+	var r0 []string
+	if rf, ok := ret.Get(0).(func(context.Context, flow.Address, flow.Identifier) []string); ok {
+		r0 = rf(ctx, address, blockID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, flow.Address, flow.Identifier) error); ok {
+		r1 = rf(ctx, address, blockID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetAccountKeyAtIndex provides a mock function with given fields: ctx, address, keyIndex, blockID
+func (_m *IngestRPC) GetAccountKeyAtIndex(ctx context.Context, address flow.Address, keyIndex uint64, blockID flow.Identifier) (*flow.AccountPublicKey, error) {
+	ret := _m.Called(ctx, address, keyIndex, blockID)
+
+	var r0 *flow.AccountPublicKey
+	if rf, ok := ret.Get(0).(func(context.Context, flow.Address, uint64, flow.Identifier) *flow.AccountPublicKey); ok {
+		r0 = rf(ctx, address, keyIndex, blockID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*flow.AccountPublicKey)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, flow.Address, uint64, flow.Identifier) error); ok {
+		r1 = rf(ctx, address, keyIndex, blockID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetAccountStorageUsed provides a mock function with given fields: ctx, address, blockID
+func (_m *IngestRPC) GetAccountStorageUsed(ctx context.Context, address flow.Address, blockID flow.Identifier) (uint64, error) {
+	ret := _m.Called(ctx, address, blockID)
+
+	var r0 uint64
+	if rf, ok := ret.Get(0).(func(context.Context, flow.Address, flow.Identifier) uint64); ok {
+		r0 = rf(ctx, address, blockID)
+	} else {
+		r0 = ret.Get(0).(uint64)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, flow.Address, flow.Identifier) error); ok {
+		r1 = rf(ctx, address, blockID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetAccounts provides a mock function with given fields: ctx, addresses, blockID
+func (_m *IngestRPC) GetAccounts(ctx context.Context, addresses []flow.Address, blockID flow.Identifier) ([]*flow.Account, []error, error) {
+	ret := _m.Called(ctx, addresses, blockID)
+
+	var r0 []*flow.Account
+	if rf, ok := ret.Get(0).(func(context.Context, []flow.Address, flow.Identifier) []*flow.Account); ok {
+		r0 = rf(ctx, addresses, blockID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*flow.Account)
+		}
+	}
+
+	var r1 []error
+	if rf, ok := ret.Get(1).(func(context.Context, []flow.Address, flow.Identifier) []error); ok {
+		r1 = rf(ctx, addresses, blockID)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).([]error)
+		}
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(context.Context, []flow.Address, flow.Identifier) error); ok {
+		r2 = rf(ctx, addresses, blockID)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// GetRegisterProofAtBlockID provides a mock function with given fields: ctx, registerIDs, blockID
+func (_m *IngestRPC) GetRegisterProofAtBlockID(ctx context.Context, registerIDs []flow.RegisterID, blockID flow.Identifier) (flow.StorageProof, error) {
+	ret := _m.Called(ctx, registerIDs, blockID)
+
+	var r0 flow.StorageProof
+	if rf, ok := ret.Get(0).(func(context.Context, []flow.RegisterID, flow.Identifier) flow.StorageProof); ok {
+		r0 = rf(ctx, registerIDs, blockID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(flow.StorageProof)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, []flow.RegisterID, flow.Identifier) error); ok {
+		r1 = rf(ctx, registerIDs, blockID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListAccountKeys provides a mock function with given fields: ctx, address, offset, limit, blockID
+func (_m *IngestRPC) ListAccountKeys(ctx context.Context, address flow.Address, offset uint64, limit uint64, blockID flow.Identifier) ([]flow.AccountPublicKey, uint64, error) {
+	ret := _m.Called(ctx, address, offset, limit, blockID)
+
+	var r0 []flow.AccountPublicKey
+	if rf, ok := ret.Get(0).(func(context.Context, flow.Address, uint64, uint64, flow.Identifier) []flow.AccountPublicKey); ok {
+		r0 = rf(ctx, address, offset, limit, blockID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]flow.AccountPublicKey)
+		}
+	}
+
+	var r1 uint64
+	if rf, ok := ret.Get(1).(func(context.Context, flow.Address, uint64, uint64, flow.Identifier) uint64); ok {
+		r1 = rf(ctx, address, offset, limit, blockID)
+	} else {
+		r1 = ret.Get(1).(uint64)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(context.Context, flow.Address, uint64, uint64, flow.Identifier) error); ok {
+		r2 = rf(ctx, address, offset, limit, blockID)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
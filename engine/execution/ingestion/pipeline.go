@@ -0,0 +1,92 @@
+package ingestion
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/onflow/flow-go/engine/execution/state/delta"
+	"github.com/onflow/flow-go/model/flow"
+)
+
+// inflightBlock is a block whose transactions are executed, but whose state is not committed and persisted yet.
+// With pipelined execution, the children of the block execute on its view meanwhile, and wait for its state to be
+// committed before committing their own state, and for it to be persisted before persisting their own.
+//
+// Only the execution of the block itself commits, persists or fails it.
+type inflightBlock struct {
+	read       delta.GetRegisterFunc // reads the registers at the end of the block, safe for concurrent use
+	committed  chan struct{}         // closed once the state of the block is committed, or its execution failed
+	persisted  chan struct{}         // closed once the state of the block is persisted, or its execution failed
+	finalState flow.StateCommitment  // set before committed is closed
+	commitErr  error                 // set before committed is closed
+	persistErr error                 // set before persisted is closed
+}
+
+// newInflightBlock returns the in-flight block executed on the given view. The view must not be updated anymore.
+func newInflightBlock(view *delta.View) *inflightBlock {
+	// the ledger reads of the view are cached without locking, so the children executing in parallel read it one at
+	// a time
+	var lock sync.Mutex
+	read := func(owner, controller, key string) (flow.RegisterValue, error) {
+		lock.Lock()
+		defer lock.Unlock()
+		return view.Peek(owner, controller, key)
+	}
+
+	return &inflightBlock{
+		read:      read,
+		committed: make(chan struct{}),
+		persisted: make(chan struct{}),
+	}
+}
+
+// commit marks the state of the block as committed, with the given final state.
+func (b *inflightBlock) commit(finalState flow.StateCommitment) {
+	b.finalState = finalState
+	close(b.committed)
+}
+
+// persist marks the state of the block as persisted.
+func (b *inflightBlock) persist() {
+	close(b.persisted)
+}
+
+// fail marks the execution of the block as failed, if its state is not persisted yet.
+func (b *inflightBlock) fail(err error) {
+	if isClosed(b.persisted) {
+		return
+	}
+	if !isClosed(b.committed) {
+		b.commitErr = err
+		close(b.committed)
+	}
+	b.persistErr = err
+	close(b.persisted)
+}
+
+// awaitCommitted waits for the state of the block to be committed, and returns its final state.
+func (b *inflightBlock) awaitCommitted() (flow.StateCommitment, error) {
+	<-b.committed
+	if b.commitErr != nil {
+		return nil, fmt.Errorf("execution of parent block failed: %w", b.commitErr)
+	}
+	return b.finalState, nil
+}
+
+// awaitPersisted waits for the state of the block to be persisted.
+func (b *inflightBlock) awaitPersisted() error {
+	<-b.persisted
+	if b.persistErr != nil {
+		return fmt.Errorf("execution of parent block failed: %w", b.persistErr)
+	}
+	return nil
+}
+
+func isClosed(c chan struct{}) bool {
+	select {
+	case <-c:
+		return true
+	default:
+		return false
+	}
+}
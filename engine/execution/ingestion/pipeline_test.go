@@ -0,0 +1,93 @@
+package ingestion
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/engine/execution/state/delta"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+func TestInflightBlock(t *testing.T) {
+
+	t.Run("children read the registers at the end of the block", func(t *testing.T) {
+		reads := 0
+		view := delta.NewView(func(owner, controller, key string) (flow.RegisterValue, error) {
+			reads++
+			return flow.RegisterValue(key), nil
+		})
+		err := view.Set("owner", "", "updated", flow.RegisterValue("value"))
+		require.NoError(t, err)
+
+		block := newInflightBlock(view)
+
+		// children executing in parallel
+		var wg sync.WaitGroup
+		for i := 0; i < 10; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				child := delta.NewView(block.read)
+
+				value, err := child.Get("owner", "", "updated")
+				assert.NoError(t, err)
+				assert.Equal(t, flow.RegisterValue("value"), value)
+
+				value, err = child.Get("owner", "", "unchanged")
+				assert.NoError(t, err)
+				assert.Equal(t, flow.RegisterValue("unchanged"), value)
+			}()
+		}
+		wg.Wait()
+
+		assert.Equal(t, 10, reads)
+	})
+
+	t.Run("committed and persisted", func(t *testing.T) {
+		block := newInflightBlock(delta.NewView(delta.AlwaysEmptyGetRegisterFunc))
+		finalState := unittest.StateCommitmentFixture()
+
+		block.commit(finalState)
+		committed, err := block.awaitCommitted()
+		require.NoError(t, err)
+		assert.Equal(t, finalState, committed)
+
+		block.persist()
+		err = block.awaitPersisted()
+		require.NoError(t, err)
+
+		// a persisted block does not fail anymore
+		block.fail(fmt.Errorf("failed"))
+		err = block.awaitPersisted()
+		assert.NoError(t, err)
+	})
+
+	t.Run("failed before committed", func(t *testing.T) {
+		block := newInflightBlock(delta.NewView(delta.AlwaysEmptyGetRegisterFunc))
+
+		block.fail(fmt.Errorf("failed"))
+		_, err := block.awaitCommitted()
+		assert.Error(t, err)
+		err = block.awaitPersisted()
+		assert.Error(t, err)
+	})
+
+	t.Run("failed before persisted", func(t *testing.T) {
+		block := newInflightBlock(delta.NewView(delta.AlwaysEmptyGetRegisterFunc))
+		finalState := unittest.StateCommitmentFixture()
+
+		block.commit(finalState)
+		block.fail(fmt.Errorf("failed"))
+
+		committed, err := block.awaitCommitted()
+		require.NoError(t, err)
+		assert.Equal(t, finalState, committed)
+		err = block.awaitPersisted()
+		assert.Error(t, err)
+	})
+}
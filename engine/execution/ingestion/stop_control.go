@@ -0,0 +1,258 @@
+package ingestion
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/rs/zerolog"
+
+	"github.com/onflow/flow-go/engine/execution/state"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module"
+	"github.com/onflow/flow-go/state/protocol"
+	"github.com/onflow/flow-go/storage"
+	"github.com/onflow/flow-go/storage/badger/operation"
+)
+
+// stopControl stops the execution after the block finalized at a given height, as set by the operator. The blocks up
+// to and including the stop height are executed and persisted, their descendants are not executed anymore. Once the
+// finalized block at the stop height is both finalized and executed, in any order, the execution is stopped, and the
+// node either crashes or idles, as requested. Blocks of other forks at the stop height do not stop the execution.
+//
+// The stop height is persisted, so that it still applies to a node restarted before reaching it. A node restarted
+// after reaching it resumes the execution, and the stop height is removed.
+type stopControl struct {
+	sync.Mutex
+	log            zerolog.Logger
+	metrics        module.ExecutionMetrics
+	db             *badger.DB
+	state          protocol.State
+	execState      state.ReadOnlyExecutionState
+	height         uint64          // the height to stop after, 0 if not set
+	crash          bool            // whether to crash once stopped, instead of idling
+	finalizedID    flow.Identifier // the finalized block at the stop height, ZeroID until it is finalized
+	stopped        bool            // whether the finalized block at the stop height was executed
+	highestStarted uint64          // the highest height of the blocks that started executing
+}
+
+func newStopControl(
+	log zerolog.Logger,
+	metrics module.ExecutionMetrics,
+	db *badger.DB,
+	state protocol.State,
+	execState state.ReadOnlyExecutionState,
+) *stopControl {
+	return &stopControl{
+		log:       log.With().Str("component", "stop_control").Logger(),
+		metrics:   metrics,
+		db:        db,
+		state:     state,
+		execState: execState,
+	}
+}
+
+// load records that the blocks up to the given height are executed already, and loads the stop height persisted
+// before the restart. If the finalized block at the stop height was executed before the restart, the stop was reached
+// already, and the stop height is removed so that the restarted node resumes the execution.
+func (s *stopControl) load(highestExecuted uint64) error {
+	s.Lock()
+	defer s.Unlock()
+
+	if highestExecuted > s.highestStarted {
+		s.highestStarted = highestExecuted
+	}
+
+	var height uint64
+	var crash bool
+	err := s.db.View(operation.RetrieveExecutionStopHeight(&height, &crash))
+	if errors.Is(err, storage.ErrNotFound) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("could not retrieve stop height: %w", err)
+	}
+
+	finalizedID, err := s.finalizedAt(height)
+	if err != nil {
+		return err
+	}
+
+	if finalizedID != flow.ZeroID {
+		executed, err := state.IsBlockExecuted(context.Background(), s.execState, finalizedID)
+		if err != nil {
+			return fmt.Errorf("could not check whether finalized block %v is executed: %w", finalizedID, err)
+		}
+		if executed {
+			err = operation.RetryOnConflict(s.db.Update, operation.RemoveExecutionStopHeight())
+			if err != nil {
+				return fmt.Errorf("could not remove reached stop height: %w", err)
+			}
+			s.log.Info().
+				Uint64("stop_height", height).
+				Msg("stop height was reached before the restart, resuming the execution")
+			return nil
+		}
+	}
+
+	s.height = height
+	s.crash = crash
+	s.finalizedID = finalizedID
+	s.metrics.ExecutionStopHeight(s.height, s.crash)
+
+	s.log.Info().
+		Uint64("stop_height", s.height).
+		Bool("crash", s.crash).
+		Msg("stop height loaded")
+
+	return nil
+}
+
+// setHeight sets the height to stop after, or removes it if the height is 0, and persists it. The stop height must be
+// above the blocks that started executing already, and can not be changed anymore once the execution is stopped.
+func (s *stopControl) setHeight(height uint64, crash bool) error {
+	s.Lock()
+	defer s.Unlock()
+
+	if s.stopped {
+		return fmt.Errorf("execution is stopped at height %d already", s.height)
+	}
+	if height != 0 && height <= s.highestStarted {
+		return fmt.Errorf("stop height %d must be above the highest block executed (%d)", height, s.highestStarted)
+	}
+	crash = crash && height != 0
+
+	// the stop height might be finalized already if the node is behind
+	finalizedID := flow.ZeroID
+	if height != 0 {
+		var err error
+		finalizedID, err = s.finalizedAt(height)
+		if err != nil {
+			return err
+		}
+	}
+
+	err := operation.RetryOnConflict(s.db.Update, storeStopHeight(height, crash))
+	if err != nil {
+		return fmt.Errorf("could not persist stop height: %w", err)
+	}
+
+	s.height = height
+	s.crash = crash
+	s.finalizedID = finalizedID
+	s.metrics.ExecutionStopHeight(s.height, s.crash)
+
+	s.log.Info().
+		Uint64("stop_height", s.height).
+		Bool("crash", s.crash).
+		Msg("stop height set")
+
+	return nil
+}
+
+// get returns the stop height, whether the node crashes once stopped, and whether the execution is stopped.
+func (s *stopControl) get() (uint64, bool, bool) {
+	s.Lock()
+	defer s.Unlock()
+	return s.height, s.crash, s.stopped
+}
+
+// startExecuting returns whether the given block can be executed, and if so, records that it started executing.
+func (s *stopControl) startExecuting(header *flow.Header) bool {
+	s.Lock()
+	defer s.Unlock()
+
+	if s.height != 0 && header.Height > s.height {
+		return false
+	}
+
+	if header.Height > s.highestStarted {
+		s.highestStarted = header.Height
+	}
+	return true
+}
+
+// blockExecuted stops the execution if the given executed and persisted block is the finalized block at the stop
+// height.
+func (s *stopControl) blockExecuted(header *flow.Header) {
+	s.Lock()
+	defer s.Unlock()
+
+	if s.stopped || s.height == 0 || header.Height != s.height || header.ID() != s.finalizedID {
+		return
+	}
+
+	s.stop(header)
+}
+
+// blockFinalized records the finalized block at the stop height, and stops the execution if it is executed already.
+func (s *stopControl) blockFinalized(header *flow.Header) error {
+	s.Lock()
+	defer s.Unlock()
+
+	if s.stopped || s.height == 0 || header.Height != s.height {
+		return nil
+	}
+
+	s.finalizedID = header.ID()
+	executed, err := state.IsBlockExecuted(context.Background(), s.execState, s.finalizedID)
+	if err != nil {
+		return fmt.Errorf("could not check whether finalized block %v is executed: %w", s.finalizedID, err)
+	}
+	if executed {
+		s.stop(header)
+	}
+
+	return nil
+}
+
+// stop stops the execution at the given finalized and executed block at the stop height.
+func (s *stopControl) stop(header *flow.Header) {
+	s.stopped = true
+	s.metrics.ExecutionStopped(true)
+
+	blockID := header.ID()
+	log := s.log.With().
+		Uint64("height", header.Height).
+		Hex("block_id", blockID[:]).
+		Logger()
+
+	if s.crash {
+		log.Fatal().Msg("execution stopped at the stop height, crashing as requested")
+	}
+
+	log.Info().Msg("execution stopped at the stop height, not executing any descendant blocks")
+}
+
+// finalizedAt returns the ID of the finalized block at the given height, ZeroID if the height is not finalized yet.
+func (s *stopControl) finalizedAt(height uint64) (flow.Identifier, error) {
+	header, err := s.state.AtHeight(height).Head()
+	if errors.Is(err, storage.ErrNotFound) {
+		return flow.ZeroID, nil
+	}
+	if err != nil {
+		return flow.ZeroID, fmt.Errorf("could not get finalized block at height %d: %w", height, err)
+	}
+	return header.ID(), nil
+}
+
+// storeStopHeight upserts the stop height, or removes it if the height is 0.
+func storeStopHeight(height uint64, crash bool) func(*badger.Txn) error {
+	return func(tx *badger.Txn) error {
+		if height == 0 {
+			err := operation.RemoveExecutionStopHeight()(tx)
+			if errors.Is(err, storage.ErrNotFound) {
+				return nil
+			}
+			return err
+		}
+
+		err := operation.UpdateExecutionStopHeight(height, crash)(tx)
+		if errors.Is(err, storage.ErrNotFound) {
+			return operation.InsertExecutionStopHeight(height, crash)(tx)
+		}
+		return err
+	}
+}
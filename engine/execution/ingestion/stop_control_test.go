@@ -0,0 +1,282 @@
+package ingestion
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	statemock "github.com/onflow/flow-go/engine/execution/state/mock"
+	"github.com/onflow/flow-go/model/flow"
+	module "github.com/onflow/flow-go/module/mock"
+	"github.com/onflow/flow-go/state/protocol"
+	"github.com/onflow/flow-go/state/protocol/invalid"
+	protocolmock "github.com/onflow/flow-go/state/protocol/mock"
+	"github.com/onflow/flow-go/storage"
+	"github.com/onflow/flow-go/storage/badger/operation"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+func headerAtHeight(height uint64) *flow.Header {
+	header := unittest.BlockHeaderFixture()
+	header.Height = height
+	return &header
+}
+
+// stopControlChain is the finalized and executed blocks seen by a stop control.
+type stopControlChain struct {
+	finalized map[uint64]*flow.Header
+	executed  map[flow.Identifier]struct{}
+}
+
+func newStopControlChain() *stopControlChain {
+	return &stopControlChain{
+		finalized: make(map[uint64]*flow.Header),
+		executed:  make(map[flow.Identifier]struct{}),
+	}
+}
+
+// newTestStopControl returns a stop control backed by the given database, which reads the finalized and executed
+// blocks from the given chain.
+func newTestStopControl(db *badger.DB, metrics *module.ExecutionMetrics, chain *stopControlChain) *stopControl {
+	ps := new(protocolmock.State)
+	ps.On("AtHeight", mock.Anything).Return(func(height uint64) protocol.Snapshot {
+		header, ok := chain.finalized[height]
+		if !ok {
+			return invalid.NewSnapshot(storage.ErrNotFound)
+		}
+		snapshot := new(protocolmock.Snapshot)
+		snapshot.On("Head").Return(header, nil)
+		return snapshot
+	})
+
+	es := new(statemock.ReadOnlyExecutionState)
+	es.On("StateCommitmentByBlockID", mock.Anything, mock.Anything).Return(
+		func(_ context.Context, blockID flow.Identifier) []byte {
+			return unittest.StateCommitmentFixture()
+		},
+		func(_ context.Context, blockID flow.Identifier) error {
+			if _, ok := chain.executed[blockID]; !ok {
+				return storage.ErrNotFound
+			}
+			return nil
+		},
+	)
+
+	return newStopControl(zerolog.Nop(), metrics, db, ps, es)
+}
+
+func TestStopControl(t *testing.T) {
+
+	t.Run("blocks above the stop height are not executed", func(t *testing.T) {
+		unittest.RunWithBadgerDB(t, func(db *badger.DB) {
+			metrics := new(module.ExecutionMetrics)
+			chain := newStopControlChain()
+			sc := newTestStopControl(db, metrics, chain)
+			require.NoError(t, sc.load(10))
+
+			metrics.On("ExecutionStopHeight", uint64(12), false).Once()
+			err := sc.setHeight(12, false)
+			require.NoError(t, err)
+
+			assert.True(t, sc.startExecuting(headerAtHeight(11)))
+			assert.True(t, sc.startExecuting(headerAtHeight(12)))
+			assert.False(t, sc.startExecuting(headerAtHeight(13)))
+
+			// a block of another fork at the stop height does not stop the execution
+			fork := headerAtHeight(12)
+			chain.executed[fork.ID()] = struct{}{}
+			sc.blockExecuted(fork)
+			_, _, stopped := sc.get()
+			assert.False(t, stopped)
+
+			// neither does the finalized block at the stop height before it is executed
+			final := headerAtHeight(12)
+			chain.finalized[12] = final
+			require.NoError(t, sc.blockFinalized(final))
+			_, _, stopped = sc.get()
+			assert.False(t, stopped)
+
+			metrics.On("ExecutionStopped", true).Once()
+			chain.executed[final.ID()] = struct{}{}
+			sc.blockExecuted(final)
+			height, crash, stopped := sc.get()
+			assert.Equal(t, uint64(12), height)
+			assert.False(t, crash)
+			assert.True(t, stopped)
+
+			// once stopped, the stop height can not be changed anymore
+			err = sc.setHeight(20, false)
+			assert.Error(t, err)
+			err = sc.setHeight(0, false)
+			assert.Error(t, err)
+			assert.False(t, sc.startExecuting(headerAtHeight(13)))
+
+			metrics.AssertExpectations(t)
+		})
+	})
+
+	t.Run("execution stops once the executed block at the stop height is finalized", func(t *testing.T) {
+		unittest.RunWithBadgerDB(t, func(db *badger.DB) {
+			metrics := new(module.ExecutionMetrics)
+			chain := newStopControlChain()
+			sc := newTestStopControl(db, metrics, chain)
+			require.NoError(t, sc.load(10))
+
+			metrics.On("ExecutionStopHeight", uint64(12), false).Once()
+			require.NoError(t, sc.setHeight(12, false))
+
+			final := headerAtHeight(12)
+			chain.executed[final.ID()] = struct{}{}
+			sc.blockExecuted(final)
+			_, _, stopped := sc.get()
+			assert.False(t, stopped)
+
+			// finalizing a block below the stop height does not stop the execution
+			require.NoError(t, sc.blockFinalized(headerAtHeight(11)))
+			_, _, stopped = sc.get()
+			assert.False(t, stopped)
+
+			metrics.On("ExecutionStopped", true).Once()
+			chain.finalized[12] = final
+			require.NoError(t, sc.blockFinalized(final))
+			_, _, stopped = sc.get()
+			assert.True(t, stopped)
+
+			metrics.AssertExpectations(t)
+		})
+	})
+
+	t.Run("stop height can be set at a finalized height", func(t *testing.T) {
+		unittest.RunWithBadgerDB(t, func(db *badger.DB) {
+			metrics := new(module.ExecutionMetrics)
+			chain := newStopControlChain()
+			sc := newTestStopControl(db, metrics, chain)
+			require.NoError(t, sc.load(10))
+
+			// the node is behind, the stop height is finalized already
+			final := headerAtHeight(12)
+			chain.finalized[12] = final
+
+			metrics.On("ExecutionStopHeight", uint64(12), false).Once()
+			require.NoError(t, sc.setHeight(12, false))
+
+			metrics.On("ExecutionStopped", true).Once()
+			chain.executed[final.ID()] = struct{}{}
+			sc.blockExecuted(final)
+			_, _, stopped := sc.get()
+			assert.True(t, stopped)
+
+			metrics.AssertExpectations(t)
+		})
+	})
+
+	t.Run("stop height must be above the blocks started executing", func(t *testing.T) {
+		unittest.RunWithBadgerDB(t, func(db *badger.DB) {
+			metrics := new(module.ExecutionMetrics)
+			sc := newTestStopControl(db, metrics, newStopControlChain())
+			require.NoError(t, sc.load(10))
+			assert.True(t, sc.startExecuting(headerAtHeight(11)))
+
+			err := sc.setHeight(10, false)
+			assert.Error(t, err)
+			err = sc.setHeight(11, false)
+			assert.Error(t, err)
+
+			metrics.On("ExecutionStopHeight", uint64(12), true).Once()
+			err = sc.setHeight(12, true)
+			require.NoError(t, err)
+
+			height, crash, stopped := sc.get()
+			assert.Equal(t, uint64(12), height)
+			assert.True(t, crash)
+			assert.False(t, stopped)
+
+			metrics.AssertExpectations(t)
+		})
+	})
+
+	t.Run("stop height can be removed before stopped", func(t *testing.T) {
+		unittest.RunWithBadgerDB(t, func(db *badger.DB) {
+			metrics := new(module.ExecutionMetrics)
+			chain := newStopControlChain()
+			sc := newTestStopControl(db, metrics, chain)
+			require.NoError(t, sc.load(0))
+
+			metrics.On("ExecutionStopHeight", uint64(5), true).Once()
+			err := sc.setHeight(5, true)
+			require.NoError(t, err)
+			assert.False(t, sc.startExecuting(headerAtHeight(6)))
+
+			metrics.On("ExecutionStopHeight", uint64(0), false).Once()
+			err = sc.setHeight(0, true)
+			require.NoError(t, err)
+			assert.True(t, sc.startExecuting(headerAtHeight(6)))
+
+			// without stop height, no block stops the execution
+			header := headerAtHeight(6)
+			chain.finalized[6] = header
+			chain.executed[header.ID()] = struct{}{}
+			require.NoError(t, sc.blockFinalized(header))
+			sc.blockExecuted(header)
+			height, crash, stopped := sc.get()
+			assert.Equal(t, uint64(0), height)
+			assert.False(t, crash)
+			assert.False(t, stopped)
+
+			// the removal is persisted as well
+			var persisted uint64
+			err = db.View(operation.RetrieveExecutionStopHeight(&persisted, &crash))
+			assert.True(t, errors.Is(err, storage.ErrNotFound))
+
+			metrics.AssertExpectations(t)
+		})
+	})
+
+	t.Run("stop height is kept across restarts until reached", func(t *testing.T) {
+		unittest.RunWithBadgerDB(t, func(db *badger.DB) {
+			metrics := new(module.ExecutionMetrics)
+			chain := newStopControlChain()
+			sc := newTestStopControl(db, metrics, chain)
+			require.NoError(t, sc.load(10))
+
+			metrics.On("ExecutionStopHeight", uint64(12), true).Twice()
+			require.NoError(t, sc.setHeight(12, true))
+
+			// the node restarts before the finalized block at the stop height is executed
+			final := headerAtHeight(12)
+			chain.finalized[12] = final
+			chain.executed[headerAtHeight(12).ID()] = struct{}{}
+
+			restarted := newTestStopControl(db, metrics, chain)
+			require.NoError(t, restarted.load(12))
+			height, crash, stopped := restarted.get()
+			assert.Equal(t, uint64(12), height)
+			assert.True(t, crash)
+			assert.False(t, stopped)
+			assert.False(t, restarted.startExecuting(headerAtHeight(13)))
+
+			// the node restarts after the finalized block at the stop height is executed, and resumes the execution
+			chain.executed[final.ID()] = struct{}{}
+
+			restarted = newTestStopControl(db, metrics, chain)
+			require.NoError(t, restarted.load(12))
+			height, crash, stopped = restarted.get()
+			assert.Equal(t, uint64(0), height)
+			assert.False(t, crash)
+			assert.False(t, stopped)
+			assert.True(t, restarted.startExecuting(headerAtHeight(13)))
+
+			var persisted uint64
+			err := db.View(operation.RetrieveExecutionStopHeight(&persisted, &crash))
+			assert.True(t, errors.Is(err, storage.ErrNotFound))
+
+			metrics.AssertExpectations(t)
+		})
+	})
+}
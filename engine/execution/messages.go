@@ -2,6 +2,7 @@ package execution
 
 import (
 	"github.com/onflow/flow-go/engine/execution/state/delta"
+	"github.com/onflow/flow-go/fvm/errors"
 	"github.com/onflow/flow-go/model/flow"
 	"github.com/onflow/flow-go/module/mempool/entity"
 )
@@ -23,6 +24,7 @@ type ComputationResult struct {
 	Events             []flow.Event
 	ServiceEvents      []flow.Event
 	TransactionResults []flow.TransactionResult
+	TransactionTraces  []flow.TransactionTrace // only recorded if transaction traces are enabled
 	GasUsed            uint64
 	StateReads         uint64
 }
@@ -39,6 +41,10 @@ func (cr *ComputationResult) AddTransactionResult(inp *flow.TransactionResult) {
 	cr.TransactionResults = append(cr.TransactionResults, *inp)
 }
 
+func (cr *ComputationResult) AddTransactionTrace(inp *flow.TransactionTrace) {
+	cr.TransactionTraces = append(cr.TransactionTraces, *inp)
+}
+
 func (cr *ComputationResult) AddGasUsed(inp uint64) {
 	cr.GasUsed += inp
 }
@@ -46,3 +52,12 @@ func (cr *ComputationResult) AddGasUsed(inp uint64) {
 func (cr *ComputationResult) AddStateSnapshot(inp *delta.SpockSnapshot) {
 	cr.StateSnapshots = append(cr.StateSnapshots, inp)
 }
+
+// DryRunResult is the outcome of executing a transaction without committing its changes.
+type DryRunResult struct {
+	ComputationUsed uint64
+	Events          []flow.Event
+	StorageDeltas   map[flow.Address]int64 // the change of the storage used by each account the transaction wrote to
+	FeeEstimate     uint64                 // the fee deducted from the payer, in the smallest unit of FLOW
+	Err             errors.Error           // the error the transaction would fail with, nil if it would succeed
+}
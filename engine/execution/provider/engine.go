@@ -35,6 +35,7 @@ type Engine struct {
 	receiptCon         network.Conduit
 	state              protocol.State
 	execState          state.ReadOnlyExecutionState
+	executionDatas     storage.ExecutionDatas // serves the execution data of the blocks along with their chunk data packs
 	me                 module.Local
 	chunksConduit      network.Conduit
 	metrics            module.ExecutionMetrics
@@ -48,6 +49,7 @@ func New(
 	state protocol.State,
 	me module.Local,
 	execState state.ReadOnlyExecutionState,
+	executionDatas storage.ExecutionDatas,
 	metrics module.ExecutionMetrics,
 	checkStakedAtBlock func(blockID flow.Identifier) (bool, error),
 ) (*Engine, error) {
@@ -61,6 +63,7 @@ func New(
 		state:              state,
 		me:                 me,
 		execState:          execState,
+		executionDatas:     executionDatas,
 		metrics:            metrics,
 		checkStakedAtBlock: checkStakedAtBlock,
 	}
@@ -164,7 +167,7 @@ func (e *Engine) onChunkDataRequest(
 		return fmt.Errorf("could not retrieve chunk ID (%s): %w", originID, err)
 	}
 
-	origin, err := e.ensureStaked(cdp.ChunkID, originID)
+	blockID, origin, err := e.ensureStaked(cdp.ChunkID, originID)
 	if err != nil {
 		return err
 	}
@@ -179,10 +182,18 @@ func (e *Engine) onChunkDataRequest(
 		collection = *coll
 	}
 
+	// the execution data of the block is sent along, so that verifiers check the chunk against the execution data
+	// committed in the execution result, it is not known for blocks executed before it was published
+	executionData, err := e.executionDatas.ByBlockID(blockID)
+	if err != nil && !errors.Is(err, storage.ErrNotFound) {
+		return fmt.Errorf("cannot retrieve execution data of block %x for chunk %x: %w", blockID, cdp.ChunkID, err)
+	}
+
 	response := &messages.ChunkDataResponse{
 		ChunkDataPack: *cdp,
 		Nonce:         rand.Uint64(),
 		Collection:    collection,
+		ExecutionData: executionData,
 	}
 
 	// sends requested chunk data pack to the requester
@@ -198,35 +209,35 @@ func (e *Engine) onChunkDataRequest(
 	return nil
 }
 
-func (e *Engine) ensureStaked(chunkID flow.Identifier, originID flow.Identifier) (*flow.Identity, error) {
+func (e *Engine) ensureStaked(chunkID flow.Identifier, originID flow.Identifier) (flow.Identifier, *flow.Identity, error) {
 
 	blockID, err := e.execState.GetBlockIDByChunkID(chunkID)
 	if err != nil {
-		return nil, engine.NewInvalidInputErrorf("cannot find blockID corresponding to chunk data pack: %w", err)
+		return flow.ZeroID, nil, engine.NewInvalidInputErrorf("cannot find blockID corresponding to chunk data pack: %w", err)
 	}
 
 	stakedAt, err := e.checkStakedAtBlock(blockID)
 	if err != nil {
-		return nil, engine.NewInvalidInputErrorf("cannot check block staking status: %w", err)
+		return flow.ZeroID, nil, engine.NewInvalidInputErrorf("cannot check block staking status: %w", err)
 	}
 	if !stakedAt {
-		return nil, engine.NewInvalidInputErrorf("this node is not staked at the block (%s) corresponding to chunk data pack (%s)", blockID.String(), chunkID.String())
+		return flow.ZeroID, nil, engine.NewInvalidInputErrorf("this node is not staked at the block (%s) corresponding to chunk data pack (%s)", blockID.String(), chunkID.String())
 	}
 
 	origin, err := e.state.AtBlockID(blockID).Identity(originID)
 	if err != nil {
-		return nil, engine.NewInvalidInputErrorf("invalid origin id (%s): %w", origin, err)
+		return flow.ZeroID, nil, engine.NewInvalidInputErrorf("invalid origin id (%s): %w", origin, err)
 	}
 
 	// only verifier nodes are allowed to request chunk data packs
 	if origin.Role != flow.RoleVerification {
-		return nil, engine.NewInvalidInputErrorf("invalid role for receiving collection: %s", origin.Role)
+		return flow.ZeroID, nil, engine.NewInvalidInputErrorf("invalid role for receiving collection: %s", origin.Role)
 	}
 
 	if origin.Stake == 0 {
-		return nil, engine.NewInvalidInputErrorf("node %s is not staked at the block (%s) corresponding to chunk data pack (%s)", originID, blockID.String(), chunkID.String())
+		return flow.ZeroID, nil, engine.NewInvalidInputErrorf("node %s is not staked at the block (%s) corresponding to chunk data pack (%s)", originID, blockID.String(), chunkID.String())
 	}
-	return origin, nil
+	return blockID, origin, nil
 }
 
 func (e *Engine) BroadcastExecutionReceipt(ctx context.Context, receipt *flow.ExecutionReceipt) error {
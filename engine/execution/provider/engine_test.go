@@ -17,6 +17,8 @@ import (
 	"github.com/onflow/flow-go/network/mocknetwork"
 	"github.com/onflow/flow-go/state/protocol"
 	mockprotocol "github.com/onflow/flow-go/state/protocol/mock"
+	"github.com/onflow/flow-go/storage"
+	mockstorage "github.com/onflow/flow-go/storage/mock"
 	"github.com/onflow/flow-go/utils/unittest"
 )
 
@@ -162,8 +164,9 @@ func TestProviderEngine_onChunkDataRequest(t *testing.T) {
 		con := new(mocknetwork.Conduit)
 
 		execState := new(state.ExecutionState)
+		executionDatas := new(mockstorage.ExecutionDatas)
 
-		e := Engine{state: ps, chunksConduit: con, execState: execState, metrics: metrics.NewNoopCollector(), checkStakedAtBlock: func(_ flow.Identifier) (bool, error) { return true, nil }}
+		e := Engine{state: ps, chunksConduit: con, execState: execState, executionDatas: executionDatas, metrics: metrics.NewNoopCollector(), checkStakedAtBlock: func(_ flow.Identifier) (bool, error) { return true, nil }}
 
 		originIdentity := unittest.IdentityFixture(unittest.WithRole(flow.RoleVerification))
 
@@ -173,6 +176,10 @@ func TestProviderEngine_onChunkDataRequest(t *testing.T) {
 		chunkDataPack.CollectionID = collectionID
 		collection := unittest.CollectionFixture(1)
 		blockID := unittest.IdentifierFixture()
+		executionData := &flow.ExecutionData{
+			BlockID:               blockID,
+			ChunkExecutionDataIDs: []flow.Identifier{unittest.IdentifierFixture()},
+		}
 
 		ps.On("AtBlockID", blockID).Return(ss)
 		ss.On("Identity", originIdentity.NodeID).Return(originIdentity, nil)
@@ -183,8 +190,10 @@ func TestProviderEngine_onChunkDataRequest(t *testing.T) {
 
 				actualChunkID := res.ChunkDataPack.ChunkID
 				assert.Equal(t, chunkID, actualChunkID)
+				assert.Equal(t, executionData, res.ExecutionData)
 			}).
 			Return(nil)
+		executionDatas.On("ByBlockID", blockID).Return(executionData, nil)
 
 		execState.
 			On("GetBlockIDByChunkID", chunkID).
@@ -207,6 +216,7 @@ func TestProviderEngine_onChunkDataRequest(t *testing.T) {
 		ss.AssertExpectations(t)
 		con.AssertExpectations(t)
 		execState.AssertExpectations(t)
+		executionDatas.AssertExpectations(t)
 	})
 
 	t.Run("reply to chunk data pack request only when staked", func(t *testing.T) {
@@ -216,6 +226,7 @@ func TestProviderEngine_onChunkDataRequest(t *testing.T) {
 		con := new(mocknetwork.Conduit)
 
 		execState := new(state.ExecutionState)
+		executionDatas := new(mockstorage.ExecutionDatas)
 
 		currentStakedState := true
 		checkStakedAtBlock := func(_ flow.Identifier) (bool, error) { return currentStakedState, nil }
@@ -224,6 +235,7 @@ func TestProviderEngine_onChunkDataRequest(t *testing.T) {
 			state:              ps,
 			chunksConduit:      con,
 			execState:          execState,
+			executionDatas:     executionDatas,
 			metrics:            metrics.NewNoopCollector(),
 			checkStakedAtBlock: checkStakedAtBlock,
 		}
@@ -249,8 +261,10 @@ func TestProviderEngine_onChunkDataRequest(t *testing.T) {
 
 				actualChunkID := res.ChunkDataPack.ChunkID
 				assert.Equal(t, chunkID, actualChunkID)
+				assert.Nil(t, res.ExecutionData)
 			}).
 			Return(nil).Once()
+		executionDatas.On("ByBlockID", blockID).Return(nil, storage.ErrNotFound).Once()
 
 		execState.
 			On("ChunkDataPackByChunkID", mock.Anything, chunkID).
@@ -0,0 +1,280 @@
+package pruner
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/rs/zerolog"
+
+	"github.com/onflow/flow-go/engine"
+	"github.com/onflow/flow-go/ledger/complete/wal"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module"
+	"github.com/onflow/flow-go/module/metrics"
+	"github.com/onflow/flow-go/storage"
+	"github.com/onflow/flow-go/storage/badger/operation"
+)
+
+// Engine periodically prunes the execution data of the executed blocks that are more than a number of heights
+// below the last sealed height, and the WAL segments that are covered by the checkpoints of the ledger.
+//
+// Only data owned by the execution node is pruned: the chunk data packs, events, transaction results, state
+// interactions, transaction traces and published execution data. The state commitments, the execution results and
+// the execution receipts of the blocks are kept, as the receipts and their results are referenced by the payloads
+// of the blocks incorporating them, and the node can still tell the state it committed to at each height. Pruned
+// data is removed from the database directly, so the caches of the storage layer might still serve it until evicted.
+type Engine struct {
+	unit         *engine.Unit
+	log          zerolog.Logger
+	db           *badger.DB
+	metrics      module.ExecutionPruningMetrics
+	retention    uint64            // number of sealed heights whose execution data is kept, 0 to keep all
+	interval     time.Duration     // interval between pruning runs
+	checkpointer *wal.Checkpointer // nil if the WAL segments are not pruned
+}
+
+// New creates a new pruner keeping the execution data of the last retention sealed heights, or of all heights if
+// retention is 0. WAL segments are removed with the given checkpointer, or kept if it is nil.
+func New(
+	log zerolog.Logger,
+	db *badger.DB,
+	metrics module.ExecutionPruningMetrics,
+	retention uint64,
+	interval time.Duration,
+	checkpointer *wal.Checkpointer,
+) *Engine {
+	return &Engine{
+		unit:         engine.NewUnit(),
+		log:          log.With().Str("engine", "pruner").Logger(),
+		db:           db,
+		metrics:      metrics,
+		retention:    retention,
+		interval:     interval,
+		checkpointer: checkpointer,
+	}
+}
+
+// Ready returns a ready channel that is closed once the engine has fully started. The first pruning run starts
+// right away.
+func (e *Engine) Ready() <-chan struct{} {
+	e.unit.LaunchPeriodically(e.prune, e.interval, 0)
+	return e.unit.Ready()
+}
+
+// Done returns a done channel that is closed once the engine has fully stopped, after the current pruning run
+// finished pruning its current height.
+func (e *Engine) Done() <-chan struct{} {
+	return e.unit.Done()
+}
+
+func (e *Engine) prune() {
+	if e.retention > 0 {
+		e.pruneExecutionData()
+	}
+
+	if e.checkpointer != nil {
+		_, err := e.PruneWAL()
+		if err != nil {
+			e.log.Error().Err(err).Msg("could not prune WAL segments")
+		}
+	}
+}
+
+func (e *Engine) pruneExecutionData() {
+	threshold, err := e.Threshold()
+	if err != nil {
+		e.log.Error().Err(err).Msg("could not get pruning threshold")
+		return
+	}
+
+	err = e.PruneToHeight(threshold)
+	if err != nil {
+		e.log.Error().Err(err).Uint64("threshold", threshold).Msg("could not prune execution data")
+	}
+}
+
+// Threshold returns the height up to which execution data can be pruned, which is retention heights below the
+// lower of the last sealed height and the height of the last executed block. Blocks that are not executed yet are
+// never pruned.
+func (e *Engine) Threshold() (uint64, error) {
+	var sealed uint64
+	err := e.db.View(operation.RetrieveSealedHeight(&sealed))
+	if err != nil {
+		return 0, fmt.Errorf("could not retrieve sealed height: %w", err)
+	}
+
+	var executedID flow.Identifier
+	err = e.db.View(operation.RetrieveExecutedBlock(&executedID))
+	if err != nil {
+		return 0, fmt.Errorf("could not retrieve last executed block: %w", err)
+	}
+
+	var executed flow.Header
+	err = e.db.View(operation.RetrieveHeader(executedID, &executed))
+	if err != nil {
+		return 0, fmt.Errorf("could not retrieve header of last executed block %v: %w", executedID, err)
+	}
+
+	height := sealed
+	if executed.Height < height {
+		height = executed.Height
+	}
+
+	if height < e.retention {
+		return 0, nil
+	}
+	return height - e.retention, nil
+}
+
+// PruneToHeight prunes the execution data of the finalized blocks from the last pruned height up to and including
+// the given height. The root block is never pruned. Each height is pruned in its own transactions, and the pruned
+// height is only moved to it once it is fully pruned, so pruning can be interrupted and resumed at any height.
+func (e *Engine) PruneToHeight(threshold uint64) error {
+	pruned, err := e.prunedHeight()
+	if err != nil {
+		return fmt.Errorf("could not get pruned height: %w", err)
+	}
+
+	if threshold <= pruned {
+		return nil
+	}
+
+	log := e.log.With().Uint64("from", pruned+1).Uint64("to", threshold).Logger()
+	log.Debug().Msg("pruning execution data")
+
+	var total uint64
+	for height := pruned + 1; height <= threshold; height++ {
+		select {
+		case <-e.unit.Quit():
+			log.Info().Uint64("pruned_height", height-1).Msg("pruning interrupted")
+			return nil
+		default:
+		}
+
+		var removed uint64
+		err := e.pruneHeight(height, &removed)
+		if err != nil {
+			return fmt.Errorf("could not prune height %d: %w", height, err)
+		}
+
+		total += removed
+		e.metrics.ExecutionDataPruned(height)
+		e.metrics.SpaceReclaimed(metrics.PrunedKindExecutionData, removed)
+	}
+
+	log.Info().Uint64("removed_bytes", total).Msg("execution data pruned")
+
+	return nil
+}
+
+// prunedHeight returns the height up to which execution data was pruned. If nothing was pruned yet, this is the
+// root height, which is stored as the pruned height.
+func (e *Engine) prunedHeight() (uint64, error) {
+	var pruned uint64
+	err := e.db.View(operation.RetrieveExecutionPrunedHeight(&pruned))
+	if err == nil {
+		return pruned, nil
+	}
+	if !errors.Is(err, storage.ErrNotFound) {
+		return 0, fmt.Errorf("could not retrieve pruned height: %w", err)
+	}
+
+	err = e.db.View(operation.RetrieveRootHeight(&pruned))
+	if err != nil {
+		return 0, fmt.Errorf("could not retrieve root height: %w", err)
+	}
+
+	err = operation.RetryOnConflict(e.db.Update, operation.InsertExecutionPrunedHeight(pruned))
+	if err != nil {
+		return 0, fmt.Errorf("could not insert pruned height: %w", err)
+	}
+
+	return pruned, nil
+}
+
+// pruneHeight prunes the execution data of the finalized block at the given height and moves the pruned height to
+// it. The execution data is removed in as many transactions as needed to stay within the transaction size limit of
+// the database, and the pruned height is only moved once all of it is removed. The size of the removed data is
+// stored in removed.
+func (e *Engine) pruneHeight(height uint64, removed *uint64) error {
+	var keys [][]byte
+	err := e.db.View(e.lookupKeys(height, &keys, removed))
+	if err != nil {
+		return fmt.Errorf("could not look up execution data: %w", err)
+	}
+
+	for len(keys) > 0 {
+		var n int
+		err = operation.RetryOnConflict(e.db.Update, operation.RemoveKeys(keys, &n))
+		if err != nil {
+			return fmt.Errorf("could not remove execution data: %w", err)
+		}
+		keys = keys[n:]
+	}
+
+	err = operation.RetryOnConflict(e.db.Update, operation.UpdateExecutionPrunedHeight(height))
+	if err != nil {
+		return fmt.Errorf("could not update pruned height: %w", err)
+	}
+
+	return nil
+}
+
+// lookupKeys looks up the keys of the execution data of the finalized block at the given height, and adds their size
+// to size. Blocks without an execution result have no execution data to prune.
+func (e *Engine) lookupKeys(height uint64, keys *[][]byte, size *uint64) func(*badger.Txn) error {
+	return func(tx *badger.Txn) error {
+		var blockID flow.Identifier
+		err := operation.LookupBlockHeight(height, &blockID)(tx)
+		if err != nil {
+			return fmt.Errorf("could not look up block at height: %w", err)
+		}
+
+		var resultID flow.Identifier
+		err = operation.LookupExecutionResult(blockID, &resultID)(tx)
+		if errors.Is(err, storage.ErrNotFound) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("could not look up execution result of block %v: %w", blockID, err)
+		}
+
+		var result flow.ExecutionResult
+		err = operation.RetrieveExecutionResult(resultID, &result)(tx)
+		if err != nil {
+			return fmt.Errorf("could not retrieve execution result %v: %w", resultID, err)
+		}
+
+		chunkIDs := make([]flow.Identifier, 0, len(result.Chunks))
+		for _, chunk := range result.Chunks {
+			chunkIDs = append(chunkIDs, chunk.ID())
+		}
+
+		err = operation.LookupExecutionDataKeys(blockID, chunkIDs, keys, size)(tx)
+		if err != nil {
+			return fmt.Errorf("could not look up execution data keys of block %v: %w", blockID, err)
+		}
+
+		return nil
+	}
+}
+
+// PruneWAL removes the WAL segments covered by the oldest checkpoint, and returns the number of bytes removed.
+func (e *Engine) PruneWAL() (uint64, error) {
+	if e.checkpointer == nil {
+		return 0, nil
+	}
+
+	removed, err := e.checkpointer.RemoveCheckpointedSegments()
+	if removed > 0 {
+		e.metrics.SpaceReclaimed(metrics.PrunedKindWALSegments, removed)
+		e.log.Info().Uint64("removed_bytes", removed).Msg("WAL segments pruned")
+	}
+	if err != nil {
+		return removed, fmt.Errorf("could not remove checkpointed segments: %w", err)
+	}
+
+	return removed, nil
+}
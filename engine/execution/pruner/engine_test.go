@@ -0,0 +1,251 @@
+package pruner
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module/metrics"
+	module "github.com/onflow/flow-go/module/mock"
+	"github.com/onflow/flow-go/storage"
+	bstorage "github.com/onflow/flow-go/storage/badger"
+	"github.com/onflow/flow-go/storage/badger/operation"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+// insertChain stores a finalized chain of executed blocks from the root height 0 up to the given height, and
+// returns their execution results by height.
+func insertChain(t *testing.T, db *badger.DB, height uint64) []*flow.ExecutionResult {
+	results := make([]*flow.ExecutionResult, 0, height+1)
+
+	err := db.Update(func(tx *badger.Txn) error {
+		parent := unittest.BlockHeaderFixture()
+		parent.Height = 0
+		require.NoError(t, operation.InsertRootHeight(0)(tx))
+
+		for h := uint64(0); h <= height; h++ {
+			header := unittest.BlockHeaderWithParentFixture(&parent)
+			header.Height = h
+			block := &flow.Block{Header: &header}
+			blockID := block.ID()
+
+			result := unittest.ExecutionResultFixture(unittest.WithBlock(block))
+			require.NoError(t, operation.InsertHeader(blockID, &header)(tx))
+			require.NoError(t, operation.IndexBlockHeight(h, blockID)(tx))
+			require.NoError(t, operation.InsertExecutionResult(result)(tx))
+			require.NoError(t, operation.IndexExecutionResult(blockID, result.ID())(tx))
+			require.NoError(t, operation.IndexStateCommitment(blockID, unittest.StateCommitmentFixture())(tx))
+			for _, chunk := range result.Chunks {
+				require.NoError(t, operation.InsertChunkDataPack(unittest.ChunkDataPackFixture(chunk.ID()))(tx))
+			}
+
+			results = append(results, result)
+			parent = header
+		}
+
+		return operation.InsertExecutedBlock(parent.ID())(tx)
+	})
+	require.NoError(t, err)
+
+	return results
+}
+
+// isPruned returns whether the execution data of the given result was pruned.
+func isPruned(t *testing.T, db *badger.DB, result *flow.ExecutionResult) bool {
+	pruned := false
+	for i, chunk := range result.Chunks {
+		var pack flow.ChunkDataPack
+		err := db.View(operation.RetrieveChunkDataPack(chunk.ID(), &pack))
+		if i == 0 {
+			pruned = errors.Is(err, storage.ErrNotFound)
+		}
+		if pruned {
+			require.True(t, errors.Is(err, storage.ErrNotFound))
+		} else {
+			require.NoError(t, err)
+		}
+	}
+
+	// the execution result is kept either way
+	var resultID flow.Identifier
+	err := db.View(operation.LookupExecutionResult(result.BlockID, &resultID))
+	require.NoError(t, err)
+
+	return pruned
+}
+
+func TestPruneExecutionData(t *testing.T) {
+	unittest.RunWithBadgerDB(t, func(db *badger.DB) {
+		results := insertChain(t, db, 10)
+
+		// the executed height is below the sealed height, so the retention counts from the executed height
+		require.NoError(t, db.Update(operation.InsertSealedHeight(12)))
+
+		collector := new(module.ExecutionPruningMetrics)
+		collector.On("ExecutionDataPruned", mock.Anything)
+		collector.On("SpaceReclaimed", metrics.PrunedKindExecutionData, mock.Anything)
+
+		e := New(zerolog.Nop(), db, collector, 4, time.Minute, nil)
+
+		threshold, err := e.Threshold()
+		require.NoError(t, err)
+		assert.Equal(t, uint64(6), threshold)
+
+		err = e.PruneToHeight(threshold)
+		require.NoError(t, err)
+
+		// the root block is never pruned
+		assert.False(t, isPruned(t, db, results[0]))
+		for h := 1; h <= 6; h++ {
+			assert.True(t, isPruned(t, db, results[h]), "height %d", h)
+		}
+		for h := 7; h <= 10; h++ {
+			assert.False(t, isPruned(t, db, results[h]), "height %d", h)
+		}
+
+		var pruned uint64
+		require.NoError(t, db.View(operation.RetrieveExecutionPrunedHeight(&pruned)))
+		assert.Equal(t, uint64(6), pruned)
+		collector.AssertCalled(t, "ExecutionDataPruned", uint64(6))
+		collector.AssertNumberOfCalls(t, "SpaceReclaimed", 6)
+
+		// pruning resumes from the pruned height
+		err = e.PruneToHeight(8)
+		require.NoError(t, err)
+		assert.True(t, isPruned(t, db, results[8]))
+		assert.False(t, isPruned(t, db, results[9]))
+		collector.AssertNumberOfCalls(t, "SpaceReclaimed", 8)
+
+		// pruning below the pruned height is a no-op
+		err = e.PruneToHeight(3)
+		require.NoError(t, err)
+		collector.AssertNumberOfCalls(t, "SpaceReclaimed", 8)
+	})
+}
+
+// TestPruneExecutionDataKeepsReceipts tests that the receipts of pruned blocks can still be read.
+func TestPruneExecutionDataKeepsReceipts(t *testing.T) {
+	unittest.RunWithBadgerDB(t, func(db *badger.DB) {
+		results := insertChain(t, db, 10)
+		require.NoError(t, db.Update(operation.InsertSealedHeight(10)))
+
+		collector := metrics.NewNoopCollector()
+		receipts := bstorage.NewExecutionReceipts(collector, db, bstorage.NewExecutionResults(collector, db))
+		myReceipts := bstorage.NewMyExecutionReceipts(collector, db, receipts)
+
+		receipt := unittest.ExecutionReceiptFixture(unittest.WithResult(results[2]))
+		require.NoError(t, myReceipts.StoreMyReceipt(receipt))
+
+		e := New(zerolog.Nop(), db, collector, 4, time.Minute, nil)
+		err := e.PruneToHeight(6)
+		require.NoError(t, err)
+		require.True(t, isPruned(t, db, results[2]))
+
+		// read the receipt from a fresh storage, so that it is not served from the caches
+		receipts = bstorage.NewExecutionReceipts(collector, db, bstorage.NewExecutionResults(collector, db))
+		myReceipts = bstorage.NewMyExecutionReceipts(collector, db, receipts)
+
+		mine, err := myReceipts.MyReceipt(results[2].BlockID)
+		require.NoError(t, err)
+		assert.Equal(t, receipt.ID(), mine.ID())
+
+		byBlock, err := receipts.ByBlockID(results[2].BlockID)
+		require.NoError(t, err)
+		require.Len(t, byBlock, 1)
+		assert.Equal(t, receipt.ID(), byBlock[0].ID())
+	})
+}
+
+func TestPruneExecutionDataSealedHeight(t *testing.T) {
+	unittest.RunWithBadgerDB(t, func(db *badger.DB) {
+		insertChain(t, db, 10)
+		require.NoError(t, db.Update(operation.InsertSealedHeight(8)))
+
+		e := New(zerolog.Nop(), db, metrics.NewNoopCollector(), 4, time.Minute, nil)
+
+		// the retention counts from the sealed height, if the sealed blocks are all executed
+		threshold, err := e.Threshold()
+		require.NoError(t, err)
+		assert.Equal(t, uint64(4), threshold)
+
+		// nothing is pruned while there are fewer heights than the retention
+		e = New(zerolog.Nop(), db, metrics.NewNoopCollector(), 20, time.Minute, nil)
+		threshold, err = e.Threshold()
+		require.NoError(t, err)
+		assert.Equal(t, uint64(0), threshold)
+	})
+}
+
+// TestPruneExecutionDataTxnTooBig tests that a block whose execution data can not be removed in a single transaction
+// is pruned in several transactions.
+func TestPruneExecutionDataTxnTooBig(t *testing.T) {
+	unittest.RunWithTempDir(t, func(dir string) {
+		// a small table size bounds the size of the transactions
+		opts := badger.DefaultOptions(dir).WithMaxTableSize(1 << 20).WithKeepL0InMemory(true).WithLogger(nil)
+		db, err := badger.Open(opts)
+		require.NoError(t, err)
+		defer db.Close()
+
+		results := insertChain(t, db, 10)
+		require.NoError(t, db.Update(operation.InsertSealedHeight(10)))
+
+		// the events of the large block are inserted in several transactions as well
+		large := results[3].BlockID
+		txID := unittest.IdentifierFixture()
+		for i := uint32(0); i < 10; i++ {
+			err = db.Update(func(tx *badger.Txn) error {
+				for j := uint32(0); j < 500; j++ {
+					event := unittest.EventFixture(flow.EventAccountCreated, i, j, txID)
+					err := operation.InsertEvent(large, event)(tx)
+					if err != nil {
+						return err
+					}
+				}
+				return nil
+			})
+			require.NoError(t, err)
+		}
+
+		// removing the execution data of the large block in a single transaction fails
+		var keys [][]byte
+		var size uint64
+		chunkIDs := make([]flow.Identifier, 0, len(results[3].Chunks))
+		for _, chunk := range results[3].Chunks {
+			chunkIDs = append(chunkIDs, chunk.ID())
+		}
+		require.NoError(t, db.View(operation.LookupExecutionDataKeys(large, chunkIDs, &keys, &size)))
+		err = db.Update(func(tx *badger.Txn) error {
+			for _, key := range keys {
+				err := tx.Delete(key)
+				if err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		require.True(t, errors.Is(err, badger.ErrTxnTooBig))
+
+		e := New(zerolog.Nop(), db, metrics.NewNoopCollector(), 4, time.Minute, nil)
+		err = e.PruneToHeight(6)
+		require.NoError(t, err)
+
+		for h := 1; h <= 6; h++ {
+			assert.True(t, isPruned(t, db, results[h]), "height %d", h)
+		}
+
+		var events []flow.Event
+		require.NoError(t, db.View(operation.LookupEventsByBlockID(large, &events)))
+		assert.Empty(t, events)
+
+		var pruned uint64
+		require.NoError(t, db.View(operation.RetrieveExecutionPrunedHeight(&pruned)))
+		assert.Equal(t, uint64(6), pruned)
+	})
+}
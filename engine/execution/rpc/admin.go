@@ -0,0 +1,49 @@
+package rpc
+
+import (
+	"context"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	exeapi "github.com/onflow/flow-go/engine/execution"
+	"github.com/onflow/flow-go/engine/execution/ingestion"
+)
+
+// adminHandler implements the admin endpoints of the execution node.
+type adminHandler struct {
+	engine ingestion.IngestAdmin
+}
+
+var _ exeapi.ExecutionAdminAPIServer = &adminHandler{}
+
+// SetStopHeight sets the height to stop the execution after, and returns the resulting setting.
+func (h *adminHandler) SetStopHeight(
+	_ context.Context,
+	req *exeapi.SetStopHeightRequest,
+) (*exeapi.StopHeightResponse, error) {
+
+	err := h.engine.SetStopHeight(req.GetHeight(), req.GetCrash())
+	if err != nil {
+		return nil, status.Errorf(codes.FailedPrecondition, "failed to set stop height: %v", err)
+	}
+
+	return h.stopHeight(), nil
+}
+
+// GetStopHeight returns the height to stop the execution after, and whether the execution is stopped.
+func (h *adminHandler) GetStopHeight(
+	_ context.Context,
+	_ *exeapi.GetStopHeightRequest,
+) (*exeapi.StopHeightResponse, error) {
+	return h.stopHeight(), nil
+}
+
+func (h *adminHandler) stopHeight() *exeapi.StopHeightResponse {
+	height, crash, stopped := h.engine.StopHeight()
+	return &exeapi.StopHeightResponse{
+		Height:  height,
+		Crash:   crash,
+		Stopped: stopped,
+	}
+}
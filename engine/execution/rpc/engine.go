@@ -1,38 +1,49 @@
 package rpc
 
 import (
+	"bytes"
 	"context"
 	"errors"
+	"fmt"
 	"net"
+	"sort"
 
 	"github.com/rs/zerolog"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 
+	"github.com/onflow/flow/protobuf/go/flow/entities"
 	"github.com/onflow/flow/protobuf/go/flow/execution"
 
 	"github.com/onflow/flow-go/engine"
 	"github.com/onflow/flow-go/engine/common/rpc/convert"
+	exeapi "github.com/onflow/flow-go/engine/execution"
 	"github.com/onflow/flow-go/engine/execution/ingestion"
+	fvmerrors "github.com/onflow/flow-go/fvm/errors"
 	"github.com/onflow/flow-go/model/flow"
 	"github.com/onflow/flow-go/storage"
 	grpcutils "github.com/onflow/flow-go/utils/grpc"
 )
 
+// maxRegisterProofSize is the max number of registers whose proof can be requested at once.
+const maxRegisterProofSize = 1000
+
 // Config defines the configurable options for the gRPC server.
 type Config struct {
-	ListenAddr string
-	MaxMsgSize int // In bytes
+	ListenAddr      string
+	AdminListenAddr string // the admin endpoints are not served if empty
+	MaxMsgSize      int    // In bytes
 }
 
 // Engine implements a gRPC server with a simplified version of the Observation API.
 type Engine struct {
-	unit    *engine.Unit
-	log     zerolog.Logger
-	handler *handler     // the gRPC service implementation
-	server  *grpc.Server // the gRPC server
-	config  Config
+	unit        *engine.Unit
+	log         zerolog.Logger
+	handler     *handler     // the gRPC service implementation
+	server      *grpc.Server // the gRPC server
+	adminServer *grpc.Server // the gRPC server of the admin endpoints, nil if not served
+	config      Config
 }
 
 // New returns a new RPC engine.
@@ -44,6 +55,8 @@ func New(
 	events storage.Events,
 	exeResults storage.ExecutionResults,
 	txResults storage.TransactionResults,
+	txTraces storage.TransactionTraces,
+	executionDatas storage.ExecutionDatas,
 	chainID flow.ChainID) *Engine {
 	log = log.With().Str("engine", "rpc").Logger()
 
@@ -61,6 +74,8 @@ func New(
 			events:             events,
 			exeResults:         exeResults,
 			transactionResults: txResults,
+			transactionTraces:  txTraces,
+			executionDatas:     executionDatas,
 		},
 		server: grpc.NewServer(
 			grpc.MaxRecvMsgSize(config.MaxMsgSize),
@@ -70,6 +85,20 @@ func New(
 	}
 
 	execution.RegisterExecutionAPIServer(eng.server, eng.handler)
+	// the following services are declared by hand in the execution engine package, like the unpublished services of
+	// the Access API
+	exeapi.RegisterExecutionBatchAPIServer(eng.server, eng.handler)
+	exeapi.RegisterExecutionAccountAPIServer(eng.server, eng.handler)
+	exeapi.RegisterExecutionDryRunAPIServer(eng.server, eng.handler)
+	exeapi.RegisterExecutionTraceAPIServer(eng.server, eng.handler)
+	exeapi.RegisterExecutionDataAPIServer(eng.server, eng.handler)
+
+	// the admin endpoints control the node, so they are served on their own address, which is only reachable by the
+	// operator of the node
+	if config.AdminListenAddr != "" {
+		eng.adminServer = grpc.NewServer()
+		exeapi.RegisterExecutionAdminAPIServer(eng.adminServer, &adminHandler{engine: e})
+	}
 
 	return eng
 }
@@ -79,13 +108,21 @@ func New(
 // started.
 func (e *Engine) Ready() <-chan struct{} {
 	e.unit.Launch(e.serve)
+	if e.adminServer != nil {
+		e.unit.Launch(e.serveAdmin)
+	}
 	return e.unit.Ready()
 }
 
 // Done returns a done channel that is closed once the engine has fully stopped.
 // It sends a signal to stop the gRPC server, then closes the channel.
 func (e *Engine) Done() <-chan struct{} {
-	return e.unit.Done(e.server.GracefulStop)
+	return e.unit.Done(func() {
+		e.server.GracefulStop()
+		if e.adminServer != nil {
+			e.adminServer.GracefulStop()
+		}
+	})
 }
 
 // serve starts the gRPC server .
@@ -106,6 +143,22 @@ func (e *Engine) serve() {
 	}
 }
 
+// serveAdmin starts the gRPC server of the admin endpoints.
+func (e *Engine) serveAdmin() {
+	e.log.Info().Msgf("starting admin server on address %s", e.config.AdminListenAddr)
+
+	l, err := net.Listen("tcp", e.config.AdminListenAddr)
+	if err != nil {
+		e.log.Err(err).Msg("failed to start admin server")
+		return
+	}
+
+	err = e.adminServer.Serve(l)
+	if err != nil {
+		e.log.Err(err).Msg("fatal error in admin server")
+	}
+}
+
 // handler implements a subset of the Observation API.
 type handler struct {
 	engine             ingestion.IngestRPC
@@ -114,6 +167,8 @@ type handler struct {
 	events             storage.Events
 	exeResults         storage.ExecutionResults
 	transactionResults storage.TransactionResults
+	transactionTraces  storage.TransactionTraces
+	executionDatas     storage.ExecutionDatas
 }
 
 var _ execution.ExecutionAPIServer = &handler{}
@@ -298,3 +353,488 @@ func (h *handler) GetAccountAtBlockID(
 	return res, nil
 
 }
+
+// GetAccountsAtBlockID looks up a batch of accounts at the given block. All accounts are read from the same view of
+// the execution state, a failed lookup is reported in the result of the account and does not fail the request.
+func (h *handler) GetAccountsAtBlockID(
+	ctx context.Context,
+	req *exeapi.GetAccountsAtBlockIDRequest,
+) (*exeapi.AccountsResponse, error) {
+
+	blockID, err := convert.BlockID(req.GetBlockId())
+	if err != nil {
+		return nil, err
+	}
+
+	addresses := make([]flow.Address, len(req.GetAddresses()))
+	for i, address := range req.GetAddresses() {
+		addresses[i], err = convert.Address(address, h.chain.Chain())
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	accounts, errs, err := h.engine.GetAccounts(ctx, addresses, blockID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get accounts: %v", err)
+	}
+
+	results := make([]*exeapi.AccountResult, len(addresses))
+	for i, address := range addresses {
+		if errs[i] != nil {
+			results[i] = &exeapi.AccountResult{ErrorMessage: fmt.Sprintf("failed to get account: %v", errs[i])}
+			continue
+		}
+
+		if accounts[i] == nil {
+			results[i] = &exeapi.AccountResult{ErrorMessage: fmt.Sprintf("account with address %s does not exist", address)}
+			continue
+		}
+
+		account, err := convert.AccountToMessage(accounts[i])
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to convert account to message: %v", err)
+		}
+		results[i] = &exeapi.AccountResult{Account: account}
+	}
+
+	return &exeapi.AccountsResponse{
+		Results: results,
+	}, nil
+}
+
+// ExecuteScriptsAtBlockID executes a batch of scripts at the given block. All scripts are executed against the same
+// view of the execution state, a failed script is reported in its result and does not fail the request.
+func (h *handler) ExecuteScriptsAtBlockID(
+	ctx context.Context,
+	req *exeapi.ExecuteScriptsAtBlockIDRequest,
+) (*exeapi.ScriptsResponse, error) {
+
+	blockID, err := convert.BlockID(req.GetBlockId())
+	if err != nil {
+		return nil, err
+	}
+
+	scripts := make([][]byte, len(req.GetScripts()))
+	arguments := make([][][]byte, len(req.GetScripts()))
+	for i, script := range req.GetScripts() {
+		scripts[i] = script.GetScript()
+		arguments[i] = script.GetArguments()
+	}
+
+	values, errs, err := h.engine.ExecuteScriptsAtBlockID(ctx, scripts, arguments, blockID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to execute scripts: %v", err)
+	}
+
+	results := make([]*exeapi.ScriptResult, len(scripts))
+	for i := range scripts {
+		if errs[i] != nil {
+			results[i] = &exeapi.ScriptResult{ErrorMessage: fmt.Sprintf("failed to execute script: %v", errs[i])}
+			continue
+		}
+		results[i] = &exeapi.ScriptResult{Value: values[i]}
+	}
+
+	return &exeapi.ScriptsResponse{
+		Results: results,
+	}, nil
+}
+
+// GetRegisterProofAtBlockID returns a batch proof of the requested registers against the state commitment of the given
+// block, which allows clients to read the register values without trusting the execution node.
+func (h *handler) GetRegisterProofAtBlockID(
+	ctx context.Context,
+	req *exeapi.GetRegisterProofAtBlockIDRequest,
+) (*exeapi.RegisterProofResponse, error) {
+
+	blockID, err := convert.BlockID(req.GetBlockId())
+	if err != nil {
+		return nil, err
+	}
+
+	if len(req.GetRegisters()) == 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "no registers given")
+	}
+	if len(req.GetRegisters()) > maxRegisterProofSize {
+		return nil, status.Errorf(codes.InvalidArgument, "requested registers (%d) exceeded maximum (%d)", len(req.GetRegisters()), maxRegisterProofSize)
+	}
+
+	registerIDs := make([]flow.RegisterID, len(req.GetRegisters()))
+	for i, register := range req.GetRegisters() {
+		registerIDs[i] = flow.NewRegisterID(
+			string(register.GetOwner()),
+			string(register.GetController()),
+			string(register.GetKey()),
+		)
+	}
+
+	proof, err := h.engine.GetRegisterProofAtBlockID(ctx, registerIDs, blockID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get register proof: %v", err)
+	}
+
+	return &exeapi.RegisterProofResponse{
+		Proof: proof,
+	}, nil
+}
+
+// GetTransactionResultsByBlockID returns the results of all transactions of the given block, including the system
+// transaction, with the events they emitted.
+func (h *handler) GetTransactionResultsByBlockID(
+	_ context.Context,
+	req *exeapi.GetTransactionResultsByBlockIDRequest,
+) (*exeapi.TransactionResultsResponse, error) {
+
+	blockID, err := convert.BlockID(req.GetBlockId())
+	if err != nil {
+		return nil, err
+	}
+
+	// check if block has been executed
+	_, err = h.exeResults.ByBlockID(blockID)
+	if errors.Is(err, storage.ErrNotFound) {
+		return nil, status.Errorf(codes.NotFound, "results for block ID %s does not exist", blockID)
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "results for block ID %s could not be retrieved", blockID)
+	}
+
+	txResults, err := h.transactionResults.ByBlockID(blockID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get transaction results: %v", err)
+	}
+
+	blockEvents, err := h.events.ByBlockID(blockID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get events for block: %v", err)
+	}
+	eventsByTx := make(map[flow.Identifier][]flow.Event)
+	for _, event := range blockEvents {
+		eventsByTx[event.TransactionID] = append(eventsByTx[event.TransactionID], event)
+	}
+
+	results := make([]*exeapi.TransactionResult, len(txResults))
+	for i, txResult := range txResults {
+		var statusCode uint32
+		if txResult.ErrorMessage != "" {
+			statusCode = 1 // same as GetTransactionResult, a status code of 1 indicates an error
+		}
+		txID := txResult.TransactionID
+		results[i] = &exeapi.TransactionResult{
+			TransactionId: txID[:],
+			StatusCode:    statusCode,
+			ErrorMessage:  txResult.ErrorMessage,
+			Events:        convert.EventsToMessages(eventsByTx[txResult.TransactionID]),
+		}
+	}
+
+	return &exeapi.TransactionResultsResponse{
+		Results: results,
+	}, nil
+}
+
+// GetAccountKeyAtIndex returns the key with the given index of an account at the given block.
+func (h *handler) GetAccountKeyAtIndex(
+	ctx context.Context,
+	req *exeapi.GetAccountKeyAtIndexRequest,
+) (*exeapi.AccountKeyResponse, error) {
+
+	blockID, address, err := h.accountRequest(req.GetBlockId(), req.GetAddress())
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := h.engine.GetAccountKeyAtIndex(ctx, address, req.GetIndex(), blockID)
+	if err != nil {
+		return nil, accountError(err, address)
+	}
+
+	message, err := convert.AccountKeyToMessage(*key)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to convert account key to message: %v", err)
+	}
+
+	return &exeapi.AccountKeyResponse{
+		AccountKey: message,
+	}, nil
+}
+
+// ListAccountKeys returns a page of the keys of an account at the given block.
+func (h *handler) ListAccountKeys(
+	ctx context.Context,
+	req *exeapi.ListAccountKeysRequest,
+) (*exeapi.AccountKeysResponse, error) {
+
+	blockID, address, err := h.accountRequest(req.GetBlockId(), req.GetAddress())
+	if err != nil {
+		return nil, err
+	}
+
+	if req.GetLimit() == 0 || req.GetLimit() > exeapi.MaxAccountKeysPageSize {
+		return nil, status.Errorf(codes.InvalidArgument, "limit must be between 1 and %d", exeapi.MaxAccountKeysPageSize)
+	}
+
+	keys, total, err := h.engine.ListAccountKeys(ctx, address, req.GetOffset(), req.GetLimit(), blockID)
+	if err != nil {
+		return nil, accountError(err, address)
+	}
+
+	resp := &exeapi.AccountKeysResponse{
+		TotalCount: total,
+	}
+	for _, key := range keys {
+		message, err := convert.AccountKeyToMessage(key)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to convert account key to message: %v", err)
+		}
+		resp.AccountKeys = append(resp.AccountKeys, message)
+	}
+
+	return resp, nil
+}
+
+// GetAccountContractNames returns the names of the contracts deployed to an account at the given block.
+func (h *handler) GetAccountContractNames(
+	ctx context.Context,
+	req *exeapi.GetAccountContractNamesRequest,
+) (*exeapi.AccountContractNamesResponse, error) {
+
+	blockID, address, err := h.accountRequest(req.GetBlockId(), req.GetAddress())
+	if err != nil {
+		return nil, err
+	}
+
+	names, err := h.engine.GetAccountContractNames(ctx, address, blockID)
+	if err != nil {
+		return nil, accountError(err, address)
+	}
+
+	return &exeapi.AccountContractNamesResponse{
+		Names: names,
+	}, nil
+}
+
+// GetAccountContract returns the code of a contract deployed to an account at the given block.
+func (h *handler) GetAccountContract(
+	ctx context.Context,
+	req *exeapi.GetAccountContractRequest,
+) (*exeapi.AccountContractResponse, error) {
+
+	blockID, address, err := h.accountRequest(req.GetBlockId(), req.GetAddress())
+	if err != nil {
+		return nil, err
+	}
+
+	code, err := h.engine.GetAccountContract(ctx, address, req.GetName(), blockID)
+	if err != nil {
+		return nil, accountError(err, address)
+	}
+	if code == nil {
+		return nil, status.Errorf(codes.NotFound, "contract %s does not exist on account %s", req.GetName(), address)
+	}
+
+	return &exeapi.AccountContractResponse{
+		Code: code,
+	}, nil
+}
+
+// GetAccountStorageUsed returns the storage used by an account at the given block.
+func (h *handler) GetAccountStorageUsed(
+	ctx context.Context,
+	req *exeapi.GetAccountStorageUsedRequest,
+) (*exeapi.AccountStorageUsedResponse, error) {
+
+	blockID, address, err := h.accountRequest(req.GetBlockId(), req.GetAddress())
+	if err != nil {
+		return nil, err
+	}
+
+	storageUsed, err := h.engine.GetAccountStorageUsed(ctx, address, blockID)
+	if err != nil {
+		return nil, accountError(err, address)
+	}
+
+	return &exeapi.AccountStorageUsedResponse{
+		StorageUsed: storageUsed,
+	}, nil
+}
+
+// DryRunTransaction executes the transaction at the given block without committing its changes.
+func (h *handler) DryRunTransaction(
+	ctx context.Context,
+	req *exeapi.DryRunTransactionRequest,
+) (*exeapi.DryRunTransactionResponse, error) {
+
+	blockID, err := convert.BlockID(req.GetBlockId())
+	if err != nil {
+		return nil, err
+	}
+
+	if req.GetTransaction() == nil {
+		return nil, status.Error(codes.InvalidArgument, "transaction is required")
+	}
+
+	tx, err := convert.MessageToTransaction(req.GetTransaction(), h.chain.Chain())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid transaction: %v", err)
+	}
+
+	result, err := h.engine.DryRunTransaction(ctx, &tx, req.GetSkipSignatureCheck(), blockID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to dry run transaction: %v", err)
+	}
+
+	res := &exeapi.DryRunTransactionResponse{
+		ComputationUsed: result.ComputationUsed,
+		Events:          make([]*entities.Event, len(result.Events)),
+		StorageDeltas:   make([]*exeapi.AccountStorageDelta, 0, len(result.StorageDeltas)),
+		FeeEstimate:     result.FeeEstimate,
+	}
+	for i, event := range result.Events {
+		res.Events[i] = convert.EventToMessage(event)
+	}
+	for address, delta := range result.StorageDeltas {
+		res.StorageDeltas = append(res.StorageDeltas, &exeapi.AccountStorageDelta{
+			Address: address.Bytes(),
+			Delta:   delta,
+		})
+	}
+	// the deltas are sorted by address, so the response does not depend on the map order
+	sort.Slice(res.StorageDeltas, func(i, j int) bool {
+		return bytes.Compare(res.StorageDeltas[i].Address, res.StorageDeltas[j].Address) < 0
+	})
+	if result.Err != nil {
+		res.ErrorCode = uint32(result.Err.Code())
+		res.ErrorMessage = result.Err.Error()
+	}
+
+	return res, nil
+}
+
+// GetTransactionTraces returns the execution traces of the given transaction, in the given block if one is given.
+// Traces are only recorded if enabled on the node.
+func (h *handler) GetTransactionTraces(
+	_ context.Context,
+	req *exeapi.GetTransactionTracesRequest,
+) (*exeapi.TransactionTracesResponse, error) {
+
+	txID, err := convert.TransactionID(req.GetTransactionId())
+	if err != nil {
+		return nil, err
+	}
+
+	var traces []flow.TransactionTrace
+	if len(req.GetBlockId()) == 0 {
+		traces, err = h.transactionTraces.ByTransactionID(txID)
+	} else {
+		var blockID flow.Identifier
+		blockID, err = convert.BlockID(req.GetBlockId())
+		if err != nil {
+			return nil, err
+		}
+
+		var trace *flow.TransactionTrace
+		trace, err = h.transactionTraces.ByBlockIDTransactionID(blockID, txID)
+		if err == nil {
+			traces = []flow.TransactionTrace{*trace}
+		}
+	}
+	if errors.Is(err, storage.ErrNotFound) {
+		return nil, status.Error(codes.NotFound, "transaction trace not found")
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get transaction traces: %v", err)
+	}
+
+	res := &exeapi.TransactionTracesResponse{
+		Traces: make([]*exeapi.TransactionTrace, 0, len(traces)),
+	}
+	for _, trace := range traces {
+		res.Traces = append(res.Traces, transactionTraceToMessage(trace))
+	}
+
+	return res, nil
+}
+
+// GetExecutionDataByBlockID returns the IDs of the execution data published for the given block, and of the execution
+// data of its chunks.
+func (h *handler) GetExecutionDataByBlockID(
+	_ context.Context,
+	req *exeapi.GetExecutionDataByBlockIDRequest,
+) (*exeapi.ExecutionDataResponse, error) {
+
+	blockID, err := convert.BlockID(req.GetBlockId())
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := h.executionDatas.ByBlockID(blockID)
+	if errors.Is(err, storage.ErrNotFound) {
+		return nil, status.Errorf(codes.NotFound, "execution data for block ID %s does not exist", blockID)
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get execution data: %v", err)
+	}
+
+	res := &exeapi.ExecutionDataResponse{
+		ExecutionDataId:       convert.IdentifierToMessage(data.ID()),
+		ChunkExecutionDataIds: convert.IdentifiersToMessages(data.ChunkExecutionDataIDs),
+	}
+
+	return res, nil
+}
+
+// transactionTraceToMessage converts a transaction trace to its message.
+func transactionTraceToMessage(trace flow.TransactionTrace) *exeapi.TransactionTrace {
+	registers := func(traces []flow.RegisterTrace) []*exeapi.RegisterTrace {
+		messages := make([]*exeapi.RegisterTrace, len(traces))
+		for i, t := range traces {
+			messages[i] = &exeapi.RegisterTrace{
+				Owner:        []byte(t.Register.Owner),
+				Controller:   []byte(t.Register.Controller),
+				Key:          []byte(t.Register.Key),
+				OldValueSize: t.OldValueSize,
+				NewValueSize: t.NewValueSize,
+			}
+		}
+		return messages
+	}
+
+	return &exeapi.TransactionTrace{
+		BlockId:          convert.IdentifierToMessage(trace.BlockID),
+		TransactionId:    convert.IdentifierToMessage(trace.TransactionID),
+		TransactionIndex: trace.TransactionIndex,
+		Reads:            registers(trace.Reads),
+		Writes:           registers(trace.Writes),
+		Programs:         trace.Programs,
+		ComputationUsed:  trace.ComputationUsed,
+		Events:           convert.EventsToMessages(trace.Events),
+	}
+}
+
+// accountRequest converts the block ID and the account address of an account request.
+func (h *handler) accountRequest(rawBlockID []byte, rawAddress []byte) (flow.Identifier, flow.Address, error) {
+	blockID, err := convert.BlockID(rawBlockID)
+	if err != nil {
+		return flow.ZeroID, flow.EmptyAddress, err
+	}
+
+	address, err := convert.Address(rawAddress, h.chain.Chain())
+	if err != nil {
+		return flow.ZeroID, flow.EmptyAddress, err
+	}
+
+	return blockID, address, nil
+}
+
+// accountError converts the error of an account lookup to a gRPC status error.
+func accountError(err error, address flow.Address) error {
+	if fvmerrors.IsAccountNotFoundError(err) {
+		return status.Errorf(codes.NotFound, "account with address %s does not exist", address)
+	}
+	if fvmerrors.IsAccountAccountPublicKeyNotFoundError(err) {
+		return status.Error(codes.NotFound, err.Error())
+	}
+	return status.Errorf(codes.Internal, "failed to get account: %v", err)
+}
@@ -15,7 +15,9 @@ import (
 	"github.com/onflow/flow/protobuf/go/flow/execution"
 
 	"github.com/onflow/flow-go/engine/common/rpc/convert"
+	exeapi "github.com/onflow/flow-go/engine/execution"
 	ingestion "github.com/onflow/flow-go/engine/execution/ingestion/mock"
+	fvmerrors "github.com/onflow/flow-go/fvm/errors"
 	"github.com/onflow/flow-go/model/flow"
 	realstorage "github.com/onflow/flow-go/storage"
 	storage "github.com/onflow/flow-go/storage/mock"
@@ -232,6 +234,323 @@ func (suite *Suite) TestGetAccountAtBlockID() {
 	})
 }
 
+// TestGetAccountsAtBlockID tests the GetAccountsAtBlockID API call
+func (suite *Suite) TestGetAccountsAtBlockID() {
+
+	id := unittest.IdentifierFixture()
+	chain := flow.Mainnet.Chain()
+	serviceAddress := chain.ServiceAddress()
+	missingAddress, err := chain.AddressAtIndex(5)
+	suite.Require().NoError(err)
+
+	serviceAccount := flow.Account{
+		Address: serviceAddress,
+	}
+
+	mockEngine := new(ingestion.IngestRPC)
+
+	// create the handler
+	handler := &handler{
+		engine: mockEngine,
+		chain:  flow.Mainnet,
+	}
+
+	suite.Run("results and errors are returned per account", func() {
+
+		mockEngine.On("GetAccounts", mock.Anything, []flow.Address{serviceAddress, missingAddress}, id).
+			Return([]*flow.Account{&serviceAccount, nil}, []error{nil, nil}, nil).Once()
+
+		req := &exeapi.GetAccountsAtBlockIDRequest{
+			BlockId:   id[:],
+			Addresses: [][]byte{serviceAddress.Bytes(), missingAddress.Bytes()},
+		}
+
+		resp, err := handler.GetAccountsAtBlockID(context.Background(), req)
+		suite.Require().NoError(err)
+		suite.Require().Len(resp.GetResults(), 2)
+
+		expectedAccount, err := convert.AccountToMessage(&serviceAccount)
+		suite.Require().NoError(err)
+		suite.Require().Equal(*expectedAccount, *resp.GetResults()[0].GetAccount())
+		suite.Require().Empty(resp.GetResults()[0].GetErrorMessage())
+
+		suite.Require().Nil(resp.GetResults()[1].GetAccount())
+		suite.Require().Contains(resp.GetResults()[1].GetErrorMessage(), "does not exist")
+		mockEngine.AssertExpectations(suite.T())
+	})
+
+	suite.Run("invalid request with invalid address", func() {
+
+		req := &exeapi.GetAccountsAtBlockIDRequest{
+			BlockId:   id[:],
+			Addresses: [][]byte{serviceAddress.Bytes(), nil},
+		}
+
+		_, err := handler.GetAccountsAtBlockID(context.Background(), req)
+		suite.Require().Error(err)
+	})
+}
+
+// TestGetTransactionResultsByBlockID tests that the results of all transactions of a block are returned with their
+// status code and events, including the system transaction.
+func (suite *Suite) TestGetTransactionResultsByBlockID() {
+
+	blockID := unittest.IdentifierFixture()
+	txID := unittest.IdentifierFixture()
+	systemTxID := unittest.IdentifierFixture()
+	txEvent := unittest.EventFixture(flow.EventAccountCreated, 0, 0, txID)
+	systemEvent := unittest.EventFixture(flow.EventType("A.8624b52f9ddcd04a.EpochManager.EpochSetup"), 1, 0, systemTxID)
+
+	handler := &handler{
+		events:             suite.events,
+		exeResults:         suite.exeResults,
+		transactionResults: suite.txResults,
+	}
+
+	suite.Run("results of executed block", func() {
+		suite.exeResults.On("ByBlockID", blockID).Return(nil, nil).Once()
+		suite.txResults.On("ByBlockID", blockID).Return([]flow.TransactionResult{
+			{TransactionID: txID, ErrorMessage: "failed"},
+			{TransactionID: systemTxID},
+		}, nil).Once()
+		suite.events.On("ByBlockID", blockID).Return([]flow.Event{txEvent, systemEvent}, nil).Once()
+
+		resp, err := handler.GetTransactionResultsByBlockID(context.Background(),
+			&exeapi.GetTransactionResultsByBlockIDRequest{BlockId: blockID[:]})
+		suite.Require().NoError(err)
+		suite.Require().Len(resp.GetResults(), 2)
+
+		failed := resp.GetResults()[0]
+		suite.Require().Equal(txID[:], failed.GetTransactionId())
+		suite.Require().Equal(uint32(1), failed.GetStatusCode())
+		suite.Require().Equal("failed", failed.GetErrorMessage())
+		suite.Require().Equal(convert.EventsToMessages([]flow.Event{txEvent}), failed.GetEvents())
+
+		system := resp.GetResults()[1]
+		suite.Require().Equal(systemTxID[:], system.GetTransactionId())
+		suite.Require().Equal(uint32(0), system.GetStatusCode())
+		suite.Require().Equal(convert.EventsToMessages([]flow.Event{systemEvent}), system.GetEvents())
+	})
+
+	suite.Run("block not executed", func() {
+		suite.exeResults.On("ByBlockID", blockID).Return(nil, realstorage.ErrNotFound).Once()
+
+		_, err := handler.GetTransactionResultsByBlockID(context.Background(),
+			&exeapi.GetTransactionResultsByBlockIDRequest{BlockId: blockID[:]})
+		suite.Require().Equal(codes.NotFound, status.Code(err))
+	})
+}
+
+// TestExecuteScriptsAtBlockID tests the ExecuteScriptsAtBlockID API call
+func (suite *Suite) TestExecuteScriptsAtBlockID() {
+
+	id := unittest.IdentifierFixture()
+	mockEngine := new(ingestion.IngestRPC)
+
+	// create the handler
+	handler := &handler{
+		engine: mockEngine,
+		chain:  flow.Mainnet,
+	}
+
+	scripts := [][]byte{[]byte("script 1"), []byte("script 2")}
+	arguments := [][][]byte{{[]byte("argument")}, nil}
+	value := []byte("value")
+
+	mockEngine.On("ExecuteScriptsAtBlockID", mock.Anything, scripts, arguments, id).
+		Return([][]byte{value, nil}, []error{nil, errors.New("script failed")}, nil).Once()
+
+	req := &exeapi.ExecuteScriptsAtBlockIDRequest{
+		BlockId: id[:],
+		Scripts: []*exeapi.Script{
+			{Script: scripts[0], Arguments: arguments[0]},
+			{Script: scripts[1]},
+		},
+	}
+
+	resp, err := handler.ExecuteScriptsAtBlockID(context.Background(), req)
+	suite.Require().NoError(err)
+	suite.Require().Len(resp.GetResults(), 2)
+
+	suite.Require().Equal(value, resp.GetResults()[0].GetValue())
+	suite.Require().Empty(resp.GetResults()[0].GetErrorMessage())
+
+	suite.Require().Nil(resp.GetResults()[1].GetValue())
+	suite.Require().Contains(resp.GetResults()[1].GetErrorMessage(), "script failed")
+	mockEngine.AssertExpectations(suite.T())
+}
+
+// TestGetRegisterProofAtBlockID tests the GetRegisterProofAtBlockID API call
+func (suite *Suite) TestGetRegisterProofAtBlockID() {
+
+	id := unittest.IdentifierFixture()
+	mockEngine := new(ingestion.IngestRPC)
+
+	// create the handler
+	handler := &handler{
+		engine: mockEngine,
+		chain:  flow.Mainnet,
+	}
+
+	registerIDs := []flow.RegisterID{
+		flow.NewRegisterID("owner", "controller", "key"),
+		flow.NewRegisterID("", "", "uuid"),
+	}
+	proof := flow.StorageProof("proof")
+
+	req := &exeapi.GetRegisterProofAtBlockIDRequest{
+		BlockId: id[:],
+		Registers: []*exeapi.RegisterID{
+			{Owner: []byte("owner"), Controller: []byte("controller"), Key: []byte("key")},
+			{Key: []byte("uuid")},
+		},
+	}
+
+	suite.Run("happy path", func() {
+		mockEngine.On("GetRegisterProofAtBlockID", mock.Anything, registerIDs, id).Return(proof, nil).Once()
+
+		resp, err := handler.GetRegisterProofAtBlockID(context.Background(), req)
+		suite.Require().NoError(err)
+		suite.Require().Equal([]byte(proof), resp.GetProof())
+		mockEngine.AssertExpectations(suite.T())
+	})
+
+	suite.Run("no registers", func() {
+		_, err := handler.GetRegisterProofAtBlockID(context.Background(), &exeapi.GetRegisterProofAtBlockIDRequest{BlockId: id[:]})
+		suite.Require().Equal(codes.InvalidArgument, status.Code(err))
+	})
+
+	suite.Run("engine error", func() {
+		mockEngine.On("GetRegisterProofAtBlockID", mock.Anything, registerIDs, id).Return(nil, errors.New("no state commitment")).Once()
+
+		_, err := handler.GetRegisterProofAtBlockID(context.Background(), req)
+		suite.Require().Equal(codes.Internal, status.Code(err))
+		mockEngine.AssertExpectations(suite.T())
+	})
+}
+
+// accountPublicKeyFixture returns a random account key with the given index.
+func (suite *Suite) accountPublicKeyFixture(index int) flow.AccountPublicKey {
+	privateKey, err := unittest.AccountKeyDefaultFixture()
+	suite.Require().NoError(err)
+
+	key := privateKey.PublicKey(1000)
+	key.Index = index
+	return key
+}
+
+// TestAccountLookups tests the API calls looking up single parts of an account
+func (suite *Suite) TestAccountLookups() {
+
+	id := unittest.IdentifierFixture()
+	address := flow.Mainnet.Chain().ServiceAddress()
+	mockEngine := new(ingestion.IngestRPC)
+
+	// create the handler
+	handler := &handler{
+		engine: mockEngine,
+		chain:  flow.Mainnet,
+	}
+
+	suite.Run("key at index", func() {
+		key := suite.accountPublicKeyFixture(1)
+		mockEngine.On("GetAccountKeyAtIndex", mock.Anything, address, uint64(1), id).Return(&key, nil).Once()
+
+		resp, err := handler.GetAccountKeyAtIndex(context.Background(), &exeapi.GetAccountKeyAtIndexRequest{
+			BlockId: id[:],
+			Address: address.Bytes(),
+			Index:   1,
+		})
+		suite.Require().NoError(err)
+		suite.Require().Equal(uint32(1), resp.GetAccountKey().GetIndex())
+		suite.Require().Equal(key.PublicKey.Encode(), resp.GetAccountKey().GetPublicKey())
+	})
+
+	suite.Run("key at missing index", func() {
+		mockEngine.On("GetAccountKeyAtIndex", mock.Anything, address, uint64(5), id).
+			Return(nil, fvmerrors.NewAccountPublicKeyNotFoundError(address, 5)).Once()
+
+		_, err := handler.GetAccountKeyAtIndex(context.Background(), &exeapi.GetAccountKeyAtIndexRequest{
+			BlockId: id[:],
+			Address: address.Bytes(),
+			Index:   5,
+		})
+		suite.Require().Equal(codes.NotFound, status.Code(err))
+	})
+
+	suite.Run("list keys", func() {
+		keys := []flow.AccountPublicKey{suite.accountPublicKeyFixture(2), suite.accountPublicKeyFixture(3)}
+		mockEngine.On("ListAccountKeys", mock.Anything, address, uint64(2), uint64(2), id).Return(keys, uint64(10), nil).Once()
+
+		resp, err := handler.ListAccountKeys(context.Background(), &exeapi.ListAccountKeysRequest{
+			BlockId: id[:],
+			Address: address.Bytes(),
+			Offset:  2,
+			Limit:   2,
+		})
+		suite.Require().NoError(err)
+		suite.Require().Len(resp.GetAccountKeys(), 2)
+		suite.Require().Equal(uint32(3), resp.GetAccountKeys()[1].GetIndex())
+		suite.Require().Equal(uint64(10), resp.GetTotalCount())
+	})
+
+	suite.Run("list keys with invalid limit", func() {
+		for _, limit := range []uint64{0, exeapi.MaxAccountKeysPageSize + 1} {
+			_, err := handler.ListAccountKeys(context.Background(), &exeapi.ListAccountKeysRequest{
+				BlockId: id[:],
+				Address: address.Bytes(),
+				Limit:   limit,
+			})
+			suite.Require().Equal(codes.InvalidArgument, status.Code(err))
+		}
+	})
+
+	suite.Run("contract names", func() {
+		mockEngine.On("GetAccountContractNames", mock.Anything, address, id).Return([]string{"A", "B"}, nil).Once()
+
+		resp, err := handler.GetAccountContractNames(context.Background(), &exeapi.GetAccountContractNamesRequest{
+			BlockId: id[:],
+			Address: address.Bytes(),
+		})
+		suite.Require().NoError(err)
+		suite.Require().Equal([]string{"A", "B"}, resp.GetNames())
+	})
+
+	suite.Run("contract", func() {
+		code := []byte("pub contract A {}")
+		mockEngine.On("GetAccountContract", mock.Anything, address, "A", id).Return(code, nil).Once()
+		mockEngine.On("GetAccountContract", mock.Anything, address, "C", id).Return(nil, nil).Once()
+
+		resp, err := handler.GetAccountContract(context.Background(), &exeapi.GetAccountContractRequest{
+			BlockId: id[:],
+			Address: address.Bytes(),
+			Name:    "A",
+		})
+		suite.Require().NoError(err)
+		suite.Require().Equal(code, resp.GetCode())
+
+		_, err = handler.GetAccountContract(context.Background(), &exeapi.GetAccountContractRequest{
+			BlockId: id[:],
+			Address: address.Bytes(),
+			Name:    "C",
+		})
+		suite.Require().Equal(codes.NotFound, status.Code(err))
+	})
+
+	suite.Run("storage used of missing account", func() {
+		mockEngine.On("GetAccountStorageUsed", mock.Anything, address, id).
+			Return(uint64(0), fvmerrors.NewAccountNotFoundError(address)).Once()
+
+		_, err := handler.GetAccountStorageUsed(context.Background(), &exeapi.GetAccountStorageUsedRequest{
+			BlockId: id[:],
+			Address: address.Bytes(),
+		})
+		suite.Require().Equal(codes.NotFound, status.Code(err))
+	})
+
+	mockEngine.AssertExpectations(suite.T())
+}
+
 // TestGetTransactionResult tests the GetTransactionResult API call
 func (suite *Suite) TestGetTransactionResult() {
 
@@ -424,3 +743,241 @@ func (suite *Suite) TestGetTransactionResult() {
 		suite.events.AssertExpectations(suite.T())
 	})
 }
+
+func (suite *Suite) TestDryRunTransaction() {
+
+	id := unittest.IdentifierFixture()
+	chain := flow.Mainnet.Chain()
+	mockEngine := new(ingestion.IngestRPC)
+
+	// create the handler
+	handler := &handler{
+		engine: mockEngine,
+		chain:  flow.Mainnet,
+	}
+
+	tx := flow.NewTransactionBody().
+		SetScript([]byte("transaction {}")).
+		SetProposalKey(chain.ServiceAddress(), 0, 0).
+		SetPayer(chain.ServiceAddress())
+
+	suite.Run("transaction that would succeed", func() {
+		first := flow.HexToAddress("02")
+		second := flow.HexToAddress("01")
+		result := &exeapi.DryRunResult{
+			ComputationUsed: 12,
+			Events:          []flow.Event{unittest.EventFixture(flow.EventAccountCreated, 0, 0, tx.ID())},
+			StorageDeltas:   map[flow.Address]int64{first: 100, second: -8},
+			FeeEstimate:     1000,
+		}
+		mockEngine.On("DryRunTransaction", mock.Anything, tx, true, id).Return(result, nil).Once()
+
+		resp, err := handler.DryRunTransaction(context.Background(), &exeapi.DryRunTransactionRequest{
+			BlockId:            id[:],
+			Transaction:        convert.TransactionToMessage(*tx),
+			SkipSignatureCheck: true,
+		})
+		suite.Require().NoError(err)
+		suite.Require().Equal(uint64(12), resp.GetComputationUsed())
+		suite.Require().Equal(uint64(1000), resp.GetFeeEstimate())
+		suite.Require().Len(resp.GetEvents(), 1)
+		suite.Require().Zero(resp.GetErrorCode())
+
+		// the storage deltas are sorted by address
+		suite.Require().Len(resp.GetStorageDeltas(), 2)
+		suite.Require().Equal(second.Bytes(), resp.GetStorageDeltas()[0].GetAddress())
+		suite.Require().Equal(int64(-8), resp.GetStorageDeltas()[0].GetDelta())
+		suite.Require().Equal(first.Bytes(), resp.GetStorageDeltas()[1].GetAddress())
+		suite.Require().Equal(int64(100), resp.GetStorageDeltas()[1].GetDelta())
+	})
+
+	suite.Run("transaction that would fail", func() {
+		result := &exeapi.DryRunResult{
+			Err: fvmerrors.NewInvalidProposalSignatureError(chain.ServiceAddress(), 0, errors.New("missing signature")),
+		}
+		mockEngine.On("DryRunTransaction", mock.Anything, tx, false, id).Return(result, nil).Once()
+
+		resp, err := handler.DryRunTransaction(context.Background(), &exeapi.DryRunTransactionRequest{
+			BlockId:     id[:],
+			Transaction: convert.TransactionToMessage(*tx),
+		})
+		suite.Require().NoError(err)
+		suite.Require().Equal(uint32(fvmerrors.ErrCodeInvalidProposalSignatureError), resp.GetErrorCode())
+		suite.Require().NotEmpty(resp.GetErrorMessage())
+	})
+
+	suite.Run("missing transaction", func() {
+		_, err := handler.DryRunTransaction(context.Background(), &exeapi.DryRunTransactionRequest{
+			BlockId: id[:],
+		})
+		suite.Require().Equal(codes.InvalidArgument, status.Code(err))
+	})
+
+	mockEngine.AssertExpectations(suite.T())
+}
+
+func (suite *Suite) TestGetTransactionTraces() {
+
+	blockID := unittest.IdentifierFixture()
+	txID := unittest.IdentifierFixture()
+	traces := new(storage.TransactionTraces)
+
+	// create the handler
+	handler := &handler{
+		transactionTraces: traces,
+		chain:             flow.Mainnet,
+	}
+
+	register := flow.NewRegisterID("owner", "controller", "key")
+	trace := flow.TransactionTrace{
+		BlockID:          blockID,
+		TransactionID:    txID,
+		TransactionIndex: 3,
+		Reads:            []flow.RegisterTrace{{Register: register, OldValueSize: 8}},
+		Writes:           []flow.RegisterTrace{{Register: register, OldValueSize: 8, NewValueSize: 16}},
+		Programs:         []string{"A.0000000000000001.Contract"},
+		ComputationUsed:  42,
+		Events:           []flow.Event{unittest.EventFixture(flow.EventAccountCreated, 3, 0, txID)},
+	}
+
+	suite.Run("traces in all blocks", func() {
+		traces.On("ByTransactionID", txID).Return([]flow.TransactionTrace{trace}, nil).Once()
+
+		resp, err := handler.GetTransactionTraces(context.Background(), &exeapi.GetTransactionTracesRequest{
+			TransactionId: txID[:],
+		})
+		suite.Require().NoError(err)
+		suite.Require().Len(resp.GetTraces(), 1)
+
+		actual := resp.GetTraces()[0]
+		suite.Require().Equal(blockID[:], actual.GetBlockId())
+		suite.Require().Equal(txID[:], actual.GetTransactionId())
+		suite.Require().Equal(uint32(3), actual.GetTransactionIndex())
+		suite.Require().Equal(uint64(42), actual.GetComputationUsed())
+		suite.Require().Equal(trace.Programs, actual.GetPrograms())
+		suite.Require().Len(actual.GetEvents(), 1)
+		suite.Require().Equal(&exeapi.RegisterTrace{
+			Owner:        []byte("owner"),
+			Controller:   []byte("controller"),
+			Key:          []byte("key"),
+			OldValueSize: 8,
+		}, actual.GetReads()[0])
+		suite.Require().Equal(uint64(16), actual.GetWrites()[0].GetNewValueSize())
+	})
+
+	suite.Run("trace in a block", func() {
+		traces.On("ByBlockIDTransactionID", blockID, txID).Return(&trace, nil).Once()
+
+		resp, err := handler.GetTransactionTraces(context.Background(), &exeapi.GetTransactionTracesRequest{
+			TransactionId: txID[:],
+			BlockId:       blockID[:],
+		})
+		suite.Require().NoError(err)
+		suite.Require().Len(resp.GetTraces(), 1)
+	})
+
+	suite.Run("untraced transaction", func() {
+		untracedID := unittest.IdentifierFixture()
+		traces.On("ByTransactionID", untracedID).Return(nil, realstorage.ErrNotFound).Once()
+
+		_, err := handler.GetTransactionTraces(context.Background(), &exeapi.GetTransactionTracesRequest{
+			TransactionId: untracedID[:],
+		})
+		suite.Require().Equal(codes.NotFound, status.Code(err))
+	})
+
+	suite.Run("invalid transaction ID", func() {
+		_, err := handler.GetTransactionTraces(context.Background(), &exeapi.GetTransactionTracesRequest{})
+		suite.Require().Error(err)
+	})
+
+	traces.AssertExpectations(suite.T())
+}
+
+// TestGetExecutionDataByBlockID tests the GetExecutionDataByBlockID API call
+func (suite *Suite) TestGetExecutionDataByBlockID() {
+
+	blockID := unittest.IdentifierFixture()
+	executionDatas := new(storage.ExecutionDatas)
+
+	// create the handler
+	handler := &handler{
+		executionDatas: executionDatas,
+		chain:          flow.Mainnet,
+	}
+
+	data := &flow.ExecutionData{
+		BlockID:               blockID,
+		ChunkExecutionDataIDs: unittest.IdentifierListFixture(2),
+	}
+
+	suite.Run("published execution data", func() {
+		executionDatas.On("ByBlockID", blockID).Return(data, nil).Once()
+
+		resp, err := handler.GetExecutionDataByBlockID(context.Background(), &exeapi.GetExecutionDataByBlockIDRequest{
+			BlockId: blockID[:],
+		})
+		suite.Require().NoError(err)
+
+		dataID := data.ID()
+		suite.Require().Equal(dataID[:], resp.GetExecutionDataId())
+		suite.Require().Equal(convert.IdentifiersToMessages(data.ChunkExecutionDataIDs), resp.GetChunkExecutionDataIds())
+	})
+
+	suite.Run("unpublished execution data", func() {
+		unpublishedID := unittest.IdentifierFixture()
+		executionDatas.On("ByBlockID", unpublishedID).Return(nil, realstorage.ErrNotFound).Once()
+
+		_, err := handler.GetExecutionDataByBlockID(context.Background(), &exeapi.GetExecutionDataByBlockIDRequest{
+			BlockId: unpublishedID[:],
+		})
+		suite.Require().Equal(codes.NotFound, status.Code(err))
+	})
+
+	suite.Run("invalid block ID", func() {
+		_, err := handler.GetExecutionDataByBlockID(context.Background(), &exeapi.GetExecutionDataByBlockIDRequest{})
+		suite.Require().Error(err)
+	})
+
+	executionDatas.AssertExpectations(suite.T())
+}
+
+// TestStopHeight tests the admin endpoints setting and returning the stop height
+func (suite *Suite) TestStopHeight() {
+
+	mockEngine := new(ingestion.IngestAdmin)
+	handler := &adminHandler{
+		engine: mockEngine,
+	}
+
+	suite.Run("set stop height", func() {
+		mockEngine.On("SetStopHeight", uint64(100), true).Return(nil).Once()
+		mockEngine.On("StopHeight").Return(uint64(100), true, false).Once()
+
+		resp, err := handler.SetStopHeight(context.Background(), &exeapi.SetStopHeightRequest{
+			Height: 100,
+			Crash:  true,
+		})
+		suite.Require().NoError(err)
+		suite.Require().Equal(&exeapi.StopHeightResponse{Height: 100, Crash: true}, resp)
+	})
+
+	suite.Run("invalid stop height", func() {
+		mockEngine.On("SetStopHeight", uint64(1), false).Return(errors.New("below executed height")).Once()
+
+		_, err := handler.SetStopHeight(context.Background(), &exeapi.SetStopHeightRequest{
+			Height: 1,
+		})
+		suite.Require().Equal(codes.FailedPrecondition, status.Code(err))
+	})
+
+	suite.Run("get stop height", func() {
+		mockEngine.On("StopHeight").Return(uint64(100), false, true).Once()
+
+		resp, err := handler.GetStopHeight(context.Background(), &exeapi.GetStopHeightRequest{})
+		suite.Require().NoError(err)
+		suite.Require().Equal(&exeapi.StopHeightResponse{Height: 100, Stopped: true}, resp)
+	})
+
+	mockEngine.AssertExpectations(suite.T())
+}
@@ -0,0 +1,217 @@
+package state
+
+import (
+	"fmt"
+
+	lru "github.com/hashicorp/golang-lru"
+
+	"github.com/onflow/flow-go/ledger"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module"
+	"github.com/onflow/flow-go/module/metrics"
+)
+
+// registerCacheLineageSize is the number of committed deltas the register cache remembers, it bounds how far back
+// a register value is looked up through the lineage of a state commitment.
+const registerCacheLineageSize = 1000
+
+// registerAt identifies the value of a register at a state commitment.
+type registerAt struct {
+	commit string
+	id     flow.RegisterID
+}
+
+// committedDelta is a delta committed to the ledger, it lists the registers updated from the parent state commitment.
+type committedDelta struct {
+	parent  string
+	updated map[flow.RegisterID]struct{}
+}
+
+// RegisterCache caches the register values read from and written to the ledger. The value of a register at a state
+// commitment never changes, so cached values never become stale. A register that is not cached at a state
+// commitment is looked up along the lineage of the commitment: its value at the parent state commitment is still
+// valid, unless the delta committed on top of the parent updated the register, which invalidates the values cached
+// at the ancestors. As the lineage forks, each fork reads the values of its own ancestors.
+//
+// The number of cached values and the length of the lineage are bounded, the least recently used are evicted.
+type RegisterCache struct {
+	metrics module.CacheMetrics
+	values  *lru.Cache // registerAt -> flow.RegisterValue
+	lineage *lru.Cache // state commitment -> *committedDelta
+}
+
+// NewRegisterCache returns a register cache holding up to the given number of register values.
+func NewRegisterCache(size uint, collector module.CacheMetrics) (*RegisterCache, error) {
+	values, err := lru.New(int(size))
+	if err != nil {
+		return nil, fmt.Errorf("could not create register value cache: %w", err)
+	}
+	lineage, err := lru.New(registerCacheLineageSize)
+	if err != nil {
+		return nil, fmt.Errorf("could not create state commitment lineage cache: %w", err)
+	}
+
+	return &RegisterCache{
+		metrics: collector,
+		values:  values,
+		lineage: lineage,
+	}, nil
+}
+
+// Get returns the value of the register at the given state commitment, if it is cached at the commitment or at one
+// of its ancestors whose value was not updated since.
+func (c *RegisterCache) Get(commit flow.StateCommitment, id flow.RegisterID) (flow.RegisterValue, bool) {
+	at := string(commit)
+
+	for depth := 0; depth <= registerCacheLineageSize; depth++ {
+		value, ok := c.values.Get(registerAt{commit: at, id: id})
+		if ok {
+			// the value found at an ancestor is cached at the commitment as well, so it is found right away next time
+			if depth > 0 {
+				c.put(string(commit), id, value.(flow.RegisterValue))
+			}
+			c.metrics.CacheHit(metrics.ResourceRegister)
+			return value.(flow.RegisterValue), true
+		}
+
+		delta, ok := c.lineage.Get(at)
+		if !ok {
+			break
+		}
+		committed := delta.(*committedDelta)
+		if _, updated := committed.updated[id]; updated {
+			break
+		}
+		at = committed.parent
+	}
+
+	c.metrics.CacheMiss(metrics.ResourceRegister)
+	return nil, false
+}
+
+// Put caches the value of the register at the given state commitment, as read from the ledger.
+func (c *RegisterCache) Put(commit flow.StateCommitment, id flow.RegisterID, value flow.RegisterValue) {
+	c.put(string(commit), id, value)
+	c.metrics.CacheEntries(metrics.ResourceRegister, uint(c.values.Len()))
+}
+
+// Committed records the delta committed on top of the parent state commitment, and caches the updated values at the
+// resulting state commitment.
+func (c *RegisterCache) Committed(parent flow.StateCommitment, commit flow.StateCommitment, ids []flow.RegisterID, values []flow.RegisterValue) {
+	// an empty delta does not change the state commitment
+	if string(parent) == string(commit) {
+		return
+	}
+
+	updated := make(map[flow.RegisterID]struct{}, len(ids))
+	for i, id := range ids {
+		updated[id] = struct{}{}
+		c.put(string(commit), id, values[i])
+	}
+	c.lineage.Add(string(commit), &committedDelta{parent: string(parent), updated: updated})
+
+	c.metrics.CacheEntries(metrics.ResourceRegister, uint(c.values.Len()))
+}
+
+func (c *RegisterCache) put(commit string, id flow.RegisterID, value flow.RegisterValue) {
+	c.values.Add(registerAt{commit: commit, id: id}, value)
+}
+
+// cachedLedger is a ledger whose register reads are served by the register cache if possible, and whose updates are
+// recorded in the register cache.
+type cachedLedger struct {
+	ledger.Ledger
+	cache *RegisterCache
+}
+
+// NewCachedLedger returns the given ledger, with its register reads and updates going through the register cache.
+func NewCachedLedger(ldg ledger.Ledger, cache *RegisterCache) ledger.Ledger {
+	return &cachedLedger{
+		Ledger: ldg,
+		cache:  cache,
+	}
+}
+
+// Get returns the values of the keys at the state of the query, only the keys not cached are read from the ledger.
+func (l *cachedLedger) Get(query *ledger.Query) ([]ledger.Value, error) {
+	commit := flow.StateCommitment(query.State())
+
+	ids, ok := keysToRegisterIDs(query.Keys())
+	if !ok {
+		return l.Ledger.Get(query)
+	}
+
+	values := make([]ledger.Value, len(ids))
+	var missing []int
+	for i, id := range ids {
+		value, ok := l.cache.Get(commit, id)
+		if !ok {
+			missing = append(missing, i)
+			continue
+		}
+		values[i] = ledger.Value(value)
+	}
+
+	if len(missing) == 0 {
+		return values, nil
+	}
+
+	keys := make([]ledger.Key, len(missing))
+	for j, i := range missing {
+		keys[j] = query.Keys()[i]
+	}
+	missingQuery, err := ledger.NewQuery(query.State(), keys)
+	if err != nil {
+		return nil, fmt.Errorf("cannot create ledger query: %w", err)
+	}
+
+	read, err := l.Ledger.Get(missingQuery)
+	if err != nil {
+		return nil, err
+	}
+	if len(read) != len(missing) {
+		return nil, fmt.Errorf("ledger returned %d values for %d keys", len(read), len(missing))
+	}
+
+	for j, i := range missing {
+		values[i] = read[j]
+		l.cache.Put(commit, ids[i], flow.RegisterValue(read[j]))
+	}
+
+	return values, nil
+}
+
+// Set updates the ledger, and records the update in the register cache.
+func (l *cachedLedger) Set(update *ledger.Update) (ledger.State, error) {
+	newState, err := l.Ledger.Set(update)
+	if err != nil {
+		return nil, err
+	}
+
+	// an update whose registers are not known can not be looked through, so the lineage stops at the new state
+	ids, ok := keysToRegisterIDs(update.Keys())
+	if !ok {
+		return newState, nil
+	}
+
+	values := make([]flow.RegisterValue, len(update.Values()))
+	for i, value := range update.Values() {
+		values[i] = flow.RegisterValue(value)
+	}
+	l.cache.Committed(flow.StateCommitment(update.State()), flow.StateCommitment(newState), ids, values)
+
+	return newState, nil
+}
+
+// keysToRegisterIDs converts ledger keys to register IDs, it returns false if a key is not a register key.
+func keysToRegisterIDs(keys []ledger.Key) ([]flow.RegisterID, bool) {
+	ids := make([]flow.RegisterID, len(keys))
+	for i, key := range keys {
+		id, err := KeyToRegisterID(key)
+		if err != nil {
+			return nil, false
+		}
+		ids[i] = id
+	}
+	return ids, true
+}
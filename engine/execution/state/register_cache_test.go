@@ -0,0 +1,146 @@
+package state_test
+
+import (
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/engine/execution/state"
+	"github.com/onflow/flow-go/engine/execution/state/delta"
+	led "github.com/onflow/flow-go/ledger"
+	ledger "github.com/onflow/flow-go/ledger/complete"
+	"github.com/onflow/flow-go/ledger/complete/wal/fixtures"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module/metrics"
+)
+
+// countingLedger counts the keys read from the ledger.
+type countingLedger struct {
+	led.Ledger
+	reads int
+}
+
+func (l *countingLedger) Get(query *led.Query) ([]led.Value, error) {
+	l.reads += query.Size()
+	return l.Ledger.Get(query)
+}
+
+func TestRegisterCache(t *testing.T) {
+	ls, err := ledger.NewLedger(&fixtures.NoopWAL{}, 100, &metrics.NoopCollector{}, zerolog.Nop(), ledger.DefaultPathFinderVersion)
+	require.NoError(t, err)
+
+	counting := &countingLedger{Ledger: ls}
+	cache, err := state.NewRegisterCache(100, &metrics.NoopCollector{})
+	require.NoError(t, err)
+	cached := state.NewCachedLedger(counting, cache)
+
+	// commit applies the given register values on top of the given state commitment
+	commit := func(parent flow.StateCommitment, values map[string]string) flow.StateCommitment {
+		view := delta.NewView(state.LedgerGetRegister(cached, parent))
+		for key, value := range values {
+			err := view.Set("owner", "", key, flow.RegisterValue(value))
+			require.NoError(t, err)
+		}
+		commit, err := state.CommitDelta(cached, view.Delta(), parent)
+		require.NoError(t, err)
+		return commit
+	}
+
+	// read reads a register at the given state commitment, and returns its value and whether it was read from the
+	// ledger
+	read := func(commit flow.StateCommitment, key string) (string, bool) {
+		reads := counting.reads
+		value, err := state.LedgerGetRegister(cached, commit)("owner", "", key)
+		require.NoError(t, err)
+		return string(value), counting.reads > reads
+	}
+
+	root := commit(ls.InitialState(), map[string]string{"a": "a0", "b": "b0"})
+
+	// the registers committed are cached
+	value, fromLedger := read(root, "a")
+	assert.Equal(t, "a0", value)
+	assert.False(t, fromLedger)
+
+	// the registers not committed are read from the ledger once
+	value, fromLedger = read(root, "c")
+	assert.Equal(t, "", value)
+	assert.True(t, fromLedger)
+	_, fromLedger = read(root, "c")
+	assert.False(t, fromLedger)
+
+	// two forks on top of the root, each updating register a
+	fork1 := commit(root, map[string]string{"a": "a1"})
+	fork2 := commit(root, map[string]string{"a": "a2", "b": "b2"})
+	child1 := commit(fork1, map[string]string{"d": "d1"})
+
+	// each fork reads its own update
+	value, fromLedger = read(child1, "a")
+	assert.Equal(t, "a1", value)
+	assert.False(t, fromLedger)
+	value, fromLedger = read(fork2, "a")
+	assert.Equal(t, "a2", value)
+	assert.False(t, fromLedger)
+
+	// registers not updated by a fork are read from the cached values of the root
+	value, fromLedger = read(child1, "b")
+	assert.Equal(t, "b0", value)
+	assert.False(t, fromLedger)
+	value, fromLedger = read(fork2, "b")
+	assert.Equal(t, "b2", value)
+	assert.False(t, fromLedger)
+	value, fromLedger = read(child1, "c")
+	assert.Equal(t, "", value)
+	assert.False(t, fromLedger)
+
+	// the root still reads its own values
+	value, fromLedger = read(root, "a")
+	assert.Equal(t, "a0", value)
+	assert.False(t, fromLedger)
+
+	// a state commitment not committed through the cache is read from the ledger
+	update, err := led.NewUpdate(root, state.RegisterIDSToKeys([]flow.RegisterID{flow.NewRegisterID("owner", "", "a")}),
+		[]led.Value{led.Value("a3")})
+	require.NoError(t, err)
+	uncached, err := ls.Set(update)
+	require.NoError(t, err)
+	value, fromLedger = read(uncached, "b")
+	assert.Equal(t, "b0", value)
+	assert.True(t, fromLedger)
+}
+
+func TestRegisterCacheEviction(t *testing.T) {
+	ls, err := ledger.NewLedger(&fixtures.NoopWAL{}, 100, &metrics.NoopCollector{}, zerolog.Nop(), ledger.DefaultPathFinderVersion)
+	require.NoError(t, err)
+
+	counting := &countingLedger{Ledger: ls}
+	cache, err := state.NewRegisterCache(1, &metrics.NoopCollector{})
+	require.NoError(t, err)
+	cached := state.NewCachedLedger(counting, cache)
+
+	view := delta.NewView(state.LedgerGetRegister(cached, ls.InitialState()))
+	err = view.Set("owner", "", "a", flow.RegisterValue("a0"))
+	require.NoError(t, err)
+	err = view.Set("owner", "", "b", flow.RegisterValue("b0"))
+	require.NoError(t, err)
+	root, err := state.CommitDelta(cached, view.Delta(), ls.InitialState())
+	require.NoError(t, err)
+
+	// only one of the updated registers is still cached, the other one is read from the ledger at the right state
+	values, err := cached.Get(mustQuery(t, root, "a", "b"))
+	require.NoError(t, err)
+	assert.Equal(t, []led.Value{led.Value("a0"), led.Value("b0")}, values)
+	assert.Equal(t, 1, counting.reads)
+}
+
+func mustQuery(t *testing.T, commit flow.StateCommitment, keys ...string) *led.Query {
+	ids := make([]flow.RegisterID, len(keys))
+	for i, key := range keys {
+		ids[i] = flow.NewRegisterID("owner", "", key)
+	}
+	query, err := led.NewQuery(commit, state.RegisterIDSToKeys(ids))
+	require.NoError(t, err)
+	return query
+}
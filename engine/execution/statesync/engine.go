@@ -0,0 +1,719 @@
+package statesync
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"sync"
+	"time"
+
+	lru "github.com/hashicorp/golang-lru"
+	"github.com/rs/zerolog"
+
+	"github.com/onflow/flow-go/engine"
+	"github.com/onflow/flow-go/ledger"
+	"github.com/onflow/flow-go/ledger/common/pathfinder"
+	"github.com/onflow/flow-go/ledger/complete/mtrie/flattener"
+	"github.com/onflow/flow-go/ledger/complete/mtrie/node"
+	"github.com/onflow/flow-go/ledger/complete/mtrie/trie"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/model/flow/filter"
+	"github.com/onflow/flow-go/model/messages"
+	"github.com/onflow/flow-go/module"
+	"github.com/onflow/flow-go/network"
+	"github.com/onflow/flow-go/state/protocol"
+	"github.com/onflow/flow-go/utils/logging"
+)
+
+// MaxDepth is the deepest depth at which tries are split. A trie split at depth d is transferred in up to 2^d
+// subtries.
+const MaxDepth = 16
+
+// splitCacheSize is the number of split tries kept to serve the subtries of, usually all requests are for the same
+// trie.
+const splitCacheSize = 4
+
+// errTimeout is returned when a peer did not respond to a request in time.
+var errTimeout = errors.New("request timed out")
+
+// Tries provides the tries of the execution state, for the engine to serve them to other execution nodes.
+type Tries interface {
+	Trie(state ledger.State) (*trie.MTrie, error)
+}
+
+// Engine synchronizes the execution state between execution nodes. A joining execution node uses it to fetch the
+// trie of a sealed state from other execution nodes: it first asks the nodes whether they hold the state, then
+// requests the manifest of the trie, which is the top of the trie down to a depth, and then the subtries below that
+// depth one by one. The manifest and each subtrie are
+// verified against the sealed state commitment when they are received, so misbehaving peers can not make the node
+// accept a different state, and the node requests the data from another peer instead.
+//
+// Once the node holds an execution state, it serves the tries it holds to other execution nodes.
+type Engine struct {
+	unit    *engine.Unit
+	log     zerolog.Logger
+	me      module.Local
+	state   protocol.State
+	con     network.Conduit
+	timeout time.Duration // the time to wait for a response before requesting the data from another peer
+
+	mu      sync.Mutex
+	tries   Tries                     // the tries served to other nodes, nil until the node holds an execution state
+	pending map[uint64]pendingRequest // the requests awaiting a response, by nonce
+	splits  *lru.Cache                // the recently split tries, by state commitment and depth
+}
+
+// pendingRequest is a request sent to a peer, awaiting its response.
+type pendingRequest struct {
+	peer     flow.Identifier
+	response chan interface{}
+}
+
+// split is a trie split at a depth, with the roots of its subtries.
+type split struct {
+	manifest []byte
+	roots    []*node.Node
+}
+
+// New creates a new state sync engine, which waits for the given time for the response to a request.
+func New(
+	log zerolog.Logger,
+	net module.Network,
+	me module.Local,
+	state protocol.State,
+	timeout time.Duration,
+) (*Engine, error) {
+
+	splits, _ := lru.New(splitCacheSize)
+
+	e := &Engine{
+		unit:    engine.NewUnit(),
+		log:     log.With().Str("engine", "state_sync").Logger(),
+		me:      me,
+		state:   state,
+		timeout: timeout,
+		pending: make(map[uint64]pendingRequest),
+		splits:  splits,
+	}
+
+	con, err := net.Register(engine.SyncExecutionState, e)
+	if err != nil {
+		return nil, fmt.Errorf("could not register state sync engine: %w", err)
+	}
+	e.con = con
+
+	return e, nil
+}
+
+// ServeFrom makes the engine serve the given tries to other execution nodes. Until it is called, requests are
+// ignored.
+func (e *Engine) ServeFrom(tries Tries) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.tries = tries
+}
+
+// Ready returns a ready channel that is closed once the engine has fully started.
+func (e *Engine) Ready() <-chan struct{} {
+	return e.unit.Ready()
+}
+
+// Done returns a done channel that is closed once the engine has fully stopped.
+func (e *Engine) Done() <-chan struct{} {
+	return e.unit.Done()
+}
+
+// SubmitLocal submits an event originating on the local node.
+func (e *Engine) SubmitLocal(event interface{}) {
+	e.Submit(e.me.NodeID(), event)
+}
+
+// Submit submits the given event from the node with the given origin ID
+// for processing in a non-blocking manner. It returns instantly and logs
+// a potential processing error internally when done.
+func (e *Engine) Submit(originID flow.Identifier, event interface{}) {
+	e.unit.Launch(func() {
+		err := e.Process(originID, event)
+		if err != nil {
+			engine.LogError(e.log, err)
+		}
+	})
+}
+
+// ProcessLocal processes an event originating on the local node.
+func (e *Engine) ProcessLocal(event interface{}) error {
+	return e.Process(e.me.NodeID(), event)
+}
+
+// Process processes the given event from the node with the given origin ID in
+// a blocking manner. It returns the potential processing error when done.
+func (e *Engine) Process(originID flow.Identifier, event interface{}) error {
+	return e.unit.Do(func() error {
+		return e.process(originID, event)
+	})
+}
+
+func (e *Engine) process(originID flow.Identifier, event interface{}) error {
+	switch v := event.(type) {
+	case *messages.ExecutionStateStatusRequest:
+		return e.onStatusRequest(originID, v)
+	case *messages.ExecutionStateManifestRequest:
+		return e.onManifestRequest(originID, v)
+	case *messages.ExecutionStateSubtrieRequest:
+		return e.onSubtrieRequest(originID, v)
+	case *messages.ExecutionStateStatusResponse:
+		e.onResponse(originID, v.Nonce, v)
+		return nil
+	case *messages.ExecutionStateManifestResponse:
+		e.onResponse(originID, v.Nonce, v)
+		return nil
+	case *messages.ExecutionStateSubtrieResponse:
+		e.onResponse(originID, v.Nonce, v)
+		return nil
+	default:
+		return fmt.Errorf("invalid event type (%T)", event)
+	}
+}
+
+// SyncSealedState fetches the trie of the state of the latest sealed block from other execution nodes, splitting
+// it at the given depth and fetching up to the given number of subtries at once. It returns the trie, with the
+// sealed block and its execution result. It only returns once the whole trie is fetched, or the context is done.
+//
+// The synced block must be sealed in the protocol state of the node, since the node continues executing from it.
+// The other execution nodes only hold the states of recent blocks, so they are asked first whether they hold the
+// state, and for the latest height sealed in their protocol state. If none of them holds it, the protocol state of
+// the node is behind the other nodes, and an error is returned right away, since the protocol state does not
+// advance before the node is started.
+func (e *Engine) SyncSealedState(ctx context.Context, depth uint16, workers uint) (*trie.MTrie, *flow.Header, *flow.ExecutionResult, error) {
+	result, seal, err := e.state.Final().SealedResult()
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("could not get sealed result: %w", err)
+	}
+
+	header, err := e.state.AtBlockID(seal.BlockID).Head()
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("could not get sealed block: %w", err)
+	}
+
+	peers, err := e.peers()
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	holders, sealedHeight, err := e.requestStatus(ctx, peers.NodeIDs(), seal.FinalState)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if len(holders) == 0 {
+		return nil, nil, nil, fmt.Errorf("no execution node holds the state of sealed block %v at height %d, the latest sealed height of the execution nodes is %d: bootstrap the protocol state from a more recent root snapshot",
+			seal.BlockID, header.Height, sealedHeight)
+	}
+
+	e.log.Info().
+		Uint64("height", header.Height).
+		Uint64("peers_sealed_height", sealedHeight).
+		Int("holders", len(holders)).
+		Msg("syncing execution state of the latest sealed block")
+
+	mtrie, err := e.sync(ctx, holders, seal.FinalState, depth, workers)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("could not sync state of sealed block %v at height %d: %w", seal.BlockID, header.Height, err)
+	}
+
+	return mtrie, header, result, nil
+}
+
+// Sync fetches the trie of the given state from other execution nodes, splitting it at the given depth and fetching
+// up to the given number of subtries at once. It only returns once the whole trie is fetched, or the context is
+// done.
+func (e *Engine) Sync(ctx context.Context, commit flow.StateCommitment, depth uint16, workers uint) (*trie.MTrie, error) {
+	peers, err := e.peers()
+	if err != nil {
+		return nil, err
+	}
+
+	return e.sync(ctx, peers.NodeIDs(), commit, depth, workers)
+}
+
+// peers returns the other staked execution nodes.
+func (e *Engine) peers() (flow.IdentityList, error) {
+	peers, err := e.state.Final().Identities(filter.And(
+		filter.HasRole(flow.RoleExecution),
+		filter.HasStake(true),
+		filter.Not(filter.HasNodeID(e.me.NodeID())),
+	))
+	if err != nil {
+		return nil, fmt.Errorf("could not get execution nodes: %w", err)
+	}
+	if len(peers) == 0 {
+		return nil, fmt.Errorf("no execution nodes to sync the state from")
+	}
+
+	return peers, nil
+}
+
+// requestStatus asks all peers at once whether they hold the given state, and waits for their responses until the
+// timeout. It returns the peers holding the state, and the highest sealed height reported by the peers.
+func (e *Engine) requestStatus(ctx context.Context, peers flow.IdentifierList, commit flow.StateCommitment) (flow.IdentifierList, uint64, error) {
+	type status struct {
+		peer flow.Identifier
+		res  interface{}
+		err  error
+	}
+
+	statuses := make(chan status, len(peers))
+	for _, peer := range peers {
+		peer := peer
+		go func() {
+			req := &messages.ExecutionStateStatusRequest{
+				StateCommitment: commit,
+				Nonce:           rand.Uint64(),
+			}
+			res, err := e.request(ctx, peer, req.Nonce, req)
+			statuses <- status{peer: peer, res: res, err: err}
+		}()
+	}
+
+	var holders flow.IdentifierList
+	var sealedHeight uint64
+	for range peers {
+		s := <-statuses
+		if s.err != nil {
+			e.log.Debug().Err(s.err).Hex("peer_id", logging.ID(s.peer)).Msg("could not request execution state status")
+			continue
+		}
+		res, ok := s.res.(*messages.ExecutionStateStatusResponse)
+		if !ok || !bytes.Equal(res.StateCommitment, commit) {
+			e.log.Warn().Hex("peer_id", logging.ID(s.peer)).Msg("invalid execution state status")
+			continue
+		}
+		if res.Held {
+			holders = append(holders, s.peer)
+		}
+		if res.SealedHeight > sealedHeight {
+			sealedHeight = res.SealedHeight
+		}
+	}
+
+	if ctx.Err() != nil {
+		return nil, 0, ctx.Err()
+	}
+
+	return holders, sealedHeight, nil
+}
+
+// sync fetches the trie of the given state from the given peers.
+func (e *Engine) sync(ctx context.Context, peers flow.IdentifierList, commit flow.StateCommitment, depth uint16, workers uint) (*trie.MTrie, error) {
+	if depth > MaxDepth {
+		return nil, fmt.Errorf("depth %d exceeds max depth %d", depth, MaxDepth)
+	}
+	if workers == 0 {
+		workers = 1
+	}
+
+	log := e.log.With().Hex("state_commitment", commit).Uint16("depth", depth).Logger()
+	log.Info().Int("peers", len(peers)).Msg("syncing execution state")
+
+	manifest, err := e.fetchManifest(ctx, peers, commit, depth)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Info().Int("subtries", len(manifest.Subtries)).Msg("execution state manifest received")
+
+	subtries, err := e.fetchSubtries(ctx, peers, commit, depth, manifest, workers)
+	if err != nil {
+		return nil, err
+	}
+
+	mtrie, err := manifest.RebuildTrie(subtries)
+	if err != nil {
+		return nil, fmt.Errorf("could not rebuild trie: %w", err)
+	}
+
+	log.Info().Uint64("registers", mtrie.AllocatedRegCount()).Msg("execution state synced")
+
+	return mtrie, nil
+}
+
+// fetchManifest requests the manifest of the trie from the peers in turn, until a peer responds with a manifest
+// that matches the state commitment.
+func (e *Engine) fetchManifest(ctx context.Context, peers flow.IdentifierList, commit flow.StateCommitment, depth uint16) (*flattener.TrieManifest, error) {
+	for attempt := rand.Intn(len(peers)); ; attempt++ {
+		peer := peers[attempt%len(peers)]
+
+		req := &messages.ExecutionStateManifestRequest{
+			StateCommitment: commit,
+			Depth:           depth,
+			Nonce:           rand.Uint64(),
+		}
+		res, err := e.request(ctx, peer, req.Nonce, req)
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		if err != nil {
+			e.log.Warn().Err(err).Hex("peer_id", logging.ID(peer)).Msg("could not request execution state manifest")
+			continue
+		}
+
+		manifest, err := verifyManifest(res, commit, depth)
+		if err != nil {
+			e.log.Warn().Err(err).Hex("peer_id", logging.ID(peer)).Msg("invalid execution state manifest")
+			continue
+		}
+
+		return manifest, nil
+	}
+}
+
+// fetchSubtries requests the subtries of the manifest from the peers, with the given number of workers. Each
+// subtrie is requested from the peers in turn, until a peer responds with a subtrie that matches the manifest.
+func (e *Engine) fetchSubtries(
+	ctx context.Context,
+	peers flow.IdentifierList,
+	commit flow.StateCommitment,
+	depth uint16,
+	manifest *flattener.TrieManifest,
+	workers uint,
+) ([]*node.Node, error) {
+
+	subtries := make([]*node.Node, len(manifest.Subtries))
+
+	indices := make(chan int, len(manifest.Subtries))
+	for i := range manifest.Subtries {
+		indices <- i
+	}
+	close(indices)
+
+	// the first error of a worker stops the other workers, and is returned
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	var errOnce sync.Once
+	var fetchErr error
+
+	var wg sync.WaitGroup
+	for w := uint(0); w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for index := range indices {
+				subtrie, err := e.fetchSubtrie(ctx, peers, commit, depth, manifest, index)
+				if err != nil {
+					errOnce.Do(func() {
+						fetchErr = fmt.Errorf("could not fetch subtrie %d: %w", index, err)
+						cancel()
+					})
+					return
+				}
+				subtries[index] = subtrie
+			}
+		}()
+	}
+	wg.Wait()
+
+	if fetchErr != nil {
+		return nil, fetchErr
+	}
+
+	return subtries, nil
+}
+
+// fetchSubtrie requests the subtrie with the given index from the peers in turn, until a peer responds with a
+// subtrie that matches the manifest. It only fails when the context is done.
+func (e *Engine) fetchSubtrie(
+	ctx context.Context,
+	peers flow.IdentifierList,
+	commit flow.StateCommitment,
+	depth uint16,
+	manifest *flattener.TrieManifest,
+	index int,
+) (*node.Node, error) {
+
+	// the manifest is verified, so the subtrie exists
+	root, err := manifest.Subtrie(index)
+	if err != nil {
+		return nil, err
+	}
+
+	for attempt := index; ; attempt++ {
+		peer := peers[attempt%len(peers)]
+
+		req := &messages.ExecutionStateSubtrieRequest{
+			StateCommitment: commit,
+			Depth:           depth,
+			Index:           uint64(index),
+			Nonce:           rand.Uint64(),
+		}
+		res, err := e.request(ctx, peer, req.Nonce, req)
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		if err != nil {
+			e.log.Warn().Err(err).Hex("peer_id", logging.ID(peer)).Int("index", index).Msg("could not request subtrie")
+			continue
+		}
+
+		subtrie, err := verifySubtrie(res, root, uint64(index))
+		if err != nil {
+			e.log.Warn().Err(err).Hex("peer_id", logging.ID(peer)).Int("index", index).Msg("invalid subtrie")
+			continue
+		}
+
+		return subtrie, nil
+	}
+}
+
+// verifyManifest decodes the manifest of the given response, and verifies that it is the manifest of the trie of
+// the given state split at the given depth.
+func verifyManifest(res interface{}, commit flow.StateCommitment, depth uint16) (*flattener.TrieManifest, error) {
+	response, ok := res.(*messages.ExecutionStateManifestResponse)
+	if !ok {
+		return nil, fmt.Errorf("unexpected response type (%T)", res)
+	}
+
+	manifest, err := flattener.DecodeTrieManifest(response.Manifest)
+	if err != nil {
+		return nil, fmt.Errorf("could not decode manifest: %w", err)
+	}
+
+	if !bytes.Equal(commit, manifest.RootHash) {
+		return nil, fmt.Errorf("manifest of state %x does not match state commitment", manifest.RootHash)
+	}
+
+	// the manifest must split the trie at the requested depth, so it does not leave out huge subtries
+	for i := range manifest.Subtries {
+		root, err := manifest.Subtrie(i)
+		if err != nil {
+			return nil, err
+		}
+		if int(root.Height) != 8*pathfinder.PathByteSize-int(depth) {
+			return nil, fmt.Errorf("root of subtrie %d is at height %d, not at depth %d", i, root.Height, depth)
+		}
+	}
+
+	err = manifest.Verify()
+	if err != nil {
+		return nil, fmt.Errorf("invalid manifest: %w", err)
+	}
+
+	return manifest, nil
+}
+
+// verifySubtrie rebuilds the subtrie of the given response, and verifies that it matches its root in the manifest.
+func verifySubtrie(res interface{}, root *flattener.StorableNode, index uint64) (*node.Node, error) {
+	response, ok := res.(*messages.ExecutionStateSubtrieResponse)
+	if !ok {
+		return nil, fmt.Errorf("unexpected response type (%T)", res)
+	}
+	if response.Index != index {
+		return nil, fmt.Errorf("got subtrie %d instead of subtrie %d", response.Index, index)
+	}
+
+	nodes, err := flattener.DecodeStorableNodes(response.Nodes)
+	if err != nil {
+		return nil, fmt.Errorf("could not decode subtrie: %w", err)
+	}
+
+	subtrie, err := flattener.RebuildSubtrie(nodes, int(root.Height), root.HashValue)
+	if err != nil {
+		return nil, fmt.Errorf("subtrie does not match manifest: %w", err)
+	}
+
+	return subtrie, nil
+}
+
+// request sends the given request to the peer, and waits for the response with the given nonce.
+func (e *Engine) request(ctx context.Context, peer flow.Identifier, nonce uint64, req interface{}) (interface{}, error) {
+	response := make(chan interface{}, 1)
+
+	e.mu.Lock()
+	e.pending[nonce] = pendingRequest{peer: peer, response: response}
+	e.mu.Unlock()
+
+	defer func() {
+		e.mu.Lock()
+		delete(e.pending, nonce)
+		e.mu.Unlock()
+	}()
+
+	err := e.con.Unicast(req, peer)
+	if err != nil {
+		return nil, fmt.Errorf("could not send request: %w", err)
+	}
+
+	timer := time.NewTimer(e.timeout)
+	defer timer.Stop()
+
+	select {
+	case res := <-response:
+		return res, nil
+	case <-timer.C:
+		return nil, errTimeout
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// onResponse passes the response on to the pending request with the given nonce. Responses to unknown requests, and
+// responses from other nodes than the requested one, are dropped.
+func (e *Engine) onResponse(originID flow.Identifier, nonce uint64, res interface{}) {
+	e.mu.Lock()
+	pending, ok := e.pending[nonce]
+	e.mu.Unlock()
+
+	if !ok || pending.peer != originID {
+		e.log.Debug().Hex("origin_id", logging.ID(originID)).Uint64("nonce", nonce).Msg("dropping unexpected response")
+		return
+	}
+
+	// the channel holds a single response, any further response is dropped
+	select {
+	case pending.response <- res:
+	default:
+	}
+}
+
+// onStatusRequest responds with whether the node holds the requested state, and with the latest sealed height.
+func (e *Engine) onStatusRequest(originID flow.Identifier, req *messages.ExecutionStateStatusRequest) error {
+	err := e.checkRequester(originID)
+	if err != nil {
+		return err
+	}
+
+	sealed, err := e.state.Sealed().Head()
+	if err != nil {
+		return fmt.Errorf("could not get sealed block: %w", err)
+	}
+
+	res := &messages.ExecutionStateStatusResponse{
+		StateCommitment: req.StateCommitment,
+		Held:            e.holds(req.StateCommitment),
+		SealedHeight:    sealed.Height,
+		Nonce:           req.Nonce,
+	}
+	err = e.con.Unicast(res, originID)
+	if err != nil {
+		return fmt.Errorf("could not send execution state status to (%x): %w", originID, err)
+	}
+
+	return nil
+}
+
+// onManifestRequest responds with the manifest of the requested trie, if the node holds it.
+func (e *Engine) onManifestRequest(originID flow.Identifier, req *messages.ExecutionStateManifestRequest) error {
+	s, ok, err := e.split(originID, req.StateCommitment, req.Depth)
+	if err != nil || !ok {
+		return err
+	}
+
+	res := &messages.ExecutionStateManifestResponse{
+		StateCommitment: req.StateCommitment,
+		Depth:           req.Depth,
+		Manifest:        s.manifest,
+		Nonce:           req.Nonce,
+	}
+	err = e.con.Unicast(res, originID)
+	if err != nil {
+		return fmt.Errorf("could not send execution state manifest to (%x): %w", originID, err)
+	}
+
+	return nil
+}
+
+// onSubtrieRequest responds with the requested subtrie, if the node holds its trie.
+func (e *Engine) onSubtrieRequest(originID flow.Identifier, req *messages.ExecutionStateSubtrieRequest) error {
+	s, ok, err := e.split(originID, req.StateCommitment, req.Depth)
+	if err != nil || !ok {
+		return err
+	}
+
+	if req.Index >= uint64(len(s.roots)) {
+		return engine.NewInvalidInputErrorf("subtrie index %d out of range, trie has %d subtries", req.Index, len(s.roots))
+	}
+
+	res := &messages.ExecutionStateSubtrieResponse{
+		StateCommitment: req.StateCommitment,
+		Depth:           req.Depth,
+		Index:           req.Index,
+		Nodes:           flattener.EncodeStorableNodes(flattener.FlattenSubtrie(s.roots[req.Index])),
+		Nonce:           req.Nonce,
+	}
+	err = e.con.Unicast(res, originID)
+	if err != nil {
+		return fmt.Errorf("could not send subtrie to (%x): %w", originID, err)
+	}
+
+	return nil
+}
+
+// checkRequester checks that the node with the given ID is a staked execution node, the state is only served to
+// staked execution nodes.
+func (e *Engine) checkRequester(originID flow.Identifier) error {
+	identity, err := e.state.Final().Identity(originID)
+	if err != nil {
+		return engine.NewInvalidInputErrorf("could not get identity of requester (%x): %w", originID, err)
+	}
+	if identity.Role != flow.RoleExecution || identity.Stake == 0 {
+		return engine.NewInvalidInputErrorf("requester (%x) is not a staked execution node", originID)
+	}
+	return nil
+}
+
+// holds returns whether the node holds the trie of the given state.
+func (e *Engine) holds(commit flow.StateCommitment) bool {
+	e.mu.Lock()
+	tries := e.tries
+	e.mu.Unlock()
+
+	if tries == nil {
+		return false
+	}
+
+	_, err := tries.Trie(ledger.State(commit))
+	return err == nil
+}
+
+// split returns the trie of the given state split at the given depth, for a request of the given node. It returns
+// false if the request is not served, because the node does not hold the trie.
+func (e *Engine) split(originID flow.Identifier, commit flow.StateCommitment, depth uint16) (*split, bool, error) {
+	err := e.checkRequester(originID)
+	if err != nil {
+		return nil, false, err
+	}
+	if depth > MaxDepth {
+		return nil, false, engine.NewInvalidInputErrorf("requested depth %d exceeds max depth %d", depth, MaxDepth)
+	}
+
+	key := fmt.Sprintf("%x/%d", commit, depth)
+	if cached, ok := e.splits.Get(key); ok {
+		return cached.(*split), true, nil
+	}
+
+	e.mu.Lock()
+	tries := e.tries
+	e.mu.Unlock()
+
+	if tries == nil {
+		e.log.Debug().Hex("origin_id", logging.ID(originID)).Msg("no execution state to serve yet")
+		return nil, false, nil
+	}
+
+	// the trie might be evicted already, or not be created yet
+	mtrie, err := tries.Trie(ledger.State(commit))
+	if err != nil {
+		e.log.Info().Err(err).Hex("origin_id", logging.ID(originID)).Hex("state_commitment", commit).
+			Msg("requested execution state not available")
+		return nil, false, nil
+	}
+
+	manifest, roots := flattener.SplitTrie(mtrie, int(depth))
+	s := &split{
+		manifest: flattener.EncodeTrieManifest(manifest),
+		roots:    roots,
+	}
+	e.splits.Add(key, s)
+
+	return s, true, nil
+}
@@ -0,0 +1,259 @@
+package statesync
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/engine"
+	"github.com/onflow/flow-go/ledger"
+	"github.com/onflow/flow-go/ledger/common/pathfinder"
+	"github.com/onflow/flow-go/ledger/common/utils"
+	"github.com/onflow/flow-go/ledger/complete/mtrie/trie"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/model/messages"
+	module "github.com/onflow/flow-go/module/mock"
+	"github.com/onflow/flow-go/network/mocknetwork"
+	protocol "github.com/onflow/flow-go/state/protocol/mock"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+// tries serves a single trie.
+type tries struct {
+	mtrie *trie.MTrie
+}
+
+func (t *tries) Trie(state ledger.State) (*trie.MTrie, error) {
+	if !bytes.Equal(state, t.mtrie.RootHash()) {
+		return nil, fmt.Errorf("trie %x not found", state)
+	}
+	return t.mtrie, nil
+}
+
+// randomTrie returns a trie with the given number of random registers.
+func randomTrie(t *testing.T, n int) *trie.MTrie {
+	emptyTrie, err := trie.NewEmptyMTrie(pathfinder.PathByteSize)
+	require.NoError(t, err)
+
+	payloads := make([]ledger.Payload, 0, n)
+	for _, payload := range utils.RandomPayloads(n, 2, 10) {
+		payloads = append(payloads, *payload)
+	}
+
+	mtrie, err := trie.NewTrieWithUpdatedRegisters(emptyTrie, utils.RandomPaths(n, pathfinder.PathByteSize), payloads)
+	require.NoError(t, err)
+	return mtrie
+}
+
+// testNetwork connects state sync engines, delivering each message synchronously to the engine of its target.
+type testNetwork struct {
+	t          *testing.T
+	identities flow.IdentityList
+	state      *protocol.State
+	snapshot   *protocol.Snapshot
+	engines    map[flow.Identifier]*Engine
+	tamper     map[flow.Identifier]func(interface{}) // tampers with the responses of a node
+}
+
+func newTestNetwork(t *testing.T, identities flow.IdentityList) *testNetwork {
+	snapshot := new(protocol.Snapshot)
+	snapshot.On("Identities", mock.Anything).Return(
+		func(selector flow.IdentityFilter) flow.IdentityList {
+			return identities.Filter(selector)
+		},
+		nil,
+	)
+	snapshot.On("Identity", mock.Anything).Return(
+		func(nodeID flow.Identifier) *flow.Identity {
+			identity, _ := identities.ByNodeID(nodeID)
+			return identity
+		},
+		func(nodeID flow.Identifier) error {
+			if _, ok := identities.ByNodeID(nodeID); !ok {
+				return fmt.Errorf("unknown node %x", nodeID)
+			}
+			return nil
+		},
+	)
+
+	state := new(protocol.State)
+	state.On("Final").Return(snapshot)
+	state.On("Sealed").Return(snapshot)
+
+	n := &testNetwork{
+		t:          t,
+		identities: identities,
+		state:      state,
+		snapshot:   snapshot,
+		engines:    make(map[flow.Identifier]*Engine),
+		tamper:     make(map[flow.Identifier]func(interface{})),
+	}
+	for _, identity := range identities {
+		n.engines[identity.NodeID] = n.newEngine(identity.NodeID)
+	}
+	return n
+}
+
+func (n *testNetwork) newEngine(nodeID flow.Identifier) *Engine {
+	me := new(module.Local)
+	me.On("NodeID").Return(nodeID)
+
+	con := new(mocknetwork.Conduit)
+	con.On("Unicast", mock.Anything, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+		event := args.Get(0)
+		targetID := args.Get(1).(flow.Identifier)
+		if tamper, ok := n.tamper[nodeID]; ok {
+			tamper(event)
+		}
+		target, ok := n.engines[targetID]
+		if !ok {
+			return
+		}
+		err := target.Process(nodeID, event)
+		if err != nil && !engine.IsInvalidInputError(err) {
+			n.t.Errorf("could not process event: %v", err)
+		}
+	})
+
+	net := new(module.Network)
+	net.On("Register", engine.SyncExecutionState, mock.Anything).Return(con, nil)
+
+	e, err := New(zerolog.Nop(), net, me, n.state, 100*time.Millisecond)
+	require.NoError(n.t, err)
+	return e
+}
+
+// TestSync tests that a node syncs the trie of a state from another node.
+func TestSync(t *testing.T) {
+	identities := unittest.IdentityListFixture(2, unittest.WithRole(flow.RoleExecution))
+	net := newTestNetwork(t, identities)
+
+	mtrie := randomTrie(t, 200)
+	net.engines[identities[1].NodeID].ServeFrom(&tries{mtrie: mtrie})
+
+	for _, depth := range []uint16{0, 1, 4, 8} {
+		synced, err := net.engines[identities[0].NodeID].Sync(context.Background(), mtrie.RootHash(), depth, 4)
+		require.NoError(t, err)
+		assert.Equal(t, mtrie.RootHash(), synced.RootHash())
+		assert.Equal(t, mtrie.AllocatedRegCount(), synced.AllocatedRegCount())
+		assert.Equal(t, mtrie.MaxDepth(), synced.MaxDepth())
+	}
+}
+
+// TestSyncFromHonestNode tests that the data of a node serving a tampered trie is rejected, and the trie is synced
+// from the other nodes.
+func TestSyncFromHonestNode(t *testing.T) {
+	identities := unittest.IdentityListFixture(3, unittest.WithRole(flow.RoleExecution))
+	net := newTestNetwork(t, identities)
+
+	mtrie := randomTrie(t, 100)
+	for _, identity := range identities[1:] {
+		net.engines[identity.NodeID].ServeFrom(&tries{mtrie: mtrie})
+	}
+
+	// the first node truncates every subtrie it sends, and sends an empty manifest
+	net.tamper[identities[1].NodeID] = func(event interface{}) {
+		switch v := event.(type) {
+		case *messages.ExecutionStateManifestResponse:
+			v.Manifest = nil
+		case *messages.ExecutionStateSubtrieResponse:
+			v.Nodes = v.Nodes[:len(v.Nodes)/2]
+		}
+	}
+
+	synced, err := net.engines[identities[0].NodeID].Sync(context.Background(), mtrie.RootHash(), 4, 2)
+	require.NoError(t, err)
+	assert.Equal(t, mtrie.RootHash(), synced.RootHash())
+}
+
+// TestSyncUnavailable tests that syncing a state no node holds only stops once the context is done.
+func TestSyncUnavailable(t *testing.T) {
+	identities := unittest.IdentityListFixture(2, unittest.WithRole(flow.RoleExecution))
+	net := newTestNetwork(t, identities)
+
+	net.engines[identities[1].NodeID].ServeFrom(&tries{mtrie: randomTrie(t, 10)})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 300*time.Millisecond)
+	defer cancel()
+
+	_, err := net.engines[identities[0].NodeID].Sync(ctx, unittest.StateCommitmentFixture(), 4, 2)
+	require.Error(t, err)
+	assert.Equal(t, context.DeadlineExceeded, err)
+}
+
+// sealState makes the nodes of the network know the given state as the state of their latest sealed block, and
+// returns the block.
+func (n *testNetwork) sealState(commit flow.StateCommitment) *flow.Block {
+	block := unittest.BlockFixture()
+	result := unittest.ExecutionResultFixture(unittest.WithBlock(&block))
+	result.Chunks[len(result.Chunks)-1].EndState = commit
+	seal := unittest.Seal.Fixture(unittest.Seal.WithResult(result))
+
+	n.snapshot.On("SealedResult").Return(result, seal, nil)
+	n.snapshot.On("Head").Return(block.Header, nil)
+	n.state.On("AtBlockID", block.ID()).Return(n.snapshot)
+	return &block
+}
+
+// TestSyncSealedState tests that a node syncs the state of its latest sealed block from the nodes holding it.
+func TestSyncSealedState(t *testing.T) {
+	identities := unittest.IdentityListFixture(3, unittest.WithRole(flow.RoleExecution))
+	net := newTestNetwork(t, identities)
+
+	mtrie := randomTrie(t, 100)
+	block := net.sealState(mtrie.RootHash())
+
+	// only one of the nodes holds the state, the other one does not serve any state yet
+	net.engines[identities[1].NodeID].ServeFrom(&tries{mtrie: mtrie})
+
+	synced, header, result, err := net.engines[identities[0].NodeID].SyncSealedState(context.Background(), 4, 2)
+	require.NoError(t, err)
+	assert.Equal(t, mtrie.RootHash(), synced.RootHash())
+	assert.Equal(t, block.ID(), header.ID())
+	assert.Equal(t, block.ID(), result.BlockID)
+}
+
+// TestSyncSealedStateNotHeld tests that syncing the state of a sealed block no node holds anymore fails right away.
+func TestSyncSealedStateNotHeld(t *testing.T) {
+	identities := unittest.IdentityListFixture(2, unittest.WithRole(flow.RoleExecution))
+	net := newTestNetwork(t, identities)
+
+	net.sealState(unittest.StateCommitmentFixture())
+	net.engines[identities[1].NodeID].ServeFrom(&tries{mtrie: randomTrie(t, 10)})
+
+	_, _, _, err := net.engines[identities[0].NodeID].SyncSealedState(context.Background(), 4, 2)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "no execution node holds the state")
+}
+
+// TestServeStakedExecutionNodes tests that the state is only served to staked execution nodes.
+func TestServeStakedExecutionNodes(t *testing.T) {
+	identities := unittest.IdentityListFixture(1, unittest.WithRole(flow.RoleExecution))
+	identities = append(identities,
+		unittest.IdentityFixture(unittest.WithRole(flow.RoleVerification)),
+		unittest.IdentityFixture(unittest.WithRole(flow.RoleExecution), unittest.WithStake(0)),
+	)
+	net := newTestNetwork(t, identities)
+
+	mtrie := randomTrie(t, 10)
+	server := net.engines[identities[0].NodeID]
+	server.ServeFrom(&tries{mtrie: mtrie})
+
+	for _, identity := range identities[1:] {
+		err := server.Process(identity.NodeID, &messages.ExecutionStateManifestRequest{StateCommitment: mtrie.RootHash(), Depth: 1})
+		assert.True(t, engine.IsInvalidInputError(err))
+	}
+
+	err := server.Process(unittest.IdentifierFixture(), &messages.ExecutionStateManifestRequest{StateCommitment: mtrie.RootHash(), Depth: 1})
+	assert.True(t, engine.IsInvalidInputError(err))
+
+	err = server.Process(unittest.IdentifierFixture(), &messages.ExecutionStateStatusRequest{StateCommitment: mtrie.RootHash()})
+	assert.True(t, engine.IsInvalidInputError(err))
+}
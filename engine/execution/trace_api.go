@@ -0,0 +1,235 @@
+package execution
+
+import (
+	"context"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/onflow/flow/protobuf/go/flow/entities"
+	"google.golang.org/grpc"
+)
+
+// ExecutionTraceAPI returns the execution traces the node recorded for a transaction, if enabled.
+
+// GetTransactionTracesRequest is the request message of ExecutionTraceAPI.GetTransactionTraces. If the block ID is
+// empty, the traces of the transaction in all blocks it was traced in are returned.
+type GetTransactionTracesRequest struct {
+	TransactionId []byte `protobuf:"bytes,1,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"`
+	BlockId       []byte `protobuf:"bytes,2,opt,name=block_id,json=blockId,proto3" json:"block_id,omitempty"`
+}
+
+func (m *GetTransactionTracesRequest) Reset()         { *m = GetTransactionTracesRequest{} }
+func (m *GetTransactionTracesRequest) String() string { return proto.CompactTextString(m) }
+func (*GetTransactionTracesRequest) ProtoMessage()    {}
+
+func (m *GetTransactionTracesRequest) GetTransactionId() []byte {
+	if m != nil {
+		return m.TransactionId
+	}
+	return nil
+}
+
+func (m *GetTransactionTracesRequest) GetBlockId() []byte {
+	if m != nil {
+		return m.BlockId
+	}
+	return nil
+}
+
+// RegisterTrace is a register accessed by a transaction, with the size of its value before the transaction and of
+// the value written by the transaction.
+type RegisterTrace struct {
+	Owner        []byte `protobuf:"bytes,1,opt,name=owner,proto3" json:"owner,omitempty"`
+	Controller   []byte `protobuf:"bytes,2,opt,name=controller,proto3" json:"controller,omitempty"`
+	Key          []byte `protobuf:"bytes,3,opt,name=key,proto3" json:"key,omitempty"`
+	OldValueSize uint64 `protobuf:"varint,4,opt,name=old_value_size,json=oldValueSize,proto3" json:"old_value_size,omitempty"`
+	NewValueSize uint64 `protobuf:"varint,5,opt,name=new_value_size,json=newValueSize,proto3" json:"new_value_size,omitempty"`
+}
+
+func (m *RegisterTrace) Reset()         { *m = RegisterTrace{} }
+func (m *RegisterTrace) String() string { return proto.CompactTextString(m) }
+func (*RegisterTrace) ProtoMessage()    {}
+
+func (m *RegisterTrace) GetOwner() []byte {
+	if m != nil {
+		return m.Owner
+	}
+	return nil
+}
+
+func (m *RegisterTrace) GetController() []byte {
+	if m != nil {
+		return m.Controller
+	}
+	return nil
+}
+
+func (m *RegisterTrace) GetKey() []byte {
+	if m != nil {
+		return m.Key
+	}
+	return nil
+}
+
+func (m *RegisterTrace) GetOldValueSize() uint64 {
+	if m != nil {
+		return m.OldValueSize
+	}
+	return 0
+}
+
+func (m *RegisterTrace) GetNewValueSize() uint64 {
+	if m != nil {
+		return m.NewValueSize
+	}
+	return 0
+}
+
+// TransactionTrace is the execution trace of a transaction in a block.
+type TransactionTrace struct {
+	BlockId          []byte            `protobuf:"bytes,1,opt,name=block_id,json=blockId,proto3" json:"block_id,omitempty"`
+	TransactionId    []byte            `protobuf:"bytes,2,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"`
+	TransactionIndex uint32            `protobuf:"varint,3,opt,name=transaction_index,json=transactionIndex,proto3" json:"transaction_index,omitempty"`
+	Reads            []*RegisterTrace  `protobuf:"bytes,4,rep,name=reads,proto3" json:"reads,omitempty"`
+	Writes           []*RegisterTrace  `protobuf:"bytes,5,rep,name=writes,proto3" json:"writes,omitempty"`
+	Programs         []string          `protobuf:"bytes,6,rep,name=programs,proto3" json:"programs,omitempty"`
+	ComputationUsed  uint64            `protobuf:"varint,7,opt,name=computation_used,json=computationUsed,proto3" json:"computation_used,omitempty"`
+	Events           []*entities.Event `protobuf:"bytes,8,rep,name=events,proto3" json:"events,omitempty"`
+}
+
+func (m *TransactionTrace) Reset()         { *m = TransactionTrace{} }
+func (m *TransactionTrace) String() string { return proto.CompactTextString(m) }
+func (*TransactionTrace) ProtoMessage()    {}
+
+func (m *TransactionTrace) GetBlockId() []byte {
+	if m != nil {
+		return m.BlockId
+	}
+	return nil
+}
+
+func (m *TransactionTrace) GetTransactionId() []byte {
+	if m != nil {
+		return m.TransactionId
+	}
+	return nil
+}
+
+func (m *TransactionTrace) GetTransactionIndex() uint32 {
+	if m != nil {
+		return m.TransactionIndex
+	}
+	return 0
+}
+
+func (m *TransactionTrace) GetReads() []*RegisterTrace {
+	if m != nil {
+		return m.Reads
+	}
+	return nil
+}
+
+func (m *TransactionTrace) GetWrites() []*RegisterTrace {
+	if m != nil {
+		return m.Writes
+	}
+	return nil
+}
+
+func (m *TransactionTrace) GetPrograms() []string {
+	if m != nil {
+		return m.Programs
+	}
+	return nil
+}
+
+func (m *TransactionTrace) GetComputationUsed() uint64 {
+	if m != nil {
+		return m.ComputationUsed
+	}
+	return 0
+}
+
+func (m *TransactionTrace) GetEvents() []*entities.Event {
+	if m != nil {
+		return m.Events
+	}
+	return nil
+}
+
+// TransactionTracesResponse holds the execution traces of a transaction.
+type TransactionTracesResponse struct {
+	Traces []*TransactionTrace `protobuf:"bytes,1,rep,name=traces,proto3" json:"traces,omitempty"`
+}
+
+func (m *TransactionTracesResponse) Reset()         { *m = TransactionTracesResponse{} }
+func (m *TransactionTracesResponse) String() string { return proto.CompactTextString(m) }
+func (*TransactionTracesResponse) ProtoMessage()    {}
+
+func (m *TransactionTracesResponse) GetTraces() []*TransactionTrace {
+	if m != nil {
+		return m.Traces
+	}
+	return nil
+}
+
+// ExecutionTraceAPIServer is the server API for the ExecutionTraceAPI service.
+type ExecutionTraceAPIServer interface {
+	GetTransactionTraces(context.Context, *GetTransactionTracesRequest) (*TransactionTracesResponse, error)
+}
+
+func getTransactionTracesHandler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTransactionTracesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExecutionTraceAPIServer).GetTransactionTraces(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/flow.execution.ExecutionTraceAPI/GetTransactionTraces",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExecutionTraceAPIServer).GetTransactionTraces(ctx, req.(*GetTransactionTracesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var executionTraceAPIServiceDesc = grpc.ServiceDesc{
+	ServiceName: "flow.execution.ExecutionTraceAPI",
+	HandlerType: (*ExecutionTraceAPIServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetTransactionTraces",
+			Handler:    getTransactionTracesHandler,
+		},
+	},
+	Streams: []grpc.StreamDesc{},
+}
+
+// RegisterExecutionTraceAPIServer registers the trace endpoint of the Execution API with the gRPC server.
+func RegisterExecutionTraceAPIServer(s *grpc.Server, srv ExecutionTraceAPIServer) {
+	s.RegisterService(&executionTraceAPIServiceDesc, srv)
+}
+
+// ExecutionTraceAPIClient is the client API for the ExecutionTraceAPI service.
+type ExecutionTraceAPIClient interface {
+	GetTransactionTraces(ctx context.Context, in *GetTransactionTracesRequest, opts ...grpc.CallOption) (*TransactionTracesResponse, error)
+}
+
+type executionTraceAPIClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewExecutionTraceAPIClient(cc grpc.ClientConnInterface) ExecutionTraceAPIClient {
+	return &executionTraceAPIClient{cc}
+}
+
+func (c *executionTraceAPIClient) GetTransactionTraces(ctx context.Context, in *GetTransactionTracesRequest, opts ...grpc.CallOption) (*TransactionTracesResponse, error) {
+	out := new(TransactionTracesResponse)
+	err := c.cc.Invoke(ctx, "/flow.execution.ExecutionTraceAPI/GetTransactionTraces", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
@@ -87,11 +87,13 @@ func registerConduits(net module.Network, state protocol.State, eng network.Engi
 		engine.ConsensusCommittee,
 		engine.SyncCommittee,
 		engine.SyncExecution,
+		engine.SyncExecutionState,
 		engine.PushTransactions,
 		engine.PushGuarantees,
 		engine.PushBlocks,
 		engine.PushReceipts,
 		engine.PushApprovals,
+		engine.PushChallenges,
 		engine.RequestCollections,
 		engine.RequestChunks,
 	}
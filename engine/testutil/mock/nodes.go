@@ -22,8 +22,8 @@ import (
 	"github.com/onflow/flow-go/engine/execution/ingestion"
 	executionprovider "github.com/onflow/flow-go/engine/execution/provider"
 	"github.com/onflow/flow-go/engine/execution/state"
-	"github.com/onflow/flow-go/engine/verification/finder"
-	"github.com/onflow/flow-go/engine/verification/match"
+	"github.com/onflow/flow-go/engine/verification/fetcher"
+	"github.com/onflow/flow-go/engine/verification/pipeline"
 	"github.com/onflow/flow-go/fvm"
 	fvmState "github.com/onflow/flow-go/fvm/state"
 	"github.com/onflow/flow-go/ledger"
@@ -35,7 +35,6 @@ import (
 	"github.com/onflow/flow-go/module/mempool"
 	"github.com/onflow/flow-go/module/mempool/entity"
 	"github.com/onflow/flow-go/module/metrics"
-	"github.com/onflow/flow-go/network"
 	"github.com/onflow/flow-go/network/stub"
 	"github.com/onflow/flow-go/state/protocol"
 	"github.com/onflow/flow-go/state/protocol/events"
@@ -192,18 +191,6 @@ func (en ExecutionNode) AssertHighestExecutedBlock(t *testing.T, header *flow.He
 // VerificationNode implements an in-process verification node for tests.
 type VerificationNode struct {
 	*GenericNode
-	CachedReceipts           mempool.ReceiptDataPacks
-	ReadyReceipts            mempool.ReceiptDataPacks
-	PendingReceipts          mempool.ReceiptDataPacks
-	PendingResults           mempool.ResultDataPacks
-	ProcessedResultIDs       mempool.Identifiers
-	DiscardedResultIDs       mempool.Identifiers
-	BlockIDsCache            mempool.Identifiers
-	PendingReceiptIDsByBlock mempool.IdentifierMap
-	ReceiptIDsByResult       mempool.IdentifierMap
-	ChunkIDsByResult         mempool.IdentifierMap
-	PendingChunks            *match.Chunks
-	VerifierEngine           network.Engine
-	FinderEngine             *finder.Engine
-	MatchEngine              network.Engine
+	PendingChunks *fetcher.Chunks
+	Pipeline      *pipeline.Pipeline
 }
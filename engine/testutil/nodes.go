@@ -31,9 +31,8 @@ import (
 	executionState "github.com/onflow/flow-go/engine/execution/state"
 	bootstrapexec "github.com/onflow/flow-go/engine/execution/state/bootstrap"
 	testmock "github.com/onflow/flow-go/engine/testutil/mock"
-	"github.com/onflow/flow-go/engine/verification/finder"
-	"github.com/onflow/flow-go/engine/verification/match"
-	"github.com/onflow/flow-go/engine/verification/verifier"
+	"github.com/onflow/flow-go/engine/verification/fetcher"
+	"github.com/onflow/flow-go/engine/verification/pipeline"
 	"github.com/onflow/flow-go/fvm"
 	"github.com/onflow/flow-go/ledger/common/pathfinder"
 	completeLedger "github.com/onflow/flow-go/ledger/complete"
@@ -51,11 +50,11 @@ import (
 	"github.com/onflow/flow-go/module/mempool/epochs"
 	"github.com/onflow/flow-go/module/mempool/stdmap"
 	"github.com/onflow/flow-go/module/metrics"
+	"github.com/onflow/flow-go/module/misbehavior"
 	"github.com/onflow/flow-go/module/signature"
 	chainsync "github.com/onflow/flow-go/module/synchronization"
 	"github.com/onflow/flow-go/module/trace"
 	"github.com/onflow/flow-go/module/validation"
-	"github.com/onflow/flow-go/network"
 	"github.com/onflow/flow-go/network/stub"
 	"github.com/onflow/flow-go/state/protocol"
 	badgerstate "github.com/onflow/flow-go/state/protocol/badger"
@@ -257,6 +256,14 @@ func ConsensusNode(t *testing.T, hub *stub.Hub, identity *flow.Identity, identit
 	receiptValidator := validation.NewReceiptValidator(node.State, node.Headers, node.Index, resultsDB, node.Seals,
 		signature.NewAggregationVerifier(encoding.ExecutionReceiptTag))
 	approvalValidator := validation.NewApprovalValidator(node.State, signature.NewAggregationVerifier(encoding.ResultApprovalTag))
+	vmCtx := fvm.NewContext(
+		node.Log,
+		fvm.WithChain(node.ChainID.Chain()),
+		fvm.WithBlocks(fvm.NewBlockFinder(node.Headers)),
+	)
+	chunkVerifier := chunks.NewChunkVerifier(fvm.NewVirtualMachine(fvm.NewInterpreterRuntime()), vmCtx)
+	challengeValidator := validation.NewChallengeValidator(node.State, node.Index, chunkVerifier,
+		signature.NewAggregationVerifier(encoding.ChallengeTag), signature.NewAggregationVerifier(encoding.ExecutionReceiptTag))
 
 	sealingEngine, err := sealing.NewEngine(
 		node.Log,
@@ -271,6 +278,7 @@ func ConsensusNode(t *testing.T, hub *stub.Hub, identity *flow.Identity, identit
 		receiptsDB,
 		node.Headers,
 		node.Index,
+		storage.NewChallenges(node.DB),
 		results,
 		receipts,
 		approvals,
@@ -279,7 +287,10 @@ func ConsensusNode(t *testing.T, hub *stub.Hub, identity *flow.Identity, identit
 		assigner,
 		receiptValidator,
 		approvalValidator,
+		challengeValidator,
 		validation.DefaultRequiredApprovalsForSealValidation,
+		sealing.DefaultRequiredChallengesForWithholding,
+		sealing.DefaultChallengeTimeout,
 		sealing.DefaultEmergencySealingActive)
 	require.Nil(t, err)
 
@@ -379,8 +390,9 @@ func ExecutionNode(t *testing.T, hub *stub.Hub, identity *flow.Identity, identit
 	require.NoError(t, err)
 
 	metrics := metrics.NewNoopCollector()
+	executionDatas := storage.NewExecutionDatas(node.DB)
 	pusherEngine, err := executionprovider.New(
-		node.Log, node.Tracer, node.Net, node.State, node.Me, execState, metrics, checkStakedAtBlock,
+		node.Log, node.Tracer, node.Net, node.State, node.Me, execState, executionDatas, metrics, checkStakedAtBlock,
 	)
 	require.NoError(t, err)
 
@@ -407,6 +419,8 @@ func ExecutionNode(t *testing.T, hub *stub.Hub, identity *flow.Identity, identit
 		vmCtx,
 		computation.DefaultProgramsCacheSize,
 		committer,
+		0,
+		false,
 	)
 	require.NoError(t, err)
 
@@ -434,6 +448,9 @@ func ExecutionNode(t *testing.T, hub *stub.Hub, identity *flow.Identity, identit
 		eventsStorage,
 		serviceEventsStorage,
 		txResultStorage,
+		storage.NewTransactionTraces(node.DB),
+		executionDatas,
+		node.DB,
 		computation,
 		pusherEngine,
 		execState,
@@ -445,6 +462,9 @@ func ExecutionNode(t *testing.T, hub *stub.Hub, identity *flow.Identity, identit
 		syncThreshold,
 		false,
 		checkStakedAtBlock,
+		func() bool { return false },
+		false,
+		true,
 	)
 	require.NoError(t, err)
 	requestEngine.WithHandle(ingestionEngine.OnCollection)
@@ -581,32 +601,21 @@ func createFollowerCore(t *testing.T, node *testmock.GenericNode, followerState
 
 type VerificationOpt func(*testmock.VerificationNode)
 
-func WithVerifierEngine(eng network.Engine) VerificationOpt {
-	return func(node *testmock.VerificationNode) {
-		node.VerifierEngine = eng
-	}
-}
-
-func WithMatchEngine(eng network.Engine) VerificationOpt {
-	return func(node *testmock.VerificationNode) {
-		node.MatchEngine = eng
-	}
-}
-
 func WithGenericNode(genericNode *testmock.GenericNode) VerificationOpt {
 	return func(node *testmock.VerificationNode) {
 		node.GenericNode = genericNode
 	}
 }
 
+// VerificationNode creates a verification node running the verification pipeline, which requests the chunk data packs
+// of its assigned chunks every requestInterval, at most failureThreshold times, and penalizes the execution nodes
+// reported for misbehaving failureThreshold times.
 func VerificationNode(t testing.TB,
 	hub *stub.Hub,
 	identity *flow.Identity,
 	identities []*flow.Identity,
 	assigner module.ChunkAssigner,
 	requestInterval time.Duration,
-	processInterval time.Duration,
-	receiptsLimit uint,
 	chunksLimit uint,
 	failureThreshold uint,
 	chainID flow.ChainID,
@@ -626,103 +635,15 @@ func VerificationNode(t testing.TB,
 		node.GenericNode = &gn
 	}
 
-	if node.CachedReceipts == nil {
-		node.CachedReceipts, err = stdmap.NewReceiptDataPacks(receiptsLimit)
-		require.Nil(t, err)
-		// registers size method of backend for metrics
-		err = mempoolCollector.Register(metrics.ResourceCachedReceipt, node.CachedReceipts.Size)
-		require.Nil(t, err)
-	}
-
-	if node.PendingReceipts == nil {
-		node.PendingReceipts, err = stdmap.NewReceiptDataPacks(receiptsLimit)
-		require.Nil(t, err)
-
-		// registers size method of backend for metrics
-		err = mempoolCollector.Register(metrics.ResourcePendingReceipt, node.PendingReceipts.Size)
-		require.Nil(t, err)
-	}
-
-	if node.ReadyReceipts == nil {
-		node.ReadyReceipts, err = stdmap.NewReceiptDataPacks(receiptsLimit)
-		require.Nil(t, err)
-		// registers size method of backend for metrics
-		err = mempoolCollector.Register(metrics.ResourceReceipt, node.ReadyReceipts.Size)
-		require.Nil(t, err)
-	}
-
-	if node.PendingResults == nil {
-		node.PendingResults = stdmap.NewResultDataPacks(receiptsLimit)
-		require.Nil(t, err)
-
-		// registers size method of backend for metrics
-		err = mempoolCollector.Register(metrics.ResourcePendingResult, node.PendingResults.Size)
-		require.Nil(t, err)
-	}
-
 	if node.PendingChunks == nil {
-		node.PendingChunks = match.NewChunks(chunksLimit)
+		node.PendingChunks = fetcher.NewChunks(chunksLimit)
 
 		// registers size method of backend for metrics
 		err = mempoolCollector.Register(metrics.ResourcePendingChunk, node.PendingChunks.Size)
 		require.Nil(t, err)
 	}
 
-	if node.ProcessedResultIDs == nil {
-		node.ProcessedResultIDs, err = stdmap.NewIdentifiers(receiptsLimit)
-		require.Nil(t, err)
-
-		// registers size method of backend for metrics
-		err = mempoolCollector.Register(metrics.ResourceProcessedResultID, node.ProcessedResultIDs.Size)
-		require.Nil(t, err)
-	}
-
-	if node.DiscardedResultIDs == nil {
-		node.DiscardedResultIDs, err = stdmap.NewIdentifiers(receiptsLimit)
-		require.Nil(t, err)
-
-		// registers size method of backend for metrics
-		err = mempoolCollector.Register(metrics.ResourceDiscardedResultID, node.DiscardedResultIDs.Size)
-		require.Nil(t, err)
-	}
-
-	if node.BlockIDsCache == nil {
-		node.BlockIDsCache, err = stdmap.NewIdentifiers(1000)
-		require.Nil(t, err)
-
-		// registers size method of backend for metrics
-		err = mempoolCollector.Register(metrics.ResourceCachedBlockID, node.BlockIDsCache.Size)
-		require.Nil(t, err)
-	}
-
-	if node.PendingReceiptIDsByBlock == nil {
-		node.PendingReceiptIDsByBlock, err = stdmap.NewIdentifierMap(receiptsLimit)
-		require.Nil(t, err)
-
-		// registers size method of backend for metrics
-		err = mempoolCollector.Register(metrics.ResourcePendingReceiptIDsByBlock, node.PendingReceiptIDsByBlock.Size)
-		require.Nil(t, err)
-	}
-
-	if node.ReceiptIDsByResult == nil {
-		node.ReceiptIDsByResult, err = stdmap.NewIdentifierMap(receiptsLimit)
-		require.Nil(t, err)
-
-		// registers size method of backend for metrics
-		err = mempoolCollector.Register(metrics.ResourceReceiptIDsByResult, node.ReceiptIDsByResult.Size)
-		require.Nil(t, err)
-	}
-
-	if node.ChunkIDsByResult == nil {
-		node.ChunkIDsByResult, err = stdmap.NewIdentifierMap(chunksLimit)
-		require.Nil(t, err)
-
-		// registers size method of backend for metrics
-		err = mempoolCollector.Register(metrics.ResourceChunkIDsByResult, node.ChunkIDsByResult.Size)
-		require.Nil(t, err)
-	}
-
-	if node.VerifierEngine == nil {
+	if node.Pipeline == nil {
 		rt := fvm.NewInterpreterRuntime()
 
 		vm := fvm.NewVirtualMachine(rt)
@@ -737,55 +658,35 @@ func VerificationNode(t testing.TB,
 
 		chunkVerifier := chunks.NewChunkVerifier(vm, vmCtx)
 
-		approvalStorage := storage.NewResultApprovals(node.Metrics, node.DB)
+		results := storage.NewExecutionResults(node.Metrics, node.DB)
 
-		node.VerifierEngine, err = verifier.New(node.Log,
-			collector,
-			node.Tracer,
-			node.Net,
-			node.State,
-			node.Me,
-			chunkVerifier,
-			approvalStorage)
-		require.Nil(t, err)
-	}
+		config := pipeline.DefaultConfig()
+		config.VerifierWorkers = 1
+		config.ChunkTimeout = 0
+		config.RetryInterval = requestInterval
+		config.MaxAttempts = int(failureThreshold)
 
-	if node.MatchEngine == nil {
-		node.MatchEngine, err = match.New(node.Log,
+		node.Pipeline, err = pipeline.New(node.Log,
 			collector,
 			node.Tracer,
 			node.Net,
 			node.Me,
-			node.PendingResults,
-			node.ChunkIDsByResult,
-			node.VerifierEngine,
-			assigner,
 			node.State,
+			chunkVerifier,
+			assigner,
+			misbehavior.NewReporter(node.Log, failureThreshold),
 			node.PendingChunks,
 			node.Headers,
-			requestInterval,
-			int(failureThreshold))
-		require.Nil(t, err)
-	}
-
-	if node.FinderEngine == nil {
-		node.FinderEngine, err = finder.New(node.Log,
-			collector,
-			node.Tracer,
-			node.Net,
-			node.Me,
-			node.State,
-			node.MatchEngine,
-			node.CachedReceipts,
-			node.PendingReceipts,
-			node.ReadyReceipts,
-			node.Headers,
-			node.ProcessedResultIDs,
-			node.DiscardedResultIDs,
-			node.PendingReceiptIDsByBlock,
-			node.ReceiptIDsByResult,
-			node.BlockIDsCache,
-			processInterval)
+			node.Blocks,
+			results,
+			storage.NewExecutionReceipts(node.Metrics, node.DB, results),
+			storage.NewResultApprovals(node.Metrics, node.DB),
+			storage.NewChunkStatuses(node.DB),
+			storage.NewChunkVerdicts(node.DB),
+			storage.NewChunkQueue(node.DB),
+			storage.NewConsumerProgress(node.DB, module.ConsumeProgressVerificationChunkIndex),
+			storage.NewConsumerProgress(node.DB, module.ConsumeProgressVerificationBlockHeight),
+			config)
 		require.Nil(t, err)
 	}
 
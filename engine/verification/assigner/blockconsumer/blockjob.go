@@ -25,8 +25,8 @@ func jobID(blockID flow.Identifier) module.JobID {
 	return module.JobID(fmt.Sprintf("%v", blockID))
 }
 
-// jobToBlock converts a block job into its corresponding block.
-func jobToBlock(job module.Job) (*flow.Block, error) {
+// JobToBlock converts a block job into its corresponding block.
+func JobToBlock(job module.Job) (*flow.Block, error) {
 	blockJob, ok := job.(*BlockJob)
 	if !ok {
 		return nil, fmt.Errorf("could not assert job to block, job id: %x", job.ID())
@@ -34,7 +34,7 @@ func jobToBlock(job module.Job) (*flow.Block, error) {
 	return blockJob.Block, nil
 }
 
-// blockToJob converts the block to a BlockJob.
-func blockToJob(block *flow.Block) *BlockJob {
+// BlockToJob converts the block to a BlockJob.
+func BlockToJob(block *flow.Block) *BlockJob {
 	return &BlockJob{Block: block}
 }
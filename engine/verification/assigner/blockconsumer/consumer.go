@@ -48,7 +48,7 @@ func NewBlockConsumer(log zerolog.Logger,
 	blockProcessor.WithBlockConsumerNotifier(worker)
 
 	// the block reader is where the consumer reads new finalized blocks from (i.e., jobs).
-	jobs := newFinalizedBlockReader(state, blocks)
+	jobs := NewFinalizedBlockReader(state, blocks)
 
 	consumer := jobqueue.NewConsumer(log, jobs, processedHeight, worker, maxProcessing)
 	defaultIndex, err := defaultProcessedIndex(state)
@@ -1,4 +1,4 @@
-package blockconsumer
+package blockconsumer_test
 
 import (
 	"testing"
@@ -7,6 +7,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/onflow/flow-go/engine/testutil"
+	"github.com/onflow/flow-go/engine/verification/assigner/blockconsumer"
 	"github.com/onflow/flow-go/engine/verification/test"
 	"github.com/onflow/flow-go/engine/verification/utils"
 	"github.com/onflow/flow-go/model/flow"
@@ -18,7 +19,7 @@ import (
 // TestBlockReader evaluates that block reader correctly reads stored finalized blocks from the blocks storage and
 // protocol state.
 func TestBlockReader(t *testing.T) {
-	withReader(t, 10, func(reader *FinalizedBlockReader, blocks []*flow.Block) {
+	withReader(t, 10, func(reader *blockconsumer.FinalizedBlockReader, blocks []*flow.Block) {
 		// head of block reader should be the same height as the last block on the chain.
 		head, err := reader.Head()
 		require.NoError(t, err)
@@ -30,7 +31,7 @@ func TestBlockReader(t *testing.T) {
 			job, err := reader.AtIndex(index)
 			require.NoError(t, err)
 
-			retrieved, err := jobToBlock(job)
+			retrieved, err := blockconsumer.JobToBlock(job)
 			require.NoError(t, err)
 			require.Equal(t, actual.ID(), retrieved.ID())
 		}
@@ -43,7 +44,7 @@ func TestBlockReader(t *testing.T) {
 func withReader(
 	t *testing.T,
 	blockCount int,
-	withBlockReader func(*FinalizedBlockReader, []*flow.Block),
+	withBlockReader func(*blockconsumer.FinalizedBlockReader, []*flow.Block),
 ) {
 	require.Equal(t, blockCount%2, 0, "block count for this test should be even")
 	unittest.RunWithBadgerDB(t, func(db *badger.DB) {
@@ -53,7 +54,7 @@ func withReader(
 		participants := unittest.IdentityListFixture(5, unittest.WithAllRoles())
 		s := testutil.CompleteStateFixture(t, collector, tracer, participants)
 
-		reader := newFinalizedBlockReader(s.State, s.Storage.Blocks)
+		reader := blockconsumer.NewFinalizedBlockReader(s.State, s.Storage.Blocks)
 
 		// generates a chain of blocks in the form of root <- R1 <- C1 <- R2 <- C2 <- ... where Rs are distinct reference
 		// blocks (i.e., containing guarantees), and Cs are container blocks for their preceding reference block,
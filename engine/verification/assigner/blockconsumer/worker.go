@@ -31,7 +31,7 @@ func (w *worker) withBlockConsumer(consumer *BlockConsumer) {
 // It then converts the job to a block and passes it to the underlying engine
 // for processing.
 func (w *worker) Run(job module.Job) error {
-	block, err := jobToBlock(job)
+	block, err := JobToBlock(job)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,254 @@
+package dashboard
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/onflow/flow-go/model/chunks"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/model/verification"
+	"github.com/onflow/flow-go/state/protocol"
+	"github.com/onflow/flow-go/storage"
+)
+
+// FetchState is the state of fetching the chunk data pack of an assigned chunk.
+type FetchState string
+
+const (
+	// FetchQueued is the state of a chunk in the chunks queue that is not yet processed by the chunk consumer.
+	FetchQueued FetchState = "queued"
+	// FetchRequested is the state of a chunk whose chunk data pack is requested from the execution nodes.
+	FetchRequested FetchState = "requested"
+	// FetchReceived is the state of a chunk whose chunk data pack is received.
+	FetchReceived FetchState = "received"
+	// FetchDone is the state of a chunk processed by the chunk consumer that no longer has a status, i.e., the
+	// block of the chunk is sealed, or the chunk was dropped by the fetcher engine.
+	FetchDone FetchState = "done"
+)
+
+// Outcome is the outcome of verifying an assigned chunk.
+type Outcome string
+
+const (
+	// OutcomePending is the outcome of a chunk that is not yet verified.
+	OutcomePending Outcome = "pending"
+	// OutcomeApproved is the outcome of a chunk that passed verification and was approved.
+	OutcomeApproved Outcome = "approved"
+	// OutcomeChallenged is the outcome of a chunk that was found faulty and challenged.
+	OutcomeChallenged Outcome = "challenged"
+	// OutcomeUnchallenged is the outcome of a chunk that was found faulty, but not challenged, as the fault could not
+	// be attributed to the execution receipt of the result.
+	OutcomeUnchallenged Outcome = "unchallenged"
+	// OutcomeNotVerified is the outcome of a chunk whose block was sealed before the chunk was approved.
+	OutcomeNotVerified Outcome = "not_verified"
+)
+
+// Report is a report of the latest chunks assigned to the verification node.
+type Report struct {
+	LatestIndex    uint64         `json:"latest_index"`    // index of the latest chunk in the chunks queue
+	ProcessedIndex uint64         `json:"processed_index"` // index up to which the chunk consumer processed the chunks
+	SealedHeight   uint64         `json:"sealed_height"`   // height of the latest sealed block
+	Blocks         []*BlockReport `json:"blocks"`          // reported blocks, from the highest
+}
+
+// BlockReport is a report of the assigned chunks of a block.
+type BlockReport struct {
+	BlockID flow.Identifier `json:"block_id"`
+	Height  uint64          `json:"height"`
+	Sealed  bool            `json:"sealed"`
+	Chunks  []*ChunkReport  `json:"chunks"`
+}
+
+// ChunkReport is a report of the progress of an assigned chunk through the verification pipeline.
+type ChunkReport struct {
+	JobIndex          uint64            `json:"job_index"`
+	ResultID          flow.Identifier   `json:"result_id"`
+	ChunkIndex        uint64            `json:"chunk_index"`
+	ChunkID           flow.Identifier   `json:"chunk_id"`
+	FetchState        FetchState        `json:"fetch_state"`
+	Attempts          int               `json:"attempts,omitempty"`
+	InvalidResponders []flow.Identifier `json:"invalid_responders,omitempty"`
+	Outcome           Outcome           `json:"outcome"`
+	ApprovalID        *flow.Identifier  `json:"approval_id,omitempty"`
+	ChallengeID       *flow.Identifier  `json:"challenge_id,omitempty"`
+	Fault             string            `json:"fault,omitempty"`
+}
+
+// Dashboard reports the chunks assigned to the verification node, and their progress through the verification
+// pipeline. It is read-only, and is backed by the chunks queue the assigner engine stores the assigned chunks in,
+// the progress of the chunk consumer, the chunk statuses persisted by the fetcher engine, and the chunk verdicts
+// persisted by the verifier engine. As the verdicts are kept once the block of a chunk is sealed, the outcome of a
+// verified chunk is reported after its status is removed.
+type Dashboard struct {
+	state       protocol.State
+	headers     storage.Headers
+	results     storage.ExecutionResults
+	chunksQueue storage.ChunksQueue
+	progress    storage.ConsumerProgress
+	statuses    storage.ChunkStatuses
+	verdicts    storage.ChunkVerdicts
+}
+
+// New returns a dashboard of the chunks assigned to the verification node.
+func New(
+	state protocol.State,
+	headers storage.Headers,
+	results storage.ExecutionResults,
+	chunksQueue storage.ChunksQueue,
+	progress storage.ConsumerProgress,
+	statuses storage.ChunkStatuses,
+	verdicts storage.ChunkVerdicts,
+) *Dashboard {
+	return &Dashboard{
+		state:       state,
+		headers:     headers,
+		results:     results,
+		chunksQueue: chunksQueue,
+		progress:    progress,
+		statuses:    statuses,
+		verdicts:    verdicts,
+	}
+}
+
+// Report returns the report of the latest assigned chunks, at most limit of them, grouped by block.
+func (d *Dashboard) Report(limit uint) (*Report, error) {
+	sealed, err := d.state.Sealed().Head()
+	if err != nil {
+		return nil, fmt.Errorf("could not get last sealed: %w", err)
+	}
+
+	report := &Report{
+		SealedHeight: sealed.Height,
+		Blocks:       []*BlockReport{},
+	}
+
+	// the chunks queue and the consumer progress are initialized once the first chunk is assigned
+	report.LatestIndex, err = d.chunksQueue.LatestIndex()
+	if errors.Is(err, storage.ErrNotFound) {
+		return report, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("could not get latest index of chunks queue: %w", err)
+	}
+	report.ProcessedIndex, err = d.progress.ProcessedIndex()
+	if err != nil && !errors.Is(err, storage.ErrNotFound) {
+		return nil, fmt.Errorf("could not get processed index of chunks queue: %w", err)
+	}
+
+	blocks := make(map[flow.Identifier]*BlockReport)
+	for index := report.LatestIndex; index > 0 && limit > 0; index-- {
+		locator, err := d.chunksQueue.AtIndex(index)
+		if errors.Is(err, storage.ErrNotFound) {
+			// reached the default index of the chunks queue
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("could not get chunk locator at index %d: %w", index, err)
+		}
+
+		chunk, block, err := d.chunkReport(index, locator, report.ProcessedIndex, sealed.Height, blocks)
+		if err != nil {
+			return nil, fmt.Errorf("could not report chunk at index %d: %w", index, err)
+		}
+		blocks[block.BlockID] = block
+		block.Chunks = append(block.Chunks, chunk)
+		limit--
+	}
+
+	for _, block := range blocks {
+		sort.Slice(block.Chunks, func(i, j int) bool {
+			return block.Chunks[i].JobIndex < block.Chunks[j].JobIndex
+		})
+		report.Blocks = append(report.Blocks, block)
+	}
+	sort.Slice(report.Blocks, func(i, j int) bool {
+		return report.Blocks[i].Height > report.Blocks[j].Height
+	})
+
+	return report, nil
+}
+
+// chunkReport returns the report of the chunk located at the given index of the chunks queue, and the report of its
+// block, which is taken from the given block reports if it is already reported.
+func (d *Dashboard) chunkReport(
+	index uint64,
+	locator *chunks.Locator,
+	processedIndex uint64,
+	sealedHeight uint64,
+	blocks map[flow.Identifier]*BlockReport,
+) (*ChunkReport, *BlockReport, error) {
+	result, err := d.results.ByID(locator.ResultID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("could not get execution result %v: %w", locator.ResultID, err)
+	}
+	chunk, ok := result.Chunks.ByIndex(locator.Index)
+	if !ok {
+		return nil, nil, fmt.Errorf("execution result %v has no chunk at index %d", locator.ResultID, locator.Index)
+	}
+
+	block, ok := blocks[result.BlockID]
+	if !ok {
+		header, err := d.headers.ByBlockID(result.BlockID)
+		if err != nil {
+			return nil, nil, fmt.Errorf("could not get header of block %v: %w", result.BlockID, err)
+		}
+		block = &BlockReport{
+			BlockID: result.BlockID,
+			Height:  header.Height,
+			Sealed:  header.Height <= sealedHeight,
+		}
+	}
+
+	report := &ChunkReport{
+		JobIndex:   index,
+		ResultID:   locator.ResultID,
+		ChunkIndex: locator.Index,
+		ChunkID:    chunk.ID(),
+		FetchState: FetchDone,
+		Outcome:    OutcomePending,
+	}
+
+	status, err := d.statuses.ByChunkID(report.ChunkID)
+	switch {
+	case errors.Is(err, storage.ErrNotFound):
+		if index > processedIndex {
+			report.FetchState = FetchQueued
+		}
+	case err != nil:
+		return nil, nil, fmt.Errorf("could not get status of chunk %v: %w", report.ChunkID, err)
+	default:
+		report.FetchState = FetchRequested
+		if status.Received() {
+			report.FetchState = FetchReceived
+		}
+		report.Attempts = status.Attempt
+		report.InvalidResponders = status.InvalidResponders
+	}
+
+	verdict, err := d.verdicts.ByChunk(locator.ResultID, locator.Index)
+	switch {
+	case errors.Is(err, storage.ErrNotFound):
+		if block.Sealed {
+			report.Outcome = OutcomeNotVerified
+		}
+	case err != nil:
+		return nil, nil, fmt.Errorf("could not get verdict of chunk %v: %w", report.ChunkID, err)
+	default:
+		switch verdict.Verdict {
+		case verification.VerdictApproved:
+			report.Outcome = OutcomeApproved
+			report.ApprovalID = &verdict.ApprovalID
+		case verification.VerdictChallenged:
+			report.Outcome = OutcomeChallenged
+			report.ChallengeID = &verdict.ChallengeID
+		case verification.VerdictUnchallenged:
+			report.Outcome = OutcomeUnchallenged
+		default:
+			return nil, nil, fmt.Errorf("unknown verdict %q of chunk %v", verdict.Verdict, report.ChunkID)
+		}
+		report.Fault = verdict.Fault
+	}
+
+	return report, block, nil
+}
@@ -0,0 +1,245 @@
+package dashboard
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/model/chunks"
+	"github.com/onflow/flow-go/model/flow"
+	vermodel "github.com/onflow/flow-go/model/verification"
+	"github.com/onflow/flow-go/module"
+	"github.com/onflow/flow-go/module/metrics"
+	protocol "github.com/onflow/flow-go/state/protocol/mock"
+	bstorage "github.com/onflow/flow-go/storage/badger"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+// dashboardFixture holds a dashboard backed by badger storage, with the chunks of two results assigned:
+//
+//   - job 1: chunk 0 of the sealed block, approved
+//   - job 2: chunk 1 of the sealed block, challenged
+//   - job 3: chunk 2 of the sealed block, processed without being verified
+//   - job 4: chunk 0 of the unsealed block, requested from an executor after an invalid response
+//   - job 5: chunk 1 of the unsealed block, received and found faulty without being challenged
+//   - job 6: chunk 2 of the unsealed block, queued
+//
+// As the block of the chunks of the sealed block is sealed, their statuses are removed.
+type dashboardFixture struct {
+	dashboard   *Dashboard
+	sealed      *flow.Header
+	unsealed    *flow.Header
+	results     []*flow.ExecutionResult
+	approvalID  flow.Identifier
+	challengeID flow.Identifier
+	invalidID   flow.Identifier
+}
+
+func newDashboardFixture(t *testing.T, db *badger.DB) *dashboardFixture {
+	collector := metrics.NewNoopCollector()
+	headers := bstorage.NewHeaders(collector, db)
+	results := bstorage.NewExecutionResults(collector, db)
+	statuses := bstorage.NewChunkStatuses(db)
+	chunksQueue := bstorage.NewChunkQueue(db)
+	progress := bstorage.NewConsumerProgress(db, module.ConsumeProgressVerificationChunkIndex)
+	verdicts := bstorage.NewChunkVerdicts(db)
+
+	f := &dashboardFixture{
+		approvalID:  unittest.IdentifierFixture(),
+		challengeID: unittest.IdentifierFixture(),
+		invalidID:   unittest.IdentifierFixture(),
+	}
+
+	sealed := unittest.BlockHeaderFixture()
+	unsealed := unittest.BlockHeaderWithParentFixture(&sealed)
+	f.sealed, f.unsealed = &sealed, &unsealed
+	for _, header := range []*flow.Header{f.sealed, f.unsealed} {
+		require.NoError(t, headers.Store(header))
+		result := unittest.ExecutionResultFixture()
+		result.BlockID = header.ID()
+		result.Chunks = unittest.ChunkListFixture(3, header.ID())
+		require.NoError(t, results.Store(result))
+		f.results = append(f.results, result)
+	}
+
+	_, err := chunksQueue.Init(0)
+	require.NoError(t, err)
+	for _, result := range f.results {
+		for _, chunk := range result.Chunks {
+			ok, err := chunksQueue.StoreChunkLocator(&chunks.Locator{ResultID: result.ID(), Index: chunk.Index})
+			require.NoError(t, err)
+			require.True(t, ok)
+		}
+	}
+	require.NoError(t, progress.InitProcessedIndex(0))
+	require.NoError(t, progress.SetProcessedIndex(5))
+
+	sealedResultID := f.results[0].ID()
+	require.NoError(t, verdicts.Store(&vermodel.ChunkVerdict{
+		ExecutionResultID: sealedResultID,
+		ChunkIndex:        0,
+		Verdict:           vermodel.VerdictApproved,
+		ApprovalID:        f.approvalID,
+	}))
+	require.NoError(t, verdicts.Store(&vermodel.ChunkVerdict{
+		ExecutionResultID: sealedResultID,
+		ChunkIndex:        1,
+		Verdict:           vermodel.VerdictChallenged,
+		ChallengeID:       f.challengeID,
+		Fault:             "final state commitment mismatch",
+	}))
+
+	unsealedResult := f.results[1]
+	requested := vermodel.NewChunkStatus(unsealedResult.Chunks[0], unsealedResult.ID(), unsealed.Height, nil, nil)
+	requested.Attempt = 2
+	requested.InvalidResponders = []flow.Identifier{f.invalidID}
+	require.NoError(t, statuses.Store(requested))
+
+	verified := vermodel.NewChunkStatus(unsealedResult.Chunks[1], unsealedResult.ID(), unsealed.Height, nil, nil)
+	verified.Attempt = 1
+	verified.ChunkDataPack = unittest.ChunkDataPackFixture(unsealedResult.Chunks[1].ID())
+	verified.Collection = &flow.Collection{}
+	verified.Verified = true
+	require.NoError(t, statuses.Store(verified))
+	require.NoError(t, verdicts.Store(&vermodel.ChunkVerdict{
+		ExecutionResultID: unsealedResult.ID(),
+		ChunkIndex:        1,
+		Verdict:           vermodel.VerdictUnchallenged,
+		Fault:             "missing register touch",
+	}))
+
+	snapshot := &protocol.Snapshot{}
+	snapshot.On("Head").Return(f.sealed, nil)
+	state := &protocol.State{}
+	state.On("Sealed").Return(snapshot)
+
+	f.dashboard = New(state, headers, results, chunksQueue, progress, statuses, verdicts)
+	return f
+}
+
+// TestReport evaluates that the dashboard reports the assigned chunks by block from the highest, with the state of
+// fetching their chunk data packs and the outcome of their verification.
+func TestReport(t *testing.T) {
+	unittest.RunWithBadgerDB(t, func(db *badger.DB) {
+		f := newDashboardFixture(t, db)
+
+		report, err := f.dashboard.Report(DefaultLimit)
+		require.NoError(t, err)
+		require.Equal(t, uint64(6), report.LatestIndex)
+		require.Equal(t, uint64(5), report.ProcessedIndex)
+		require.Equal(t, f.sealed.Height, report.SealedHeight)
+		require.Len(t, report.Blocks, 2)
+
+		unsealed := report.Blocks[0]
+		require.Equal(t, f.unsealed.ID(), unsealed.BlockID)
+		require.Equal(t, f.unsealed.Height, unsealed.Height)
+		require.False(t, unsealed.Sealed)
+		require.Len(t, unsealed.Chunks, 3)
+
+		requested := unsealed.Chunks[0]
+		require.Equal(t, uint64(4), requested.JobIndex)
+		require.Equal(t, f.results[1].Chunks[0].ID(), requested.ChunkID)
+		require.Equal(t, FetchRequested, requested.FetchState)
+		require.Equal(t, 2, requested.Attempts)
+		require.Equal(t, []flow.Identifier{f.invalidID}, requested.InvalidResponders)
+		require.Equal(t, OutcomePending, requested.Outcome)
+
+		unchallenged := unsealed.Chunks[1]
+		require.Equal(t, FetchReceived, unchallenged.FetchState)
+		require.Equal(t, OutcomeUnchallenged, unchallenged.Outcome)
+		require.Equal(t, "missing register touch", unchallenged.Fault)
+		require.Nil(t, unchallenged.ApprovalID)
+		require.Nil(t, unchallenged.ChallengeID)
+
+		queued := unsealed.Chunks[2]
+		require.Equal(t, uint64(6), queued.JobIndex)
+		require.Equal(t, FetchQueued, queued.FetchState)
+		require.Equal(t, OutcomePending, queued.Outcome)
+
+		sealed := report.Blocks[1]
+		require.Equal(t, f.sealed.ID(), sealed.BlockID)
+		require.True(t, sealed.Sealed)
+		require.Len(t, sealed.Chunks, 3)
+
+		approved := sealed.Chunks[0]
+		require.Equal(t, FetchDone, approved.FetchState)
+		require.Equal(t, OutcomeApproved, approved.Outcome)
+		require.Equal(t, f.approvalID, *approved.ApprovalID)
+
+		// the outcome of a verified chunk is kept once its status is removed at sealing
+		challenged := sealed.Chunks[1]
+		require.Equal(t, FetchDone, challenged.FetchState)
+		require.Equal(t, OutcomeChallenged, challenged.Outcome)
+		require.Equal(t, f.challengeID, *challenged.ChallengeID)
+		require.Equal(t, "final state commitment mismatch", challenged.Fault)
+		require.Nil(t, challenged.ApprovalID)
+
+		notVerified := sealed.Chunks[2]
+		require.Equal(t, FetchDone, notVerified.FetchState)
+		require.Equal(t, OutcomeNotVerified, notVerified.Outcome)
+	})
+}
+
+// TestReport_Empty evaluates that the dashboard reports no blocks before any chunk is assigned.
+func TestReport_Empty(t *testing.T) {
+	unittest.RunWithBadgerDB(t, func(db *badger.DB) {
+		sealed := unittest.BlockHeaderFixture()
+		snapshot := &protocol.Snapshot{}
+		snapshot.On("Head").Return(&sealed, nil)
+		state := &protocol.State{}
+		state.On("Sealed").Return(snapshot)
+
+		collector := metrics.NewNoopCollector()
+		dashboard := New(state,
+			bstorage.NewHeaders(collector, db),
+			bstorage.NewExecutionResults(collector, db),
+			bstorage.NewChunkQueue(db),
+			bstorage.NewConsumerProgress(db, module.ConsumeProgressVerificationChunkIndex),
+			bstorage.NewChunkStatuses(db),
+			bstorage.NewChunkVerdicts(db))
+
+		report, err := dashboard.Report(DefaultLimit)
+		require.NoError(t, err)
+		require.Equal(t, sealed.Height, report.SealedHeight)
+		require.Empty(t, report.Blocks)
+	})
+}
+
+// TestAssignedChunks evaluates that the assigned chunks endpoint serves the latest assigned chunks up to the
+// requested limit, and rejects invalid requests.
+func TestAssignedChunks(t *testing.T) {
+	unittest.RunWithBadgerDB(t, func(db *badger.DB) {
+		f := newDashboardFixture(t, db)
+		server := NewServer(f.dashboard, "", unittest.Logger())
+
+		t.Run("limited report", func(t *testing.T) {
+			rr := httptest.NewRecorder()
+			server.Handler.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/v1/assigned_chunks?limit=2", nil))
+			require.Equal(t, http.StatusOK, rr.Code)
+
+			var report Report
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &report))
+			require.Len(t, report.Blocks, 1)
+			require.Equal(t, f.unsealed.ID(), report.Blocks[0].BlockID)
+			require.Len(t, report.Blocks[0].Chunks, 2)
+			require.Equal(t, uint64(5), report.Blocks[0].Chunks[0].JobIndex)
+			require.Equal(t, uint64(6), report.Blocks[0].Chunks[1].JobIndex)
+		})
+
+		t.Run("invalid limit", func(t *testing.T) {
+			rr := httptest.NewRecorder()
+			server.Handler.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/v1/assigned_chunks?limit=x", nil))
+			require.Equal(t, http.StatusBadRequest, rr.Code)
+		})
+
+		t.Run("method not allowed", func(t *testing.T) {
+			rr := httptest.NewRecorder()
+			server.Handler.ServeHTTP(rr, httptest.NewRequest(http.MethodPost, "/v1/assigned_chunks", nil))
+			require.Equal(t, http.StatusMethodNotAllowed, rr.Code)
+		})
+	})
+}
@@ -0,0 +1,68 @@
+package dashboard
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/rs/zerolog"
+
+	"github.com/onflow/flow-go/engine"
+)
+
+// Engine serves the dashboard of the chunks assigned to the verification node over HTTP.
+type Engine struct {
+	unit   *engine.Unit
+	log    zerolog.Logger
+	server *http.Server // the HTTP server of the dashboard, nil if not served
+}
+
+// NewEngine returns an engine serving the dashboard on the given address, the dashboard is not served if the address
+// is empty.
+func NewEngine(log zerolog.Logger, dashboard *Dashboard, address string) *Engine {
+	log = log.With().Str("engine", "dashboard").Logger()
+	eng := &Engine{
+		unit: engine.NewUnit(),
+		log:  log,
+	}
+
+	if address != "" {
+		eng.server = NewServer(dashboard, address, log)
+	}
+
+	return eng
+}
+
+// Ready returns a ready channel that is closed once the engine has fully started.
+func (e *Engine) Ready() <-chan struct{} {
+	if e.server != nil {
+		e.unit.Launch(e.serve)
+	}
+	return e.unit.Ready()
+}
+
+// Done returns a done channel that is closed once the engine has fully stopped.
+func (e *Engine) Done() <-chan struct{} {
+	return e.unit.Done(func() {
+		if e.server == nil {
+			return
+		}
+		err := e.server.Shutdown(context.Background())
+		if err != nil {
+			e.log.Error().Err(err).Msg("error stopping dashboard server")
+		}
+	})
+}
+
+// serve starts the HTTP server of the dashboard.
+func (e *Engine) serve() {
+	e.log.Info().Msgf("starting dashboard server on address %s", e.server.Addr)
+
+	err := e.server.ListenAndServe()
+	if errors.Is(err, http.ErrServerClosed) {
+		return
+	}
+	if err != nil {
+		e.log.Err(err).Msg("failed to start the dashboard server")
+	}
+}
@@ -0,0 +1,105 @@
+package dashboard
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/rs/zerolog"
+)
+
+const (
+	// DefaultLimit is the number of assigned chunks reported when the request does not specify a limit.
+	DefaultLimit = 100
+	// MaxLimit is the maximum number of assigned chunks reported for a request.
+	MaxLimit = 1000
+)
+
+// Error is the JSON model of a failed request.
+type Error struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// NewServer returns an HTTP server that serves the dashboard as a read-only JSON endpoint:
+//
+//	GET /v1/assigned_chunks?limit=N
+//
+// returns the report of the latest N assigned chunks, grouped by block.
+func NewServer(dashboard *Dashboard, address string, log zerolog.Logger) *http.Server {
+	h := &handler{
+		dashboard: dashboard,
+		log:       log.With().Str("component", "dashboard").Logger(),
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1/assigned_chunks", h.AssignedChunks)
+
+	return &http.Server{
+		Addr:    address,
+		Handler: mux,
+	}
+}
+
+type handler struct {
+	dashboard *Dashboard
+	log       zerolog.Logger
+}
+
+// AssignedChunks serves the report of the latest assigned chunks.
+func (h *handler) AssignedChunks(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		h.writeError(w, http.StatusMethodNotAllowed, fmt.Errorf("method %s not allowed", r.Method))
+		return
+	}
+
+	limit, err := parseLimit(r.URL.Query().Get("limit"))
+	if err != nil {
+		h.writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	report, err := h.dashboard.Report(limit)
+	if err != nil {
+		h.log.Error().Err(err).Msg("could not report assigned chunks")
+		h.writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	h.writeJSON(w, http.StatusOK, report)
+}
+
+// parseLimit parses the limit of a request, which defaults to DefaultLimit and is capped at MaxLimit.
+func parseLimit(value string) (uint, error) {
+	if value == "" {
+		return DefaultLimit, nil
+	}
+
+	limit, err := strconv.ParseUint(value, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid limit %q: %w", value, err)
+	}
+	if limit > MaxLimit {
+		return MaxLimit, nil
+	}
+
+	return uint(limit), nil
+}
+
+func (h *handler) writeError(w http.ResponseWriter, code int, err error) {
+	h.writeJSON(w, code, Error{
+		Code:    code,
+		Message: err.Error(),
+	})
+}
+
+func (h *handler) writeJSON(w http.ResponseWriter, code int, resp interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+
+	err := json.NewEncoder(w).Encode(resp)
+	if err != nil {
+		h.log.Error().Err(err).Msg("failed to write response")
+	}
+}
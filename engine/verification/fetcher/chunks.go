@@ -5,45 +5,10 @@ import (
 	"time"
 
 	"github.com/onflow/flow-go/model/flow"
+	vermodel "github.com/onflow/flow-go/model/verification"
 	"github.com/onflow/flow-go/module/mempool/stdmap"
 )
 
-// ChunkStatus is a data struct represents the current status of fetching
-// chunk data pack for the chunk.
-type ChunkStatus struct {
-	Chunk             *flow.Chunk
-	ExecutionResultID flow.Identifier
-	Height            uint64
-	Agrees            []flow.Identifier
-	Disagrees         []flow.Identifier
-	LastAttempt       time.Time
-	Attempt           int
-}
-
-func (s ChunkStatus) ID() flow.Identifier {
-	return s.Chunk.ID()
-}
-
-func (s ChunkStatus) Checksum() flow.Identifier {
-	return s.Chunk.ID()
-}
-
-func NewChunkStatus(
-	chunk *flow.Chunk,
-	resultID flow.Identifier,
-	height uint64,
-	agrees []flow.Identifier,
-	disagrees []flow.Identifier,
-) *ChunkStatus {
-	return &ChunkStatus{
-		Chunk:             chunk,
-		ExecutionResultID: resultID,
-		Height:            height,
-		Agrees:            agrees,
-		Disagrees:         disagrees,
-	}
-}
-
 type Chunks struct {
 	*stdmap.Backend
 }
@@ -55,17 +20,17 @@ func NewChunks(limit uint) *Chunks {
 	return chunks
 }
 
-func fromEntity(entity flow.Entity) *ChunkStatus {
-	chunk, ok := entity.(*ChunkStatus)
+func fromEntity(entity flow.Entity) *vermodel.ChunkStatus {
+	chunk, ok := entity.(*vermodel.ChunkStatus)
 	if !ok {
 		panic(fmt.Sprintf("could not convert the entity into chunk status from the mempool: %v", entity))
 	}
 	return chunk
 }
 
-func (cs *Chunks) All() []*ChunkStatus {
+func (cs *Chunks) All() []*vermodel.ChunkStatus {
 	all := cs.Backend.All()
-	allChunks := make([]*ChunkStatus, 0, len(all))
+	allChunks := make([]*vermodel.ChunkStatus, 0, len(all))
 	for _, entity := range all {
 		chunk := fromEntity(entity)
 		allChunks = append(allChunks, chunk)
@@ -73,7 +38,7 @@ func (cs *Chunks) All() []*ChunkStatus {
 	return allChunks
 }
 
-func (cs *Chunks) ByID(chunkID flow.Identifier) (*ChunkStatus, bool) {
+func (cs *Chunks) ByID(chunkID flow.Identifier) (*vermodel.ChunkStatus, bool) {
 	entity, exists := cs.Backend.ByID(chunkID)
 	if !exists {
 		return nil, false
@@ -82,7 +47,7 @@ func (cs *Chunks) ByID(chunkID flow.Identifier) (*ChunkStatus, bool) {
 	return chunk, true
 }
 
-func (cs *Chunks) Add(chunk *ChunkStatus) bool {
+func (cs *Chunks) Add(chunk *vermodel.ChunkStatus) bool {
 	return cs.Backend.Add(chunk)
 }
 
@@ -104,3 +69,24 @@ func (cs *Chunks) IncrementAttempt(chunkID flow.Identifier) bool {
 
 	return err == nil
 }
+
+// AddInvalidResponder records that the executor with the given ID responded with an invalid chunk data pack of the
+// chunk, so that the chunk data pack is no longer requested from it. It returns false if the chunk does not exist.
+func (cs *Chunks) AddInvalidResponder(chunkID flow.Identifier, executorID flow.Identifier) bool {
+	err := cs.Backend.Run(func(backdata map[flow.Identifier]flow.Entity) error {
+		entity, exists := backdata[chunkID]
+		if !exists {
+			return fmt.Errorf("not exist")
+		}
+		chunk := fromEntity(entity)
+		for _, responderID := range chunk.InvalidResponders {
+			if responderID == executorID {
+				return nil
+			}
+		}
+		chunk.InvalidResponders = append(chunk.InvalidResponders, executorID)
+		return nil
+	})
+
+	return err == nil
+}
@@ -6,6 +6,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/onflow/flow-go/model/flow"
+	vermodel "github.com/onflow/flow-go/model/verification"
 	"github.com/onflow/flow-go/utils/unittest"
 )
 
@@ -16,7 +17,7 @@ func TestCanTry(t *testing.T) {
 		chunks := NewChunks(10)
 		c := unittest.ChunkFixture(flow.Identifier{0x11}, 0)
 		c.Index = 0
-		chunk := NewChunkStatus(c, flow.Identifier{0xaa}, 3, []flow.Identifier{}, []flow.Identifier{})
+		chunk := vermodel.NewChunkStatus(c, flow.Identifier{0xaa}, 3, []flow.Identifier{}, []flow.Identifier{})
 		chunks.Add(chunk)
 		results := []bool{}
 		for i := 0; i < 5; i++ {
@@ -12,9 +12,11 @@ import (
 
 	"github.com/onflow/flow-go/engine"
 	"github.com/onflow/flow-go/engine/verification"
+	"github.com/onflow/flow-go/model/chunks"
 	"github.com/onflow/flow-go/model/flow"
 	"github.com/onflow/flow-go/model/flow/filter"
 	"github.com/onflow/flow-go/model/messages"
+	vermodel "github.com/onflow/flow-go/model/verification"
 	"github.com/onflow/flow-go/module"
 	"github.com/onflow/flow-go/network"
 	"github.com/onflow/flow-go/state/protocol"
@@ -25,6 +27,16 @@ import (
 // Fetch engine processes each chunk in the chunk job queue, fetches its chunk data pack
 // from the execution nodes who produced the receipts, and when the chunk data pack are
 // received, it passes the verifiable chunk data to verifier engine to verify the chunk.
+//
+// The chunk data packs are validated against their chunks when they are received. The misbehavior of
+// an execution node responding with an invalid chunk data pack is reported, and the chunk data pack is
+// requested again right away from the other execution nodes, never from that execution node again.
+// Execution nodes penalized for their reported misbehavior are no longer requested any chunk data pack,
+// and their responses are dropped.
+//
+// The status of each chunk is persisted as it progresses, so that after a restart the chunks
+// whose chunk data pack was requested are not requested again right away, and the chunks whose
+// chunk data pack was received are passed to the verifier engine again without requesting it.
 type Engine struct {
 	unit                  *engine.Unit
 	log                   zerolog.Logger
@@ -36,11 +48,16 @@ type Engine struct {
 	pendingChunks         *Chunks                   // used to store all the pending chunks that assigned to this node
 	con                   network.Conduit           // used to send the chunk data request
 	headers               storage.Headers           // used to fetch the block header when chunk data is ready to be verified
+	blocks                storage.Blocks            // used to find the collection of the chunk when chunk data is received
 	chunkConsumerNotifier module.ProcessingNotifier // to report a chunk has been processed
 
-	receiptsDB    storage.ExecutionReceipts // used to find executor of the chunk
-	retryInterval time.Duration             // determines time in milliseconds for retrying chunk data requests
-	maxAttempt    int                       // max time of retries to fetch the chunk data pack for a chunk
+	results       storage.ExecutionResults   // used to find the chunk of a chunk locator
+	receiptsDB    storage.ExecutionReceipts  // used to find executor of the chunk
+	statuses      storage.ChunkStatuses      // used to persist the status of chunks across restarts
+	reporter      module.MisbehaviorReporter // used to report executors responding with invalid chunk data packs
+	retryInterval time.Duration              // determines time in milliseconds for retrying chunk data requests
+	maxAttempt    int                        // max time of retries to fetch the chunk data pack for a chunk
+	prunedHeight  uint64                     // the statuses of the chunks below this height have been removed
 }
 
 func New(
@@ -53,6 +70,11 @@ func New(
 	state protocol.State,
 	chunks *Chunks,
 	headers storage.Headers,
+	blocks storage.Blocks,
+	results storage.ExecutionResults,
+	receipts storage.ExecutionReceipts,
+	statuses storage.ChunkStatuses,
+	reporter module.MisbehaviorReporter,
 	retryInterval time.Duration,
 	maxAttempt int,
 ) (*Engine, error) {
@@ -66,6 +88,11 @@ func New(
 		state:         state,
 		pendingChunks: chunks,
 		headers:       headers,
+		blocks:        blocks,
+		results:       results,
+		receiptsDB:    receipts,
+		statuses:      statuses,
+		reporter:      reporter,
 		retryInterval: retryInterval,
 		maxAttempt:    maxAttempt,
 	}
@@ -86,12 +113,18 @@ func (e *Engine) WithChunkConsumerNotifier(notifier module.ProcessingNotifier) {
 	e.chunkConsumerNotifier = notifier
 }
 
-// Ready initializes the engine and returns a channel that is closed when the initialization is done
+// Ready initializes the engine and returns a channel that is closed when the initialization is done.
+// It resumes the chunks that were in progress before the node restarted.
 func (e *Engine) Ready() <-chan struct{} {
 	if e.chunkConsumerNotifier == nil {
 		panic("missing chunk consumer notifier callback in verification fetcher engine")
 	}
 
+	err := e.restoreChunks()
+	if err != nil {
+		e.log.Fatal().Err(err).Msg("could not restore chunks in progress")
+	}
+
 	delay := time.Duration(0)
 	// run a periodic check to retry requesting chunk data packs for chunks that assigned to me.
 	// if onTimer takes longer than retryInterval, the next call will be blocked until the previous
@@ -149,7 +182,7 @@ func (e *Engine) process(originID flow.Identifier, event interface{}) error {
 
 	switch resource := event.(type) {
 	case *messages.ChunkDataResponse:
-		err = e.onChunkDataPack(originID, &resource.ChunkDataPack, &resource.Collection)
+		err = e.onChunkDataPack(originID, &resource.ChunkDataPack, &resource.Collection, resource.ExecutionData)
 	default:
 		return fmt.Errorf("invalid event type (%T)", event)
 	}
@@ -164,68 +197,167 @@ func (e *Engine) process(originID flow.Identifier, event interface{}) error {
 	return nil
 }
 
-// ProcessMyChunk processes the chunk that assigned to me. It should not be blocking since
+// ProcessAssignedChunk processes the chunk that assigned to me. It should not be blocking since
 // multiple workers might be calling it concurrently.
-// It skips chunks for sealed blocks.
+// It skips chunks for sealed blocks, and chunks that were in progress before the node restarted.
 // It fetches the chunk data pack, once received, verifier engine will be verifying
 // Once a chunk has been processed, it will call the processing notifier callback to notify
 // the chunk consumer in order to process the next chunk.
-func (e *Engine) ProcessMyChunk(c *flow.Chunk, resultID flow.Identifier) {
+func (e *Engine) ProcessAssignedChunk(locator *chunks.Locator) {
+	locatorID := locator.ID()
+	lg := e.log.With().
+		Hex("locator_id", locatorID[:]).
+		Hex("result_id", locator.ResultID[:]).
+		Uint64("chunk_index", locator.Index).
+		Logger()
+
+	c, err := e.chunkByLocator(locator)
+	if err != nil {
+		lg.Error().Err(err).Msg("could not find chunk of locator")
+		e.chunkConsumerNotifier.Notify(locatorID)
+		return
+	}
+
 	chunkID := c.ID()
 	blockID := c.ChunkBody.BlockID
-	lg := e.log.With().
+	lg = lg.With().
 		Hex("chunk", chunkID[:]).
 		Hex("block", blockID[:]).
-		Hex("result_id", resultID[:]).
 		Logger()
 
 	sealed, header, err := blockIsSealed(e.state, e.headers, blockID)
 
 	if err != nil {
 		lg.Error().Err(err).Msg("could not check if block is sealed")
-		e.chunkConsumerNotifier.Notify(chunkID)
+		e.chunkConsumerNotifier.Notify(locatorID)
 		return
 	}
 
 	// skip sealed blocks
 	if sealed {
 		lg.Debug().Msg("skip sealed chunk")
-		e.chunkConsumerNotifier.Notify(chunkID)
+		e.chunkConsumerNotifier.Notify(locatorID)
 		return
 	}
 
 	lg = lg.With().Uint64("height", header.Height).Logger()
 
-	err = e.processChunk(c, header, resultID)
+	// the chunks that were in progress before a restart are resumed from their status
+	status, err := e.statuses.ByChunkID(chunkID)
+	if err == nil {
+		if status.Received() {
+			// the chunk has been passed to the verifier engine already
+			lg.Debug().Msg("skip chunk whose chunk data pack was already received")
+			e.chunkConsumerNotifier.Notify(locatorID)
+			return
+		}
+		// the chunk is pending, and the chunk consumer is notified once its chunk data pack is received
+		lg.Debug().Msg("skip chunk whose chunk data pack was already requested")
+		return
+	}
+	if !errors.Is(err, storage.ErrNotFound) {
+		lg.Error().Err(err).Msg("could not retrieve chunk status")
+		e.chunkConsumerNotifier.Notify(locatorID)
+		return
+	}
+
+	err = e.processChunk(c, header, locator.ResultID)
 
 	if err != nil {
 		lg.Error().Err(err).Msg("could not process chunk")
 		// we report finish processing this chunk even if it failed
-		e.chunkConsumerNotifier.Notify(chunkID)
+		e.chunkConsumerNotifier.Notify(locatorID)
 	} else {
 		lg.Info().Msgf("processing chunk")
 	}
 }
 
+// chunkByLocator returns the chunk the locator points to.
+func (e *Engine) chunkByLocator(locator *chunks.Locator) (*flow.Chunk, error) {
+	result, err := e.results.ByID(locator.ResultID)
+	if err != nil {
+		return nil, fmt.Errorf("could not retrieve result %v: %w", locator.ResultID, err)
+	}
+
+	c, ok := result.Chunks.ByIndex(locator.Index)
+	if !ok {
+		return nil, fmt.Errorf("chunk index %d out of range for result %v", locator.Index, locator.ResultID)
+	}
+
+	return c, nil
+}
+
+// restoreChunks resumes the chunks that were in progress before the node restarted, from their persisted status.
+// The chunks whose chunk data pack was requested are pending again, and are requested again once their retry interval
+// elapses. The chunks whose chunk data pack was received are passed to the verifier engine again, unless they have
+// been verified already.
+func (e *Engine) restoreChunks() error {
+	sealed, err := e.state.Sealed().Head()
+	if err != nil {
+		return fmt.Errorf("could not get last sealed: %w", err)
+	}
+
+	err = e.removeSealedStatuses(sealed.Height)
+	if err != nil {
+		return fmt.Errorf("could not remove statuses of sealed chunks: %w", err)
+	}
+
+	statuses, err := e.statuses.All()
+	if err != nil {
+		return fmt.Errorf("could not retrieve chunk statuses: %w", err)
+	}
+
+	pending := 0
+	received := 0
+	for _, status := range statuses {
+		if status.Verified {
+			continue
+		}
+
+		if status.Received() {
+			err := e.verifyChunkWithChunkDataPack(status.Chunk, status.ExecutionResultID, status.ChunkDataPack, status.Collection, status.ExecutionData)
+			if err != nil {
+				return fmt.Errorf("could not verify received chunk %v: %w", status.ID(), err)
+			}
+			received++
+			continue
+		}
+
+		e.pendingChunks.Add(status)
+		pending++
+	}
+
+	e.log.Info().
+		Int("pending", pending).
+		Int("received", received).
+		Msg("chunks in progress restored")
+
+	return nil
+}
+
 func (e *Engine) processChunk(c *flow.Chunk, header *flow.Header, resultID flow.Identifier) error {
 	blockID := c.ChunkBody.BlockID
-	receiptsData, err := e.receiptsDB.ByBlockID(blockID)
+	receipts, err := e.receiptsDB.ByBlockID(blockID)
 	if err != nil {
 		return fmt.Errorf("could not retrieve receipts for block: %v: %w", blockID, err)
 	}
 
-	var receipts []*flow.ExecutionReceipt
-	copy(receipts, receiptsData)
-
 	agrees, disagrees := executorsOf(receipts, resultID)
 	// chunk data pack request will only be sent to executors who produced the same result,
 	// never to who produced different results.
-	status := NewChunkStatus(c, resultID, header.Height, agrees, disagrees)
+	status := vermodel.NewChunkStatus(c, resultID, header.Height, agrees, disagrees)
 	added := e.pendingChunks.Add(status)
 	if !added {
 		return nil
 	}
 
+	// the status is persisted before requesting the chunk data pack, so that the chunk is
+	// resumed after a restart.
+	err = e.statuses.Store(status)
+	if err != nil {
+		return fmt.Errorf("could not store chunk status: %w", err)
+	}
+
 	allExecutors, err := e.state.Final().Identities(filter.HasRole(flow.RoleExecution))
 	if err != nil {
 		return fmt.Errorf("could not find executors: %w", err)
@@ -313,10 +445,18 @@ func (e *Engine) onTimer() {
 
 		if isSealed {
 			removed := e.pendingChunks.Rem(chunkID)
+			if removed {
+				e.chunkConsumerNotifier.Notify(chunk.Locator().ID())
+			}
 			lg.Info().Bool("removed", removed).Msg("chunk has been sealed, no longer needed")
 			continue
 		}
 
+		// the chunk data pack was requested recently, e.g., right before a restart
+		if time.Since(chunk.LastAttempt) < e.retryInterval {
+			continue
+		}
+
 		// check if has reached max try
 		if !CanTry(e.maxAttempt, chunk) {
 			lg.Debug().
@@ -334,12 +474,41 @@ func (e *Engine) onTimer() {
 
 		log.Info().Msg("chunk data requested")
 	}
+
+	err = e.removeSealedStatuses(sealed.Height)
+	if err != nil {
+		e.log.Error().Err(err).Msg("could not remove statuses of sealed chunks")
+	}
+}
+
+// removeSealedStatuses removes the persisted status of the chunks that have been sealed, including the
+// chunks that have been received or verified, which are no longer in the pending chunks. Only the heights
+// sealed since the last removal are visited, through the height index of the statuses.
+func (e *Engine) removeSealedStatuses(sealedHeight uint64) error {
+	if sealedHeight < e.prunedHeight {
+		return nil
+	}
+
+	removed, err := e.statuses.RemoveByHeightRange(e.prunedHeight, sealedHeight)
+	if err != nil {
+		return fmt.Errorf("could not remove statuses of chunks up to height %d: %w", sealedHeight, err)
+	}
+	e.prunedHeight = sealedHeight + 1
+
+	if removed > 0 {
+		e.log.Debug().
+			Uint64("sealed_height", sealedHeight).
+			Uint("removed", removed).
+			Msg("statuses of sealed chunks removed")
+	}
+
+	return nil
 }
 
 // requestChunkDataPack request the chunk data pack from the execution node.
 // the chunk data pack includes the collection and statecommitments that
 // needed to make a VerifiableChunk
-func (e *Engine) requestChunkDataPack(c *ChunkStatus, allExecutors flow.IdentityList) error {
+func (e *Engine) requestChunkDataPack(c *vermodel.ChunkStatus, allExecutors flow.IdentityList) error {
 	chunkID := c.ID()
 
 	// creates chunk data pack request event
@@ -348,7 +517,7 @@ func (e *Engine) requestChunkDataPack(c *ChunkStatus, allExecutors flow.Identity
 		Nonce:   rand.Uint64(), // prevent the request from being deduplicated by the receiver
 	}
 
-	targetIDs := chooseChunkDataPackTarget(allExecutors, c.Agrees, c.Disagrees)
+	targetIDs := chooseChunkDataPackTarget(allExecutors, c.Agrees, c.Disagrees, e.excludedExecutors(c, allExecutors))
 
 	// publishes the chunk data request to the network
 	err := e.con.Publish(req, targetIDs...)
@@ -362,14 +531,37 @@ func (e *Engine) requestChunkDataPack(c *ChunkStatus, allExecutors flow.Identity
 		return fmt.Errorf("chunk is no longer needed to be processed")
 	}
 
+	err = e.statuses.Store(c)
+	if err != nil {
+		return fmt.Errorf("could not store status of chunk (id=%s): %w", chunkID, err)
+	}
+
 	return nil
 }
 
+// excludedExecutors returns the executors the chunk data pack of the chunk is never requested from, i.e., the ones who
+// responded with an invalid chunk data pack of the chunk, and the ones penalized for their misbehavior.
+func (e *Engine) excludedExecutors(c *vermodel.ChunkStatus, allExecutors flow.IdentityList) []flow.Identifier {
+	excluded := make([]flow.Identifier, 0, len(c.InvalidResponders))
+	excluded = append(excluded, c.InvalidResponders...)
+	for _, executor := range allExecutors {
+		if e.reporter.IsPenalized(executor.NodeID) {
+			excluded = append(excluded, executor.NodeID)
+		}
+	}
+	return excluded
+}
+
 func chooseChunkDataPackTarget(
 	allExecutors flow.IdentityList,
 	agrees []flow.Identifier,
 	disagrees []flow.Identifier,
+	excluded []flow.Identifier,
 ) []flow.Identifier {
+	// excluded executors are never requested
+	allExecutors = allExecutors.Filter(filter.Not(filter.HasNodeID(excluded...)))
+	agrees = withoutIDs(agrees, excluded)
+
 	// if there are enough receipts produced the same result (agrees), we will
 	// randomly pick 2 from them
 	if len(agrees) >= 2 {
@@ -390,13 +582,39 @@ func chooseChunkDataPackTarget(
 	return append(agrees, nonResponders...)
 }
 
+// withoutIDs returns the identifiers except for the excluded ones.
+func withoutIDs(ids []flow.Identifier, excluded []flow.Identifier) []flow.Identifier {
+	if len(excluded) == 0 {
+		return ids
+	}
+
+	lookup := make(map[flow.Identifier]struct{}, len(excluded))
+	for _, id := range excluded {
+		lookup[id] = struct{}{}
+	}
+
+	remaining := make([]flow.Identifier, 0, len(ids))
+	for _, id := range ids {
+		if _, ok := lookup[id]; !ok {
+			remaining = append(remaining, id)
+		}
+	}
+	return remaining
+}
+
 func (e *Engine) onChunkDataPack(
 	originID flow.Identifier,
 	chunkDataPack *flow.ChunkDataPack,
 	collection *flow.Collection,
+	executionData *flow.ExecutionData,
 ) error {
 	chunkID := chunkDataPack.ChunkID
 
+	// responses of penalized executors are dropped
+	if e.reporter.IsPenalized(originID) {
+		return engine.NewInvalidInputErrorf("chunk data pack from penalized node: %v", originID)
+	}
+
 	// make sure we still need it
 	status, exists := e.pendingChunks.ByID(chunkID)
 	if !exists {
@@ -405,13 +623,22 @@ func (e *Engine) onChunkDataPack(
 	}
 
 	chunk := status.Chunk
+	resultID := status.ExecutionResultID
+
+	result, err := e.getResultByID(chunk.BlockID, resultID)
+	if err != nil {
+		return fmt.Errorf("could not get result by id %v: %w", resultID, err)
+	}
 
 	// make sure the chunk data pack is valid
-	err := e.validateChunkDataPack(
-		chunk, originID, chunkDataPack, collection)
+	err = e.validateChunkDataPack(
+		chunk, originID, result, chunkDataPack, collection, executionData)
+	if engine.IsInvalidInputError(err) {
+		e.onInvalidChunkDataPack(originID, status, err)
+		return fmt.Errorf("invalid chunk data pack for chunk: %v block: %v: %w", chunkID, chunk.BlockID, err)
+	}
 	if err != nil {
-		return engine.NewInvalidInputErrorf(
-			"invalid chunk data pack for chunk: %v block: %v", chunkID, chunk.BlockID)
+		return fmt.Errorf("could not validate chunk data pack for chunk: %v block: %v: %w", chunkID, chunk.BlockID, err)
 	}
 
 	// make sure we won't process duplicated chunk data pack
@@ -423,10 +650,19 @@ func (e *Engine) onChunkDataPack(
 
 	// whenever we removed a chunk from pending chunks, we need to
 	// report that the job has been finished eventually
-	defer e.chunkConsumerNotifier.Notify(chunkID)
+	defer e.chunkConsumerNotifier.Notify(status.Locator().ID())
+
+	// the received chunk data pack is persisted before passing the chunk to the verifier engine,
+	// so that the chunk is verified after a restart without requesting its chunk data pack again.
+	status.ChunkDataPack = chunkDataPack
+	status.Collection = collection
+	status.ExecutionData = executionData
+	err = e.statuses.Store(status)
+	if err != nil {
+		return fmt.Errorf("could not store status of chunk %v: %w", chunkID, err)
+	}
 
-	resultID := status.ExecutionResultID
-	err = e.verifyChunkWithChunkDataPack(chunk, resultID, chunkDataPack, collection)
+	err = e.verifyChunkWithChunkDataPack(chunk, resultID, chunkDataPack, collection, executionData)
 	if err != nil {
 		return fmt.Errorf("could not verify chunk with chunk data pack for result: %v: %w", resultID, err)
 	}
@@ -434,10 +670,62 @@ func (e *Engine) onChunkDataPack(
 	return nil
 }
 
+// onInvalidChunkDataPack reports the misbehavior of the executor that responded with an invalid chunk data pack of
+// the chunk, and requests the chunk data pack again right away from the other executors, preferably from the ones
+// who committed to the same result.
+func (e *Engine) onInvalidChunkDataPack(originID flow.Identifier, status *vermodel.ChunkStatus, reason error) {
+	chunkID := status.ID()
+	lg := e.log.With().
+		Hex("chunk_id", logging.ID(chunkID)).
+		Hex("result_id", logging.ID(status.ExecutionResultID)).
+		Hex("origin_id", logging.ID(originID)).
+		Logger()
+
+	e.reporter.ReportMisbehavior(originID, reason)
+
+	added := e.pendingChunks.AddInvalidResponder(chunkID, originID)
+	if !added {
+		// the chunk data pack has been received from another executor meanwhile
+		return
+	}
+
+	if !CanTry(e.maxAttempt, status) {
+		lg.Debug().
+			Int("max_attempt", e.maxAttempt).
+			Int("actual_attempts", status.Attempt).
+			Msg("max attempts reached, no longer fetch data pack for chunk")
+
+		// the invalid responder is persisted, as the chunk data pack is not requested again
+		err := e.statuses.Store(status)
+		if err != nil {
+			lg.Error().Err(err).Msg("could not store chunk status")
+		}
+		return
+	}
+
+	allExecutors, err := e.state.Final().Identities(filter.HasRole(flow.RoleExecution))
+	if err != nil {
+		lg.Error().Err(err).Msg("could not get executors")
+		return
+	}
+
+	err = e.requestChunkDataPack(status, allExecutors)
+	if err != nil {
+		lg.Warn().Err(err).Msg("could not request chunk data pack again")
+		return
+	}
+
+	lg.Info().Msg("chunk data pack requested again after invalid response")
+}
+
 // verifyChunkWithChunkDataPack fetches the result for the executed block, and
 // make verifiable chunk data, and pass it to the verifier for verification,
 func (e *Engine) verifyChunkWithChunkDataPack(
-	chunk *flow.Chunk, resultID flow.Identifier, chunkDataPack *flow.ChunkDataPack, collection *flow.Collection,
+	chunk *flow.Chunk,
+	resultID flow.Identifier,
+	chunkDataPack *flow.ChunkDataPack,
+	collection *flow.Collection,
+	executionData *flow.ExecutionData,
 ) error {
 	header, err := e.headers.ByBlockID(chunk.BlockID)
 	if err != nil {
@@ -450,7 +738,7 @@ func (e *Engine) verifyChunkWithChunkDataPack(
 	}
 
 	vchunk, err := e.makeVerifiableChunkData(
-		chunk, header, result, chunkDataPack, collection)
+		chunk, header, result, chunkDataPack, collection, executionData)
 
 	if err != nil {
 		return fmt.Errorf("could not make verifiable chunk data: %w", err)
@@ -467,8 +755,10 @@ func (e *Engine) verifyChunkWithChunkDataPack(
 func (e *Engine) validateChunkDataPack(
 	chunk *flow.Chunk,
 	senderID flow.Identifier,
+	result *flow.ExecutionResult,
 	chunkDataPack *flow.ChunkDataPack,
 	collection *flow.Collection,
+	executionData *flow.ExecutionData,
 ) error {
 	// 1. sender must be an execution node at that block
 	blockID := chunk.BlockID
@@ -492,11 +782,59 @@ func (e *Engine) validateChunkDataPack(
 			chunk.ChunkBody.StartState, chunkDataPack.StartState)
 	}
 
-	// 3. collection id must match
-	collID := collection.ID()
-	if chunkDataPack.CollectionID != collID {
-		return engine.NewInvalidInputErrorf("mismatch collection id, %v != %v",
-			chunkDataPack.CollectionID, collID)
+	// 3. collection must be the one of the chunk, i.e., the collection guaranteed at the collection
+	// index of the chunk, or no collection for the system chunk.
+	block, err := e.blocks.ByID(blockID)
+	if err != nil {
+		return fmt.Errorf("could not get block of chunk: %w", err)
+	}
+	guarantees := block.Payload.Guarantees
+	collectionIndex := int(chunk.CollectionIndex)
+	if collectionIndex > len(guarantees) {
+		return fmt.Errorf("collection index of chunk out of range: %d > %d", collectionIndex, len(guarantees))
+	}
+
+	if collectionIndex == len(guarantees) {
+		// system chunk
+		if chunkDataPack.CollectionID != flow.ZeroID || len(collection.Transactions) != 0 {
+			return engine.NewInvalidInputErrorf("unexpected collection for system chunk: %v", chunkDataPack.CollectionID)
+		}
+
+		// 4. the system chunk only executes the system transaction
+		if chunk.NumberOfTransactions != 1 {
+			return engine.NewInvalidInputErrorf("mismatch number of transactions of system chunk, 1 != %d",
+				chunk.NumberOfTransactions)
+		}
+	} else {
+		guaranteed := guarantees[collectionIndex].CollectionID
+		if chunkDataPack.CollectionID != guaranteed {
+			return engine.NewInvalidInputErrorf("mismatch guaranteed collection id, %v != %v",
+				chunkDataPack.CollectionID, guaranteed)
+		}
+
+		collID := collection.ID()
+		if chunkDataPack.CollectionID != collID {
+			return engine.NewInvalidInputErrorf("mismatch collection id, %v != %v",
+				chunkDataPack.CollectionID, collID)
+		}
+
+		// 4. number of transactions must match
+		if uint64(len(collection.Transactions)) != chunk.NumberOfTransactions {
+			return engine.NewInvalidInputErrorf("mismatch number of transactions, %d != %d",
+				len(collection.Transactions), chunk.NumberOfTransactions)
+		}
+	}
+
+	// 5. execution data must be the one committed in the result, if the result commits to the execution data of its
+	// block, so that the chunk is verified against it.
+	if result.ExecutionDataID != flow.ZeroID {
+		if executionData == nil {
+			return engine.NewInvalidInputErrorf("missing execution data committed in result: %v", result.ID())
+		}
+		err = executionData.CheckCommitted(result)
+		if err != nil {
+			return engine.NewInvalidInputErrorf("invalid execution data: %w", err)
+		}
 	}
 
 	return nil
@@ -523,6 +861,7 @@ func (e *Engine) makeVerifiableChunkData(
 	result *flow.ExecutionResult,
 	chunkDataPack *flow.ChunkDataPack,
 	collection *flow.Collection,
+	executionData *flow.ExecutionData,
 ) (*verification.VerifiableChunkData, error) {
 
 	// system chunk is the last chunk
@@ -541,6 +880,11 @@ func (e *Engine) makeVerifiableChunkData(
 		endState = result.Chunks[chunk.Index+1].StartState
 	}
 
+	// the execution data is only checked against the results committing to it
+	if result.ExecutionDataID == flow.ZeroID {
+		executionData = nil
+	}
+
 	return &verification.VerifiableChunkData{
 		IsSystemChunk: isSystemChunk,
 		Chunk:         chunk,
@@ -549,6 +893,7 @@ func (e *Engine) makeVerifiableChunkData(
 		Collection:    collection,
 		ChunkDataPack: chunkDataPack,
 		EndState:      endState,
+		ExecutionData: executionData,
 	}, nil
 }
 
@@ -562,7 +907,7 @@ func (e *Engine) HandleChunkDataPack(originID flow.Identifier, chunkDataPack *fl
 
 // CanTry returns checks the history attempts and determine whether a chunk request
 // can be tried again.
-func CanTry(maxAttempt int, chunk *ChunkStatus) bool {
+func CanTry(maxAttempt int, chunk *vermodel.ChunkStatus) bool {
 	return chunk.Attempt < maxAttempt
 }
 
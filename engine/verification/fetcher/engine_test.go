@@ -1 +1,385 @@
 package fetcher
+
+import (
+	"testing"
+
+	"github.com/rs/zerolog"
+	testifymock "github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/engine"
+	"github.com/onflow/flow-go/model/flow"
+	vermodel "github.com/onflow/flow-go/model/verification"
+	"github.com/onflow/flow-go/module/metrics"
+	"github.com/onflow/flow-go/module/mock"
+	"github.com/onflow/flow-go/module/trace"
+	"github.com/onflow/flow-go/network/mocknetwork"
+	protocol "github.com/onflow/flow-go/state/protocol/mock"
+	"github.com/onflow/flow-go/storage"
+	mockstorage "github.com/onflow/flow-go/storage/mock"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+// fetcherFixture holds a fetcher engine for a block with two collections, and the mocks it is built with.
+type fetcherFixture struct {
+	engine      *Engine
+	block       *flow.Block
+	collections []*flow.Collection
+	result      *flow.ExecutionResult // result of the block, committing to no execution data
+	executors   flow.IdentityList
+	con         *mocknetwork.Conduit
+	verifier    *mocknetwork.Engine
+	reporter    *mock.MisbehaviorReporter
+	penalized   []flow.Identifier // executors penalized by the reporter
+	statuses    *mockstorage.ChunkStatuses
+}
+
+func newFetcherFixture(t *testing.T) *fetcherFixture {
+	collections := make([]*flow.Collection, 0, 2)
+	guarantees := make([]*flow.CollectionGuarantee, 0, 2)
+	for i := 0; i < 2; i++ {
+		collection := unittest.CollectionFixture(2)
+		collections = append(collections, &collection)
+		guarantees = append(guarantees, unittest.CollectionGuaranteeFixture(unittest.WithCollection(&collection)))
+	}
+	block := unittest.BlockFixture()
+	block.SetPayload(unittest.PayloadFixture(unittest.WithGuarantees(guarantees...)))
+
+	executors := unittest.IdentityListFixture(3, unittest.WithRole(flow.RoleExecution))
+	verID := unittest.IdentityFixture(unittest.WithRole(flow.RoleVerification))
+	identities := append(executors, verID)
+
+	snapshot := &protocol.Snapshot{}
+	snapshot.On("Identity", testifymock.Anything).Return(
+		func(nodeID flow.Identifier) *flow.Identity {
+			identity, _ := identities.ByNodeID(nodeID)
+			return identity
+		},
+		func(nodeID flow.Identifier) error {
+			_, ok := identities.ByNodeID(nodeID)
+			if !ok {
+				return storage.ErrNotFound
+			}
+			return nil
+		})
+	snapshot.On("Identities", testifymock.Anything).Return(
+		func(selector flow.IdentityFilter) flow.IdentityList {
+			return identities.Filter(selector)
+		},
+		nil)
+	state := &protocol.State{}
+	state.On("AtBlockID", block.ID()).Return(snapshot)
+	state.On("Final").Return(snapshot)
+
+	blocks := &mockstorage.Blocks{}
+	blocks.On("ByID", block.ID()).Return(&block, nil)
+
+	f := &fetcherFixture{
+		block:       &block,
+		collections: collections,
+		executors:   executors,
+		con:         &mocknetwork.Conduit{},
+		verifier:    &mocknetwork.Engine{},
+		reporter:    &mock.MisbehaviorReporter{},
+		statuses:    &mockstorage.ChunkStatuses{},
+	}
+
+	f.result = &flow.ExecutionResult{BlockID: block.ID()}
+	for i := 0; i <= len(collections); i++ {
+		f.result.Chunks = append(f.result.Chunks, f.chunk(uint(i)))
+	}
+	receipts := &mockstorage.ExecutionReceipts{}
+	receipts.On("ByBlockID", block.ID()).Return(flow.ExecutionReceiptList{{ExecutionResult: *f.result}}, nil)
+
+	f.reporter.On("IsPenalized", testifymock.Anything).Return(
+		func(nodeID flow.Identifier) bool {
+			for _, penalized := range f.penalized {
+				if penalized == nodeID {
+					return true
+				}
+			}
+			return false
+		})
+
+	net := &mock.Network{}
+	net.On("Register", engine.RequestChunks, testifymock.Anything).Return(f.con, nil)
+
+	var err error
+	f.engine, err = New(zerolog.Nop(),
+		metrics.NewNoopCollector(),
+		trace.NewNoopTracer(),
+		net,
+		&mock.Local{},
+		f.verifier,
+		state,
+		NewChunks(10),
+		&mockstorage.Headers{},
+		blocks,
+		&mockstorage.ExecutionResults{},
+		receipts,
+		f.statuses,
+		f.reporter,
+		0,
+		3)
+	require.NoError(t, err)
+
+	return f
+}
+
+// chunk returns a chunk of the block with the given collection index, the system chunk for the index past its
+// collections.
+func (f *fetcherFixture) chunk(collectionIndex uint) *flow.Chunk {
+	chunk := unittest.ChunkFixture(f.block.ID(), collectionIndex)
+	chunk.Index = uint64(collectionIndex)
+	chunk.NumberOfTransactions = 1 // the system transaction
+	if int(collectionIndex) < len(f.collections) {
+		chunk.NumberOfTransactions = uint64(len(f.collections[collectionIndex].Transactions))
+	}
+	return chunk
+}
+
+// chunkDataPack returns a valid chunk data pack of the chunk, with its collection.
+func (f *fetcherFixture) chunkDataPack(chunk *flow.Chunk) (*flow.ChunkDataPack, *flow.Collection) {
+	collection := &flow.Collection{}
+	collectionID := flow.ZeroID
+	if int(chunk.CollectionIndex) < len(f.collections) {
+		collection = f.collections[chunk.CollectionIndex]
+		collectionID = collection.ID()
+	}
+
+	return &flow.ChunkDataPack{
+		ChunkID:      chunk.ID(),
+		StartState:   chunk.StartState,
+		Proof:        []byte{'p'},
+		CollectionID: collectionID,
+	}, collection
+}
+
+// TestValidateChunkDataPack evaluates that chunk data packs are validated against their chunks, and that the invalid
+// ones are rejected with an invalid input error.
+func TestValidateChunkDataPack(t *testing.T) {
+	f := newFetcherFixture(t)
+	exeID := f.executors[0].NodeID
+
+	t.Run("valid chunk data pack", func(t *testing.T) {
+		chunk := f.chunk(1)
+		dataPack, collection := f.chunkDataPack(chunk)
+		require.NoError(t, f.engine.validateChunkDataPack(chunk, exeID, f.result, dataPack, collection, nil))
+	})
+
+	t.Run("valid chunk data pack of system chunk", func(t *testing.T) {
+		chunk := f.chunk(2)
+		dataPack, collection := f.chunkDataPack(chunk)
+		require.NoError(t, f.engine.validateChunkDataPack(chunk, exeID, f.result, dataPack, collection, nil))
+	})
+
+	t.Run("sender is not an execution node", func(t *testing.T) {
+		chunk := f.chunk(0)
+		dataPack, collection := f.chunkDataPack(chunk)
+		err := f.engine.validateChunkDataPack(chunk, unittest.IdentifierFixture(), f.result, dataPack, collection, nil)
+		require.True(t, engine.IsInvalidInputError(err))
+	})
+
+	t.Run("mismatching start state", func(t *testing.T) {
+		chunk := f.chunk(0)
+		dataPack, collection := f.chunkDataPack(chunk)
+		dataPack.StartState = unittest.StateCommitmentFixture()
+		err := f.engine.validateChunkDataPack(chunk, exeID, f.result, dataPack, collection, nil)
+		require.True(t, engine.IsInvalidInputError(err))
+	})
+
+	t.Run("collection of another chunk", func(t *testing.T) {
+		chunk := f.chunk(0)
+		dataPack, collection := f.chunkDataPack(f.chunk(1))
+		dataPack.ChunkID = chunk.ID()
+		dataPack.StartState = chunk.StartState
+		err := f.engine.validateChunkDataPack(chunk, exeID, f.result, dataPack, collection, nil)
+		require.True(t, engine.IsInvalidInputError(err))
+	})
+
+	t.Run("collection not matching chunk data pack", func(t *testing.T) {
+		chunk := f.chunk(0)
+		dataPack, _ := f.chunkDataPack(chunk)
+		collection := unittest.CollectionFixture(2)
+		err := f.engine.validateChunkDataPack(chunk, exeID, f.result, dataPack, &collection, nil)
+		require.True(t, engine.IsInvalidInputError(err))
+	})
+
+	t.Run("collection for system chunk", func(t *testing.T) {
+		chunk := f.chunk(2)
+		dataPack, _ := f.chunkDataPack(chunk)
+		collection := unittest.CollectionFixture(1)
+		dataPack.CollectionID = collection.ID()
+		err := f.engine.validateChunkDataPack(chunk, exeID, f.result, dataPack, &collection, nil)
+		require.True(t, engine.IsInvalidInputError(err))
+	})
+
+	t.Run("mismatching number of transactions", func(t *testing.T) {
+		chunk := f.chunk(0)
+		chunk.NumberOfTransactions++
+		dataPack, collection := f.chunkDataPack(chunk)
+		err := f.engine.validateChunkDataPack(chunk, exeID, f.result, dataPack, collection, nil)
+		require.True(t, engine.IsInvalidInputError(err))
+	})
+
+	t.Run("mismatching number of transactions of system chunk", func(t *testing.T) {
+		chunk := f.chunk(2)
+		chunk.NumberOfTransactions = 0
+		dataPack, collection := f.chunkDataPack(chunk)
+		err := f.engine.validateChunkDataPack(chunk, exeID, f.result, dataPack, collection, nil)
+		require.True(t, engine.IsInvalidInputError(err))
+	})
+
+	// the result commits to the execution data of its block
+	executionData := &flow.ExecutionData{
+		BlockID:               f.block.ID(),
+		ChunkExecutionDataIDs: unittest.IdentifierListFixture(len(f.result.Chunks)),
+	}
+	committed := *f.result
+	committed.ExecutionDataID = executionData.ID()
+
+	t.Run("valid execution data", func(t *testing.T) {
+		chunk := f.chunk(0)
+		dataPack, collection := f.chunkDataPack(chunk)
+		require.NoError(t, f.engine.validateChunkDataPack(chunk, exeID, &committed, dataPack, collection, executionData))
+	})
+
+	t.Run("missing execution data", func(t *testing.T) {
+		chunk := f.chunk(0)
+		dataPack, collection := f.chunkDataPack(chunk)
+		err := f.engine.validateChunkDataPack(chunk, exeID, &committed, dataPack, collection, nil)
+		require.True(t, engine.IsInvalidInputError(err))
+	})
+
+	t.Run("execution data not committed in result", func(t *testing.T) {
+		chunk := f.chunk(0)
+		dataPack, collection := f.chunkDataPack(chunk)
+		other := &flow.ExecutionData{
+			BlockID:               f.block.ID(),
+			ChunkExecutionDataIDs: unittest.IdentifierListFixture(len(f.result.Chunks)),
+		}
+		err := f.engine.validateChunkDataPack(chunk, exeID, &committed, dataPack, collection, other)
+		require.True(t, engine.IsInvalidInputError(err))
+	})
+}
+
+// TestInvalidChunkDataPack evaluates that receiving an invalid chunk data pack reports the misbehavior of its sender,
+// and requests the chunk data pack again from the other executors that committed to the same result, without passing
+// the chunk to the verifier engine.
+func TestInvalidChunkDataPack(t *testing.T) {
+	f := newFetcherFixture(t)
+	f.engine.WithChunkConsumerNotifier(&mock.ProcessingNotifier{})
+
+	chunk := f.chunk(0)
+	agrees := f.executors[:2].NodeIDs()
+	status := vermodel.NewChunkStatus(chunk, f.result.ID(), f.block.Header.Height, agrees, nil)
+	require.True(t, f.engine.pendingChunks.Add(status))
+
+	invalidID := agrees[0]
+	dataPack, collection := f.chunkDataPack(chunk)
+	dataPack.StartState = unittest.StateCommitmentFixture()
+
+	f.reporter.On("ReportMisbehavior", invalidID, testifymock.Anything).Return().Once()
+	f.con.On("Publish", testifymock.Anything, testifymock.Anything, testifymock.Anything).
+		Return(nil).
+		Run(func(args testifymock.Arguments) {
+			require.NotContains(t, args[1:], invalidID, "chunk data pack requested again from invalid responder")
+			require.Contains(t, args[1:], agrees[1], "chunk data pack not requested from other agreeing executor")
+		}).
+		Once()
+	f.statuses.On("Store", status).Return(nil).Once()
+
+	err := f.engine.onChunkDataPack(invalidID, dataPack, collection, nil)
+	require.Error(t, err)
+
+	f.reporter.AssertExpectations(t)
+	f.con.AssertExpectations(t)
+	f.statuses.AssertExpectations(t)
+	f.verifier.AssertNotCalled(t, "ProcessLocal", testifymock.Anything)
+
+	// the chunk is still pending, and the invalid responder is recorded with its status
+	pending, ok := f.engine.pendingChunks.ByID(chunk.ID())
+	require.True(t, ok)
+	require.Equal(t, []flow.Identifier{invalidID}, pending.InvalidResponders)
+	require.Equal(t, 1, pending.Attempt)
+}
+
+// TestPenalizedExecutor evaluates that a penalized executor is no longer requested chunk data packs, and that its
+// chunk data packs are dropped without reporting it again.
+func TestPenalizedExecutor(t *testing.T) {
+	f := newFetcherFixture(t)
+	f.engine.WithChunkConsumerNotifier(&mock.ProcessingNotifier{})
+
+	chunk := f.chunk(0)
+	agrees := f.executors[:2].NodeIDs()
+	status := vermodel.NewChunkStatus(chunk, f.result.ID(), f.block.Header.Height, agrees, nil)
+	require.True(t, f.engine.pendingChunks.Add(status))
+
+	penalizedID := agrees[0]
+	f.penalized = []flow.Identifier{penalizedID}
+
+	f.con.On("Publish", testifymock.Anything, testifymock.Anything, testifymock.Anything).
+		Return(nil).
+		Run(func(args testifymock.Arguments) {
+			require.NotContains(t, args[1:], penalizedID, "chunk data pack requested from penalized executor")
+			require.Contains(t, args[1:], agrees[1], "chunk data pack not requested from other agreeing executor")
+		}).
+		Once()
+	f.statuses.On("Store", status).Return(nil).Once()
+
+	require.NoError(t, f.engine.requestChunkDataPack(status, f.executors))
+
+	dataPack, collection := f.chunkDataPack(chunk)
+	err := f.engine.onChunkDataPack(penalizedID, dataPack, collection, nil)
+	require.True(t, engine.IsInvalidInputError(err))
+
+	f.con.AssertExpectations(t)
+	f.statuses.AssertExpectations(t)
+	f.reporter.AssertNotCalled(t, "ReportMisbehavior", testifymock.Anything, testifymock.Anything)
+	f.verifier.AssertNotCalled(t, "ProcessLocal", testifymock.Anything)
+
+	// the chunk is still pending
+	_, ok := f.engine.pendingChunks.ByID(chunk.ID())
+	require.True(t, ok)
+}
+
+// TestChooseChunkDataPackTarget evaluates that chunk data packs are never requested from the executors that
+// responded with an invalid chunk data pack.
+func TestChooseChunkDataPackTarget(t *testing.T) {
+	executors := unittest.IdentityListFixture(4, unittest.WithRole(flow.RoleExecution))
+	agrees := executors[:3].NodeIDs()
+	invalid := []flow.Identifier{agrees[0]}
+
+	for i := 0; i < 10; i++ {
+		targets := chooseChunkDataPackTarget(executors, agrees, nil, invalid)
+		require.Len(t, targets, 2)
+		require.NotContains(t, targets, invalid[0])
+		require.Subset(t, agrees[1:], targets)
+	}
+
+	// once a single agreeing executor remains, the other target is any executor who did not respond invalidly
+	invalid = append(invalid, agrees[1])
+	for i := 0; i < 10; i++ {
+		targets := chooseChunkDataPackTarget(executors, agrees, nil, invalid)
+		require.Contains(t, targets, agrees[2])
+		require.NotContains(t, targets, invalid[0])
+		require.NotContains(t, targets, invalid[1])
+	}
+}
+
+// TestRemoveSealedStatuses evaluates that the statuses of sealed chunks are removed by height range, and that each
+// sealed height is only visited once.
+func TestRemoveSealedStatuses(t *testing.T) {
+	f := newFetcherFixture(t)
+
+	f.statuses.On("RemoveByHeightRange", uint64(0), uint64(10)).Return(uint(3), nil).Once()
+	require.NoError(t, f.engine.removeSealedStatuses(10))
+
+	// no new sealed height
+	require.NoError(t, f.engine.removeSealedStatuses(10))
+
+	f.statuses.On("RemoveByHeightRange", uint64(11), uint64(12)).Return(uint(0), nil).Once()
+	require.NoError(t, f.engine.removeSealedStatuses(12))
+
+	f.statuses.AssertExpectations(t)
+}
@@ -14,4 +14,5 @@ type VerifiableChunkData struct {
 	Collection    *flow.Collection      // collection corresponding to the chunk
 	ChunkDataPack *flow.ChunkDataPack   // chunk data package needed to verify this chunk
 	EndState      flow.StateCommitment  // state commitment at the end of this chunk
+	ExecutionData *flow.ExecutionData   // execution data committed in the execution result, nil if not committed
 }
@@ -0,0 +1,187 @@
+package pipeline
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	"github.com/onflow/flow-go/engine/verification/assigner"
+	"github.com/onflow/flow-go/engine/verification/assigner/blockconsumer"
+	"github.com/onflow/flow-go/engine/verification/fetcher"
+	"github.com/onflow/flow-go/engine/verification/fetcher/chunkconsumer"
+	"github.com/onflow/flow-go/engine/verification/verifier"
+	"github.com/onflow/flow-go/module"
+	"github.com/onflow/flow-go/state/protocol"
+	"github.com/onflow/flow-go/storage"
+)
+
+// Config contains the configuration of the verification pipeline.
+type Config struct {
+	BlockWorkers    int64         // number of finalized blocks whose receipts are assigned in parallel
+	ChunkWorkers    int64         // number of assigned chunks whose chunk data packs are fetched in parallel
+	VerifierWorkers uint          // number of chunks verified in parallel
+	ChunkTimeout    time.Duration // time after which the verifier stops waiting for a chunk verification, 0 for none
+	RetryInterval   time.Duration // time interval of retrying chunk data pack requests
+	MaxAttempts     int           // number of times a chunk data pack is requested
+}
+
+// DefaultConfig returns the default configuration of the verification pipeline.
+func DefaultConfig() Config {
+	return Config{
+		BlockWorkers:    1,
+		ChunkWorkers:    20,
+		VerifierWorkers: 4,
+		ChunkTimeout:    5 * time.Minute,
+		RetryInterval:   5 * time.Second,
+		MaxAttempts:     17500,
+	}
+}
+
+// Pipeline is the verification pipeline of a verification node. Its progress is persisted, so that a restarted node
+// resumes verifying where it left off:
+// - the block consumer reads the finalized blocks, and passes them to the assigner engine.
+// - the assigner engine determines the chunks of the results included in the blocks that are assigned to this node,
+// and stores them in the chunks queue.
+// - the chunk consumer reads the assigned chunks from the chunks queue, and passes them to the fetcher engine.
+// - the fetcher engine requests the chunk data packs of the chunks from the execution nodes, validates them, and
+// passes the chunks to the verifier engine.
+// - the verifier engine verifies the chunks, and approves or challenges them.
+type Pipeline struct {
+	Verifier      *verifier.Engine
+	Fetcher       *fetcher.Engine
+	ChunkConsumer *chunkconsumer.ChunkConsumer
+	Assigner      *assigner.Engine
+	BlockConsumer *blockconsumer.BlockConsumer
+}
+
+// New wires the engines of the verification pipeline, which persist their progress in the given storage.
+// The block consumer of the pipeline should be notified of the finalized blocks.
+func New(
+	log zerolog.Logger,
+	metrics module.VerificationMetrics,
+	tracer module.Tracer,
+	net module.Network,
+	me module.Local,
+	state protocol.State,
+	chunkVerifier module.ChunkVerifier,
+	chunkAssigner module.ChunkAssigner,
+	reporter module.MisbehaviorReporter,
+	pendingChunks *fetcher.Chunks,
+	headers storage.Headers,
+	blocks storage.Blocks,
+	results storage.ExecutionResults,
+	receipts storage.ExecutionReceipts,
+	approvals storage.ResultApprovals,
+	statuses storage.ChunkStatuses,
+	verdicts storage.ChunkVerdicts,
+	chunksQueue storage.ChunksQueue,
+	processedChunkIndex storage.ConsumerProgress,
+	processedBlockHeight storage.ConsumerProgress,
+	config Config,
+) (*Pipeline, error) {
+
+	// the chunks queue is only initialized the first time the node starts
+	_, err := chunksQueue.Init(chunkconsumer.DefaultJobIndex)
+	if err != nil {
+		return nil, fmt.Errorf("could not initialize chunks queue: %w", err)
+	}
+
+	verifierEng, err := verifier.New(log,
+		metrics,
+		tracer,
+		net,
+		state,
+		me,
+		chunkVerifier,
+		receipts,
+		approvals,
+		statuses,
+		verdicts,
+		config.VerifierWorkers,
+		config.ChunkTimeout)
+	if err != nil {
+		return nil, fmt.Errorf("could not create verifier engine: %w", err)
+	}
+
+	fetcherEng, err := fetcher.New(log,
+		metrics,
+		tracer,
+		net,
+		me,
+		verifierEng,
+		state,
+		pendingChunks,
+		headers,
+		blocks,
+		results,
+		receipts,
+		statuses,
+		reporter,
+		config.RetryInterval,
+		config.MaxAttempts)
+	if err != nil {
+		return nil, fmt.Errorf("could not create fetcher engine: %w", err)
+	}
+
+	chunkConsumer := chunkconsumer.NewChunkConsumer(log,
+		processedChunkIndex,
+		chunksQueue,
+		fetcherEng,
+		config.ChunkWorkers)
+
+	assignerEng := assigner.New(log,
+		metrics,
+		tracer,
+		me,
+		state,
+		chunkAssigner,
+		chunksQueue,
+		chunkConsumer)
+
+	blockConsumer, _, err := blockconsumer.NewBlockConsumer(log,
+		processedBlockHeight,
+		blocks,
+		state,
+		assignerEng,
+		config.BlockWorkers)
+	if err != nil {
+		return nil, fmt.Errorf("could not create block consumer: %w", err)
+	}
+
+	return &Pipeline{
+		Verifier:      verifierEng,
+		Fetcher:       fetcherEng,
+		ChunkConsumer: chunkConsumer,
+		Assigner:      assignerEng,
+		BlockConsumer: blockConsumer,
+	}, nil
+}
+
+// Ready starts the engines of the pipeline from its end, so that each engine is ready before the engine feeding it,
+// and returns a channel that is closed once all of them are ready.
+func (p *Pipeline) Ready() <-chan struct{} {
+	components := []module.ReadyDoneAware{p.Verifier, p.Fetcher, p.ChunkConsumer, p.Assigner, p.BlockConsumer}
+	ready := make(chan struct{})
+	go func() {
+		for _, component := range components {
+			<-component.Ready()
+		}
+		close(ready)
+	}()
+	return ready
+}
+
+// Done stops the engines of the pipeline from its start, so that no engine is fed once it is stopped, and returns a
+// channel that is closed once all of them are done.
+func (p *Pipeline) Done() <-chan struct{} {
+	components := []module.ReadyDoneAware{p.BlockConsumer, p.Assigner, p.ChunkConsumer, p.Fetcher, p.Verifier}
+	done := make(chan struct{})
+	go func() {
+		for _, component := range components {
+			<-component.Done()
+		}
+		close(done)
+	}()
+	return done
+}
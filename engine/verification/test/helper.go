@@ -12,6 +12,7 @@ import (
 	"github.com/stretchr/testify/require"
 	"golang.org/x/exp/rand"
 
+	"github.com/onflow/flow-go/consensus/hotstuff/model"
 	"github.com/onflow/flow-go/crypto"
 	"github.com/onflow/flow-go/engine"
 	"github.com/onflow/flow-go/engine/testutil"
@@ -31,14 +32,13 @@ import (
 )
 
 // VerificationHappyPath runs `verNodeCount`-many verification nodes
-// and checks that concurrently received execution receipts with the same result part that
-// by each verification node results in:
-// - the selection of the assigned chunks by the ingest engine
-// - request of the associated chunk data pack to the assigned chunks
-// - formation of a complete verifiable chunk by the ingest engine for each assigned chunk
-// - submitting a verifiable chunk locally to the verify engine by the ingest engine
-// - dropping the ingestion of the ERs that share the same result once the verifiable chunk is submitted to verify engine
-// - broadcast of a matching result approval to consensus nodes for each assigned chunk
+// and checks that finalizing a block holding an execution receipt with `chunkNum`-many chunks
+// results in each verification node:
+// - assigning the chunks of the result of the receipt through the assigner engine
+// - requesting the chunk data packs of its assigned chunks from the execution node through the fetcher engine
+// - verifying its assigned chunks through the verifier engine
+// - broadcasting a matching result approval to the consensus nodes for each assigned chunk
+// - clearing its assigned chunks from the pending chunks
 func VerificationHappyPath(t *testing.T,
 	verNodeCount int,
 	chunkNum int,
@@ -50,9 +50,8 @@ func VerificationHappyPath(t *testing.T,
 		Int("chunk_num", chunkNum).
 		Msg("TestHappyPath started")
 
-	// ingest engine parameters
+	// fetcher engine parameters
 	// set based on following issue (3443)
-	processInterval := 1 * time.Second
 	requestInterval := 1 * time.Second
 	failureThreshold := uint(2)
 
@@ -80,19 +79,12 @@ func VerificationHappyPath(t *testing.T,
 			identities,
 			assigner,
 			requestInterval,
-			processInterval,
+			uint(10*chunkNum), // limits size of pending chunks mempool to 10 * chunkNum
 			failureThreshold,
-			uint(10),          // limits size of receipt related mempools to 10
-			uint(10*chunkNum), // limits size of chunks related mempools to 10 * chunkNum
 			chainID,
 			verCollector,
 			mempoolCollector)
 
-		// starts all the engines
-		<-verNode.FinderEngine.Ready()
-		<-verNode.MatchEngine.(module.ReadyDoneAware).Ready()
-		<-verNode.VerifierEngine.(module.ReadyDoneAware).Ready()
-
 		verNodes = append(verNodes, verNode)
 	}
 
@@ -101,11 +93,17 @@ func VerificationHappyPath(t *testing.T,
 	root, err := verNodes[0].State.Params().Root()
 	require.NoError(t, err)
 
-	// creates a child block of root, with its corresponding execution result.
+	// creates a reference block of root, with its corresponding execution result, and a container
+	// block holding the receipt of the execution node for it.
 	completeER := utils.CompleteExecutionReceiptFixture(t, chunkNum, chainID.Chain(), root)
+	for _, receipt := range completeER.Receipts {
+		receipt.ExecutorID = exeIdentity.NodeID
+	}
+	completeER.ContainerBlock.SetPayload(unittest.PayloadFixture(unittest.WithReceipts(completeER.Receipts...)))
 
 	// imitates follower engine on verification nodes
-	// received block of `completeER` and mutate state accordingly.
+	// received and finalized the blocks of `completeER` and mutate state accordingly.
+	var blocks []*flow.Block
 	for _, node := range verNodes {
 		// ensures all nodes have same root block
 		// this is necessary for state mutation.
@@ -113,9 +111,7 @@ func VerificationHappyPath(t *testing.T,
 		require.NoError(t, err)
 		require.Equal(t, root, rootBlock)
 
-		// extends state of node by block of `completeER`.
-		err = node.State.Extend(completeER.ReceiptsData[0].ReferenceBlock)
-		assert.Nil(t, err)
+		blocks = ExtendStateWithFinalizedBlocks(t, []*utils.CompleteExecutionReceipt{completeER}, node.State)
 	}
 
 	// mocks the assignment to only assign "some" chunks to each verification node.
@@ -134,36 +130,26 @@ func VerificationHappyPath(t *testing.T,
 		completeER,
 		chainID)
 
-	// sends execution receipt to each of verification nodes
-	verWG := sync.WaitGroup{}
+	// starts the verification pipeline of each verification node,
+	// and sets its network in continuous delivery mode
+	verNets := make([]*stub.Network, 0)
 	for _, verNode := range verNodes {
-		verWG.Add(1)
-		go func(vn enginemock.VerificationNode, receipt *flow.ExecutionReceipt) {
-			defer verWG.Done()
-			err := vn.FinderEngine.Process(exeIdentity.NodeID, receipt)
-			require.NoError(t, err)
-		}(verNode, completeER.Receipts[0])
-	}
-
-	// requires all verification nodes process the receipt
-	unittest.RequireReturnsBefore(t, verWG.Wait, time.Duration(chunkNum*verNodeCount*5)*time.Second,
-		"verification node process")
+		unittest.RequireCloseBefore(t, verNode.Pipeline.Ready(), time.Second, "could not start verification pipeline")
 
-	// creates a network instance for each verification node
-	// and sets it in continuous delivery mode
-	// then flushes the collection requests
-	verNets := make([]*stub.Network, 0)
-	for _, verIdentity := range verIdentities {
-		verNet, ok := hub.GetNetwork(verIdentity.NodeID)
+		verNet, ok := hub.GetNetwork(verNode.Me.NodeID())
 		assert.True(t, ok)
 		verNet.StartConDev(requestInterval, true)
-		verNet.DeliverSome(true, func(m *stub.PendingMessage) bool {
-			return m.Channel == engine.RequestCollections
-		})
 
 		verNets = append(verNets, verNet)
 	}
 
+	// notifies the block consumer of each verification node of the finalized blocks
+	for _, verNode := range verNodes {
+		for range blocks {
+			verNode.Pipeline.BlockConsumer.OnFinalizedBlock(&model.Block{})
+		}
+	}
+
 	// requires all verification nodes send a result approval per assigned chunk
 	unittest.RequireReturnsBefore(t, conWG.Wait, time.Duration(chunkNum*verNodeCount*5)*time.Second,
 		"consensus node process")
@@ -175,12 +161,9 @@ func VerificationHappyPath(t *testing.T,
 
 	// stops verification nodes
 	// Note: this should be done prior to any evaluation to make sure that
-	// the process method of Ingest engines is done working.
+	// the engines of the verification pipeline are done working.
 	for _, verNode := range verNodes {
-		// stops all the engines
-		<-verNode.FinderEngine.Done()
-		<-verNode.MatchEngine.(module.ReadyDoneAware).Done()
-		<-verNode.VerifierEngine.(module.ReadyDoneAware).Done()
+		unittest.RequireCloseBefore(t, verNode.Pipeline.Done(), time.Second, "could not stop verification pipeline")
 	}
 
 	// stops continuous delivery of nodes
@@ -191,17 +174,11 @@ func VerificationHappyPath(t *testing.T,
 	conNode.Done()
 	exeNode.Done()
 
-	// asserts that all processing pipeline of verification node is fully
-	// cleaned up.
+	// asserts that all assigned chunks of the verification nodes are cleaned up
+	// from their pending chunks once verified.
 	for _, verNode := range verNodes {
-		assert.Equal(t, verNode.ChunkIDsByResult.Size(), uint(0))
-		assert.Equal(t, verNode.CachedReceipts.Size(), uint(0))
-		assert.Equal(t, verNode.ReadyReceipts.Size(), uint(0))
-		assert.Equal(t, verNode.PendingChunks.Size(), uint(0))
-		assert.Equal(t, verNode.PendingReceiptIDsByBlock.Size(), uint(0))
-		assert.Equal(t, verNode.PendingReceipts.Size(), uint(0))
-		assert.Equal(t, verNode.PendingResults.Size(), uint(0))
-		assert.Equal(t, verNode.ReceiptIDsByResult.Size(), uint(0))
+		assert.Equal(t, uint(0), verNode.PendingChunks.Size())
+		verNode.Done()
 	}
 
 	// to demarcate the debug logs
@@ -0,0 +1,357 @@
+package test
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	testifymock "github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/consensus/hotstuff/model"
+	"github.com/onflow/flow-go/crypto"
+	"github.com/onflow/flow-go/engine"
+	"github.com/onflow/flow-go/engine/testutil/mocklocal"
+	"github.com/onflow/flow-go/engine/verification"
+	"github.com/onflow/flow-go/engine/verification/dashboard"
+	"github.com/onflow/flow-go/engine/verification/fetcher"
+	"github.com/onflow/flow-go/engine/verification/pipeline"
+	chmodels "github.com/onflow/flow-go/model/chunks"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/model/messages"
+	"github.com/onflow/flow-go/module"
+	"github.com/onflow/flow-go/module/metrics"
+	"github.com/onflow/flow-go/module/mock"
+	"github.com/onflow/flow-go/module/trace"
+	"github.com/onflow/flow-go/network/mocknetwork"
+	protocol "github.com/onflow/flow-go/state/protocol/mock"
+	bstorage "github.com/onflow/flow-go/storage/badger"
+	"github.com/onflow/flow-go/storage/badger/operation"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+// TestVerificationNodeRestart evaluates that a verification node killed in the middle of its verification pipeline
+// resumes from its persisted progress once restarted:
+// - the node is assigned three chunks of a result included in a finalized block, and requests their chunk data packs.
+// - the chunk data pack of the first chunk is never received before the node is killed.
+// - the chunk data pack of the second chunk is received, and the chunk is approved before the node is killed.
+// - the chunk data pack of the third chunk is received, but the node is killed while verifying the chunk.
+// Once restarted on the same database, the node does not request any of the chunk data packs again, does not approve
+// the second chunk again, approves the third chunk, and approves the first chunk once its chunk data pack arrives.
+// The dashboard of the node then reports the three chunks as approved.
+func TestVerificationNodeRestart(t *testing.T) {
+	unittest.RunWithBadgerDB(t, func(db *badger.DB) {
+		seed := unittest.SeedFixture(crypto.KeyGenSeedMinLenBLSBLS12381)
+		sk, err := crypto.GeneratePrivateKey(crypto.BLSBLS12381, seed)
+		require.NoError(t, err)
+
+		verID := unittest.IdentityFixture(unittest.WithRole(flow.RoleVerification))
+		exeID := unittest.IdentityFixture(unittest.WithRole(flow.RoleExecution))
+		conID := unittest.IdentityFixture(unittest.WithRole(flow.RoleConsensus))
+
+		// the executed block is the child of the last sealed block, and contains three collections
+		collections := make([]*flow.Collection, 0, 3)
+		guarantees := make([]*flow.CollectionGuarantee, 0, 3)
+		for i := 0; i < 3; i++ {
+			collection := unittest.CollectionFixture(1)
+			collections = append(collections, &collection)
+			guarantees = append(guarantees, unittest.CollectionGuaranteeFixture(unittest.WithCollection(&collection)))
+		}
+		sealed := unittest.BlockHeaderFixture()
+		block := unittest.BlockWithParentFixture(&sealed)
+		block.SetPayload(unittest.PayloadFixture(unittest.WithGuarantees(guarantees...)))
+
+		result := unittest.ExecutionResultFixture(unittest.WithBlock(&block))
+		for _, chunk := range result.Chunks[:3] {
+			chunk.NumberOfTransactions = 1
+		}
+		receipt := unittest.ExecutionReceiptFixture(
+			unittest.WithExecutorID(exeID.NodeID),
+			unittest.WithResult(result))
+
+		// the receipt is included in the finalized child of the executed block
+		container := unittest.BlockWithParentFixture(block.Header)
+		container.SetPayload(unittest.PayloadFixture(unittest.WithReceipts(receipt)))
+
+		storages := bstorage.InitAll(metrics.NewNoopCollector(), db)
+		for _, finalized := range []*flow.Block{&block, &container} {
+			require.NoError(t, storages.Blocks.Store(finalized))
+			require.NoError(t, db.Update(operation.IndexBlockHeight(finalized.Header.Height, finalized.ID())))
+		}
+
+		state := restartStateFixture(&sealed, container.Header, block.Header, flow.IdentityList{verID, exeID, conID})
+
+		// the non-system chunks of the result are assigned to the node
+		assignment := chmodels.NewAssignment()
+		for _, chunk := range result.Chunks[:3] {
+			assignment.Add(chunk, flow.IdentifierList{verID.NodeID})
+		}
+		chunkAssigner := &mock.ChunkAssigner{}
+		chunkAssigner.On("Assign", testifymock.Anything, block.ID()).Return(assignment, nil)
+
+		// the node is killed while verifying the third chunk
+		nodeA := newRestartNode(t, db, state, mocklocal.NewMockLocal(sk, verID.NodeID, t), chunkAssigner, 2)
+		nodeA.start(t)
+
+		nodeA.pipeline.BlockConsumer.OnFinalizedBlock(&model.Block{BlockID: container.ID()})
+		require.Eventually(t, func() bool {
+			return len(nodeA.requestedChunks(result)) == 3
+		}, time.Second, 10*time.Millisecond, "chunk data packs of assigned chunks are not requested")
+		require.ElementsMatch(t, []uint64{0, 1, 2}, nodeA.requestedChunks(result))
+
+		require.NoError(t, nodeA.pipeline.Fetcher.Process(exeID.NodeID, chunkDataResponseFixture(result.Chunks[1], collections[1])))
+		nodeA.requireApproved(t, 1)
+		require.NoError(t, nodeA.pipeline.Fetcher.Process(exeID.NodeID, chunkDataResponseFixture(result.Chunks[2], collections[2])))
+		unittest.RequireCloseBefore(t, nodeA.chVerif.hanging, time.Second, "third chunk is not verified")
+
+		nodeA.kill(t)
+
+		// the restarted node resumes on the same database, and its chunk consumer delivers the unfinished chunks again
+		nodeB := newRestartNode(t, db, state, mocklocal.NewMockLocal(sk, verID.NodeID, t), chunkAssigner, -1)
+		nodeB.start(t)
+		nodeB.requireApproved(t, 2)
+
+		// the finalized block is not assigned again once the node is notified of finalized blocks
+		nodeB.pipeline.BlockConsumer.OnFinalizedBlock(&model.Block{BlockID: container.ID()})
+		require.NoError(t, nodeB.pipeline.Fetcher.Process(exeID.NodeID, chunkDataResponseFixture(result.Chunks[0], collections[0])))
+		nodeB.requireApproved(t, 0)
+
+		nodeB.stop(t)
+
+		// none of the chunk data packs are requested again, and the approved chunk is not approved again
+		assert.Empty(t, nodeB.requestedChunks(result))
+		assert.Empty(t, nodeB.approved)
+
+		// all chunks are approved exactly once
+		approvals := bstorage.NewResultApprovals(metrics.NewNoopCollector(), db)
+		for _, chunk := range result.Chunks[:3] {
+			_, err := approvals.ByChunk(result.ID(), chunk.Index)
+			assert.NoError(t, err)
+		}
+
+		// the dashboard reports the chunks assigned by the pipeline, with the approvals it emitted
+		dash := dashboard.New(state,
+			storages.Headers,
+			storages.Results,
+			bstorage.NewChunkQueue(db),
+			bstorage.NewConsumerProgress(db, module.ConsumeProgressVerificationChunkIndex),
+			bstorage.NewChunkStatuses(db),
+			bstorage.NewChunkVerdicts(db))
+		report, err := dash.Report(10)
+		require.NoError(t, err)
+		require.Len(t, report.Blocks, 1)
+		assert.Equal(t, block.ID(), report.Blocks[0].BlockID)
+		require.Len(t, report.Blocks[0].Chunks, 3)
+		for _, chunk := range report.Blocks[0].Chunks {
+			assert.NotEqual(t, dashboard.FetchQueued, chunk.FetchState)
+			require.Equal(t, dashboard.OutcomeApproved, chunk.Outcome)
+			approval, err := approvals.ByChunk(result.ID(), chunk.ChunkIndex)
+			require.NoError(t, err)
+			assert.Equal(t, approval.ID(), *chunk.ApprovalID)
+		}
+	})
+}
+
+// restartNode holds the verification pipeline of a verification node, wired as in the verification node binary, with
+// the chunk data pack requests and the result approvals it publishes.
+type restartNode struct {
+	pipeline *pipeline.Pipeline
+	chVerif  *hangingChunkVerifier
+	mu       sync.Mutex
+	requests []flow.Identifier // chunk IDs of requested chunk data packs
+	approved chan uint64       // chunk indices of published result approvals
+}
+
+// newRestartNode creates the verification pipeline of a verification node on the given database, whose verification
+// of the chunk with the given index hangs until the node is killed.
+func newRestartNode(t *testing.T,
+	db *badger.DB,
+	state *protocol.State,
+	me *mocklocal.MockLocal,
+	chunkAssigner *mock.ChunkAssigner,
+	hangingIndex int64) *restartNode {
+
+	node := &restartNode{
+		chVerif: &hangingChunkVerifier{
+			index:   hangingIndex,
+			hanging: make(chan struct{}),
+			killed:  make(chan struct{}),
+		},
+		approved: make(chan uint64, 3),
+	}
+
+	requestCon := &mocknetwork.Conduit{}
+	requestCon.On("Publish", testifymock.Anything, testifymock.Anything, testifymock.Anything).
+		Return(nil).
+		Run(func(args testifymock.Arguments) {
+			req, ok := args[0].(*messages.ChunkDataRequest)
+			require.True(t, ok)
+			node.mu.Lock()
+			defer node.mu.Unlock()
+			node.requests = append(node.requests, req.ChunkID)
+		})
+
+	approvalCon := &mocknetwork.Conduit{}
+	approvalCon.On("Publish", testifymock.Anything, testifymock.Anything).
+		Return(nil).
+		Run(func(args testifymock.Arguments) {
+			approval, ok := args[0].(*flow.ResultApproval)
+			require.True(t, ok)
+			node.approved <- approval.Body.ChunkIndex
+		})
+
+	net := &mock.Network{}
+	net.On("Register", engine.PushApprovals, testifymock.Anything).Return(approvalCon, nil)
+	net.On("Register", engine.ProvideApprovalsByChunk, testifymock.Anything).Return(&mocknetwork.Conduit{}, nil)
+	net.On("Register", engine.PushChallenges, testifymock.Anything).Return(&mocknetwork.Conduit{}, nil)
+	net.On("Register", engine.RequestChunks, testifymock.Anything).Return(requestCon, nil)
+
+	// storage layer is instantiated freshly, as it would be by a restarted node
+	collector := metrics.NewNoopCollector()
+	storages := bstorage.InitAll(collector, db)
+
+	// all chunk data packs are valid
+	reporter := &mock.MisbehaviorReporter{}
+	reporter.On("IsPenalized", testifymock.Anything).Return(false)
+
+	config := pipeline.DefaultConfig()
+	config.ChunkWorkers = 3
+	config.VerifierWorkers = 1
+	config.ChunkTimeout = 0
+	config.RetryInterval = time.Hour // chunk data packs are not retried throughout the test
+	config.MaxAttempts = 3
+
+	var err error
+	node.pipeline, err = pipeline.New(zerolog.Nop(),
+		collector,
+		trace.NewNoopTracer(),
+		net,
+		me,
+		state,
+		node.chVerif,
+		chunkAssigner,
+		reporter,
+		fetcher.NewChunks(100),
+		storages.Headers,
+		storages.Blocks,
+		storages.Results,
+		storages.Receipts,
+		bstorage.NewResultApprovals(collector, db),
+		bstorage.NewChunkStatuses(db),
+		bstorage.NewChunkVerdicts(db),
+		bstorage.NewChunkQueue(db),
+		bstorage.NewConsumerProgress(db, module.ConsumeProgressVerificationChunkIndex),
+		bstorage.NewConsumerProgress(db, module.ConsumeProgressVerificationBlockHeight),
+		config)
+	require.NoError(t, err)
+
+	return node
+}
+
+func (n *restartNode) start(t *testing.T) {
+	unittest.RequireCloseBefore(t, n.pipeline.Ready(), time.Second, "could not start verification pipeline")
+}
+
+func (n *restartNode) stop(t *testing.T) {
+	unittest.RequireCloseBefore(t, n.pipeline.Done(), time.Second, "could not stop verification pipeline")
+}
+
+// kill stops the node, aborting the verification of its hanging chunk.
+func (n *restartNode) kill(t *testing.T) {
+	close(n.chVerif.killed)
+	n.stop(t)
+}
+
+// requestedChunks returns the indices of the chunks of the result whose chunk data packs the node requested.
+func (n *restartNode) requestedChunks(result *flow.ExecutionResult) []uint64 {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	indices := make([]uint64, 0, len(n.requests))
+	for _, chunk := range result.Chunks {
+		for _, chunkID := range n.requests {
+			if chunk.ID() == chunkID {
+				indices = append(indices, chunk.Index)
+			}
+		}
+	}
+	return indices
+}
+
+// requireApproved requires the next result approval published by the node to be for the chunk with the given index.
+func (n *restartNode) requireApproved(t *testing.T, index uint64) {
+	select {
+	case approved := <-n.approved:
+		require.Equal(t, index, approved, "unexpected chunk approved")
+	case <-time.After(time.Second):
+		t.Fatalf("chunk %d is not approved", index)
+	}
+}
+
+// hangingChunkVerifier verifies all chunks successfully, except for the chunk with the given index, whose verification
+// hangs until the node is killed, and then fails.
+type hangingChunkVerifier struct {
+	index   int64
+	hanging chan struct{}
+	killed  chan struct{}
+}
+
+func (v *hangingChunkVerifier) Verify(vc *verification.VerifiableChunkData) ([]byte, chmodels.ChunkFault, error) {
+	if int64(vc.Chunk.Index) == v.index {
+		close(v.hanging)
+		<-v.killed
+		return nil, nil, fmt.Errorf("node killed during verification")
+	}
+	return []byte{}, nil, nil
+}
+
+func (v *hangingChunkVerifier) SystemChunkVerify(vc *verification.VerifiableChunkData) ([]byte, chmodels.ChunkFault, error) {
+	return v.Verify(vc)
+}
+
+// restartStateFixture mocks the protocol state of a verification node, which has sealed the parent of the given block,
+// and finalized the given final block.
+func restartStateFixture(sealed *flow.Header, final *flow.Header, header *flow.Header, identities flow.IdentityList) *protocol.State {
+	state := &protocol.State{}
+
+	sealedSnapshot := &protocol.Snapshot{}
+	sealedSnapshot.On("Head").Return(sealed, nil)
+	state.On("Sealed").Return(sealedSnapshot)
+
+	finalSnapshot := &protocol.Snapshot{}
+	finalSnapshot.On("Head").Return(final, nil)
+	finalSnapshot.On("Identities", testifymock.Anything).Return(
+		func(selector flow.IdentityFilter) flow.IdentityList {
+			return identities.Filter(selector)
+		},
+		nil)
+	state.On("Final").Return(finalSnapshot)
+
+	blockSnapshot := &protocol.Snapshot{}
+	blockSnapshot.On("Identity", testifymock.Anything).Return(
+		func(nodeID flow.Identifier) *flow.Identity {
+			identity, _ := identities.ByNodeID(nodeID)
+			return identity
+		},
+		nil)
+	state.On("AtBlockID", header.ID()).Return(blockSnapshot)
+
+	return state
+}
+
+// chunkDataResponseFixture returns a valid chunk data response for the given chunk and its collection.
+func chunkDataResponseFixture(chunk *flow.Chunk, collection *flow.Collection) *messages.ChunkDataResponse {
+	return &messages.ChunkDataResponse{
+		ChunkDataPack: flow.ChunkDataPack{
+			ChunkID:      chunk.ID(),
+			StartState:   chunk.StartState,
+			Proof:        []byte{'p'},
+			CollectionID: collection.ID(),
+		},
+		Collection: *collection,
+	}
+}
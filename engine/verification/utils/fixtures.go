@@ -213,14 +213,17 @@ func ExecutionResultFixture(t *testing.T, chunkCount int, chain flow.Chain, refB
 			require.NoError(t, err, "error updating registers")
 
 			var collectionID flow.Identifier
+			var txCount uint64
 
-			// account for system chunk being last
+			// account for system chunk being last, which only executes the system transaction
 			if i < len(computationResult.StateSnapshots)-1 {
 				collectionGuarantee := executableBlock.Block.Payload.Guarantees[i]
 				completeCollection := executableBlock.CompleteCollections[collectionGuarantee.ID()]
 				collectionID = completeCollection.Collection().ID()
+				txCount = uint64(len(completeCollection.Transactions))
 			} else {
 				collectionID = flow.ZeroID
+				txCount = 1
 			}
 
 			chunk := &flow.Chunk{
@@ -234,8 +237,7 @@ func ExecutionResultFixture(t *testing.T, chunkCount int, chain flow.Chain, refB
 					BlockID:         executableBlock.ID(),
 					// TODO: record gas used
 					TotalComputationUsed: 0,
-					// TODO: record number of txs
-					NumberOfTransactions: 0,
+					NumberOfTransactions: txCount,
 				},
 				Index:    uint64(i),
 				EndState: endStateCommitment,
@@ -256,7 +258,7 @@ func ExecutionResultFixture(t *testing.T, chunkCount int, chain flow.Chain, refB
 				ChunkID:      chunk.ID(),
 				StartState:   chunk.StartState,
 				Proof:        proof,
-				CollectionID: collection.ID(),
+				CollectionID: collectionID,
 			}
 
 			chunks = append(chunks, chunk)
@@ -323,9 +325,10 @@ func LightExecutionResultFixture(chunkCount int) *CompleteExecutionReceipt {
 	for i := 0; i < chunkCount; i++ {
 		chunk := &flow.Chunk{
 			ChunkBody: flow.ChunkBody{
-				CollectionIndex: uint(i),
-				BlockID:         blockID,
-				EventCollection: unittest.IdentifierFixture(),
+				CollectionIndex:      uint(i),
+				BlockID:              blockID,
+				EventCollection:      unittest.IdentifierFixture(),
+				NumberOfTransactions: uint64(len(collections[i].Transactions)),
 			},
 			Index: uint64(i),
 		}
@@ -12,3 +12,10 @@ func NewResultApprovalHasher() hash.Hasher {
 	h := crypto.NewBLSKMAC(encoding.ResultApprovalTag)
 	return h
 }
+
+// NewChallengeHasher generates and returns a hasher for signing
+// and verification of challenges
+func NewChallengeHasher() hash.Hasher {
+	h := crypto.NewBLSKMAC(encoding.ChallengeTag)
+	return h
+}
@@ -2,7 +2,9 @@ package verifier
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"time"
 
 	"github.com/opentracing/opentracing-go"
 	"github.com/rs/zerolog"
@@ -17,6 +19,7 @@ import (
 	"github.com/onflow/flow-go/model/flow"
 	"github.com/onflow/flow-go/model/flow/filter"
 	"github.com/onflow/flow-go/model/messages"
+	vermodel "github.com/onflow/flow-go/model/verification"
 	"github.com/onflow/flow-go/module"
 	"github.com/onflow/flow-go/module/trace"
 	"github.com/onflow/flow-go/network"
@@ -25,10 +28,17 @@ import (
 	"github.com/onflow/flow-go/utils/logging"
 )
 
+// queueCapacity is the max number of verifiable chunks waiting to be verified, submitting more chunks blocks until
+// the workers catch up.
+const queueCapacity = 1000
+
 // Engine (verifier engine) verifies chunks, generates result approvals or raises challenges.
 // as input it accepts verifiable chunks (chunk + all data needed) and perform verification by
 // constructing a partial trie, executing transactions and check the final state commitment and
 // other chunk meta data (e.g. tx count)
+//
+// Verifiable chunks are queued and verified by a pool of workers, the chunks of the lowest block height first, as
+// their blocks are the closest to emergency sealing.
 type Engine struct {
 	unit        *engine.Unit               // used to control startup/shutdown
 	log         zerolog.Logger             // used to log relevant actions
@@ -36,12 +46,21 @@ type Engine struct {
 	tracer      module.Tracer              // used for tracing
 	pushConduit network.Conduit            // used to push result approvals
 	pullConduit network.Conduit            // used to respond to requests for result approvals
+	chalConduit network.Conduit            // used to push challenges
 	me          module.Local               // used to access local node information
 	state       protocol.State             // used to access the protocol state
 	rah         hash.Hasher                // used as hasher to sign the result approvals
+	chh         hash.Hasher                // used as hasher to sign the challenges
 	chVerif     module.ChunkVerifier       // used to verify chunks
 	spockHasher hash.Hasher                // used for generating spocks
+	receipts    storage.ExecutionReceipts  // used to retrieve the receipts committing to faulty results
 	approvals   storage.ResultApprovals    // used to store result approvals
+	statuses    storage.ChunkStatuses      // used to persist that chunks have been verified
+	verdicts    storage.ChunkVerdicts      // used to persist the verdicts on verified chunks
+	queue       *chunkQueue                // used to queue verifiable chunks for the workers
+	workers     uint                       // number of chunks verified in parallel
+	timeout     time.Duration              // time after which a worker stops waiting for a chunk verification, 0 for none
+	abandoned   chan struct{}              // slots of the timed out verifications still running in the background
 }
 
 // New creates and returns a new instance of a verifier engine.
@@ -53,9 +72,18 @@ func New(
 	state protocol.State,
 	me module.Local,
 	chVerif module.ChunkVerifier,
+	receipts storage.ExecutionReceipts,
 	approvals storage.ResultApprovals,
+	statuses storage.ChunkStatuses,
+	verdicts storage.ChunkVerdicts,
+	workers uint,
+	timeout time.Duration,
 ) (*Engine, error) {
 
+	if workers == 0 {
+		return nil, fmt.Errorf("at least one verification worker is required")
+	}
+
 	e := &Engine{
 		unit:        engine.NewUnit(),
 		log:         log.With().Str("engine", "verifier").Logger(),
@@ -65,8 +93,16 @@ func New(
 		me:          me,
 		chVerif:     chVerif,
 		rah:         utils.NewResultApprovalHasher(),
+		chh:         utils.NewChallengeHasher(),
 		spockHasher: crypto.NewBLSKMAC(encoding.SPOCKTag),
+		receipts:    receipts,
 		approvals:   approvals,
+		statuses:    statuses,
+		verdicts:    verdicts,
+		queue:       newChunkQueue(queueCapacity),
+		workers:     workers,
+		timeout:     timeout,
+		abandoned:   make(chan struct{}, workers),
 	}
 
 	var err error
@@ -80,17 +116,27 @@ func New(
 		return nil, fmt.Errorf("could not register engine on approval pull channel: %w", err)
 	}
 
+	e.chalConduit, err = net.Register(engine.PushChallenges, e)
+	if err != nil {
+		return nil, fmt.Errorf("could not register engine on challenge push channel: %w", err)
+	}
+
 	return e, nil
 }
 
 // Ready returns a channel that is closed when the verifier engine is ready.
+// It starts the verification workers.
 func (e *Engine) Ready() <-chan struct{} {
+	for i := uint(0); i < e.workers; i++ {
+		e.unit.Launch(e.verificationWorker)
+	}
 	return e.unit.Ready()
 }
 
 // Done returns a channel that is closed when the verifier engine is done.
+// The chunks still queued are dropped, the chunks being verified are finished.
 func (e *Engine) Done() <-chan struct{} {
-	return e.unit.Done()
+	return e.unit.Done(e.queue.close)
 }
 
 // SubmitLocal submits an event originating on the local node.
@@ -129,7 +175,7 @@ func (e *Engine) process(originID flow.Identifier, event interface{}) error {
 
 	switch resource := event.(type) {
 	case *verification.VerifiableChunkData:
-		err = e.verifiableChunkHandler(originID, resource)
+		err = e.queueChunk(originID, resource)
 	case *messages.ApprovalRequest:
 		err = e.approvalRequestHandler(originID, resource)
 	default:
@@ -146,6 +192,91 @@ func (e *Engine) process(originID flow.Identifier, event interface{}) error {
 	return nil
 }
 
+// queueChunk queues the verifiable chunk to be verified by the workers, it blocks while the queue is full.
+func (e *Engine) queueChunk(originID flow.Identifier, vc *verification.VerifiableChunkData) error {
+	// only accept internal calls
+	if originID != e.me.NodeID() {
+		return fmt.Errorf("invalid remote origin for verify")
+	}
+
+	depth, ok := e.queue.push(originID, vc)
+	if !ok {
+		return fmt.Errorf("could not queue verifiable chunk, verifier engine is shutting down")
+	}
+	e.metrics.OnVerifierQueueDepth(depth)
+
+	return nil
+}
+
+// verificationWorker verifies the queued chunks one at a time, until the engine shuts down.
+func (e *Engine) verificationWorker() {
+	for {
+		chunk, depth, ok := e.queue.pop()
+		if !ok {
+			return
+		}
+		e.metrics.OnVerifierQueueDepth(depth)
+
+		e.verifyWithTimeout(chunk)
+	}
+}
+
+// verifyWithTimeout verifies the queued chunk, and stops waiting for the verification once the timeout is reached.
+// The chunk verifier can not be interrupted, so a timed out verification keeps running in the background and still
+// emits its result approval, but no longer holds up the chunks queued behind it. At most as many timed out
+// verifications as workers run in the background, once reached the worker waits for the timed out verification,
+// so that the number of running verifications stays bounded.
+func (e *Engine) verifyWithTimeout(chunk *queuedChunk) {
+	if e.timeout == 0 {
+		_ = e.verifiableChunkHandler(chunk.originID, chunk.vc)
+		return
+	}
+
+	done := make(chan struct{})
+	e.unit.Launch(func() {
+		defer close(done)
+		_ = e.verifiableChunkHandler(chunk.originID, chunk.vc)
+	})
+
+	timer := time.NewTimer(e.timeout)
+	defer timer.Stop()
+
+	select {
+	case <-done:
+		return
+	case <-e.unit.Quit():
+		return
+	case <-timer.C:
+	}
+
+	e.metrics.OnChunkVerificationTimeout()
+	log := e.log.With().
+		Hex("result_id", logging.Entity(chunk.vc.Result)).
+		Uint64("chunk_index", chunk.vc.Chunk.Index).
+		Uint64("height", chunk.vc.Header.Height).
+		Dur("timeout", e.timeout).
+		Dur("queued_for", time.Since(chunk.queuedAt)).
+		Logger()
+
+	select {
+	case e.abandoned <- struct{}{}:
+		log.Warn().Msg("chunk verification timed out, verifying the next chunk meanwhile")
+		// releases the slot once the timed out verification is done
+		e.unit.Launch(func() {
+			<-done
+			<-e.abandoned
+		})
+	default:
+		log.Warn().
+			Int("abandoned", cap(e.abandoned)).
+			Msg("chunk verification timed out, waiting for it as too many timed out verifications are running")
+		select {
+		case <-done:
+		case <-e.unit.Quit():
+		}
+	}
+}
+
 // verify handles the core verification process. It accepts a verifiable chunk
 // and all dependent resources, verifies the chunk, and emits a
 // result approval if applicable.
@@ -177,6 +308,18 @@ func (e *Engine) verify(ctx context.Context, originID flow.Identifier,
 	}
 	log.With().Hex("chunk_id", logging.Entity(ch)).Logger()
 
+	// skips the chunks approved already, e.g., chunks passed again to the verifier after a restart
+	approval, err := e.approvals.ByChunk(vc.Result.ID(), vc.Chunk.Index)
+	if err == nil {
+		log.Info().Msg("chunk has been approved already, skipping")
+		verdict := newVerdict(vc, vermodel.VerdictApproved)
+		verdict.ApprovalID = approval.ID()
+		return e.storeVerdict(ch.ID(), verdict)
+	}
+	if !errors.Is(err, storage.ErrNotFound) {
+		return fmt.Errorf("could not check for approval of chunk: %w", err)
+	}
+
 	// execute the assigned chunk
 	span, _ := e.tracer.StartSpanFromContext(ctx, trace.VERVerChunkVerify)
 
@@ -194,28 +337,41 @@ func (e *Engine) verify(ctx context.Context, originID flow.Identifier,
 		return fmt.Errorf("cannot verify chunk: %w", err)
 	}
 
-	// if any fault found with the chunk
+	// if any fault found with the chunk, raise a challenge instead of generating a result approval
 	if chFault != nil {
-		switch chFault.(type) {
-		case *chmodels.CFMissingRegisterTouch:
-			e.log.Error().Msg(chFault.String())
-		case *chmodels.CFNonMatchingFinalState:
-			// TODO raise challenge
-			e.log.Warn().Msg(chFault.String())
-		case *chmodels.CFInvalidVerifiableChunk:
-			// TODO raise challenge
-			e.log.Error().Msg(chFault.String())
-		default:
-			return engine.NewInvalidInputErrorf("unknown type of chunk fault is received (type: %T) : %v",
-				chFault, chFault.String())
+		receipt, err := e.receiptOf(vc.Result)
+		if err != nil {
+			return fmt.Errorf("could not retrieve receipt of faulty result: %w", err)
 		}
-		// don't do anything else, but skip generating result approvals
-		return nil
+		proof, err := faultProof(vc, chFault, receipt)
+		if err != nil {
+			return engine.NewInvalidInputErrorf("could not build fault proof: %w", err)
+		}
+		log := log.With().
+			Str("fault_kind", proof.Kind.String()).
+			Str("fault", chFault.String()).
+			Logger()
+
+		// the chunk is not approved either way, but it is only challenged if an execution node is accountable for it
+		verdict := newVerdict(vc, vermodel.VerdictUnchallenged)
+		verdict.Fault = chFault.String()
+		if receipt == nil {
+			log.Warn().Msg("chunk is faulty, but no receipt committing to the result is known, not raising challenge")
+			return e.storeVerdict(ch.ID(), verdict)
+		}
+
+		log.Warn().Msg("chunk is faulty, raising challenge")
+		verdict.Verdict = vermodel.VerdictChallenged
+		verdict.ChallengeID, err = e.raiseChallenge(ctx, proof)
+		if err != nil {
+			return fmt.Errorf("could not raise challenge: %w", err)
+		}
+		return e.storeVerdict(ch.ID(), verdict)
 	}
 
 	// Generate result approval
 	span, _ = e.tracer.StartSpanFromContext(ctx, trace.VERVerGenerateResultApproval)
-	approval, err := e.GenerateResultApproval(vc.Chunk.Index, vc.Result.ID(), vc.Header.ID(), spockSecret)
+	approval, err = e.GenerateResultApproval(vc.Chunk.Index, vc.Result.ID(), vc.Header.ID(), spockSecret)
 	span.Finish()
 	if err != nil {
 		return fmt.Errorf("couldn't generate a result approval: %w", err)
@@ -231,6 +387,13 @@ func (e *Engine) verify(ctx context.Context, originID flow.Identifier,
 		return fmt.Errorf("could not index approval: %w", err)
 	}
 
+	verdict := newVerdict(vc, vermodel.VerdictApproved)
+	verdict.ApprovalID = approval.ID()
+	err = e.storeVerdict(ch.ID(), verdict)
+	if err != nil {
+		return err
+	}
+
 	// Extracting consensus node ids
 	// TODO state extraction should be done based on block references
 	consensusNodes, err := e.state.Final().
@@ -253,6 +416,115 @@ func (e *Engine) verify(ctx context.Context, originID flow.Identifier,
 	return nil
 }
 
+// newVerdict returns the verdict on the chunk of the verifiable chunk.
+func newVerdict(vc *verification.VerifiableChunkData, verdict vermodel.Verdict) *vermodel.ChunkVerdict {
+	return &vermodel.ChunkVerdict{
+		ExecutionResultID: vc.Result.ID(),
+		ChunkIndex:        vc.Chunk.Index,
+		Verdict:           verdict,
+	}
+}
+
+// storeVerdict persists the verdict on the chunk, which is kept once the block of the chunk is sealed, and then marks
+// the chunk as verified.
+func (e *Engine) storeVerdict(chunkID flow.Identifier, verdict *vermodel.ChunkVerdict) error {
+	err := e.verdicts.Store(verdict)
+	if err != nil {
+		return fmt.Errorf("could not store verdict on chunk: %w", err)
+	}
+
+	return e.markVerified(chunkID)
+}
+
+// markVerified persists that the chunk has been verified, so that it is not verified again after a restart.
+// Chunks without a persisted status, i.e., chunks not fetched by the fetcher engine, are skipped.
+func (e *Engine) markVerified(chunkID flow.Identifier) error {
+	status, err := e.statuses.ByChunkID(chunkID)
+	if errors.Is(err, storage.ErrNotFound) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("could not retrieve status of chunk: %w", err)
+	}
+
+	status.Verified = true
+	err = e.statuses.Store(status)
+	if err != nil {
+		return fmt.Errorf("could not store status of chunk: %w", err)
+	}
+
+	return nil
+}
+
+// receiptOf returns a receipt of an execution node committing to the given result, among the receipts incorporated
+// in the blocks, or nil if there is none.
+func (e *Engine) receiptOf(result *flow.ExecutionResult) (*flow.ExecutionReceiptMeta, error) {
+	receipts, err := e.receipts.ByBlockID(result.BlockID)
+	if err != nil {
+		return nil, fmt.Errorf("could not retrieve receipts of block %x: %w", result.BlockID, err)
+	}
+
+	resultID := result.ID()
+	for _, receipt := range receipts {
+		if receipt.ExecutionResult.ID() == resultID {
+			return receipt.Meta(), nil
+		}
+	}
+
+	return nil, nil
+}
+
+// raiseChallenge signs a challenge holding the fault proof, broadcasts it to the consensus nodes, and returns its ID.
+func (e *Engine) raiseChallenge(ctx context.Context, proof *flow.FaultProof) (flow.Identifier, error) {
+	span, _ := e.tracer.StartSpanFromContext(ctx, trace.VERVerGenerateChallenge)
+	challenge, err := e.GenerateChallenge(proof)
+	span.Finish()
+	if err != nil {
+		return flow.ZeroID, fmt.Errorf("couldn't generate a challenge: %w", err)
+	}
+
+	// TODO state extraction should be done based on block references
+	consensusNodes, err := e.state.Final().
+		Identities(filter.HasRole(flow.RoleConsensus))
+	if err != nil {
+		return flow.ZeroID, fmt.Errorf("could not load consensus node IDs: %w", err)
+	}
+
+	err = e.chalConduit.Publish(challenge, consensusNodes.NodeIDs()...)
+	if err != nil {
+		return flow.ZeroID, fmt.Errorf("could not submit challenge: %w", err)
+	}
+
+	e.log.Info().
+		Hex("result_id", logging.ID(proof.ExecutionResultID)).
+		Uint64("chunk_index", proof.ChunkIndex).
+		Hex("challenge_id", logging.Entity(challenge)).
+		Msg("challenge submitted")
+	e.metrics.OnChallenge()
+
+	return challenge.ID(), nil
+}
+
+// GenerateChallenge generates a challenge of a chunk of an execution result from its fault proof.
+func (e *Engine) GenerateChallenge(proof *flow.FaultProof) (*flow.Challenge, error) {
+	body := flow.ChallengeBody{
+		FaultProof:   *proof,
+		ChallengerID: e.me.NodeID(),
+	}
+
+	// generates a signature over challenge body
+	bodyID := body.ID()
+	bodySign, err := e.me.Sign(bodyID[:], e.chh)
+	if err != nil {
+		return nil, fmt.Errorf("could not sign challenge body: %w", err)
+	}
+
+	return &flow.Challenge{
+		Body:              body,
+		VerifierSignature: bodySign,
+	}, nil
+}
+
 // GenerateResultApproval generates result approval for specific chunk of an execution receipt.
 func (e *Engine) GenerateResultApproval(chunkIndex uint64,
 	execResultID flow.Identifier,
@@ -323,7 +595,9 @@ func (e *Engine) verifiableChunkHandler(originID flow.Identifier, ch *verificati
 	log.Info().Msg("verifiable chunk received")
 
 	// starts verification of chunk
+	started := time.Now()
 	err := e.verify(ctx, originID, ch)
+	e.metrics.OnChunkVerified(time.Since(started))
 
 	if err != nil {
 		log.Info().Err(err).Msg("could not verify chunk")
@@ -4,7 +4,9 @@ import (
 	"crypto/rand"
 	"errors"
 	"fmt"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/rs/zerolog"
 	"github.com/stretchr/testify/assert"
@@ -22,11 +24,13 @@ import (
 	"github.com/onflow/flow-go/engine/verification/verifier"
 	chmodel "github.com/onflow/flow-go/model/chunks"
 	"github.com/onflow/flow-go/model/flow"
+	vermodel "github.com/onflow/flow-go/model/verification"
 	realModule "github.com/onflow/flow-go/module"
 	mockmodule "github.com/onflow/flow-go/module/mock"
 	"github.com/onflow/flow-go/module/trace"
 	"github.com/onflow/flow-go/network/mocknetwork"
 	protocol "github.com/onflow/flow-go/state/protocol/mock"
+	"github.com/onflow/flow-go/storage"
 	mockstorage "github.com/onflow/flow-go/storage/mock"
 	"github.com/onflow/flow-go/utils/unittest"
 )
@@ -43,8 +47,12 @@ type VerifierEngineTestSuite struct {
 	chain     flow.Chain
 	pushCon   *mocknetwork.Conduit // mocks con for submitting result approvals
 	pullCon   *mocknetwork.Conduit
+	chalCon   *mocknetwork.Conduit            // mocks con for submitting challenges
 	metrics   *mockmodule.VerificationMetrics // mocks performance monitoring metrics
+	receipts  *mockstorage.ExecutionReceipts
 	approvals *mockstorage.ResultApprovals
+	statuses  *mockstorage.ChunkStatuses
+	verdicts  *mockstorage.ChunkVerdicts
 }
 
 func TestVerifierEngine(t *testing.T) {
@@ -59,12 +67,19 @@ func (suite *VerifierEngineTestSuite) SetupTest() {
 	suite.ss = &protocol.Snapshot{}
 	suite.pushCon = &mocknetwork.Conduit{}
 	suite.pullCon = &mocknetwork.Conduit{}
+	suite.chalCon = &mocknetwork.Conduit{}
 	suite.metrics = &mockmodule.VerificationMetrics{}
 	suite.chain = flow.Testnet.Chain()
+	suite.receipts = &mockstorage.ExecutionReceipts{}
 	suite.approvals = &mockstorage.ResultApprovals{}
+	suite.statuses = &mockstorage.ChunkStatuses{}
+	suite.verdicts = &mockstorage.ChunkVerdicts{}
 
 	suite.approvals.On("Store", mock.Anything).Return(nil)
 	suite.approvals.On("Index", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	suite.approvals.On("ByChunk", mock.Anything, mock.Anything).Return(nil, storage.ErrNotFound)
+	suite.statuses.On("ByChunkID", mock.Anything).Return(nil, storage.ErrNotFound)
+	suite.verdicts.On("Store", mock.Anything).Return(nil)
 
 	suite.net.On("Register", engine.PushApprovals, testifymock.Anything).
 		Return(suite.pushCon, nil).
@@ -74,6 +89,10 @@ func (suite *VerifierEngineTestSuite) SetupTest() {
 		Return(suite.pullCon, nil).
 		Once()
 
+	suite.net.On("Register", engine.PushChallenges, testifymock.Anything).
+		Return(suite.chalCon, nil).
+		Once()
+
 	suite.state.On("Final").Return(suite.ss)
 
 	// Mocks the signature oracle of the engine
@@ -99,6 +118,11 @@ func (suite *VerifierEngineTestSuite) SetupTest() {
 }
 
 func (suite *VerifierEngineTestSuite) TestNewEngine() *verifier.Engine {
+	return suite.newEngine(ChunkVerifierMock{}, 0)
+}
+
+// newEngine creates a verifier engine with a single worker, using the given chunk verifier and chunk timeout.
+func (suite *VerifierEngineTestSuite) newEngine(chunkVerifier realModule.ChunkVerifier, timeout time.Duration) *verifier.Engine {
 	e, err := verifier.New(
 		zerolog.Logger{},
 		suite.metrics,
@@ -106,13 +130,27 @@ func (suite *VerifierEngineTestSuite) TestNewEngine() *verifier.Engine {
 		suite.net,
 		suite.state,
 		suite.me,
-		ChunkVerifierMock{},
-		suite.approvals)
+		chunkVerifier,
+		suite.receipts,
+		suite.approvals,
+		suite.statuses,
+		suite.verdicts,
+		1,
+		timeout)
 	require.Nil(suite.T(), err)
 
 	suite.net.AssertExpectations(suite.T())
 	return e
+}
+
+// startEngine starts the verification workers of the engine.
+func (suite *VerifierEngineTestSuite) startEngine(eng *verifier.Engine) {
+	unittest.RequireCloseBefore(suite.T(), eng.Ready(), time.Second, "could not start verifier engine")
+}
 
+// stopEngine stops the verification workers of the engine.
+func (suite *VerifierEngineTestSuite) stopEngine(eng *verifier.Engine) {
+	unittest.RequireCloseBefore(suite.T(), eng.Done(), time.Second, "could not stop verifier engine")
 }
 
 func (suite *VerifierEngineTestSuite) TestInvalidSender() {
@@ -141,6 +179,8 @@ func (suite *VerifierEngineTestSuite) TestIncorrectResult() {
 func (suite *VerifierEngineTestSuite) TestVerifyHappyPath() {
 
 	eng := suite.TestNewEngine()
+	suite.startEngine(eng)
+	defer suite.stopEngine(eng)
 	myID := unittest.IdentifierFixture()
 	consensusNodes := unittest.IdentityListFixture(1, unittest.WithRole(flow.RoleConsensus))
 	// creates a verifiable chunk
@@ -155,11 +195,17 @@ func (suite *VerifierEngineTestSuite) TestVerifyHappyPath() {
 	suite.metrics.On("OnVerifiableChunkReceived").Return()
 	// emission of result approval
 	suite.metrics.On("OnResultApproval").Return()
+	// queueing and verification of the chunk
+	suite.metrics.On("OnVerifierQueueDepth", testifymock.Anything).Return()
+	suite.metrics.On("OnChunkVerified", testifymock.Anything).Return()
 
+	published := make(chan struct{})
 	suite.pushCon.
 		On("Publish", testifymock.Anything, testifymock.Anything).
 		Return(nil).
 		Run(func(args testifymock.Arguments) {
+			defer close(published)
+
 			// check that the approval matches the input execution result
 			ra, ok := args[0].(*flow.ResultApproval)
 			suite.Assert().True(ok)
@@ -178,13 +224,24 @@ func (suite *VerifierEngineTestSuite) TestVerifyHappyPath() {
 
 	err := eng.Process(myID, vChunk)
 	suite.Assert().NoError(err)
+
+	// the chunk is verified asynchronously by the worker
+	unittest.RequireCloseBefore(suite.T(), published, time.Second, "result approval was not published")
 	suite.ss.AssertExpectations(suite.T())
 	suite.pushCon.AssertExpectations(suite.T())
 
 }
 
 func (suite *VerifierEngineTestSuite) TestVerifyUnhappyPaths() {
+	verdicts := make(chan *vermodel.ChunkVerdict, 3)
+	suite.verdicts = &mockstorage.ChunkVerdicts{}
+	suite.verdicts.On("Store", testifymock.Anything).Return(nil).Run(func(args testifymock.Arguments) {
+		verdicts <- args[0].(*vermodel.ChunkVerdict)
+	})
+
 	eng := suite.TestNewEngine()
+	suite.startEngine(eng)
+	defer suite.stopEngine(eng)
 	myID := unittest.IdentifierFixture()
 	consensusNodes := unittest.IdentityListFixture(1, unittest.WithRole(flow.RoleConsensus))
 
@@ -195,15 +252,26 @@ func (suite *VerifierEngineTestSuite) TestVerifyUnhappyPaths() {
 	// mocks metrics
 	// reception of verifiable chunk
 	suite.metrics.On("OnVerifiableChunkReceived").Return()
-
-	// we shouldn't receive any result approval
-	suite.pushCon.
-		On("Publish", testifymock.Anything, testifymock.Anything).
+	suite.metrics.On("OnVerifierQueueDepth", testifymock.Anything).Return()
+
+	// waits for all chunks to be verified
+	var verified sync.WaitGroup
+	verified.Add(3)
+	suite.metrics.On("OnChunkVerified", testifymock.Anything).Return().Run(func(testifymock.Arguments) {
+		verified.Done()
+	})
+
+	// we shouldn't receive any result approval, but a challenge for each faulty chunk
+	suite.metrics.On("OnChallenge").Return()
+	challenges := make(chan *flow.Challenge, 3)
+	suite.chalCon.
+		On("Publish", testifymock.Anything, consensusNodes[0].NodeID).
 		Return(nil).
 		Run(func(args testifymock.Arguments) {
-			// TODO change this to check challeneges
-			_, ok := args[0].(*flow.ResultApproval)
-			suite.Assert().False(ok)
+			challenge, ok := args[0].(*flow.Challenge)
+			suite.Require().True(ok)
+			suite.Assert().Equal(myID, challenge.Body.ChallengerID)
+			challenges <- challenge
 		})
 
 	var tests = []struct {
@@ -214,15 +282,298 @@ func (suite *VerifierEngineTestSuite) TestVerifyUnhappyPaths() {
 		{unittest.VerifiableChunkDataFixture(uint64(2)), nil},
 		{unittest.VerifiableChunkDataFixture(uint64(3)), nil},
 	}
+	receipts := make(map[flow.Identifier]*flow.ExecutionReceipt)
+	for _, test := range tests {
+		receipt := unittest.ExecutionReceiptFixture(unittest.WithResult(test.vc.Result))
+		receipts[test.vc.Result.ID()] = receipt
+		suite.receipts.On("ByBlockID", test.vc.Result.BlockID).Return(flow.ExecutionReceiptList{receipt}, nil)
+	}
 	for _, test := range tests {
 		err := eng.Process(myID, test.vc)
 		suite.Assert().NoError(err)
 	}
+
+	unittest.RequireReturnsBefore(suite.T(), verified.Wait, time.Second, "chunks were not verified")
+	close(challenges)
+
+	raised := make(map[flow.Identifier]*flow.Challenge)
+	for challenge := range challenges {
+		raised[challenge.Body.ExecutionResultID] = challenge
+	}
+	suite.Require().Len(raised, 3)
+	suite.pushCon.AssertNotCalled(suite.T(), "Publish", testifymock.Anything, testifymock.Anything)
+	suite.metrics.AssertNumberOfCalls(suite.T(), "OnChallenge", 3)
+
+	// the fault proofs hold the evidence of the faults, and the verdicts on the chunks refer to their challenges
+	kinds := map[uint64]flow.ChunkFaultKind{
+		1: flow.ChunkFaultMissingRegisterTouch,
+		2: flow.ChunkFaultInvalidChunkDataPack,
+		3: flow.ChunkFaultNonMatchingFinalState,
+	}
+	for _, test := range tests {
+		challenge, ok := raised[test.vc.Result.ID()]
+		suite.Require().True(ok)
+		suite.Assert().Equal(kinds[test.vc.Chunk.Index], challenge.Body.Kind)
+		suite.Assert().Equal(*receipts[test.vc.Result.ID()].Meta(), challenge.Body.Receipt)
+		suite.Assert().Equal(*test.vc.Collection, challenge.Body.Collection)
+		suite.Assert().Equal(*test.vc.ChunkDataPack, challenge.Body.ChunkDataPack)
+		switch challenge.Body.Kind {
+		case flow.ChunkFaultMissingRegisterTouch:
+			suite.Assert().Len(challenge.Body.MissingRegisters, 1)
+		case flow.ChunkFaultNonMatchingFinalState:
+			suite.Assert().Len(challenge.Body.Trace, 1)
+			suite.Assert().NotEmpty(challenge.Body.ComputedEndState)
+		}
+	}
+
+	close(verdicts)
+	stored := make(map[flow.Identifier]*vermodel.ChunkVerdict)
+	for verdict := range verdicts {
+		suite.Assert().NotEmpty(verdict.Fault)
+		stored[verdict.ExecutionResultID] = verdict
+	}
+	suite.Require().Len(stored, 3)
+	for resultID, verdict := range stored {
+		suite.Assert().Equal(vermodel.VerdictChallenged, verdict.Verdict)
+		suite.Assert().Equal(raised[resultID].ID(), verdict.ChallengeID)
+	}
+}
+
+// TestVerifyFaultWithoutReceipt tests that a faulty chunk is not challenged when no receipt committing to its result is
+// known, as the fault can not be attributed to an execution node, and that it is neither approved.
+func (suite *VerifierEngineTestSuite) TestVerifyFaultWithoutReceipt() {
+	suite.verdicts = &mockstorage.ChunkVerdicts{}
+	suite.verdicts.On("Store", testifymock.Anything).Return(nil).Run(func(args testifymock.Arguments) {
+		verdict := args[0].(*vermodel.ChunkVerdict)
+		suite.Assert().Equal(vermodel.VerdictUnchallenged, verdict.Verdict)
+		suite.Assert().Equal(flow.ZeroID, verdict.ChallengeID)
+	}).Once()
+
+	eng := suite.TestNewEngine()
+	suite.startEngine(eng)
+	defer suite.stopEngine(eng)
+	myID := unittest.IdentifierFixture()
+	suite.me.MockNodeID(myID)
+
+	suite.metrics.On("OnVerifiableChunkReceived").Return()
+	suite.metrics.On("OnVerifierQueueDepth", testifymock.Anything).Return()
+	verified := make(chan struct{})
+	suite.metrics.On("OnChunkVerified", testifymock.Anything).Return().Run(func(testifymock.Arguments) {
+		close(verified)
+	}).Once()
+
+	// chunk with a non-matching final state
+	vChunk := unittest.VerifiableChunkDataFixture(uint64(3))
+	suite.receipts.On("ByBlockID", vChunk.Result.BlockID).Return(flow.ExecutionReceiptList{}, nil)
+
+	err := eng.Process(myID, vChunk)
+	suite.Assert().NoError(err)
+
+	unittest.RequireCloseBefore(suite.T(), verified, time.Second, "chunk was not verified")
+	suite.chalCon.AssertNotCalled(suite.T(), "Publish", testifymock.Anything, testifymock.Anything)
+	suite.pushCon.AssertNotCalled(suite.T(), "Publish", testifymock.Anything, testifymock.Anything)
+	suite.verdicts.AssertExpectations(suite.T())
+}
+
+// TestVerifyApprovedChunk tests that a chunk approved already, e.g., passed again to the verifier after a restart, is
+// not approved again, and that its verdict is persisted and it is marked as verified.
+func (suite *VerifierEngineTestSuite) TestVerifyApprovedChunk() {
+	vChunk := unittest.VerifiableChunkDataFixture(uint64(0))
+	approval := unittest.ResultApprovalFixture()
+	status := vermodel.NewChunkStatus(vChunk.Chunk, vChunk.Result.ID(), vChunk.Header.Height, nil, nil)
+
+	suite.approvals = &mockstorage.ResultApprovals{}
+	suite.approvals.On("ByChunk", vChunk.Result.ID(), vChunk.Chunk.Index).Return(approval, nil)
+	suite.statuses = &mockstorage.ChunkStatuses{}
+	suite.statuses.On("ByChunkID", vChunk.Chunk.ID()).Return(status, nil)
+	suite.verdicts = &mockstorage.ChunkVerdicts{}
+	suite.verdicts.On("Store", &vermodel.ChunkVerdict{
+		ExecutionResultID: vChunk.Result.ID(),
+		ChunkIndex:        vChunk.Chunk.Index,
+		Verdict:           vermodel.VerdictApproved,
+		ApprovalID:        approval.ID(),
+	}).Return(nil).Once()
+
+	marked := make(chan struct{})
+	suite.statuses.On("Store", status).Return(nil).Run(func(args testifymock.Arguments) {
+		suite.Assert().True(args[0].(*vermodel.ChunkStatus).Verified)
+		close(marked)
+	}).Once()
+
+	eng := suite.TestNewEngine()
+	suite.startEngine(eng)
+	defer suite.stopEngine(eng)
+	myID := unittest.IdentifierFixture()
+	suite.me.MockNodeID(myID)
+
+	suite.metrics.On("OnVerifiableChunkReceived").Return()
+	suite.metrics.On("OnVerifierQueueDepth", testifymock.Anything).Return()
+	suite.metrics.On("OnChunkVerified", testifymock.Anything).Return()
+
+	err := eng.Process(myID, vChunk)
+	suite.Assert().NoError(err)
+
+	unittest.RequireCloseBefore(suite.T(), marked, time.Second, "chunk was not marked as verified")
+	suite.verdicts.AssertExpectations(suite.T())
+	suite.approvals.AssertNotCalled(suite.T(), "Store", testifymock.Anything)
+	suite.pushCon.AssertNotCalled(suite.T(), "Publish", testifymock.Anything, testifymock.Anything)
+}
+
+// TestVerifyTimeout tests that a chunk whose verification times out does not hold up the chunks queued behind it,
+// and that its result approval is still emitted once its verification is done.
+func (suite *VerifierEngineTestSuite) TestVerifyTimeout() {
+	release := make(chan struct{})
+	eng := suite.newEngine(&blockingChunkVerifier{release: release}, 10*time.Millisecond)
+	suite.startEngine(eng)
+	defer suite.stopEngine(eng)
+	myID := unittest.IdentifierFixture()
+	consensusNodes := unittest.IdentityListFixture(1, unittest.WithRole(flow.RoleConsensus))
+
+	suite.me.MockNodeID(myID)
+	suite.ss.On("Identities", testifymock.Anything).Return(consensusNodes, nil)
+
+	suite.metrics.On("OnVerifiableChunkReceived").Return()
+	suite.metrics.On("OnResultApproval").Return()
+	suite.metrics.On("OnVerifierQueueDepth", testifymock.Anything).Return()
+	suite.metrics.On("OnChunkVerified", testifymock.Anything).Return()
+
+	timedOut := make(chan struct{})
+	suite.metrics.On("OnChunkVerificationTimeout").Return().Run(func(testifymock.Arguments) {
+		close(timedOut)
+	}).Once()
+
+	// the blocked chunk is of the lower height, so it is verified first
+	blocked := unittest.VerifiableChunkDataFixture(uint64(0))
+	blocked.Header.Height = 1
+	next := unittest.VerifiableChunkDataFixture(uint64(1))
+	next.Header.Height = 2
+
+	approved := make(chan flow.Identifier, 2)
+	suite.pushCon.
+		On("Publish", testifymock.Anything, testifymock.Anything).
+		Return(nil).
+		Run(func(args testifymock.Arguments) {
+			ra, ok := args[0].(*flow.ResultApproval)
+			suite.Assert().True(ok)
+			approved <- ra.Body.ExecutionResultID
+		})
+
+	suite.Assert().NoError(eng.Process(myID, blocked))
+	suite.Assert().NoError(eng.Process(myID, next))
+
+	// the next chunk is verified while the blocked chunk is still being verified
+	unittest.RequireCloseBefore(suite.T(), timedOut, time.Second, "chunk verification did not time out")
+	select {
+	case resultID := <-approved:
+		suite.Assert().Equal(next.Result.ID(), resultID)
+	case <-time.After(time.Second):
+		suite.T().Fatal("next chunk was not verified")
+	}
+
+	// the blocked chunk is still approved once verified
+	close(release)
+	select {
+	case resultID := <-approved:
+		suite.Assert().Equal(blocked.Result.ID(), resultID)
+	case <-time.After(time.Second):
+		suite.T().Fatal("timed out chunk was not approved")
+	}
+}
+
+// TestVerifyTimeoutBounded tests that once as many timed out verifications as workers are still running, a worker
+// waits for its timed out verification before verifying the next chunk.
+func (suite *VerifierEngineTestSuite) TestVerifyTimeoutBounded() {
+	release := make(chan struct{})
+	eng := suite.newEngine(&blockingChunkVerifier{release: release}, 10*time.Millisecond)
+	suite.startEngine(eng)
+	defer suite.stopEngine(eng)
+	myID := unittest.IdentifierFixture()
+	consensusNodes := unittest.IdentityListFixture(1, unittest.WithRole(flow.RoleConsensus))
+
+	suite.me.MockNodeID(myID)
+	suite.ss.On("Identities", testifymock.Anything).Return(consensusNodes, nil)
+
+	suite.metrics.On("OnVerifiableChunkReceived").Return()
+	suite.metrics.On("OnResultApproval").Return()
+	suite.metrics.On("OnVerifierQueueDepth", testifymock.Anything).Return()
+	suite.metrics.On("OnChunkVerified", testifymock.Anything).Return()
+
+	timedOut := make(chan struct{}, 2)
+	suite.metrics.On("OnChunkVerificationTimeout").Return().Run(func(testifymock.Arguments) {
+		timedOut <- struct{}{}
+	}).Twice()
+
+	// both blocked chunks time out, the single worker of the engine is only released from the first one
+	blocked1 := unittest.VerifiableChunkDataFixture(uint64(0))
+	blocked1.Header.Height = 1
+	blocked2 := unittest.VerifiableChunkDataFixture(uint64(0))
+	blocked2.Header.Height = 2
+	next := unittest.VerifiableChunkDataFixture(uint64(1))
+	next.Header.Height = 3
+
+	approved := make(chan flow.Identifier, 3)
+	suite.pushCon.
+		On("Publish", testifymock.Anything, testifymock.Anything).
+		Return(nil).
+		Run(func(args testifymock.Arguments) {
+			ra, ok := args[0].(*flow.ResultApproval)
+			suite.Assert().True(ok)
+			approved <- ra.Body.ExecutionResultID
+		})
+
+	suite.Assert().NoError(eng.Process(myID, blocked1))
+	suite.Assert().NoError(eng.Process(myID, blocked2))
+	suite.Assert().NoError(eng.Process(myID, next))
+
+	for i := 0; i < 2; i++ {
+		select {
+		case <-timedOut:
+		case <-time.After(time.Second):
+			suite.T().Fatal("chunk verification did not time out")
+		}
+	}
+
+	// the worker waits for the second timed out verification, so the next chunk is not verified
+	select {
+	case resultID := <-approved:
+		suite.T().Fatalf("unexpected approval of result %v while the worker waits", resultID)
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	// all chunks are approved once the blocked chunks are verified
+	close(release)
+	var results []flow.Identifier
+	for i := 0; i < 3; i++ {
+		select {
+		case resultID := <-approved:
+			results = append(results, resultID)
+		case <-time.After(time.Second):
+			suite.T().Fatal("chunk was not approved")
+		}
+	}
+	suite.Assert().ElementsMatch([]flow.Identifier{blocked1.Result.ID(), blocked2.Result.ID(), next.Result.ID()}, results)
 }
 
 type ChunkVerifierMock struct {
 }
 
+// blockingChunkVerifier verifies all chunks successfully, but blocks the verification of the chunks with index 0
+// until released.
+type blockingChunkVerifier struct {
+	release chan struct{}
+}
+
+func (v *blockingChunkVerifier) Verify(vc *verification.VerifiableChunkData) ([]byte, chmodel.ChunkFault, error) {
+	if vc.Chunk.Index == 0 {
+		<-v.release
+	}
+	return []byte{}, nil, nil
+}
+
+func (v *blockingChunkVerifier) SystemChunkVerify(vc *verification.VerifiableChunkData) ([]byte, chmodel.ChunkFault, error) {
+	return v.Verify(vc)
+}
+
 func (v ChunkVerifierMock) Verify(vc *verification.VerifiableChunkData) ([]byte, chmodel.ChunkFault, error) {
 	if vc.IsSystemChunk {
 		return nil, nil, fmt.Errorf("wrong method invoked for verifying system chunk")
@@ -234,7 +585,7 @@ func (v ChunkVerifierMock) Verify(vc *verification.VerifiableChunkData) ([]byte,
 	// return error
 	case 1:
 		return nil, chmodel.NewCFMissingRegisterTouch(
-			[]string{"test missing register touch"},
+			[]flow.RegisterID{flow.NewRegisterID("owner", "controller", "key")},
 			vc.Chunk.Index,
 			vc.Result.ID()), nil
 
@@ -249,10 +600,13 @@ func (v ChunkVerifierMock) Verify(vc *verification.VerifiableChunkData) ([]byte,
 		return nil, chmodel.NewCFNonMatchingFinalState(
 			unittest.StateCommitmentFixture(),
 			unittest.StateCommitmentFixture(),
+			[]flow.ChunkTraceStep{{TransactionIndex: 0, Updates: []flow.RegisterEntry{{
+				Key:   flow.NewRegisterID("owner", "controller", "key"),
+				Value: []byte{'F'},
+			}}}},
 			vc.Chunk.Index,
 			vc.Result.ID()), nil
 
-	// TODO add cases for challenges
 	// return successful by default
 	default:
 		return nil, nil, nil
@@ -0,0 +1,50 @@
+package verifier
+
+import (
+	"fmt"
+
+	"github.com/onflow/flow-go/engine/verification"
+	chmodels "github.com/onflow/flow-go/model/chunks"
+	"github.com/onflow/flow-go/model/flow"
+)
+
+// faultProof builds the fault proof of the verifiable chunk, from the fault found while verifying it and the receipt
+// committing to its result, if any.
+func faultProof(vc *verification.VerifiableChunkData, chFault chmodels.ChunkFault, receipt *flow.ExecutionReceiptMeta) (*flow.FaultProof, error) {
+	proof := &flow.FaultProof{
+		BlockID:           vc.Header.ID(),
+		ExecutionResultID: vc.Result.ID(),
+		ChunkIndex:        vc.Chunk.Index,
+	}
+	if receipt != nil {
+		proof.Receipt = *receipt
+	}
+	if vc.Collection != nil {
+		proof.Collection = *vc.Collection
+	}
+	if vc.ChunkDataPack != nil {
+		proof.ChunkDataPack = *vc.ChunkDataPack
+	}
+	if vc.ExecutionData != nil {
+		proof.ExecutionData = *vc.ExecutionData
+	}
+
+	switch fault := chFault.(type) {
+	case *chmodels.CFInvalidVerifiableChunk:
+		proof.Kind = flow.ChunkFaultInvalidChunkDataPack
+	case *chmodels.CFMissingRegisterTouch:
+		proof.Kind = flow.ChunkFaultMissingRegisterTouch
+		proof.MissingRegisters = fault.Registers()
+	case *chmodels.CFNonMatchingFinalState:
+		proof.Kind = flow.ChunkFaultNonMatchingFinalState
+		proof.Trace = fault.Trace()
+		proof.ComputedEndState = fault.Expected()
+	case *chmodels.CFNonMatchingExecutionData:
+		proof.Kind = flow.ChunkFaultNonMatchingExecutionData
+		proof.ComputedChunkExecutionDataID = fault.Computed()
+	default:
+		return nil, fmt.Errorf("unknown type of chunk fault (type: %T): %v", chFault, chFault.String())
+	}
+
+	return proof, nil
+}
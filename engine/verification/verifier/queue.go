@@ -0,0 +1,120 @@
+package verifier
+
+import (
+	"container/heap"
+	"sync"
+	"time"
+
+	"github.com/onflow/flow-go/engine/verification"
+	"github.com/onflow/flow-go/model/flow"
+)
+
+// queuedChunk is a verifiable chunk waiting to be verified.
+type queuedChunk struct {
+	originID flow.Identifier
+	vc       *verification.VerifiableChunkData
+	seq      uint64    // the order the chunk was queued in
+	queuedAt time.Time // the time the chunk was queued at
+}
+
+// chunkHeap orders the queued chunks by the height of their blocks, and by the order they were queued in for the
+// same height.
+type chunkHeap []*queuedChunk
+
+func (h chunkHeap) Len() int { return len(h) }
+
+func (h chunkHeap) Less(i, j int) bool {
+	if h[i].vc.Header.Height != h[j].vc.Header.Height {
+		return h[i].vc.Header.Height < h[j].vc.Header.Height
+	}
+	return h[i].seq < h[j].seq
+}
+
+func (h chunkHeap) Swap(i, j int) { h[i], h[j] = h[j], h[i] }
+
+func (h *chunkHeap) Push(x interface{}) { *h = append(*h, x.(*queuedChunk)) }
+
+func (h *chunkHeap) Pop() interface{} {
+	old := *h
+	n := len(old)
+	item := old[n-1]
+	old[n-1] = nil
+	*h = old[:n-1]
+	return item
+}
+
+// chunkQueue is a bounded queue of the verifiable chunks waiting to be verified. The chunks of the lowest block
+// height are verified first, as their blocks are the closest to emergency sealing. Pushing to a full queue blocks
+// until a chunk is popped, and popping from an empty queue blocks until a chunk is pushed, or the queue is closed.
+type chunkQueue struct {
+	mu       sync.Mutex
+	notEmpty *sync.Cond
+	notFull  *sync.Cond
+	capacity int
+	chunks   chunkHeap
+	seq      uint64
+	closed   bool
+}
+
+func newChunkQueue(capacity int) *chunkQueue {
+	q := &chunkQueue{
+		capacity: capacity,
+	}
+	q.notEmpty = sync.NewCond(&q.mu)
+	q.notFull = sync.NewCond(&q.mu)
+	return q
+}
+
+// push queues the verifiable chunk, and returns the number of queued chunks. It returns false if the queue was
+// closed.
+func (q *chunkQueue) push(originID flow.Identifier, vc *verification.VerifiableChunkData) (int, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	for len(q.chunks) >= q.capacity && !q.closed {
+		q.notFull.Wait()
+	}
+	if q.closed {
+		return 0, false
+	}
+
+	heap.Push(&q.chunks, &queuedChunk{
+		originID: originID,
+		vc:       vc,
+		seq:      q.seq,
+		queuedAt: time.Now(),
+	})
+	q.seq++
+	q.notEmpty.Signal()
+
+	return len(q.chunks), true
+}
+
+// pop returns the queued chunk of the lowest block height, and the number of chunks left. It returns false if the
+// queue was closed.
+func (q *chunkQueue) pop() (*queuedChunk, int, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	for len(q.chunks) == 0 && !q.closed {
+		q.notEmpty.Wait()
+	}
+	if q.closed {
+		return nil, 0, false
+	}
+
+	chunk := heap.Pop(&q.chunks).(*queuedChunk)
+	q.notFull.Signal()
+
+	return chunk, len(q.chunks), true
+}
+
+// close closes the queue, the chunks left are dropped.
+func (q *chunkQueue) close() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	q.closed = true
+	q.notEmpty.Broadcast()
+	q.notFull.Broadcast()
+}
@@ -0,0 +1,80 @@
+package verifier
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/engine/verification"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+func chunkAtHeight(height uint64) *verification.VerifiableChunkData {
+	vc := unittest.VerifiableChunkDataFixture(0)
+	vc.Header.Height = height
+	return vc
+}
+
+// TestChunkQueue_Order tests that the chunks of the lowest height are popped first, in the order they were pushed.
+func TestChunkQueue_Order(t *testing.T) {
+	q := newChunkQueue(10)
+	originID := unittest.IdentifierFixture()
+
+	high := chunkAtHeight(3)
+	low1 := chunkAtHeight(1)
+	mid := chunkAtHeight(2)
+	low2 := chunkAtHeight(1)
+
+	for i, vc := range []*verification.VerifiableChunkData{high, low1, mid, low2} {
+		depth, ok := q.push(originID, vc)
+		require.True(t, ok)
+		assert.Equal(t, i+1, depth)
+	}
+
+	for i, expected := range []*verification.VerifiableChunkData{low1, low2, mid, high} {
+		chunk, depth, ok := q.pop()
+		require.True(t, ok)
+		assert.Same(t, expected, chunk.vc)
+		assert.Equal(t, originID, chunk.originID)
+		assert.Equal(t, 3-i, depth)
+	}
+}
+
+// TestChunkQueue_Full tests that pushing to a full queue blocks until a chunk is popped.
+func TestChunkQueue_Full(t *testing.T) {
+	q := newChunkQueue(1)
+	originID := unittest.IdentifierFixture()
+
+	_, ok := q.push(originID, chunkAtHeight(1))
+	require.True(t, ok)
+
+	pushed := unittest.RequireNeverReturnBefore(t, func() {
+		_, ok := q.push(originID, chunkAtHeight(2))
+		assert.True(t, ok)
+	}, 50*time.Millisecond, "push to a full queue should block")
+
+	chunk, _, ok := q.pop()
+	require.True(t, ok)
+	assert.Equal(t, uint64(1), chunk.vc.Header.Height)
+
+	unittest.RequireCloseBefore(t, pushed, time.Second, "push should return once a chunk is popped")
+}
+
+// TestChunkQueue_Close tests that closing the queue releases the blocked pops and pushes.
+func TestChunkQueue_Close(t *testing.T) {
+	q := newChunkQueue(1)
+	originID := unittest.IdentifierFixture()
+
+	popped := unittest.RequireNeverReturnBefore(t, func() {
+		_, _, ok := q.pop()
+		assert.False(t, ok)
+	}, 50*time.Millisecond, "pop from an empty queue should block")
+
+	q.close()
+	unittest.RequireCloseBefore(t, popped, time.Second, "pop should return once the queue is closed")
+
+	_, ok := q.push(originID, chunkAtHeight(1))
+	assert.False(t, ok)
+}
@@ -1,6 +1,8 @@
 package event
 
 import (
+	"fmt"
+
 	"github.com/onflow/cadence"
 	"github.com/onflow/cadence/runtime/common"
 
@@ -24,6 +26,16 @@ func GetServiceEventWhitelist() []string {
 	return serviceEventWhitelistFlat
 }
 
+// ServiceEventTypes returns the fully qualified types of the service events of the given chain, i.e., the whitelisted
+// events of the contracts deployed to its service account.
+func ServiceEventTypes(chain flow.Chain) []flow.EventType {
+	types := make([]flow.EventType, 0, len(serviceEventWhitelistFlat))
+	for _, identifier := range serviceEventWhitelistFlat {
+		types = append(types, flow.EventType(fmt.Sprintf("A.%s.%s", chain.ServiceAddress().Hex(), identifier)))
+	}
+	return types
+}
+
 func IsServiceEvent(event cadence.Event, chain flow.Chain) bool {
 	serviceAccount := chain.ServiceAddress()
 
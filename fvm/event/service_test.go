@@ -53,3 +53,12 @@ func Test_IsServiceEvent(t *testing.T) {
 	})
 
 }
+
+func Test_ServiceEventTypes(t *testing.T) {
+
+	chain := flow.Mainnet.Chain()
+
+	types := ServiceEventTypes(chain)
+	assert.Len(t, types, len(GetServiceEventWhitelist()))
+	assert.Contains(t, types, flow.EventType("A."+chain.ServiceAddress().Hex()+".EpochManager.EpochSetup"))
+}
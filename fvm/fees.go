@@ -24,3 +24,17 @@ func deductTransactionFeeTransaction(accountAddress, serviceAddress flow.Address
 		0,
 	)
 }
+
+const transactionFeeScriptTemplate = `
+import FlowServiceAccount from 0x%s
+
+pub fun main(): UFix64 {
+  return FlowServiceAccount.transactionFee
+}
+`
+
+// TransactionFeeScript returns a script reading the fee that the service account deducts from the payer
+// of each transaction, in the smallest unit of FLOW.
+func TransactionFeeScript(serviceAddress flow.Address) *ScriptProcedure {
+	return Script([]byte(fmt.Sprintf(transactionFeeScriptTemplate, serviceAddress)))
+}
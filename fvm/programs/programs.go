@@ -1,6 +1,7 @@
 package programs
 
 import (
+	"sort"
 	"sync"
 
 	"github.com/onflow/cadence/runtime/common"
@@ -42,6 +43,9 @@ type Programs struct {
 	programs   map[common.LocationID]ProgramEntry
 	parentFunc ProgramGetFunc
 	cleaned    bool
+
+	loadedLock sync.Mutex
+	loaded     map[common.LocationID]struct{} // the locations of the programs loaded since TrackLoaded, nil if not tracking
 }
 
 func NewEmptyPrograms() *Programs {
@@ -70,6 +74,7 @@ func (p *Programs) Get(location common.Location) (*interpreter.Program, *state.S
 	programEntry, has := p.get(location)
 
 	if has {
+		p.recordLoaded(location)
 		return programEntry.Program, programEntry.State, true
 	}
 
@@ -98,6 +103,41 @@ func (p *Programs) Set(location common.Location, program *interpreter.Program, s
 		Program:  program,
 		State:    state,
 	}
+
+	p.recordLoaded(location)
+}
+
+// TrackLoaded starts tracking the locations of the programs loaded, either from the cache or by setting them after
+// parsing, until Loaded is called. Programs loaded through children are only tracked by the children.
+func (p *Programs) TrackLoaded() {
+	p.loadedLock.Lock()
+	defer p.loadedLock.Unlock()
+
+	p.loaded = make(map[common.LocationID]struct{})
+}
+
+// Loaded returns the sorted locations of the programs loaded since TrackLoaded was called, and stops tracking them.
+func (p *Programs) Loaded() []string {
+	p.loadedLock.Lock()
+	defer p.loadedLock.Unlock()
+
+	locations := make([]string, 0, len(p.loaded))
+	for id := range p.loaded {
+		locations = append(locations, string(id))
+	}
+	sort.Strings(locations)
+
+	p.loaded = nil
+	return locations
+}
+
+func (p *Programs) recordLoaded(location common.Location) {
+	p.loadedLock.Lock()
+	defer p.loadedLock.Unlock()
+
+	if p.loaded != nil {
+		p.loaded[location.ID()] = struct{}{}
+	}
 }
 
 // HasChanges indicates if any changes has been introduced
@@ -106,6 +146,15 @@ func (p *Programs) HasChanges() bool {
 	return len(p.programs) > 0 || p.cleaned
 }
 
+// Cleaned indicates if the programs were cleaned up after a contract update, so that the programs of the parent
+// are outdated as well
+func (p *Programs) Cleaned() bool {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+
+	return p.cleaned
+}
+
 // ForceCleanup is used to force a complete cleanup
 // It exists temporarily to facilitate a temporary measure which can retry
 // a transaction in case checking fails
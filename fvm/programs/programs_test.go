@@ -169,4 +169,34 @@ func Test_Programs(t *testing.T) {
 		require.True(t, child.HasChanges())
 	})
 
+	t.Run("tracking loaded programs", func(t *testing.T) {
+		parentLocation := common.IdentifierLocation("parent")
+
+		parent := NewEmptyPrograms()
+		parent.Set(parentLocation, someProgram, newState)
+
+		// nothing is tracked before tracking starts
+		parent.Set(someLocation, someProgram, newState)
+		child := parent.ChildPrograms()
+		child.TrackLoaded()
+		require.Empty(t, child.Loaded())
+
+		child.TrackLoaded()
+
+		// programs loaded from the parent, set, and missing
+		_, _, has := child.Get(parentLocation)
+		require.True(t, has)
+		child.Set(addressLocation, someProgram, newState)
+		_, _, has = child.Get(common.IdentifierLocation("missing"))
+		require.False(t, has)
+		_, _, has = child.Get(parentLocation)
+		require.True(t, has)
+
+		require.Equal(t, []string{string(addressLocation.ID()), string(parentLocation.ID())}, child.Loaded())
+
+		// tracking stops once the loaded programs are returned
+		_, _, has = child.Get(someLocation)
+		require.True(t, has)
+		require.Empty(t, child.Loaded())
+	})
 }
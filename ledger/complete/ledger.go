@@ -223,6 +223,11 @@ func (l *Ledger) ForestSize() int {
 	return l.forest.Size()
 }
 
+// Trie returns the trie of the given state, if the ledger still holds it.
+func (l *Ledger) Trie(state ledger.State) (*trie.MTrie, error) {
+	return l.forest.GetTrie(ledger.RootHash(state))
+}
+
 // Checkpointer returns a checkpointer instance
 func (l *Ledger) Checkpointer() (*wal.Checkpointer, error) {
 	checkpointer, err := l.wal.NewCheckpointer()
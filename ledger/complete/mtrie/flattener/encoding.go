@@ -1,6 +1,7 @@
 package flattener
 
 import (
+	"bytes"
 	"fmt"
 	"io"
 
@@ -185,3 +186,166 @@ func ReadStorableTrie(reader io.Reader) (*StorableTrie, error) {
 
 	return storableTrie, nil
 }
+
+// EncodeStorableNodes encodes a sequence of StorableNodes whose 0th element is nil, as the nodes of a subtrie
+func EncodeStorableNodes(storableNodes []*StorableNode) []byte {
+	buf := make([]byte, 0, 2+8)
+	// 2-bytes encoding version
+	buf = utils.AppendUint16(buf, encodingDecodingVersion)
+
+	return appendStorableNodes(buf, storableNodes)
+}
+
+// DecodeStorableNodes decodes a sequence of StorableNodes encoded with EncodeStorableNodes
+func DecodeStorableNodes(encoded []byte) ([]*StorableNode, error) {
+	reader := bytes.NewReader(encoded)
+
+	err := readEncodingVersion(reader)
+	if err != nil {
+		return nil, fmt.Errorf("error reading storable nodes: %w", err)
+	}
+
+	storableNodes, err := readStorableNodes(reader)
+	if err != nil {
+		return nil, fmt.Errorf("error reading storable nodes: %w", err)
+	}
+
+	if reader.Len() > 0 {
+		return nil, fmt.Errorf("error reading storable nodes: %d trailing bytes", reader.Len())
+	}
+
+	return storableNodes, nil
+}
+
+// EncodeTrieManifest encodes TrieManifest
+func EncodeTrieManifest(manifest *TrieManifest) []byte {
+	buf := make([]byte, 0, 2+2+len(manifest.RootHash)+8+8*len(manifest.Subtries))
+	// 2-bytes encoding version
+	buf = utils.AppendUint16(buf, encodingDecodingVersion)
+
+	// 2-bytes Big Endian uint16 RootHash length and n-bytes RootHash
+	buf = utils.AppendShortData(buf, manifest.RootHash)
+
+	// 8-bytes Big Endian uint64 number of nodes and the encoded nodes
+	buf = appendStorableNodes(buf, manifest.Nodes)
+
+	// 8-bytes Big Endian uint64 number of subtries and 8-bytes Big Endian uint64 index of each subtrie root
+	buf = utils.AppendUint64(buf, uint64(len(manifest.Subtries)))
+	for _, index := range manifest.Subtries {
+		buf = utils.AppendUint64(buf, index)
+	}
+
+	return buf
+}
+
+// DecodeTrieManifest decodes a TrieManifest encoded with EncodeTrieManifest
+func DecodeTrieManifest(encoded []byte) (*TrieManifest, error) {
+	reader := bytes.NewReader(encoded)
+
+	err := readEncodingVersion(reader)
+	if err != nil {
+		return nil, fmt.Errorf("error reading trie manifest: %w", err)
+	}
+
+	manifest := &TrieManifest{}
+
+	manifest.RootHash, err = utils.ReadShortDataFromReader(reader)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read roothash data: %w", err)
+	}
+
+	manifest.Nodes, err = readStorableNodes(reader)
+	if err != nil {
+		return nil, fmt.Errorf("error reading trie manifest: %w", err)
+	}
+
+	count, err := readUint64(reader)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read number of subtries: %w", err)
+	}
+	if count > uint64(len(manifest.Nodes)) {
+		return nil, fmt.Errorf("trie manifest has %d subtries but only %d nodes", count, len(manifest.Nodes))
+	}
+
+	manifest.Subtries = make([]uint64, 0, count)
+	for i := uint64(0); i < count; i++ {
+		index, err := readUint64(reader)
+		if err != nil {
+			return nil, fmt.Errorf("cannot read index of subtrie %d: %w", i, err)
+		}
+		manifest.Subtries = append(manifest.Subtries, index)
+	}
+
+	if reader.Len() > 0 {
+		return nil, fmt.Errorf("error reading trie manifest: %d trailing bytes", reader.Len())
+	}
+
+	return manifest, nil
+}
+
+// appendStorableNodes appends the number of nodes after the nil 0th element and the encoded nodes
+func appendStorableNodes(buf []byte, storableNodes []*StorableNode) []byte {
+	count := 0
+	if len(storableNodes) > 0 {
+		count = len(storableNodes) - 1
+	}
+
+	// 8-bytes Big Endian uint64 number of nodes
+	buf = utils.AppendUint64(buf, uint64(count))
+
+	for i := 1; i < len(storableNodes); i++ {
+		buf = append(buf, EncodeStorableNode(storableNodes[i])...)
+	}
+
+	return buf
+}
+
+// readStorableNodes reads the nodes appended by appendStorableNodes, and puts nil as 0th element
+func readStorableNodes(reader *bytes.Reader) ([]*StorableNode, error) {
+	count, err := readUint64(reader)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read number of nodes: %w", err)
+	}
+
+	// the count is not trusted, so the slice is not allocated upfront
+	storableNodes := []*StorableNode{nil}
+	for i := uint64(0); i < count; i++ {
+		storableNode, err := ReadStorableNode(reader)
+		if err != nil {
+			return nil, fmt.Errorf("cannot read node %d: %w", i+1, err)
+		}
+		storableNodes = append(storableNodes, storableNode)
+	}
+
+	return storableNodes, nil
+}
+
+func readEncodingVersion(reader io.Reader) error {
+	buf := make([]byte, 2)
+	_, err := io.ReadFull(reader, buf)
+	if err != nil {
+		return fmt.Errorf("cannot read version part: %w", err)
+	}
+
+	version, _, err := utils.ReadUint16(buf)
+	if err != nil {
+		return err
+	}
+
+	if version > encodingDecodingVersion {
+		return fmt.Errorf("unsuported version %d > %d", version, encodingDecodingVersion)
+	}
+
+	return nil
+}
+
+func readUint64(reader io.Reader) (uint64, error) {
+	buf := make([]byte, 8)
+	_, err := io.ReadFull(reader, buf)
+	if err != nil {
+		return 0, err
+	}
+
+	value, _, err := utils.ReadUint64(buf)
+	return value, err
+}
@@ -86,17 +86,7 @@ func toStorableNode(node *node.Node, indexForNode node2indexMap) (*StorableNode,
 		return nil, fmt.Errorf("internal error: missing node with hash %s", hex.EncodeToString(node.RightChild().Hash()))
 	}
 
-	storableNode := &StorableNode{
-		LIndex:     leftIndex,
-		RIndex:     rightIndex,
-		Height:     uint16(node.Height()),
-		Path:       node.Path(),
-		EncPayload: encoding.EncodePayload(node.Payload()),
-		HashValue:  node.Hash(),
-		MaxDepth:   node.MaxDepth(),
-		RegCount:   node.RegCount(),
-	}
-	return storableNode, nil
+	return storableNode(node, leftIndex, rightIndex), nil
 }
 
 func toStorableTrie(mtrie *trie.MTrie, indexForNode node2indexMap) (*StorableTrie, error) {
@@ -0,0 +1,250 @@
+package flattener
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/onflow/flow-go/ledger"
+	"github.com/onflow/flow-go/ledger/common/encoding"
+	"github.com/onflow/flow-go/ledger/complete/mtrie/node"
+	"github.com/onflow/flow-go/ledger/complete/mtrie/trie"
+)
+
+// TrieManifest is the top of a trie down to a given depth. The subtries whose roots are at that depth are left out,
+// only their roots are included with their hashes, so the subtries can be transferred and verified one by one.
+//
+// Like in a FlattenedTrie, the nodes are listed in an order which satisfies Descendents-First-Relationship, index 0
+// is nil, and the root of the trie is the last node. The roots of the left out subtries have no children. Leaves
+// above the depth are included in the manifest, so not every path of the trie leads to a left out subtrie.
+type TrieManifest struct {
+	RootHash []byte
+	Nodes    []*StorableNode
+	Subtries []uint64 // the indices of the roots of the left out subtries, ordered by their paths
+}
+
+// SplitTrie splits the trie at the given depth. It returns the manifest of the trie and the roots of the left out
+// subtries, in the same order as the subtries of the manifest.
+func SplitTrie(t *trie.MTrie, depth int) (*TrieManifest, []*node.Node) {
+	manifest := &TrieManifest{
+		RootHash: t.RootHash(),
+		Nodes:    []*StorableNode{nil}, // 0th element is nil
+	}
+
+	var roots []*node.Node
+	var split func(n *node.Node, depth int) uint64
+	split = func(n *node.Node, depth int) uint64 {
+		if n == nil {
+			return 0
+		}
+
+		// interim nodes at the depth are the roots of left out subtries
+		if depth == 0 && (n.LeftChild() != nil || n.RightChild() != nil) {
+			manifest.Nodes = append(manifest.Nodes, storableNode(n, 0, 0))
+			index := uint64(len(manifest.Nodes) - 1)
+			manifest.Subtries = append(manifest.Subtries, index)
+			roots = append(roots, n)
+			return index
+		}
+
+		left := split(n.LeftChild(), depth-1)
+		right := split(n.RightChild(), depth-1)
+		manifest.Nodes = append(manifest.Nodes, storableNode(n, left, right))
+		return uint64(len(manifest.Nodes) - 1)
+	}
+	split(t.RootNode(), depth)
+
+	return manifest, roots
+}
+
+// FlattenSubtrie returns the nodes of the subtrie with the given root, in an order which satisfies
+// Descendents-First-Relationship. Index 0 is nil and the root is the last node.
+func FlattenSubtrie(root *node.Node) []*StorableNode {
+	nodes := []*StorableNode{nil} // 0th element is nil
+
+	// the nodes of a trie are not shared, so every node is visited once
+	var flatten func(n *node.Node) uint64
+	flatten = func(n *node.Node) uint64 {
+		if n == nil {
+			return 0
+		}
+		left := flatten(n.LeftChild())
+		right := flatten(n.RightChild())
+		nodes = append(nodes, storableNode(n, left, right))
+		return uint64(len(nodes) - 1)
+	}
+	flatten(root)
+
+	return nodes
+}
+
+// Subtrie returns the root of the left out subtrie with the given index.
+func (m *TrieManifest) Subtrie(index int) (*StorableNode, error) {
+	if index < 0 || index >= len(m.Subtries) {
+		return nil, fmt.Errorf("subtrie index %d out of range, manifest has %d subtries", index, len(m.Subtries))
+	}
+	i := m.Subtries[index]
+	if i == 0 || i >= uint64(len(m.Nodes)) || m.Nodes[i] == nil {
+		return nil, fmt.Errorf("invalid node index %d of subtrie %d", i, index)
+	}
+	return m.Nodes[i], nil
+}
+
+// Verify verifies that the nodes of the manifest hash up to its root hash, given the hashes of the roots of the
+// left out subtries.
+func (m *TrieManifest) Verify() error {
+	_, err := m.rebuild(nil)
+	return err
+}
+
+// RebuildTrie rebuilds the trie from the manifest and the roots of all its left out subtries, in the same order as
+// the subtries of the manifest. The hash of each subtrie must match the hash of its root in the manifest, and the
+// nodes of the manifest must hash up to its root hash.
+func (m *TrieManifest) RebuildTrie(subtries []*node.Node) (*trie.MTrie, error) {
+	if len(subtries) != len(m.Subtries) {
+		return nil, fmt.Errorf("got %d subtries for a manifest of %d subtries", len(subtries), len(m.Subtries))
+	}
+	for i, subtrie := range subtries {
+		if subtrie == nil {
+			return nil, fmt.Errorf("subtrie %d is missing", i)
+		}
+	}
+
+	root, err := m.rebuild(subtries)
+	if err != nil {
+		return nil, err
+	}
+
+	mtrie, err := trie.NewMTrie(root)
+	if err != nil {
+		return nil, fmt.Errorf("restoring trie failed: %w", err)
+	}
+	return mtrie, nil
+}
+
+// rebuild rebuilds the root of the trie from the manifest. If no subtries are given, the roots of the left out
+// subtries are taken from the manifest as they are.
+func (m *TrieManifest) rebuild(subtries []*node.Node) (*node.Node, error) {
+	substitutes := make(map[uint64]*node.Node, len(m.Subtries))
+	for i, index := range m.Subtries {
+		root, err := m.Subtrie(i)
+		if err != nil {
+			return nil, err
+		}
+		if root.LIndex != 0 || root.RIndex != 0 {
+			return nil, fmt.Errorf("root of subtrie %d has children", i)
+		}
+		if _, ok := substitutes[index]; ok {
+			return nil, fmt.Errorf("duplicate root of subtrie %d", i)
+		}
+
+		if subtries != nil {
+			substitutes[index] = subtries[i]
+		} else {
+			substitutes[index] = node.NewNode(int(root.Height), nil, nil, nil, nil, root.HashValue, root.MaxDepth, root.RegCount)
+		}
+	}
+
+	nodes, err := rebuildVerifiedNodes(m.Nodes, substitutes)
+	if err != nil {
+		return nil, err
+	}
+
+	root := nodes[len(nodes)-1]
+	if root == nil || !bytes.Equal(root.Hash(), m.RootHash) {
+		return nil, fmt.Errorf("nodes of manifest do not match its root hash %x", m.RootHash)
+	}
+	return root, nil
+}
+
+// RebuildSubtrie rebuilds a subtrie from its nodes, and verifies that its root is at the given height and has the
+// given hash.
+func RebuildSubtrie(storableNodes []*StorableNode, height int, hash []byte) (*node.Node, error) {
+	nodes, err := rebuildVerifiedNodes(storableNodes, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	root := nodes[len(nodes)-1]
+	if root == nil {
+		return nil, fmt.Errorf("subtrie has no root")
+	}
+	if root.Height() != height {
+		return nil, fmt.Errorf("root of subtrie has height %d, expected %d", root.Height(), height)
+	}
+	if !bytes.Equal(root.Hash(), hash) {
+		return nil, fmt.Errorf("root of subtrie has hash %x, expected %x", root.Hash(), hash)
+	}
+	return root, nil
+}
+
+// rebuildVerifiedNodes generates a list of Nodes from a sequence of StorableNodes, like RebuildNodes, but computes
+// the hash, max depth and register count of every node instead of taking them from the storable node. The nodes at
+// the indices of the substitutes are replaced by them. The computed hash of every node must match the stored hash.
+func rebuildVerifiedNodes(storableNodes []*StorableNode, substitutes map[uint64]*node.Node) ([]*node.Node, error) {
+	if len(storableNodes) == 0 {
+		return nil, fmt.Errorf("no nodes to rebuild")
+	}
+
+	nodes := make([]*node.Node, 0, len(storableNodes))
+	for i, snode := range storableNodes {
+		if snode == nil {
+			nodes = append(nodes, nil)
+			continue
+		}
+		if (snode.LIndex >= uint64(i)) || (snode.RIndex >= uint64(i)) {
+			return nil, fmt.Errorf("sequence of StorableNodes does not satisfy Descendents-First-Relationship")
+		}
+
+		if substitute, ok := substitutes[uint64(i)]; ok {
+			if substitute.Height() != int(snode.Height) || !bytes.Equal(substitute.Hash(), snode.HashValue) {
+				return nil, fmt.Errorf("substitute of node %d does not match the node", i)
+			}
+			nodes = append(nodes, substitute)
+			continue
+		}
+
+		left := nodes[snode.LIndex]
+		right := nodes[snode.RIndex]
+
+		var n *node.Node
+		switch {
+		case len(snode.Path) > 0:
+			if left != nil || right != nil {
+				return nil, fmt.Errorf("leaf node %d has children", i)
+			}
+			payload, err := encoding.DecodePayload(snode.EncPayload)
+			if err != nil {
+				return nil, fmt.Errorf("failed to decode a payload for an storableNode %w", err)
+			}
+			n = node.NewLeaf(ledger.Path(snode.Path), payload, int(snode.Height))
+		case left == nil && right == nil:
+			n = node.NewEmptyTreeRoot(int(snode.Height))
+		default:
+			if (left != nil && left.Height() != int(snode.Height)-1) || (right != nil && right.Height() != int(snode.Height)-1) {
+				return nil, fmt.Errorf("children of node %d are not one level below it", i)
+			}
+			n = node.NewInterimNode(int(snode.Height), left, right)
+		}
+
+		if !bytes.Equal(n.Hash(), snode.HashValue) {
+			return nil, fmt.Errorf("hash of node %d does not match its content", i)
+		}
+		nodes = append(nodes, n)
+	}
+
+	return nodes, nil
+}
+
+// storableNode returns the storable node of the given node, with the given indices of its children.
+func storableNode(n *node.Node, leftIndex, rightIndex uint64) *StorableNode {
+	return &StorableNode{
+		LIndex:     leftIndex,
+		RIndex:     rightIndex,
+		Height:     uint16(n.Height()),
+		Path:       n.Path(),
+		EncPayload: encoding.EncodePayload(n.Payload()),
+		HashValue:  n.Hash(),
+		MaxDepth:   n.MaxDepth(),
+		RegCount:   n.RegCount(),
+	}
+}
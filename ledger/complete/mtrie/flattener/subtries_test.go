@@ -0,0 +1,165 @@
+package flattener_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/ledger"
+	"github.com/onflow/flow-go/ledger/common/utils"
+	"github.com/onflow/flow-go/ledger/complete/mtrie/flattener"
+	"github.com/onflow/flow-go/ledger/complete/mtrie/node"
+	"github.com/onflow/flow-go/ledger/complete/mtrie/trie"
+)
+
+// randomTrie returns a trie with the given number of random registers.
+func randomTrie(t *testing.T, n int) (*trie.MTrie, []ledger.Path) {
+	pathByteSize := 32
+	emptyTrie, err := trie.NewEmptyMTrie(pathByteSize)
+	require.NoError(t, err)
+
+	paths := utils.RandomPaths(n, pathByteSize)
+	payloads := make([]ledger.Payload, 0, n)
+	for _, payload := range utils.RandomPayloads(n, 2, 10) {
+		payloads = append(payloads, *payload)
+	}
+
+	mtrie, err := trie.NewTrieWithUpdatedRegisters(emptyTrie, paths, payloads)
+	require.NoError(t, err)
+	return mtrie, paths
+}
+
+// transferSubtries flattens and rebuilds the left out subtries of the manifest.
+func transferSubtries(t *testing.T, manifest *flattener.TrieManifest, roots []*node.Node) []*node.Node {
+	subtries := make([]*node.Node, 0, len(roots))
+	for i, root := range roots {
+		subtrieRoot, err := manifest.Subtrie(i)
+		require.NoError(t, err)
+
+		subtrie, err := flattener.RebuildSubtrie(flattener.FlattenSubtrie(root), int(subtrieRoot.Height), subtrieRoot.HashValue)
+		require.NoError(t, err)
+		subtries = append(subtries, subtrie)
+	}
+	return subtries
+}
+
+func TestSplitAndRebuildTrie(t *testing.T) {
+	mtrie, paths := randomTrie(t, 1000)
+
+	for _, depth := range []int{0, 1, 4, 8, 12} {
+		manifest, roots := flattener.SplitTrie(mtrie, depth)
+		require.Len(t, manifest.Subtries, len(roots))
+		require.NoError(t, manifest.Verify())
+
+		subtries := transferSubtries(t, manifest, roots)
+
+		rebuilt, err := manifest.RebuildTrie(subtries)
+		require.NoError(t, err, "depth %d", depth)
+		assert.Equal(t, mtrie.RootHash(), rebuilt.RootHash())
+		assert.Equal(t, mtrie.AllocatedRegCount(), rebuilt.AllocatedRegCount())
+		assert.Equal(t, mtrie.MaxDepth(), rebuilt.MaxDepth())
+
+		expected := mtrie.UnsafeRead(paths)
+		actual := rebuilt.UnsafeRead(paths)
+		for i := range paths {
+			require.True(t, expected[i].Equals(actual[i]))
+		}
+	}
+}
+
+// TestSplitCompactTrie tests splitting a trie whose leaves are above the depth of the split.
+func TestSplitCompactTrie(t *testing.T) {
+	emptyTrie, err := trie.NewEmptyMTrie(32)
+	require.NoError(t, err)
+
+	paths := []ledger.Path{utils.PathByUint8(1), utils.PathByUint8(2), utils.PathByUint8(130)}
+	payloads := []ledger.Payload{*utils.LightPayload8('A', 'a'), *utils.LightPayload8('B', 'b'), *utils.LightPayload8('C', 'c')}
+	mtrie, err := trie.NewTrieWithUpdatedRegisters(emptyTrie, paths, payloads)
+	require.NoError(t, err)
+
+	manifest, roots := flattener.SplitTrie(mtrie, 16)
+	assert.Empty(t, roots)
+
+	rebuilt, err := manifest.RebuildTrie(nil)
+	require.NoError(t, err)
+	assert.Equal(t, mtrie.RootHash(), rebuilt.RootHash())
+
+	// the empty trie is a manifest on its own
+	manifest, roots = flattener.SplitTrie(emptyTrie, 8)
+	assert.Empty(t, roots)
+	rebuilt, err = manifest.RebuildTrie(nil)
+	require.NoError(t, err)
+	assert.Equal(t, emptyTrie.RootHash(), rebuilt.RootHash())
+}
+
+func TestSplitTrieTampered(t *testing.T) {
+	mtrie, _ := randomTrie(t, 200)
+	manifest, roots := flattener.SplitTrie(mtrie, 3)
+	require.NotEmpty(t, roots)
+
+	t.Run("tampered payload", func(t *testing.T) {
+		subtrieRoot, err := manifest.Subtrie(0)
+		require.NoError(t, err)
+
+		nodes := flattener.FlattenSubtrie(roots[0])
+		for _, n := range nodes {
+			if n != nil && len(n.Path) > 0 {
+				n.EncPayload = flattener.FlattenSubtrie(roots[len(roots)-1])[1].EncPayload
+				break
+			}
+		}
+
+		_, err = flattener.RebuildSubtrie(nodes, int(subtrieRoot.Height), subtrieRoot.HashValue)
+		assert.Error(t, err)
+	})
+
+	t.Run("subtrie of another trie", func(t *testing.T) {
+		subtrieRoot, err := manifest.Subtrie(0)
+		require.NoError(t, err)
+
+		_, err = flattener.RebuildSubtrie(flattener.FlattenSubtrie(roots[1]), int(subtrieRoot.Height), subtrieRoot.HashValue)
+		assert.Error(t, err)
+	})
+
+	t.Run("tampered manifest", func(t *testing.T) {
+		tampered, _ := flattener.SplitTrie(mtrie, 3)
+		root, err := tampered.Subtrie(0)
+		require.NoError(t, err)
+		root.HashValue = roots[1].Hash()
+
+		assert.Error(t, tampered.Verify())
+	})
+
+	t.Run("missing subtrie", func(t *testing.T) {
+		subtries := transferSubtries(t, manifest, roots)
+		_, err := manifest.RebuildTrie(subtries[1:])
+		assert.Error(t, err)
+
+		subtries[0] = subtries[1]
+		_, err = manifest.RebuildTrie(subtries)
+		assert.Error(t, err)
+	})
+}
+
+func TestEncodeTrieManifest(t *testing.T) {
+	mtrie, _ := randomTrie(t, 100)
+	manifest, roots := flattener.SplitTrie(mtrie, 4)
+
+	// empty and nil payloads encode the same, so the re-encoded values are compared
+	encoded := flattener.EncodeTrieManifest(manifest)
+	decoded, err := flattener.DecodeTrieManifest(encoded)
+	require.NoError(t, err)
+	assert.Equal(t, encoded, flattener.EncodeTrieManifest(decoded))
+	require.NoError(t, decoded.Verify())
+
+	nodes := flattener.EncodeStorableNodes(flattener.FlattenSubtrie(roots[0]))
+	decodedNodes, err := flattener.DecodeStorableNodes(nodes)
+	require.NoError(t, err)
+	assert.Equal(t, nodes, flattener.EncodeStorableNodes(decodedNodes))
+
+	_, err = flattener.DecodeTrieManifest(encoded[:len(encoded)-1])
+	assert.Error(t, err)
+	_, err = flattener.DecodeTrieManifest(append(encoded, 0))
+	assert.Error(t, err)
+}
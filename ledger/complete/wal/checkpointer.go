@@ -311,6 +311,52 @@ func (c *Checkpointer) RemoveCheckpoint(checkpoint int) error {
 	return os.Remove(path.Join(c.dir, NumberToFilename(checkpoint)))
 }
 
+// RemoveCheckpointedSegments removes the WAL segments up to and including the oldest checkpoint. These segments are
+// not needed to restore the state from any of the checkpoints. The last segment is never removed, as it is being
+// written to. It returns the number of bytes removed.
+func (c *Checkpointer) RemoveCheckpointedSegments() (uint64, error) {
+	checkpoints, err := c.Checkpoints()
+	if err != nil {
+		return 0, fmt.Errorf("cannot list checkpoints: %w", err)
+	}
+	if len(checkpoints) == 0 {
+		return 0, nil
+	}
+
+	first, last, err := c.wal.Segments()
+	if err != nil {
+		return 0, fmt.Errorf("cannot get range of segments: %w", err)
+	}
+	if first == -1 {
+		return 0, nil
+	}
+
+	to := checkpoints[0]
+	if to >= last {
+		to = last - 1
+	}
+
+	var removed uint64
+	for i := first; i <= to; i++ {
+		filename := path.Join(c.dir, NumberToFilenamePart(i))
+		info, err := os.Stat(filename)
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return removed, fmt.Errorf("cannot stat segment %d: %w", i, err)
+		}
+
+		err = os.Remove(filename)
+		if err != nil {
+			return removed, fmt.Errorf("cannot remove segment %d: %w", i, err)
+		}
+		removed += uint64(info.Size())
+	}
+
+	return removed, nil
+}
+
 func LoadCheckpoint(filepath string) (*flattener.FlattenedForest, error) {
 	file, err := os.Open(filepath)
 	if err != nil {
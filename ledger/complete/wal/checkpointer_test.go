@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"fmt"
 	"io"
+	"io/ioutil"
 	"math/rand"
 	"os"
 	"path"
@@ -490,6 +491,61 @@ func Test_StoringLoadingCheckpoints(t *testing.T) {
 
 }
 
+func Test_RemoveCheckpointedSegments(t *testing.T) {
+
+	unittest.RunWithTempDir(t, func(dir string) {
+
+		wal, err := realWAL.NewDiskWAL(zerolog.Nop(), nil, metrics.NewNoopCollector(), dir, size*10, pathByteSize, segmentSize)
+		require.NoError(t, err)
+
+		checkpointer, err := wal.NewCheckpointer()
+		require.NoError(t, err)
+
+		// no checkpoints, no segment can be removed
+		removed, err := checkpointer.RemoveCheckpointedSegments()
+		require.NoError(t, err)
+		require.Zero(t, removed)
+
+		// WAL segments are 32kB, so each update of 2 payloads of at least 64kB creates new segments
+		rootHash := ledger.RootHash(utils.RandomValues(1, 32, 32)[0])
+		for i := 0; i < size; i++ {
+			update := &ledger.TrieUpdate{
+				RootHash: rootHash,
+				Paths:    utils.RandomPaths(numInsPerStep, pathByteSize),
+				Payloads: utils.RandomPayloads(numInsPerStep, 2<<15, 2<<16),
+			}
+			err = wal.RecordUpdate(update)
+			require.NoError(t, err)
+		}
+
+		first, last, err := wal.Segments()
+		require.NoError(t, err)
+		require.Equal(t, 0, first)
+		require.Greater(t, last, 5)
+
+		// only the presence of checkpoint files matters for removing segments
+		for _, checkpoint := range []int{3, 5} {
+			err = ioutil.WriteFile(path.Join(dir, realWAL.NumberToFilename(checkpoint)), []byte{}, 0644)
+			require.NoError(t, err)
+		}
+
+		removed, err = checkpointer.RemoveCheckpointedSegments()
+		require.NoError(t, err)
+		require.NotZero(t, removed)
+
+		// the segments after the oldest checkpoint are kept
+		for i := 0; i <= last; i++ {
+			if i <= 3 {
+				require.NoFileExists(t, path.Join(dir, realWAL.NumberToFilenamePart(i)))
+			} else {
+				require.FileExists(t, path.Join(dir, realWAL.NumberToFilenamePart(i)))
+			}
+		}
+
+		<-wal.Done()
+	})
+}
+
 func loadIntoForest(forest *mtrie.Forest, forestSequencing *flattener.FlattenedForest) error {
 	tries, err := flattener.RebuildTries(forestSequencing)
 	if err != nil {
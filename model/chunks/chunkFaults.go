@@ -1,7 +1,6 @@
 package chunks
 
 import (
-	"encoding/hex"
 	"fmt"
 
 	"github.com/onflow/flow-go/model/flow"
@@ -17,20 +16,25 @@ type ChunkFault interface {
 
 // CFMissingRegisterTouch is returned when a register touch is missing (read or update)
 type CFMissingRegisterTouch struct {
-	regsterIDs []string
+	registers  []flow.RegisterID
 	chunkIndex uint64
 	execResID  flow.Identifier
 }
 
 func (cf CFMissingRegisterTouch) String() string {
-	hexStrings := make([]string, len(cf.regsterIDs))
-	for i, s := range cf.regsterIDs {
-		hexStrings[i] = hex.EncodeToString([]byte(s))
+	hexStrings := make([]string, len(cf.registers))
+	for i, reg := range cf.registers {
+		hexStrings[i] = reg.String()
 	}
 
 	return fmt.Sprint("at least one register touch was missing inside the chunk data package that was needed while running transactions (hex-encoded): ", hexStrings)
 }
 
+// Registers returns the registers missing from the chunk data package
+func (cf CFMissingRegisterTouch) Registers() []flow.RegisterID {
+	return cf.registers
+}
+
 // ChunkIndex returns chunk index of the faulty chunk
 func (cf CFMissingRegisterTouch) ChunkIndex() uint64 {
 	return cf.chunkIndex
@@ -42,8 +46,8 @@ func (cf CFMissingRegisterTouch) ExecutionResultID() flow.Identifier {
 }
 
 // NewCFMissingRegisterTouch creates a new instance of Chunk Fault (MissingRegisterTouch)
-func NewCFMissingRegisterTouch(regsterIDs []string, chInx uint64, execResID flow.Identifier) *CFMissingRegisterTouch {
-	return &CFMissingRegisterTouch{regsterIDs: regsterIDs,
+func NewCFMissingRegisterTouch(registers []flow.RegisterID, chInx uint64, execResID flow.Identifier) *CFMissingRegisterTouch {
+	return &CFMissingRegisterTouch{registers: registers,
 		chunkIndex: chInx,
 		execResID:  execResID}
 }
@@ -53,6 +57,7 @@ func NewCFMissingRegisterTouch(regsterIDs []string, chInx uint64, execResID flow
 type CFNonMatchingFinalState struct {
 	expected   []byte
 	computed   []byte
+	trace      []flow.ChunkTraceStep
 	chunkIndex uint64
 	execResID  flow.Identifier
 }
@@ -71,9 +76,66 @@ func (cf CFNonMatchingFinalState) ExecutionResultID() flow.Identifier {
 	return cf.execResID
 }
 
+// Expected returns the final state commitment expected from applying the register updates of the chunk
+func (cf CFNonMatchingFinalState) Expected() []byte {
+	return cf.expected
+}
+
+// Computed returns the final state commitment provided by the chunk
+func (cf CFNonMatchingFinalState) Computed() []byte {
+	return cf.computed
+}
+
+// Trace returns the register updates of the transactions of the chunk, in execution order
+func (cf CFNonMatchingFinalState) Trace() []flow.ChunkTraceStep {
+	return cf.trace
+}
+
 // NewCFNonMatchingFinalState creates a new instance of Chunk Fault (NonMatchingFinalState)
-func NewCFNonMatchingFinalState(expected []byte, computed []byte, chInx uint64, execResID flow.Identifier) *CFNonMatchingFinalState {
+func NewCFNonMatchingFinalState(expected []byte, computed []byte, trace []flow.ChunkTraceStep, chInx uint64, execResID flow.Identifier) *CFNonMatchingFinalState {
 	return &CFNonMatchingFinalState{expected: expected,
+		computed:   computed,
+		trace:      trace,
+		chunkIndex: chInx,
+		execResID:  execResID}
+}
+
+// CFNonMatchingExecutionData is returned when the execution data of the chunk, computed from its collection, the events
+// of its transactions and its register updates, doesn't match the one listed in the execution data committed by the result
+type CFNonMatchingExecutionData struct {
+	expected   flow.Identifier
+	computed   flow.Identifier
+	chunkIndex uint64
+	execResID  flow.Identifier
+}
+
+func (cf CFNonMatchingExecutionData) String() string {
+	return fmt.Sprintf("chunk execution data doesn't match, expected [%x] but computed [%x]", cf.expected, cf.computed)
+}
+
+// ChunkIndex returns chunk index of the faulty chunk
+func (cf CFNonMatchingExecutionData) ChunkIndex() uint64 {
+	return cf.chunkIndex
+}
+
+// ExecutionResultID returns the execution result identifier including the faulty chunk
+func (cf CFNonMatchingExecutionData) ExecutionResultID() flow.Identifier {
+	return cf.execResID
+}
+
+// Expected returns the ID of the chunk execution data listed in the execution data committed by the result
+func (cf CFNonMatchingExecutionData) Expected() flow.Identifier {
+	return cf.expected
+}
+
+// Computed returns the ID of the chunk execution data computed by verifying the chunk
+func (cf CFNonMatchingExecutionData) Computed() flow.Identifier {
+	return cf.computed
+}
+
+// NewCFNonMatchingExecutionData creates a new instance of Chunk Fault (NonMatchingExecutionData)
+func NewCFNonMatchingExecutionData(expected flow.Identifier, computed flow.Identifier, chInx uint64, execResID flow.Identifier) *CFNonMatchingExecutionData {
+	return &CFNonMatchingExecutionData{expected: expected,
 		computed:   computed,
 		chunkIndex: chInx,
 		execResID:  execResID}
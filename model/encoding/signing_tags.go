@@ -29,6 +29,8 @@ var (
 	ExecutionReceiptTag = tag("Execution-Receipt")
 	// ResultApprovalTag is used for result approvals
 	ResultApprovalTag = tag("Result-Approval")
+	// ChallengeTag is used for challenges of execution results
+	ChallengeTag = tag("Challenge")
 	// SPOCKTag is used to generate SPoCK proofs
 	SPOCKTag = tag("SPoCK")
 )
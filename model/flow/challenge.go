@@ -0,0 +1,141 @@
+package flow
+
+import (
+	"github.com/onflow/flow-go/crypto"
+	"github.com/onflow/flow-go/model/fingerprint"
+)
+
+// ChunkFaultKind is the kind of fault a verifier found in a chunk of an execution result.
+type ChunkFaultKind uint8
+
+const (
+	// ChunkFaultInvalidChunkDataPack indicates that the partial trie of the chunk data pack can not be constructed
+	// at the start state of the chunk.
+	ChunkFaultInvalidChunkDataPack ChunkFaultKind = iota + 1
+	// ChunkFaultMissingRegisterTouch indicates that registers touched by the chunk are missing from its chunk data
+	// pack.
+	ChunkFaultMissingRegisterTouch
+	// ChunkFaultNonMatchingFinalState indicates that the registers updated by the chunk lead to a different end
+	// state than the one committed to by the execution result.
+	ChunkFaultNonMatchingFinalState
+	// ChunkFaultNonMatchingExecutionData indicates that the execution data of the chunk differs from the one listed in
+	// the execution data committed to by the execution result.
+	ChunkFaultNonMatchingExecutionData
+)
+
+func (k ChunkFaultKind) String() string {
+	switch k {
+	case ChunkFaultInvalidChunkDataPack:
+		return "invalid_chunk_data_pack"
+	case ChunkFaultMissingRegisterTouch:
+		return "missing_register_touch"
+	case ChunkFaultNonMatchingFinalState:
+		return "non_matching_final_state"
+	case ChunkFaultNonMatchingExecutionData:
+		return "non_matching_execution_data"
+	default:
+		return "unknown"
+	}
+}
+
+// ChunkTraceStep holds the registers updated by a transaction of a chunk, as executed by the verifier.
+type ChunkTraceStep struct {
+	TransactionIndex uint32
+	Updates          []RegisterEntry
+}
+
+// FaultProof is a self-contained proof that a chunk of an execution result is faulty. It holds the receipt of an
+// execution node committing to the execution result, the collection and the chunk data pack the verifier verified the
+// chunk with, and the evidence of the fault. The evidence of a missing register touch are the registers touched by the
+// chunk that are missing from the chunk data pack. The evidence of a non matching final state is the execution trace
+// of the chunk, and the end state resulting from applying its register updates to the chunk data pack. The evidence
+// of a non matching execution data is the ID of the execution data of the chunk, as computed by the verifier. An
+// invalid chunk data pack is its own evidence. Consensus nodes check the evidence by verifying the chunk again with the
+// collection, the chunk data pack and the execution data of the proof.
+type FaultProof struct {
+	BlockID                      Identifier           // ID of the block the execution result is for
+	ExecutionResultID            Identifier           // ID of the execution result
+	ChunkIndex                   uint64               // index of the faulty chunk
+	Kind                         ChunkFaultKind       // kind of the fault
+	Receipt                      ExecutionReceiptMeta // receipt of an execution node committing to the execution result
+	Collection                   Collection           // collection of the chunk, empty for the system chunk
+	ChunkDataPack                ChunkDataPack        // chunk data pack the chunk was verified with
+	ExecutionData                ExecutionData        // execution data committed in the execution result, empty if not committed
+	MissingRegisters             []RegisterID         // registers touched by the chunk, but missing from the chunk data pack
+	Trace                        []ChunkTraceStep     // register updates of the transactions of the chunk, in execution order
+	ComputedEndState             StateCommitment      // end state resulting from the register updates of the trace
+	ComputedChunkExecutionDataID Identifier           // ID of the execution data of the chunk, as computed by verifying it
+}
+
+// ChallengeBody holds the body part of a challenge
+type ChallengeBody struct {
+	FaultProof
+	ChallengerID Identifier // node id raising this challenge
+}
+
+// ID generates a unique identifier using ChallengeBody
+func (cb ChallengeBody) ID() Identifier {
+	return MakeID(cb)
+}
+
+// Fingerprint encodes the challenge body, with the collection of its fault proof encoded by its own fingerprint, as
+// transactions can not be RLP encoded.
+func (cb ChallengeBody) Fingerprint() []byte {
+	return fingerprint.Fingerprint(struct {
+		BlockID                      Identifier
+		ExecutionResultID            Identifier
+		ChunkIndex                   uint64
+		Kind                         ChunkFaultKind
+		Receipt                      ExecutionReceiptMeta
+		Collection                   []byte
+		ChunkDataPack                ChunkDataPack
+		ExecutionData                ExecutionData
+		MissingRegisters             []RegisterID
+		Trace                        []ChunkTraceStep
+		ComputedEndState             StateCommitment
+		ComputedChunkExecutionDataID Identifier
+		ChallengerID                 Identifier
+	}{
+		BlockID:                      cb.BlockID,
+		ExecutionResultID:            cb.ExecutionResultID,
+		ChunkIndex:                   cb.ChunkIndex,
+		Kind:                         cb.Kind,
+		Receipt:                      cb.Receipt,
+		Collection:                   cb.Collection.Fingerprint(),
+		ChunkDataPack:                cb.ChunkDataPack,
+		ExecutionData:                cb.ExecutionData,
+		MissingRegisters:             cb.MissingRegisters,
+		Trace:                        cb.Trace,
+		ComputedEndState:             cb.ComputedEndState,
+		ComputedChunkExecutionDataID: cb.ComputedChunkExecutionDataID,
+		ChallengerID:                 cb.ChallengerID,
+	})
+}
+
+// Challenge disputes the correctness of a chunk of an execution result, raised by a verification node that found
+// the chunk to be faulty. Results whose chunk is challenged by enough of its assigned verifiers are not sealed.
+type Challenge struct {
+	Body              ChallengeBody
+	VerifierSignature crypto.Signature // signature over all above fields
+}
+
+// ID generates a unique identifier using challenge body
+func (c Challenge) ID() Identifier {
+	return MakeID(c.Body)
+}
+
+// Checksum generates checksum using the challenge full content
+func (c Challenge) Checksum() Identifier {
+	return MakeID(c)
+}
+
+// Fingerprint encodes the challenge with the fingerprint of its body.
+func (c Challenge) Fingerprint() []byte {
+	return fingerprint.Fingerprint(struct {
+		Body              []byte
+		VerifierSignature crypto.Signature
+	}{
+		Body:              c.Body.Fingerprint(),
+		VerifierSignature: c.VerifierSignature,
+	})
+}
@@ -0,0 +1,106 @@
+package flow
+
+import (
+	"fmt"
+
+	"github.com/onflow/flow-go/model/fingerprint"
+)
+
+// ExecutionData is the data produced by executing a block, published by execution nodes for consumers that would
+// otherwise request the chunk data packs and events of the block one by one. It lists the execution data of the
+// chunks of the block, which is published chunk by chunk to bound the size of the messages serving it.
+//
+// The execution data is content-addressed by its ID, which is committed in the execution result of the block.
+// Consumers authenticate it against the result with CheckCommitted, and the execution data of its chunks by their
+// IDs, whichever execution node serves it.
+type ExecutionData struct {
+	BlockID               Identifier
+	ChunkExecutionDataIDs []Identifier // in the order of the chunks of the execution result
+}
+
+// ID returns the hash of the execution data, which addresses its content.
+func (ed ExecutionData) ID() Identifier {
+	return MakeID(ed)
+}
+
+func (ed ExecutionData) Checksum() Identifier {
+	return MakeID(ed)
+}
+
+// CheckCommitted returns an error if the execution data is not the one the given execution result commits to.
+func (ed ExecutionData) CheckCommitted(result *ExecutionResult) error {
+	if result.ExecutionDataID == ZeroID {
+		return fmt.Errorf("execution result %x commits to no execution data", result.ID())
+	}
+	if ed.BlockID != result.BlockID {
+		return fmt.Errorf("execution data is for block %x instead of %x", ed.BlockID, result.BlockID)
+	}
+	if len(ed.ChunkExecutionDataIDs) != len(result.Chunks) {
+		return fmt.Errorf("execution data lists %d chunks instead of %d", len(ed.ChunkExecutionDataIDs), len(result.Chunks))
+	}
+	if ed.ID() != result.ExecutionDataID {
+		return fmt.Errorf("execution data %x is not the execution data %x committed in the execution result", ed.ID(), result.ExecutionDataID)
+	}
+	return nil
+}
+
+// ChunkExecutionData is the data produced by executing a chunk: the collection of the chunk, the events emitted by
+// its transactions, and the trie update of the chunk, i.e. the registers it updated. The collection of the system
+// chunk is not part of the block payload, so it is nil.
+type ChunkExecutionData struct {
+	BlockID    Identifier
+	Index      uint64 // index of the chunk in the execution result
+	Collection *Collection
+	Events     []Event
+	TrieUpdate RegisterEntries // sorted by register ID
+}
+
+// ID returns the hash of the chunk execution data, which addresses its content.
+func (cd ChunkExecutionData) ID() Identifier {
+	return MakeID(cd)
+}
+
+func (cd ChunkExecutionData) Checksum() Identifier {
+	return MakeID(cd)
+}
+
+// Fingerprint encodes the complete content of the chunk execution data. The fingerprint of an event only covers its
+// transaction and index, so events are encoded with all their fields.
+func (cd ChunkExecutionData) Fingerprint() []byte {
+	type eventBody struct {
+		Type             EventType
+		TransactionID    Identifier
+		TransactionIndex uint32
+		EventIndex       uint32
+		Payload          []byte
+	}
+
+	var collection []byte
+	if cd.Collection != nil {
+		collection = cd.Collection.Fingerprint()
+	}
+	events := make([]eventBody, 0, len(cd.Events))
+	for _, event := range cd.Events {
+		events = append(events, eventBody{
+			Type:             event.Type,
+			TransactionID:    event.TransactionID,
+			TransactionIndex: event.TransactionIndex,
+			EventIndex:       event.EventIndex,
+			Payload:          event.Payload,
+		})
+	}
+
+	return fingerprint.Fingerprint(struct {
+		BlockID    Identifier
+		Index      uint64
+		Collection []byte
+		Events     []eventBody
+		TrieUpdate RegisterEntries
+	}{
+		BlockID:    cd.BlockID,
+		Index:      cd.Index,
+		Collection: collection,
+		Events:     events,
+		TrieUpdate: cd.TrieUpdate,
+	})
+}
@@ -0,0 +1,77 @@
+package flow_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+// TestChunkExecutionDataID tests that the ID of chunk execution data addresses its complete content.
+func TestChunkExecutionDataID(t *testing.T) {
+	chunkExecutionData := func() *flow.ChunkExecutionData {
+		collection := unittest.CollectionFixture(2)
+		return &flow.ChunkExecutionData{
+			BlockID:    flow.Identifier{1},
+			Collection: &collection,
+			Events:     []flow.Event{unittest.EventFixture(flow.EventAccountCreated, 0, 0, flow.Identifier{2})},
+			TrieUpdate: flow.RegisterEntries{{Key: flow.RegisterID{Owner: "owner", Key: "key"}, Value: []byte{1}}},
+		}
+	}
+
+	data := chunkExecutionData()
+	assert.Equal(t, data.ID(), data.ID())
+
+	// collection fixtures have random transactions
+	assert.NotEqual(t, data.ID(), chunkExecutionData().ID())
+
+	changed := *data
+	changed.Events = []flow.Event{data.Events[0]}
+	changed.Events[0].Payload = []byte("changed")
+	assert.NotEqual(t, data.ID(), changed.ID())
+
+	changed.Events = data.Events
+	changed.TrieUpdate = flow.RegisterEntries{{Key: flow.RegisterID{Owner: "owner", Key: "key"}, Value: []byte{2}}}
+	assert.NotEqual(t, data.ID(), changed.ID())
+
+	changed.TrieUpdate = data.TrieUpdate
+	changed.Index = 1
+	assert.NotEqual(t, data.ID(), changed.ID())
+
+	changed.Index = data.Index
+	assert.Equal(t, data.ID(), changed.ID())
+
+	// the system chunk has no collection
+	system := flow.ChunkExecutionData{BlockID: flow.Identifier{1}, Index: 1, Events: []flow.Event{}}
+	assert.NotEqual(t, system.ID(), flow.ChunkExecutionData{BlockID: flow.Identifier{2}, Index: 1, Events: []flow.Event{}}.ID())
+}
+
+// TestExecutionDataCheckCommitted tests that execution data is only accepted if its ID is committed in the execution
+// result of its block.
+func TestExecutionDataCheckCommitted(t *testing.T) {
+	block := unittest.BlockFixture()
+	result := unittest.ExecutionResultFixture(unittest.WithBlock(&block))
+	data := flow.ExecutionData{
+		BlockID:               block.ID(),
+		ChunkExecutionDataIDs: unittest.IdentifierListFixture(len(result.Chunks)),
+	}
+
+	// results of execution nodes that do not publish execution data commit to none
+	assert.Error(t, data.CheckCommitted(result))
+
+	result.ExecutionDataID = data.ID()
+	assert.NoError(t, data.CheckCommitted(result))
+
+	changed := data
+	changed.ChunkExecutionDataIDs = unittest.IdentifierListFixture(len(result.Chunks))
+	assert.Error(t, changed.CheckCommitted(result))
+
+	changed.ChunkExecutionDataIDs = append(data.ChunkExecutionDataIDs, unittest.IdentifierFixture())
+	assert.Error(t, changed.CheckCommitted(result))
+
+	changed.ChunkExecutionDataIDs = data.ChunkExecutionDataIDs
+	changed.BlockID = unittest.IdentifierFixture()
+	assert.Error(t, changed.CheckCommitted(result))
+}
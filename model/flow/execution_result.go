@@ -1,11 +1,16 @@
 package flow
 
+import (
+	"github.com/onflow/flow-go/model/fingerprint"
+)
+
 // ExecutionResult ...
 type ExecutionResult struct {
 	PreviousResultID Identifier // commit of the previous ER
 	BlockID          Identifier // commit of the current block
 	Chunks           ChunkList
 	ServiceEvents    []ServiceEvent
+	ExecutionDataID  Identifier // ID of the execution data of the block, zero if not committed
 }
 
 // ID returns the hash of the execution result body
@@ -18,6 +23,40 @@ func (er ExecutionResult) Checksum() Identifier {
 	return MakeID(er)
 }
 
+// Fingerprint encodes the execution result. The execution data ID is only encoded if the result commits to execution
+// data, so that the IDs of results not committing to any, such as the root result of the bootstrap data, are the same
+// as before the field was introduced. Nodes not knowing the field compute different IDs for results committing to
+// execution data though, so that execution nodes only commit to it once all nodes know the field, i.e. after a spork.
+func (er ExecutionResult) Fingerprint() []byte {
+	if er.ExecutionDataID == ZeroID {
+		return fingerprint.Fingerprint(struct {
+			PreviousResultID Identifier
+			BlockID          Identifier
+			Chunks           ChunkList
+			ServiceEvents    []ServiceEvent
+		}{
+			PreviousResultID: er.PreviousResultID,
+			BlockID:          er.BlockID,
+			Chunks:           er.Chunks,
+			ServiceEvents:    er.ServiceEvents,
+		})
+	}
+
+	return fingerprint.Fingerprint(struct {
+		PreviousResultID Identifier
+		BlockID          Identifier
+		Chunks           ChunkList
+		ServiceEvents    []ServiceEvent
+		ExecutionDataID  Identifier
+	}{
+		PreviousResultID: er.PreviousResultID,
+		BlockID:          er.BlockID,
+		Chunks:           er.Chunks,
+		ServiceEvents:    er.ServiceEvents,
+		ExecutionDataID:  er.ExecutionDataID,
+	})
+}
+
 // FinalStateCommitment returns the Execution Result's commitment to the final
 // execution state of the block, i.e. the last chunk's output state.
 //
@@ -41,3 +41,30 @@ func TestExecutionResultGroupBy(t *testing.T) {
 	unknown := groups.GetGroup(unittest.IdentifierFixture())
 	assert.Equal(t, 0, unknown.Size())
 }
+
+// TestExecutionResultID tests that the ID of an execution result not committing to execution data is the one of the
+// execution result without the execution data ID field, and that the execution data ID is part of the ID otherwise.
+func TestExecutionResultID(t *testing.T) {
+	result := unittest.ExecutionResultFixture()
+	legacy := struct {
+		PreviousResultID flow.Identifier
+		BlockID          flow.Identifier
+		Chunks           flow.ChunkList
+		ServiceEvents    []flow.ServiceEvent
+	}{
+		PreviousResultID: result.PreviousResultID,
+		BlockID:          result.BlockID,
+		Chunks:           result.Chunks,
+		ServiceEvents:    result.ServiceEvents,
+	}
+	legacyID := flow.MakeID(legacy)
+	assert.Equal(t, legacyID, result.ID())
+
+	committed := *result
+	committed.ExecutionDataID = unittest.IdentifierFixture()
+	assert.NotEqual(t, legacyID, committed.ID())
+
+	changed := committed
+	changed.ExecutionDataID = unittest.IdentifierFixture()
+	assert.NotEqual(t, committed.ID(), changed.ID())
+}
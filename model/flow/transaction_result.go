@@ -11,6 +11,9 @@ type TransactionResult struct {
 	TransactionID Identifier
 	// ErrorMessage contains the error message of any error that may have occurred when the transaction was executed
 	ErrorMessage string
+	// StatusCode is the status code of the execution as reported by execution nodes, 0 on success and 1 on error.
+	// It is set by access nodes indexing the results, execution nodes derive it from the error message.
+	StatusCode uint32
 }
 
 // String returns the string representation of this error.
@@ -0,0 +1,30 @@
+package flow
+
+// TransactionTrace records what the execution of a transaction touched, for investigating its behaviour.
+type TransactionTrace struct {
+	BlockID          Identifier
+	TransactionID    Identifier
+	TransactionIndex uint32
+	Reads            []RegisterTrace // the registers read, sorted by register ID
+	Writes           []RegisterTrace // the registers written, sorted by register ID
+	Programs         []string        // the locations of the Cadence programs loaded, sorted
+	ComputationUsed  uint64
+	Events           []Event
+}
+
+// RegisterTrace is a register accessed by a transaction, with the size of its value before the transaction and of
+// the value written by the transaction. The new value size of a register that was only read is 0.
+type RegisterTrace struct {
+	Register     RegisterID
+	OldValueSize uint64
+	NewValueSize uint64
+}
+
+// ID returns a canonical identifier that is guaranteed to be unique.
+func (t TransactionTrace) ID() Identifier {
+	return t.TransactionID
+}
+
+func (t TransactionTrace) Checksum() Identifier {
+	return MakeID(t)
+}
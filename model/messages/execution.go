@@ -18,7 +18,8 @@ type ChunkDataRequest struct {
 type ChunkDataResponse struct {
 	ChunkDataPack flow.ChunkDataPack
 	Collection    flow.Collection
-	Nonce         uint64 // so that we aren't deduplicated by the network layer
+	ExecutionData *flow.ExecutionData // execution data of the block of the chunk, nil if not known
+	Nonce         uint64              // so that we aren't deduplicated by the network layer
 }
 
 // ExecutionStateSyncRequest represents a request for state deltas between
@@ -54,3 +55,58 @@ func (b *ExecutionStateDelta) Height() uint64 {
 func (b *ExecutionStateDelta) ParentID() flow.Identifier {
 	return b.Block.Header.ParentID
 }
+
+// ExecutionStateStatusRequest represents a request for whether a node holds the
+// execution state with the given state commitment, and for the height of the
+// latest block it knows as sealed.
+type ExecutionStateStatusRequest struct {
+	StateCommitment flow.StateCommitment
+	Nonce           uint64 // so that we aren't deduplicated by the network layer
+}
+
+// ExecutionStateStatusResponse is the response to a status request.
+type ExecutionStateStatusResponse struct {
+	StateCommitment flow.StateCommitment
+	Held            bool   // whether the node holds and serves the requested state
+	SealedHeight    uint64 // the height of the latest block sealed in the protocol state of the node
+	Nonce           uint64 // the nonce of the request
+}
+
+// ExecutionStateManifestRequest represents a request for the manifest of the
+// trie of the execution state with the given state commitment, split at the
+// given depth.
+type ExecutionStateManifestRequest struct {
+	StateCommitment flow.StateCommitment
+	Depth           uint16
+	Nonce           uint64 // so that we aren't deduplicated by the network layer
+}
+
+// ExecutionStateManifestResponse is the response to a manifest request. It
+// contains the encoded manifest of the trie, which is the top of the trie down
+// to the requested depth with the subtries below left out.
+type ExecutionStateManifestResponse struct {
+	StateCommitment flow.StateCommitment
+	Depth           uint16
+	Manifest        []byte
+	Nonce           uint64 // the nonce of the request
+}
+
+// ExecutionStateSubtrieRequest represents a request for the subtrie with the
+// given index among the subtries left out of the manifest of the trie of the
+// execution state with the given state commitment, split at the given depth.
+type ExecutionStateSubtrieRequest struct {
+	StateCommitment flow.StateCommitment
+	Depth           uint16
+	Index           uint64
+	Nonce           uint64 // so that we aren't deduplicated by the network layer
+}
+
+// ExecutionStateSubtrieResponse is the response to a subtrie request. It
+// contains the encoded nodes of the subtrie.
+type ExecutionStateSubtrieResponse struct {
+	StateCommitment flow.StateCommitment
+	Depth           uint16
+	Index           uint64
+	Nodes           []byte
+	Nonce           uint64 // the nonce of the request
+}
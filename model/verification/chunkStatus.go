@@ -0,0 +1,66 @@
+package verification
+
+import (
+	"time"
+
+	"github.com/onflow/flow-go/model/chunks"
+	"github.com/onflow/flow-go/model/flow"
+)
+
+// ChunkStatus represents the progress of a chunk assigned to a verification node through its verification pipeline.
+// The chunk data pack of the chunk is requested until it is received, and the chunk is then verified.
+// This is an internal entity for verification node, which is persisted so that the pipeline resumes from where it
+// left off after a restart.
+type ChunkStatus struct {
+	Chunk             *flow.Chunk
+	ExecutionResultID flow.Identifier
+	Height            uint64
+	Agrees            []flow.Identifier
+	Disagrees         []flow.Identifier
+	LastAttempt       time.Time
+	Attempt           int
+	ChunkDataPack     *flow.ChunkDataPack // received chunk data pack of the chunk, nil while it is requested
+	Collection        *flow.Collection    // collection of the received chunk data pack
+	ExecutionData     *flow.ExecutionData // execution data of the block received with the chunk data pack, if any
+	Verified          bool                // whether the chunk has been verified
+	InvalidResponders []flow.Identifier   // executors that responded with an invalid chunk data pack of the chunk
+}
+
+func NewChunkStatus(
+	chunk *flow.Chunk,
+	resultID flow.Identifier,
+	height uint64,
+	agrees []flow.Identifier,
+	disagrees []flow.Identifier,
+) *ChunkStatus {
+	return &ChunkStatus{
+		Chunk:             chunk,
+		ExecutionResultID: resultID,
+		Height:            height,
+		Agrees:            agrees,
+		Disagrees:         disagrees,
+	}
+}
+
+// ID returns the unique identifier for the ChunkStatus which is the id of its chunk.
+func (s ChunkStatus) ID() flow.Identifier {
+	return s.Chunk.ID()
+}
+
+// Checksum returns the checksum of the ChunkStatus.
+func (s ChunkStatus) Checksum() flow.Identifier {
+	return s.Chunk.ID()
+}
+
+// Received returns true if the chunk data pack of the chunk has been received.
+func (s ChunkStatus) Received() bool {
+	return s.ChunkDataPack != nil
+}
+
+// Locator returns the locator of the chunk in its execution result.
+func (s ChunkStatus) Locator() *chunks.Locator {
+	return &chunks.Locator{
+		ResultID: s.ExecutionResultID,
+		Index:    s.Chunk.Index,
+	}
+}
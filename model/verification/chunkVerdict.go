@@ -0,0 +1,29 @@
+package verification
+
+import (
+	"github.com/onflow/flow-go/model/flow"
+)
+
+// Verdict is the verdict of a verification node on a chunk it verified.
+type Verdict string
+
+const (
+	// VerdictApproved is the verdict on a chunk that passed verification, and was approved.
+	VerdictApproved Verdict = "approved"
+	// VerdictChallenged is the verdict on a faulty chunk that was challenged.
+	VerdictChallenged Verdict = "challenged"
+	// VerdictUnchallenged is the verdict on a faulty chunk that was neither approved nor challenged, as its fault can
+	// not be attributed to the execution nodes, or no receipt committing to its result is known.
+	VerdictUnchallenged Verdict = "unchallenged"
+)
+
+// ChunkVerdict is the outcome of verifying a chunk assigned to a verification node. Unlike the status of the chunk,
+// it is kept once the block of the chunk is sealed.
+type ChunkVerdict struct {
+	ExecutionResultID flow.Identifier
+	ChunkIndex        uint64
+	Verdict           Verdict
+	ApprovalID        flow.Identifier // ID of the result approval of an approved chunk
+	ChallengeID       flow.Identifier // ID of the challenge of a challenged chunk
+	Fault             string          // fault of a challenged or unchallenged chunk
+}
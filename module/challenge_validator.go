@@ -0,0 +1,16 @@
+package module
+
+import "github.com/onflow/flow-go/model/flow"
+
+// ChallengeValidator is used for validating challenges received from
+// verification nodes against the challenged execution result, with respect
+// to current protocol state.
+// Returns the following:
+// * nil - in case of success
+// * sentinel engine.InvalidInputError when challenge is invalid
+// * sentinel engine.OutdatedInputError if the corresponding block has a finalized seal
+// * sentinel engine.UnverifiableInputError if challenge cannot be validated because of missing data
+// * exception in case of any other error, usually this is not expected.
+type ChallengeValidator interface {
+	Validate(challenge *flow.Challenge, result *flow.ExecutionResult) error
+}
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"errors"
 	"fmt"
+	"sort"
 
 	executionState "github.com/onflow/flow-go/engine/execution/state"
 	"github.com/onflow/flow-go/fvm/programs"
@@ -52,13 +53,15 @@ func (fcv *ChunkVerifier) Verify(vc *verification.VerifiableChunkData) ([]byte,
 		return nil, nil, fmt.Errorf("wrong method invoked for verifying system chunk")
 	}
 
+	// transactions are indexed across the chunks of the block, as executed by execution nodes
+	txIndex := firstTransactionIndex(vc.Result, vc.Chunk.Index)
 	transactions := make([]*fvm.TransactionProcedure, 0)
 	for i, txBody := range vc.Collection.Transactions {
-		tx := fvm.Transaction(txBody, uint32(i))
+		tx := fvm.Transaction(txBody, txIndex+uint32(i))
 		transactions = append(transactions, tx)
 	}
 
-	return fcv.verifyTransactions(vc.Chunk, vc.ChunkDataPack, vc.Result, vc.Header, transactions, vc.EndState)
+	return fcv.verifyTransactions(vc, transactions)
 }
 
 // SystemChunkVerify verifies a given VerifiableChunk corresponding to a system chunk.
@@ -73,28 +76,41 @@ func (fcv *ChunkVerifier) SystemChunkVerify(vc *verification.VerifiableChunkData
 
 	// transaction body of system chunk
 	txBody := fvm.SystemChunkTransaction(fcv.vmCtx.Chain.ServiceAddress())
-	tx := fvm.Transaction(txBody, uint32(0))
+	tx := fvm.Transaction(txBody, firstTransactionIndex(vc.Result, vc.Chunk.Index))
 	transactions := []*fvm.TransactionProcedure{tx}
 
 	systemChunkContext := fvm.NewContextFromParent(fcv.systemChunkCtx,
 		fvm.WithBlockHeader(vc.Header),
 	)
 
-	return fcv.verifyTransactionsInContext(systemChunkContext, vc.Chunk, vc.ChunkDataPack, vc.Result, transactions, vc.EndState)
+	return fcv.verifyTransactionsInContext(systemChunkContext, vc, transactions)
 }
 
-func (fcv *ChunkVerifier) verifyTransactionsInContext(context fvm.Context, chunk *flow.Chunk,
-	chunkDataPack *flow.ChunkDataPack,
-	result *flow.ExecutionResult,
-	transactions []*fvm.TransactionProcedure,
-	endState flow.StateCommitment) ([]byte, chmodels.ChunkFault, error) {
+// firstTransactionIndex returns the index in the block of the first transaction of the chunk, which follows the
+// transactions of the previous chunks.
+func firstTransactionIndex(result *flow.ExecutionResult, chunkIndex uint64) uint32 {
+	var txIndex uint32
+	for _, chunk := range result.Chunks {
+		if chunk.Index >= chunkIndex {
+			break
+		}
+		txIndex += uint32(chunk.NumberOfTransactions)
+	}
+	return txIndex
+}
+
+func (fcv *ChunkVerifier) verifyTransactionsInContext(context fvm.Context,
+	vc *verification.VerifiableChunkData,
+	transactions []*fvm.TransactionProcedure) ([]byte, chmodels.ChunkFault, error) {
 
 	// TODO check collection hash to match
 	// TODO check datapack hash to match
 	// TODO check the number of transactions and computation used
 
-	chIndex := chunk.Index
-	execResID := result.ID()
+	chunkDataPack := vc.ChunkDataPack
+	endState := vc.EndState
+	chIndex := vc.Chunk.Index
+	execResID := vc.Result.ID()
 
 	if chunkDataPack == nil {
 		return nil, nil, fmt.Errorf("missing chunk data pack")
@@ -108,6 +124,120 @@ func (fcv *ChunkVerifier) verifyTransactionsInContext(context fvm.Context, chunk
 			nil
 	}
 
+	chunkView, unknownRegTouch, err := fcv.executeTransactions(context, chunkDataPack, psmt, transactions, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// check read access to unknown registers
+	if len(unknownRegTouch) > 0 {
+		missingRegs := make([]flow.RegisterID, 0, len(unknownRegTouch))
+		for registerID := range unknownRegTouch {
+			missingRegs = append(missingRegs, registerID)
+		}
+		sort.Slice(missingRegs, func(i, j int) bool {
+			return missingRegs[i].String() < missingRegs[j].String()
+		})
+		return nil, chmodels.NewCFMissingRegisterTouch(missingRegs, chIndex, execResID), nil
+	}
+
+	// applying chunk delta (register updates at chunk level) to the partial trie
+	// this returns the expected end state commitment after updates and the list of
+	// register keys that was not provided by the chunk data package (err).
+	regs, values := chunkView.Delta().RegisterUpdates()
+
+	update, err := ledger.NewUpdate(
+		chunkDataPack.StartState,
+		executionState.RegisterIDSToKeys(regs),
+		executionState.RegisterValuesToValues(values),
+	)
+	if err != nil {
+		return nil, nil, fmt.Errorf("cannot create ledger update: %w", err)
+	}
+
+	expEndStateComm, err := psmt.Set(update)
+
+	if err != nil {
+		if errors.Is(err, ledger.ErrMissingKeys{}) {
+			keys := err.(*ledger.ErrMissingKeys).Keys
+			missingRegs := make([]flow.RegisterID, 0, len(keys))
+			for _, key := range keys {
+				registerID, err := executionState.KeyToRegisterID(key)
+				if err != nil {
+					continue
+				}
+				missingRegs = append(missingRegs, registerID)
+			}
+			sort.Slice(missingRegs, func(i, j int) bool {
+				return missingRegs[i].String() < missingRegs[j].String()
+			})
+			return nil, chmodels.NewCFMissingRegisterTouch(missingRegs, chIndex, execResID), nil
+		}
+		return nil, chmodels.NewCFMissingRegisterTouch(nil, chIndex, execResID), nil
+	}
+
+	// TODO check if exec node provided register touches that was not used (no read and no update)
+	// check if the end state commitment mentioned in the chunk matches
+	// what the partial trie is providing.
+	if !bytes.Equal(expEndStateComm, endState) {
+		trace, err := fcv.traceTransactions(context, chunkDataPack, transactions)
+		if err != nil {
+			return nil, nil, fmt.Errorf("could not trace transactions of faulty chunk: %w", err)
+		}
+		return nil, chmodels.NewCFNonMatchingFinalState(expEndStateComm, endState, trace, chIndex, execResID), nil
+	}
+
+	// check the execution data of the chunk, if the result commits to the execution data of its block
+	if vc.ExecutionData != nil {
+		if chIndex >= uint64(len(vc.ExecutionData.ChunkExecutionDataIDs)) {
+			return nil, nil, fmt.Errorf("execution data lists no chunk at index %d", chIndex)
+		}
+		expected := vc.ExecutionData.ChunkExecutionDataIDs[chIndex]
+		computed := chunkExecutionData(vc, transactions, regs, values).ID()
+		if computed != expected {
+			return nil, chmodels.NewCFNonMatchingExecutionData(expected, computed, chIndex, execResID), nil
+		}
+	}
+
+	return chunkView.SpockSecret(), nil, nil
+}
+
+// chunkExecutionData returns the execution data of the verified chunk, as generated by execution nodes: the
+// collection of the chunk, the events of its transactions in execution order and its register updates. The
+// collection of the system chunk is nil.
+func chunkExecutionData(vc *verification.VerifiableChunkData,
+	transactions []*fvm.TransactionProcedure,
+	regs []flow.RegisterID,
+	values []flow.RegisterValue) *flow.ChunkExecutionData {
+
+	chunk := &flow.ChunkExecutionData{
+		BlockID: vc.Chunk.BlockID,
+		Index:   vc.Chunk.Index,
+		Events:  make([]flow.Event, 0),
+	}
+	if !vc.IsSystemChunk {
+		chunk.Collection = vc.Collection
+	}
+	for _, tx := range transactions {
+		chunk.Events = append(chunk.Events, tx.Events...)
+	}
+	chunk.TrieUpdate = make(flow.RegisterEntries, 0, len(regs))
+	for i, reg := range regs {
+		chunk.TrieUpdate = append(chunk.TrieUpdate, flow.RegisterEntry{Key: reg, Value: values[i]})
+	}
+
+	return chunk
+}
+
+// executeTransactions executes the transactions of the chunk on the registers of the chunk data pack, held by the
+// partial trie. It returns the view of the chunk, and the registers touched by the transactions that are missing from
+// the chunk data pack. If trace is not nil, the register updates of each transaction are appended to it.
+func (fcv *ChunkVerifier) executeTransactions(context fvm.Context,
+	chunkDataPack *flow.ChunkDataPack,
+	psmt *partial.Ledger,
+	transactions []*fvm.TransactionProcedure,
+	trace *[]flow.ChunkTraceStep) (*delta.View, map[flow.RegisterID]struct{}, error) {
+
 	// transactions in chunk can reuse the same cache, but its unknown
 	// if there were changes between chunks, so we always start with a new one
 	programs := programs.NewEmptyPrograms()
@@ -115,7 +245,7 @@ func (fcv *ChunkVerifier) verifyTransactionsInContext(context fvm.Context, chunk
 	// chunk view construction
 	// unknown register tracks access to parts of the partial trie which
 	// are not expanded and values are unknown.
-	unknownRegTouch := make(map[string]*ledger.Key)
+	unknownRegTouch := make(map[flow.RegisterID]struct{})
 	getRegister := func(owner, controller, key string) (flow.RegisterValue, error) {
 		// check if register has been provided in the chunk data pack
 		registerID := flow.NewRegisterID(owner, controller, key)
@@ -132,7 +262,7 @@ func (fcv *ChunkVerifier) verifyTransactionsInContext(context fvm.Context, chunk
 		if err != nil {
 			if errors.Is(err, ledger.ErrMissingKeys{}) {
 
-				unknownRegTouch[registerID.String()] = &registerKey
+				unknownRegTouch[registerID] = struct{}{}
 				return nil, fmt.Errorf("missing register")
 			}
 			// append to missing keys if error is ErrMissingKeys
@@ -158,6 +288,15 @@ func (fcv *ChunkVerifier) verifyTransactionsInContext(context fvm.Context, chunk
 			return nil, nil, fmt.Errorf("failed to execute transaction: %d (%w)", i, err)
 		}
 
+		if trace != nil {
+			regs, values := txView.(*delta.View).Delta().RegisterUpdates()
+			updates := make([]flow.RegisterEntry, len(regs))
+			for j, reg := range regs {
+				updates[j] = flow.RegisterEntry{Key: reg, Value: values[j]}
+			}
+			*trace = append(*trace, flow.ChunkTraceStep{TransactionIndex: tx.TxIndex, Updates: updates})
+		}
+
 		// always merge back the tx view (fvm is responsible for changes on tx errors)
 		err = chunkView.MergeView(txView)
 		if err != nil {
@@ -165,61 +304,42 @@ func (fcv *ChunkVerifier) verifyTransactionsInContext(context fvm.Context, chunk
 		}
 	}
 
-	// check read access to unknown registers
-	if len(unknownRegTouch) > 0 {
-		var missingRegs []string
-		for _, key := range unknownRegTouch {
-			missingRegs = append(missingRegs, key.String())
-		}
-		return nil, chmodels.NewCFMissingRegisterTouch(missingRegs, chIndex, execResID), nil
-	}
+	return chunkView, unknownRegTouch, nil
+}
 
-	// applying chunk delta (register updates at chunk level) to the partial trie
-	// this returns the expected end state commitment after updates and the list of
-	// register keys that was not provided by the chunk data package (err).
-	regs, values := chunkView.Delta().RegisterUpdates()
+// traceTransactions executes the transactions of a faulty chunk again, and returns the register updates of each
+// transaction, which are the evidence of the fault proof. The transactions are only traced once a fault is found, so
+// that verifying chunks that are not faulty does not pay for it. They are executed on a fresh partial trie, as
+// applying the register updates of the chunk changed the one they were verified with.
+func (fcv *ChunkVerifier) traceTransactions(context fvm.Context,
+	chunkDataPack *flow.ChunkDataPack,
+	transactions []*fvm.TransactionProcedure) ([]flow.ChunkTraceStep, error) {
 
-	update, err := ledger.NewUpdate(
-		chunkDataPack.StartState,
-		executionState.RegisterIDSToKeys(regs),
-		executionState.RegisterValuesToValues(values),
-	)
+	psmt, err := partial.NewLedger(chunkDataPack.Proof, chunkDataPack.StartState, partial.DefaultPathFinderVersion)
 	if err != nil {
-		return nil, nil, fmt.Errorf("cannot create ledger update: %w", err)
+		return nil, fmt.Errorf("could not construct partial trie: %w", err)
 	}
 
-	expEndStateComm, err := psmt.Set(update)
+	// the procedures hold the outcome of their previous execution
+	procedures := make([]*fvm.TransactionProcedure, 0, len(transactions))
+	for _, tx := range transactions {
+		procedures = append(procedures, fvm.Transaction(tx.Transaction, tx.TxIndex))
+	}
 
+	trace := make([]flow.ChunkTraceStep, 0, len(transactions))
+	_, _, err = fcv.executeTransactions(context, chunkDataPack, psmt, procedures, &trace)
 	if err != nil {
-		if errors.Is(err, ledger.ErrMissingKeys{}) {
-			keys := err.(*ledger.ErrMissingKeys).Keys
-			stringKeys := make([]string, len(keys))
-			for i, key := range keys {
-				stringKeys[i] = key.String()
-			}
-			return nil, chmodels.NewCFMissingRegisterTouch(stringKeys, chIndex, execResID), nil
-		}
-		return nil, chmodels.NewCFMissingRegisterTouch(nil, chIndex, execResID), nil
+		return nil, err
 	}
 
-	// TODO check if exec node provided register touches that was not used (no read and no update)
-	// check if the end state commitment mentioned in the chunk matches
-	// what the partial trie is providing.
-	if !bytes.Equal(expEndStateComm, endState) {
-		return nil, chmodels.NewCFNonMatchingFinalState(expEndStateComm, endState, chIndex, execResID), nil
-	}
-	return chunkView.SpockSecret(), nil, nil
+	return trace, nil
 }
 
-func (fcv *ChunkVerifier) verifyTransactions(chunk *flow.Chunk,
-	chunkDataPack *flow.ChunkDataPack,
-	result *flow.ExecutionResult,
-	header *flow.Header,
-	transactions []*fvm.TransactionProcedure,
-	endState flow.StateCommitment) ([]byte, chmodels.ChunkFault, error) {
+func (fcv *ChunkVerifier) verifyTransactions(vc *verification.VerifiableChunkData,
+	transactions []*fvm.TransactionProcedure) ([]byte, chmodels.ChunkFault, error) {
 
 	// build a block context
-	blockCtx := fvm.NewContextFromParent(fcv.vmCtx, fvm.WithBlockHeader(header))
+	blockCtx := fvm.NewContextFromParent(fcv.vmCtx, fvm.WithBlockHeader(vc.Header))
 
-	return fcv.verifyTransactionsInContext(blockCtx, chunk, chunkDataPack, result, transactions, endState)
+	return fcv.verifyTransactionsInContext(blockCtx, vc, transactions)
 }
@@ -100,8 +100,11 @@ func (s *ChunkVerifierTestSuite) TestWrongEndState() {
 	assert.Nil(s.T(), err)
 	assert.NotNil(s.T(), chFaults)
 	assert.Nil(s.T(), spockSecret)
-	_, ok := chFaults.(*chunksmodels.CFNonMatchingFinalState)
-	assert.True(s.T(), ok)
+	fault, ok := chFaults.(*chunksmodels.CFNonMatchingFinalState)
+	require.True(s.T(), ok)
+	// the register updates of the transactions are traced as the evidence of the fault
+	require.Len(s.T(), fault.Trace(), len(vch.Collection.Transactions))
+	assert.NotEmpty(s.T(), fault.Trace()[0].Updates)
 }
 
 // TestFailedTx tests verification behavior in case
@@ -151,6 +154,49 @@ func (s *ChunkVerifierTestSuite) TestEmptyCollection() {
 	assert.NotNil(s.T(), spockSecret)
 }
 
+// TestExecutionData tests that the execution data of the chunk is checked against the one listed in the execution
+// data committed in the result, with the transactions of the chunk indexed across the chunks of the block.
+func (s *ChunkVerifierTestSuite) TestExecutionData() {
+	vch := GetBaselineVerifiableChunk(s.T(), []byte{})
+	assert.NotNil(s.T(), vch)
+
+	// the chunk follows a chunk of three transactions
+	vch.Chunk.Index = 1
+	previous := &flow.Chunk{ChunkBody: flow.ChunkBody{NumberOfTransactions: 3}, Index: 0}
+	vch.Result.Chunks = flow.ChunkList{previous, vch.Chunk}
+
+	events := make([]flow.Event, 0, len(vch.Collection.Transactions))
+	for i, tx := range vch.Collection.Transactions {
+		events = append(events, flow.Event{Type: "flow.Test", TransactionID: tx.ID(), TransactionIndex: uint32(3 + i)})
+	}
+	chunkData := flow.ChunkExecutionData{
+		BlockID:    vch.Chunk.BlockID,
+		Index:      vch.Chunk.Index,
+		Collection: vch.Collection,
+		Events:     events,
+		TrieUpdate: flow.RegisterEntries{{Key: flow.NewRegisterID("05", "", ""), Value: []byte{'B'}}},
+	}
+	vch.ExecutionData = &flow.ExecutionData{
+		BlockID:               vch.Chunk.BlockID,
+		ChunkExecutionDataIDs: []flow.Identifier{unittest.IdentifierFixture(), chunkData.ID()},
+	}
+
+	spockSecret, chFaults, err := s.verifier.Verify(vch)
+	require.NoError(s.T(), err)
+	assert.Nil(s.T(), chFaults)
+	assert.NotNil(s.T(), spockSecret)
+
+	// the execution data listed for the chunk is not the one of its execution
+	vch.ExecutionData.ChunkExecutionDataIDs[1] = unittest.IdentifierFixture()
+	spockSecret, chFaults, err = s.verifier.Verify(vch)
+	require.NoError(s.T(), err)
+	assert.Nil(s.T(), spockSecret)
+	fault, ok := chFaults.(*chunksmodels.CFNonMatchingExecutionData)
+	require.True(s.T(), ok)
+	assert.Equal(s.T(), vch.ExecutionData.ChunkExecutionDataIDs[1], fault.Expected())
+	assert.Equal(s.T(), chunkData.ID(), fault.Computed())
+}
+
 // GetBaselineVerifiableChunk returns a verifiable chunk and sets the script
 // of a transaction in the middle of the collection to some value to signal the
 // mocked vm on what to return as tx exec outcome.
@@ -285,6 +331,7 @@ func (vm *vmMock) Run(ctx fvm.Context, proc fvm.Procedure, led state.View, progr
 		_, _ = led.Get("05", "", "")
 		_ = led.Set("05", "", "", []byte{'B'})
 		tx.Logs = []string{"log1", "log2"}
+		tx.Events = []flow.Event{{Type: "flow.Test", TransactionID: tx.ID, TransactionIndex: tx.TxIndex}}
 	}
 
 	return nil
@@ -7,6 +7,7 @@ import (
 const (
 	ConsumeProgressVerificationBlockHeight = "ConsumeProgressVerificationBlockHeight"
 	ConsumeProgressVerificationChunkIndex  = "ConsumeProgressVerificationChunkIndex"
+	ConsumeProgressAccessIndexedHeight     = "ConsumeProgressAccessIndexedHeight"
 )
 
 // JobID is a unique ID of the job.
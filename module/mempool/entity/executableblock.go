@@ -26,6 +26,13 @@ type ExecutableBlock struct {
 	Block               *flow.Block
 	CompleteCollections map[flow.Identifier]*CompleteCollection // key is the collection ID.
 	StartState          flow.StateCommitment
+
+	// with pipelined execution, a block can start executing before the state of its parent is committed, in which
+	// case StartState is not set, and AwaitStartState waits for the state commitment of the parent instead.
+	// The hooks are local to this node, and not encoded when the block is sent in a state delta.
+	AwaitStartState func() (flow.StateCommitment, error) `json:"-"`
+	// Executed, if set, is called once all transactions of the block are executed, before their state is committed.
+	Executed func() `json:"-"`
 }
 
 // BlocksByCollection represents a collection that the execution node
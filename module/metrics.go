@@ -195,6 +195,18 @@ type VerificationMetrics interface {
 	// OnResultApproval is called whenever a result approval for is emitted to consensus nodes.
 	// It increases the total number of result approvals.
 	OnResultApproval()
+	// OnChallenge is called whenever a challenge of a faulty chunk is emitted to consensus nodes.
+	// It increases the total number of challenges.
+	OnChallenge()
+	// OnVerifierQueueDepth is called whenever the number of verifiable chunks waiting to be verified by Verifier
+	// engine changes. It sets the depth of the queue to the input.
+	OnVerifierQueueDepth(depth int)
+	// OnChunkVerified is called whenever Verifier engine finishes verifying a chunk.
+	// It records the time spent verifying the chunk.
+	OnChunkVerified(duration time.Duration)
+	// OnChunkVerificationTimeout is called whenever the verification of a chunk by Verifier engine times out.
+	// It increments the total number of timed out chunk verifications.
+	OnChunkVerificationTimeout()
 
 	// OnFinalizedBlockReceived is called whenever a finalized block arrives at the assigner engine.
 	// It increments the total number of finalized blocks.
@@ -325,6 +337,13 @@ type ExecutionMetrics interface {
 
 	// ExecutionSync reports when the state syncing is triggered or stopped.
 	ExecutionSync(syncing bool)
+
+	// ExecutionStopHeight reports the height the execution stops after, 0 if not set, and whether the node crashes
+	// once stopped
+	ExecutionStopHeight(height uint64, crash bool)
+
+	// ExecutionStopped reports whether the execution is stopped at the stop height
+	ExecutionStopped(stopped bool)
 }
 
 type TransactionMetrics interface {
@@ -346,6 +365,21 @@ type TransactionMetrics interface {
 	TransactionSubmissionFailed()
 }
 
+type RateLimitMetrics interface {
+	// RequestRejected reports an Access API request that was rejected because the client exceeded its rate limit
+	// or quota for the given method. The reason is either metrics.RateLimitReasonRate or metrics.RateLimitReasonQuota.
+	RequestRejected(method string, reason string)
+}
+
+type ExecutionPruningMetrics interface {
+	// ExecutionDataPruned reports the height up to which the execution data of executed blocks was pruned
+	ExecutionDataPruned(height uint64)
+
+	// SpaceReclaimed reports the number of bytes freed by removing data of the given kind, which is either
+	// metrics.PrunedKindExecutionData or metrics.PrunedKindWALSegments.
+	SpaceReclaimed(kind string, bytes uint64)
+}
+
 type PingMetrics interface {
 	// NodeReachable tracks the round trip time in milliseconds taken to ping a node
 	// The nodeInfo provides additional information about the node such as the name of the node operator
@@ -9,8 +9,9 @@ import (
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/rs/zerolog"
 
-	"github.com/onflow/flow-go/engine/verification/match"
+	"github.com/onflow/flow-go/engine/verification/fetcher"
 	"github.com/onflow/flow-go/engine/verification/test"
+	"github.com/onflow/flow-go/model/flow"
 	"github.com/onflow/flow-go/model/messages"
 	vermodel "github.com/onflow/flow-go/model/verification"
 	"github.com/onflow/flow-go/module/buffer"
@@ -119,7 +120,7 @@ func demo() {
 		}
 
 		// creates pending chunks mempool, and registers size method of backend for metrics
-		pendingChunks := match.NewChunks(100)
+		pendingChunks := fetcher.NewChunks(100)
 		err = mc.Register(metrics.ResourcePendingChunk, pendingChunks.Size)
 		if err != nil {
 			panic(err)
@@ -205,13 +206,11 @@ func demo() {
 			})
 
 			tryRandomCall(func() {
-				pendingChunks.Add(&match.ChunkStatus{
-					Chunk:             receipt.ExecutionResult.Chunks[0],
-					ExecutionResultID: receipt.ExecutionResult.ID(),
-					ExecutorID:        receipt.ExecutorID,
-					LastAttempt:       time.Time{},
-					Attempt:           0,
-				})
+				pendingChunks.Add(vermodel.NewChunkStatus(receipt.ExecutionResult.Chunks[0],
+					receipt.ExecutionResult.ID(),
+					0,
+					[]flow.Identifier{receipt.ExecutorID},
+					nil))
 			})
 
 			tryRandomCall(func() {
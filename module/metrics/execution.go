@@ -48,6 +48,9 @@ type ExecutionCollector struct {
 	transactionInterpretTime         prometheus.Histogram
 	totalChunkDataPackRequests       prometheus.Counter
 	stateSyncActive                  prometheus.Gauge
+	stopHeight                       prometheus.Gauge
+	stopCrash                        prometheus.Gauge
+	stopped                          prometheus.Gauge
 	executionStateDiskUsage          prometheus.Gauge
 }
 
@@ -311,6 +314,27 @@ func NewExecutionCollector(tracer module.Tracer, registerer prometheus.Registere
 			Help:      "indicates if the state sync is active",
 		}),
 
+		stopHeight: promauto.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespaceExecution,
+			Subsystem: subsystemIngestion,
+			Name:      "stop_height",
+			Help:      "the height the execution stops after, 0 if not set",
+		}),
+
+		stopCrash: promauto.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespaceExecution,
+			Subsystem: subsystemIngestion,
+			Name:      "stop_crash",
+			Help:      "indicates if the node crashes once the execution is stopped at the stop height",
+		}),
+
+		stopped: promauto.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespaceExecution,
+			Subsystem: subsystemIngestion,
+			Name:      "stopped",
+			Help:      "indicates if the execution is stopped at the stop height",
+		}),
+
 		executionStateDiskUsage: promauto.NewGauge(prometheus.GaugeOpts{
 			Namespace: namespaceExecution,
 			Subsystem: subsystemMTrie,
@@ -481,6 +505,25 @@ func (ec *ExecutionCollector) ExecutionSync(syncing bool) {
 	ec.stateSyncActive.Set(float64(0))
 }
 
+// ExecutionStopHeight reports the height the execution stops after, and whether the node crashes once stopped
+func (ec *ExecutionCollector) ExecutionStopHeight(height uint64, crash bool) {
+	ec.stopHeight.Set(float64(height))
+	if crash {
+		ec.stopCrash.Set(float64(1))
+		return
+	}
+	ec.stopCrash.Set(float64(0))
+}
+
+// ExecutionStopped reports whether the execution is stopped at the stop height
+func (ec *ExecutionCollector) ExecutionStopped(stopped bool) {
+	if stopped {
+		ec.stopped.Set(float64(1))
+		return
+	}
+	ec.stopped.Set(float64(0))
+}
+
 func (ec *ExecutionCollector) DiskSize(bytes uint64) {
 	ec.executionStateDiskUsage.Set(float64(bytes))
 }
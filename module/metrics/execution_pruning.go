@@ -0,0 +1,42 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// The kinds of data removed by the execution pruner.
+const (
+	PrunedKindExecutionData = "execution_data"
+	PrunedKindWALSegments   = "wal_segments"
+)
+
+type ExecutionPruningCollector struct {
+	prunedHeight   prometheus.Gauge
+	reclaimedBytes *prometheus.CounterVec
+}
+
+func NewExecutionPruningCollector() *ExecutionPruningCollector {
+	return &ExecutionPruningCollector{
+		prunedHeight: promauto.NewGauge(prometheus.GaugeOpts{
+			Name:      "pruned_height",
+			Namespace: namespaceExecution,
+			Subsystem: subsystemPruner,
+			Help:      "the height up to which the execution data of executed blocks was pruned",
+		}),
+		reclaimedBytes: promauto.NewCounterVec(prometheus.CounterOpts{
+			Name:      "reclaimed_bytes_total",
+			Namespace: namespaceExecution,
+			Subsystem: subsystemPruner,
+			Help:      "the number of bytes freed by pruning execution data and WAL segments",
+		}, []string{LabelKind}),
+	}
+}
+
+func (pc *ExecutionPruningCollector) ExecutionDataPruned(height uint64) {
+	pc.prunedHeight.Set(float64(height))
+}
+
+func (pc *ExecutionPruningCollector) SpaceReclaimed(kind string, bytes uint64) {
+	pc.reclaimedBytes.WithLabelValues(kind).Add(float64(bytes))
+}
@@ -12,6 +12,9 @@ const (
 	LabelNodeRole    = "noderole"
 	LabelNodeInfo    = "nodeinfo"
 	LabelPriority    = "priority"
+	LabelMethod      = "method"
+	LabelReason      = "reason"
+	LabelKind        = "kind"
 )
 
 const (
@@ -61,7 +64,7 @@ const (
 	ResourcePendingReceiptIDsByBlock = "pending_receipt_ids_by_block" // verification node, finder engine
 	ResourcePendingResult            = "pending_result"               // verification node, match engine
 	ResourceChunkIDsByResult         = "chunk_ids_by_result"          // verification node, match engine
-	ResourcePendingChunk             = "pending_chunk"                // verification node, match engine
+	ResourcePendingChunk             = "pending_chunk"                // verification node, fetcher engine
 	ResourcePendingBlock             = "pending_block"                // verification node, match engine
 	ResourceCachedReceipt            = "cached_receipt"               // verification node, finder engine
 	ResourceCachedBlockID            = "cached_block_id"              // verification node, finder engine
@@ -71,12 +74,14 @@ const (
 	ResourceApprovalQueue            = "sealing_approval_queue"          // consensus node, sealing engine
 	ResourceReceiptQueue             = "sealing_receipt_queue"           // consensus node, sealing engine
 	ResourceApprovalResponseQueue    = "sealing_approval_response_queue" // consensus node, sealing engine
+	ResourceChallengeQueue           = "sealing_challenge_queue"         // consensus node, sealing engine
 	ResourceBlockProposalQueue       = "compliance_proposal_queue"       // consensus node, compliance engine
 	ResourceBlockVoteQueue           = "compliance_vote_queue"           // consensus node, compliance engine
 	ResourceChunkDataPack            = "chunk_data_pack"                 // execution node
 	ResourceEvents                   = "events"                          // execution node
 	ResourceServiceEvents            = "service_events"                  // execution node
 	ResourceTransactionResults       = "transaction_results"             // execution node
+	ResourceRegister                 = "register"                        // execution node
 )
 
 const (
@@ -85,6 +90,7 @@ const (
 	MessageBlockVote            = "vote"
 	MessageExecutionReceipt     = "receipt"
 	MessageResultApproval       = "approval"
+	MessageChallenge            = "challenge"
 	MessageSyncRequest          = "ping"
 	MessageSyncResponse         = "pong"
 	MessageRangeRequest         = "range"
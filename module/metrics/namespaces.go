@@ -31,6 +31,7 @@ const (
 const (
 	subsystemTransactionTiming     = "transaction_timing"
 	subsystemTransactionSubmission = "transaction_submission"
+	subsystemRateLimit             = "rate_limit"
 )
 
 // Collection subsystem
@@ -51,6 +52,7 @@ const (
 	subsystemIngestion    = "ingestion"
 	subsystemRuntime      = "runtime"
 	subsystemProvider     = "provider"
+	subsystemPruner       = "pruner"
 )
 
 // Verification Subsystems
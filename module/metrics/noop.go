@@ -81,6 +81,10 @@ func (nc *NoopCollector) OnVerifiableChunkReceived()
 func (nc *NoopCollector) OnChunkDataPackReceived()                                               {}
 func (nc *NoopCollector) OnChunkDataPackRequested()                                              {}
 func (nc *NoopCollector) OnResultApproval()                                                      {}
+func (nc *NoopCollector) OnChallenge()                                                           {}
+func (nc *NoopCollector) OnVerifierQueueDepth(depth int)                                         {}
+func (nc *NoopCollector) OnChunkVerified(duration time.Duration)                                 {}
+func (nc *NoopCollector) OnChunkVerificationTimeout()                                            {}
 func (nc *NoopCollector) OnAssignerProcessFinalizedBlock(height uint64)                          {}
 func (nc *NoopCollector) OnChunksAssigned(chunks int)                                            {}
 func (nc *NoopCollector) OnChunkProcessed()                                                      {}
@@ -118,6 +122,11 @@ func (nc *NoopCollector) TransactionFinalized(txID flow.Identifier, when time.Ti
 func (nc *NoopCollector) TransactionExecuted(txID flow.Identifier, when time.Time)               {}
 func (nc *NoopCollector) TransactionExpired(txID flow.Identifier)                                {}
 func (nc *NoopCollector) TransactionSubmissionFailed()                                           {}
+func (nc *NoopCollector) RequestRejected(method string, reason string)                           {}
+func (nc *NoopCollector) ExecutionDataPruned(height uint64)                                      {}
+func (nc *NoopCollector) SpaceReclaimed(kind string, bytes uint64)                               {}
 func (nc *NoopCollector) ChunkDataPackRequested()                                                {}
 func (nc *NoopCollector) ExecutionSync(syncing bool)                                             {}
+func (nc *NoopCollector) ExecutionStopHeight(height uint64, crash bool)                          {}
+func (nc *NoopCollector) ExecutionStopped(stopped bool)                                          {}
 func (nc *NoopCollector) DiskSize(uint64)                                                        {}
@@ -0,0 +1,31 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// The reasons for rejecting an Access API request.
+const (
+	RateLimitReasonRate  = "rate_limit"
+	RateLimitReasonQuota = "quota"
+)
+
+type RateLimitCollector struct {
+	rejectedRequests *prometheus.CounterVec
+}
+
+func NewRateLimitCollector() *RateLimitCollector {
+	return &RateLimitCollector{
+		rejectedRequests: promauto.NewCounterVec(prometheus.CounterOpts{
+			Name:      "rejected_requests_total",
+			Namespace: namespaceAccess,
+			Subsystem: subsystemRateLimit,
+			Help:      "the number of Access API requests rejected because the client exceeded its rate limit or quota",
+		}, []string{LabelMethod, LabelReason}),
+	}
+}
+
+func (rc *RateLimitCollector) RequestRejected(method string, reason string) {
+	rc.rejectedRequests.WithLabelValues(method, reason).Inc()
+}
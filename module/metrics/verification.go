@@ -1,6 +1,8 @@
 package metrics
 
 import (
+	"time"
+
 	"github.com/prometheus/client_golang/prometheus"
 
 	"github.com/onflow/flow-go/module"
@@ -25,8 +27,12 @@ type VerificationCollector struct {
 	requestedChunkDataPackTotal   prometheus.Counter // total number of chunk data packs requested by match engine
 
 	// Verifier Engine
-	receivedVerifiableChunksTotal prometheus.Counter // total verifiable chunks received by verifier engine
-	resultApprovalsTotal          prometheus.Counter // total result approvals sent by verifier engine
+	receivedVerifiableChunksTotal prometheus.Counter   // total verifiable chunks received by verifier engine
+	resultApprovalsTotal          prometheus.Counter   // total result approvals sent by verifier engine
+	challengesTotal               prometheus.Counter   // total challenges sent by verifier engine
+	verifierQueueDepth            prometheus.Gauge     // verifiable chunks waiting to be verified by verifier engine
+	chunkVerificationDuration     prometheus.Histogram // time spent verifying a chunk by verifier engine
+	chunkVerificationTimeouts     prometheus.Counter   // total chunk verifications timed out in verifier engine
 
 }
 
@@ -117,6 +123,35 @@ func NewVerificationCollector(tracer module.Tracer, registerer prometheus.Regist
 		Help:      "total number of emitted result approvals by verifier engine",
 	})
 
+	sentChallengesTotal := prometheus.NewCounter(prometheus.CounterOpts{
+		Name:      "challenges_total",
+		Namespace: namespaceVerification,
+		Subsystem: subsystemVerifierEngine,
+		Help:      "total number of emitted challenges of faulty chunks by verifier engine",
+	})
+
+	verifierQueueDepth := prometheus.NewGauge(prometheus.GaugeOpts{
+		Name:      "queue_depth",
+		Namespace: namespaceVerification,
+		Subsystem: subsystemVerifierEngine,
+		Help:      "number of verifiable chunks waiting to be verified by verifier engine",
+	})
+
+	chunkVerificationDuration := prometheus.NewHistogram(prometheus.HistogramOpts{
+		Name:      "chunk_verification_seconds",
+		Namespace: namespaceVerification,
+		Subsystem: subsystemVerifierEngine,
+		Help:      "time spent verifying a chunk by verifier engine",
+		Buckets:   []float64{0.1, 0.5, 1, 5, 10, 30, 60},
+	})
+
+	chunkVerificationTimeouts := prometheus.NewCounter(prometheus.CounterOpts{
+		Name:      "chunk_verification_timeouts_total",
+		Namespace: namespaceVerification,
+		Subsystem: subsystemVerifierEngine,
+		Help:      "total number of chunk verifications timed out in verifier engine",
+	})
+
 	// registers all metrics and panics if any fails.
 	registerer.MustRegister(
 		receivedFinalizedHeight,
@@ -129,7 +164,11 @@ func NewVerificationCollector(tracer module.Tracer, registerer prometheus.Regist
 		receivedChunkDataPackTotal,
 		requestedChunkDataPackTotal,
 		receivedVerifiableChunksTotal,
-		sentResultApprovalTotal)
+		sentResultApprovalTotal,
+		sentChallengesTotal,
+		verifierQueueDepth,
+		chunkVerificationDuration,
+		chunkVerificationTimeouts)
 
 	vc := &VerificationCollector{
 		tracer:                        tracer,
@@ -142,8 +181,12 @@ func NewVerificationCollector(tracer module.Tracer, registerer prometheus.Regist
 		sntVerifiableChunksTotal:      sentVerifiableChunksTotal,
 		receivedVerifiableChunksTotal: receivedVerifiableChunksTotal,
 		resultApprovalsTotal:          sentResultApprovalTotal,
+		challengesTotal:               sentChallengesTotal,
 		receivedChunkDataPackTotal:    receivedChunkDataPackTotal,
 		requestedChunkDataPackTotal:   requestedChunkDataPackTotal,
+		verifierQueueDepth:            verifierQueueDepth,
+		chunkVerificationDuration:     chunkVerificationDuration,
+		chunkVerificationTimeouts:     chunkVerificationTimeouts,
 	}
 
 	return vc
@@ -202,6 +245,30 @@ func (vc *VerificationCollector) OnResultApproval() {
 	vc.resultApprovalsTotal.Inc()
 }
 
+// OnChallenge is called whenever a challenge of a faulty chunk is emitted to consensus nodes.
+// It increases the total number of challenges.
+func (vc *VerificationCollector) OnChallenge() {
+	vc.challengesTotal.Inc()
+}
+
+// OnVerifierQueueDepth is called whenever the number of verifiable chunks waiting to be verified by Verifier
+// engine changes. It sets the depth of the queue to the input.
+func (vc *VerificationCollector) OnVerifierQueueDepth(depth int) {
+	vc.verifierQueueDepth.Set(float64(depth))
+}
+
+// OnChunkVerified is called whenever Verifier engine finishes verifying a chunk.
+// It records the time spent verifying the chunk.
+func (vc *VerificationCollector) OnChunkVerified(duration time.Duration) {
+	vc.chunkVerificationDuration.Observe(duration.Seconds())
+}
+
+// OnChunkVerificationTimeout is called whenever the verification of a chunk by Verifier engine times out.
+// It increments the total number of timed out chunk verifications.
+func (vc *VerificationCollector) OnChunkVerificationTimeout() {
+	vc.chunkVerificationTimeouts.Inc()
+}
+
 // OnFinalizedBlockReceived is called whenever a finalized block arrives at the assigner engine.
 // It sets updates the latest finalized height processed at assigner engine.
 //
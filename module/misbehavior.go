@@ -0,0 +1,14 @@
+package module
+
+import "github.com/onflow/flow-go/model/flow"
+
+// MisbehaviorReporter is used by engines to report nodes misbehaving towards them, e.g., responding to their requests
+// with invalid data, so that the misbehaving nodes can be penalized.
+type MisbehaviorReporter interface {
+	// ReportMisbehavior reports that the node with the given ID misbehaved, for the given reason.
+	ReportMisbehavior(nodeID flow.Identifier, reason error)
+
+	// IsPenalized returns whether the node with the given ID is penalized for its reported misbehavior, in which
+	// case engines should neither send requests to it nor accept its responses.
+	IsPenalized(nodeID flow.Identifier) bool
+}
@@ -0,0 +1,57 @@
+package misbehavior
+
+import (
+	"sync"
+
+	"github.com/rs/zerolog"
+
+	"github.com/onflow/flow-go/model/flow"
+)
+
+// Reporter reports the misbehavior of nodes by logging it, and penalizes the nodes reported at least `threshold`
+// times, so that the engines stop interacting with them. The penalties are kept in memory, they are lifted once this
+// node restarts, until misbehavior is penalized by the protocol.
+type Reporter struct {
+	log       zerolog.Logger
+	threshold uint
+	mu        sync.RWMutex
+	reports   map[flow.Identifier]uint // number of misbehaviors reported by node ID
+}
+
+// NewReporter returns a reporter penalizing the nodes once they are reported for misbehaving threshold times.
+func NewReporter(log zerolog.Logger, threshold uint) *Reporter {
+	return &Reporter{
+		log:       log.With().Str("module", "misbehavior").Logger(),
+		threshold: threshold,
+		reports:   make(map[flow.Identifier]uint),
+	}
+}
+
+// ReportMisbehavior reports that the node with the given ID misbehaved, for the given reason.
+func (r *Reporter) ReportMisbehavior(nodeID flow.Identifier, reason error) {
+	r.mu.Lock()
+	r.reports[nodeID]++
+	reports := r.reports[nodeID]
+	r.mu.Unlock()
+
+	r.log.Warn().
+		Hex("node_id", nodeID[:]).
+		Uint("reports", reports).
+		Err(reason).
+		Msg("node misbehavior reported")
+
+	if reports == r.threshold {
+		r.log.Error().
+			Hex("node_id", nodeID[:]).
+			Uint("reports", reports).
+			Msg("node penalized for misbehavior")
+	}
+}
+
+// IsPenalized returns whether the node with the given ID was reported for misbehaving at least threshold times.
+func (r *Reporter) IsPenalized(nodeID flow.Identifier) bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.reports[nodeID] >= r.threshold
+}
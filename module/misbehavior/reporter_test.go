@@ -0,0 +1,29 @@
+package misbehavior
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+// TestReporter_Penalized evaluates that a node is penalized once it is reported for misbehaving threshold times.
+func TestReporter_Penalized(t *testing.T) {
+	reporter := NewReporter(zerolog.Nop(), 2)
+	nodeID := unittest.IdentifierFixture()
+	otherID := unittest.IdentifierFixture()
+
+	assert.False(t, reporter.IsPenalized(nodeID))
+
+	reporter.ReportMisbehavior(nodeID, fmt.Errorf("invalid response"))
+	assert.False(t, reporter.IsPenalized(nodeID))
+
+	reporter.ReportMisbehavior(nodeID, fmt.Errorf("invalid response"))
+	assert.True(t, reporter.IsPenalized(nodeID))
+
+	// misbehavior of a node does not penalize other nodes
+	assert.False(t, reporter.IsPenalized(otherID))
+}
@@ -0,0 +1,27 @@
+// Code generated by mockery v1.0.0. DO NOT EDIT.
+
+package mock
+
+import (
+	flow "github.com/onflow/flow-go/model/flow"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// ChallengeValidator is an autogenerated mock type for the ChallengeValidator type
+type ChallengeValidator struct {
+	mock.Mock
+}
+
+// Validate provides a mock function with given fields: challenge, result
+func (_m *ChallengeValidator) Validate(challenge *flow.Challenge, result *flow.ExecutionResult) error {
+	ret := _m.Called(challenge, result)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*flow.Challenge, *flow.ExecutionResult) error); ok {
+		r0 = rf(challenge, result)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
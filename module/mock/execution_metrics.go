@@ -54,6 +54,16 @@ func (_m *ExecutionMetrics) ExecutionStorageStateCommitment(bytes int64) {
 	_m.Called(bytes)
 }
 
+// ExecutionStopHeight provides a mock function with given fields: height, crash
+func (_m *ExecutionMetrics) ExecutionStopHeight(height uint64, crash bool) {
+	_m.Called(height, crash)
+}
+
+// ExecutionStopped provides a mock function with given fields: stopped
+func (_m *ExecutionMetrics) ExecutionStopped(stopped bool) {
+	_m.Called(stopped)
+}
+
 // ExecutionSync provides a mock function with given fields: syncing
 func (_m *ExecutionMetrics) ExecutionSync(syncing bool) {
 	_m.Called(syncing)
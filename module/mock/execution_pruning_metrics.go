@@ -0,0 +1,20 @@
+// Code generated by mockery v1.0.0. DO NOT EDIT.
+
+package mock
+
+import mock "github.com/stretchr/testify/mock"
+
+// ExecutionPruningMetrics is an autogenerated mock type for the ExecutionPruningMetrics type
+type ExecutionPruningMetrics struct {
+	mock.Mock
+}
+
+// ExecutionDataPruned provides a mock function with given fields: height
+func (_m *ExecutionPruningMetrics) ExecutionDataPruned(height uint64) {
+	_m.Called(height)
+}
+
+// SpaceReclaimed provides a mock function with given fields: kind, bytes
+func (_m *ExecutionPruningMetrics) SpaceReclaimed(kind string, bytes uint64) {
+	_m.Called(kind, bytes)
+}
@@ -0,0 +1,32 @@
+// Code generated by mockery v1.0.0. DO NOT EDIT.
+
+package mock
+
+import (
+	flow "github.com/onflow/flow-go/model/flow"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MisbehaviorReporter is an autogenerated mock type for the MisbehaviorReporter type
+type MisbehaviorReporter struct {
+	mock.Mock
+}
+
+// IsPenalized provides a mock function with given fields: nodeID
+func (_m *MisbehaviorReporter) IsPenalized(nodeID flow.Identifier) bool {
+	ret := _m.Called(nodeID)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(flow.Identifier) bool); ok {
+		r0 = rf(nodeID)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
+// ReportMisbehavior provides a mock function with given fields: nodeID, reason
+func (_m *MisbehaviorReporter) ReportMisbehavior(nodeID flow.Identifier, reason error) {
+	_m.Called(nodeID, reason)
+}
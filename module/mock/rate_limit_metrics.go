@@ -0,0 +1,15 @@
+// Code generated by mockery v1.0.0. DO NOT EDIT.
+
+package mock
+
+import mock "github.com/stretchr/testify/mock"
+
+// RateLimitMetrics is an autogenerated mock type for the RateLimitMetrics type
+type RateLimitMetrics struct {
+	mock.Mock
+}
+
+// RequestRejected provides a mock function with given fields: method, reason
+func (_m *RateLimitMetrics) RequestRejected(method string, reason string) {
+	_m.Called(method, reason)
+}
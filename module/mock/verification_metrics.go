@@ -2,7 +2,11 @@
 
 package mock
 
-import mock "github.com/stretchr/testify/mock"
+import (
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
 
 // VerificationMetrics is an autogenerated mock type for the VerificationMetrics type
 type VerificationMetrics struct {
@@ -14,6 +18,11 @@ func (_m *VerificationMetrics) OnAssignerProcessFinalizedBlock(height uint64) {
 	_m.Called(height)
 }
 
+// OnChallenge provides a mock function with given fields:
+func (_m *VerificationMetrics) OnChallenge() {
+	_m.Called()
+}
+
 // OnChunkDataPackReceived provides a mock function with given fields:
 func (_m *VerificationMetrics) OnChunkDataPackReceived() {
 	_m.Called()
@@ -29,6 +38,16 @@ func (_m *VerificationMetrics) OnChunkProcessed() {
 	_m.Called()
 }
 
+// OnChunkVerificationTimeout provides a mock function with given fields:
+func (_m *VerificationMetrics) OnChunkVerificationTimeout() {
+	_m.Called()
+}
+
+// OnChunkVerified provides a mock function with given fields: duration
+func (_m *VerificationMetrics) OnChunkVerified(duration time.Duration) {
+	_m.Called(duration)
+}
+
 // OnChunksAssigned provides a mock function with given fields: chunks
 func (_m *VerificationMetrics) OnChunksAssigned(chunks int) {
 	_m.Called(chunks)
@@ -63,3 +82,8 @@ func (_m *VerificationMetrics) OnVerifiableChunkReceived() {
 func (_m *VerificationMetrics) OnVerifiableChunkSent() {
 	_m.Called()
 }
+
+// OnVerifierQueueDepth provides a mock function with given fields: depth
+func (_m *VerificationMetrics) OnVerifierQueueDepth(depth int) {
+	_m.Called(depth)
+}
@@ -142,6 +142,7 @@ const (
 	VERVerVerifyWithMetrics       SpanName = "ver.verify.verifyWithMetrics"
 	VERVerChunkVerify             SpanName = "ver.verify.ChunkVerifier.Verify"
 	VERVerGenerateResultApproval  SpanName = "ver.verify.GenerateResultApproval"
+	VERVerGenerateChallenge       SpanName = "ver.verify.GenerateChallenge"
 
 	// Flow Virtual Machine
 	FVMVerifyTransaction             SpanName = "fvm.verifyTransaction"
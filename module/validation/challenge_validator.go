@@ -0,0 +1,245 @@
+package validation
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/onflow/flow-go/engine"
+	"github.com/onflow/flow-go/engine/verification"
+	chmodels "github.com/onflow/flow-go/model/chunks"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module"
+	"github.com/onflow/flow-go/state/protocol"
+	"github.com/onflow/flow-go/storage"
+)
+
+// challengeValidator validates challenges raised by verification nodes. Besides the challenger and its signature,
+// it checks that the fault proof of the challenge is tied to data signed by an execution node that committed to the
+// challenged result, which is the receipt of the execution node. The fault itself is checked by verifying the chunk
+// again, with the collection and the chunk data pack of the fault proof. Verifying a chunk executes its transactions,
+// which consensus nodes only do for challenged chunks.
+type challengeValidator struct {
+	state            protocol.State
+	index            storage.Index
+	chunkVerifier    module.ChunkVerifier // verifies the challenged chunks again
+	verifier         module.Verifier      // verifies the signatures of challenges
+	receiptsVerifier module.Verifier      // verifies the signatures of execution receipts
+}
+
+func NewChallengeValidator(state protocol.State, index storage.Index, chunkVerifier module.ChunkVerifier, verifier module.Verifier, receiptsVerifier module.Verifier) *challengeValidator {
+	return &challengeValidator{
+		state:            state,
+		index:            index,
+		chunkVerifier:    chunkVerifier,
+		verifier:         verifier,
+		receiptsVerifier: receiptsVerifier,
+	}
+}
+
+func (v *challengeValidator) Validate(challenge *flow.Challenge, result *flow.ExecutionResult) error {
+	proof := challenge.Body.FaultProof
+
+	if result.ID() != proof.ExecutionResultID || result.BlockID != proof.BlockID {
+		return engine.NewInvalidInputErrorf("challenge is not for execution result %x", result.ID())
+	}
+
+	// check if we already have the block the challenge pertains to
+	head, err := v.state.AtBlockID(proof.BlockID).Head()
+	if err != nil {
+		if !errors.Is(err, storage.ErrNotFound) {
+			return fmt.Errorf("failed to retrieve header for block %x: %w", proof.BlockID, err)
+		}
+		return engine.NewUnverifiableInputError("no header for block: %v", proof.BlockID)
+	}
+
+	// drop challenge, if it is for block whose height is lower or equal to already sealed height
+	sealed, err := v.state.Sealed().Head()
+	if err != nil {
+		return fmt.Errorf("could not find sealed block: %w", err)
+	}
+	if sealed.Height >= head.Height {
+		return engine.NewOutdatedInputErrorf("result is for already sealed and finalized block height")
+	}
+
+	identity, err := identityForNode(v.state, head.ID(), challenge.Body.ChallengerID)
+	if err != nil {
+		return fmt.Errorf("failed to get identity for node %v: %w", challenge.Body.ChallengerID, err)
+	}
+
+	// Check if the challenger was a staked verifier at that block.
+	err = ensureStakedNodeWithRole(identity, flow.RoleVerification)
+	if err != nil {
+		return fmt.Errorf("challenge not from authorized verifier: %w", err)
+	}
+
+	err = v.verifySignature(challenge, identity)
+	if err != nil {
+		return fmt.Errorf("invalid challenge signature: %w", err)
+	}
+
+	err = v.verifyReceipt(&proof.Receipt, result, head.ID())
+	if err != nil {
+		return fmt.Errorf("invalid receipt of challenged result: %w", err)
+	}
+
+	chunk, ok := result.Chunks.ByIndex(proof.ChunkIndex)
+	if !ok {
+		return engine.NewInvalidInputErrorf("chunk index %d out of range for execution result %x", proof.ChunkIndex, result.ID())
+	}
+
+	err = v.verifyFaultProof(&proof, result, chunk, head)
+	if err != nil {
+		return fmt.Errorf("invalid fault proof: %w", err)
+	}
+
+	return nil
+}
+
+// verifyReceipt checks that the receipt commits to the challenged result, and is signed by an execution node staked
+// at the block of the result.
+func (v *challengeValidator) verifyReceipt(receipt *flow.ExecutionReceiptMeta, result *flow.ExecutionResult, blockID flow.Identifier) error {
+	if receipt.ResultID != result.ID() {
+		return engine.NewInvalidInputErrorf("receipt commits to result %x instead of %x", receipt.ResultID, result.ID())
+	}
+
+	identity, err := identityForNode(v.state, blockID, receipt.ExecutorID)
+	if err != nil {
+		return fmt.Errorf("failed to get identity for node %v: %w", receipt.ExecutorID, err)
+	}
+
+	err = ensureStakedNodeWithRole(identity, flow.RoleExecution)
+	if err != nil {
+		return fmt.Errorf("receipt not from authorized executor: %w", err)
+	}
+
+	id := receipt.ID()
+	valid, err := v.receiptsVerifier.Verify(id[:], receipt.ExecutorSignature, identity.StakingPubKey)
+	if err != nil {
+		return fmt.Errorf("failed to verify signature: %w", err)
+	}
+	if !valid {
+		return engine.NewInvalidInputErrorf("invalid signature for (%x)", identity.NodeID)
+	}
+
+	return nil
+}
+
+func (v *challengeValidator) verifySignature(challenge *flow.Challenge, nodeIdentity *flow.Identity) error {
+	id := challenge.Body.ID()
+	valid, err := v.verifier.Verify(id[:], challenge.VerifierSignature, nodeIdentity.StakingPubKey)
+	if err != nil {
+		return fmt.Errorf("failed to verify signature: %w", err)
+	}
+
+	if !valid {
+		return engine.NewInvalidInputErrorf("invalid signature for (%x)", nodeIdentity.NodeID)
+	}
+
+	return nil
+}
+
+// verifyFaultProof checks the evidence of the fault proof by verifying the chunk again, with the collection and the
+// chunk data pack of the proof. The chunk data pack is not signed by the execution node serving it, but the partial
+// trie of its registers is proven against the start state of the chunk, which is signed by the execution nodes in
+// their receipts, and the collection is checked against the guarantee of the block. The execution data is checked
+// against the execution result, if the result commits to it. The fault is accepted only if verifying the chunk finds a
+// fault of the same kind, with the same evidence.
+func (v *challengeValidator) verifyFaultProof(proof *flow.FaultProof, result *flow.ExecutionResult, chunk *flow.Chunk, header *flow.Header) error {
+	chunkDataPack := proof.ChunkDataPack
+	if chunkDataPack.ChunkID != chunk.ID() {
+		return engine.NewInvalidInputErrorf("chunk data pack is for chunk %x instead of %x", chunkDataPack.ChunkID, chunk.ID())
+	}
+	if !bytes.Equal(chunkDataPack.StartState, chunk.StartState) {
+		return engine.NewInvalidInputErrorf("chunk data pack starts at state %x instead of %x", chunkDataPack.StartState, chunk.StartState)
+	}
+
+	index, err := v.index.ByBlockID(result.BlockID)
+	if err != nil {
+		return fmt.Errorf("could not find payload index for executed block %v: %w", result.BlockID, err)
+	}
+
+	// the system chunk follows the chunks of the collections of the block, it has no collection
+	isSystemChunk := chunk.CollectionIndex == uint(len(index.CollectionIDs))
+	if isSystemChunk {
+		if len(proof.Collection.Transactions) > 0 {
+			return engine.NewInvalidInputErrorf("collection of system chunk is not empty")
+		}
+	} else {
+		if chunk.CollectionIndex > uint(len(index.CollectionIDs)) {
+			return engine.NewInvalidInputErrorf("collection index %d out of range for block %x", chunk.CollectionIndex, result.BlockID)
+		}
+		collectionID := proof.Collection.ID()
+		if collectionID != index.CollectionIDs[chunk.CollectionIndex] {
+			return engine.NewInvalidInputErrorf("collection %x is not guaranteed at index %d of block %x", collectionID, chunk.CollectionIndex, result.BlockID)
+		}
+		if collectionID != chunkDataPack.CollectionID {
+			return engine.NewInvalidInputErrorf("chunk data pack is for collection %x instead of %x", chunkDataPack.CollectionID, collectionID)
+		}
+	}
+
+	vc := &verification.VerifiableChunkData{
+		IsSystemChunk: isSystemChunk,
+		Chunk:         chunk,
+		Header:        header,
+		Result:        result,
+		Collection:    &proof.Collection,
+		ChunkDataPack: &chunkDataPack,
+		EndState:      chunk.EndState,
+	}
+	if result.ExecutionDataID != flow.ZeroID {
+		executionData := proof.ExecutionData
+		err = executionData.CheckCommitted(result)
+		if err != nil {
+			return engine.NewInvalidInputErrorf("invalid execution data: %w", err)
+		}
+		vc.ExecutionData = &executionData
+	}
+	var fault chmodels.ChunkFault
+	if isSystemChunk {
+		_, fault, err = v.chunkVerifier.SystemChunkVerify(vc)
+	} else {
+		_, fault, err = v.chunkVerifier.Verify(vc)
+	}
+	if err != nil {
+		return fmt.Errorf("could not verify challenged chunk: %w", err)
+	}
+	if fault == nil {
+		return engine.NewInvalidInputErrorf("challenged chunk is not faulty")
+	}
+
+	switch fault := fault.(type) {
+	case *chmodels.CFInvalidVerifiableChunk:
+		if proof.Kind != flow.ChunkFaultInvalidChunkDataPack {
+			return engine.NewInvalidInputErrorf("challenged chunk has fault %s instead of %s", flow.ChunkFaultInvalidChunkDataPack.String(), proof.Kind.String())
+		}
+	case *chmodels.CFMissingRegisterTouch:
+		if proof.Kind != flow.ChunkFaultMissingRegisterTouch {
+			return engine.NewInvalidInputErrorf("challenged chunk has fault %s instead of %s", flow.ChunkFaultMissingRegisterTouch.String(), proof.Kind.String())
+		}
+		if flow.MakeID(proof.MissingRegisters) != flow.MakeID(fault.Registers()) {
+			return engine.NewInvalidInputErrorf("missing registers do not match the registers missing from the chunk data pack")
+		}
+	case *chmodels.CFNonMatchingFinalState:
+		if proof.Kind != flow.ChunkFaultNonMatchingFinalState {
+			return engine.NewInvalidInputErrorf("challenged chunk has fault %s instead of %s", flow.ChunkFaultNonMatchingFinalState.String(), proof.Kind.String())
+		}
+		if !bytes.Equal(proof.ComputedEndState, fault.Expected()) {
+			return engine.NewInvalidInputErrorf("computed end state %x does not match end state %x of the chunk data pack", proof.ComputedEndState, fault.Expected())
+		}
+		if flow.MakeID(proof.Trace) != flow.MakeID(fault.Trace()) {
+			return engine.NewInvalidInputErrorf("trace does not match the execution of the chunk")
+		}
+	case *chmodels.CFNonMatchingExecutionData:
+		if proof.Kind != flow.ChunkFaultNonMatchingExecutionData {
+			return engine.NewInvalidInputErrorf("challenged chunk has fault %s instead of %s", flow.ChunkFaultNonMatchingExecutionData.String(), proof.Kind.String())
+		}
+		if proof.ComputedChunkExecutionDataID != fault.Computed() {
+			return engine.NewInvalidInputErrorf("computed chunk execution data %x does not match execution data %x of the chunk", proof.ComputedChunkExecutionDataID, fault.Computed())
+		}
+	default:
+		return fmt.Errorf("unknown type of chunk fault (type: %T): %v", fault, fault.String())
+	}
+
+	return nil
+}
@@ -0,0 +1,397 @@
+package validation
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/onflow/flow-go/engine"
+	"github.com/onflow/flow-go/engine/verification"
+	chmodels "github.com/onflow/flow-go/model/chunks"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module"
+	mock2 "github.com/onflow/flow-go/module/mock"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+func TestChallengeValidator(t *testing.T) {
+	suite.Run(t, new(ChallengeValidationSuite))
+}
+
+type ChallengeValidationSuite struct {
+	unittest.BaseChainSuite
+
+	challengeValidator module.ChallengeValidator
+	chunkVerifier      *mock2.ChunkVerifier
+	verifier           *mock2.Verifier
+	receiptsVerifier   *mock2.Verifier
+}
+
+func (cs *ChallengeValidationSuite) SetupTest() {
+	cs.SetupChain()
+	cs.chunkVerifier = &mock2.ChunkVerifier{}
+	cs.verifier = &mock2.Verifier{}
+	cs.receiptsVerifier = &mock2.Verifier{}
+	cs.challengeValidator = NewChallengeValidator(cs.State, cs.IndexDB, cs.chunkVerifier, cs.verifier, cs.receiptsVerifier)
+}
+
+// challengeFixture returns an execution result for the given block, and a challenge of its first chunk raised by the
+// given verifier. The block guarantees the collection of the chunk, and the challenge holds the collection and the
+// chunk data pack of the chunk.
+func (cs *ChallengeValidationSuite) challengeFixture(block *flow.Block, challengerID flow.Identifier) (*flow.ExecutionResult, *flow.Challenge) {
+	collection := unittest.CollectionFixture(1)
+	block.Payload.Guarantees = []*flow.CollectionGuarantee{
+		unittest.CollectionGuaranteeFixture(unittest.WithCollection(&collection)),
+	}
+	result := unittest.ExecutionResultFixture(unittest.WithBlock(block))
+	chunk := result.Chunks[0]
+
+	challenge := unittest.ChallengeFixture(result, chunk.Index, challengerID)
+	challenge.Body.Receipt.ExecutorID = cs.ExeID
+	challenge.Body.Collection = collection
+	challenge.Body.ChunkDataPack = flow.ChunkDataPack{
+		ChunkID:      chunk.ID(),
+		StartState:   chunk.StartState,
+		Proof:        []byte{'p'},
+		CollectionID: collection.ID(),
+	}
+	return result, challenge
+}
+
+// mockVerify mocks verifying the challenged chunk again, which finds the given fault.
+func (cs *ChallengeValidationSuite) mockVerify(challenge *flow.Challenge, result *flow.ExecutionResult, fault chmodels.ChunkFault) {
+	chunk := result.Chunks[challenge.Body.ChunkIndex]
+	cs.chunkVerifier.On("Verify", mock.MatchedBy(func(vc *verification.VerifiableChunkData) bool {
+		return !vc.IsSystemChunk &&
+			vc.Chunk == chunk &&
+			vc.Result == result &&
+			vc.Header.ID() == result.BlockID &&
+			vc.Collection.ID() == challenge.Body.Collection.ID() &&
+			vc.ChunkDataPack.ChunkID == chunk.ID() &&
+			bytes.Equal(vc.EndState, chunk.EndState)
+	})).Return(nil, fault, nil).Once()
+}
+
+// finalStateFault returns the fault found when verifying the challenged chunk again, matching the evidence of the
+// challenge.
+func finalStateFault(challenge *flow.Challenge, result *flow.ExecutionResult) chmodels.ChunkFault {
+	chunk := result.Chunks[challenge.Body.ChunkIndex]
+	return chmodels.NewCFNonMatchingFinalState(challenge.Body.ComputedEndState, chunk.EndState, challenge.Body.Trace,
+		chunk.Index, result.ID())
+}
+
+// commitExecutionData makes the result commit to execution data of its block, which the challenge holds, and returns
+// the execution data.
+func commitExecutionData(result *flow.ExecutionResult, challenge *flow.Challenge) *flow.ExecutionData {
+	executionData := &flow.ExecutionData{
+		BlockID:               result.BlockID,
+		ChunkExecutionDataIDs: unittest.IdentifierListFixture(len(result.Chunks)),
+	}
+	result.ExecutionDataID = executionData.ID()
+	challenge.Body.ExecutionResultID = result.ID()
+	challenge.Body.Receipt.ResultID = result.ID()
+	challenge.Body.ExecutionData = *executionData
+	return executionData
+}
+
+// executionDataFault returns the fault of a chunk whose execution data does not match the one committed in the result,
+// matching the evidence of the challenge.
+func executionDataFault(challenge *flow.Challenge, result *flow.ExecutionResult) chmodels.ChunkFault {
+	index := challenge.Body.ChunkIndex
+	return chmodels.NewCFNonMatchingExecutionData(challenge.Body.ExecutionData.ChunkExecutionDataIDs[index],
+		challenge.Body.ComputedChunkExecutionDataID, index, result.ID())
+}
+
+// mockSignature mocks the verification of the signature of the challenge.
+func (cs *ChallengeValidationSuite) mockSignature(challenge *flow.Challenge, valid bool) {
+	challengeID := challenge.Body.ID()
+	cs.verifier.On("Verify",
+		challengeID[:],
+		challenge.VerifierSignature,
+		cs.Identities[challenge.Body.ChallengerID].StakingPubKey).Return(valid, nil).Once()
+}
+
+// mockReceiptSignature mocks the verification of the signature of the receipt of the challenge.
+func (cs *ChallengeValidationSuite) mockReceiptSignature(challenge *flow.Challenge, valid bool) {
+	receipt := challenge.Body.Receipt
+	receiptID := receipt.ID()
+	cs.receiptsVerifier.On("Verify",
+		receiptID[:],
+		receipt.ExecutorSignature,
+		cs.Identities[receipt.ExecutorID].StakingPubKey).Return(valid, nil).Once()
+}
+
+// try to submit a valid challenge
+func (cs *ChallengeValidationSuite) TestChallengeValid() {
+	result, challenge := cs.challengeFixture(&cs.UnfinalizedBlock, cs.VerID)
+	challenge.Body.Trace = []flow.ChunkTraceStep{{TransactionIndex: 0, Updates: []flow.RegisterEntry{{
+		Key:   flow.NewRegisterID("owner", "controller", "key"),
+		Value: []byte{'F'},
+	}}}}
+	cs.mockSignature(challenge, true)
+	cs.mockReceiptSignature(challenge, true)
+	cs.mockVerify(challenge, result, finalStateFault(challenge, result))
+
+	err := cs.challengeValidator.Validate(challenge, result)
+	cs.Require().NoError(err, "should process a valid challenge")
+	cs.chunkVerifier.AssertExpectations(cs.T())
+}
+
+// try to submit a valid challenge of the system chunk
+func (cs *ChallengeValidationSuite) TestChallengeValidSystemChunk() {
+	result, challenge := cs.challengeFixture(&cs.UnfinalizedBlock, cs.VerID)
+	chunk := result.Chunks[1]
+	challenge.Body.ChunkIndex = chunk.Index
+	challenge.Body.Kind = flow.ChunkFaultMissingRegisterTouch
+	challenge.Body.MissingRegisters = []flow.RegisterID{flow.NewRegisterID("owner", "controller", "key")}
+	challenge.Body.Collection = flow.Collection{}
+	challenge.Body.ChunkDataPack.ChunkID = chunk.ID()
+	challenge.Body.ChunkDataPack.StartState = chunk.StartState
+	challenge.Body.ChunkDataPack.CollectionID = flow.ZeroID
+	cs.mockSignature(challenge, true)
+	cs.mockReceiptSignature(challenge, true)
+	cs.chunkVerifier.On("SystemChunkVerify", mock.MatchedBy(func(vc *verification.VerifiableChunkData) bool {
+		return vc.IsSystemChunk && vc.Chunk == chunk
+	})).Return(nil, chmodels.NewCFMissingRegisterTouch(challenge.Body.MissingRegisters, chunk.Index, result.ID()), nil).Once()
+
+	err := cs.challengeValidator.Validate(challenge, result)
+	cs.Require().NoError(err, "should process a valid challenge of the system chunk")
+	cs.chunkVerifier.AssertExpectations(cs.T())
+}
+
+// try to submit a challenge with invalid signature
+func (cs *ChallengeValidationSuite) TestChallengeInvalidSignature() {
+	result, challenge := cs.challengeFixture(&cs.UnfinalizedBlock, cs.VerID)
+	cs.mockSignature(challenge, false)
+
+	err := cs.challengeValidator.Validate(challenge, result)
+	cs.Require().Error(err, "should fail with invalid signature")
+	cs.Require().True(engine.IsInvalidInputError(err))
+}
+
+// try to submit a challenge for another execution result
+func (cs *ChallengeValidationSuite) TestChallengeOtherResult() {
+	_, challenge := cs.challengeFixture(&cs.UnfinalizedBlock, cs.VerID)
+	result := unittest.ExecutionResultFixture(unittest.WithBlock(&cs.UnfinalizedBlock))
+
+	err := cs.challengeValidator.Validate(challenge, result)
+	cs.Require().Error(err, "should reject challenge for another result")
+	cs.Require().True(engine.IsInvalidInputError(err))
+}
+
+// try to submit a challenge for an unknown block
+func (cs *ChallengeValidationSuite) TestChallengeUnknownBlock() {
+	block := unittest.BlockFixture()
+	result, challenge := cs.challengeFixture(&block, cs.VerID)
+
+	err := cs.challengeValidator.Validate(challenge, result)
+	cs.Require().Error(err, "should mark challenge as unverifiable")
+	cs.Require().True(engine.IsUnverifiableInputError(err))
+}
+
+// try to submit a challenge from a consensus node
+func (cs *ChallengeValidationSuite) TestChallengeInvalidRole() {
+	result, challenge := cs.challengeFixture(&cs.UnfinalizedBlock, cs.ConID)
+
+	err := cs.challengeValidator.Validate(challenge, result)
+	cs.Require().Error(err, "should reject challenge from wrong challenger role")
+	cs.Require().True(engine.IsInvalidInputError(err))
+}
+
+// try to submit a challenge for a sealed result
+func (cs *ChallengeValidationSuite) TestChallengeSealedResult() {
+	result, challenge := cs.challengeFixture(&cs.LatestSealedBlock, cs.VerID)
+
+	err := cs.challengeValidator.Validate(challenge, result)
+	cs.Require().Error(err, "should ignore challenge for sealed result")
+	cs.Require().True(engine.IsOutdatedInputError(err))
+}
+
+// try to submit a challenge whose receipt commits to another result
+func (cs *ChallengeValidationSuite) TestChallengeReceiptOtherResult() {
+	result, challenge := cs.challengeFixture(&cs.UnfinalizedBlock, cs.VerID)
+	challenge.Body.Receipt.ResultID = unittest.IdentifierFixture()
+	cs.mockSignature(challenge, true)
+
+	err := cs.challengeValidator.Validate(challenge, result)
+	cs.Require().Error(err, "should reject receipt of another result")
+	cs.Require().True(engine.IsInvalidInputError(err))
+}
+
+// try to submit a challenge whose receipt is not signed by its executor
+func (cs *ChallengeValidationSuite) TestChallengeReceiptInvalidSignature() {
+	result, challenge := cs.challengeFixture(&cs.UnfinalizedBlock, cs.VerID)
+	cs.mockSignature(challenge, true)
+	cs.mockReceiptSignature(challenge, false)
+
+	err := cs.challengeValidator.Validate(challenge, result)
+	cs.Require().Error(err, "should reject receipt with invalid signature")
+	cs.Require().True(engine.IsInvalidInputError(err))
+}
+
+// try to submit a challenge whose receipt is not from an execution node
+func (cs *ChallengeValidationSuite) TestChallengeReceiptInvalidRole() {
+	result, challenge := cs.challengeFixture(&cs.UnfinalizedBlock, cs.VerID)
+	challenge.Body.Receipt.ExecutorID = cs.VerID
+	cs.mockSignature(challenge, true)
+
+	err := cs.challengeValidator.Validate(challenge, result)
+	cs.Require().Error(err, "should reject receipt from wrong executor role")
+	cs.Require().True(engine.IsInvalidInputError(err))
+}
+
+// try to submit a challenge whose chunk data pack is not the one of the challenged chunk
+func (cs *ChallengeValidationSuite) TestChallengeChunkDataPackOtherChunk() {
+	result, challenge := cs.challengeFixture(&cs.UnfinalizedBlock, cs.VerID)
+	challenge.Body.ChunkDataPack.ChunkID = unittest.IdentifierFixture()
+	cs.mockSignature(challenge, true)
+	cs.mockReceiptSignature(challenge, true)
+
+	err := cs.challengeValidator.Validate(challenge, result)
+	cs.Require().Error(err, "should reject chunk data pack of another chunk")
+	cs.Require().True(engine.IsInvalidInputError(err))
+	cs.chunkVerifier.AssertNotCalled(cs.T(), "Verify", mock.Anything)
+}
+
+// try to submit a challenge whose chunk data pack does not start at the start state of the challenged chunk
+func (cs *ChallengeValidationSuite) TestChallengeChunkDataPackOtherStartState() {
+	result, challenge := cs.challengeFixture(&cs.UnfinalizedBlock, cs.VerID)
+	challenge.Body.ChunkDataPack.StartState = unittest.StateCommitmentFixture()
+	cs.mockSignature(challenge, true)
+	cs.mockReceiptSignature(challenge, true)
+
+	err := cs.challengeValidator.Validate(challenge, result)
+	cs.Require().Error(err, "should reject chunk data pack starting at another state")
+	cs.Require().True(engine.IsInvalidInputError(err))
+	cs.chunkVerifier.AssertNotCalled(cs.T(), "Verify", mock.Anything)
+}
+
+// try to submit a challenge whose collection is not guaranteed by the block
+func (cs *ChallengeValidationSuite) TestChallengeCollectionNotGuaranteed() {
+	result, challenge := cs.challengeFixture(&cs.UnfinalizedBlock, cs.VerID)
+	challenge.Body.Collection = unittest.CollectionFixture(1)
+	challenge.Body.ChunkDataPack.CollectionID = challenge.Body.Collection.ID()
+	cs.mockSignature(challenge, true)
+	cs.mockReceiptSignature(challenge, true)
+
+	err := cs.challengeValidator.Validate(challenge, result)
+	cs.Require().Error(err, "should reject collection not guaranteed by the block")
+	cs.Require().True(engine.IsInvalidInputError(err))
+	cs.chunkVerifier.AssertNotCalled(cs.T(), "Verify", mock.Anything)
+}
+
+// try to submit a valid challenge of the execution data of a chunk
+func (cs *ChallengeValidationSuite) TestChallengeValidExecutionData() {
+	result, challenge := cs.challengeFixture(&cs.UnfinalizedBlock, cs.VerID)
+	executionData := commitExecutionData(result, challenge)
+	challenge.Body.Kind = flow.ChunkFaultNonMatchingExecutionData
+	challenge.Body.ComputedChunkExecutionDataID = unittest.IdentifierFixture()
+	cs.mockSignature(challenge, true)
+	cs.mockReceiptSignature(challenge, true)
+	cs.chunkVerifier.On("Verify", mock.MatchedBy(func(vc *verification.VerifiableChunkData) bool {
+		return vc.Result == result && vc.ExecutionData != nil && vc.ExecutionData.ID() == executionData.ID()
+	})).Return(nil, executionDataFault(challenge, result), nil).Once()
+
+	err := cs.challengeValidator.Validate(challenge, result)
+	cs.Require().NoError(err, "should process a valid challenge of the execution data")
+	cs.chunkVerifier.AssertExpectations(cs.T())
+}
+
+// try to submit a challenge without the execution data committed in the result
+func (cs *ChallengeValidationSuite) TestChallengeExecutionDataNotCommitted() {
+	result, challenge := cs.challengeFixture(&cs.UnfinalizedBlock, cs.VerID)
+	commitExecutionData(result, challenge)
+	challenge.Body.ExecutionData = flow.ExecutionData{}
+	cs.mockSignature(challenge, true)
+	cs.mockReceiptSignature(challenge, true)
+
+	err := cs.challengeValidator.Validate(challenge, result)
+	cs.Require().Error(err, "should reject execution data not committed in the result")
+	cs.Require().True(engine.IsInvalidInputError(err))
+	cs.chunkVerifier.AssertNotCalled(cs.T(), "Verify", mock.Anything)
+}
+
+// try to submit a challenge of a chunk that is not faulty
+func (cs *ChallengeValidationSuite) TestChallengeChunkNotFaulty() {
+	result, challenge := cs.challengeFixture(&cs.UnfinalizedBlock, cs.VerID)
+	cs.mockSignature(challenge, true)
+	cs.mockReceiptSignature(challenge, true)
+	cs.mockVerify(challenge, result, nil)
+
+	err := cs.challengeValidator.Validate(challenge, result)
+	cs.Require().Error(err, "should reject challenge of chunk that is not faulty")
+	cs.Require().True(engine.IsInvalidInputError(err))
+}
+
+// try to submit a challenge of another fault than the one of the chunk
+func (cs *ChallengeValidationSuite) TestChallengeOtherFault() {
+	result, challenge := cs.challengeFixture(&cs.UnfinalizedBlock, cs.VerID)
+	cs.mockSignature(challenge, true)
+	cs.mockReceiptSignature(challenge, true)
+	cs.mockVerify(challenge, result, chmodels.NewCFInvalidVerifiableChunk("test", errors.New("test"), 0, result.ID()))
+
+	err := cs.challengeValidator.Validate(challenge, result)
+	cs.Require().Error(err, "should reject challenge of another fault")
+	cs.Require().True(engine.IsInvalidInputError(err))
+}
+
+// try to submit challenges whose evidence does not match the fault of the chunk
+func (cs *ChallengeValidationSuite) TestChallengeInvalidEvidence() {
+	cs.Run("computed end state", func() {
+		result, challenge := cs.challengeFixture(&cs.UnfinalizedBlock, cs.VerID)
+		fault := finalStateFault(challenge, result)
+		challenge.Body.ComputedEndState = unittest.StateCommitmentFixture()
+		cs.mockSignature(challenge, true)
+		cs.mockReceiptSignature(challenge, true)
+		cs.mockVerify(challenge, result, fault)
+
+		err := cs.challengeValidator.Validate(challenge, result)
+		cs.Require().Error(err, "should reject computed end state not matching the chunk data pack")
+		cs.Require().True(engine.IsInvalidInputError(err))
+	})
+
+	cs.Run("trace", func() {
+		result, challenge := cs.challengeFixture(&cs.UnfinalizedBlock, cs.VerID)
+		fault := finalStateFault(challenge, result)
+		challenge.Body.Trace = []flow.ChunkTraceStep{{TransactionIndex: 0}}
+		cs.mockSignature(challenge, true)
+		cs.mockReceiptSignature(challenge, true)
+		cs.mockVerify(challenge, result, fault)
+
+		err := cs.challengeValidator.Validate(challenge, result)
+		cs.Require().Error(err, "should reject trace not matching the execution of the chunk")
+		cs.Require().True(engine.IsInvalidInputError(err))
+	})
+
+	cs.Run("computed chunk execution data", func() {
+		result, challenge := cs.challengeFixture(&cs.UnfinalizedBlock, cs.VerID)
+		commitExecutionData(result, challenge)
+		challenge.Body.Kind = flow.ChunkFaultNonMatchingExecutionData
+		fault := executionDataFault(challenge, result)
+		challenge.Body.ComputedChunkExecutionDataID = unittest.IdentifierFixture()
+		cs.mockSignature(challenge, true)
+		cs.mockReceiptSignature(challenge, true)
+		cs.mockVerify(challenge, result, fault)
+
+		err := cs.challengeValidator.Validate(challenge, result)
+		cs.Require().Error(err, "should reject computed chunk execution data not matching the execution of the chunk")
+		cs.Require().True(engine.IsInvalidInputError(err))
+	})
+
+	cs.Run("missing registers", func() {
+		result, challenge := cs.challengeFixture(&cs.UnfinalizedBlock, cs.VerID)
+		challenge.Body.Kind = flow.ChunkFaultMissingRegisterTouch
+		challenge.Body.MissingRegisters = []flow.RegisterID{flow.NewRegisterID("owner", "controller", "key")}
+		cs.mockSignature(challenge, true)
+		cs.mockReceiptSignature(challenge, true)
+		missing := []flow.RegisterID{flow.NewRegisterID("owner", "controller", "other")}
+		cs.mockVerify(challenge, result, chmodels.NewCFMissingRegisterTouch(missing, 0, result.ID()))
+
+		err := cs.challengeValidator.Validate(challenge, result)
+		cs.Require().Error(err, "should reject registers not missing from the chunk data pack")
+		cs.Require().True(engine.IsInvalidInputError(err))
+	})
+}
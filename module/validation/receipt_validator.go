@@ -60,6 +60,13 @@ func (v *receiptValidator) verifyChunksFormat(result *flow.ExecutionResult) erro
 		if chunk.BlockID != result.BlockID {
 			return engine.NewInvalidInputErrorf("invalid blockID, expected %v got %v", result.BlockID, chunk.BlockID)
 		}
+
+		// each chunk continues at the end state of its previous chunk, the start state of the first chunk is
+		// checked against the previous result in resultChainCheck
+		if index > 0 && !bytes.Equal(result.Chunks[index-1].EndState, chunk.StartState) {
+			return engine.NewInvalidInputErrorf("invalid StartState of chunk %d, expected %x got %x",
+				index, result.Chunks[index-1].EndState, chunk.StartState)
+		}
 	}
 
 	// we create one chunk per collection, plus the
@@ -196,6 +196,25 @@ func (s *ReceiptValidationSuite) TestReceiptInvalidCollectionIndex() {
 	s.Assert().True(engine.IsInvalidInputError(err))
 }
 
+// TestReceiptInvalidChunkStartState tests that we reject receipt with a chunk not starting at the end state of its
+// previous chunk
+func (s *ReceiptValidationSuite) TestReceiptInvalidChunkStartState() {
+	valSubgrph := s.ValidSubgraphFixture()
+	valSubgrph.Result.Chunks[1].StartState = unittest.StateCommitmentFixture()
+	receipt := unittest.ExecutionReceiptFixture(unittest.WithExecutorID(s.ExeID),
+		unittest.WithResult(valSubgrph.Result))
+	s.AddSubgraphFixtureToMempools(valSubgrph)
+
+	s.verifier.On("Verify",
+		mock.Anything,
+		mock.Anything,
+		mock.Anything).Return(true, nil).Maybe()
+
+	err := s.receiptValidator.Validate(receipt)
+	s.Require().Error(err, "should reject chunk not starting at the end state of its previous chunk")
+	s.Assert().True(engine.IsInvalidInputError(err))
+}
+
 // TestReceiptNoPreviousResult tests that we reject receipt with missing previous result
 func (s *ReceiptValidationSuite) TestReceiptNoPreviousResult() {
 	valSubgrph := s.ValidSubgraphFixture()
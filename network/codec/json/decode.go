@@ -59,12 +59,26 @@ func decode(env Envelope) (interface{}, error) {
 		v = &flow.ExecutionReceipt{}
 	case CodeResultApproval:
 		v = &flow.ResultApproval{}
+	case CodeChallenge:
+		v = &flow.Challenge{}
 
 	// execution state synchronization
 	case CodeExecutionStateSyncRequest:
 		v = &messages.ExecutionStateSyncRequest{}
 	case CodeExecutionStateDelta:
 		v = &messages.ExecutionStateDelta{}
+	case CodeExecutionStateStatusRequest:
+		v = &messages.ExecutionStateStatusRequest{}
+	case CodeExecutionStateStatusResponse:
+		v = &messages.ExecutionStateStatusResponse{}
+	case CodeExecutionStateManifestRequest:
+		v = &messages.ExecutionStateManifestRequest{}
+	case CodeExecutionStateManifestResponse:
+		v = &messages.ExecutionStateManifestResponse{}
+	case CodeExecutionStateSubtrieRequest:
+		v = &messages.ExecutionStateSubtrieRequest{}
+	case CodeExecutionStateSubtrieResponse:
+		v = &messages.ExecutionStateSubtrieResponse{}
 
 	// data exchange for execution of blocks
 	case CodeChunkDataRequest:
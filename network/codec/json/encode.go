@@ -58,12 +58,26 @@ func encode(v interface{}) (*Envelope, error) {
 		code = CodeExecutionReceipt
 	case *flow.ResultApproval:
 		code = CodeResultApproval
+	case *flow.Challenge:
+		code = CodeChallenge
 
 	// execution state synchronization
 	case *messages.ExecutionStateSyncRequest:
 		code = CodeExecutionStateSyncRequest
 	case *messages.ExecutionStateDelta:
 		code = CodeExecutionStateDelta
+	case *messages.ExecutionStateStatusRequest:
+		code = CodeExecutionStateStatusRequest
+	case *messages.ExecutionStateStatusResponse:
+		code = CodeExecutionStateStatusResponse
+	case *messages.ExecutionStateManifestRequest:
+		code = CodeExecutionStateManifestRequest
+	case *messages.ExecutionStateManifestResponse:
+		code = CodeExecutionStateManifestResponse
+	case *messages.ExecutionStateSubtrieRequest:
+		code = CodeExecutionStateSubtrieRequest
+	case *messages.ExecutionStateSubtrieResponse:
+		code = CodeExecutionStateSubtrieResponse
 
 	// data exchange for execution of blocks
 	case *messages.ChunkDataRequest:
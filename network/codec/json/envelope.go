@@ -6,6 +6,7 @@ import (
 	"encoding/json"
 )
 
+// message codes are part of the wire format, new codes must be appended to the end of the block
 const (
 
 	// consensus
@@ -51,6 +52,17 @@ const (
 
 	// testing
 	CodeEcho
+
+	// execution state synchronization from sealed state
+	CodeExecutionStateManifestRequest
+	CodeExecutionStateManifestResponse
+	CodeExecutionStateSubtrieRequest
+	CodeExecutionStateSubtrieResponse
+	CodeExecutionStateStatusRequest
+	CodeExecutionStateStatusResponse
+
+	// fault proofs of faulty chunks
+	CodeChallenge
 )
 
 // Envelope is a wrapper to convey type information with JSON encoding without
@@ -0,0 +1,53 @@
+package badger
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/dgraph-io/badger/v2"
+
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/storage"
+	"github.com/onflow/flow-go/storage/badger/operation"
+)
+
+// Challenges stores the valid challenges of execution results a consensus node received, so that challenged
+// results remain withheld from sealing after a restart.
+type Challenges struct {
+	db *badger.DB
+}
+
+func NewChallenges(db *badger.DB) *Challenges {
+	return &Challenges{
+		db: db,
+	}
+}
+
+// Store stores a challenge, keyed by the ID of the challenged result and the challenge ID. Storing a challenge that
+// is already stored is a no-op.
+func (c *Challenges) Store(challenge *flow.Challenge) error {
+	err := operation.RetryOnConflict(c.db.Update, operation.InsertChallenge(challenge))
+	if err != nil && !errors.Is(err, storage.ErrAlreadyExists) {
+		return fmt.Errorf("could not store challenge: %w", err)
+	}
+	return nil
+}
+
+// All returns all stored challenges.
+func (c *Challenges) All() ([]*flow.Challenge, error) {
+	var challenges []*flow.Challenge
+	err := c.db.View(operation.FindChallenges(&challenges))
+	if err != nil {
+		return nil, fmt.Errorf("could not retrieve challenges: %w", err)
+	}
+	return challenges, nil
+}
+
+// RemoveByResultID removes the challenges of the result with the given ID, if any.
+func (c *Challenges) RemoveByResultID(resultID flow.Identifier) error {
+	err := operation.RetryOnConflict(c.db.Update, operation.RemoveChallengesByResultID(resultID))
+	if err != nil {
+		return fmt.Errorf("could not remove challenges of result %v: %w", resultID, err)
+	}
+	return nil
+}
@@ -0,0 +1,54 @@
+package badger_test
+
+import (
+	"testing"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/utils/unittest"
+
+	bstorage "github.com/onflow/flow-go/storage/badger"
+)
+
+// TestChallenges tests storing, listing and removing the challenges of execution results.
+func TestChallenges(t *testing.T) {
+	unittest.RunWithBadgerDB(t, func(db *badger.DB) {
+		store := bstorage.NewChallenges(db)
+
+		all, err := store.All()
+		require.NoError(t, err)
+		require.Empty(t, all)
+
+		challenged := unittest.ExecutionResultFixture()
+		first := unittest.ChallengeFixture(challenged, 0, unittest.IdentifierFixture())
+		second := unittest.ChallengeFixture(challenged, 1, unittest.IdentifierFixture())
+		other := unittest.ChallengeFixture(unittest.ExecutionResultFixture(), 0, unittest.IdentifierFixture())
+
+		require.NoError(t, store.Store(first))
+		require.NoError(t, store.Store(second))
+		require.NoError(t, store.Store(other))
+
+		// storing a challenge again is a no-op
+		require.NoError(t, store.Store(first))
+
+		all, err = store.All()
+		require.NoError(t, err)
+		require.Len(t, all, 3)
+		assert.ElementsMatch(t,
+			[]flow.Identifier{first.ID(), second.ID(), other.ID()},
+			[]flow.Identifier{all[0].ID(), all[1].ID(), all[2].ID()})
+
+		require.NoError(t, store.RemoveByResultID(challenged.ID()))
+
+		all, err = store.All()
+		require.NoError(t, err)
+		require.Len(t, all, 1)
+		assert.Equal(t, other.ID(), all[0].ID())
+
+		// removing the challenges of a result without challenges is a no-op
+		require.NoError(t, store.RemoveByResultID(challenged.ID()))
+	})
+}
@@ -0,0 +1,122 @@
+package badger
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/dgraph-io/badger/v2"
+
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/model/verification"
+	"github.com/onflow/flow-go/storage"
+	"github.com/onflow/flow-go/storage/badger/operation"
+)
+
+// ChunkStatuses stores the status of the chunks assigned to a verification node, so that its verification pipeline
+// resumes from where it left off after a restart.
+type ChunkStatuses struct {
+	db *badger.DB
+}
+
+func NewChunkStatuses(db *badger.DB) *ChunkStatuses {
+	return &ChunkStatuses{
+		db: db,
+	}
+}
+
+// Store stores the status of a chunk, keyed by chunk ID. It replaces the status already stored for the chunk.
+func (c *ChunkStatuses) Store(status *verification.ChunkStatus) error {
+	return operation.RetryOnConflict(c.db.Update, func(tx *badger.Txn) error {
+		err := operation.UpdateChunkStatus(status)(tx)
+		if err == nil {
+			return nil
+		}
+		if !errors.Is(err, storage.ErrNotFound) {
+			return fmt.Errorf("could not update chunk status: %w", err)
+		}
+
+		err = operation.InsertChunkStatus(status)(tx)
+		if err != nil {
+			return fmt.Errorf("could not insert chunk status: %w", err)
+		}
+		err = operation.IndexChunkStatusByHeight(status.Height, status.ID())(tx)
+		if err != nil {
+			return fmt.Errorf("could not index chunk status by height: %w", err)
+		}
+		return nil
+	})
+}
+
+// ByChunkID returns the status of the chunk with the given ID.
+func (c *ChunkStatuses) ByChunkID(chunkID flow.Identifier) (*verification.ChunkStatus, error) {
+	var status verification.ChunkStatus
+	err := c.db.View(operation.RetrieveChunkStatus(chunkID, &status))
+	if err != nil {
+		return nil, fmt.Errorf("could not retrieve chunk status: %w", err)
+	}
+	return &status, nil
+}
+
+// Remove removes the status of the chunk with the given ID, if it exists.
+func (c *ChunkStatuses) Remove(chunkID flow.Identifier) error {
+	err := operation.RetryOnConflict(c.db.Update, removeChunkStatus(chunkID))
+	if err != nil && !errors.Is(err, storage.ErrNotFound) {
+		return fmt.Errorf("could not remove chunk status: %w", err)
+	}
+	return nil
+}
+
+// RemoveByHeightRange removes the statuses of the chunks of the blocks at heights between the given heights,
+// both included, and returns the number of removed statuses. Only the statuses in the range are read, through
+// their height index.
+func (c *ChunkStatuses) RemoveByHeightRange(from uint64, to uint64) (uint, error) {
+	if from > to {
+		return 0, nil
+	}
+
+	var chunkIDs []flow.Identifier
+	err := c.db.View(operation.LookupChunkStatusesByHeightRange(from, to, &chunkIDs))
+	if err != nil {
+		return 0, fmt.Errorf("could not look up chunk statuses by height: %w", err)
+	}
+
+	removed := uint(0)
+	for _, chunkID := range chunkIDs {
+		err := operation.RetryOnConflict(c.db.Update, removeChunkStatus(chunkID))
+		if errors.Is(err, storage.ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			return removed, fmt.Errorf("could not remove status of chunk %v: %w", chunkID, err)
+		}
+		removed++
+	}
+
+	return removed, nil
+}
+
+// removeChunkStatus removes the status of the chunk with the given ID along with its height index.
+func removeChunkStatus(chunkID flow.Identifier) func(*badger.Txn) error {
+	return func(tx *badger.Txn) error {
+		var status verification.ChunkStatus
+		err := operation.RetrieveChunkStatus(chunkID, &status)(tx)
+		if err != nil {
+			return fmt.Errorf("could not retrieve chunk status: %w", err)
+		}
+		err = operation.RemoveChunkStatusHeightIndex(status.Height, chunkID)(tx)
+		if err != nil {
+			return fmt.Errorf("could not remove height index of chunk status: %w", err)
+		}
+		return operation.RemoveChunkStatus(chunkID)(tx)
+	}
+}
+
+// All returns the statuses of all chunks.
+func (c *ChunkStatuses) All() ([]*verification.ChunkStatus, error) {
+	var statuses []*verification.ChunkStatus
+	err := c.db.View(operation.FindChunkStatuses(&statuses))
+	if err != nil {
+		return nil, fmt.Errorf("could not retrieve chunk statuses: %w", err)
+	}
+	return statuses, nil
+}
@@ -0,0 +1,120 @@
+package badger_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/model/verification"
+	"github.com/onflow/flow-go/storage"
+	"github.com/onflow/flow-go/utils/unittest"
+
+	bstorage "github.com/onflow/flow-go/storage/badger"
+)
+
+// TestChunkStatuses tests storing, updating, listing and removing the statuses of chunks.
+func TestChunkStatuses(t *testing.T) {
+	unittest.RunWithBadgerDB(t, func(db *badger.DB) {
+		store := bstorage.NewChunkStatuses(db)
+
+		requested := verification.NewChunkStatus(
+			unittest.ChunkFixture(unittest.IdentifierFixture(), 0),
+			unittest.IdentifierFixture(),
+			10,
+			[]flow.Identifier{unittest.IdentifierFixture()},
+			[]flow.Identifier{})
+		received := verification.NewChunkStatus(
+			unittest.ChunkFixture(unittest.IdentifierFixture(), 1),
+			unittest.IdentifierFixture(),
+			11,
+			[]flow.Identifier{},
+			[]flow.Identifier{})
+
+		_, err := store.ByChunkID(requested.ID())
+		require.True(t, errors.Is(err, storage.ErrNotFound))
+
+		require.NoError(t, store.Store(requested))
+		require.NoError(t, store.Store(received))
+
+		// storing the status of a chunk again replaces it
+		collection := unittest.CollectionFixture(1)
+		received.ChunkDataPack = unittest.ChunkDataPackFixture(received.ID())
+		received.Collection = &collection
+		received.Attempt = 2
+		require.NoError(t, store.Store(received))
+
+		actual, err := store.ByChunkID(received.ID())
+		require.NoError(t, err)
+		assert.True(t, actual.Received())
+		assert.Equal(t, 2, actual.Attempt)
+		assert.Equal(t, received.ChunkDataPack.ID(), actual.ChunkDataPack.ID())
+		assert.Equal(t, collection.ID(), actual.Collection.ID())
+		assert.Equal(t, received.Locator().ID(), actual.Locator().ID())
+
+		all, err := store.All()
+		require.NoError(t, err)
+		require.Len(t, all, 2)
+		assert.ElementsMatch(t, []flow.Identifier{requested.ID(), received.ID()}, []flow.Identifier{all[0].ID(), all[1].ID()})
+
+		require.NoError(t, store.Remove(requested.ID()))
+		_, err = store.ByChunkID(requested.ID())
+		require.True(t, errors.Is(err, storage.ErrNotFound))
+
+		// removing a status that does not exist is a no-op
+		require.NoError(t, store.Remove(requested.ID()))
+
+		all, err = store.All()
+		require.NoError(t, err)
+		require.Len(t, all, 1)
+		assert.Equal(t, received.ID(), all[0].ID())
+	})
+}
+
+// TestChunkStatusesRemoveByHeightRange tests that removing the statuses of chunks by height range only removes the
+// statuses of the chunks of the blocks in the range.
+func TestChunkStatusesRemoveByHeightRange(t *testing.T) {
+	unittest.RunWithBadgerDB(t, func(db *badger.DB) {
+		store := bstorage.NewChunkStatuses(db)
+
+		statuses := make([]*verification.ChunkStatus, 0)
+		for height := uint64(10); height < 15; height++ {
+			for index := uint(0); index < 2; index++ {
+				status := verification.NewChunkStatus(
+					unittest.ChunkFixture(unittest.IdentifierFixture(), index),
+					unittest.IdentifierFixture(),
+					height,
+					[]flow.Identifier{},
+					[]flow.Identifier{})
+				require.NoError(t, store.Store(status))
+				// storing the status again does not index it twice
+				require.NoError(t, store.Store(status))
+				statuses = append(statuses, status)
+			}
+		}
+
+		removed, err := store.RemoveByHeightRange(0, 11)
+		require.NoError(t, err)
+		assert.Equal(t, uint(4), removed)
+
+		// removing a chunk status removes its height index
+		require.NoError(t, store.Remove(statuses[4].ID()))
+
+		removed, err = store.RemoveByHeightRange(12, 13)
+		require.NoError(t, err)
+		assert.Equal(t, uint(3), removed)
+
+		// an empty range removes nothing
+		removed, err = store.RemoveByHeightRange(13, 12)
+		require.NoError(t, err)
+		assert.Equal(t, uint(0), removed)
+
+		all, err := store.All()
+		require.NoError(t, err)
+		require.Len(t, all, 2)
+		assert.ElementsMatch(t, []flow.Identifier{statuses[8].ID(), statuses[9].ID()}, []flow.Identifier{all[0].ID(), all[1].ID()})
+	})
+}
@@ -0,0 +1,49 @@
+package badger
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/dgraph-io/badger/v2"
+
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/model/verification"
+	"github.com/onflow/flow-go/storage"
+	"github.com/onflow/flow-go/storage/badger/operation"
+)
+
+// ChunkVerdicts stores the verdicts of a verification node on the chunks it verified.
+type ChunkVerdicts struct {
+	db *badger.DB
+}
+
+func NewChunkVerdicts(db *badger.DB) *ChunkVerdicts {
+	return &ChunkVerdicts{
+		db: db,
+	}
+}
+
+// Store stores the verdict on a chunk, keyed by the ID of its execution result and its index. It replaces the verdict
+// already stored for the chunk.
+func (c *ChunkVerdicts) Store(verdict *verification.ChunkVerdict) error {
+	return operation.RetryOnConflict(c.db.Update, func(tx *badger.Txn) error {
+		err := operation.UpdateChunkVerdict(verdict)(tx)
+		if errors.Is(err, storage.ErrNotFound) {
+			err = operation.InsertChunkVerdict(verdict)(tx)
+		}
+		if err != nil {
+			return fmt.Errorf("could not store chunk verdict: %w", err)
+		}
+		return nil
+	})
+}
+
+// ByChunk returns the verdict on the chunk with the given index of the execution result with the given ID.
+func (c *ChunkVerdicts) ByChunk(resultID flow.Identifier, chunkIndex uint64) (*verification.ChunkVerdict, error) {
+	var verdict verification.ChunkVerdict
+	err := c.db.View(operation.RetrieveChunkVerdict(resultID, chunkIndex, &verdict))
+	if err != nil {
+		return nil, fmt.Errorf("could not retrieve chunk verdict: %w", err)
+	}
+	return &verdict, nil
+}
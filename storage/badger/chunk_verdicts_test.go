@@ -0,0 +1,58 @@
+package badger_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/model/verification"
+	"github.com/onflow/flow-go/storage"
+	"github.com/onflow/flow-go/utils/unittest"
+
+	bstorage "github.com/onflow/flow-go/storage/badger"
+)
+
+// TestChunkVerdicts tests storing, replacing and retrieving the verdicts on chunks.
+func TestChunkVerdicts(t *testing.T) {
+	unittest.RunWithBadgerDB(t, func(db *badger.DB) {
+		store := bstorage.NewChunkVerdicts(db)
+		resultID := unittest.IdentifierFixture()
+
+		_, err := store.ByChunk(resultID, 0)
+		require.True(t, errors.Is(err, storage.ErrNotFound))
+
+		approved := &verification.ChunkVerdict{
+			ExecutionResultID: resultID,
+			ChunkIndex:        0,
+			Verdict:           verification.VerdictApproved,
+			ApprovalID:        unittest.IdentifierFixture(),
+		}
+		challenged := &verification.ChunkVerdict{
+			ExecutionResultID: resultID,
+			ChunkIndex:        1,
+			Verdict:           verification.VerdictChallenged,
+			ChallengeID:       unittest.IdentifierFixture(),
+			Fault:             "non-matching final state",
+		}
+		require.NoError(t, store.Store(approved))
+		require.NoError(t, store.Store(challenged))
+
+		actual, err := store.ByChunk(resultID, 0)
+		require.NoError(t, err)
+		assert.Equal(t, approved, actual)
+
+		actual, err = store.ByChunk(resultID, 1)
+		require.NoError(t, err)
+		assert.Equal(t, challenged, actual)
+
+		// storing the verdict on a chunk again replaces it
+		approved.ApprovalID = unittest.IdentifierFixture()
+		require.NoError(t, store.Store(approved))
+		actual, err = store.ByChunk(resultID, 0)
+		require.NoError(t, err)
+		assert.Equal(t, approved, actual)
+	})
+}
@@ -0,0 +1,81 @@
+package badger
+
+import (
+	"fmt"
+
+	"github.com/dgraph-io/badger/v2"
+
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/storage/badger/operation"
+)
+
+// ExecutionDatas is the blob store of the execution data published for blocks, content-addressed by the ID of the
+// execution data.
+type ExecutionDatas struct {
+	db *badger.DB
+}
+
+func NewExecutionDatas(db *badger.DB) *ExecutionDatas {
+	return &ExecutionDatas{
+		db: db,
+	}
+}
+
+// Store stores the execution data of a block and of its chunks, and indexes it by the block ID. Blocks executed again
+// produce the same execution data, so storing it again is a no-op.
+func (e *ExecutionDatas) Store(data *flow.ExecutionData, chunks []*flow.ChunkExecutionData) error {
+	return operation.RetryOnConflict(e.db.Update, func(tx *badger.Txn) error {
+		for _, chunk := range chunks {
+			err := operation.SkipDuplicates(operation.InsertChunkExecutionData(chunk))(tx)
+			if err != nil {
+				return fmt.Errorf("could not insert chunk execution data: %w", err)
+			}
+		}
+		err := operation.SkipDuplicates(operation.InsertExecutionData(data))(tx)
+		if err != nil {
+			return fmt.Errorf("could not insert execution data: %w", err)
+		}
+		err = operation.SkipDuplicates(operation.IndexExecutionData(data.BlockID, data.ID()))(tx)
+		if err != nil {
+			return fmt.Errorf("could not index execution data: %w", err)
+		}
+		return nil
+	})
+}
+
+// ByID returns the execution data with the given ID
+func (e *ExecutionDatas) ByID(executionDataID flow.Identifier) (*flow.ExecutionData, error) {
+	var data flow.ExecutionData
+	err := e.db.View(operation.RetrieveExecutionData(executionDataID, &data))
+	if err != nil {
+		return nil, handleError(err, flow.ExecutionData{})
+	}
+	return &data, nil
+}
+
+// ByBlockID returns the execution data of the given block
+func (e *ExecutionDatas) ByBlockID(blockID flow.Identifier) (*flow.ExecutionData, error) {
+	var data flow.ExecutionData
+	err := e.db.View(func(tx *badger.Txn) error {
+		var executionDataID flow.Identifier
+		err := operation.LookupExecutionData(blockID, &executionDataID)(tx)
+		if err != nil {
+			return fmt.Errorf("could not look up execution data: %w", err)
+		}
+		return operation.RetrieveExecutionData(executionDataID, &data)(tx)
+	})
+	if err != nil {
+		return nil, handleError(err, flow.ExecutionData{})
+	}
+	return &data, nil
+}
+
+// ChunkByID returns the chunk execution data with the given ID
+func (e *ExecutionDatas) ChunkByID(chunkExecutionDataID flow.Identifier) (*flow.ChunkExecutionData, error) {
+	var chunk flow.ChunkExecutionData
+	err := e.db.View(operation.RetrieveChunkExecutionData(chunkExecutionDataID, &chunk))
+	if err != nil {
+		return nil, handleError(err, flow.ChunkExecutionData{})
+	}
+	return &chunk, nil
+}
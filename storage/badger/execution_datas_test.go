@@ -0,0 +1,77 @@
+package badger_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/storage"
+	"github.com/onflow/flow-go/utils/unittest"
+
+	bstorage "github.com/onflow/flow-go/storage/badger"
+)
+
+func TestStoringExecutionData(t *testing.T) {
+	unittest.RunWithBadgerDB(t, func(db *badger.DB) {
+		store := bstorage.NewExecutionDatas(db)
+
+		blockID := unittest.IdentifierFixture()
+		collection := unittest.CollectionFixture(2)
+		txID := collection.Transactions[0].ID()
+		chunks := []*flow.ChunkExecutionData{
+			{
+				BlockID:    blockID,
+				Collection: &collection,
+				Events:     []flow.Event{unittest.EventFixture(flow.EventAccountCreated, 0, 0, txID)},
+				TrieUpdate: flow.RegisterEntries{{Key: flow.NewRegisterID("owner", "", "key"), Value: []byte{1}}},
+			},
+			{
+				BlockID:    blockID,
+				Index:      1,
+				Events:     []flow.Event{},
+				TrieUpdate: flow.RegisterEntries{},
+			},
+		}
+		data := &flow.ExecutionData{
+			BlockID:               blockID,
+			ChunkExecutionDataIDs: []flow.Identifier{chunks[0].ID(), chunks[1].ID()},
+		}
+
+		err := store.Store(data, chunks)
+		require.NoError(t, err)
+
+		// storing the same execution data again is a no-op
+		err = store.Store(data, chunks)
+		require.NoError(t, err)
+
+		byID, err := store.ByID(data.ID())
+		require.NoError(t, err)
+		assert.Equal(t, data.ID(), byID.ID())
+
+		for i, chunkExecutionDataID := range byID.ChunkExecutionDataIDs {
+			chunk, err := store.ChunkByID(chunkExecutionDataID)
+			require.NoError(t, err)
+			assert.Equal(t, chunks[i].ID(), chunk.ID())
+		}
+		chunk, err := store.ChunkByID(chunks[0].ID())
+		require.NoError(t, err)
+		assert.Equal(t, collection.ID(), chunk.Collection.ID())
+
+		byBlockID, err := store.ByBlockID(blockID)
+		require.NoError(t, err)
+		assert.Equal(t, data.ID(), byBlockID.ID())
+
+		_, err = store.ByID(unittest.IdentifierFixture())
+		assert.True(t, errors.Is(err, storage.ErrNotFound))
+
+		_, err = store.ByBlockID(unittest.IdentifierFixture())
+		assert.True(t, errors.Is(err, storage.ErrNotFound))
+
+		_, err = store.ChunkByID(unittest.IdentifierFixture())
+		assert.True(t, errors.Is(err, storage.ErrNotFound))
+	})
+}
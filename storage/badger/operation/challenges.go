@@ -0,0 +1,36 @@
+package operation
+
+import (
+	"github.com/dgraph-io/badger/v2"
+
+	"github.com/onflow/flow-go/model/flow"
+)
+
+// InsertChallenge inserts a challenge keyed by the ID of the challenged result and the challenge ID.
+func InsertChallenge(challenge *flow.Challenge) func(*badger.Txn) error {
+	return insert(makePrefix(codeChallenge, challenge.Body.ExecutionResultID, challenge.ID()), challenge)
+}
+
+// FindChallenges iterates through all challenges, and adds them to the found slice.
+func FindChallenges(found *[]*flow.Challenge) func(*badger.Txn) error {
+	return traverse(makePrefix(codeChallenge), func() (checkFunc, createFunc, handleFunc) {
+		check := func(key []byte) bool {
+			return true
+		}
+		var val flow.Challenge
+		create := func() interface{} {
+			return &val
+		}
+		handle := func() error {
+			*found = append(*found, &val)
+			return nil
+		}
+		return check, create, handle
+	})
+}
+
+// RemoveChallengesByResultID removes the challenges of the result with the given ID.
+func RemoveChallengesByResultID(resultID flow.Identifier) func(*badger.Txn) error {
+	var removed uint64
+	return removeByPrefix(makePrefix(codeChallenge, resultID), &removed)
+}
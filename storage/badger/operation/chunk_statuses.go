@@ -0,0 +1,62 @@
+package operation
+
+import (
+	"github.com/dgraph-io/badger/v2"
+
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/model/verification"
+)
+
+// InsertChunkStatus inserts the status of a chunk keyed by chunk ID.
+func InsertChunkStatus(status *verification.ChunkStatus) func(*badger.Txn) error {
+	return insert(makePrefix(codeChunkStatus, status.ID()), status)
+}
+
+// UpdateChunkStatus updates the status of a chunk keyed by chunk ID.
+func UpdateChunkStatus(status *verification.ChunkStatus) func(*badger.Txn) error {
+	return update(makePrefix(codeChunkStatus, status.ID()), status)
+}
+
+// RetrieveChunkStatus retrieves the status of a chunk by chunk ID.
+func RetrieveChunkStatus(chunkID flow.Identifier, status *verification.ChunkStatus) func(*badger.Txn) error {
+	return retrieve(makePrefix(codeChunkStatus, chunkID), status)
+}
+
+// RemoveChunkStatus removes the status of the chunk with the given chunk ID.
+func RemoveChunkStatus(chunkID flow.Identifier) func(*badger.Txn) error {
+	return remove(makePrefix(codeChunkStatus, chunkID))
+}
+
+// IndexChunkStatusByHeight indexes the status of a chunk by the height of its block.
+func IndexChunkStatusByHeight(height uint64, chunkID flow.Identifier) func(*badger.Txn) error {
+	return insert(makePrefix(codeIndexChunkStatusByHeight, height, chunkID), chunkID)
+}
+
+// RemoveChunkStatusHeightIndex removes the index of the status of a chunk by the height of its block.
+func RemoveChunkStatusHeightIndex(height uint64, chunkID flow.Identifier) func(*badger.Txn) error {
+	return remove(makePrefix(codeIndexChunkStatusByHeight, height, chunkID))
+}
+
+// LookupChunkStatusesByHeightRange retrieves the IDs of the chunks whose status is indexed at a height between the
+// given heights, both included.
+func LookupChunkStatusesByHeightRange(from uint64, to uint64, chunkIDs *[]flow.Identifier) func(*badger.Txn) error {
+	return iterate(makePrefix(codeIndexChunkStatusByHeight, from), makePrefix(codeIndexChunkStatusByHeight, to), lookup(chunkIDs))
+}
+
+// FindChunkStatuses iterates through the statuses of all chunks, and adds them to the found slice.
+func FindChunkStatuses(found *[]*verification.ChunkStatus) func(*badger.Txn) error {
+	return traverse(makePrefix(codeChunkStatus), func() (checkFunc, createFunc, handleFunc) {
+		check := func(key []byte) bool {
+			return true
+		}
+		var val verification.ChunkStatus
+		create := func() interface{} {
+			return &val
+		}
+		handle := func() error {
+			*found = append(*found, &val)
+			return nil
+		}
+		return check, create, handle
+	})
+}
@@ -0,0 +1,23 @@
+package operation
+
+import (
+	"github.com/dgraph-io/badger/v2"
+
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/model/verification"
+)
+
+// InsertChunkVerdict inserts the verdict on a chunk keyed by the ID of its execution result and its index.
+func InsertChunkVerdict(verdict *verification.ChunkVerdict) func(*badger.Txn) error {
+	return insert(makePrefix(codeChunkVerdict, verdict.ExecutionResultID, verdict.ChunkIndex), verdict)
+}
+
+// UpdateChunkVerdict updates the verdict on a chunk keyed by the ID of its execution result and its index.
+func UpdateChunkVerdict(verdict *verification.ChunkVerdict) func(*badger.Txn) error {
+	return update(makePrefix(codeChunkVerdict, verdict.ExecutionResultID, verdict.ChunkIndex), verdict)
+}
+
+// RetrieveChunkVerdict retrieves the verdict on a chunk by the ID of its execution result and its index.
+func RetrieveChunkVerdict(resultID flow.Identifier, chunkIndex uint64, verdict *verification.ChunkVerdict) func(*badger.Txn) error {
+	return retrieve(makePrefix(codeChunkVerdict, resultID, chunkIndex), verdict)
+}
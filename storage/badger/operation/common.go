@@ -132,6 +132,52 @@ func remove(key []byte) func(*badger.Txn) error {
 	}
 }
 
+// removeByPrefix removes all the entities whose keys have the given prefix, and adds the size of the removed keys
+// and values to removed. If there is no such entity, this is a no-op.
+func removeByPrefix(prefix []byte, removed *uint64) func(*badger.Txn) error {
+	return func(tx *badger.Txn) error {
+		// collect the keys first, as keys can not be deleted while iterating over them
+		var keys [][]byte
+		err := lookupKeysByPrefix(prefix, &keys, removed)(tx)
+		if err != nil {
+			return err
+		}
+
+		for _, key := range keys {
+			err := tx.Delete(key)
+			if err != nil {
+				return fmt.Errorf("could not delete key: %w", err)
+			}
+		}
+
+		return nil
+	}
+}
+
+// lookupKeysByPrefix appends the keys of all the entities whose keys have the given prefix to keys, and adds the size
+// of the keys and values to size.
+func lookupKeysByPrefix(prefix []byte, keys *[][]byte, size *uint64) func(*badger.Txn) error {
+	return func(tx *badger.Txn) error {
+		if len(prefix) == 0 {
+			return fmt.Errorf("prefix must not be empty")
+		}
+
+		opts := badger.DefaultIteratorOptions
+		opts.PrefetchValues = false
+		opts.Prefix = prefix
+
+		it := tx.NewIterator(opts)
+		defer it.Close()
+		for it.Seek(prefix); it.ValidForPrefix(prefix); it.Next() {
+			item := it.Item()
+			*keys = append(*keys, item.KeyCopy(nil))
+			*size += uint64(item.EstimatedSize())
+		}
+
+		return nil
+	}
+}
+
 // retrieve will retrieve the binary data under the given key from the badger DB
 // and decode it into the given entity. The provided entity needs to be a
 // pointer to an initialized entity of the correct type.
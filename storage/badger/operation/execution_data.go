@@ -0,0 +1,74 @@
+package operation
+
+import (
+	"errors"
+
+	"github.com/dgraph-io/badger/v2"
+
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/storage"
+)
+
+// InsertExecutionData inserts the execution data of a block by its ID.
+func InsertExecutionData(data *flow.ExecutionData) func(*badger.Txn) error {
+	return insert(makePrefix(codeExecutionData, data.ID()), data)
+}
+
+// RetrieveExecutionData retrieves the execution data with the given ID.
+func RetrieveExecutionData(executionDataID flow.Identifier, data *flow.ExecutionData) func(*badger.Txn) error {
+	return retrieve(makePrefix(codeExecutionData, executionDataID), data)
+}
+
+// InsertChunkExecutionData inserts the execution data of a chunk by its ID.
+func InsertChunkExecutionData(chunk *flow.ChunkExecutionData) func(*badger.Txn) error {
+	return insert(makePrefix(codeChunkExecutionData, chunk.ID()), chunk)
+}
+
+// RetrieveChunkExecutionData retrieves the chunk execution data with the given ID.
+func RetrieveChunkExecutionData(chunkExecutionDataID flow.Identifier, chunk *flow.ChunkExecutionData) func(*badger.Txn) error {
+	return retrieve(makePrefix(codeChunkExecutionData, chunkExecutionDataID), chunk)
+}
+
+// IndexExecutionData inserts the ID of the execution data of a block keyed by block ID.
+func IndexExecutionData(blockID flow.Identifier, executionDataID flow.Identifier) func(*badger.Txn) error {
+	return insert(makePrefix(codeIndexExecutionDataByBlock, blockID), executionDataID)
+}
+
+// LookupExecutionData finds the ID of the execution data of a block.
+func LookupExecutionData(blockID flow.Identifier, executionDataID *flow.Identifier) func(*badger.Txn) error {
+	return retrieve(makePrefix(codeIndexExecutionDataByBlock, blockID), executionDataID)
+}
+
+// lookupExecutionDataKeys appends the keys of the execution data of the given block, of its chunks, and of its index
+// to keys, and adds the size of the keys and values to size. The key of the index comes last, as the others are
+// looked up through it.
+func lookupExecutionDataKeys(blockID flow.Identifier, keys *[][]byte, size *uint64) func(*badger.Txn) error {
+	return func(tx *badger.Txn) error {
+		var executionDataID flow.Identifier
+		err := LookupExecutionData(blockID, &executionDataID)(tx)
+		if errors.Is(err, storage.ErrNotFound) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		var data flow.ExecutionData
+		err = RetrieveExecutionData(executionDataID, &data)(tx)
+		if err != nil {
+			return err
+		}
+		for _, chunkExecutionDataID := range data.ChunkExecutionDataIDs {
+			err = lookupKeysByPrefix(makePrefix(codeChunkExecutionData, chunkExecutionDataID), keys, size)(tx)
+			if err != nil {
+				return err
+			}
+		}
+
+		err = lookupKeysByPrefix(makePrefix(codeExecutionData, executionDataID), keys, size)(tx)
+		if err != nil {
+			return err
+		}
+		return lookupKeysByPrefix(makePrefix(codeIndexExecutionDataByBlock, blockID), keys, size)(tx)
+	}
+}
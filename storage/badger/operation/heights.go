@@ -49,3 +49,46 @@ func UpdateLastCompleteBlockHeight(height uint64) func(*badger.Txn) error {
 func RetrieveLastCompleteBlockHeight(height *uint64) func(*badger.Txn) error {
 	return retrieve(makePrefix(codeLastCompleteBlockHeight), height)
 }
+
+func InsertExecutionPrunedHeight(height uint64) func(*badger.Txn) error {
+	return insert(makePrefix(codeExecutionPrunedHeight), height)
+}
+
+func UpdateExecutionPrunedHeight(height uint64) func(*badger.Txn) error {
+	return update(makePrefix(codeExecutionPrunedHeight), height)
+}
+
+func RetrieveExecutionPrunedHeight(height *uint64) func(*badger.Txn) error {
+	return retrieve(makePrefix(codeExecutionPrunedHeight), height)
+}
+
+// executionStopHeight is the stored stop height of the execution, along with whether the node crashes once stopped.
+type executionStopHeight struct {
+	Height uint64
+	Crash  bool
+}
+
+func InsertExecutionStopHeight(height uint64, crash bool) func(*badger.Txn) error {
+	return insert(makePrefix(codeExecutionStopHeight), executionStopHeight{Height: height, Crash: crash})
+}
+
+func UpdateExecutionStopHeight(height uint64, crash bool) func(*badger.Txn) error {
+	return update(makePrefix(codeExecutionStopHeight), executionStopHeight{Height: height, Crash: crash})
+}
+
+func RetrieveExecutionStopHeight(height *uint64, crash *bool) func(*badger.Txn) error {
+	return func(tx *badger.Txn) error {
+		var stop executionStopHeight
+		err := retrieve(makePrefix(codeExecutionStopHeight), &stop)(tx)
+		if err != nil {
+			return err
+		}
+		*height = stop.Height
+		*crash = stop.Crash
+		return nil
+	}
+}
+
+func RemoveExecutionStopHeight() func(*badger.Txn) error {
+	return remove(makePrefix(codeExecutionStopHeight))
+}
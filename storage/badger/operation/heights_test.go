@@ -3,12 +3,14 @@
 package operation
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/dgraph-io/badger/v2"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/onflow/flow-go/storage"
 	"github.com/onflow/flow-go/utils/unittest"
 )
 
@@ -83,3 +85,58 @@ func TestLastCompleteBlockHeightInsertUpdateRetrieve(t *testing.T) {
 		assert.Equal(t, retrieved, height)
 	})
 }
+
+func TestExecutionPrunedHeightInsertUpdateRetrieve(t *testing.T) {
+	unittest.RunWithBadgerDB(t, func(db *badger.DB) {
+		height := uint64(1337)
+
+		err := db.Update(InsertExecutionPrunedHeight(height))
+		require.Nil(t, err)
+
+		var retrieved uint64
+		err = db.View(RetrieveExecutionPrunedHeight(&retrieved))
+		require.Nil(t, err)
+
+		assert.Equal(t, retrieved, height)
+
+		height = 9999
+		err = db.Update(UpdateExecutionPrunedHeight(height))
+		require.Nil(t, err)
+
+		err = db.View(RetrieveExecutionPrunedHeight(&retrieved))
+		require.Nil(t, err)
+
+		assert.Equal(t, retrieved, height)
+	})
+}
+
+func TestExecutionStopHeightInsertUpdateRetrieveRemove(t *testing.T) {
+	unittest.RunWithBadgerDB(t, func(db *badger.DB) {
+		var height uint64
+		var crash bool
+		err := db.View(RetrieveExecutionStopHeight(&height, &crash))
+		require.True(t, errors.Is(err, storage.ErrNotFound))
+
+		err = db.Update(InsertExecutionStopHeight(1337, true))
+		require.Nil(t, err)
+
+		err = db.View(RetrieveExecutionStopHeight(&height, &crash))
+		require.Nil(t, err)
+		assert.Equal(t, uint64(1337), height)
+		assert.True(t, crash)
+
+		err = db.Update(UpdateExecutionStopHeight(9999, false))
+		require.Nil(t, err)
+
+		err = db.View(RetrieveExecutionStopHeight(&height, &crash))
+		require.Nil(t, err)
+		assert.Equal(t, uint64(9999), height)
+		assert.False(t, crash)
+
+		err = db.Update(RemoveExecutionStopHeight())
+		require.Nil(t, err)
+
+		err = db.View(RetrieveExecutionStopHeight(&height, &crash))
+		require.True(t, errors.Is(err, storage.ErrNotFound))
+	})
+}
@@ -26,6 +26,8 @@ const (
 	codeExecutedBlock           = 23 // latest executed block with max height
 	codeRootHeight              = 24 // the height of the first loaded block
 	codeLastCompleteBlockHeight = 25 // the height of the last block for which all collections were received
+	codeExecutionPrunedHeight   = 26 // the height up to which the execution data of executed blocks was pruned
+	codeExecutionStopHeight     = 27 // the height the operator set to stop the execution after
 
 	// codes for single entity storage
 	// 31 was used for identities before epochs
@@ -67,6 +69,23 @@ const (
 	codeJobQueue             = 71
 	codeJobQueuePointer      = 72
 
+	// codes for the optional execution traces of transactions
+	codeTransactionTrace      = 80
+	codeIndexTransactionTrace = 81 // index mapping transaction ID to the blocks it was traced in
+
+	// codes for the execution data published for downstream consumers
+	codeExecutionData             = 82
+	codeIndexExecutionDataByBlock = 83 // index mapping block ID to the ID of its execution data
+	codeChunkExecutionData        = 84
+
+	// codes for the progress of the verification pipeline of verification nodes
+	codeChunkStatus              = 85
+	codeChunkVerdict             = 86
+	codeIndexChunkStatusByHeight = 87 // index mapping block height to the IDs of the chunks of its chunk statuses
+
+	// codes for the challenges of execution results consensus nodes received
+	codeChallenge = 88
+
 	// legacy codes (should be cleaned up)
 	codeChunkDataPack                = 100
 	codeCommit                       = 101
@@ -81,7 +100,8 @@ const (
 	codeIndexResultApprovalByChunk   = 204
 
 	// internal failure information that should be preserved across restarts
-	codeExecutionFork = 254
+	codeExecutionForkHalt = 253 // the sealed block whose sealed result differs from the result of this execution node
+	codeExecutionFork     = 254
 )
 
 func makePrefix(code byte, keys ...interface{}) []byte {
@@ -0,0 +1,80 @@
+package operation
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/dgraph-io/badger/v2"
+
+	"github.com/onflow/flow-go/model/flow"
+)
+
+// LookupExecutionDataKeys appends the keys of the data an execution node stores for an executed block to keys: the
+// chunk data packs of the given chunks and their index, the events, service events, transaction results and
+// transaction traces, the state interactions and the published execution data. The state commitment, the execution
+// result and the execution receipt of the block are not included, since the receipt is retrieved along with its
+// result, and both are referenced by the payloads of the blocks incorporating the receipt. The size of the keys and
+// values is added to size. The keys of the data that is looked up through other data come first, so that removing
+// the keys in order can be resumed after an interruption.
+func LookupExecutionDataKeys(blockID flow.Identifier, chunkIDs []flow.Identifier, keys *[][]byte, size *uint64) func(*badger.Txn) error {
+	return func(tx *badger.Txn) error {
+		for _, chunkID := range chunkIDs {
+			err := lookupKeysByPrefix(makePrefix(codeChunkDataPack, chunkID), keys, size)(tx)
+			if err != nil {
+				return fmt.Errorf("could not look up chunk data pack %x: %w", chunkID, err)
+			}
+			err = lookupKeysByPrefix(makePrefix(codeIndexBlockByChunkID, chunkID), keys, size)(tx)
+			if err != nil {
+				return fmt.Errorf("could not look up block index of chunk %x: %w", chunkID, err)
+			}
+		}
+
+		prefixes := []struct {
+			prefix []byte
+			name   string
+		}{
+			{makePrefix(codeEvent, blockID), "events"},
+			{makePrefix(codeServiceEvent, blockID), "service events"},
+			{makePrefix(codeTransactionResult, blockID), "transaction results"},
+			{makePrefix(codeExecutionStateInteractions, blockID), "state interactions"},
+		}
+		for _, p := range prefixes {
+			err := lookupKeysByPrefix(p.prefix, keys, size)(tx)
+			if err != nil {
+				return fmt.Errorf("could not look up %s: %w", p.name, err)
+			}
+		}
+
+		err := lookupTransactionTraceKeys(blockID, keys, size)(tx)
+		if err != nil {
+			return fmt.Errorf("could not look up transaction traces: %w", err)
+		}
+
+		err = lookupExecutionDataKeys(blockID, keys, size)(tx)
+		if err != nil {
+			return fmt.Errorf("could not look up published execution data: %w", err)
+		}
+
+		return nil
+	}
+}
+
+// RemoveKeys removes the given keys in order, until all of them are removed or the transaction is too big to remove
+// any more, and stores the number of removed keys in removed. The remaining keys are to be removed in a following
+// transaction. Keys that do not exist are skipped.
+func RemoveKeys(keys [][]byte, removed *int) func(*badger.Txn) error {
+	return func(tx *badger.Txn) error {
+		*removed = 0
+		for _, key := range keys {
+			err := tx.Delete(key)
+			if errors.Is(err, badger.ErrTxnTooBig) && *removed > 0 {
+				return nil
+			}
+			if err != nil {
+				return fmt.Errorf("could not delete key: %w", err)
+			}
+			*removed++
+		}
+		return nil
+	}
+}
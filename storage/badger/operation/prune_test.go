@@ -0,0 +1,185 @@
+package operation
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/storage"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+// insertExecutionData stores the execution data of an executed block with the given result, including a trace of
+// the given transaction and the published execution data of the block.
+func insertExecutionData(t *testing.T, db *badger.DB, blockID flow.Identifier, result *flow.ExecutionResult, tracedID flow.Identifier) {
+	trace := &flow.TransactionTrace{BlockID: blockID, TransactionID: tracedID}
+	err := db.Update(func(tx *badger.Txn) error {
+		for _, chunk := range result.Chunks {
+			err := InsertChunkDataPack(unittest.ChunkDataPackFixture(chunk.ID()))(tx)
+			require.NoError(t, err)
+			err = IndexBlockIDByChunkID(chunk.ID(), blockID)(tx)
+			require.NoError(t, err)
+		}
+
+		txID := unittest.IdentifierFixture()
+		err := InsertEvent(blockID, unittest.EventFixture(flow.EventAccountCreated, 0, 0, txID))(tx)
+		require.NoError(t, err)
+		err = InsertTransactionResult(blockID, &flow.TransactionResult{TransactionID: txID})(tx)
+		require.NoError(t, err)
+		err = InsertExecutionResult(result)(tx)
+		require.NoError(t, err)
+		err = insert(makePrefix(codeTransactionTrace, blockID, tracedID), trace)(tx)
+		require.NoError(t, err)
+		err = insert(makePrefix(codeIndexTransactionTrace, tracedID, blockID), blockID)(tx)
+		require.NoError(t, err)
+		err = IndexExecutionResult(blockID, result.ID())(tx)
+		require.NoError(t, err)
+		published, publishedChunk := publishedExecutionData(blockID)
+		err = InsertChunkExecutionData(publishedChunk)(tx)
+		require.NoError(t, err)
+		err = InsertExecutionData(published)(tx)
+		require.NoError(t, err)
+		err = IndexExecutionData(blockID, published.ID())(tx)
+		require.NoError(t, err)
+		return IndexStateCommitment(blockID, unittest.StateCommitmentFixture())(tx)
+	})
+	require.NoError(t, err)
+}
+
+// publishedExecutionData returns the execution data of a block, of a single chunk.
+func publishedExecutionData(blockID flow.Identifier) (*flow.ExecutionData, *flow.ChunkExecutionData) {
+	chunk := &flow.ChunkExecutionData{BlockID: blockID}
+	data := &flow.ExecutionData{BlockID: blockID, ChunkExecutionDataIDs: []flow.Identifier{chunk.ID()}}
+	return data, chunk
+}
+
+// pruneExecutionData removes the execution data of the given block and chunks, and returns the size of the removed
+// keys and values.
+func pruneExecutionData(t *testing.T, db *badger.DB, blockID flow.Identifier, chunkIDs []flow.Identifier) uint64 {
+	var keys [][]byte
+	var size uint64
+	err := db.View(LookupExecutionDataKeys(blockID, chunkIDs, &keys, &size))
+	require.NoError(t, err)
+
+	var removed int
+	err = db.Update(RemoveKeys(keys, &removed))
+	require.NoError(t, err)
+	require.Equal(t, len(keys), removed)
+
+	return size
+}
+
+func TestPruneExecutionData(t *testing.T) {
+	unittest.RunWithBadgerDB(t, func(db *badger.DB) {
+		// the same transaction is traced in both blocks
+		tracedID := unittest.IdentifierFixture()
+
+		pruned := unittest.BlockFixture()
+		prunedResult := unittest.ExecutionResultFixture(unittest.WithBlock(&pruned))
+		insertExecutionData(t, db, pruned.ID(), prunedResult, tracedID)
+
+		kept := unittest.BlockFixture()
+		keptResult := unittest.ExecutionResultFixture(unittest.WithBlock(&kept))
+		insertExecutionData(t, db, kept.ID(), keptResult, tracedID)
+
+		chunkIDs := make([]flow.Identifier, 0, len(prunedResult.Chunks))
+		for _, chunk := range prunedResult.Chunks {
+			chunkIDs = append(chunkIDs, chunk.ID())
+		}
+
+		removed := pruneExecutionData(t, db, pruned.ID(), chunkIDs)
+		assert.NotZero(t, removed)
+
+		var err error
+
+		// the execution data of the pruned block is removed
+		for _, chunkID := range chunkIDs {
+			var pack flow.ChunkDataPack
+			err = db.View(RetrieveChunkDataPack(chunkID, &pack))
+			assert.True(t, errors.Is(err, storage.ErrNotFound))
+
+			var blockID flow.Identifier
+			err = db.View(LookupBlockIDByChunkID(chunkID, &blockID))
+			assert.True(t, errors.Is(err, storage.ErrNotFound))
+		}
+
+		var events []flow.Event
+		err = db.View(LookupEventsByBlockID(pruned.ID(), &events))
+		require.NoError(t, err)
+		assert.Empty(t, events)
+
+		var results []flow.TransactionResult
+		err = db.View(LookupTransactionResultsByBlockID(pruned.ID(), &results))
+		require.NoError(t, err)
+		assert.Empty(t, results)
+
+		var trace flow.TransactionTrace
+		err = db.View(RetrieveTransactionTrace(pruned.ID(), tracedID, &trace))
+		assert.True(t, errors.Is(err, storage.ErrNotFound))
+
+		var tracedBlockIDs []flow.Identifier
+		err = db.View(LookupTransactionTraceBlocks(tracedID, &tracedBlockIDs))
+		require.NoError(t, err)
+		assert.Equal(t, []flow.Identifier{kept.ID()}, tracedBlockIDs)
+
+		var executionDataID flow.Identifier
+		err = db.View(LookupExecutionData(pruned.ID(), &executionDataID))
+		assert.True(t, errors.Is(err, storage.ErrNotFound))
+
+		prunedData, prunedChunk := publishedExecutionData(pruned.ID())
+		var published flow.ExecutionData
+		err = db.View(RetrieveExecutionData(prunedData.ID(), &published))
+		assert.True(t, errors.Is(err, storage.ErrNotFound))
+
+		var publishedChunk flow.ChunkExecutionData
+		err = db.View(RetrieveChunkExecutionData(prunedChunk.ID(), &publishedChunk))
+		assert.True(t, errors.Is(err, storage.ErrNotFound))
+
+		// the state commitment and the execution result of the pruned block are kept
+		var commit flow.StateCommitment
+		err = db.View(LookupStateCommitment(pruned.ID(), &commit))
+		require.NoError(t, err)
+
+		var resultID flow.Identifier
+		err = db.View(LookupExecutionResult(pruned.ID(), &resultID))
+		require.NoError(t, err)
+		assert.Equal(t, prunedResult.ID(), resultID)
+
+		var result flow.ExecutionResult
+		err = db.View(RetrieveExecutionResult(prunedResult.ID(), &result))
+		require.NoError(t, err)
+
+		// the execution data of the other block is kept
+		err = db.View(LookupEventsByBlockID(kept.ID(), &events))
+		require.NoError(t, err)
+		assert.Len(t, events, 1)
+
+		err = db.View(LookupExecutionResult(kept.ID(), &resultID))
+		require.NoError(t, err)
+		assert.Equal(t, keptResult.ID(), resultID)
+
+		err = db.View(LookupExecutionData(kept.ID(), &executionDataID))
+		require.NoError(t, err)
+		err = db.View(RetrieveExecutionData(executionDataID, &published))
+		require.NoError(t, err)
+		assert.Equal(t, kept.ID(), published.BlockID)
+		require.Len(t, published.ChunkExecutionDataIDs, 1)
+		err = db.View(RetrieveChunkExecutionData(published.ChunkExecutionDataIDs[0], &publishedChunk))
+		require.NoError(t, err)
+		assert.Equal(t, kept.ID(), publishedChunk.BlockID)
+
+		for _, chunk := range keptResult.Chunks {
+			var pack flow.ChunkDataPack
+			err = db.View(RetrieveChunkDataPack(chunk.ID(), &pack))
+			require.NoError(t, err)
+		}
+
+		// pruning again is a no-op
+		removed = pruneExecutionData(t, db, pruned.ID(), chunkIDs)
+		assert.Zero(t, removed)
+	})
+}
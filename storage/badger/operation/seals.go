@@ -57,3 +57,15 @@ func RemoveExecutionForkEvidence() func(*badger.Txn) error {
 func RetrieveExecutionForkEvidence(conflictingSeals *[]*flow.IncorporatedResultSeal) func(*badger.Txn) error {
 	return retrieve(makePrefix(codeExecutionFork), conflictingSeals)
 }
+
+func InsertExecutionForkHalt(blockID flow.Identifier) func(*badger.Txn) error {
+	return insert(makePrefix(codeExecutionForkHalt), blockID)
+}
+
+func RemoveExecutionForkHalt() func(*badger.Txn) error {
+	return remove(makePrefix(codeExecutionForkHalt))
+}
+
+func RetrieveExecutionForkHalt(blockID *flow.Identifier) func(*badger.Txn) error {
+	return retrieve(makePrefix(codeExecutionForkHalt), blockID)
+}
@@ -3,6 +3,7 @@
 package operation
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/dgraph-io/badger/v2"
@@ -10,6 +11,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/storage"
 	"github.com/onflow/flow-go/utils/unittest"
 )
 
@@ -59,3 +61,25 @@ func TestSealIndexAndLookup(t *testing.T) {
 		assert.Equal(t, expected, actual)
 	})
 }
+
+func TestExecutionForkHaltInsertRetrieveRemove(t *testing.T) {
+	unittest.RunWithBadgerDB(t, func(db *badger.DB) {
+		var actual flow.Identifier
+		err := db.View(RetrieveExecutionForkHalt(&actual))
+		require.True(t, errors.Is(err, storage.ErrNotFound))
+
+		expected := unittest.IdentifierFixture()
+		err = db.Update(InsertExecutionForkHalt(expected))
+		require.Nil(t, err)
+
+		err = db.View(RetrieveExecutionForkHalt(&actual))
+		require.Nil(t, err)
+		assert.Equal(t, expected, actual)
+
+		err = db.Update(RemoveExecutionForkHalt())
+		require.Nil(t, err)
+
+		err = db.View(RetrieveExecutionForkHalt(&actual))
+		require.True(t, errors.Is(err, storage.ErrNotFound))
+	})
+}
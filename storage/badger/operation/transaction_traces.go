@@ -0,0 +1,71 @@
+package operation
+
+import (
+	"github.com/dgraph-io/badger/v2"
+
+	"github.com/onflow/flow-go/model/flow"
+)
+
+func BatchInsertTransactionTrace(blockID flow.Identifier, trace *flow.TransactionTrace) func(batch *badger.WriteBatch) error {
+	return batchInsert(makePrefix(codeTransactionTrace, blockID, trace.TransactionID), trace)
+}
+
+func BatchIndexTransactionTrace(transactionID flow.Identifier, blockID flow.Identifier) func(batch *badger.WriteBatch) error {
+	return batchInsert(makePrefix(codeIndexTransactionTrace, transactionID, blockID), blockID)
+}
+
+func RetrieveTransactionTrace(blockID flow.Identifier, transactionID flow.Identifier, trace *flow.TransactionTrace) func(*badger.Txn) error {
+	return retrieve(makePrefix(codeTransactionTrace, blockID, transactionID), trace)
+}
+
+// LookupTransactionTraceBlocks looks up the blocks the transaction with the given ID was traced in.
+func LookupTransactionTraceBlocks(transactionID flow.Identifier, blockIDs *[]flow.Identifier) func(*badger.Txn) error {
+
+	iterationFunc := func() (checkFunc, createFunc, handleFunc) {
+		check := func(_ []byte) bool {
+			return true
+		}
+		var blockID flow.Identifier
+		create := func() interface{} {
+			return &blockID
+		}
+		handle := func() error {
+			*blockIDs = append(*blockIDs, blockID)
+			return nil
+		}
+		return check, create, handle
+	}
+
+	return traverse(makePrefix(codeIndexTransactionTrace, transactionID), iterationFunc)
+}
+
+// lookupTransactionTraceKeys appends the keys of the index of the transaction traces of the given block, followed by
+// the keys of the traces, to keys, and adds the size of the keys and values to size. The keys of the traces come last,
+// as the keys of the index are looked up through them.
+func lookupTransactionTraceKeys(blockID flow.Identifier, keys *[][]byte, size *uint64) func(*badger.Txn) error {
+	return func(tx *badger.Txn) error {
+		prefix := makePrefix(codeTransactionTrace, blockID)
+
+		// the keys of the traces end with the transaction ID
+		var transactionIDs []flow.Identifier
+		opts := badger.DefaultIteratorOptions
+		opts.PrefetchValues = false
+		it := tx.NewIterator(opts)
+		for it.Seek(prefix); it.ValidForPrefix(prefix); it.Next() {
+			key := it.Item().Key()
+			var transactionID flow.Identifier
+			copy(transactionID[:], key[len(prefix):])
+			transactionIDs = append(transactionIDs, transactionID)
+		}
+		it.Close()
+
+		for _, transactionID := range transactionIDs {
+			err := lookupKeysByPrefix(makePrefix(codeIndexTransactionTrace, transactionID, blockID), keys, size)(tx)
+			if err != nil {
+				return err
+			}
+		}
+
+		return lookupKeysByPrefix(prefix, keys, size)(tx)
+	}
+}
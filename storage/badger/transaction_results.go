@@ -99,3 +99,13 @@ func (tr *TransactionResults) ByBlockIDTransactionID(blockID flow.Identifier, tx
 	}
 	return &transactionResult, nil
 }
+
+// ByBlockID returns the runtime transaction results of all transactions of the given block
+func (tr *TransactionResults) ByBlockID(blockID flow.Identifier) ([]flow.TransactionResult, error) {
+	var results []flow.TransactionResult
+	err := tr.db.View(operation.LookupTransactionResultsByBlockID(blockID, &results))
+	if err != nil {
+		return nil, fmt.Errorf("could not retrieve transaction results: %w", err)
+	}
+	return results, nil
+}
@@ -0,0 +1,88 @@
+package badger
+
+import (
+	"fmt"
+
+	"github.com/dgraph-io/badger/v2"
+
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/storage"
+	"github.com/onflow/flow-go/storage/badger/operation"
+)
+
+// TransactionTraces stores the execution traces of transactions. Traces are only read when investigating the
+// behaviour of a transaction, so they are not cached.
+type TransactionTraces struct {
+	db *badger.DB
+}
+
+func NewTransactionTraces(db *badger.DB) *TransactionTraces {
+	return &TransactionTraces{
+		db: db,
+	}
+}
+
+// Store stores the execution traces of the transactions of the given block in a single batch
+func (t *TransactionTraces) Store(blockID flow.Identifier, traces []flow.TransactionTrace) error {
+	batch := NewBatch(t.db)
+	writeBatch := batch.GetWriter()
+
+	for i := range traces {
+		trace := &traces[i]
+		err := operation.BatchInsertTransactionTrace(blockID, trace)(writeBatch)
+		if err != nil {
+			return fmt.Errorf("could not batch insert transaction trace: %w", err)
+		}
+		err = operation.BatchIndexTransactionTrace(trace.TransactionID, blockID)(writeBatch)
+		if err != nil {
+			return fmt.Errorf("could not batch index transaction trace: %w", err)
+		}
+	}
+
+	err := batch.Flush()
+	if err != nil {
+		return fmt.Errorf("could not store transaction traces: %w", err)
+	}
+	return nil
+}
+
+// ByBlockIDTransactionID returns the execution trace of the given transaction in the given block
+func (t *TransactionTraces) ByBlockIDTransactionID(blockID flow.Identifier, transactionID flow.Identifier) (*flow.TransactionTrace, error) {
+	var trace flow.TransactionTrace
+	err := t.db.View(operation.RetrieveTransactionTrace(blockID, transactionID, &trace))
+	if err != nil {
+		return nil, handleError(err, flow.TransactionTrace{})
+	}
+	return &trace, nil
+}
+
+// ByTransactionID returns the execution traces of the given transaction in every block it was traced in. A
+// transaction is usually executed in a single block, but a block may be executed with several results.
+func (t *TransactionTraces) ByTransactionID(transactionID flow.Identifier) ([]flow.TransactionTrace, error) {
+	var traces []flow.TransactionTrace
+	err := t.db.View(func(tx *badger.Txn) error {
+		var blockIDs []flow.Identifier
+		err := operation.LookupTransactionTraceBlocks(transactionID, &blockIDs)(tx)
+		if err != nil {
+			return fmt.Errorf("could not look up traced blocks: %w", err)
+		}
+
+		for _, blockID := range blockIDs {
+			var trace flow.TransactionTrace
+			err = operation.RetrieveTransactionTrace(blockID, transactionID, &trace)(tx)
+			if err != nil {
+				return fmt.Errorf("could not retrieve trace in block %v: %w", blockID, err)
+			}
+			traces = append(traces, trace)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(traces) == 0 {
+		return nil, storage.ErrNotFound
+	}
+	return traces, nil
+}
@@ -0,0 +1,78 @@
+package badger_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/storage"
+	"github.com/onflow/flow-go/utils/unittest"
+
+	bstorage "github.com/onflow/flow-go/storage/badger"
+)
+
+func TestStoringTransactionTraces(t *testing.T) {
+	unittest.RunWithBadgerDB(t, func(db *badger.DB) {
+		store := bstorage.NewTransactionTraces(db)
+
+		blockID := unittest.IdentifierFixture()
+		traces := make([]flow.TransactionTrace, 0)
+		for i := 0; i < 5; i++ {
+			txID := unittest.IdentifierFixture()
+			traces = append(traces, flow.TransactionTrace{
+				BlockID:          blockID,
+				TransactionID:    txID,
+				TransactionIndex: uint32(i),
+				Reads: []flow.RegisterTrace{
+					{Register: flow.NewRegisterID("owner", "controller", "key"), OldValueSize: 10},
+				},
+				Writes: []flow.RegisterTrace{
+					{Register: flow.NewRegisterID("owner", "", "other"), OldValueSize: 2, NewValueSize: 4},
+				},
+				Programs:        []string{"A.0000000000000001.Contract"},
+				ComputationUsed: uint64(i * 10),
+				Events:          []flow.Event{unittest.EventFixture(flow.EventAccountCreated, uint32(i), 0, txID)},
+			})
+		}
+
+		err := store.Store(blockID, traces)
+		require.NoError(t, err)
+
+		for _, trace := range traces {
+			actual, err := store.ByBlockIDTransactionID(blockID, trace.TransactionID)
+			require.NoError(t, err)
+			assert.Equal(t, trace, *actual)
+
+			byTransaction, err := store.ByTransactionID(trace.TransactionID)
+			require.NoError(t, err)
+			assert.Equal(t, []flow.TransactionTrace{trace}, byTransaction)
+		}
+
+		// the same transaction traced in another block
+		otherBlockID := unittest.IdentifierFixture()
+		other := traces[0]
+		other.BlockID = otherBlockID
+		err = store.Store(otherBlockID, []flow.TransactionTrace{other})
+		require.NoError(t, err)
+
+		byTransaction, err := store.ByTransactionID(other.TransactionID)
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []flow.TransactionTrace{traces[0], other}, byTransaction)
+	})
+}
+
+func TestReadingNotStoredTransactionTrace(t *testing.T) {
+	unittest.RunWithBadgerDB(t, func(db *badger.DB) {
+		store := bstorage.NewTransactionTraces(db)
+
+		_, err := store.ByBlockIDTransactionID(unittest.IdentifierFixture(), unittest.IdentifierFixture())
+		assert.True(t, errors.Is(err, storage.ErrNotFound))
+
+		_, err = store.ByTransactionID(unittest.IdentifierFixture())
+		assert.True(t, errors.Is(err, storage.ErrNotFound))
+	})
+}
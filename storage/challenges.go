@@ -0,0 +1,20 @@
+package storage
+
+import (
+	"github.com/onflow/flow-go/model/flow"
+)
+
+// Challenges represents persistent storage for the valid challenges of execution results a consensus node
+// received, so that challenged results remain withheld from sealing after a restart.
+type Challenges interface {
+
+	// Store stores a challenge, keyed by the ID of the challenged result and the challenge ID. Storing a challenge
+	// that is already stored is a no-op.
+	Store(challenge *flow.Challenge) error
+
+	// All returns all stored challenges.
+	All() ([]*flow.Challenge, error)
+
+	// RemoveByResultID removes the challenges of the result with the given ID, if any.
+	RemoveByResultID(resultID flow.Identifier) error
+}
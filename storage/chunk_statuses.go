@@ -0,0 +1,27 @@
+package storage
+
+import (
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/model/verification"
+)
+
+// ChunkStatuses represents persistent storage for the status of the chunks assigned to a verification node, as they
+// progress through its verification pipeline.
+type ChunkStatuses interface {
+
+	// Store stores the status of a chunk, keyed by chunk ID. It replaces the status already stored for the chunk.
+	Store(status *verification.ChunkStatus) error
+
+	// ByChunkID returns the status of the chunk with the given ID.
+	ByChunkID(chunkID flow.Identifier) (*verification.ChunkStatus, error)
+
+	// Remove removes the status of the chunk with the given ID, if it exists.
+	Remove(chunkID flow.Identifier) error
+
+	// RemoveByHeightRange removes the statuses of the chunks of the blocks at heights between the given heights,
+	// both included, and returns the number of removed statuses.
+	RemoveByHeightRange(from uint64, to uint64) (uint, error)
+
+	// All returns the statuses of all chunks.
+	All() ([]*verification.ChunkStatus, error)
+}
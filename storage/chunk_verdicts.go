@@ -0,0 +1,17 @@
+package storage
+
+import (
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/model/verification"
+)
+
+// ChunkVerdicts represents persistent storage for the verdicts of a verification node on the chunks it verified.
+type ChunkVerdicts interface {
+
+	// Store stores the verdict on a chunk, keyed by the ID of its execution result and its index. It replaces the
+	// verdict already stored for the chunk.
+	Store(verdict *verification.ChunkVerdict) error
+
+	// ByChunk returns the verdict on the chunk with the given index of the execution result with the given ID.
+	ByChunk(resultID flow.Identifier, chunkIndex uint64) (*verification.ChunkVerdict, error)
+}
@@ -5,6 +5,10 @@ import (
 )
 
 type ChunksQueue interface {
+	// Init initializes the latest index of the queue with the given default index, unless it is initialized already.
+	// It returns whether the queue was initialized.
+	Init(defaultIndex uint64) (bool, error)
+
 	StoreChunkLocator(locator *chunks.Locator) (bool, error)
 
 	LatestIndex() (uint64, error)
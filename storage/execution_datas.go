@@ -0,0 +1,20 @@
+package storage
+
+import "github.com/onflow/flow-go/model/flow"
+
+// ExecutionDatas represents persistent storage for the execution data published for blocks
+type ExecutionDatas interface {
+
+	// Store stores the execution data of a block and of its chunks, and indexes it by the block ID. Storing the same
+	// execution data again is a no-op.
+	Store(data *flow.ExecutionData, chunks []*flow.ChunkExecutionData) error
+
+	// ByID returns the execution data with the given ID
+	ByID(executionDataID flow.Identifier) (*flow.ExecutionData, error)
+
+	// ByBlockID returns the execution data of the given block
+	ByBlockID(blockID flow.Identifier) (*flow.ExecutionData, error)
+
+	// ChunkByID returns the chunk execution data with the given ID
+	ChunkByID(chunkExecutionDataID flow.Identifier) (*flow.ChunkExecutionData, error)
+}
@@ -0,0 +1,64 @@
+// Code generated by mockery v1.0.0. DO NOT EDIT.
+
+package mock
+
+import (
+	flow "github.com/onflow/flow-go/model/flow"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// Challenges is an autogenerated mock type for the Challenges type
+type Challenges struct {
+	mock.Mock
+}
+
+// All provides a mock function with given fields:
+func (_m *Challenges) All() ([]*flow.Challenge, error) {
+	ret := _m.Called()
+
+	var r0 []*flow.Challenge
+	if rf, ok := ret.Get(0).(func() []*flow.Challenge); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*flow.Challenge)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RemoveByResultID provides a mock function with given fields: resultID
+func (_m *Challenges) RemoveByResultID(resultID flow.Identifier) error {
+	ret := _m.Called(resultID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(flow.Identifier) error); ok {
+		r0 = rf(resultID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Store provides a mock function with given fields: challenge
+func (_m *Challenges) Store(challenge *flow.Challenge) error {
+	ret := _m.Called(challenge)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*flow.Challenge) error); ok {
+		r0 = rf(challenge)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
@@ -0,0 +1,110 @@
+// Code generated by mockery v1.0.0. DO NOT EDIT.
+
+package mock
+
+import (
+	flow "github.com/onflow/flow-go/model/flow"
+	mock "github.com/stretchr/testify/mock"
+
+	verification "github.com/onflow/flow-go/model/verification"
+)
+
+// ChunkStatuses is an autogenerated mock type for the ChunkStatuses type
+type ChunkStatuses struct {
+	mock.Mock
+}
+
+// All provides a mock function with given fields:
+func (_m *ChunkStatuses) All() ([]*verification.ChunkStatus, error) {
+	ret := _m.Called()
+
+	var r0 []*verification.ChunkStatus
+	if rf, ok := ret.Get(0).(func() []*verification.ChunkStatus); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*verification.ChunkStatus)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ByChunkID provides a mock function with given fields: chunkID
+func (_m *ChunkStatuses) ByChunkID(chunkID flow.Identifier) (*verification.ChunkStatus, error) {
+	ret := _m.Called(chunkID)
+
+	var r0 *verification.ChunkStatus
+	if rf, ok := ret.Get(0).(func(flow.Identifier) *verification.ChunkStatus); ok {
+		r0 = rf(chunkID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*verification.ChunkStatus)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(flow.Identifier) error); ok {
+		r1 = rf(chunkID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Remove provides a mock function with given fields: chunkID
+func (_m *ChunkStatuses) Remove(chunkID flow.Identifier) error {
+	ret := _m.Called(chunkID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(flow.Identifier) error); ok {
+		r0 = rf(chunkID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RemoveByHeightRange provides a mock function with given fields: from, to
+func (_m *ChunkStatuses) RemoveByHeightRange(from uint64, to uint64) (uint, error) {
+	ret := _m.Called(from, to)
+
+	var r0 uint
+	if rf, ok := ret.Get(0).(func(uint64, uint64) uint); ok {
+		r0 = rf(from, to)
+	} else {
+		r0 = ret.Get(0).(uint)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(uint64, uint64) error); ok {
+		r1 = rf(from, to)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Store provides a mock function with given fields: status
+func (_m *ChunkStatuses) Store(status *verification.ChunkStatus) error {
+	ret := _m.Called(status)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*verification.ChunkStatus) error); ok {
+		r0 = rf(status)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
@@ -0,0 +1,52 @@
+// Code generated by mockery v1.0.0. DO NOT EDIT.
+
+package mock
+
+import (
+	flow "github.com/onflow/flow-go/model/flow"
+	mock "github.com/stretchr/testify/mock"
+
+	verification "github.com/onflow/flow-go/model/verification"
+)
+
+// ChunkVerdicts is an autogenerated mock type for the ChunkVerdicts type
+type ChunkVerdicts struct {
+	mock.Mock
+}
+
+// ByChunk provides a mock function with given fields: resultID, chunkIndex
+func (_m *ChunkVerdicts) ByChunk(resultID flow.Identifier, chunkIndex uint64) (*verification.ChunkVerdict, error) {
+	ret := _m.Called(resultID, chunkIndex)
+
+	var r0 *verification.ChunkVerdict
+	if rf, ok := ret.Get(0).(func(flow.Identifier, uint64) *verification.ChunkVerdict); ok {
+		r0 = rf(resultID, chunkIndex)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*verification.ChunkVerdict)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(flow.Identifier, uint64) error); ok {
+		r1 = rf(resultID, chunkIndex)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Store provides a mock function with given fields: verdict
+func (_m *ChunkVerdicts) Store(verdict *verification.ChunkVerdict) error {
+	ret := _m.Called(verdict)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*verification.ChunkVerdict) error); ok {
+		r0 = rf(verdict)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
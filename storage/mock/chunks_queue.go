@@ -35,6 +35,27 @@ func (_m *ChunksQueue) AtIndex(index uint64) (*chunks.Locator, error) {
 	return r0, r1
 }
 
+// Init provides a mock function with given fields: defaultIndex
+func (_m *ChunksQueue) Init(defaultIndex uint64) (bool, error) {
+	ret := _m.Called(defaultIndex)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(uint64) bool); ok {
+		r0 = rf(defaultIndex)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(uint64) error); ok {
+		r1 = rf(defaultIndex)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // LatestIndex provides a mock function with given fields:
 func (_m *ChunksQueue) LatestIndex() (uint64, error) {
 	ret := _m.Called()
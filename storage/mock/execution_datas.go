@@ -0,0 +1,97 @@
+// Code generated by mockery v1.0.0. DO NOT EDIT.
+
+package mock
+
+import (
+	flow "github.com/onflow/flow-go/model/flow"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// ExecutionDatas is an autogenerated mock type for the ExecutionDatas type
+type ExecutionDatas struct {
+	mock.Mock
+}
+
+// ByBlockID provides a mock function with given fields: blockID
+func (_m *ExecutionDatas) ByBlockID(blockID flow.Identifier) (*flow.ExecutionData, error) {
+	ret := _m.Called(blockID)
+
+	var r0 *flow.ExecutionData
+	if rf, ok := ret.Get(0).(func(flow.Identifier) *flow.ExecutionData); ok {
+		r0 = rf(blockID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*flow.ExecutionData)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(flow.Identifier) error); ok {
+		r1 = rf(blockID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ByID provides a mock function with given fields: executionDataID
+func (_m *ExecutionDatas) ByID(executionDataID flow.Identifier) (*flow.ExecutionData, error) {
+	ret := _m.Called(executionDataID)
+
+	var r0 *flow.ExecutionData
+	if rf, ok := ret.Get(0).(func(flow.Identifier) *flow.ExecutionData); ok {
+		r0 = rf(executionDataID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*flow.ExecutionData)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(flow.Identifier) error); ok {
+		r1 = rf(executionDataID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ChunkByID provides a mock function with given fields: chunkExecutionDataID
+func (_m *ExecutionDatas) ChunkByID(chunkExecutionDataID flow.Identifier) (*flow.ChunkExecutionData, error) {
+	ret := _m.Called(chunkExecutionDataID)
+
+	var r0 *flow.ChunkExecutionData
+	if rf, ok := ret.Get(0).(func(flow.Identifier) *flow.ChunkExecutionData); ok {
+		r0 = rf(chunkExecutionDataID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*flow.ChunkExecutionData)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(flow.Identifier) error); ok {
+		r1 = rf(chunkExecutionDataID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Store provides a mock function with given fields: data, chunks
+func (_m *ExecutionDatas) Store(data *flow.ExecutionData, chunks []*flow.ChunkExecutionData) error {
+	ret := _m.Called(data, chunks)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*flow.ExecutionData, []*flow.ChunkExecutionData) error); ok {
+		r0 = rf(data, chunks)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
@@ -0,0 +1,74 @@
+// Code generated by mockery v1.0.0. DO NOT EDIT.
+
+package mock
+
+import (
+	flow "github.com/onflow/flow-go/model/flow"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// TransactionTraces is an autogenerated mock type for the TransactionTraces type
+type TransactionTraces struct {
+	mock.Mock
+}
+
+// ByBlockIDTransactionID provides a mock function with given fields: blockID, transactionID
+func (_m *TransactionTraces) ByBlockIDTransactionID(blockID flow.Identifier, transactionID flow.Identifier) (*flow.TransactionTrace, error) {
+	ret := _m.Called(blockID, transactionID)
+
+	var r0 *flow.TransactionTrace
+	if rf, ok := ret.Get(0).(func(flow.Identifier, flow.Identifier) *flow.TransactionTrace); ok {
+		r0 = rf(blockID, transactionID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*flow.TransactionTrace)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(flow.Identifier, flow.Identifier) error); ok {
+		r1 = rf(blockID, transactionID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ByTransactionID provides a mock function with given fields: transactionID
+func (_m *TransactionTraces) ByTransactionID(transactionID flow.Identifier) ([]flow.TransactionTrace, error) {
+	ret := _m.Called(transactionID)
+
+	var r0 []flow.TransactionTrace
+	if rf, ok := ret.Get(0).(func(flow.Identifier) []flow.TransactionTrace); ok {
+		r0 = rf(transactionID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]flow.TransactionTrace)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(flow.Identifier) error); ok {
+		r1 = rf(transactionID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Store provides a mock function with given fields: blockID, traces
+func (_m *TransactionTraces) Store(blockID flow.Identifier, traces []flow.TransactionTrace) error {
+	ret := _m.Called(blockID, traces)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(flow.Identifier, []flow.TransactionTrace) error); ok {
+		r0 = rf(blockID, traces)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
@@ -10,4 +10,7 @@ type TransactionResults interface {
 
 	// ByBlockIDTransactionID returns the transaction result for the given block ID and transaction ID
 	ByBlockIDTransactionID(blockID flow.Identifier, transactionID flow.Identifier) (*flow.TransactionResult, error)
+
+	// ByBlockID returns the transaction results of all transactions of the given block
+	ByBlockID(blockID flow.Identifier) ([]flow.TransactionResult, error)
 }
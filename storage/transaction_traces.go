@@ -0,0 +1,16 @@
+package storage
+
+import "github.com/onflow/flow-go/model/flow"
+
+// TransactionTraces represents persistent storage for the execution traces of transactions
+type TransactionTraces interface {
+
+	// Store stores the execution traces of the transactions of the given block
+	Store(blockID flow.Identifier, traces []flow.TransactionTrace) error
+
+	// ByBlockIDTransactionID returns the execution trace of the given transaction in the given block
+	ByBlockIDTransactionID(blockID flow.Identifier, transactionID flow.Identifier) (*flow.TransactionTrace, error)
+
+	// ByTransactionID returns the execution traces of the given transaction in every block it was traced in
+	ByTransactionID(transactionID flow.Identifier) ([]flow.TransactionTrace, error)
+}
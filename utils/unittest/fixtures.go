@@ -616,6 +616,28 @@ func ResultApprovalFixture(opts ...func(*flow.ResultApproval)) *flow.ResultAppro
 	return &approval
 }
 
+// ChallengeFixture creates a challenge of a non matching final state, raised against the chunk of the given index of
+// the execution result.
+func ChallengeFixture(result *flow.ExecutionResult, chunkIndex uint64, challengerID flow.Identifier) *flow.Challenge {
+	receipt := ExecutionReceiptFixture(WithResult(result))
+	return &flow.Challenge{
+		Body: flow.ChallengeBody{
+			FaultProof: flow.FaultProof{
+				BlockID:           result.BlockID,
+				ExecutionResultID: result.ID(),
+				ChunkIndex:        chunkIndex,
+				Kind:              flow.ChunkFaultNonMatchingFinalState,
+				Receipt:           *receipt.Meta(),
+				Collection:        CollectionFixture(1),
+				ChunkDataPack:     *ChunkDataPackFixture(IdentifierFixture()),
+				ComputedEndState:  StateCommitmentFixture(),
+			},
+			ChallengerID: challengerID,
+		},
+		VerifierSignature: SignatureFixture(),
+	}
+}
+
 func StateCommitmentFixture() flow.StateCommitment {
 	var state = make([]byte, 20)
 	_, _ = crand.Read(state[0:20])
@@ -829,6 +851,10 @@ func ChunkListFixture(n uint, blockID flow.Identifier) flow.ChunkList {
 	for i := uint64(0); i < uint64(n); i++ {
 		chunk := ChunkFixture(blockID, uint(i))
 		chunk.Index = i
+		// each chunk continues at the end state of its previous chunk
+		if i > 0 {
+			chunk.StartState = chunks[i-1].EndState
+		}
 		chunks = append(chunks, chunk)
 	}
 	return chunks